@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newArticlesCmd(s *settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "articles",
+		Short: "Refresh the market data embedded in articles from stored markets",
+		RunE:  runE(s, backfillArticles),
+	}
+}
+
+// loadArticles returns the stored articles matching filter, narrowed by
+// --since and --limit.
+func (a *app) loadArticles(ctx context.Context, filter bson.M) ([]models.Article, error) {
+	cursor, err := a.db.Collection("articles").Find(ctx, a.sinceFilter(filter, "created_at"), a.findOptions("created_at"))
+	if err != nil {
+		return nil, fmt.Errorf("query articles: %w", err)
+	}
+
+	var articles []models.Article
+	if err := cursor.All(ctx, &articles); err != nil {
+		return nil, fmt.Errorf("decode articles: %w", err)
+	}
+	return articles, nil
+}
+
+func backfillArticles(ctx context.Context, a *app) error {
+	articles, err := a.loadArticles(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	// Load every referenced market in one query
+	ids := make(map[string]bool)
+	for _, article := range articles {
+		for _, ref := range article.Markets {
+			ids[ref.MarketID] = true
+		}
+		if article.PrimaryMarket != nil {
+			ids[article.PrimaryMarket.MarketID] = true
+		}
+	}
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+
+	var markets []models.Market
+	if len(idList) > 0 {
+		cursor, err := a.db.Collection("markets").Find(ctx, bson.M{"market_id": bson.M{"$in": idList}})
+		if err != nil {
+			return fmt.Errorf("query markets: %w", err)
+		}
+		if err := cursor.All(ctx, &markets); err != nil {
+			return fmt.Errorf("decode markets: %w", err)
+		}
+	}
+	byID := make(map[string]*models.Market, len(markets))
+	for i := range markets {
+		byID[markets[i].MarketID] = &markets[i]
+	}
+	log.Info().Int("referenced", len(ids)).Int("found", len(byID)).Msg("Loaded market data")
+
+	// refresh rebuilds a ref from the current market, keeping the
+	// probability recorded when the article was written
+	refresh := func(ref models.MarketRef) (models.MarketRef, bool) {
+		m, ok := byID[ref.MarketID]
+		if !ok {
+			return ref, false
+		}
+		return models.MarketRef{
+			MarketID:     m.MarketID,
+			Question:     m.Question,
+			Slug:         m.Slug,
+			Probability:  m.Probability,
+			PreviousProb: ref.PreviousProb,
+			Change24h:    m.Change24h,
+			Volume24h:    m.Volume24h,
+			TotalVolume:  m.TotalVolume,
+			EndDate:      m.EndDate,
+		}, true
+	}
+
+	p := newProgress(len(articles), a.settings.dryRun)
+	for _, article := range articles {
+		changed := false
+
+		refs := make([]models.MarketRef, 0, len(article.Markets))
+		for _, ref := range article.Markets {
+			updated, ok := refresh(ref)
+			changed = changed || ok
+			refs = append(refs, updated)
+		}
+
+		primary := article.PrimaryMarket
+		if primary != nil {
+			updated, ok := refresh(*primary)
+			changed = changed || ok
+			primary = &updated
+		}
+
+		if !changed {
+			p.NotFound()
+			continue
+		}
+
+		log.Debug().
+			Str("article_id", article.ID.Hex()).
+			Str("headline", article.Headline).
+			Int("markets", len(refs)).
+			Msg("Updating article markets")
+
+		err := a.update(ctx, "articles",
+			bson.M{"_id": article.ID},
+			bson.M{"$set": bson.M{
+				"markets":        refs,
+				"primary_market": primary,
+				"updated_at":     time.Now(),
+			}},
+		)
+		if err != nil {
+			p.Failed(err, article.ID.Hex())
+			continue
+		}
+		p.Updated()
+	}
+	p.Done()
+	return nil
+}
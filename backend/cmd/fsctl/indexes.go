@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newIndexesCmd(s *settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "indexes",
+		Short: "Create missing MongoDB indexes and drop the ones they replace",
+		RunE:  runE(s, migrateIndexes),
+	}
+}
+
+func migrateIndexes(ctx context.Context, a *app) error {
+	audits, err := storage.MigrateIndexes(ctx, a.db, a.settings.dryRun)
+	for _, audit := range audits {
+		log.Info().
+			Str("collection", audit.Collection).
+			Strs("create", audit.Missing).
+			Strs("drop", audit.Obsolete).
+			Bool("dry_run", a.settings.dryRun).
+			Msg("Indexes")
+	}
+	if err != nil {
+		return err
+	}
+	log.Info().Int("collections", len(audits)).Bool("dry_run", a.settings.dryRun).Msg("Index migration finished")
+	return nil
+}
@@ -0,0 +1,201 @@
+// Package main provides fsctl, the FutureSignals maintenance CLI for
+// backfills from Polymarket, schema migrations, moving content between
+// environments and static site exports.
+//
+// Usage:
+//
+//	fsctl backfill urls|probability|enrichment|articles [flags]
+//	fsctl migrate up|down|status [flags]
+//	fsctl export|import articles|markets|snapshots [flags]
+//	fsctl export-site [flags]
+//	fsctl indexes [flags]
+//
+// Every command accepts --dry-run, --limit and --since.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func main() {
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// settings holds the flags shared by every command.
+type settings struct {
+	mongoURI string
+	database string
+	dryRun   bool
+	limit    int
+	since    time.Duration
+	timeout  time.Duration
+	debug    bool
+
+	// Polymarket lookups for markets outside the top events
+	concurrency int
+	rate        int
+}
+
+// app is the wiring shared by commands.
+type app struct {
+	settings   *settings
+	db         *mongo.Database
+	polymarket *polymarket.Client
+	limiter    *ratelimit.Limiter
+}
+
+func newRootCmd() *cobra.Command {
+	s := &settings{}
+
+	root := &cobra.Command{
+		Use:           "fsctl",
+		Short:         "FutureSignals maintenance tool",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			zerolog.SetGlobalLevel(zerolog.InfoLevel)
+			if s.debug {
+				zerolog.SetGlobalLevel(zerolog.DebugLevel)
+			}
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&s.mongoURI, "mongo-uri", envOr("MONGODB_URI", os.Getenv("MONGO_URI")), "MongoDB connection URI (env MONGODB_URI)")
+	flags.StringVar(&s.database, "db", envOr("MONGODB_DATABASE", envOr("MONGO_DB", "futuresignals")), "MongoDB database (env MONGODB_DATABASE)")
+	flags.BoolVar(&s.dryRun, "dry-run", false, "report what would change without writing")
+	flags.IntVar(&s.limit, "limit", 0, "maximum number of records to process (0 for no limit)")
+	flags.DurationVar(&s.since, "since", 0, "only process records created within this window, e.g. 72h (0 for all)")
+	flags.DurationVar(&s.timeout, "timeout", 30*time.Minute, "overall time limit")
+	flags.BoolVar(&s.debug, "debug", false, "log each record")
+
+	backfill := &cobra.Command{
+		Use:   "backfill",
+		Short: "Backfill market and article data",
+	}
+	backfill.PersistentFlags().IntVar(&s.concurrency, "concurrency", 4, "parallel Polymarket lookups for markets outside the top events")
+	backfill.PersistentFlags().IntVar(&s.rate, "rate", 10, "maximum Polymarket requests per second")
+	backfill.AddCommand(
+		newURLsCmd(s),
+		newProbabilityCmd(s),
+		newEnrichmentCmd(s),
+		newArticlesCmd(s),
+	)
+
+	root.AddCommand(backfill, newMigrateCmd(s), newIndexesCmd(s), newExportCmd(s), newImportCmd(s), newExportSiteCmd(s))
+	return root
+}
+
+// runE wraps a command body with the shared wiring: flag checks, the
+// timeout, and the MongoDB connection.
+func runE(s *settings, fn func(ctx context.Context, a *app) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		if s.mongoURI == "" {
+			err := errors.New("--mongo-uri or MONGODB_URI is required")
+			log.Error().Err(err).Send()
+			return err
+		}
+		if s.limit < 0 || s.since < 0 {
+			err := errors.New("--limit and --since must not be negative")
+			log.Error().Err(err).Send()
+			return err
+		}
+		if s.concurrency < 1 {
+			s.concurrency = 1
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), s.timeout)
+		defer cancel()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(s.mongoURI))
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to connect to MongoDB")
+			return err
+		}
+		defer client.Disconnect(context.Background())
+
+		log.Info().
+			Str("command", cmd.CommandPath()).
+			Str("db", s.database).
+			Bool("dry_run", s.dryRun).
+			Int("limit", s.limit).
+			Dur("since", s.since).
+			Msg("Starting")
+
+		if err := fn(ctx, &app{
+			settings:   s,
+			db:         client.Database(s.database),
+			polymarket: polymarket.NewClient(),
+			limiter:    ratelimit.New(0),
+		}); err != nil {
+			log.Error().Err(err).Str("command", cmd.CommandPath()).Msg("Command failed")
+			return err
+		}
+		return nil
+	}
+}
+
+// sinceFilter adds --since to filter on the given timestamp field.
+func (a *app) sinceFilter(filter bson.M, field string) bson.M {
+	if a.settings.since > 0 {
+		filter[field] = bson.M{"$gte": time.Now().Add(-a.settings.since)}
+	}
+	return filter
+}
+
+// findOptions applies --limit, oldest records first so repeated limited
+// runs are predictable.
+func (a *app) findOptions(sortField string) *options.FindOptions {
+	opts := options.Find().SetSort(bson.D{{Key: sortField, Value: 1}})
+	if a.settings.limit > 0 {
+		opts.SetLimit(int64(a.settings.limit))
+	}
+	return opts
+}
+
+// wait blocks until the --rate budget allows another Polymarket request.
+func (a *app) wait(ctx context.Context) error {
+	for {
+		ok, delay := a.limiter.Reserve("polymarket", a.settings.rate, time.Second)
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// update applies an update unless this is a dry run.
+func (a *app) update(ctx context.Context, collection string, filter, update bson.M) error {
+	if a.settings.dryRun {
+		return nil
+	}
+	_, err := a.db.Collection(collection).UpdateOne(ctx, filter, update)
+	return err
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
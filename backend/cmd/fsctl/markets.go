@@ -0,0 +1,388 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// topEventsLimit is how many events, by 24h volume, the event-based
+	// backfills read from Polymarket in one request.
+	topEventsLimit = 100
+
+	// marketBatchSize is how many market IDs go in one /markets?id= lookup.
+	marketBatchSize = 20
+)
+
+func newURLsCmd(s *settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "urls",
+		Short: "Point each market's Polymarket URL at its event page",
+		RunE:  runE(s, backfillURLs),
+	}
+}
+
+func newProbabilityCmd(s *settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "probability",
+		Short: "Refresh market probability, volume and liquidity from the top events",
+		RunE:  runE(s, backfillProbability),
+	}
+}
+
+func newEnrichmentCmd(s *settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "enrichment",
+		Short: "Fill in market pricing, media, event and tag data from the top events",
+		RunE:  runE(s, backfillEnrichment),
+	}
+}
+
+// loadMarkets returns the stored markets selected by --since and --limit.
+// Documents that don't decode are logged and skipped.
+func (a *app) loadMarkets(ctx context.Context) ([]models.Market, error) {
+	filter := a.sinceFilter(bson.M{"market_id": bson.M{"$ne": ""}}, "first_seen_at")
+	cursor, err := a.db.Collection("markets").Find(ctx, filter, a.findOptions("first_seen_at"))
+	if err != nil {
+		return nil, fmt.Errorf("query markets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var markets []models.Market
+	for cursor.Next(ctx) {
+		var m models.Market
+		if err := cursor.Decode(&m); err != nil {
+			log.Warn().Err(err).Msg("Skipping undecodable market")
+			continue
+		}
+		markets = append(markets, m)
+	}
+	return markets, cursor.Err()
+}
+
+// eventMarket is a Polymarket market with the event it belongs to. event
+// is nil when Polymarket returned the market without one.
+type eventMarket struct {
+	market polymarket.Market
+	event  *polymarket.Event
+}
+
+// topEventMarkets indexes the markets of the top open events by market ID.
+func (a *app) topEventMarkets(ctx context.Context) (map[string]eventMarket, error) {
+	if err := a.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	active, closed := true, false
+	events, err := a.polymarket.GetEvents(ctx, polymarket.EventFilters{
+		Active:    &active,
+		Closed:    &closed,
+		Limit:     topEventsLimit,
+		Order:     "volume24hr",
+		Ascending: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]eventMarket)
+	for i := range events {
+		for _, m := range events[i].Markets {
+			byID[m.ID] = eventMarket{market: m, event: &events[i]}
+		}
+	}
+
+	log.Info().
+		Int("events", len(events)).
+		Int("markets", len(byID)).
+		Msg("Fetched top events from Polymarket")
+
+	return byID, nil
+}
+
+// upstreamMarkets indexes Polymarket data for the stored markets by market
+// ID. The top events cover most active markets in one request; the rest
+// are looked up by ID.
+func (a *app) upstreamMarkets(ctx context.Context, markets []models.Market) (map[string]eventMarket, error) {
+	byID, err := a.topEventMarkets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, m := range markets {
+		if _, ok := byID[m.MarketID]; !ok {
+			missing = append(missing, m.MarketID)
+		}
+	}
+
+	fetched, err := a.fetchMarkets(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, em := range fetched {
+		byID[id] = em
+	}
+	return byID, nil
+}
+
+// fetchMarkets looks markets up by ID in batches of marketBatchSize, spread
+// over --concurrency workers and held to --rate requests per second. A
+// failed batch is logged and its markets left out of the result.
+func (a *app) fetchMarkets(ctx context.Context, ids []string) (map[string]eventMarket, error) {
+	byID := make(map[string]eventMarket, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		failed  int
+		batches = make(chan []string)
+	)
+
+	for i := 0; i < a.settings.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := a.wait(ctx); err != nil {
+					continue
+				}
+
+				found, err := a.polymarket.GetMarketsByID(ctx, batch)
+				if err != nil {
+					log.Warn().Err(err).Strs("market_ids", batch).Msg("Failed to fetch markets")
+					mu.Lock()
+					failed += len(batch)
+					mu.Unlock()
+					continue
+				}
+
+				mu.Lock()
+				for j := range found {
+					em := eventMarket{market: found[j]}
+					if len(found[j].Events) > 0 {
+						em.event = &found[j].Events[0]
+					}
+					byID[found[j].ID] = em
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for start := 0; start < len(ids); start += marketBatchSize {
+		end := min(start+marketBatchSize, len(ids))
+		select {
+		case batches <- ids[start:end]:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Int("requested", len(ids)).
+		Int("found", len(byID)).
+		Int("failed", failed).
+		Msg("Fetched remaining markets by ID")
+
+	return byID, nil
+}
+
+func backfillURLs(ctx context.Context, a *app) error {
+	markets, err := a.loadMarkets(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(markets))
+	for i, m := range markets {
+		ids[i] = m.MarketID
+	}
+	upstream, err := a.fetchMarkets(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	p := newProgress(len(markets), a.settings.dryRun)
+	for _, m := range markets {
+		em, ok := upstream[m.MarketID]
+		if !ok || em.event == nil {
+			p.NotFound()
+			continue
+		}
+
+		url := "https://polymarket.com/event/" + em.event.Slug
+		if m.PolymarketURL == url {
+			p.Skipped()
+			continue
+		}
+
+		log.Debug().Str("market_id", m.MarketID).Str("old", m.PolymarketURL).Str("new", url).Msg("Updating URL")
+		err = a.update(ctx, "markets",
+			bson.M{"market_id": m.MarketID},
+			bson.M{"$set": bson.M{"polymarket_url": url}},
+		)
+		if err != nil {
+			p.Failed(err, m.MarketID)
+			continue
+		}
+		p.Updated()
+	}
+	p.Done()
+	return nil
+}
+
+func backfillProbability(ctx context.Context, a *app) error {
+	markets, err := a.loadMarkets(ctx)
+	if err != nil {
+		return err
+	}
+	upstream, err := a.upstreamMarkets(ctx, markets)
+	if err != nil {
+		return err
+	}
+
+	p := newProgress(len(markets), a.settings.dryRun)
+	for _, m := range markets {
+		em, ok := upstream[m.MarketID]
+		if !ok {
+			p.NotFound()
+			continue
+		}
+
+		log.Debug().
+			Str("market_id", m.MarketID).
+			Float64("old_prob", m.Probability).
+			Float64("new_prob", em.market.YesPrice).
+			Msg("Updating probability")
+
+		set := bson.M{
+			"probability":  em.market.YesPrice,
+			"total_volume": em.market.VolumeNum,
+			"liquidity":    em.market.LiquidityNum,
+			"updated_at":   time.Now(),
+		}
+		if em.event != nil {
+			set["event_volume"] = em.event.Volume
+			set["event_volume_24h"] = em.event.Volume24hr
+		}
+
+		err := a.update(ctx, "markets", bson.M{"market_id": m.MarketID}, bson.M{"$set": set})
+		if err != nil {
+			p.Failed(err, m.MarketID)
+			continue
+		}
+		p.Updated()
+	}
+	p.Done()
+	return nil
+}
+
+func backfillEnrichment(ctx context.Context, a *app) error {
+	markets, err := a.loadMarkets(ctx)
+	if err != nil {
+		return err
+	}
+	upstream, err := a.upstreamMarkets(ctx, markets)
+	if err != nil {
+		return err
+	}
+
+	p := newProgress(len(markets), a.settings.dryRun)
+	for _, m := range markets {
+		// Event data is most of the enrichment; skip markets without it
+		em, ok := upstream[m.MarketID]
+		if !ok || em.event == nil {
+			p.NotFound()
+			continue
+		}
+		pm, event := em.market, em.event
+
+		var tags []models.PolymarketTag
+		for _, t := range event.Tags {
+			tags = append(tags, models.PolymarketTag{Label: t.Label, Slug: t.Slug})
+		}
+
+		// Use market media if available, otherwise the event's
+		image := pm.Image
+		if image == "" {
+			image = event.Image
+		}
+		icon := pm.Icon
+		if icon == "" {
+			icon = event.Icon
+		}
+
+		log.Debug().Str("market_id", m.MarketID).Str("event", event.Title).Msg("Enriching market")
+
+		set := bson.M{
+			// Pricing
+			"probability":      pm.YesPrice,
+			"last_trade_price": pm.LastTradePrice,
+			"change_24h":       pm.OneDayPriceChange,
+			"change_7d":        pm.OneWeekPriceChange,
+
+			// Volume
+			"volume_24h":       pm.Volume24hr,
+			"volume_7d":        pm.Volume1wk,
+			"total_volume":     pm.VolumeNum,
+			"event_volume":     event.Volume,
+			"event_volume_24h": event.Volume24hr,
+
+			// Event data
+			"event_title":   event.Title,
+			"comment_count": event.CommentCount,
+			"series_slug":   event.SeriesSlug,
+
+			// Media
+			"image": image,
+			"icon":  icon,
+
+			// Resolution
+			"resolution_source": pm.ResolutionSource,
+			"competitor_count":  event.CompetitorCount,
+
+			// Status
+			"start_date": pm.StartDate,
+			"liquidity":  pm.LiquidityNum,
+
+			// Meta
+			"updated_at": time.Now(),
+		}
+		if startDate := models.ParseMarketDate(pm.StartDate); startDate != nil {
+			set["start_date_ts"] = startDate
+		}
+		// Events embedded in a by-ID lookup may come without tags; keep
+		// the stored ones rather than clearing them
+		if len(tags) > 0 {
+			set["polymarket_tags"] = tags
+		}
+
+		err := a.update(ctx, "markets", bson.M{"market_id": m.MarketID}, bson.M{"$set": set})
+		if err != nil {
+			p.Failed(err, m.MarketID)
+			continue
+		}
+		p.Updated()
+	}
+	p.Done()
+	return nil
+}
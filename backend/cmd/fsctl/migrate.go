@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/migrations"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd(s *settings) *cobra.Command {
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or roll back schema migrations",
+	}
+	migrate.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply pending migrations (--dry-run lists them)",
+			RunE:  runE(s, migrateUp),
+		},
+		&cobra.Command{
+			Use:   "down",
+			Short: "Roll back the most recently applied migration",
+			RunE:  runE(s, migrateDown),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "List migrations and when each was applied",
+			RunE:  runE(s, migrateStatus),
+		},
+	)
+	return migrate
+}
+
+func migrateUp(ctx context.Context, a *app) error {
+	m := migrations.New(a.db)
+	if a.settings.dryRun {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		for _, mig := range pending {
+			log.Info().Int("version", mig.Version).Str("name", mig.Name).Msg("Pending")
+		}
+		log.Info().Int("pending", len(pending)).Msg("Done")
+		return nil
+	}
+
+	applied, err := m.Up(ctx)
+	log.Info().Int("applied", len(applied)).Msg("Done")
+	return err
+}
+
+func migrateDown(ctx context.Context, a *app) error {
+	m := migrations.New(a.db)
+	if a.settings.dryRun {
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for i := len(statuses) - 1; i >= 0; i-- {
+			if statuses[i].AppliedAt != nil {
+				log.Info().Int("version", statuses[i].Version).Str("name", statuses[i].Name).Msg("Would roll back")
+				return nil
+			}
+		}
+		log.Info().Msg("No migration applied")
+		return nil
+	}
+
+	mig, err := m.Down(ctx)
+	if err != nil {
+		return err
+	}
+	if mig == nil {
+		log.Info().Msg("No migration applied")
+		return nil
+	}
+	log.Info().Int("version", mig.Version).Str("name", mig.Name).Msg("Rolled back")
+	return nil
+}
+
+func migrateStatus(ctx context.Context, a *app) error {
+	statuses, err := migrations.New(a.db).Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, st := range statuses {
+		event := log.Info().Int("version", st.Version).Str("name", st.Name)
+		if st.AppliedAt != nil {
+			event = event.Time("applied_at", *st.AppliedAt)
+		}
+		event.Bool("applied", st.AppliedAt != nil).Msg("Migration")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// progressEvery is how many records pass between progress lines.
+const progressEvery = 50
+
+// progress counts what happened to each record in a run and reports it as
+// structured log lines.
+type progress struct {
+	total  int
+	dryRun bool
+	start  time.Time
+
+	processed int
+	updated   int
+	skipped   int
+	notFound  int
+	failed    int
+}
+
+func newProgress(total int, dryRun bool) *progress {
+	log.Info().Int("total", total).Msg("Records to process")
+	return &progress{total: total, dryRun: dryRun, start: time.Now()}
+}
+
+// Updated records a change that was written (or would be, in a dry run).
+func (p *progress) Updated() { p.updated++; p.tick() }
+
+// Skipped records a record that needed no change.
+func (p *progress) Skipped() { p.skipped++; p.tick() }
+
+// NotFound records a record with no matching upstream data.
+func (p *progress) NotFound() { p.notFound++; p.tick() }
+
+// Failed records a record that couldn't be processed.
+func (p *progress) Failed(err error, id string) {
+	log.Error().Err(err).Str("id", id).Msg("Failed to process record")
+	p.failed++
+	p.tick()
+}
+
+func (p *progress) tick() {
+	p.processed++
+	if p.processed%progressEvery == 0 {
+		log.Info().
+			Int("processed", p.processed).
+			Int("total", p.total).
+			Int("updated", p.updated).
+			Int("skipped", p.skipped).
+			Int("not_found", p.notFound).
+			Int("failed", p.failed).
+			Msg("Progress")
+	}
+}
+
+// Done logs the run summary.
+func (p *progress) Done() {
+	log.Info().
+		Bool("dry_run", p.dryRun).
+		Int("processed", p.processed).
+		Int("updated", p.updated).
+		Int("skipped", p.skipped).
+		Int("not_found", p.notFound).
+		Int("failed", p.failed).
+		Dur("took", time.Since(p.start)).
+		Msg("Done")
+}
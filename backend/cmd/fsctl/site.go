@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/blob"
+	"github.com/leeaandrob/futuresignals/internal/staticsite"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newExportSiteCmd(s *settings) *cobra.Command {
+	var (
+		dir     string
+		siteURL string
+		formats []string
+	)
+	cmd := &cobra.Command{
+		Use:   "export-site",
+		Short: "Render published articles to Markdown and HTML files with front matter",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, f := range formats {
+				if !staticsite.ValidFormat(f) {
+					return fmt.Errorf("unknown format %q (expected markdown or html)", f)
+				}
+			}
+			return runE(s, func(ctx context.Context, a *app) error {
+				cfg := staticsite.DefaultConfig()
+				cfg.SiteURL = siteURL
+				cfg.Formats = formats
+				return exportSite(ctx, a, staticsite.NewExporter(storage.NewSiteArticles(a.db), blob.NewFileStorage(dir, ""), cfg))
+			})(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "./site", "directory to write articles/<slug>.md and .html under")
+	cmd.Flags().StringVar(&siteURL, "site-url", envOr("SITE_URL", staticsite.DefaultConfig().SiteURL), "public site URL for links (env SITE_URL)")
+	cmd.Flags().StringSliceVar(&formats, "formats", staticsite.DefaultConfig().Formats, "formats to write: markdown, html or both")
+	return cmd
+}
+
+func exportSite(ctx context.Context, a *app, exporter *staticsite.Exporter) error {
+	var since time.Time
+	if a.settings.since > 0 {
+		since = time.Now().Add(-a.settings.since)
+	}
+	if a.settings.dryRun {
+		count, err := storage.NewSiteArticles(a.db).CountPublishedArticles(ctx, since)
+		if err != nil {
+			return fmt.Errorf("count articles: %w", err)
+		}
+		log.Info().Int64("articles", count).Bool("dry_run", true).Msg("Done")
+		return nil
+	}
+
+	result, err := exporter.Export(ctx, since)
+	log.Info().Int("articles", result.Articles).Int("files", result.Files).Int("removed", result.Removed).Int("failed", result.Failed).Msg("Done")
+	return err
+}
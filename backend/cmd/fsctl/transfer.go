@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// importBatchSize is how many documents go in one bulk write on import.
+const importBatchSize = 500
+
+// transferable describes a collection that can be exported and imported.
+type transferable struct {
+	collection string
+	// Timestamp --since, --from and --to filter on
+	dateField string
+	// Field documents are upserted by, so an import can be rerun and
+	// matches records the target created itself. Time-series collections
+	// have none and take inserts only; documents already there are skipped.
+	key string
+}
+
+var transferables = map[string]transferable{
+	"articles":  {collection: "articles", dateField: "created_at", key: "slug"},
+	"markets":   {collection: "markets", dateField: "first_seen_at", key: "market_id"},
+	"snapshots": {collection: "snapshots", dateField: "captured_at"},
+}
+
+// transferFlags holds the export and import flags.
+type transferFlags struct {
+	file string
+	gzip bool
+	from string
+	to   string
+}
+
+func newExportCmd(s *settings) *cobra.Command {
+	f := &transferFlags{}
+	cmd := &cobra.Command{
+		Use:       "export articles|markets|snapshots",
+		Short:     "Write a collection to JSONL, one Extended JSON document per line",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"articles", "markets", "snapshots"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(s, func(ctx context.Context, a *app) error {
+				return exportCollection(ctx, a, transferables[args[0]], f)
+			})(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&f.file, "out", "-", "file to write, - for stdout; a .gz name is gzipped")
+	cmd.Flags().BoolVar(&f.gzip, "gzip", false, "gzip the output")
+	cmd.Flags().StringVar(&f.from, "from", "", "only export records from this date on (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&f.to, "to", "", "only export records before this date (YYYY-MM-DD or RFC 3339)")
+	return cmd
+}
+
+func newImportCmd(s *settings) *cobra.Command {
+	f := &transferFlags{}
+	cmd := &cobra.Command{
+		Use:       "import articles|markets|snapshots",
+		Short:     "Load a JSONL export into a collection",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"articles", "markets", "snapshots"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(s, func(ctx context.Context, a *app) error {
+				return importCollection(ctx, a, transferables[args[0]], f)
+			})(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&f.file, "in", "-", "file to read, - for stdin; gzipped input is detected")
+	return cmd
+}
+
+// dateFilter builds the filter for --since, --from and --to on field.
+func (a *app) dateFilter(field string, f *transferFlags) (bson.M, error) {
+	filter := a.sinceFilter(bson.M{}, field)
+	bounds := bson.M{}
+	if cond, ok := filter[field].(bson.M); ok {
+		bounds = cond
+	}
+	for _, b := range []struct {
+		op, value string
+	}{{"$gte", f.from}, {"$lt", f.to}} {
+		if b.value == "" {
+			continue
+		}
+		t, err := parseDate(b.value)
+		if err != nil {
+			return nil, err
+		}
+		// --since and --from both set a lower bound; keep the later one
+		if prev, ok := bounds[b.op].(time.Time); ok && b.op == "$gte" && prev.After(t) {
+			continue
+		}
+		bounds[b.op] = t
+	}
+	if len(bounds) > 0 {
+		filter[field] = bounds
+	}
+	return filter, nil
+}
+
+// parseDate reads a YYYY-MM-DD date (midnight UTC) or an RFC 3339 time.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: want YYYY-MM-DD or RFC 3339", value)
+	}
+	return t, nil
+}
+
+func exportCollection(ctx context.Context, a *app, t transferable, f *transferFlags) error {
+	filter, err := a.dateFilter(t.dateField, f)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if f.file != "-" {
+		file, err := os.Create(f.file)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	buf := bufio.NewWriter(out)
+	out = buf
+	var gz *gzip.Writer
+	if f.gzip || strings.HasSuffix(f.file, ".gz") {
+		gz = gzip.NewWriter(buf)
+		out = gz
+	}
+
+	cursor, err := a.db.Collection(t.collection).Find(ctx, filter, a.findOptions(t.dateField))
+	if err != nil {
+		return fmt.Errorf("query %s: %w", t.collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	exported := 0
+	for cursor.Next(ctx) {
+		// Canonical Extended JSON keeps dates, ObjectIDs and number types
+		// intact for the import
+		line, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return fmt.Errorf("encode document: %w", err)
+		}
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		exported++
+		if exported%progressEvery == 0 {
+			log.Debug().Int("exported", exported).Msg("Progress")
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	log.Info().Str("collection", t.collection).Int("exported", exported).Str("file", f.file).Msg("Done")
+	return nil
+}
+
+func importCollection(ctx context.Context, a *app, t transferable, f *transferFlags) error {
+	var in io.Reader = os.Stdin
+	if f.file != "-" {
+		file, err := os.Open(f.file)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	in, err := maybeGunzip(in)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	// Articles with market data blocks run well past the default 64KB
+	scanner.Buffer(make([]byte, 0, 1024*1024), 16*1024*1024)
+
+	// The input's length isn't known up front, so there's no total
+	p := &progress{dryRun: a.settings.dryRun, start: time.Now()}
+	var (
+		batch []mongo.WriteModel
+		lines []int // Input line of each batch entry
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch, lines = batch[:0], lines[:0] }()
+		if a.settings.dryRun {
+			for range batch {
+				p.Updated()
+			}
+			return nil
+		}
+
+		_, err := a.db.Collection(t.collection).BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false))
+		failed := map[int]bool{}
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			// Rejected documents are reported, bar inserts of documents
+			// already there; the rest of the batch is written
+			for _, we := range bulkErr.WriteErrors {
+				failed[we.Index] = true
+				if t.key == "" && mongo.IsDuplicateKeyError(we) {
+					p.Skipped()
+					continue
+				}
+				p.Failed(we, fmt.Sprintf("line %d", lines[we.Index]))
+			}
+			if bulkErr.WriteConcernError == nil {
+				err = nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("write %s: %w", t.collection, err)
+		}
+		for i := range batch {
+			if !failed[i] {
+				p.Updated()
+			}
+		}
+		return nil
+	}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		if a.settings.limit > 0 && p.processed+len(batch) >= a.settings.limit {
+			break
+		}
+
+		var doc bson.D
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), true, &doc); err != nil {
+			p.Failed(err, fmt.Sprintf("line %d", line))
+			continue
+		}
+
+		if t.key == "" {
+			batch = append(batch, mongo.NewInsertOneModel().SetDocument(doc))
+			lines = append(lines, line)
+		} else {
+			key, ok := docField(doc, t.key)
+			if !ok {
+				p.Failed(fmt.Errorf("document has no %s", t.key), fmt.Sprintf("line %d", line))
+				continue
+			}
+			// The target's _id is kept, as a replacement can't change it
+			batch = append(batch, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{t.key: key}).
+				SetReplacement(withoutField(doc, "_id")).
+				SetUpsert(true))
+			lines = append(lines, line)
+		}
+
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read line %d: %w", line+1, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	p.Done()
+	return nil
+}
+
+// maybeGunzip returns a reader of r's content, gunzipped if r is gzip.
+func maybeGunzip(r io.Reader) (io.Reader, error) {
+	buf := bufio.NewReader(r)
+	magic, err := buf.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(buf)
+	}
+	return buf, nil
+}
+
+// docField returns the value of the document's top-level field key.
+func docField(doc bson.D, key string) (interface{}, bool) {
+	for _, e := range doc {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+// withoutField returns doc without its top-level field key.
+func withoutField(doc bson.D, key string) bson.D {
+	out := make(bson.D, 0, len(doc))
+	for _, e := range doc {
+		if e.Key != key {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -4,19 +4,45 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/abtest"
+	"github.com/leeaandrob/futuresignals/internal/alerts"
+	"github.com/leeaandrob/futuresignals/internal/analysis"
+	"github.com/leeaandrob/futuresignals/internal/analytics"
 	"github.com/leeaandrob/futuresignals/internal/api"
+	"github.com/leeaandrob/futuresignals/internal/assets"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/blob"
+	"github.com/leeaandrob/futuresignals/internal/cache"
 	"github.com/leeaandrob/futuresignals/internal/config"
 	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/embeddings"
 	"github.com/leeaandrob/futuresignals/internal/enrichment"
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/migrations"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/newsletter"
+	"github.com/leeaandrob/futuresignals/internal/notify"
+	"github.com/leeaandrob/futuresignals/internal/og"
 	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/publisher"
 	"github.com/leeaandrob/futuresignals/internal/qwen"
 	"github.com/leeaandrob/futuresignals/internal/scheduler"
+	"github.com/leeaandrob/futuresignals/internal/settings"
+	"github.com/leeaandrob/futuresignals/internal/staticsite"
 	"github.com/leeaandrob/futuresignals/internal/storage"
 	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/leeaandrob/futuresignals/internal/webhooks"
+	"github.com/leeaandrob/futuresignals/internal/xtracker"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -41,11 +67,28 @@ func main() {
 	}
 
 	if err := cfg.Validate(); err != nil {
+		var invalid *config.ValidationError
+		if errors.As(err, &invalid) {
+			for _, problem := range invalid.Problems {
+				log.Error().Msg(problem)
+			}
+			log.Fatal().Int("problems", len(invalid.Problems)).Msg("Invalid configuration")
+		}
 		log.Fatal().Err(err).Msg("Invalid configuration")
 	}
 
 	ctx := context.Background()
 
+	// Initialize tracing (no-op without an OTLP endpoint)
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		Endpoint:    cfg.OTLPEndpoint,
+		ServiceName: cfg.OTelServiceName,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize tracing")
+	}
+
 	// Initialize storage
 	store, err := storage.NewStore(ctx, cfg.MongoURI, cfg.MongoDB)
 	if err != nil {
@@ -53,58 +96,511 @@ func main() {
 	}
 	defer store.Close(ctx)
 
+	// Apply pending schema migrations; with several replicas starting, one
+	// migrates and the others go on
+	if cfg.MigrateOnStartup {
+		applied, err := migrations.New(store.Database()).Up(ctx)
+		switch {
+		case errors.Is(err, migrations.ErrLocked):
+			log.Info().Msg("Another instance is applying migrations")
+		case err != nil:
+			log.Error().Err(err).Msg("Failed to apply migrations")
+		case len(applied) > 0:
+			log.Info().Int("applied", len(applied)).Msg("Applied migrations")
+		}
+	}
+
+	// Detect market categories with the taxonomy stored in MongoDB
+	models.SetCategoryProvider(store.CategoryCache())
+
 	// Initialize Polymarket client
 	pmClient := polymarket.NewClient()
 	log.Info().Msg("Polymarket client initialized")
 
-	// Initialize Qwen LLM client
-	var llmClient *qwen.Client
-	if cfg.DashScopeAPIKey != "" {
-		llmClient = qwen.NewClient(qwen.Config{
-			APIKey:   cfg.DashScopeAPIKey,
-			Endpoint: cfg.DashScopeEndpoint,
-			Model:    cfg.QwenModel,
-		})
-		log.Info().Str("model", cfg.QwenModel).Msg("Qwen LLM client initialized")
-	} else {
-		log.Warn().Msg("Qwen client not initialized (no API key)")
+	// Load prompt templates, with any overrides from PROMPTS_DIR
+	promptLib, err := prompts.Load(cfg.PromptsDir)
+	if err != nil {
+		log.Fatal().Err(err).Str("dir", cfg.PromptsDir).Msg("Failed to load prompt templates")
+	}
+	prompts.SetDefault(promptLib)
+
+	// Initialize LLM provider
+	llmProvider := newLLMProvider(cfg)
+	if llmProvider != nil {
+		// Record token usage and estimated cost per generation
+		llmProvider = llm.NewUsageTracker(llmProvider, cfg.LLMProvider, store)
+
+		// Retry transient failures, then fall back to cheaper models; the
+		// tracker sits underneath so every attempt is recorded
+		retryCfg := llm.DefaultRetryConfig()
+		retryCfg.MaxAttempts = cfg.LLMMaxAttempts
+		retryCfg.Timeout = cfg.LLMTimeout
+		retryCfg.Backoff = cfg.LLMRetryBackoff
+		retryCfg.Fallbacks = cfg.LLMFallbackModels
+		if slices.Equal(retryCfg.Fallbacks, []string{"none"}) {
+			retryCfg.Fallbacks = nil
+		}
+		llmProvider = llm.NewRetryingProvider(llmProvider, cfg.LLMProvider, retryCfg)
 	}
 
 	// Initialize enrichment pipeline
+	// Embedding relevance for news and social posts; nil keeps keywords
+	embeddingScorer := newEmbeddingScorer(cfg, store)
+
 	var enricher *enrichment.Enricher
 	if cfg.EnableEnrichment {
 		enricher = enrichment.NewEnricher(enrichment.EnrichmentConfig{
-			TavilyAPIKey:    cfg.TavilyAPIKey,
-			ExaAPIKey:       cfg.ExaAPIKey,
-			FirecrawlAPIKey: cfg.FirecrawlAPIKey,
-			MaxNewsResults:  5,
-			MaxDeepScrapes:  2,
-			EnableTavily:    cfg.TavilyAPIKey != "",
-			EnableExa:       cfg.ExaAPIKey != "",
-			EnableFirecrawl: cfg.FirecrawlAPIKey != "",
+			TavilyAPIKey:     cfg.TavilyAPIKey,
+			ExaAPIKey:        cfg.ExaAPIKey,
+			FirecrawlAPIKey:  cfg.FirecrawlAPIKey,
+			EnableTavily:     cfg.TavilyAPIKey != "",
+			EnableExa:        cfg.ExaAPIKey != "",
+			EnableFirecrawl:  cfg.FirecrawlAPIKey != "",
+			EnableGoogleNews: cfg.EnableGoogleNews,
+			EnableReddit:     cfg.EnableReddit,
+			Cache: enrichment.CacheConfig{
+				TavilyTTL:     cfg.EnrichmentCacheTavilyTTL,
+				ExaTTL:        cfg.EnrichmentCacheExaTTL,
+				GoogleNewsTTL: cfg.EnrichmentCacheGoogleTTL,
+				RedditTTL:     cfg.EnrichmentCacheRedditTTL,
+				FirecrawlTTL:  cfg.EnrichmentCacheFirecrawlTTL,
+				MemoryEntries: cfg.EnrichmentCacheMemory,
+			},
 		})
+		enricher.SetCacheStore(store)
+		if embeddingScorer != nil {
+			enricher.SetScorer(embeddingScorer)
+		}
 		log.Info().Msg("Enrichment pipeline initialized")
 	}
 
 	// Initialize market syncer
 	syncConfig := syncer.DefaultSyncerConfig()
 	syncConfig.SyncInterval = cfg.PollInterval
+	syncConfig.SnapshotInterval = cfg.SnapshotInterval
 	syncConfig.MinVolume24h = cfg.MinVolume24h
 	syncConfig.BreakingThreshold = cfg.MinProbabilityChange
+	syncConfig.EventCooldown = cfg.EventCooldown
+	syncConfig.EventEscalation = cfg.EventEscalation
+	syncConfig.BreakingMinLiquidity = cfg.BreakingMinLiquidity
+	syncConfig.BreakingConfirmCycles = cfg.BreakingConfirmCycles
+	syncConfig.TrendingWeights = cfg.TrendingWeights
+	syncConfig.TrendingHalfLife = cfg.TrendingHalfLife
 
 	marketSyncer := syncer.NewSyncer(pmClient, store, syncConfig)
+	if llmProvider != nil {
+		// Settle uncategorized and ambiguous markets with the LLM
+		marketSyncer.SetClassifier(llmProvider)
+	}
 	log.Info().Msg("Market syncer initialized")
 
 	// Initialize content generator
-	generator := content.NewGenerator(store, marketSyncer, llmClient, enricher)
+	generator := content.NewGenerator(store, marketSyncer, llmProvider, enricher)
+	generator.SetDedup(content.DedupConfig{
+		Mode:            content.DedupMode(cfg.DedupMode),
+		Window:          cfg.DedupWindow,
+		MinUpdateChange: cfg.DedupMinUpdateChange,
+	})
+	qualityCfg := content.DefaultQualityConfig()
+	qualityCfg.Enabled = cfg.QualityGateEnabled
+	qualityCfg.MaxHeadlineLength = cfg.QualityMaxHeadlineLength
+	qualityCfg.BannedPhrases = append(qualityCfg.BannedPhrases, cfg.QualityBannedPhrases...)
+	qualityCfg.ProbabilityTolerance = cfg.QualityProbTolerance
+	qualityCfg.VolumeTolerance = cfg.QualityVolumeTolerance
+	generator.SetQuality(qualityCfg)
+	generator.SetHistoryLookbacks(cfg.HistoryLookbacks)
+	if cfg.SchedulerLocksEnabled {
+		generator.SetLocks(store, content.GenerationLockConfig{Owner: cfg.InstanceID, Cooldown: cfg.GenerationCooldown})
+	}
 	log.Info().Msg("Content generator initialized")
 
+	// Cache hot API reads; syncs and new articles invalidate them
+	readCache := newReadCache(ctx, cfg)
+	if readCache != nil {
+		marketSyncer.SetCache(readCache)
+		generator.SetCache(readCache)
+	}
+
+	// Poll tracked accounts for social signals and cite them in articles
+	var xtrackerWorker *xtracker.Worker
+	if cfg.XTrackerEnabled {
+		correlationCfg := xtracker.DefaultCorrelationConfig()
+		correlationCfg.TimeWindow = cfg.XTrackerLookback
+		correlationCfg.ImpactDelay = cfg.XTrackerImpactDelay
+		correlator := xtracker.NewCorrelator(xtracker.NewClient(xtracker.WithBaseURL(cfg.XTrackerURL)), store, correlationCfg)
+		correlator.SetSignalStore(store)
+		correlator.SetAccountStore(store)
+		correlator.AddSource(models.PlatformBluesky, xtracker.NewBlueskyClient(cfg.BlueskyURL))
+		correlator.AddSource(models.PlatformTruthSocial, xtracker.NewTruthSocialClient(cfg.TruthSocialURL))
+		if embeddingScorer != nil {
+			correlator.SetScorer(embeddingScorer)
+		}
+		if cfg.XTrackerLLMRelevance && llmProvider != nil {
+			relevanceCache := readCache
+			if relevanceCache == nil {
+				relevanceCache = cache.NewMemory(cfg.CacheMemoryEntries)
+			}
+			relevanceCfg := xtracker.DefaultRelevanceConfig()
+			relevanceCfg.Threshold = cfg.XTrackerRelevanceThreshold
+			relevanceCfg.MaxPairsPerCycle = cfg.XTrackerRelevanceMaxPairs
+			correlator.SetRelevanceScorer(xtracker.NewRelevanceScorer(llmProvider, relevanceCache, relevanceCfg))
+			log.Info().
+				Float64("threshold", cfg.XTrackerRelevanceThreshold).
+				Int("max_pairs", cfg.XTrackerRelevanceMaxPairs).
+				Msg("LLM social relevance enabled")
+		}
+		generator.SetCorrelator(correlator)
+
+		workerCfg := xtracker.DefaultWorkerConfig()
+		workerCfg.PollInterval = cfg.XTrackerPollInterval
+		workerCfg.Lookback = cfg.XTrackerLookback
+		xtrackerWorker = xtracker.NewWorker(correlator, marketSyncer, workerCfg)
+		log.Info().Str("url", cfg.XTrackerURL).Msg("XTracker worker initialized")
+	}
+
+	s3Config := blob.S3Config{
+		Bucket:          cfg.S3Bucket,
+		Region:          cfg.S3Region,
+		AccessKeyID:     cfg.S3AccessKeyID,
+		SecretAccessKey: cfg.S3SecretKey,
+		Endpoint:        cfg.S3Endpoint,
+		PublicURL:       cfg.S3PublicURL,
+	}
+
+	// Render share images for new articles
+	ogImageDir := ""
+	if cfg.OGImagesEnabled {
+		var storage og.Storage
+		if cfg.OGStorage == "s3" {
+			storage = blob.NewS3Storage(s3Config)
+		} else {
+			ogImageDir = cfg.OGImageDir
+			storage = blob.NewFileStorage(cfg.OGImageDir, strings.TrimRight(cfg.PublicAPIURL, "/")+"/og")
+		}
+		ogCfg := og.DefaultConfig()
+		ogCfg.Format = cfg.OGImageFormat
+		generator.SetImageRenderer(og.NewRenderer(storage, ogCfg))
+		log.Info().Str("storage", cfg.OGStorage).Str("format", cfg.OGImageFormat).Msg("Share images enabled")
+	}
+
+	// Initialize social publishers
+	var xPublisher *publisher.XPublisher
+	if cfg.XEnabled() {
+		xPublisher = publisher.NewXPublisher(store, publisher.XConfig{
+			APIKey:          cfg.XAPIKey,
+			APISecret:       cfg.XAPISecret,
+			AccessToken:     cfg.XAccessToken,
+			AccessSecret:    cfg.XAccessSecret,
+			SiteURL:         cfg.SiteURL,
+			MinSignificance: models.Significance(cfg.XMinSignificance),
+			MaxPostsPerHour: cfg.XMaxPostsPerHour,
+			DedupWindow:     cfg.XDedupWindow,
+		})
+		generator.AddPublisher(xPublisher)
+	}
+
+	var discordPublisher *publisher.DiscordPublisher
+	if len(cfg.DiscordWebhooks) > 0 {
+		eventTypes := make([]syncer.EventType, len(cfg.DiscordEventTypes))
+		for i, t := range cfg.DiscordEventTypes {
+			eventTypes[i] = syncer.EventType(t)
+		}
+		discordPublisher = publisher.NewDiscordPublisher(store, marketSyncer, publisher.DiscordConfig{
+			Webhooks:        cfg.DiscordWebhooks,
+			SiteURL:         cfg.SiteURL,
+			MinSignificance: models.Significance(cfg.DiscordMinSignificance),
+			EventTypes:      eventTypes,
+			MaxPostsPerHour: cfg.DiscordMaxPostsPerHour,
+			DedupWindow:     cfg.DiscordDedupWindow,
+		})
+		generator.AddPublisher(discordPublisher)
+	}
+
+	// Notify partner sites of article changes
+	var contentWebhooks *webhooks.Notifier
+	if len(cfg.ContentWebhookURLs) > 0 {
+		contentWebhooks = webhooks.NewNotifier(webhooks.Config{
+			Endpoints: cfg.ContentWebhookURLs,
+			Secret:    cfg.ContentWebhookSecret,
+			APIURL:    cfg.PublicAPIURL,
+			Timeout:   webhooks.DefaultConfig().Timeout,
+		})
+		generator.AddPublisher(contentWebhooks)
+	}
+
+	// Deliver market events to watchlist webhooks
+	var alertService *alerts.Service
+	if cfg.AlertsEnabled {
+		alertService = alerts.NewService(store, marketSyncer, alerts.Config{
+			SiteURL:    cfg.SiteURL,
+			MaxPerHour: cfg.AlertsMaxPerHour,
+			Timeout:    alerts.DefaultConfig().Timeout,
+		})
+	}
+
 	// Initialize scheduler
 	sched := scheduler.NewScheduler(generator, marketSyncer)
+	sched.SetMinEventScore(cfg.EventMinScore)
+	if cfg.SchedulerLocksEnabled {
+		sched.SetLocks(store, scheduler.LockConfig{Owner: cfg.InstanceID, TTL: cfg.SchedulerLockTTL})
+	}
+	sched.SetRetryPolicy(scheduler.RetryPolicy{Attempts: cfg.JobRetryAttempts, Backoff: cfg.JobRetryBackoff})
+	sched.SetRunHistory(store)
+	sched.SetCalendar(store)
+	sched.SetExplainers(store)
+	sched.SetBriefings(store)
+
+	// Keep the category sentiment cache fresh for the homepage heatmap
+	sched.AddJob(&scheduler.Job{
+		Name: "category-sentiment",
+		Schedule: scheduler.Schedule{
+			Type:     scheduler.ScheduleInterval,
+			Interval: 5 * time.Minute,
+		},
+		Handler: func(ctx context.Context) error {
+			_, err := store.RefreshCategorySentiments(ctx)
+			return err
+		},
+		Retry: scheduler.RetryPolicy{Attempts: 1}, // The next run is minutes away
+	})
+
+	// Recompute market correlations for related markets and article context
+	correlations := analysis.NewCorrelationAnalyzer(store, analysis.DefaultCorrelationConfig())
+	sched.AddJob(&scheduler.Job{
+		Name: "market-correlation",
+		Schedule: scheduler.Schedule{
+			Type:     scheduler.ScheduleInterval,
+			Interval: time.Hour,
+		},
+		Handler: correlations.Run,
+	})
+
+	// Score resolved markets' odds against their outcomes
+	accuracy := analysis.NewAccuracyAnalyzer(store, analysis.DefaultAccuracyConfig())
+	sched.AddJob(&scheduler.Job{
+		Name: "forecast-accuracy",
+		Schedule: scheduler.Schedule{
+			Type:   scheduler.ScheduleDaily,
+			Hour:   3,
+			Minute: 0,
+		},
+		Handler: accuracy.Run,
+	})
+
+	// Archive markets that closed a while ago, out of market lists
+	if cfg.MarketArchiveAfter > 0 {
+		sched.AddJob(&scheduler.Job{
+			Name: "market-archive",
+			Schedule: scheduler.Schedule{
+				Type:   scheduler.ScheduleDaily,
+				Hour:   4,
+				Minute: 0,
+			},
+			Handler: func(ctx context.Context) error {
+				archived, err := store.ArchiveClosedMarkets(ctx, cfg.MarketArchiveAfter)
+				if err == nil && archived > 0 {
+					log.Info().Int64("archived", archived).Msg("Archived closed markets")
+				}
+				return err
+			},
+		})
+	}
+
+	// Purge old LLM usage, analytics, job runs and enrichment cache entries
+	retention := storage.Retention{
+		LLMUsage:        cfg.RetentionLLMUsage,
+		AnalyticsEvents: cfg.RetentionAnalyticsEvents,
+		JobRuns:         cfg.RetentionJobRuns,
+		EnrichmentCache: cfg.RetentionEnrichmentCache,
+	}
+	sched.AddJob(&scheduler.Job{
+		Name: "retention",
+		Schedule: scheduler.Schedule{
+			Type:   scheduler.ScheduleDaily,
+			Hour:   4,
+			Minute: 30,
+		},
+		Handler: func(ctx context.Context) error {
+			reports, err := store.ApplyRetention(ctx, retention, false)
+			for _, r := range reports {
+				if r.Documents > 0 {
+					log.Info().Str("collection", r.Collection).Int64("deleted", r.Documents).Time("cutoff", r.Cutoff).Msg("Purged old documents")
+				}
+			}
+			return err
+		},
+	})
+
+	// Render articles updated since the last nightly run (with an hour of
+	// overlap) to the static site export, and remove those taken down;
+	// fsctl export-site does them all
+	if cfg.SiteExportEnabled {
+		var siteStorage staticsite.Storage
+		siteCfg := staticsite.DefaultConfig()
+		siteCfg.SiteURL = cfg.SiteURL
+		siteCfg.Formats = cfg.SiteExportFormats
+		if cfg.SiteExportStorage == "s3" {
+			siteStorage = blob.NewS3Storage(s3Config)
+			siteCfg.Prefix = "site/"
+		} else {
+			siteStorage = blob.NewFileStorage(cfg.SiteExportDir, "")
+		}
+		exporter := staticsite.NewExporter(store, siteStorage, siteCfg)
+		sched.AddJob(&scheduler.Job{
+			Name: "site-export",
+			Schedule: scheduler.Schedule{
+				Type:   scheduler.ScheduleDaily,
+				Hour:   5,
+				Minute: 0,
+			},
+			Handler: func(ctx context.Context) error {
+				result, err := exporter.Export(ctx, time.Now().Add(-25*time.Hour))
+				log.Info().Int("articles", result.Articles).Int("files", result.Files).Int("removed", result.Removed).Int("failed", result.Failed).Msg("Exported static site")
+				return err
+			},
+		})
+		log.Info().Str("storage", cfg.SiteExportStorage).Strs("formats", cfg.SiteExportFormats).Msg("Static site export enabled")
+	}
+
 	log.Info().Msg("Scheduler initialized")
 
 	// Initialize API server with syncer and scheduler for admin endpoints
-	apiServer := api.NewServer(store, marketSyncer, sched, cfg.HTTPAddr)
+	apiServer := api.NewServer(store, marketSyncer, sched, api.ServerConfig{
+		Addr:                 cfg.HTTPAddr,
+		SiteURL:              cfg.SiteURL,
+		AdminAPIKey:          cfg.AdminAPIKey,
+		AdminJWTSecret:       cfg.AdminJWTSecret,
+		AdminRateLimit:       cfg.AdminRateLimit,
+		SessionSecret:        cfg.SessionSecret,
+		OGImageDir:           ogImageDir,
+		MarketMaxAge:         cfg.MarketMaxAge,
+		ContentPreviewSecret: cfg.ContentPreviewSecret,
+		ContentPreviewTTL:    cfg.ContentPreviewTTL,
+		TrustedProxies:       cfg.TrustedProxies,
+		ClientIPHeader:       cfg.ClientIPHeader,
+		RateLimit: api.RateLimitConfig{
+			IPRate:   cfg.RateLimitIPRate,
+			IPBurst:  cfg.RateLimitIPBurst,
+			KeyRate:  cfg.RateLimitKeyRate,
+			KeyBurst: cfg.RateLimitKeyBurst,
+			Exempt:   cfg.RateLimitExempt,
+		},
+	})
+	apiServer.SetGenerator(generator)
+	apiServer.SetRetention(retention)
+	if contentWebhooks != nil {
+		apiServer.SetContentNotifier(contentWebhooks)
+	}
+
+	// Tune detection with runtime settings, reloaded as editors change them
+	settingsWatcher := settings.NewWatcher(store, settings.DefaultConfig())
+	settingsWatcher.AddTarget(marketSyncer)
+	settingsWatcher.AddTarget(sched)
+	apiServer.SetSettings(settingsWatcher)
+	if readCache != nil {
+		apiServer.SetCache(readCache, api.CacheTTLs{
+			Feed:       cfg.CacheFeedTTL,
+			Markets:    cfg.CacheMarketsTTL,
+			Categories: cfg.CacheCategoriesTTL,
+			Articles:   cfg.CacheArticlesTTL,
+		})
+	}
+
+	// Serve market images through the asset proxy
+	if cfg.AssetProxyEnabled {
+		var cache blob.Storage
+		if cfg.AssetCache == "s3" {
+			cache = blob.NewS3Storage(s3Config)
+		} else {
+			cache = blob.NewFileStorage(cfg.AssetCacheDir, "")
+		}
+		assetCfg := assets.DefaultConfig()
+		assetCfg.BaseURL = cfg.PublicAPIURL
+		apiServer.SetAssets(assets.NewProxy(store, cache, assetCfg))
+		log.Info().Str("cache", cfg.AssetCache).Msg("Asset proxy enabled")
+	}
+
+	// Record reader analytics and keep the daily rollups current
+	analyticsWriter := analytics.NewWriter(store, analytics.DefaultConfig())
+	apiServer.SetAnalytics(analyticsWriter)
+	sched.AddJob(&scheduler.Job{
+		Name: "analytics-rollup",
+		Schedule: scheduler.Schedule{
+			Type:     scheduler.ScheduleInterval,
+			Interval: time.Hour,
+		},
+		Handler: analyticsWriter.Rollup,
+	})
+
+	// Promote winning headlines once each variant has been seen enough
+	headlineCfg := abtest.DefaultConfig()
+	headlineCfg.MinImpressions = cfg.HeadlineTestMinImpressions
+	headlines := abtest.NewEvaluator(store, headlineCfg)
+	sched.AddJob(&scheduler.Job{
+		Name: "headline-tests",
+		Schedule: scheduler.Schedule{
+			Type:     scheduler.ScheduleInterval,
+			Interval: 15 * time.Minute,
+		},
+		Handler: headlines.Run,
+	})
+
+	// Initialize user accounts (magic links also need a mailer, below)
+	var accounts *auth.Accounts
+	if cfg.SessionSecret != "" {
+		accounts = auth.NewAccounts(store, auth.AccountConfig{
+			SessionSecret:      cfg.SessionSecret,
+			SessionTTL:         cfg.SessionTTL,
+			LoginTokenTTL:      cfg.LoginLinkTTL,
+			APIURL:             cfg.PublicAPIURL,
+			GoogleClientID:     cfg.GoogleClientID,
+			GoogleClientSecret: cfg.GoogleClientSecret,
+		})
+		apiServer.SetAccounts(accounts)
+	}
+
+	// Route articles and watchlist alerts to signed-in users
+	var dispatcher *notify.Dispatcher
+	if accounts != nil {
+		dispatcher = notify.NewDispatcher(store, notify.Config{
+			SiteURL:          cfg.SiteURL,
+			TelegramBotToken: cfg.TelegramBotToken,
+			MaxPerHour:       cfg.NotifyMaxPerHour,
+		})
+		generator.AddPublisher(dispatcher)
+		if alertService != nil {
+			alertService.SetNotifier(dispatcher)
+		}
+	}
+
+	// Initialize newsletter (requires a mailer)
+	if mailer := newMailer(cfg); mailer != nil {
+		svc := newsletter.NewService(store, mailer, newsletter.Config{
+			SiteURL:    cfg.SiteURL,
+			APIURL:     cfg.PublicAPIURL,
+			BatchSize:  cfg.NewsletterBatchSize,
+			BatchDelay: cfg.NewsletterBatchDelay,
+		})
+		apiServer.SetNewsletter(svc)
+		if accounts != nil {
+			accounts.SetLinkMailer(svc)
+		}
+		if dispatcher != nil {
+			dispatcher.SetMailer(mailer)
+		}
+
+		// Send after the 8:00 morning briefing has been generated
+		sched.AddJob(&scheduler.Job{
+			Name: "morning-newsletter",
+			Schedule: scheduler.Schedule{
+				Type:   scheduler.ScheduleDaily,
+				Hour:   8,
+				Minute: 15,
+			},
+			Handler: svc.SendMorningBriefing,
+		})
+		log.Info().Str("provider", cfg.NewsletterProvider).Msg("Newsletter initialized")
+	}
 
 	// Setup signal handling
 	sigChan := make(chan os.Signal, 1)
@@ -117,8 +613,28 @@ func main() {
 		}
 	}()
 
+	analyticsWriter.Start()
+	settingsWatcher.Start()
 	marketSyncer.Start()
 	sched.Start()
+	if xtrackerWorker != nil {
+		xtrackerWorker.Start()
+	}
+	if xPublisher != nil {
+		xPublisher.Start()
+	}
+	if discordPublisher != nil {
+		discordPublisher.Start()
+	}
+	if alertService != nil {
+		alertService.Start()
+	}
+	if dispatcher != nil {
+		dispatcher.Start()
+	}
+	if contentWebhooks != nil {
+		contentWebhooks.Start()
+	}
 
 	log.Info().
 		Str("api", cfg.HTTPAddr).
@@ -128,11 +644,155 @@ func main() {
 	<-sigChan
 	log.Info().Msg("Shutdown signal received")
 
-	// Graceful shutdown
+	// Graceful shutdown: stop taking events, let the article generations in
+	// flight finish, then flush the posts and notifications they queued,
+	// all within SHUTDOWN_TIMEOUT
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelDrain()
+	sched.Drain(drainCtx)
+	// Alerts feed the dispatcher; stop them before draining it
+	if alertService != nil {
+		alertService.Stop()
+	}
+	if xPublisher != nil {
+		xPublisher.Drain(drainCtx)
+	}
+	if discordPublisher != nil {
+		discordPublisher.Drain(drainCtx)
+	}
+	if dispatcher != nil {
+		dispatcher.Drain(drainCtx)
+	}
+	if contentWebhooks != nil {
+		contentWebhooks.Drain(drainCtx)
+	}
+	cancelDrain()
+
 	shutdownCtx := context.Background()
-	sched.Stop()
+	// Stop before the syncer; the worker emits onto its event bus
+	if xtrackerWorker != nil {
+		xtrackerWorker.Stop()
+	}
+	settingsWatcher.Stop()
 	marketSyncer.Stop()
 	apiServer.Shutdown(shutdownCtx)
+	analyticsWriter.Stop()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Warn().Err(err).Msg("Failed to flush traces")
+	}
 
 	log.Info().Msg("FutureSignals engine stopped")
 }
+
+// newMailer builds the configured newsletter mailer, or nil when email
+// isn't configured.
+func newMailer(cfg *config.Config) newsletter.Mailer {
+	switch cfg.NewsletterProvider {
+	case "ses":
+		if cfg.SMTPUsername == "" {
+			log.Warn().Msg("Newsletter disabled (no SES SMTP credentials)")
+			return nil
+		}
+		return newsletter.NewSESMailer(cfg.SESRegion, cfg.SMTPUsername, cfg.SMTPPassword, cfg.NewsletterFrom)
+
+	default:
+		if cfg.SMTPHost == "" {
+			log.Warn().Msg("Newsletter disabled (no SMTP host)")
+			return nil
+		}
+		return newsletter.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.NewsletterFrom)
+	}
+}
+
+// newLLMProvider builds the configured LLM backend. It returns a nil
+// interface (not a typed nil) when the provider has no API key, so the
+// generator falls back to template content.
+func newLLMProvider(cfg *config.Config) llm.Provider {
+	switch cfg.LLMProvider {
+	case llm.ProviderOpenAI:
+		if cfg.OpenAIAPIKey == "" {
+			log.Warn().Msg("OpenAI provider not initialized (no API key)")
+			return nil
+		}
+		log.Info().Str("model", cfg.OpenAIModel).Msg("OpenAI LLM provider initialized")
+		return llm.NewOpenAIProvider(llm.OpenAIConfig{
+			APIKey:   cfg.OpenAIAPIKey,
+			Endpoint: cfg.OpenAIEndpoint,
+			Models: llm.ModelMap{
+				llm.ModelDefault: cfg.OpenAIModel,
+				llm.ModelFast:    cfg.OpenAIFastModel,
+			},
+		})
+
+	case llm.ProviderAnthropic:
+		if cfg.AnthropicAPIKey == "" {
+			log.Warn().Msg("Anthropic provider not initialized (no API key)")
+			return nil
+		}
+		log.Info().Str("model", cfg.AnthropicModel).Msg("Anthropic LLM provider initialized")
+		return llm.NewAnthropicProvider(llm.AnthropicConfig{
+			APIKey: cfg.AnthropicAPIKey,
+			Models: llm.ModelMap{
+				llm.ModelDefault: cfg.AnthropicModel,
+				llm.ModelFast:    cfg.AnthropicFastModel,
+			},
+		})
+
+	default:
+		if cfg.DashScopeAPIKey == "" {
+			log.Warn().Msg("Qwen client not initialized (no API key)")
+			return nil
+		}
+		log.Info().Str("model", cfg.QwenModel).Msg("Qwen LLM client initialized")
+		return qwen.NewClient(qwen.Config{
+			APIKey:    cfg.DashScopeAPIKey,
+			Endpoint:  cfg.DashScopeEndpoint,
+			Model:     cfg.QwenModel,
+			FastModel: cfg.QwenFastModel,
+		})
+	}
+}
+
+// newEmbeddingScorer builds the embedding relevance scorer, or returns nil
+// when no embedding provider is configured or its client can't be created.
+func newEmbeddingScorer(cfg *config.Config, store *storage.Store) *embeddings.Scorer {
+	ecfg := embeddings.Config{Provider: cfg.EmbeddingProvider, Model: cfg.EmbeddingModel}
+	switch cfg.EmbeddingProvider {
+	case "":
+		return nil
+	case embeddings.ProviderDashScope:
+		ecfg.APIKey, ecfg.Endpoint = cfg.DashScopeAPIKey, cfg.DashScopeEndpoint
+	case embeddings.ProviderOpenAI:
+		ecfg.APIKey, ecfg.Endpoint = cfg.OpenAIAPIKey, cfg.OpenAIEndpoint
+	}
+
+	client, err := embeddings.NewClient(ecfg)
+	if err != nil {
+		log.Warn().Err(err).Msg("Embedding relevance not initialized")
+		return nil
+	}
+	log.Info().
+		Str("provider", cfg.EmbeddingProvider).
+		Str("model", client.Model()).
+		Float64("threshold", cfg.EmbeddingThreshold).
+		Msg("Embedding relevance initialized")
+	return embeddings.NewScorer(client, store, cfg.EmbeddingThreshold)
+}
+
+// newReadCache returns the API read cache selected by CACHE_BACKEND, or nil
+// when it is off.
+func newReadCache(ctx context.Context, cfg *config.Config) cache.Cache {
+	switch cfg.CacheBackend {
+	case "memory":
+		log.Info().Int("entries", cfg.CacheMemoryEntries).Msg("In-process read cache enabled")
+		return cache.NewMemory(cfg.CacheMemoryEntries)
+	case "redis":
+		c, err := cache.NewRedis(ctx, cfg.RedisURL)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to connect to Redis")
+		}
+		log.Info().Msg("Redis read cache enabled")
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Package abtest runs headline experiments on articles: readers are
+// assigned a headline variant deterministically, and the variant with the
+// best click-through is promoted once each has been seen enough.
+package abtest
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config holds headline test configuration.
+type Config struct {
+	// Impressions every variant needs before a winner is promoted
+	MinImpressions int
+
+	// Tests still short of MinImpressions after this long keep the
+	// original headline
+	MaxDuration time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		MinImpressions: 500,
+		MaxDuration:    7 * 24 * time.Hour,
+	}
+}
+
+// Apply swaps in the headline variant assigned to session and returns its
+// 1-based number, or 0 when the article has no running test. The same
+// session always gets the same variant of an article.
+func Apply(session string, article *models.Article) int {
+	test := article.HeadlineTest
+	if !test.Running() {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(session))
+	h.Write([]byte(article.ID.Hex()))
+	i := int(h.Sum32() % uint32(len(test.Variants)))
+
+	article.Headline = test.Variants[i].Headline
+	article.MetaTitle = article.Headline
+	return i + 1
+}
+
+// Evaluator refreshes running tests' counts and promotes winners.
+type Evaluator struct {
+	store  *storage.Store
+	config Config
+}
+
+// NewEvaluator creates a new evaluator.
+func NewEvaluator(store *storage.Store, cfg Config) *Evaluator {
+	return &Evaluator{store: store, config: cfg}
+}
+
+// Run evaluates every running headline test. It is meant to run as a
+// scheduler job.
+func (e *Evaluator) Run(ctx context.Context) error {
+	articles, err := e.store.GetHeadlineTests(ctx, false, 1000)
+	if err != nil {
+		return err
+	}
+	if len(articles) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	counts, err := e.store.GetHeadlineVariantCounts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byArticle := make(map[primitive.ObjectID][]models.HeadlineVariantCount)
+	for _, c := range counts {
+		byArticle[c.ArticleID] = append(byArticle[c.ArticleID], c)
+	}
+
+	now := time.Now()
+	decided := 0
+	for _, a := range articles {
+		test := a.HeadlineTest
+		if test == nil {
+			continue
+		}
+		e.evaluate(test, byArticle[a.ID], now)
+		if test.Winner != nil {
+			decided++
+			log.Info().
+				Str("slug", a.Slug).
+				Str("headline", test.Variants[*test.Winner].Headline).
+				Int("variant", *test.Winner+1).
+				Msg("Headline test decided")
+		}
+		if err := e.store.SaveHeadlineTest(ctx, a.ID, test); err != nil {
+			log.Warn().Err(err).Str("slug", a.Slug).Msg("Failed to save headline test")
+		}
+	}
+
+	log.Debug().Int("tests", len(articles)).Int("decided", decided).Msg("Evaluated headline tests")
+	return nil
+}
+
+// evaluate updates a test's counts and decides it when every variant has
+// MinImpressions, or keeps the original once MaxDuration has passed.
+func (e *Evaluator) evaluate(test *models.HeadlineTest, counts []models.HeadlineVariantCount, now time.Time) {
+	for i := range test.Variants {
+		test.Variants[i].Impressions = 0
+		test.Variants[i].Clicks = 0
+		test.Variants[i].CTR = 0
+	}
+	for _, c := range counts {
+		if i := c.Variant - 1; i >= 0 && i < len(test.Variants) {
+			v := &test.Variants[i]
+			v.Impressions = c.Impressions
+			v.Clicks = c.Clicks
+			if v.Impressions > 0 {
+				v.CTR = float64(v.Clicks) / float64(v.Impressions)
+			}
+		}
+	}
+	test.EvaluatedAt = &now
+
+	ready := true
+	for _, v := range test.Variants {
+		if v.Impressions < e.config.MinImpressions {
+			ready = false
+		}
+	}
+
+	winner := -1
+	switch {
+	case ready:
+		// Ties go to the earlier variant, favouring the original
+		winner = 0
+		for i, v := range test.Variants {
+			if v.CTR > test.Variants[winner].CTR {
+				winner = i
+			}
+		}
+	case now.Sub(test.StartedAt) > e.config.MaxDuration:
+		winner = 0
+	}
+	if winner >= 0 {
+		test.Winner = &winner
+		test.DecidedAt = &now
+	}
+}
@@ -0,0 +1,292 @@
+// Package alerts delivers market events for watched markets to the webhooks
+// configured on watchlists.
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+)
+
+// Config holds alert delivery configuration.
+type Config struct {
+	// Public site URL for market links
+	SiteURL string
+
+	// Delivery cap per watchlist per rolling hour (0 = unlimited)
+	MaxPerHour int
+
+	// Timeout for one webhook delivery
+	Timeout time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		MaxPerHour: 30,
+		Timeout:    10 * time.Second,
+	}
+}
+
+// WatchlistStore looks up the watchlists that follow a market.
+// *storage.Store implements it.
+type WatchlistStore interface {
+	GetAlertWatchlists(ctx context.Context, marketID string) ([]models.Watchlist, error)
+}
+
+// Notifier routes alert hits on a signed-in user's watchlists through the
+// user's notification preferences.
+type Notifier interface {
+	NotifyAlert(ctx context.Context, userID string, alert Payload, significance models.Significance, category string)
+}
+
+// Service subscribes to syncer events and posts each one to the webhooks of
+// the watchlists that follow its market, and hands hits on users'
+// watchlists to the notifier.
+type Service struct {
+	store      WatchlistStore
+	syncer     *syncer.Syncer
+	config     Config
+	httpClient *http.Client
+	limiter    *ratelimit.Limiter
+	notifier   Notifier
+
+	events <-chan syncer.Event
+	wg     sync.WaitGroup
+}
+
+// NewService creates a new alert service. Call Start to begin delivering.
+func NewService(store WatchlistStore, sync *syncer.Syncer, cfg Config) *Service {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultConfig().Timeout
+	}
+
+	return &Service{
+		store:      store,
+		syncer:     sync,
+		config:     cfg,
+		httpClient: WebhookClient(cfg.Timeout),
+		limiter:    ratelimit.New(2 * time.Hour),
+	}
+}
+
+// SetNotifier routes alerts on users' watchlists to their notification
+// channels.
+func (s *Service) SetNotifier(n Notifier) {
+	s.notifier = n
+}
+
+// Start subscribes to market events.
+func (s *Service) Start() {
+	log.Info().Int("max_per_hour", s.config.MaxPerHour).Msg("Starting watchlist alerts")
+
+	s.events = s.syncer.Subscribe("alerts")
+	s.wg.Add(1)
+	go s.eventLoop()
+}
+
+// Stop stops delivering alerts.
+func (s *Service) Stop() {
+	if s.events != nil {
+		s.syncer.Unsubscribe(s.events)
+		s.wg.Wait()
+	}
+}
+
+// ErrPrivateWebhook is returned for webhooks that point at the service's
+// own network rather than the public internet.
+var ErrPrivateWebhook = errors.New("webhook URL must resolve to a public address")
+
+// ValidateWebhook checks that a webhook URL is an absolute https URL whose
+// host resolves only to public addresses. A host can resolve differently
+// at delivery time, so deliveries also go through WebhookClient.
+func ValidateWebhook(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("invalid webhook URL")
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("webhook URL must use https")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("webhook host does not resolve")
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return ErrPrivateWebhook
+		}
+	}
+	return nil
+}
+
+// WebhookClient returns an HTTP client for posting to user-supplied
+// webhooks. It refuses to connect to non-public addresses, checked on the
+// address actually dialed so DNS rebinding and redirects can't reach
+// internal services, and ignores proxy settings for the same reason.
+func WebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddr(addrPort.Addr()) {
+				return ErrPrivateWebhook
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// sharedAddrSpace is the carrier-grade NAT range, not routable on the
+// public internet.
+var sharedAddrSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr reports whether addr is a public unicast address.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddrSpace.Contains(addr)
+}
+
+// Payload is the JSON body posted to alert webhooks.
+type Payload struct {
+	WatchlistID   string                 `json:"watchlist_id"`
+	WatchlistName string                 `json:"watchlist_name"`
+	Type          string                 `json:"type"`
+	MarketID      string                 `json:"market_id"`
+	Question      string                 `json:"question"`
+	URL           string                 `json:"url"`
+	Probability   float64                `json:"probability"`
+	Change24h     float64                `json:"change_24h"`
+	Volume24h     float64                `json:"volume_24h"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp     time.Time              `json:"timestamp"`
+}
+
+// eventLoop delivers each event to the watchlists following its market.
+func (s *Service) eventLoop() {
+	defer s.wg.Done()
+
+	for event := range s.events {
+		if event.Market == nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		s.deliver(ctx, event)
+		cancel()
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, event syncer.Event) {
+	lists, err := s.store.GetAlertWatchlists(ctx, event.Market.MarketID)
+	if err != nil {
+		log.Warn().Err(err).Str("market", event.Market.MarketID).Msg("Failed to load alert watchlists")
+		return
+	}
+
+	for _, list := range lists {
+		if !wantsType(list.Alerts, event.Type) {
+			continue
+		}
+
+		userID, isUser := strings.CutPrefix(list.Owner, "user:")
+		if isUser && s.notifier != nil {
+			s.notifier.NotifyAlert(ctx, userID, s.payload(list, event), event.Significance(), event.Market.Category)
+		}
+		if list.Alerts.WebhookURL == "" {
+			continue
+		}
+
+		if !s.limiter.Allow(list.ID.Hex(), s.config.MaxPerHour, time.Hour) {
+			metrics.AlertsDelivered.WithLabelValues("dropped").Inc()
+			continue
+		}
+
+		if err := s.post(ctx, list.Alerts.WebhookURL, s.payload(list, event)); err != nil {
+			log.Warn().Err(err).Str("watchlist", list.ID.Hex()).Msg("Alert webhook failed")
+			metrics.AlertsDelivered.WithLabelValues("error").Inc()
+			continue
+		}
+		metrics.AlertsDelivered.WithLabelValues("ok").Inc()
+	}
+}
+
+// wantsType reports whether alerts should fire for an event type.
+func wantsType(alerts models.WatchlistAlerts, eventType syncer.EventType) bool {
+	return len(alerts.EventTypes) == 0 || slices.Contains(alerts.EventTypes, string(eventType))
+}
+
+func (s *Service) payload(list models.Watchlist, event syncer.Event) Payload {
+	m := event.Market
+	return Payload{
+		WatchlistID:   list.ID.Hex(),
+		WatchlistName: list.Name,
+		Type:          string(event.Type),
+		MarketID:      m.MarketID,
+		Question:      m.Question,
+		URL:           strings.TrimRight(s.config.SiteURL, "/") + "/market/" + m.Slug,
+		Probability:   m.Probability,
+		Change24h:     m.Change24h,
+		Volume24h:     m.Volume24h,
+		Metadata:      event.Metadata,
+		Timestamp:     event.Timestamp,
+	}
+}
+
+func (s *Service) post(ctx context.Context, webhook string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FutureSignals-Alerts/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 16<<10))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
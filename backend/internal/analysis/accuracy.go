@@ -0,0 +1,224 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Horizon is how long before resolution a forecast is read.
+type Horizon struct {
+	Name     string
+	Duration time.Duration
+}
+
+// AccuracyConfig holds configuration for forecast accuracy scoring.
+type AccuracyConfig struct {
+	// How far back to look for resolved markets
+	Window time.Duration
+
+	Horizons []Horizon
+
+	// Minimum scored markets for a category/horizon to be reported
+	MinMarkets int
+
+	// Number of equal-width calibration buckets
+	Buckets int
+}
+
+// DefaultAccuracyConfig returns default configuration.
+func DefaultAccuracyConfig() AccuracyConfig {
+	return AccuracyConfig{
+		Window: 365 * 24 * time.Hour,
+		Horizons: []Horizon{
+			{Name: "1d", Duration: 24 * time.Hour},
+			{Name: "7d", Duration: 7 * 24 * time.Hour},
+			{Name: "30d", Duration: 30 * 24 * time.Hour},
+		},
+		MinMarkets: 5,
+		Buckets:    10,
+	}
+}
+
+// logLossEpsilon keeps log loss finite for forecasts of exactly 0 or 1.
+const logLossEpsilon = 1e-4
+
+// AccuracyAnalyzer scores market prices as forecasts of resolved outcomes.
+type AccuracyAnalyzer struct {
+	store  *storage.Store
+	config AccuracyConfig
+}
+
+// NewAccuracyAnalyzer creates a new accuracy analyzer.
+func NewAccuracyAnalyzer(store *storage.Store, cfg AccuracyConfig) *AccuracyAnalyzer {
+	return &AccuracyAnalyzer{store: store, config: cfg}
+}
+
+// scoreAccumulator sums one category/horizon's forecasts.
+type scoreAccumulator struct {
+	n       int
+	brier   float64
+	logLoss float64
+	buckets []bucketAccumulator
+}
+
+type bucketAccumulator struct {
+	n        int
+	forecast float64
+	yes      int
+}
+
+func (a *scoreAccumulator) add(p, outcome float64) {
+	a.n++
+	a.brier += (p - outcome) * (p - outcome)
+
+	clamped := math.Min(math.Max(p, logLossEpsilon), 1-logLossEpsilon)
+	a.logLoss -= outcome*math.Log(clamped) + (1-outcome)*math.Log(1-clamped)
+
+	i := int(p * float64(len(a.buckets)))
+	if i >= len(a.buckets) {
+		i = len(a.buckets) - 1
+	}
+	b := &a.buckets[i]
+	b.n++
+	b.forecast += p
+	if outcome == 1 {
+		b.yes++
+	}
+}
+
+// Run scores every market resolved within the window and replaces the
+// stored accuracy scores.
+func (a *AccuracyAnalyzer) Run(ctx context.Context) error {
+	start := time.Now()
+
+	markets, err := a.store.GetResolvedMarkets(ctx, a.config.Window)
+	if err != nil {
+		return fmt.Errorf("load resolved markets: %w", err)
+	}
+
+	acc := make(map[string]*scoreAccumulator)
+	accumulator := func(category string, h Horizon) *scoreAccumulator {
+		key := category + ":" + h.Name
+		if acc[key] == nil {
+			acc[key] = &scoreAccumulator{buckets: make([]bucketAccumulator, a.config.Buckets)}
+		}
+		return acc[key]
+	}
+
+	var longest time.Duration
+	for _, h := range a.config.Horizons {
+		longest = max(longest, h.Duration)
+	}
+
+	for i := range markets {
+		m := &markets[i]
+		if m.ResolvedAt == nil || !m.ResolutionKnown() {
+			continue
+		}
+
+		// Daily closes covering the longest horizon before resolution
+		since := time.Since(*m.ResolvedAt) + longest + 24*time.Hour
+		rollups, err := a.store.GetSnapshotRollups(ctx, m.MarketID, models.GranularityDay, since)
+		if err != nil {
+			return fmt.Errorf("load rollups for %s: %w", m.MarketID, err)
+		}
+
+		outcome := 0.0
+		if m.ResolvedYes() {
+			outcome = 1
+		}
+
+		for _, h := range a.config.Horizons {
+			p, ok := priceAt(rollups, m.ResolvedAt.Add(-h.Duration))
+			if !ok {
+				continue
+			}
+			accumulator(m.Category, h).add(p, outcome)
+			accumulator(models.AccuracyCategoryAll, h).add(p, outcome)
+		}
+	}
+
+	computedAt := time.Now()
+	var scores []models.AccuracyScore
+	for _, category := range scoreCategories(markets) {
+		for _, h := range a.config.Horizons {
+			sa := acc[category+":"+h.Name]
+			if sa == nil || sa.n < a.config.MinMarkets {
+				continue
+			}
+			scores = append(scores, a.score(category, h, sa, computedAt))
+		}
+	}
+
+	if err := a.store.SaveAccuracyScores(ctx, scores); err != nil {
+		return err
+	}
+
+	log.Info().
+		Int("resolved_markets", len(markets)).
+		Int("scores", len(scores)).
+		Dur("took", time.Since(start)).
+		Msg("Forecast accuracy updated")
+
+	return nil
+}
+
+func (a *AccuracyAnalyzer) score(category string, h Horizon, sa *scoreAccumulator, computedAt time.Time) models.AccuracyScore {
+	n := float64(sa.n)
+	score := models.AccuracyScore{
+		ID:           category + ":" + h.Name,
+		Category:     category,
+		Horizon:      h.Name,
+		HorizonHours: int(h.Duration.Hours()),
+		Markets:      sa.n,
+		BrierScore:   sa.brier / n,
+		LogLoss:      sa.logLoss / n,
+		ComputedAt:   computedAt,
+	}
+
+	width := 1 / float64(len(sa.buckets))
+	for i, b := range sa.buckets {
+		bucket := models.CalibrationBucket{
+			Lower: float64(i) * width,
+			Upper: float64(i+1) * width,
+			Count: b.n,
+		}
+		if b.n > 0 {
+			bucket.Forecast = b.forecast / float64(b.n)
+			bucket.Observed = float64(b.yes) / float64(b.n)
+		}
+		score.Calibration = append(score.Calibration, bucket)
+	}
+	return score
+}
+
+// priceAt returns the close of the last daily rollup that ended by t.
+// Rollups are newest first.
+func priceAt(rollups []models.SnapshotRollup, t time.Time) (float64, bool) {
+	for _, r := range rollups {
+		if !r.BucketStart.Add(24 * time.Hour).After(t) {
+			return r.Close, true
+		}
+	}
+	return 0, false
+}
+
+// scoreCategories returns "all" followed by the markets' categories, in
+// first-seen order.
+func scoreCategories(markets []models.Market) []string {
+	categories := []string{models.AccuracyCategoryAll}
+	seen := map[string]bool{models.AccuracyCategoryAll: true}
+	for _, m := range markets {
+		if !seen[m.Category] {
+			seen[m.Category] = true
+			categories = append(categories, m.Category)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,261 @@
+// Package analysis provides cross-market analytics computed from stored
+// market data.
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// CorrelationConfig holds configuration for correlation detection.
+type CorrelationConfig struct {
+	// Rolling window of hourly snapshots to correlate
+	Window time.Duration
+
+	// Minimum |r| for a pair to be stored
+	Threshold float64
+
+	// Minimum paired hourly changes for a coefficient to count
+	MinSamples int
+
+	// Number of markets (by 24h volume) considered per run
+	MaxMarkets int
+
+	// Largest category/tag group compared pairwise, by volume
+	MaxGroupSize int
+}
+
+// DefaultCorrelationConfig returns default configuration.
+func DefaultCorrelationConfig() CorrelationConfig {
+	return CorrelationConfig{
+		Window:       7 * 24 * time.Hour,
+		Threshold:    0.6,
+		MinSamples:   24,
+		MaxMarkets:   300,
+		MaxGroupSize: 40,
+	}
+}
+
+// CorrelationStore is the storage the correlation analyzer reads markets
+// and their price history from and saves correlations to. *storage.Store
+// implements it, as does storage.MemoryStore.
+type CorrelationStore interface {
+	storage.MarketStore
+	storage.SnapshotStore
+	storage.CorrelationStore
+}
+
+// CorrelationAnalyzer detects markets whose probabilities move together.
+// Only markets sharing a category or tag are compared, which keeps the pair
+// count manageable and the matches explainable.
+type CorrelationAnalyzer struct {
+	store  CorrelationStore
+	config CorrelationConfig
+}
+
+// NewCorrelationAnalyzer creates a new correlation analyzer.
+func NewCorrelationAnalyzer(store CorrelationStore, cfg CorrelationConfig) *CorrelationAnalyzer {
+	return &CorrelationAnalyzer{store: store, config: cfg}
+}
+
+// candidatePair is a pair of markets to compare and why.
+type candidatePair struct {
+	a, b       *models.Market
+	category   string
+	sharedTags []string
+}
+
+// Run computes correlations for the current top markets and stores the
+// pairs above the threshold.
+func (c *CorrelationAnalyzer) Run(ctx context.Context) error {
+	start := time.Now()
+
+	markets, err := c.store.GetTopMarketsByVolume(ctx, c.config.MaxMarkets)
+	if err != nil {
+		return fmt.Errorf("load markets: %w", err)
+	}
+
+	pairs := c.candidatePairs(markets)
+
+	// Hourly change series, loaded lazily and shared across pairs
+	series := make(map[string]map[int64]float64)
+	load := func(m *models.Market) (map[int64]float64, error) {
+		if s, ok := series[m.MarketID]; ok {
+			return s, nil
+		}
+		snapshots, err := c.store.GetSnapshots(ctx, m.MarketID, c.config.Window)
+		if err != nil {
+			return nil, err
+		}
+		s := hourlyChanges(snapshots)
+		series[m.MarketID] = s
+		return s, nil
+	}
+
+	computedAt := time.Now()
+	var results []models.MarketCorrelation
+	for _, p := range pairs {
+		sa, err := load(p.a)
+		if err != nil {
+			return fmt.Errorf("load snapshots for %s: %w", p.a.MarketID, err)
+		}
+		sb, err := load(p.b)
+		if err != nil {
+			return fmt.Errorf("load snapshots for %s: %w", p.b.MarketID, err)
+		}
+
+		r, n := pearson(sa, sb)
+		if n < c.config.MinSamples || math.IsNaN(r) || math.Abs(r) < c.config.Threshold {
+			continue
+		}
+
+		results = append(results, models.MarketCorrelation{
+			MarketA:        p.a.MarketID,
+			MarketB:        p.b.MarketID,
+			Correlation:    r,
+			Strength:       math.Abs(r),
+			Samples:        n,
+			WindowHours:    int(c.config.Window.Hours()),
+			SharedCategory: p.category,
+			SharedTags:     p.sharedTags,
+			ComputedAt:     computedAt,
+		})
+	}
+
+	if err := c.store.SaveCorrelations(ctx, results, computedAt); err != nil {
+		return err
+	}
+
+	log.Info().
+		Int("markets", len(markets)).
+		Int("pairs", len(pairs)).
+		Int("correlated", len(results)).
+		Dur("took", time.Since(start)).
+		Msg("Market correlations updated")
+
+	return nil
+}
+
+// candidatePairs returns each pair of markets that share a category or a
+// tag, once, with MarketA < MarketB.
+func (c *CorrelationAnalyzer) candidatePairs(markets []models.Market) []candidatePair {
+	// markets arrive sorted by volume, so truncating a group keeps the biggest
+	groups := make(map[string][]*models.Market)
+	for i := range markets {
+		m := &markets[i]
+		if m.Category != "" {
+			groups["category:"+m.Category] = append(groups["category:"+m.Category], m)
+		}
+		for _, tag := range m.Tags {
+			groups["tag:"+tag] = append(groups["tag:"+tag], m)
+		}
+	}
+
+	seen := make(map[[2]string]bool)
+	var pairs []candidatePair
+	for _, group := range groups {
+		if len(group) > c.config.MaxGroupSize {
+			group = group[:c.config.MaxGroupSize]
+		}
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				a, b := group[i], group[j]
+				if a.MarketID == b.MarketID {
+					continue
+				}
+				if a.MarketID > b.MarketID {
+					a, b = b, a
+				}
+				key := [2]string{a.MarketID, b.MarketID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				p := candidatePair{a: a, b: b, sharedTags: sharedTags(a.Tags, b.Tags)}
+				if a.Category == b.Category {
+					p.category = a.Category
+				}
+				pairs = append(pairs, p)
+			}
+		}
+	}
+	return pairs
+}
+
+// hourlyChanges converts snapshots into probability changes keyed by the
+// Unix hour they end in. Changes are used instead of levels, since two
+// markets that merely trend over the week would correlate on levels.
+func hourlyChanges(snapshots []models.Snapshot) map[int64]float64 {
+	levels := make(map[int64]float64, len(snapshots))
+	for _, s := range snapshots {
+		levels[s.CapturedAt.Unix()/3600] = s.Probability // last value per hour wins
+	}
+
+	hours := make([]int64, 0, len(levels))
+	for h := range levels {
+		hours = append(hours, h)
+	}
+	sort.Slice(hours, func(i, j int) bool { return hours[i] < hours[j] })
+
+	changes := make(map[int64]float64, len(hours))
+	for i := 1; i < len(hours); i++ {
+		if hours[i]-hours[i-1] == 1 {
+			changes[hours[i]] = levels[hours[i]] - levels[hours[i-1]]
+		}
+	}
+	return changes
+}
+
+// pearson returns the correlation of two series over their shared keys and
+// the number of shared keys. It returns NaN when either side is constant.
+func pearson(a, b map[int64]float64) (float64, int) {
+	var n int
+	var sumA, sumB, sumAA, sumBB, sumAB float64
+	for k, x := range a {
+		y, ok := b[k]
+		if !ok {
+			continue
+		}
+		n++
+		sumA += x
+		sumB += y
+		sumAA += x * x
+		sumBB += y * y
+		sumAB += x * y
+	}
+	if n < 2 {
+		return math.NaN(), n
+	}
+
+	fn := float64(n)
+	cov := sumAB - sumA*sumB/fn
+	varA := sumAA - sumA*sumA/fn
+	varB := sumBB - sumB*sumB/fn
+	if varA <= 0 || varB <= 0 {
+		return math.NaN(), n
+	}
+	return cov / math.Sqrt(varA*varB), n
+}
+
+func sharedTags(a, b []string) []string {
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[t] = true
+	}
+	var shared []string
+	for _, t := range b {
+		if set[t] {
+			shared = append(shared, t)
+			delete(set, t)
+		}
+	}
+	return shared
+}
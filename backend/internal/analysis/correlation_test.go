@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+)
+
+func TestCorrelationAnalyzerRun(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStore()
+
+	markets := []*models.Market{
+		{MarketID: "a", Slug: "a", Category: "crypto", Active: true, Volume24h: 3_000},
+		{MarketID: "b", Slug: "b", Category: "crypto", Active: true, Volume24h: 2_000},
+		{MarketID: "c", Slug: "c", Category: "sports", Active: true, Volume24h: 1_000},
+	}
+	for _, m := range markets {
+		if err := store.UpsertMarket(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// b mirrors a's hourly moves at half the size; c moves with a too, but
+	// shares neither its category nor a tag, so isn't compared
+	start := time.Now().Add(-40 * time.Hour).Truncate(time.Hour)
+	var snapshots []models.Snapshot
+	for i := 0; i < 36; i++ {
+		at := start.Add(time.Duration(i) * time.Hour)
+		move := 0.05 * math.Sin(float64(i))
+		snapshots = append(snapshots,
+			models.Snapshot{MarketID: "a", Probability: 0.5 + move, CapturedAt: at},
+			models.Snapshot{MarketID: "b", Probability: 0.3 + move/2, CapturedAt: at},
+			models.Snapshot{MarketID: "c", Probability: 0.6 + move, CapturedAt: at},
+		)
+	}
+	if err := store.AddSnapshots(snapshots...); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultCorrelationConfig()
+	cfg.Window = storage.RawSnapshotRange
+	analyzer := NewCorrelationAnalyzer(store, cfg)
+	if err := analyzer.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	correlations, err := store.GetCorrelationsForMarket(ctx, "a", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(correlations) != 1 {
+		t.Fatalf("got %d correlations, want 1: %+v", len(correlations), correlations)
+	}
+	c := correlations[0]
+	if c.MarketA != "a" || c.MarketB != "b" || c.SharedCategory != "crypto" {
+		t.Errorf("got pair %s/%s in %q, want a/b in crypto", c.MarketA, c.MarketB, c.SharedCategory)
+	}
+	if math.Abs(c.Correlation-1) > 1e-9 || c.Samples != 35 {
+		t.Errorf("got r = %v over %d samples, want 1 over 35", c.Correlation, c.Samples)
+	}
+
+	related, err := store.GetRelatedMarkets(ctx, "b", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(related) != 1 || related[0].Market.MarketID != "a" {
+		t.Errorf("got related markets %+v, want a", related)
+	}
+
+	// Once b closes, a rerun prunes its pair. Pairs are stamped to the
+	// millisecond, so the rerun must start in a later one.
+	time.Sleep(2 * time.Millisecond)
+	b := *markets[1]
+	b.Active = false
+	if err := store.UpsertMarket(ctx, &b); err != nil {
+		t.Fatal(err)
+	}
+	if err := analyzer.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if correlations, err := store.GetCorrelationsForMarket(ctx, "a", 10); err != nil || len(correlations) != 0 {
+		t.Errorf("got %d correlations after b closed (err %v), want 0", len(correlations), err)
+	}
+}
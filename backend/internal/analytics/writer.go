@@ -0,0 +1,144 @@
+// Package analytics records reader interactions with articles and rolls
+// them up into daily per-article totals.
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Config holds analytics writer configuration.
+type Config struct {
+	// Events buffered before new ones are dropped
+	BufferSize int
+
+	// Events written per insert
+	BatchSize int
+
+	// Longest an event waits in the buffer before being written
+	FlushInterval time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		BufferSize:    10000,
+		BatchSize:     500,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// Writer buffers analytics events and writes them in batches off the
+// request path. Tracking never blocks; events are dropped when the buffer
+// is full.
+type Writer struct {
+	store  *storage.Store
+	config Config
+
+	events chan models.AnalyticsEvent
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWriter creates a new writer. Call Start to begin writing.
+func NewWriter(store *storage.Store, cfg Config) *Writer {
+	return &Writer{
+		store:  store,
+		config: cfg,
+		events: make(chan models.AnalyticsEvent, cfg.BufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts the write loop.
+func (w *Writer) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop writes buffered events and stops the write loop.
+func (w *Writer) Stop() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+// Track queues an event for writing.
+func (w *Writer) Track(event models.AnalyticsEvent) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	select {
+	case w.events <- event:
+	default:
+		metrics.AnalyticsEvents.WithLabelValues(string(event.Type), "dropped").Inc()
+	}
+}
+
+func (w *Writer) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.AnalyticsEvent, 0, w.config.BatchSize)
+	for {
+		select {
+		case <-w.done:
+			// Drain what is already buffered
+			for {
+				select {
+				case e := <-w.events:
+					batch = append(batch, e)
+					if len(batch) >= w.config.BatchSize {
+						batch = w.flush(batch)
+					}
+				default:
+					w.flush(batch)
+					return
+				}
+			}
+		case e := <-w.events:
+			batch = append(batch, e)
+			if len(batch) >= w.config.BatchSize {
+				batch = w.flush(batch)
+			}
+		case <-ticker.C:
+			batch = w.flush(batch)
+		}
+	}
+}
+
+// flush writes a batch and returns it emptied for reuse.
+func (w *Writer) flush(batch []models.AnalyticsEvent) []models.AnalyticsEvent {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := "written"
+	if err := w.store.InsertAnalyticsEvents(ctx, batch); err != nil {
+		log.Warn().Err(err).Int("events", len(batch)).Msg("Failed to write analytics events")
+		result = "error"
+	}
+	for _, e := range batch {
+		metrics.AnalyticsEvents.WithLabelValues(string(e.Type), result).Inc()
+	}
+
+	return batch[:0]
+}
+
+// Rollup recomputes the daily rollups for yesterday and today (UTC). It is
+// meant to run as a scheduler job.
+func (w *Writer) Rollup(ctx context.Context) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return w.store.RollupAnalytics(ctx, today.AddDate(0, 0, -1))
+}
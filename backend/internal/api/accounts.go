@@ -0,0 +1,328 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/alerts"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// loginLimit caps magic link requests per client IP, since each one sends
+// an email.
+const loginLimit = 5
+
+// oauthStateCookie holds the OAuth state between the redirect and callback.
+const oauthStateCookie = "fs_oauth_state"
+
+// SetAccounts enables user sign-in endpoints.
+func (s *Server) SetAccounts(accounts *auth.Accounts) {
+	s.accounts = accounts
+}
+
+func (s *Server) requireAccounts(w http.ResponseWriter) bool {
+	if s.accounts == nil {
+		respondError(w, http.StatusServiceUnavailable, "Accounts not available")
+		return false
+	}
+	return true
+}
+
+// RequestLogin emails a magic sign-in link.
+func (s *Server) RequestLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAccounts(w) {
+		return
+	}
+
+	if !s.limiter.Allow("login:"+clientIP(r), loginLimit, time.Hour) {
+		respondError(w, http.StatusTooManyRequests, "Too many sign-in requests, try again later")
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch err := s.accounts.RequestLogin(r.Context(), req.Email); {
+	case errors.Is(err, auth.ErrInvalidEmail):
+		respondError(w, http.StatusBadRequest, "Invalid email address")
+		return
+	case errors.Is(err, auth.ErrProviderDisabled):
+		respondError(w, http.StatusServiceUnavailable, "Email sign-in not available")
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("Login link request failed")
+		respondError(w, http.StatusInternalServerError, "Failed to send sign-in link")
+		return
+	}
+
+	respondDataStatus(w, http.StatusAccepted, statusMessage{
+		Status:  "ok",
+		Message: "Check your inbox for a sign-in link",
+	})
+}
+
+// VerifyLoginLink handles the magic link: it starts a cookie session and
+// redirects to the site.
+func (s *Server) VerifyLoginLink(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAccounts(w) {
+		return
+	}
+
+	_, token, err := s.accounts.VerifyLogin(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidLogin) {
+			log.Error().Err(err).Msg("Login verification failed")
+		}
+		http.Redirect(w, r, s.siteURL+"/?login=invalid", http.StatusSeeOther)
+		return
+	}
+
+	s.setSessionCookie(w, token)
+	http.Redirect(w, r, s.siteURL+"/?login=ok", http.StatusSeeOther)
+}
+
+// VerifyLogin exchanges a magic link token for a session token, for
+// clients that don't use cookies.
+func (s *Server) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAccounts(w) {
+		return
+	}
+
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, token, err := s.accounts.VerifyLogin(r.Context(), req.Token)
+	if errors.Is(err, auth.ErrInvalidLogin) {
+		respondError(w, http.StatusUnauthorized, "Invalid or expired sign-in link")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Login verification failed")
+		respondError(w, http.StatusInternalServerError, "Failed to sign in")
+		return
+	}
+
+	respondData(w, sessionResponse{
+		Token:     token,
+		ExpiresAt: time.Now().Add(s.accounts.SessionTTL()),
+		User:      user,
+	})
+}
+
+// sessionResponse is a new session token and its user.
+type sessionResponse struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      *models.User `json:"user"`
+}
+
+// GoogleLogin redirects to Google's consent screen.
+func (s *Server) GoogleLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAccounts(w) {
+		return
+	}
+
+	authURL, state, err := s.accounts.GoogleAuthURL()
+	if errors.Is(err, auth.ErrProviderDisabled) {
+		respondError(w, http.StatusServiceUnavailable, "Google sign-in not available")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to start Google sign-in")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/api/auth/google",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   s.secureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// GoogleCallback completes Google sign-in, starts a cookie session and
+// redirects to the site.
+func (s *Server) GoogleCallback(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAccounts(w) {
+		return
+	}
+
+	expected := ""
+	if c, err := r.Cookie(oauthStateCookie); err == nil {
+		expected = c.Value
+	}
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Path: "/api/auth/google", MaxAge: -1})
+
+	q := r.URL.Query()
+	_, token, err := s.accounts.GoogleLogin(r.Context(), q.Get("code"), q.Get("state"), expected)
+	if err != nil {
+		log.Warn().Err(err).Msg("Google sign-in failed")
+		http.Redirect(w, r, s.siteURL+"/?login=invalid", http.StatusSeeOther)
+		return
+	}
+
+	s.setSessionCookie(w, token)
+	http.Redirect(w, r, s.siteURL+"/?login=ok", http.StatusSeeOther)
+}
+
+// GetCurrentUser returns the signed-in user.
+func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.currentUser(w, r)
+	if !ok {
+		return
+	}
+	respondData(w, user)
+}
+
+// Logout clears the session cookie. Bearer session tokens stay valid until
+// they expire; clients discard them.
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   s.secureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setSessionCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(s.accounts.SessionTTL().Seconds()),
+		HttpOnly: true,
+		Secure:   s.secureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// secureCookies reports whether cookies should be HTTPS-only, which they
+// are whenever the site is served over HTTPS.
+func (s *Server) secureCookies() bool {
+	return strings.HasPrefix(s.siteURL, "https://")
+}
+
+// GetNotificationPreferences returns the signed-in user's notification
+// preferences, or the defaults if none are saved.
+func (s *Server) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := s.store.GetNotificationPreferences(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch preferences")
+		return
+	}
+	if prefs == nil {
+		prefs = &models.NotificationPreferences{
+			Categories:      []string{},
+			MinSignificance: models.SignificanceHigh,
+		}
+	}
+
+	respondData(w, prefs)
+}
+
+// UpdateNotificationPreferences replaces the signed-in user's notification
+// preferences.
+func (s *Server) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	var prefs models.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	for _, slug := range prefs.Categories {
+		if models.GetCategoryBySlug(slug) == nil {
+			respondError(w, http.StatusBadRequest, "Unknown category: "+slug)
+			return
+		}
+	}
+	switch prefs.MinSignificance {
+	case models.SignificanceLow, models.SignificanceMedium, models.SignificanceHigh, models.SignificanceBreaking:
+	case "":
+		prefs.MinSignificance = models.SignificanceHigh
+	default:
+		respondError(w, http.StatusBadRequest, "min_significance must be low, medium, high or breaking")
+		return
+	}
+	if prefs.Channels.WebhookURL != "" {
+		if err := alerts.ValidateWebhook(r.Context(), prefs.Channels.WebhookURL); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if q := prefs.QuietHours; q != nil {
+		if q.Start < 0 || q.Start > 23 || q.End < 0 || q.End > 23 {
+			respondError(w, http.StatusBadRequest, "quiet hours must be between 0 and 23")
+			return
+		}
+		if _, err := time.LoadLocation(q.Timezone); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown time zone: "+q.Timezone)
+			return
+		}
+	}
+
+	prefs.UserID = user.ID
+	prefs.Email = user.Email
+	if err := s.store.SaveNotificationPreferences(r.Context(), &prefs); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save preferences")
+		return
+	}
+
+	respondData(w, prefs)
+}
+
+// currentUser resolves the signed-in user or writes an error.
+func (s *Server) currentUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	if !s.requireAccounts(w) {
+		return nil, false
+	}
+
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Not signed in")
+		return nil, false
+	}
+
+	user, err := s.accounts.User(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch user")
+		return nil, false
+	}
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not signed in")
+		return nil, false
+	}
+	return user, true
+}
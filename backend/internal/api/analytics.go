@@ -0,0 +1,157 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/analytics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// trackLimit caps analytics pings per client IP per hour.
+const trackLimit = 600
+
+// SetAnalytics enables analytics tracking.
+func (s *Server) SetAnalytics(w *analytics.Writer) {
+	s.handlers.analytics = w
+}
+
+// trackRequest is a read-depth or outbound-click ping from an article page.
+type trackRequest struct {
+	Type     models.AnalyticsEventType `json:"type"`
+	Slug     string                    `json:"slug"`
+	MarketID string                    `json:"market_id"`
+	Depth    int                       `json:"depth"`
+}
+
+// TrackEvent records a read-depth or outbound-click ping. Page views are
+// recorded when the article is fetched. Bodies are accepted as any content
+// type so browsers can send them with navigator.sendBeacon.
+func (s *Server) TrackEvent(w http.ResponseWriter, r *http.Request) {
+	if s.handlers.analytics == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !s.limiter.Allow("track:"+clientIP(r), trackLimit, time.Hour) {
+		respondError(w, http.StatusTooManyRequests, "Too many events")
+		return
+	}
+
+	var req trackRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 4096)).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch req.Type {
+	case models.AnalyticsReadDepth:
+		if req.Depth <= 0 || req.Depth > 100 {
+			respondError(w, http.StatusBadRequest, "depth must be between 1 and 100")
+			return
+		}
+		req.MarketID = ""
+	case models.AnalyticsOutboundClick:
+		if req.MarketID == "" {
+			respondError(w, http.StatusBadRequest, "market_id is required")
+			return
+		}
+		req.Depth = 0
+	default:
+		respondError(w, http.StatusBadRequest, "type must be read_depth or outbound_click")
+		return
+	}
+
+	article, err := s.store.GetArticleBySlug(r.Context(), req.Slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	s.handlers.analytics.Track(models.AnalyticsEvent{
+		Type:      req.Type,
+		ArticleID: article.ID,
+		Slug:      article.Slug,
+		Category:  article.Category,
+		MarketID:  req.MarketID,
+		Depth:     req.Depth,
+		Session:   analyticsSession(r),
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// analyticsSession identifies the reader behind a ping so read-throughs
+// count readers rather than pings. The session key is hashed because it
+// may be a login session cookie.
+func analyticsSession(r *http.Request) string {
+	sum := sha256.Sum256([]byte(sessionKey(r)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// AdminGetAnalytics returns top articles, click-through to markets and
+// traffic by category from the daily rollups over the last ?days= (default
+// 7).
+func (s *Server) AdminGetAnalytics(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed <= 0 || parsed > 365 {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	ctx := r.Context()
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	top, err := s.store.GetTopArticleAnalytics(ctx, since, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch top articles")
+		return
+	}
+	categories, err := s.store.GetCategoryTraffic(ctx, since)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch category traffic")
+		return
+	}
+	daily, err := s.store.GetDailyTraffic(ctx, since)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch daily traffic")
+		return
+	}
+
+	var views, clicks, reads int
+	for _, d := range daily {
+		views += d.Views
+		clicks += d.Clicks
+		reads += d.ReadThroughs
+	}
+	var ctr float64
+	if views > 0 {
+		ctr = float64(clicks) / float64(views)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"since": since,
+		"totals": map[string]interface{}{
+			"views":         views,
+			"read_throughs": reads,
+			"clicks":        clicks,
+			"ctr":           ctr,
+		},
+		"top_articles": top,
+		"categories":   categories,
+		"daily":        daily,
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/webhooks"
+	"github.com/rs/zerolog/log"
+)
+
+// AdminUnpublishArticle takes an article off the site, leaving it as a
+// draft.
+func (s *Server) AdminUnpublishArticle(w http.ResponseWriter, r *http.Request) {
+	s.changeArticle(w, r, s.store.UnpublishArticle, webhooks.EventArticleUnpublished, "Article unpublished")
+}
+
+// AdminDeleteArticle soft-deletes an article. It stops being served, and
+// can be restored.
+func (s *Server) AdminDeleteArticle(w http.ResponseWriter, r *http.Request) {
+	s.changeArticle(w, r, s.store.DeleteArticle, webhooks.EventArticleDeleted, "Article deleted")
+}
+
+// AdminRestoreArticle undoes an unpublish or delete, republishing the
+// article if it was live.
+func (s *Server) AdminRestoreArticle(w http.ResponseWriter, r *http.Request) {
+	s.changeArticle(w, r, s.store.RestoreArticle, webhooks.EventArticleRestored, "Article restored")
+}
+
+// AdminRestoreMarket takes an archived market out of the archive, back
+// into market lists.
+func (s *Server) AdminRestoreMarket(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	restored, err := s.store.RestoreMarket(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to restore market")
+		return
+	}
+	if !restored {
+		respondError(w, http.StatusNotFound, "Archived market not found")
+		return
+	}
+
+	s.logStatusChange(r, "market", slug, "Market restored")
+	cache.Invalidate(r.Context(), s.handlers.cache, cache.GroupMarkets, cache.GroupCategories, cache.GroupFeed)
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Market restored",
+	})
+}
+
+// changeArticle applies an article status change to the article named by
+// the slug URL parameter, responding 404 if change finds none, and sends
+// the content notifier event.
+func (s *Server) changeArticle(w http.ResponseWriter, r *http.Request, change func(context.Context, string) (bool, error), event, msg string) {
+	slug := chi.URLParam(r, "slug")
+	changed, err := change(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update article")
+		return
+	}
+	if !changed {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	s.logStatusChange(r, "article", slug, msg)
+	cache.Invalidate(r.Context(), s.handlers.cache, cache.GroupCategories, cache.GroupFeed, cache.GroupArticles)
+	if s.contentNotifier != nil {
+		if article, err := s.store.GetArticleBySlug(r.Context(), slug); err != nil {
+			log.Warn().Err(err).Str("slug", slug).Msg("Failed to load article for content notification")
+		} else {
+			s.contentNotifier.NotifyArticle(r.Context(), event, article)
+		}
+	}
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": msg,
+	})
+}
+
+// logStatusChange logs an admin's archive or publication change.
+func (s *Server) logStatusChange(r *http.Request, kind, slug, msg string) {
+	event := log.Info().Str(kind, slug)
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		event = event.Str("by", p.Subject)
+	}
+	event.Msg(msg)
+}
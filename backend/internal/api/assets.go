@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/assets"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// assetCacheControl lets browsers and CDNs keep proxied images for a week;
+// an asset's hash always names the same source image.
+const assetCacheControl = "public, max-age=604800, immutable"
+
+// SetAssets serves market images through the asset proxy.
+func (s *Server) SetAssets(p *assets.Proxy) {
+	s.handlers.assets = p
+}
+
+// proxyImages points the market images in a response at the asset proxy.
+func (h *Handlers) proxyImages(r *http.Request, markets []models.Market) {
+	if h.assets != nil {
+		h.assets.Rewrite(r.Context(), markets)
+	}
+}
+
+// proxyImage is proxyImages for a single market.
+func (h *Handlers) proxyImage(r *http.Request, market *models.Market) {
+	if h.assets != nil && market != nil {
+		markets := []models.Market{*market}
+		h.assets.Rewrite(r.Context(), markets)
+		*market = markets[0]
+	}
+}
+
+// GetAsset serves a proxied market image. ?w= requests a width in pixels,
+// rounded up to the nearest size the proxy renders.
+func (h *Handlers) GetAsset(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+	if h.assets == nil || !validAssetHash(hash) {
+		respondError(w, http.StatusNotFound, "Asset not found")
+		return
+	}
+
+	width := 0
+	if v := r.URL.Query().Get("w"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			respondError(w, http.StatusBadRequest, "w must be a positive integer")
+			return
+		}
+		width = parsed
+	}
+
+	img, err := h.assets.Open(r.Context(), hash, width)
+	if errors.Is(err, assets.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Asset not found")
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("hash", hash).Msg("Failed to serve asset")
+		// Let clients retry soon rather than caching the failure
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		respondError(w, http.StatusBadGateway, "Failed to fetch image")
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Cache-Control", assetCacheControl)
+	w.Header().Set("ETag", `"`+hash+"-"+strconv.Itoa(width)+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(img.Data))
+}
+
+// validAssetHash reports whether hash is shaped like models.AssetHash.
+func validAssetHash(hash string) bool {
+	_, err := hex.DecodeString(hash)
+	return err == nil && len(hash) == 32
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// briefingRequest is the body of briefing create and edit requests. On
+// edit, absent fields keep their current values.
+type briefingRequest struct {
+	Type           *models.BriefingType `json:"type"`
+	Title          *string              `json:"title"`
+	MarketsPerCat  *int                 `json:"markets_per_category"`
+	Categories     *[]string            `json:"categories"`
+	IncludeSummary *bool                `json:"include_summary"`
+	Enabled        *bool                `json:"enabled"`
+	Hour           *int                 `json:"hour"`
+	Minute         *int                 `json:"minute"`
+	Days           *[]int               `json:"days"`
+}
+
+// apply copies the fields present in the request onto cfg. The type is
+// only set on create.
+func (req *briefingRequest) apply(cfg *models.BriefingConfig) {
+	if req.Title != nil {
+		cfg.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.MarketsPerCat != nil {
+		cfg.MarketsPerCat = *req.MarketsPerCat
+	}
+	if req.Categories != nil {
+		cfg.Categories = models.NormalizeKeywords(*req.Categories)
+	}
+	if req.IncludeSummary != nil {
+		cfg.IncludeSummary = *req.IncludeSummary
+	}
+	if req.Enabled != nil {
+		cfg.Enabled = *req.Enabled
+	}
+	if req.Hour != nil {
+		cfg.Hour = *req.Hour
+	}
+	if req.Minute != nil {
+		cfg.Minute = *req.Minute
+	}
+	if req.Days != nil {
+		cfg.Days = *req.Days
+	}
+}
+
+// AdminListBriefings lists the briefing configs in order of their
+// scheduled time.
+func (s *Server) AdminListBriefings(w http.ResponseWriter, r *http.Request) {
+	configs, err := s.store.GetBriefingConfigs(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch briefings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"briefings": configs,
+		"count":     len(configs),
+	})
+}
+
+// AdminGetBriefing returns a briefing config.
+func (s *Server) AdminGetBriefing(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := s.briefingConfig(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, cfg)
+}
+
+// AdminCreateBriefing adds a briefing and schedules it if enabled.
+// Briefings are created enabled unless the request says otherwise.
+func (s *Server) AdminCreateBriefing(w http.ResponseWriter, r *http.Request) {
+	var req briefingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	cfg := &models.BriefingConfig{Enabled: true}
+	if req.Type != nil {
+		cfg.Type = *req.Type
+	}
+	req.apply(cfg)
+	if !s.validBriefingConfig(w, r, cfg) {
+		return
+	}
+
+	err := s.store.CreateBriefingConfig(r.Context(), cfg)
+	if errors.Is(err, storage.ErrBriefingConfigExists) {
+		respondError(w, http.StatusConflict, "Briefing already exists")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create briefing")
+		return
+	}
+
+	s.briefingsChanged(r, cfg.Type, "Briefing created")
+	respondJSON(w, http.StatusCreated, cfg)
+}
+
+// AdminUpdateBriefing changes the fields present in the request body.
+// categories and days, when present, replace the whole list.
+func (s *Server) AdminUpdateBriefing(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := s.briefingConfig(w, r)
+	if !ok {
+		return
+	}
+
+	var req briefingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Type != nil && *req.Type != cfg.Type {
+		respondError(w, http.StatusBadRequest, "type cannot be changed")
+		return
+	}
+
+	req.apply(cfg)
+	if !s.validBriefingConfig(w, r, cfg) {
+		return
+	}
+
+	updated, err := s.store.UpdateBriefingConfig(r.Context(), cfg)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update briefing")
+		return
+	}
+	if !updated {
+		respondError(w, http.StatusNotFound, "Briefing not found")
+		return
+	}
+
+	s.briefingsChanged(r, cfg.Type, "Briefing updated")
+	respondJSON(w, http.StatusOK, cfg)
+}
+
+// AdminDeleteBriefing removes a briefing and unschedules it.
+func (s *Server) AdminDeleteBriefing(w http.ResponseWriter, r *http.Request) {
+	briefingType := models.BriefingType(chi.URLParam(r, "type"))
+	deleted, err := s.store.DeleteBriefingConfig(r.Context(), briefingType)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete briefing")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Briefing not found")
+		return
+	}
+
+	s.briefingsChanged(r, briefingType, "Briefing deleted")
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Briefing deleted",
+	})
+}
+
+// briefingConfig loads the config named by the type URL parameter,
+// responding 404 if there is none.
+func (s *Server) briefingConfig(w http.ResponseWriter, r *http.Request) (*models.BriefingConfig, bool) {
+	cfg, err := s.store.GetBriefingConfig(r.Context(), models.BriefingType(chi.URLParam(r, "type")))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch briefing")
+		return nil, false
+	}
+	if cfg == nil {
+		respondError(w, http.StatusNotFound, "Briefing not found")
+		return nil, false
+	}
+	return cfg, true
+}
+
+// validBriefingConfig checks a config can be scheduled and that its
+// categories exist, responding 400 if not.
+func (s *Server) validBriefingConfig(w http.ResponseWriter, r *http.Request, cfg *models.BriefingConfig) bool {
+	if err := cfg.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	for _, category := range cfg.Categories {
+		if _, err := s.store.GetCategoryBySlug(r.Context(), category); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown category: "+category)
+			return false
+		}
+	}
+	return true
+}
+
+// briefingsChanged logs a briefing change and reschedules the briefing
+// jobs on this instance; others pick the change up on their next reload.
+func (s *Server) briefingsChanged(r *http.Request, briefingType models.BriefingType, msg string) {
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		log.Info().Str("by", p.Subject).Str("briefing", string(briefingType)).Msg(msg)
+	}
+	if s.scheduler == nil {
+		return
+	}
+	if err := s.scheduler.ReloadBriefings(r.Context()); err != nil {
+		log.Warn().Err(err).Msg("Failed to reschedule briefings")
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CacheTTLs sets how long each group of read results is cached. A zero
+// TTL leaves the group uncached.
+type CacheTTLs struct {
+	Feed       time.Duration
+	Markets    time.Duration
+	Categories time.Duration
+	Articles   time.Duration
+}
+
+func (t CacheTTLs) forGroup(group string) time.Duration {
+	switch group {
+	case cache.GroupFeed:
+		return t.Feed
+	case cache.GroupMarkets:
+		return t.Markets
+	case cache.GroupCategories:
+		return t.Categories
+	case cache.GroupArticles:
+		return t.Articles
+	}
+	return 0
+}
+
+// SetCache caches the home feed, market lists, categories and article
+// renderings. Results are cached before per-reader changes such as
+// headline variants are applied.
+func (s *Server) SetCache(c cache.Cache, ttls CacheTTLs) {
+	s.handlers.cache = c
+	s.handlers.cacheTTLs = ttls
+}
+
+// cachedValue wraps a cached result; values are BSON-encoded so fields
+// hidden from JSON, like headline tests, survive the round trip.
+type cachedValue[T any] struct {
+	Value T `bson:"v"`
+}
+
+// cachedRead returns the result cached under key, or loads and caches it.
+// Cache failures fall back to load; load errors are not cached.
+func cachedRead[T any](ctx context.Context, h *Handlers, group, key string, load func() (T, error)) (T, error) {
+	ttl := h.cacheTTLs.forGroup(group)
+	if h.cache == nil || ttl <= 0 {
+		return load()
+	}
+
+	data, ok, err := h.cache.Get(ctx, group, key)
+	switch {
+	case err != nil:
+		log.Warn().Err(err).Str("group", group).Str("key", key).Msg("Failed to read cache")
+		metrics.APICacheLookups.WithLabelValues(group, "error").Inc()
+	case ok:
+		var cached cachedValue[T]
+		if err := bson.Unmarshal(data, &cached); err == nil {
+			metrics.APICacheLookups.WithLabelValues(group, "hit").Inc()
+			return cached.Value, nil
+		}
+		metrics.APICacheLookups.WithLabelValues(group, "error").Inc()
+	default:
+		metrics.APICacheLookups.WithLabelValues(group, "miss").Inc()
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err = bson.Marshal(cachedValue[T]{Value: value})
+	if err != nil {
+		log.Warn().Err(err).Str("group", group).Msg("Failed to encode cache entry")
+		return value, nil
+	}
+	if err := h.cache.Set(ctx, group, key, data, ttl); err != nil {
+		log.Warn().Err(err).Str("group", group).Str("key", key).Msg("Failed to write cache")
+	}
+	return value, nil
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// calendarRequest is the body of calendar create and edit requests. On
+// edit, absent fields keep their current values.
+type calendarRequest struct {
+	Type         *models.ArticleType  `json:"type"`
+	Market       *string              `json:"market"` // Market slug
+	Category     *string              `json:"category"`
+	BriefingType *models.BriefingType `json:"briefing_type"`
+	Notes        *string              `json:"notes"`
+	RunAt        *time.Time           `json:"run_at"`
+}
+
+// apply copies the fields present in the request onto item.
+func (req *calendarRequest) apply(item *models.ScheduledContent) {
+	if req.Type != nil {
+		item.Type = *req.Type
+	}
+	if req.Market != nil {
+		item.MarketSlug = strings.TrimSpace(*req.Market)
+	}
+	if req.Category != nil {
+		item.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.BriefingType != nil {
+		item.BriefingType = *req.BriefingType
+	}
+	if req.Notes != nil {
+		item.Notes = *req.Notes
+	}
+	if req.RunAt != nil {
+		item.RunAt = req.RunAt.UTC()
+	}
+}
+
+// AdminListCalendar lists content calendar entries in run order, optionally
+// filtered by ?status= and a ?from=/?to= (RFC 3339) window on their run time.
+func (s *Server) AdminListCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	status := models.ScheduledContentStatus(q.Get("status"))
+	switch status {
+	case "", models.ScheduledPending, models.ScheduledRunning, models.ScheduledDone,
+		models.ScheduledFailed, models.ScheduledCanceled:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, running, done, failed or canceled")
+		return
+	}
+
+	var from, to time.Time
+	for _, bound := range []struct {
+		name string
+		t    *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := q.Get(bound.name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, bound.name+" must be an RFC 3339 time")
+				return
+			}
+			*bound.t = parsed
+		}
+	}
+
+	items, err := s.store.ListScheduledContent(r.Context(), status, from, to, getLimit(r, 50))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch calendar")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries": items,
+		"count":   len(items),
+	})
+}
+
+// AdminGetCalendarEntry returns a content calendar entry.
+func (s *Server) AdminGetCalendarEntry(w http.ResponseWriter, r *http.Request) {
+	item, ok := s.calendarEntry(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, item)
+}
+
+// AdminCreateCalendarEntry schedules a one-off article generation.
+func (s *Server) AdminCreateCalendarEntry(w http.ResponseWriter, r *http.Request) {
+	var req calendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item := &models.ScheduledContent{}
+	req.apply(item)
+	if !s.validCalendarEntry(w, r, item) {
+		return
+	}
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		item.CreatedBy = p.Subject
+	}
+
+	if err := s.store.CreateScheduledContent(r.Context(), item); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to schedule content")
+		return
+	}
+
+	log.Info().
+		Str("id", item.ID.Hex()).
+		Str("type", string(item.Type)).
+		Time("run_at", item.RunAt).
+		Str("by", item.CreatedBy).
+		Msg("Content scheduled")
+	respondJSON(w, http.StatusCreated, item)
+}
+
+// AdminUpdateCalendarEntry edits a pending content calendar entry.
+func (s *Server) AdminUpdateCalendarEntry(w http.ResponseWriter, r *http.Request) {
+	item, ok := s.calendarEntry(w, r)
+	if !ok {
+		return
+	}
+
+	var req calendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if item.Status != models.ScheduledPending {
+		respondError(w, http.StatusConflict, "Only pending entries can be edited")
+		return
+	}
+
+	req.apply(item)
+	if !s.validCalendarEntry(w, r, item) {
+		return
+	}
+
+	updated, err := s.store.UpdateScheduledContent(r.Context(), item)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update calendar entry")
+		return
+	}
+	if updated == nil {
+		respondError(w, http.StatusConflict, "Only pending entries can be edited")
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// AdminCancelCalendarEntry cancels a pending content calendar entry.
+func (s *Server) AdminCancelCalendarEntry(w http.ResponseWriter, r *http.Request) {
+	item, ok := s.calendarEntry(w, r)
+	if !ok {
+		return
+	}
+
+	canceled, err := s.store.CancelScheduledContent(r.Context(), item.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to cancel calendar entry")
+		return
+	}
+	if !canceled {
+		respondError(w, http.StatusConflict, "Only pending entries can be canceled")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Calendar entry canceled",
+	})
+}
+
+// calendarEntry loads the entry named by the id URL parameter, responding
+// 404 if there is none.
+func (s *Server) calendarEntry(w http.ResponseWriter, r *http.Request) (*models.ScheduledContent, bool) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Calendar entry not found")
+		return nil, false
+	}
+	item, err := s.store.GetScheduledContent(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch calendar entry")
+		return nil, false
+	}
+	if item == nil {
+		respondError(w, http.StatusNotFound, "Calendar entry not found")
+		return nil, false
+	}
+	return item, true
+}
+
+// validCalendarEntry checks an entry can be generated and is scheduled in
+// the future, responding 400 if not. Inputs that don't apply to the entry's
+// type are cleared.
+func (s *Server) validCalendarEntry(w http.ResponseWriter, r *http.Request, item *models.ScheduledContent) bool {
+	if err := item.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if item.RunAt.Before(time.Now().Add(-time.Minute)) {
+		respondError(w, http.StatusBadRequest, "run_at must be in the future")
+		return false
+	}
+
+	switch item.Type {
+	case models.ArticleTypeBreaking, models.ArticleTypeNewMarket, models.ArticleTypeResolution:
+		item.Category, item.BriefingType = "", ""
+		if _, err := s.store.GetMarketBySlug(r.Context(), item.MarketSlug); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown market: "+item.MarketSlug)
+			return false
+		}
+	case models.ArticleTypeDigest:
+		item.MarketSlug, item.BriefingType = "", ""
+		if _, err := s.store.GetCategoryBySlug(r.Context(), item.Category); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown category: "+item.Category)
+			return false
+		}
+	case models.ArticleTypeBriefing:
+		item.MarketSlug, item.Category = "", ""
+		cfg, err := s.store.GetBriefingConfig(r.Context(), item.BriefingType)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch briefing")
+			return false
+		}
+		if cfg == nil {
+			respondError(w, http.StatusBadRequest, "Unknown briefing type: "+string(item.BriefingType))
+			return false
+		}
+	default:
+		item.MarketSlug, item.Category, item.BriefingType = "", "", ""
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// categorySlugPattern matches slugs usable in /category/{slug} URLs.
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// AdminCreateCategory adds a static category to the taxonomy. New markets
+// are assigned to it once one of its keywords matches their question.
+func (s *Server) AdminCreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Slug        string   `json:"slug"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Icon        string   `json:"icon"`
+		Color       string   `json:"color"`
+		Order       int      `json:"order"`
+		Keywords    []string `json:"keywords"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !categorySlugPattern.MatchString(req.Slug) {
+		respondError(w, http.StatusBadRequest, "slug must be lowercase letters, digits and dashes")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	category := &models.Category{
+		Slug:        req.Slug,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Icon:        req.Icon,
+		Color:       req.Color,
+		Order:       req.Order,
+		Keywords:    models.NormalizeKeywords(req.Keywords),
+	}
+	err := s.store.CreateCategory(r.Context(), category)
+	if errors.Is(err, storage.ErrCategoryExists) {
+		respondError(w, http.StatusConflict, "Category already exists")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create category")
+		return
+	}
+
+	s.categoryChanged(r, category.Slug, "Category created")
+	respondJSON(w, http.StatusCreated, category)
+}
+
+// AdminUpdateCategory changes the fields present in the request body.
+// keywords, when present, replaces the category's whole keyword list.
+func (s *Server) AdminUpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name        *string   `json:"name"`
+		Description *string   `json:"description"`
+		Icon        *string   `json:"icon"`
+		Color       *string   `json:"color"`
+		Order       *int      `json:"order"`
+		Keywords    *[]string `json:"keywords"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		respondError(w, http.StatusBadRequest, "name must not be empty")
+		return
+	}
+
+	update := storage.CategoryUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Color:       req.Color,
+		Order:       req.Order,
+	}
+	if req.Keywords != nil {
+		update.Keywords = models.NormalizeKeywords(*req.Keywords)
+	}
+
+	slug := chi.URLParam(r, "slug")
+	category, err := s.store.UpdateCategory(r.Context(), slug, update)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update category")
+		return
+	}
+	if category == nil {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	s.categoryChanged(r, slug, "Category updated")
+	respondJSON(w, http.StatusOK, category)
+}
+
+// AdminAddCategoryKeywords adds keywords to a category.
+func (s *Server) AdminAddCategoryKeywords(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Keywords []string `json:"keywords"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	keywords := models.NormalizeKeywords(req.Keywords)
+	if len(keywords) == 0 {
+		respondError(w, http.StatusBadRequest, "at least one keyword is required")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	category, err := s.store.AddCategoryKeywords(r.Context(), slug, keywords)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to add keywords")
+		return
+	}
+	if category == nil {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	s.categoryChanged(r, slug, "Category keywords added")
+	respondJSON(w, http.StatusOK, category)
+}
+
+// AdminRemoveCategoryKeyword removes one keyword from a category.
+func (s *Server) AdminRemoveCategoryKeyword(w http.ResponseWriter, r *http.Request) {
+	keyword := strings.ToLower(strings.TrimSpace(chi.URLParam(r, "keyword")))
+	if keyword == "" {
+		respondError(w, http.StatusBadRequest, "keyword is required")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	category, err := s.store.RemoveCategoryKeyword(r.Context(), slug, keyword)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to remove keyword")
+		return
+	}
+	if category == nil {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+
+	s.categoryChanged(r, slug, "Category keyword removed")
+	respondJSON(w, http.StatusOK, category)
+}
+
+// categoryChanged logs a taxonomy edit and drops the cached categories.
+func (s *Server) categoryChanged(r *http.Request, slug, msg string) {
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		log.Info().Str("by", p.Subject).Str("category", slug).Msg(msg)
+	}
+	cache.Invalidate(r.Context(), s.handlers.cache, cache.GroupCategories)
+}
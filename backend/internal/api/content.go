@@ -0,0 +1,443 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// The content delivery API (/api/v1/content) serves articles and markets
+// to partner sites and headless frontends in schemas of their own, so the
+// stored models can change without breaking syndication. Within v1,
+// fields are only ever added; renaming or removing one takes a v2.
+
+const (
+	// previewIssuer is the issuer of draft preview tokens.
+	previewIssuer = "futuresignals-preview"
+
+	// defaultPreviewTTL is how long preview tokens last when
+	// ServerConfig.ContentPreviewTTL is unset.
+	defaultPreviewTTL = 24 * time.Hour
+)
+
+// Article statuses in the delivery schema.
+const (
+	contentStatusPublished = "published"
+	contentStatusDraft     = "draft"
+)
+
+// ContentNotifier is told when an admin changes an article's publication,
+// with one of the webhooks event names.
+type ContentNotifier interface {
+	NotifyArticle(ctx context.Context, event string, article *models.Article)
+}
+
+// SetContentNotifier sends content change notifications for admin
+// unpublish, delete and restore actions.
+func (s *Server) SetContentNotifier(n ContentNotifier) {
+	s.contentNotifier = n
+}
+
+// contentArticle is the v1 delivery schema of an article.
+type contentArticle struct {
+	ID          string             `json:"id"`
+	Slug        string             `json:"slug"`
+	URL         string             `json:"url"`
+	Status      string             `json:"status"` // published or draft
+	Type        string             `json:"type"`
+	Category    string             `json:"category"`
+	Headline    string             `json:"headline"`
+	Subheadline string             `json:"subheadline"`
+	Summary     string             `json:"summary"`
+	Body        contentArticleBody `json:"body"`
+	Tags        []string           `json:"tags"`
+
+	// Condensed variants, when generated
+	ShortForm *contentShortForm `json:"short_form,omitempty"`
+
+	// low, medium, high or breaking; bullish, bearish or neutral
+	Significance string `json:"significance"`
+	Sentiment    string `json:"sentiment"`
+
+	// Markets covered, primary market first, as of the latest amendment
+	Markets    []contentMarketRef `json:"markets"`
+	Amendments []contentAmendment `json:"amendments"`
+
+	ImageURL        string `json:"image_url,omitempty"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+
+	PublishedAt time.Time `json:"published_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// contentArticleBody is an article's text, section by section.
+type contentArticleBody struct {
+	WhatHappened string   `json:"what_happened"`
+	WhyItMatters string   `json:"why_it_matters"`
+	Context      []string `json:"context"`
+	WhatToWatch  string   `json:"what_to_watch"`
+	Analysis     string   `json:"analysis,omitempty"`
+}
+
+// contentShortForm is an article's social blurb, TL;DR bullets and push
+// notification line.
+type contentShortForm struct {
+	Social string   `json:"social"`
+	TLDR   []string `json:"tldr"`
+	Push   string   `json:"push"`
+}
+
+// contentMarketRef is a market an article covers.
+type contentMarketRef struct {
+	ID          string  `json:"id"`
+	Slug        string  `json:"slug"`
+	Question    string  `json:"question"`
+	Probability float64 `json:"probability"`
+	Change24h   float64 `json:"change_24h"`
+	Volume24h   float64 `json:"volume_24h"`
+}
+
+// contentAmendment is an update appended to a published article.
+type contentAmendment struct {
+	Headline     string    `json:"headline"`
+	WhatChanged  string    `json:"what_changed"`
+	PreviousProb float64   `json:"previous_probability"`
+	Probability  float64   `json:"probability"`
+	AmendedAt    time.Time `json:"amended_at"`
+}
+
+// contentMarket is the v1 delivery schema of a market.
+type contentMarket struct {
+	ID          string           `json:"id"`
+	Slug        string           `json:"slug"`
+	URL         string           `json:"url"`
+	Status      string           `json:"status"` // open, closed or resolved
+	Question    string           `json:"question"`
+	Description string           `json:"description"`
+	Category    string           `json:"category"`
+	Tags        []string         `json:"tags"`
+	ImageURL    string           `json:"image_url,omitempty"`
+	Outcomes    []contentOutcome `json:"outcomes"`
+
+	// Yes price, its 24h change, and volume in USD
+	Probability float64 `json:"probability"`
+	Change24h   float64 `json:"change_24h"`
+	Volume24h   float64 `json:"volume_24h"`
+	TotalVolume float64 `json:"total_volume"`
+	Liquidity   float64 `json:"liquidity"`
+
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	WinningOutcome string     `json:"winning_outcome,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// contentOutcome is one of a market's outcomes and its price.
+type contentOutcome struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// previewTokenResponse is a minted draft preview link.
+type previewTokenResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// newContentArticle converts an article to the delivery schema.
+func newContentArticle(article *models.Article, siteURL string) contentArticle {
+	c := contentArticle{
+		ID:          article.ID.Hex(),
+		Slug:        article.Slug,
+		URL:         siteURL + "/article/" + article.Slug,
+		Status:      contentStatusPublished,
+		Type:        string(article.Type),
+		Category:    article.Category,
+		Headline:    article.Headline,
+		Subheadline: article.Subheadline,
+		Summary:     article.Summary,
+		Body: contentArticleBody{
+			WhatHappened: article.Body.WhatHappened,
+			WhyItMatters: article.Body.WhyItMatters,
+			Context:      article.Body.Context,
+			WhatToWatch:  article.Body.WhatToWatch,
+			Analysis:     article.Body.Analysis,
+		},
+		Tags:            article.Tags,
+		Significance:    string(article.Significance),
+		Sentiment:       article.Sentiment,
+		Markets:         []contentMarketRef{},
+		Amendments:      []contentAmendment{},
+		ImageURL:        article.OGImageURL,
+		MetaTitle:       article.MetaTitle,
+		MetaDescription: article.MetaDescription,
+		PublishedAt:     article.PublishedAt,
+		UpdatedAt:       article.UpdatedAt,
+	}
+	if !article.Published {
+		c.Status = contentStatusDraft
+	}
+	if c.Tags == nil {
+		c.Tags = []string{}
+	}
+	if c.Body.Context == nil {
+		c.Body.Context = []string{}
+	}
+	if s := article.ShortForm; s != nil {
+		c.ShortForm = &contentShortForm{Social: s.Social, TLDR: s.TLDR, Push: s.Push}
+		if c.ShortForm.TLDR == nil {
+			c.ShortForm.TLDR = []string{}
+		}
+	}
+
+	refs := article.Markets
+	if article.PrimaryMarket != nil {
+		refs = append([]models.MarketRef{*article.PrimaryMarket}, refs...)
+	}
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		if seen[ref.MarketID] {
+			continue
+		}
+		seen[ref.MarketID] = true
+		c.Markets = append(c.Markets, contentMarketRef{
+			ID:          ref.MarketID,
+			Slug:        ref.Slug,
+			Question:    ref.Question,
+			Probability: ref.Probability,
+			Change24h:   ref.Change24h,
+			Volume24h:   ref.Volume24h,
+		})
+	}
+
+	for _, a := range article.Amendments {
+		c.Amendments = append(c.Amendments, contentAmendment{
+			Headline:     a.Headline,
+			WhatChanged:  a.WhatChanged,
+			PreviousProb: a.PreviousProb,
+			Probability:  a.Probability,
+			AmendedAt:    a.AmendedAt,
+		})
+	}
+	return c
+}
+
+// newContentMarket converts a market to the delivery schema.
+func newContentMarket(market *models.Market, siteURL string) contentMarket {
+	c := contentMarket{
+		ID:             market.MarketID,
+		Slug:           market.Slug,
+		URL:            siteURL + "/market/" + market.Slug,
+		Question:       market.Question,
+		Description:    market.Description,
+		Category:       market.Category,
+		Tags:           market.Tags,
+		ImageURL:       market.Image,
+		Outcomes:       []contentOutcome{},
+		Probability:    market.Probability,
+		Change24h:      market.Change24h,
+		Volume24h:      market.Volume24h,
+		TotalVolume:    market.TotalVolume,
+		Liquidity:      market.Liquidity,
+		EndDate:        market.EndDateTS,
+		WinningOutcome: market.WinningOutcome,
+		ResolvedAt:     market.ResolvedAt,
+		UpdatedAt:      market.UpdatedAt,
+	}
+	switch {
+	case market.Resolved:
+		c.Status = "resolved"
+	case market.Closed || !market.Active:
+		c.Status = "closed"
+	default:
+		c.Status = "open"
+	}
+	if c.Tags == nil {
+		c.Tags = []string{}
+	}
+	for i, name := range market.Outcomes {
+		outcome := contentOutcome{Name: name}
+		if i < len(market.OutcomePrices) {
+			outcome.Price = market.OutcomePrices[i]
+		}
+		c.Outcomes = append(c.Outcomes, outcome)
+	}
+	return c
+}
+
+// contentOffset reads the offset query parameter, validated by the spec.
+func contentOffset(r *http.Request) int {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	return offset
+}
+
+// GetContentArticles lists published articles, newest first. Taken down
+// articles aren't listed, even with updated_since; partners learn of them
+// from the content webhooks.
+func (s *Server) GetContentArticles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := storage.ArticleQuery{
+		Type:     models.ArticleType(query.Get("type")),
+		Category: query.Get("category"),
+		Offset:   contentOffset(r),
+		Limit:    getLimit(r, 20),
+	}
+	if since := query.Get("updated_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "updated_since must be an RFC 3339 time")
+			return
+		}
+		q.UpdatedSince = t
+	}
+
+	articles, err := s.store.ListArticles(r.Context(), q)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+
+	items := make([]contentArticle, len(articles))
+	for i := range articles {
+		items[i] = newContentArticle(&articles[i], s.siteURL)
+	}
+	respondList(w, items, Meta{Type: query.Get("type"), Category: q.Category})
+}
+
+// GetContentArticle returns a published article. With a valid preview
+// token for its slug, drafts are returned too.
+func (s *Server) GetContentArticle(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	preview := false
+	if token := r.URL.Query().Get("preview"); token != "" {
+		if !s.validPreviewToken(token, slug) {
+			respondError(w, http.StatusUnauthorized, "Invalid or expired preview token")
+			return
+		}
+		preview = true
+	}
+
+	article, err := s.store.GetArticleBySlug(r.Context(), slug)
+	if err != nil || article.DeletedAt != nil || (!article.Published && !preview) {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+	respondData(w, newContentArticle(article, s.siteURL))
+}
+
+// GetContentMarkets lists open markets by 24h volume.
+func (s *Server) GetContentMarkets(w http.ResponseWriter, r *http.Request) {
+	q := storage.MarketQuery{
+		Sort:     storage.MarketSortVolume,
+		Category: r.URL.Query().Get("category"),
+		Offset:   contentOffset(r),
+		Limit:    getLimit(r, 20),
+	}
+	markets, err := s.store.ListMarkets(r.Context(), q)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+
+	items := make([]contentMarket, len(markets))
+	for i := range markets {
+		s.handlers.proxyImage(r, &markets[i])
+		items[i] = newContentMarket(&markets[i], s.siteURL)
+	}
+	respondList(w, items, Meta{Sort: q.Sort, Category: q.Category})
+}
+
+// GetContentMarket returns a market.
+func (s *Server) GetContentMarket(w http.ResponseWriter, r *http.Request) {
+	market, err := s.store.GetMarketBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+	s.handlers.proxyImage(r, market)
+	respondData(w, newContentMarket(market, s.siteURL))
+}
+
+// AdminCreatePreviewToken mints a token to read an article, published or
+// not, through the content API until it expires.
+func (s *Server) AdminCreatePreviewToken(w http.ResponseWriter, r *http.Request) {
+	if s.previewSecret == "" {
+		respondError(w, http.StatusServiceUnavailable, "Draft previews not available")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	article, err := s.store.GetArticleBySlug(r.Context(), slug)
+	if err != nil || article.DeletedAt != nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	ttl := s.previewTTL
+	var req struct {
+		TTL string `json:"ttl"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	if req.TTL != "" {
+		parsed, err := time.ParseDuration(req.TTL)
+		if err != nil || parsed <= 0 || parsed > s.previewTTL {
+			respondError(w, http.StatusBadRequest, "ttl must be a positive duration no longer than "+s.previewTTL.String())
+			return
+		}
+		ttl = parsed
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    previewIssuer,
+		Subject:   slug,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString([]byte(s.previewSecret))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create preview token")
+		return
+	}
+
+	s.logStatusChange(r, "article", slug, "Preview token created")
+	respondJSON(w, http.StatusCreated, previewTokenResponse{
+		Token:     token,
+		URL:       "/api/v1/content/articles/" + slug + "?preview=" + token,
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
+
+// validPreviewToken reports whether token is an unexpired preview token
+// for slug.
+func (s *Server) validPreviewToken(token, slug string) bool {
+	if s.previewSecret == "" {
+		return false
+	}
+
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(s.previewSecret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired(), jwt.WithIssuer(previewIssuer), jwt.WithSubject(slug))
+	if err != nil {
+		log.Debug().Err(err).Str("slug", slug).Msg("Rejected preview token")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package api
+
+import "net/http"
+
+// Envelope is the body of public API responses: the payload in data, list
+// metadata in meta, and error instead of data when the request failed.
+type Envelope[T any] struct {
+	Data  *T        `json:"data,omitempty"`
+	Meta  *Meta     `json:"meta,omitempty"`
+	Error *APIError `json:"error,omitempty"`
+}
+
+// Meta describes a list payload: its length and the filters that selected
+// it.
+type Meta struct {
+	Count    int    `json:"count"`
+	Sort     string `json:"sort,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Category string `json:"category,omitempty"`
+	Window   string `json:"window,omitempty"`
+	MarketID string `json:"market_id,omitempty"`
+	Days     int    `json:"days,omitempty"`
+}
+
+// APIError is a failed request's status code and message.
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// respondData writes data in an envelope.
+func respondData[T any](w http.ResponseWriter, data T) {
+	respondDataStatus(w, http.StatusOK, data)
+}
+
+// respondDataStatus writes data in an envelope with the given status, e.g.
+// 201 Created.
+func respondDataStatus[T any](w http.ResponseWriter, status int, data T) {
+	respondJSON(w, status, Envelope[T]{Data: &data})
+}
+
+// statusMessage is the payload of requests that accept work for later,
+// such as sending an email.
+type statusMessage struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// respondList writes items in an envelope, with meta counting them. A nil
+// list is written as [].
+func respondList[T any](w http.ResponseWriter, items []T, meta Meta) {
+	if items == nil {
+		items = []T{}
+	}
+	meta.Count = len(items)
+	respondJSON(w, http.StatusOK, Envelope[[]T]{Data: &items, Meta: &meta})
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// articleCacheControl makes clients and CDNs revalidate articles on
+	// every request: responses carry per-reader headline variants, and
+	// signed-in reads are recorded.
+	articleCacheControl = "private, no-cache"
+
+	// defaultMarketMaxAge is how long market responses may be reused when
+	// ServerConfig.MarketMaxAge is unset.
+	defaultMarketMaxAge = 15 * time.Second
+)
+
+// marketCacheControl lets clients and CDNs reuse market responses, which
+// are the same for every reader, for maxAge.
+func marketCacheControl(maxAge time.Duration) string {
+	return fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+}
+
+// conditionalGET tags successful responses with an ETag hashed from the
+// body and the given Cache-Control, answering a matching If-None-Match
+// with 304 Not Modified instead of the body.
+func conditionalGET(cacheControl string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(bw, r)
+
+			if bw.status != http.StatusOK {
+				w.WriteHeader(bw.status)
+				w.Write(bw.body.Bytes())
+				return
+			}
+
+			sum := sha256.Sum256(bw.body.Bytes())
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+			w.Header().Set("Cache-Control", cacheControl)
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(bw.body.Bytes()))
+		})
+	}
+}
+
+// bufferedResponse holds a handler's response so it can be hashed before
+// anything is sent.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wroteHeader {
+		b.status, b.wroteHeader = status, true
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
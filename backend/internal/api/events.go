@@ -0,0 +1,177 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// dashboardDefaultDays and dashboardMaxDays bound the ?days= window of
+	// an event dashboard's probability history.
+	dashboardDefaultDays = 30
+	dashboardMaxDays     = 90
+
+	// dashboardMovers and dashboardArticles cap the movers and articles on
+	// an event dashboard.
+	dashboardMovers   = 5
+	dashboardArticles = 20
+)
+
+// GetEventBySlug returns a Polymarket event with its synced markets, most
+// likely outcome first, for "Who will win?" style pages.
+func (h *Handlers) GetEventBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		respondError(w, http.StatusBadRequest, "Slug is required")
+		return
+	}
+
+	event, err := h.store.GetPolymarketEventBySlug(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Event not found")
+		return
+	}
+	markets, err := h.store.GetEventMarkets(r.Context(), event.EventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+	h.proxyImages(r, markets)
+
+	respondData(w, eventResponse{Event: event, Markets: markets})
+}
+
+// eventResponse is an event with its markets.
+type eventResponse struct {
+	Event   *models.PolymarketEvent `json:"event"`
+	Markets []models.Market         `json:"markets"`
+}
+
+// GetEventDashboard returns the data for an event dashboard, such as an
+// election page: each candidate market (up to ?limit=, default 20, most
+// likely first) with its probability history over the last ?days=
+// (default 30, at most 90), the candidates that moved most over the past
+// day, and the latest articles covering any of them.
+func (h *Handlers) GetEventDashboard(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	limit := getLimit(r, 20)
+
+	days := dashboardDefaultDays
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > dashboardMaxDays {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = parsed
+	}
+
+	ctx := r.Context()
+	key := "dashboard:" + slug + ":" + strconv.Itoa(days) + ":" + strconv.Itoa(limit)
+	dashboard, err := cachedRead(ctx, h, cache.GroupMarkets, key, func() (eventDashboard, error) {
+		event, err := h.store.GetPolymarketEventBySlug(ctx, slug)
+		if err != nil {
+			return eventDashboard{}, err
+		}
+		markets, err := h.store.GetEventMarkets(ctx, event.EventID)
+		if err != nil {
+			return eventDashboard{}, err
+		}
+		markets = markets[:min(len(markets), limit)]
+		h.proxyImages(r, markets)
+
+		window := time.Duration(days) * 24 * time.Hour
+		candidates := make([]eventCandidate, 0, len(markets))
+		ids := make([]string, 0, len(markets))
+		for _, m := range markets {
+			snapshots, err := h.store.GetSnapshots(ctx, m.MarketID, window)
+			if err != nil {
+				return eventDashboard{}, err
+			}
+			history := make([]models.ProbabilityPoint, 0, len(snapshots)+1)
+			for _, s := range slices.Backward(snapshots) {
+				history = append(history, models.ProbabilityPoint{Time: s.CapturedAt, Probability: s.Probability, Volume24h: s.Volume24h})
+			}
+			// Rollups trail the live price; end on the latest sync
+			history = append(history, models.ProbabilityPoint{Time: m.UpdatedAt, Probability: m.Probability, Volume24h: m.Volume24h})
+
+			candidates = append(candidates, eventCandidate{Market: m, History: history})
+			ids = append(ids, m.MarketID)
+		}
+
+		articles, err := h.store.GetArticlesByMarketIDs(ctx, ids, dashboardArticles)
+		if err != nil {
+			return eventDashboard{}, err
+		}
+
+		return eventDashboard{
+			Event:      event,
+			Candidates: candidates,
+			Movers:     eventMovers(markets),
+			Articles:   articles,
+		}, nil
+	})
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		respondError(w, http.StatusNotFound, "Event not found")
+		return
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to build event dashboard")
+		return
+	}
+	h.serveHeadlines(r, dashboard.Articles)
+
+	respondData(w, dashboard)
+}
+
+// eventMovers returns the markets with the largest 24h moves, biggest
+// first, leaving out ones that didn't move.
+func eventMovers(markets []models.Market) []models.MarketRef {
+	moved := make([]models.Market, 0, len(markets))
+	for _, m := range markets {
+		if m.Change24h != 0 {
+			moved = append(moved, m)
+		}
+	}
+	sort.SliceStable(moved, func(i, j int) bool { return math.Abs(moved[i].Change24h) > math.Abs(moved[j].Change24h) })
+
+	movers := make([]models.MarketRef, 0, dashboardMovers)
+	for _, m := range moved[:min(len(moved), dashboardMovers)] {
+		movers = append(movers, models.MarketRef{
+			MarketID:    m.MarketID,
+			Question:    m.Question,
+			Slug:        m.Slug,
+			Probability: m.Probability,
+			Change24h:   m.Change24h,
+			Volume24h:   m.Volume24h,
+			TotalVolume: m.TotalVolume,
+		})
+	}
+	return movers
+}
+
+// eventDashboard is an event with its candidates' price history, top
+// movers and coverage.
+type eventDashboard struct {
+	Event      *models.PolymarketEvent `json:"event" bson:"event"`
+	Candidates []eventCandidate        `json:"candidates" bson:"candidates"`
+	Movers     []models.MarketRef      `json:"movers" bson:"movers"`
+	Articles   []models.Article        `json:"articles" bson:"articles"`
+}
+
+// eventCandidate is one of an event's markets with its probability
+// history, oldest first.
+type eventCandidate struct {
+	Market  models.Market             `json:"market" bson:"market"`
+	History []models.ProbabilityPoint `json:"history" bson:"history"`
+}
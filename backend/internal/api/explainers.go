@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// explainerRequest is the body of explainer topic create requests.
+type explainerRequest struct {
+	Title    string `json:"title"`
+	Query    string `json:"query"`
+	Category string `json:"category"`
+	Priority int    `json:"priority"`
+	Notes    string `json:"notes"`
+}
+
+// AdminListExplainers lists explainer topics in queue order, optionally
+// filtered by ?status=.
+func (s *Server) AdminListExplainers(w http.ResponseWriter, r *http.Request) {
+	status := models.ExplainerStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", models.ExplainerQueued, models.ExplainerRunning, models.ExplainerPublished, models.ExplainerFailed:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be queued, running, published or failed")
+		return
+	}
+
+	topics, err := s.store.ListExplainerTopics(r.Context(), status, getLimit(r, 50))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch explainer topics")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"topics": topics,
+		"count":  len(topics),
+	})
+}
+
+// AdminGetExplainer returns an explainer topic.
+func (s *Server) AdminGetExplainer(w http.ResponseWriter, r *http.Request) {
+	topic, ok := s.explainerTopic(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, topic)
+}
+
+// AdminCreateExplainer queues an explainer topic.
+func (s *Server) AdminCreateExplainer(w http.ResponseWriter, r *http.Request) {
+	var req explainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	topic := &models.ExplainerTopic{
+		Title:    strings.TrimSpace(req.Title),
+		Query:    strings.TrimSpace(req.Query),
+		Category: strings.TrimSpace(req.Category),
+		Priority: req.Priority,
+		Notes:    req.Notes,
+	}
+	topic.ID = models.TagSlug(topic.Title)
+	if err := topic.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if topic.Category != "" {
+		if _, err := s.store.GetCategoryBySlug(r.Context(), topic.Category); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown category: "+topic.Category)
+			return
+		}
+	}
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		topic.CreatedBy = p.Subject
+	}
+
+	err := s.store.CreateExplainerTopic(r.Context(), topic)
+	if errors.Is(err, storage.ErrExplainerTopicExists) {
+		respondError(w, http.StatusConflict, "Explainer topic already exists")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to queue explainer topic")
+		return
+	}
+
+	log.Info().
+		Str("topic", topic.ID).
+		Int("priority", topic.Priority).
+		Str("by", topic.CreatedBy).
+		Msg("Explainer topic queued")
+	respondJSON(w, http.StatusCreated, topic)
+}
+
+// AdminRequeueExplainer queues a failed explainer topic again.
+func (s *Server) AdminRequeueExplainer(w http.ResponseWriter, r *http.Request) {
+	topic, ok := s.explainerTopic(w, r)
+	if !ok {
+		return
+	}
+
+	requeued, err := s.store.RequeueExplainerTopic(r.Context(), topic.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to requeue explainer topic")
+		return
+	}
+	if !requeued {
+		respondError(w, http.StatusConflict, "Only failed topics can be requeued")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Explainer topic requeued",
+	})
+}
+
+// AdminDeleteExplainer removes an explainer topic that isn't running. A
+// published explainer's article is kept.
+func (s *Server) AdminDeleteExplainer(w http.ResponseWriter, r *http.Request) {
+	topic, ok := s.explainerTopic(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := s.store.DeleteExplainerTopic(r.Context(), topic.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete explainer topic")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusConflict, "Running topics can't be deleted")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Explainer topic deleted",
+	})
+}
+
+// explainerTopic loads the topic named by the id URL parameter, responding
+// 404 if there is none.
+func (s *Server) explainerTopic(w http.ResponseWriter, r *http.Request) (*models.ExplainerTopic, bool) {
+	topic, err := s.store.GetExplainerTopic(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch explainer topic")
+		return nil, false
+	}
+	if topic == nil {
+		respondError(w, http.StatusNotFound, "Explainer topic not found")
+		return nil, false
+	}
+	return topic, true
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/ranking"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// feedCandidates is how many recent articles are ranked per request.
+	feedCandidates = 100
+
+	// feedViewHistory is how many recent views feed a reader's affinities.
+	feedViewHistory = 200
+
+	// feedSize is how many ranked articles are returned.
+	feedSize = 20
+)
+
+// GetPersonalizedFeed returns the home feed with its recent articles ranked
+// by the signed-in user's category affinities, derived from the articles
+// they have read and the markets on their watchlists. Anonymous callers get
+// the generic home feed.
+func (s *Server) GetPersonalizedFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	feed := personalizedFeedResponse{homeFeedResponse: s.handlers.homeFeed(r)}
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	id, err := primitive.ObjectIDFromHex(userID)
+	if !ok || err != nil {
+		respondData(w, feed)
+		return
+	}
+
+	var sig ranking.Signals
+	sig.Views, err = s.store.GetArticleViews(ctx, id, feedViewHistory)
+	if err != nil {
+		log.Warn().Err(err).Str("user", userID).Msg("Failed to load article views")
+	}
+	sig.Watched = s.watchedMarkets(r, models.UserOwner(userID))
+
+	candidates, err := s.store.GetRecentArticles(ctx, feedCandidates)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(sig.Views))
+	for _, v := range sig.Views {
+		seen[v.ArticleID] = true
+	}
+
+	now := time.Now()
+	ranker := ranking.NewRanker(ranking.DefaultConfig())
+	affinities := ranker.Affinities(sig, now)
+	ranked := ranker.Rank(candidates, affinities, seen, now)
+	if len(ranked) > feedSize {
+		ranked = ranked[:feedSize]
+	}
+
+	s.handlers.serveHeadlines(r, ranked)
+	feed.Recent = ranked
+	feed.Affinities = affinities
+	feed.Personalized = true
+	respondData(w, feed)
+}
+
+// personalizedFeedResponse is the home feed with the affinities its recent
+// articles were ranked by.
+type personalizedFeedResponse struct {
+	homeFeedResponse
+	Affinities   ranking.Affinities `json:"affinities,omitempty"`
+	Personalized bool               `json:"personalized"`
+}
+
+// watchedMarkets returns the markets on an owner's watchlists.
+func (s *Server) watchedMarkets(r *http.Request, owner string) []models.Market {
+	lists, err := s.store.GetWatchlists(r.Context(), owner)
+	if err != nil {
+		log.Warn().Err(err).Str("owner", owner).Msg("Failed to load watchlists")
+		return nil
+	}
+
+	var ids []string
+	for _, list := range lists {
+		ids = append(ids, list.MarketIDs...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	markets, err := s.store.GetMarketsByIDs(r.Context(), ids)
+	if err != nil {
+		log.Warn().Err(err).Str("owner", owner).Msg("Failed to load watched markets")
+		return nil
+	}
+	return markets
+}
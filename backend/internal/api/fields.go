@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+)
+
+// fieldSet maps a model's JSON field names to the document fields they are
+// loaded from.
+type fieldSet struct {
+	bson map[string][]string
+	with func(context.Context, []string) context.Context
+}
+
+var (
+	marketFields = newFieldSet(models.Market{}, storage.WithMarketFields)
+
+	// Serving a headline variant needs the test alongside the headline
+	articleFields = newFieldSet(models.Article{}, storage.WithArticleFields).loading("headline", "headline_test")
+)
+
+func newFieldSet(model any, with func(context.Context, []string) context.Context) fieldSet {
+	set := fieldSet{bson: make(map[string][]string), with: with}
+	t := reflect.TypeOf(model)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		bsonName, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if jsonName == "" || jsonName == "-" || bsonName == "" || bsonName == "-" {
+			continue
+		}
+		set.bson[jsonName] = []string{bsonName}
+	}
+	return set
+}
+
+// loading makes selecting the JSON field name also load field.
+func (set fieldSet) loading(name, field string) fieldSet {
+	set.bson[name] = append(set.bson[name], field)
+	return set
+}
+
+// fieldSelection is a validated ?fields= list; the zero value selects
+// every field.
+type fieldSelection struct {
+	set    fieldSet
+	fields []string // JSON names, sorted
+
+	// BSON fields loaded for the handler but not returned
+	extra []string
+}
+
+// parseFields reads the comma-separated ?fields= parameter against set.
+func parseFields(r *http.Request, set fieldSet) (fieldSelection, error) {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return fieldSelection{}, nil
+	}
+
+	seen := make(map[string]bool)
+	sel := fieldSelection{set: set}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := set.bson[name]; !ok {
+			return fieldSelection{}, fmt.Errorf("unknown field %q", name)
+		}
+		seen[name] = true
+		sel.fields = append(sel.fields, name)
+	}
+	sort.Strings(sel.fields)
+	return sel, nil
+}
+
+// listFields parses ?fields= against set, answering 400 when it names a
+// field set doesn't have.
+func listFields(w http.ResponseWriter, r *http.Request, set fieldSet) (fieldSelection, bool) {
+	sel, err := parseFields(r, set)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return fieldSelection{}, false
+	}
+	return sel, true
+}
+
+// context narrows the store's list queries under ctx to the selection.
+func (s fieldSelection) context(ctx context.Context) context.Context {
+	if len(s.fields) == 0 {
+		return ctx
+	}
+	bsonFields := slices.Clone(s.extra)
+	for _, name := range s.fields {
+		bsonFields = append(bsonFields, s.set.bson[name]...)
+	}
+	return s.set.with(ctx, bsonFields)
+}
+
+// cacheKey suffixes a read cache key so sparse lists are cached apart from
+// full ones.
+func (s fieldSelection) cacheKey(key string) string {
+	if len(s.fields) == 0 {
+		return key
+	}
+	key += ":fields=" + strings.Join(s.fields, ",")
+	if len(s.extra) > 0 {
+		key += ":extra=" + strings.Join(s.extra, ",")
+	}
+	return key
+}
+
+// respondFields writes items as respondList does, keeping only the selected
+// fields of each.
+func respondFields[T any](w http.ResponseWriter, items []T, meta Meta, sel fieldSelection) {
+	if len(sel.fields) == 0 {
+		respondList(w, items, meta)
+		return
+	}
+
+	sparse := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to encode response")
+			return
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &all); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to encode response")
+			return
+		}
+		kept := make(map[string]json.RawMessage, len(sel.fields))
+		for _, name := range sel.fields {
+			if v, ok := all[name]; ok {
+				kept[name] = v
+			}
+		}
+		sparse = append(sparse, kept)
+	}
+	respondList(w, sparse, meta)
+}
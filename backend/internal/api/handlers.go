@@ -1,23 +1,68 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/abtest"
+	"github.com/leeaandrob/futuresignals/internal/analytics"
+	"github.com/leeaandrob/futuresignals/internal/assets"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/cache"
 	"github.com/leeaandrob/futuresignals/internal/models"
 	"github.com/leeaandrob/futuresignals/internal/storage"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Store is the storage the public read handlers use. *storage.Store
+// implements it, as does storage.MemoryStore.
+type Store interface {
+	storage.MarketStore
+	storage.ArticleStore
+	storage.SnapshotStore
+	storage.EventStore
+	storage.CategoryStore
+	storage.SocialSignalStore
+
+	GetTagCounts(ctx context.Context, limit int) ([]models.TagCount, error)
+	GetAccuracyScores(ctx context.Context, category, horizon string) ([]models.AccuracyScore, error)
+	GetStats(ctx context.Context) (*storage.Stats, error)
+	RecordArticleView(ctx context.Context, userID primitive.ObjectID, article *models.Article) error
+}
+
 // Handlers holds the API handlers.
 type Handlers struct {
-	store *storage.Store
+	store   Store
+	siteURL string
+
+	// Records article views (nil disables tracking)
+	analytics *analytics.Writer
+
+	// Rewrites market images to proxied URLs (nil serves them as synced)
+	assets *assets.Proxy
+
+	related *relatedArticles
+
+	// Caches hot read results (nil disables caching)
+	cache     cache.Cache
+	cacheTTLs CacheTTLs
+
+	// Reports sync staleness in the health check (nil when not syncing)
+	syncer *syncer.Syncer
 }
 
 // NewHandlers creates new API handlers.
-func NewHandlers(store *storage.Store) *Handlers {
-	return &Handlers{store: store}
+func NewHandlers(store Store, siteURL string) *Handlers {
+	return &Handlers{store: store, siteURL: siteURL, related: newRelatedArticles(store)}
 }
 
 // Response helpers
@@ -29,7 +74,7 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, map[string]string{"error": message})
+	respondJSON(w, status, Envelope[struct{}]{Error: &APIError{Status: status, Message: message}})
 }
 
 func getLimit(r *http.Request, defaultLimit int) int {
@@ -49,17 +94,21 @@ func getLimit(r *http.Request, defaultLimit int) int {
 // GetArticles returns recent articles.
 func (h *Handlers) GetArticles(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 20)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetRecentArticles(r.Context(), limit)
+	articles, err := h.store.GetRecentArticles(ctx, limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{}, sel)
 }
 
 // GetArticleBySlug returns a single article by slug.
@@ -71,142 +120,368 @@ func (h *Handlers) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	article, err := h.store.GetArticleBySlug(r.Context(), slug)
-	if err != nil {
+	if err != nil || article.DeletedAt != nil || !article.Published {
 		respondError(w, http.StatusNotFound, "Article not found")
 		return
 	}
 
-	// Increment views
-	h.store.IncrementArticleViews(r.Context(), article.ID)
+	// Record the view under the reader's headline variant, if any; view
+	// counts are updated as events are written
+	variant := abtest.Apply(sessionKey(r), article)
+	if h.analytics != nil {
+		h.analytics.Track(models.AnalyticsEvent{
+			Type:      models.AnalyticsView,
+			ArticleID: article.ID,
+			Slug:      article.Slug,
+			Category:  article.Category,
+			Variant:   variant,
+		})
+	}
+	if userID, ok := auth.UserIDFromContext(r.Context()); ok {
+		if id, err := primitive.ObjectIDFromHex(userID); err == nil {
+			if err := h.store.RecordArticleView(r.Context(), id, article); err != nil {
+				log.Debug().Err(err).Str("slug", slug).Msg("Failed to record article view")
+			}
+		}
+	}
 
-	respondJSON(w, http.StatusOK, article)
+	// Include the follow-up chain when the article is part of one
+	response := articleResponse{
+		Article: article,
+		JSONLD:  newsArticleJSONLD(article, h.siteURL),
+	}
+	if article.PreviousArticleID != nil || len(article.Updates) > 0 {
+		chain, err := h.store.GetArticleChain(r.Context(), article)
+		if err != nil {
+			log.Warn().Err(err).Str("slug", slug).Msg("Failed to load article chain")
+		} else {
+			response.Chain = chain
+		}
+	}
+
+	related, err := h.related.For(r.Context(), article)
+	if err != nil {
+		log.Warn().Err(err).Str("slug", slug).Msg("Failed to load related articles")
+	} else {
+		response.RelatedArticles = related
+	}
+
+	respondData(w, response)
+}
+
+// articleResponse is an article with its follow-up chain, oldest first,
+// articles to read next, and schema.org NewsArticle structured data for the
+// page.
+type articleResponse struct {
+	*models.Article
+	Chain           []models.ArticleLink    `json:"chain,omitempty"`
+	RelatedArticles []models.RelatedArticle `json:"related_articles"`
+	JSONLD          *newsArticleLD          `json:"json_ld"`
 }
 
 // GetArticlesByType returns articles of a specific type.
 func (h *Handlers) GetArticlesByType(w http.ResponseWriter, r *http.Request) {
 	articleType := chi.URLParam(r, "type")
 	limit := getLimit(r, 20)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetArticlesByType(r.Context(), models.ArticleType(articleType), limit)
+	articles, err := h.store.GetArticlesByType(ctx, models.ArticleType(articleType), limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"type":     articleType,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{Type: articleType}, sel)
 }
 
 // GetArticlesByCategory returns articles for a category.
 func (h *Handlers) GetArticlesByCategory(w http.ResponseWriter, r *http.Request) {
 	category := chi.URLParam(r, "category")
 	limit := getLimit(r, 20)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetArticlesByCategory(r.Context(), category, limit)
+	articles, err := h.store.GetArticlesByCategory(ctx, category, limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"category": category,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{Category: category}, sel)
 }
 
 // GetBreakingArticles returns breaking news articles.
 func (h *Handlers) GetBreakingArticles(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 10)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetArticlesByType(r.Context(), models.ArticleTypeBreaking, limit)
+	articles, err := h.store.GetArticlesByType(ctx, models.ArticleTypeBreaking, limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{}, sel)
 }
 
 // GetTrendingArticles returns trending articles.
 func (h *Handlers) GetTrendingArticles(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 10)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetArticlesByType(r.Context(), models.ArticleTypeTrending, limit)
+	articles, err := h.store.GetArticlesByType(ctx, models.ArticleTypeTrending, limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{}, sel)
 }
 
 // GetFeaturedArticles returns featured articles.
 func (h *Handlers) GetFeaturedArticles(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 5)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	articles, err := h.store.GetFeaturedArticles(r.Context(), limit)
+	articles, err := h.store.GetFeaturedArticles(ctx, limit)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{}, sel)
 }
 
 // GetTodayArticles returns articles published today.
 func (h *Handlers) GetTodayArticles(w http.ResponseWriter, r *http.Request) {
-	articles, err := h.store.GetTodayArticles(r.Context())
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
+
+	articles, err := h.store.GetTodayArticles(ctx)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"articles": articles,
-		"count":    len(articles),
-	})
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{}, sel)
 }
 
 // ============================================================================
 // MARKET HANDLERS
 // ============================================================================
 
-// GetMarkets returns markets.
+// GetMarkets returns open markets by 24h volume, or in the ?sort= order,
+// narrowed by any of ?category=, ?min_volume=, ?max_probability=,
+// ?ending_within=, ?tier=, ?liquidity_tier= and ?volume_tier=.
 func (h *Handlers) GetMarkets(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 50)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	markets, err := h.store.GetTopMarketsByVolume(r.Context(), limit)
+	q, err := parseMarketQuery(r, limit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets": markets,
-		"count":   len(markets),
+	key := marketQueryKey(q)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.ListMarkets(ctx, q)
+		h.proxyImages(r, markets)
+		return markets, err
 	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+
+	h.respondMarkets(w, r, key, markets, Meta{Sort: q.Sort, Category: q.Category}, sel)
+}
+
+// parseMarketQuery reads GetMarkets' sort and filter parameters.
+func parseMarketQuery(r *http.Request, limit int) (storage.MarketQuery, error) {
+	query := r.URL.Query()
+	q := storage.MarketQuery{
+		Sort:     query.Get("sort"),
+		Category: query.Get("category"),
+		Limit:    limit,
+	}
+	if q.Sort == "" {
+		q.Sort = storage.MarketSortVolume
+	}
+	if !storage.ValidMarketSort(q.Sort) {
+		return q, fmt.Errorf("unknown sort %q", q.Sort)
+	}
+
+	if v := query.Get("min_volume"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			return q, errors.New("min_volume must be a non-negative number")
+		}
+		q.MinVolume = parsed
+	}
+	if v := query.Get("max_probability"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return q, errors.New("max_probability must be between 0 and 1")
+		}
+		q.MaxProbability = &parsed
+	}
+	for _, tier := range []struct {
+		param string
+		value *models.Tier
+	}{
+		{"tier", &q.Tier},
+		{"liquidity_tier", &q.LiquidityTier},
+		{"volume_tier", &q.VolumeTier},
+	} {
+		if v := query.Get(tier.param); v != "" {
+			if !models.ValidTier(v) {
+				return q, fmt.Errorf("%s must be low, medium or high", tier.param)
+			}
+			*tier.value = models.Tier(v)
+		}
+	}
+	if v := query.Get("ending_within"); v != "" {
+		within, err := parseWithin(v)
+		if err != nil || within <= 0 {
+			return q, errors.New("ending_within must be a positive duration such as 48h or 7d")
+		}
+		q.EndingWithin = within
+	}
+	return q, nil
 }
 
-// GetMarketBySlug returns a single market by slug.
+// parseWithin parses a duration in time.ParseDuration's syntax or a whole
+// number of days, e.g. "7d".
+func parseWithin(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		return time.Duration(n) * 24 * time.Hour, err
+	}
+	return time.ParseDuration(s)
+}
+
+// marketQueryKey is the read cache key for a GetMarkets query.
+func marketQueryKey(q storage.MarketQuery) string {
+	maxProbability := ""
+	if q.MaxProbability != nil {
+		maxProbability = strconv.FormatFloat(*q.MaxProbability, 'g', -1, 64)
+	}
+	return strings.Join([]string{
+		"list", q.Sort, q.Category,
+		strconv.FormatFloat(q.MinVolume, 'g', -1, 64),
+		maxProbability,
+		q.EndingWithin.String(),
+		string(q.Tier), string(q.LiquidityTier), string(q.VolumeTier),
+		strconv.Itoa(q.Limit),
+	}, ":")
+}
+
+// GetMarketBySlug returns a single market by slug, with its sparkline when
+// ?include=sparkline is set.
 func (h *Handlers) GetMarketBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
 		respondError(w, http.StatusBadRequest, "Slug is required")
 		return
 	}
+	include, ok := includeSparkline(w, r)
+	if !ok {
+		return
+	}
+
+	market, err := h.store.GetMarketBySlug(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+	h.proxyImage(r, market)
+
+	if !include {
+		respondData(w, market)
+		return
+	}
+	sparklines, err := h.sparklines(r.Context(), "market:"+market.MarketID, []models.Market{*market})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch sparklines")
+		return
+	}
+	sparkline := sparklines[market.MarketID]
+	respondData(w, sparklineMarket{Market: *market, Sparkline: &sparkline})
+}
+
+// GetRelatedMarkets returns markets whose probability moves correlate with
+// the given market's.
+func (h *Handlers) GetRelatedMarkets(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	limit := getLimit(r, 10)
+
+	market, err := h.store.GetMarketBySlug(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+
+	related, err := h.store.GetRelatedMarkets(r.Context(), market.MarketID, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch related markets")
+		return
+	}
+	for _, rel := range related {
+		h.proxyImage(r, rel.Market)
+	}
+
+	respondList(w, related, Meta{MarketID: market.MarketID})
+}
+
+// GetMarketArticles returns the coverage of a market in chronological
+// order, for its detail page.
+func (h *Handlers) GetMarketArticles(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	limit := getLimit(r, 50)
+	sel, ok := listFields(w, r, articleFields)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
 	market, err := h.store.GetMarketBySlug(r.Context(), slug)
 	if err != nil {
@@ -214,73 +489,197 @@ func (h *Handlers) GetMarketBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, market)
+	articles, err := h.store.GetArticlesByMarketID(ctx, market.MarketID, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+	h.serveHeadlines(r, articles)
+
+	respondFields(w, articles, Meta{MarketID: market.MarketID}, sel)
 }
 
 // GetTrendingMarkets returns trending markets.
 func (h *Handlers) GetTrendingMarkets(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	markets, err := h.store.GetTrendingMarkets(r.Context(), limit)
+	key := "trending:" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.GetTrendingMarkets(ctx, limit)
+		h.proxyImages(r, markets)
+		return markets, err
+	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets": markets,
-		"count":   len(markets),
+	h.respondMarkets(w, r, key, markets, Meta{}, sel)
+}
+
+// GetCategoryTrendingMarkets returns a category's open markets by trending
+// score, so categories other than politics get their own trending list.
+func (h *Handlers) GetCategoryTrendingMarkets(w http.ResponseWriter, r *http.Request) {
+	category := chi.URLParam(r, "category")
+	if cat := models.GetCategoryBySlug(category); cat == nil || cat.Dynamic {
+		respondError(w, http.StatusNotFound, "Category not found")
+		return
+	}
+	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
+
+	key := "trending:" + category + ":" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.ListMarkets(ctx, storage.MarketQuery{Category: category, Sort: storage.MarketSortTrending, Limit: limit})
+		h.proxyImages(r, markets)
+		return markets, err
 	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+
+	h.respondMarkets(w, r, key, markets, Meta{Category: category}, sel)
 }
 
 // GetMarketsByCategory returns markets for a category.
 func (h *Handlers) GetMarketsByCategory(w http.ResponseWriter, r *http.Request) {
 	category := chi.URLParam(r, "category")
 	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	markets, err := h.store.GetMarketsByCategory(r.Context(), category, limit)
+	key := "category:" + category + ":" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.GetMarketsByCategory(ctx, category, limit)
+		h.proxyImages(r, markets)
+		return markets, err
+	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets":  markets,
-		"category": category,
-		"count":    len(markets),
-	})
+	h.respondMarkets(w, r, key, markets, Meta{Category: category}, sel)
 }
 
 // GetNewMarkets returns recently created markets.
 func (h *Handlers) GetNewMarkets(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	markets, err := h.store.GetNewMarkets(r.Context(), 24*7, limit) // Last 7 days
+	key := "new:" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.GetNewMarkets(ctx, 24*7, limit) // Last 7 days
+		h.proxyImages(r, markets)
+		return markets, err
+	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets": markets,
-		"count":   len(markets),
+	h.respondMarkets(w, r, key, markets, Meta{}, sel)
+}
+
+// Closing-soon window in days: the default and the largest ?days= accepted.
+const (
+	closingSoonDefaultDays = 7
+	closingSoonMaxDays     = 30
+)
+
+// GetClosingSoonMarkets returns open markets ending within the next ?days=
+// (default 7, at most 30), soonest first.
+func (h *Handlers) GetClosingSoonMarkets(w http.ResponseWriter, r *http.Request) {
+	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
+
+	days := closingSoonDefaultDays
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > closingSoonMaxDays {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 30")
+			return
+		}
+		days = parsed
+	}
+
+	key := "closing:" + strconv.Itoa(days) + ":" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.GetClosingSoonMarkets(ctx, time.Duration(days)*24*time.Hour, limit)
+		h.proxyImages(r, markets)
+		return markets, err
 	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+
+	h.respondMarkets(w, r, key, markets, Meta{Days: days}, sel)
 }
 
-// GetBreakingMarkets returns markets with significant movements.
+// GetBreakingMarkets returns markets with significant movements. The
+// window query parameter selects 1h, 6h or 24h (default) changes, and
+// threshold the minimum absolute change.
 func (h *Handlers) GetBreakingMarkets(w http.ResponseWriter, r *http.Request) {
 	limit := getLimit(r, 20)
+	sel, ok := marketListFields(w, r)
+	if !ok {
+		return
+	}
+	ctx := sel.context(r.Context())
 
-	markets, err := h.store.GetBreakingMarkets(r.Context(), 0.05, limit)
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = storage.ChangeWindow24h
+	}
+	if !storage.ValidChangeWindow(window) {
+		respondError(w, http.StatusBadRequest, "window must be 1h, 6h or 24h")
+		return
+	}
+
+	threshold := 0.05
+	if t := r.URL.Query().Get("threshold"); t != "" {
+		parsed, err := strconv.ParseFloat(t, 64)
+		if err != nil || parsed <= 0 || parsed >= 1 {
+			respondError(w, http.StatusBadRequest, "threshold must be between 0 and 1")
+			return
+		}
+		threshold = parsed
+	}
+
+	key := "breaking:" + window + ":" + strconv.FormatFloat(threshold, 'g', -1, 64) + ":" + strconv.Itoa(limit)
+	markets, err := cachedRead(ctx, h, cache.GroupMarkets, sel.cacheKey(key), func() ([]models.Market, error) {
+		markets, err := h.store.GetBreakingMarkets(ctx, window, threshold, limit)
+		h.proxyImages(r, markets)
+		return markets, err
+	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets": markets,
-		"count":   len(markets),
-	})
+	h.respondMarkets(w, r, key, markets, Meta{Window: window}, sel)
 }
 
 // ============================================================================
@@ -289,44 +688,55 @@ func (h *Handlers) GetBreakingMarkets(w http.ResponseWriter, r *http.Request) {
 
 // GetCategories returns all categories.
 func (h *Handlers) GetCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.store.GetCategories(r.Context())
+	categories, err := cachedRead(r.Context(), h, cache.GroupCategories, "all", func() ([]models.Category, error) {
+		return h.store.GetCategories(r.Context())
+	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch categories")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"categories": categories,
-		"count":      len(categories),
-	})
+	respondList(w, categories, Meta{})
 }
 
 // GetCategoryBySlug returns a single category with its content.
 func (h *Handlers) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
-	category, err := h.store.GetCategoryBySlug(r.Context(), slug)
+	page, err := cachedRead(r.Context(), h, cache.GroupCategories, "page:"+slug, func() (categoryPage, error) {
+		category, err := h.store.GetCategoryBySlug(r.Context(), slug)
+		if err != nil {
+			return categoryPage{}, err
+		}
+
+		// Get markets and articles for this category
+		markets, _ := h.store.GetMarketsByCategory(r.Context(), slug, 10)
+		articles, _ := h.store.GetArticlesByCategory(r.Context(), slug, 10)
+		h.proxyImages(r, markets)
+		return categoryPage{Category: category, Markets: markets, Articles: articles}, nil
+	})
 	if err != nil {
 		respondError(w, http.StatusNotFound, "Category not found")
 		return
 	}
+	h.serveHeadlines(r, page.Articles)
 
-	// Get markets and articles for this category
-	markets, _ := h.store.GetMarketsByCategory(r.Context(), slug, 10)
-	articles, _ := h.store.GetArticlesByCategory(r.Context(), slug, 10)
+	respondData(w, page)
+}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"category": category,
-		"markets":  markets,
-		"articles": articles,
-	})
+// categoryPage is a category with its top markets and latest articles.
+type categoryPage struct {
+	Category *models.Category `json:"category" bson:"category"`
+	Markets  []models.Market  `json:"markets" bson:"markets"`
+	Articles []models.Article `json:"articles" bson:"articles"`
 }
 
 // ============================================================================
 // SENTIMENT/PULSE HANDLERS
 // ============================================================================
 
-// GetSentiment returns category momentum/sentiment data for the Market Pulse.
+// GetSentiment returns category momentum/sentiment data for the Market Pulse
+// and homepage heatmap, as last cached by the category-sentiment job.
 func (h *Handlers) GetSentiment(w http.ResponseWriter, r *http.Request) {
 	sentiments, err := h.store.GetCategorySentiments(r.Context())
 	if err != nil {
@@ -334,10 +744,7 @@ func (h *Handlers) GetSentiment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"sentiments": sentiments,
-		"count":      len(sentiments),
-	})
+	respondList(w, sentiments, Meta{})
 }
 
 // GetCategorySentiment returns sentiment for a specific category.
@@ -353,7 +760,7 @@ func (h *Handlers) GetCategorySentiment(w http.ResponseWriter, r *http.Request)
 	// Find the requested category
 	for _, s := range sentiments {
 		if s.Category == category {
-			respondJSON(w, http.StatusOK, s)
+			respondData(w, s)
 			return
 		}
 	}
@@ -373,15 +780,44 @@ func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, stats)
+	respondData(w, stats)
+}
+
+// GetAccuracy returns forecast accuracy scores for resolved markets,
+// optionally filtered by ?category= and ?horizon=.
+func (h *Handlers) GetAccuracy(w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+	horizon := r.URL.Query().Get("horizon")
+
+	scores, err := h.store.GetAccuracyScores(r.Context(), category, horizon)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch accuracy scores")
+		return
+	}
+
+	respondList(w, scores, Meta{})
 }
 
-// HealthCheck returns service health.
+// HealthCheck returns service health. The service is degraded, though
+// still serving, when market data has gone stale.
 func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	respondJSON(w, http.StatusOK, map[string]string{
-		"status":  "healthy",
-		"service": "futuresignals",
-	})
+	health := healthResponse{Status: "healthy", Service: "futuresignals"}
+	if h.syncer != nil {
+		status := h.syncer.Status()
+		health.Status = status.State
+		if !status.LastSuccessAt.IsZero() {
+			health.LastSyncAt = &status.LastSuccessAt
+		}
+	}
+	respondData(w, health)
+}
+
+// healthResponse reports that the service is up, and whether its market
+// data is current.
+type healthResponse struct {
+	Status     string     `json:"status"`
+	Service    string     `json:"service"`
+	LastSyncAt *time.Time `json:"last_sync_at,omitempty"`
 }
 
 // ============================================================================
@@ -390,27 +826,81 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 // GetHomeFeed returns curated content for the homepage.
 func (h *Handlers) GetHomeFeed(w http.ResponseWriter, r *http.Request) {
+	respondData(w, h.homeFeed(r))
+}
+
+// homeTrendingPerCategory is how many markets each of the homepage's
+// per-category trending modules shows.
+const homeTrendingPerCategory = 5
+
+// homeFeedResponse holds the homepage sections.
+type homeFeedResponse struct {
+	Featured           []models.Article   `json:"featured" bson:"featured"`
+	Recent             []models.Article   `json:"recent" bson:"recent"`
+	TrendingMarkets    []models.Market    `json:"trending_markets" bson:"trending_markets"`
+	TrendingByCategory []categoryTrending `json:"trending_by_category" bson:"trending_by_category"`
+	Today              []models.Article   `json:"today" bson:"today"`
+}
+
+// categoryTrending is a category's top trending markets, for a homepage
+// module.
+type categoryTrending struct {
+	Category string          `json:"category" bson:"category"`
+	Name     string          `json:"name" bson:"name"`
+	Markets  []models.Market `json:"markets" bson:"markets"`
+}
+
+// homeFeed assembles the homepage sections.
+func (h *Handlers) homeFeed(r *http.Request) homeFeedResponse {
 	ctx := r.Context()
 
-	// Get featured/breaking articles
-	featured, _ := h.store.GetFeaturedArticles(ctx, 3)
-	if len(featured) == 0 {
-		featured, _ = h.store.GetArticlesByType(ctx, models.ArticleTypeBreaking, 3)
-	}
+	feed, _ := cachedRead(ctx, h, cache.GroupFeed, "home", func() (homeFeedResponse, error) {
+		var feed homeFeedResponse
+
+		// Get featured/breaking articles
+		feed.Featured, _ = h.store.GetFeaturedArticles(ctx, 3)
+		if len(feed.Featured) == 0 {
+			feed.Featured, _ = h.store.GetArticlesByType(ctx, models.ArticleTypeBreaking, 3)
+		}
 
-	// Get recent articles
-	recent, _ := h.store.GetRecentArticles(ctx, 10)
+		// Get recent articles
+		feed.Recent, _ = h.store.GetRecentArticles(ctx, 10)
 
-	// Get trending markets
-	trendingMarkets, _ := h.store.GetTrendingMarkets(ctx, 10)
+		// Get trending markets, overall and per category
+		feed.TrendingMarkets, _ = h.store.GetTrendingMarkets(ctx, 10)
+		feed.TrendingByCategory = h.trendingByCategory(r)
 
-	// Get today's briefings
-	todayArticles, _ := h.store.GetTodayArticles(ctx)
+		// Get today's briefings
+		feed.Today, _ = h.store.GetTodayArticles(ctx)
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"featured":         featured,
-		"recent":           recent,
-		"trending_markets": trendingMarkets,
-		"today":            todayArticles,
+		h.proxyImages(r, feed.TrendingMarkets)
+		return feed, nil
 	})
+
+	h.serveHeadlines(r, feed.Featured)
+	h.serveHeadlines(r, feed.Recent)
+	h.serveHeadlines(r, feed.Today)
+
+	return feed
+}
+
+// trendingByCategory returns the homepage's per-category trending modules
+// in taxonomy order, leaving out categories without open markets.
+func (h *Handlers) trendingByCategory(r *http.Request) []categoryTrending {
+	byCategory, err := h.store.GetTrendingMarketsByCategory(r.Context(), homeTrendingPerCategory)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get trending markets by category")
+		return nil
+	}
+
+	var modules []categoryTrending
+	for _, cat := range models.GetStaticCategories() {
+		markets := byCategory[cat.Slug]
+		if len(markets) == 0 {
+			continue
+		}
+		h.proxyImages(r, markets)
+		modules = append(modules, categoryTrending{Category: cat.Slug, Name: cat.Name, Markets: markets})
+	}
+	return modules
 }
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/leeaandrob/futuresignals/internal/abtest"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// SessionHeader carries a stable per-reader id for headline tests. Clients
+// that render server-side should forward their reader's id in it.
+const SessionHeader = "X-Session-ID"
+
+// sessionKey identifies the reader for headline variant assignment: the
+// session header, the sign-in cookie, or the client IP.
+func sessionKey(r *http.Request) string {
+	if id := r.Header.Get(SessionHeader); id != "" {
+		return id
+	}
+	if c, err := r.Cookie(auth.SessionCookie); err == nil && c.Value != "" {
+		return c.Value
+	}
+	return clientIP(r)
+}
+
+// serveHeadlines swaps in this reader's variant for each article under a
+// headline test and records the impressions.
+func (h *Handlers) serveHeadlines(r *http.Request, articles []models.Article) {
+	session := sessionKey(r)
+	for i := range articles {
+		a := &articles[i]
+		variant := abtest.Apply(session, a)
+		if variant == 0 || h.analytics == nil {
+			continue
+		}
+		h.analytics.Track(models.AnalyticsEvent{
+			Type:      models.AnalyticsImpression,
+			ArticleID: a.ID,
+			Slug:      a.Slug,
+			Category:  a.Category,
+			Variant:   variant,
+		})
+	}
+}
+
+// headlineTestResponse is an article's headline test in the admin API.
+type headlineTestResponse struct {
+	ArticleID string               `json:"article_id"`
+	Slug      string               `json:"slug"`
+	Headline  string               `json:"headline"`
+	Test      *models.HeadlineTest `json:"test"`
+}
+
+// AdminGetHeadlineTests returns headline tests with per-variant
+// impressions, clicks and CTR as of their last evaluation. ?status= is
+// running (default) or decided.
+func (s *Server) AdminGetHeadlineTests(w http.ResponseWriter, r *http.Request) {
+	decided := false
+	switch r.URL.Query().Get("status") {
+	case "", "running":
+	case "decided":
+		decided = true
+	default:
+		respondError(w, http.StatusBadRequest, "status must be running or decided")
+		return
+	}
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	articles, err := s.store.GetHeadlineTests(r.Context(), decided, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch headline tests")
+		return
+	}
+
+	tests := make([]headlineTestResponse, len(articles))
+	for i, a := range articles {
+		tests[i] = headlineTestResponse{
+			ArticleID: a.ID.Hex(),
+			Slug:      a.Slug,
+			Headline:  a.Headline,
+			Test:      a.HeadlineTest,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"tests": tests,
+		"count": len(tests),
+	})
+}
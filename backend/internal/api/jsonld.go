@@ -0,0 +1,108 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// siteName is the organization credited as author and publisher.
+const siteName = "FutureSignals"
+
+// newsArticleLD is a schema.org NewsArticle for embedding as JSON-LD.
+type newsArticleLD struct {
+	Context             string         `json:"@context"`
+	Type                string         `json:"@type"`
+	Headline            string         `json:"headline"`
+	Description         string         `json:"description,omitempty"`
+	URL                 string         `json:"url"`
+	MainEntityOfPage    ldRef          `json:"mainEntityOfPage"`
+	Image               *ldImage       `json:"image,omitempty"`
+	DatePublished       string         `json:"datePublished,omitempty"`
+	DateModified        string         `json:"dateModified,omitempty"`
+	Author              ldOrganization `json:"author"`
+	Publisher           ldOrganization `json:"publisher"`
+	ArticleSection      string         `json:"articleSection,omitempty"`
+	Keywords            string         `json:"keywords,omitempty"`
+	About               []ldThing      `json:"about,omitempty"`
+	IsAccessibleForFree bool           `json:"isAccessibleForFree"`
+}
+
+type ldRef struct {
+	Type string `json:"@type"`
+	ID   string `json:"@id"`
+}
+
+type ldImage struct {
+	Type   string `json:"@type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+type ldOrganization struct {
+	Type string   `json:"@type"`
+	Name string   `json:"name"`
+	URL  string   `json:"url,omitempty"`
+	Logo *ldImage `json:"logo,omitempty"`
+}
+
+type ldThing struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// newsArticleJSONLD builds NewsArticle structured data for an article, with
+// the markets it covers as "about" entities.
+func newsArticleJSONLD(article *models.Article, siteURL string) *newsArticleLD {
+	siteURL = strings.TrimRight(siteURL, "/")
+	articleURL := siteURL + "/article/" + article.Slug
+	logo := &ldImage{Type: "ImageObject", URL: siteURL + "/logo.png"}
+
+	ld := &newsArticleLD{
+		Context:          "https://schema.org",
+		Type:             "NewsArticle",
+		Headline:         article.Headline,
+		Description:      article.Summary,
+		URL:              articleURL,
+		MainEntityOfPage: ldRef{Type: "WebPage", ID: articleURL},
+		Image: &ldImage{
+			Type:   "ImageObject",
+			URL:    siteURL + "/og-image.png",
+			Width:  1200,
+			Height: 630,
+		},
+		DatePublished:       formatLDTime(article.PublishedAt),
+		DateModified:        formatLDTime(article.UpdatedAt),
+		Author:              ldOrganization{Type: "Organization", Name: siteName, URL: siteURL},
+		Publisher:           ldOrganization{Type: "NewsMediaOrganization", Name: siteName, Logo: logo},
+		ArticleSection:      article.Category,
+		Keywords:            strings.Join(article.Tags, ", "),
+		IsAccessibleForFree: true,
+	}
+	if ld.DateModified == "" {
+		ld.DateModified = ld.DatePublished
+	}
+	if article.OGImageURL != "" {
+		ld.Image.URL = article.OGImageURL
+	}
+
+	for _, m := range article.Markets {
+		thing := ldThing{Type: "Thing", Name: m.Question}
+		if m.Slug != "" {
+			thing.URL = siteURL + "/market/" + m.Slug
+		}
+		ld.About = append(ld.About, thing)
+	}
+
+	return ld
+}
+
+func formatLDTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/newsletter"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// subscribeLimit caps signups per client IP, since each one sends an email.
+const subscribeLimit = 5
+
+// SetNewsletter enables the newsletter endpoints.
+func (s *Server) SetNewsletter(svc *newsletter.Service) {
+	s.newsletter = svc
+}
+
+// Subscribe starts a newsletter subscription and sends the confirmation email.
+func (s *Server) Subscribe(w http.ResponseWriter, r *http.Request) {
+	if s.newsletter == nil {
+		respondError(w, http.StatusServiceUnavailable, "Newsletter not available")
+		return
+	}
+
+	if !s.limiter.Allow("subscribe:"+clientIP(r), subscribeLimit, time.Hour) {
+		respondError(w, http.StatusTooManyRequests, "Too many signups, try again later")
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := s.newsletter.Subscribe(r.Context(), req.Email); err != nil {
+		if errors.Is(err, newsletter.ErrInvalidEmail) {
+			respondError(w, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+		log.Error().Err(err).Msg("Newsletter subscribe failed")
+		respondError(w, http.StatusInternalServerError, "Failed to subscribe")
+		return
+	}
+
+	respondDataStatus(w, http.StatusAccepted, statusMessage{
+		Status:  "ok",
+		Message: "Check your inbox to confirm your subscription",
+	})
+}
+
+// ConfirmSubscription handles the confirmation link and redirects to the site.
+func (s *Server) ConfirmSubscription(w http.ResponseWriter, r *http.Request) {
+	if s.newsletter == nil {
+		respondError(w, http.StatusServiceUnavailable, "Newsletter not available")
+		return
+	}
+
+	sub, err := s.newsletter.Confirm(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		log.Error().Err(err).Msg("Newsletter confirm failed")
+		respondError(w, http.StatusInternalServerError, "Failed to confirm subscription")
+		return
+	}
+
+	status := "confirmed"
+	if sub == nil {
+		status = "invalid"
+	}
+	http.Redirect(w, r, s.siteURL+"/?newsletter="+status, http.StatusSeeOther)
+}
+
+// Unsubscribe handles unsubscribe links. GET redirects to the site; POST is
+// the RFC 8058 one-click unsubscribe used by mail clients.
+func (s *Server) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if s.newsletter == nil {
+		respondError(w, http.StatusServiceUnavailable, "Newsletter not available")
+		return
+	}
+
+	ok, err := s.newsletter.Unsubscribe(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		log.Error().Err(err).Msg("Newsletter unsubscribe failed")
+		respondError(w, http.StatusInternalServerError, "Failed to unsubscribe")
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		respondData(w, unsubscribeResponse{Unsubscribed: ok})
+		return
+	}
+	http.Redirect(w, r, s.siteURL+"/?newsletter=unsubscribed", http.StatusSeeOther)
+}
+
+// unsubscribeResponse reports whether a one-click unsubscribe matched a
+// subscriber.
+type unsubscribeResponse struct {
+	Unsubscribed bool `json:"unsubscribed"`
+}
+
+// AdminListSubscribers returns subscribers, optionally filtered by ?status=.
+func (s *Server) AdminListSubscribers(w http.ResponseWriter, r *http.Request) {
+	status := models.SubscriberStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", models.SubscriberPending, models.SubscriberConfirmed, models.SubscriberUnsubscribed:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, confirmed or unsubscribed")
+		return
+	}
+
+	limit := getLimit(r, 50)
+	offset := 0
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	subs, err := s.store.ListSubscribers(r.Context(), status, int64(offset), int64(limit))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch subscribers")
+		return
+	}
+	total, err := s.store.CountSubscribers(r.Context(), status)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count subscribers")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"subscribers": subs,
+		"count":       len(subs),
+		"total":       total,
+	})
+}
+
+// AdminDeleteSubscriber permanently removes a subscriber, e.g. for a data
+// deletion request.
+func (s *Server) AdminDeleteSubscriber(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscriber ID")
+		return
+	}
+
+	deleted, err := s.store.DeleteSubscriber(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete subscriber")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Subscriber not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Subscriber deleted",
+	})
+}
+
+// clientIP returns the request's client address without the port.
+// realIP has already applied a trusted proxy's forwarding header.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,652 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// apiParam is a path or query parameter of an operation.
+type apiParam struct {
+	Name        string
+	In          string // "path" or "query"
+	Type        string // "string", "integer" or "number"
+	Description string
+	Default     any
+	Enum        []string
+
+	// Inclusive and exclusive bounds for numeric parameters
+	Minimum, Maximum                   *float64
+	ExclusiveMinimum, ExclusiveMaximum *float64
+}
+
+// apiOperation documents a public read endpoint. Its parameters are checked
+// by validateRequests before the handler runs.
+type apiOperation struct {
+	Method  string
+	Path    string // chi route pattern, e.g. /api/markets/{slug}
+	Summary string
+	Tag     string
+	Params  []apiParam
+
+	// Response is a value of the data payload's type; List responses
+	// carry a slice of it and meta
+	Response any
+	List     bool
+}
+
+func bound(v float64) *float64 { return &v }
+
+func limitParam(defaultLimit int) apiParam {
+	return apiParam{
+		Name: "limit", In: "query", Type: "integer", Default: defaultLimit,
+		Description: "Maximum number of items",
+		Minimum:     bound(1), Maximum: bound(100),
+	}
+}
+
+// offsetParam skips into a list, for paging.
+func offsetParam() apiParam {
+	return apiParam{
+		Name: "offset", In: "query", Type: "integer", Default: 0,
+		Description: "Number of items to skip",
+		Minimum:     bound(0), Maximum: bound(10000),
+	}
+}
+
+// fieldsParam selects the fields of each item in a list, see parseFields.
+func fieldsParam() apiParam {
+	return apiParam{
+		Name: "fields", In: "query", Type: "string",
+		Description: "Comma-separated fields to return for each item, e.g. id,slug,question; all fields when omitted",
+	}
+}
+
+// includeParam adds sparklines to market responses, see respondMarkets.
+func includeParam() apiParam {
+	return apiParam{
+		Name: "include", In: "query", Type: "string",
+		Description: "sparkline adds each market's probability over the last 24h (hourly) and 7d (every 6h), oldest first, as sparkline.day and sparkline.week",
+		Enum:        []string{"sparkline"},
+	}
+}
+
+func pathParam(name, description string) apiParam {
+	return apiParam{Name: name, In: "path", Type: "string", Description: description}
+}
+
+// articleTypes are the values accepted by /api/articles/type/{type}.
+var articleTypes = []string{
+	string(models.ArticleTypeBreaking),
+	string(models.ArticleTypeBriefing),
+	string(models.ArticleTypeTrending),
+	string(models.ArticleTypeNewMarket),
+	string(models.ArticleTypeDeepDive),
+	string(models.ArticleTypeDigest),
+	string(models.ArticleTypeExplainer),
+	string(models.ArticleTypeSocialSignal),
+	string(models.ArticleTypeUpdate),
+	string(models.ArticleTypeResolution),
+	string(models.ArticleTypeWeekAhead),
+}
+
+// tiers are the values accepted by the market tier filters.
+var tiers = []string{string(models.TierLow), string(models.TierMedium), string(models.TierHigh)}
+
+// apiOperations is the public read API as published at /api/openapi.json.
+var apiOperations = []apiOperation{
+	{Method: "GET", Path: "/api/health", Summary: "Service health", Tag: "System", Response: healthResponse{}},
+	{Method: "GET", Path: "/api/stats", Summary: "Market and article counts", Tag: "System", Response: storage.Stats{}},
+	{
+		Method: "GET", Path: "/api/stats/accuracy", Summary: "Forecast accuracy scores for resolved markets", Tag: "System",
+		Params: []apiParam{
+			{Name: "category", In: "query", Type: "string", Description: "Only scores for this category"},
+			{Name: "horizon", In: "query", Type: "string", Description: "Only scores at this horizon, e.g. 7d"},
+		},
+		Response: models.AccuracyScore{}, List: true,
+	},
+
+	{Method: "GET", Path: "/api/feed", Summary: "Homepage sections", Tag: "Feed", Response: homeFeedResponse{}},
+	{Method: "GET", Path: "/api/feed/personalized", Summary: "Homepage sections ranked for the signed-in reader", Tag: "Feed", Response: personalizedFeedResponse{}},
+
+	{Method: "GET", Path: "/api/articles", Summary: "Latest articles", Tag: "Articles", Params: []apiParam{limitParam(20), fieldsParam()}, Response: models.Article{}, List: true},
+	{Method: "GET", Path: "/api/articles/breaking", Summary: "Breaking news articles", Tag: "Articles", Params: []apiParam{limitParam(10), fieldsParam()}, Response: models.Article{}, List: true},
+	{Method: "GET", Path: "/api/articles/trending", Summary: "Trending articles", Tag: "Articles", Params: []apiParam{limitParam(10), fieldsParam()}, Response: models.Article{}, List: true},
+	{Method: "GET", Path: "/api/articles/featured", Summary: "Featured articles", Tag: "Articles", Params: []apiParam{limitParam(5), fieldsParam()}, Response: models.Article{}, List: true},
+	{
+		Method: "GET", Path: "/api/articles/type/{type}", Summary: "Articles of one type", Tag: "Articles",
+		Params: []apiParam{
+			{Name: "type", In: "path", Type: "string", Description: "Article type", Enum: articleTypes},
+			limitParam(20),
+			fieldsParam(),
+		},
+		Response: models.Article{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/articles/category/{category}", Summary: "Articles in a category", Tag: "Articles",
+		Params:   []apiParam{pathParam("category", "Category slug"), limitParam(20), fieldsParam()},
+		Response: models.Article{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/articles/{slug}", Summary: "An article with its follow-ups and related reading", Tag: "Articles",
+		Params:   []apiParam{pathParam("slug", "Article slug")},
+		Response: articleResponse{},
+	},
+	{
+		Method: "GET", Path: "/api/articles/{slug}/plain", Summary: "An article flattened to Markdown and plain text, for AMP pages, reader modes and voice assistants", Tag: "Articles",
+		Params:   []apiParam{pathParam("slug", "Article slug")},
+		Response: plainArticle{},
+	},
+
+	{
+		Method: "GET", Path: "/api/markets", Summary: "Open markets, sorted and filtered", Tag: "Markets",
+		Params: []apiParam{
+			limitParam(50),
+			fieldsParam(),
+			includeParam(),
+			{
+				Name: "sort", In: "query", Type: "string", Default: storage.MarketSortVolume,
+				Description: "Order: 24h volume, trending score, 24h change or liquidity highest first, end date soonest first, or newest first",
+				Enum: []string{
+					storage.MarketSortVolume, storage.MarketSortTrending, storage.MarketSortChange24h,
+					storage.MarketSortLiquidity, storage.MarketSortEndDate, storage.MarketSortNewest,
+				},
+			},
+			{Name: "category", In: "query", Type: "string", Description: "Only markets in this category"},
+			{Name: "min_volume", In: "query", Type: "number", Description: "Lowest 24h volume", Minimum: bound(0)},
+			{Name: "max_probability", In: "query", Type: "number", Description: "Highest yes price", Minimum: bound(0), Maximum: bound(1)},
+			{Name: "ending_within", In: "query", Type: "string", Description: "Only markets ending within this long, e.g. 48h or 7d"},
+			{Name: "tier", In: "query", Type: "string", Description: "Only markets in this overall tier, the lower of their liquidity and volume tiers", Enum: tiers},
+			{Name: "liquidity_tier", In: "query", Type: "string", Description: "Only markets in this liquidity tier: low under $10K, high $100K and up", Enum: tiers},
+			{Name: "volume_tier", In: "query", Type: "string", Description: "Only markets in this 24h volume tier: low under $10K, high $250K and up", Enum: tiers},
+		},
+		Response: models.Market{}, List: true,
+	},
+	{Method: "GET", Path: "/api/markets/trending", Summary: "Trending markets", Tag: "Markets", Params: []apiParam{limitParam(20), fieldsParam(), includeParam()}, Response: models.Market{}, List: true},
+	{
+		Method: "GET", Path: "/api/markets/trending/{category}", Summary: "Trending markets in a category", Tag: "Markets",
+		Params:   []apiParam{pathParam("category", "Category slug"), limitParam(20), fieldsParam(), includeParam()},
+		Response: models.Market{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/breaking", Summary: "Markets with significant probability moves", Tag: "Markets",
+		Params: []apiParam{
+			limitParam(20),
+			{
+				Name: "window", In: "query", Type: "string", Default: storage.ChangeWindow24h,
+				Description: "Window the change is measured over",
+				Enum:        []string{storage.ChangeWindow1h, storage.ChangeWindow6h, storage.ChangeWindow24h},
+			},
+			{
+				Name: "threshold", In: "query", Type: "number", Default: 0.05,
+				Description:      "Minimum absolute probability change",
+				ExclusiveMinimum: bound(0), ExclusiveMaximum: bound(1),
+			},
+			fieldsParam(),
+			includeParam(),
+		},
+		Response: models.Market{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/closing-soon", Summary: "Open markets ending soon, soonest first", Tag: "Markets",
+		Params: []apiParam{
+			limitParam(20),
+			fieldsParam(),
+			includeParam(),
+			{
+				Name: "days", In: "query", Type: "integer", Default: closingSoonDefaultDays,
+				Description: "How many days ahead to look",
+				Minimum:     bound(1), Maximum: bound(closingSoonMaxDays),
+			},
+		},
+		Response: models.Market{}, List: true,
+	},
+	{Method: "GET", Path: "/api/markets/new", Summary: "Recently listed markets", Tag: "Markets", Params: []apiParam{limitParam(20), fieldsParam(), includeParam()}, Response: models.Market{}, List: true},
+	{
+		Method: "GET", Path: "/api/markets/category/{category}", Summary: "Markets in a category", Tag: "Markets",
+		Params:   []apiParam{pathParam("category", "Category slug"), limitParam(20), fieldsParam(), includeParam()},
+		Response: models.Market{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/{slug}", Summary: "A market", Tag: "Markets",
+		Params:   []apiParam{pathParam("slug", "Market slug"), includeParam()},
+		Response: models.Market{},
+	},
+	{
+		Method: "GET", Path: "/api/markets/{slug}/related", Summary: "Markets whose moves correlate with a market's", Tag: "Markets",
+		Params:   []apiParam{pathParam("slug", "Market slug"), limitParam(10)},
+		Response: models.RelatedMarket{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/{slug}/articles", Summary: "Articles covering a market", Tag: "Markets",
+		Params:   []apiParam{pathParam("slug", "Market slug"), limitParam(50), fieldsParam()},
+		Response: models.Article{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/{slug}/timeline", Summary: "Price points, events and articles for a market in time order", Tag: "Markets",
+		Params: []apiParam{
+			pathParam("slug", "Market slug"),
+			{
+				Name: "days", In: "query", Type: "integer", Default: timelineDefaultDays,
+				Description: "How many days back the timeline goes",
+				Minimum:     bound(1), Maximum: bound(timelineMaxDays),
+			},
+		},
+		Response: models.TimelineEntry{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/markets/{slug}/signals", Summary: "Social posts correlated with a market", Tag: "Signals",
+		Params:   []apiParam{pathParam("slug", "Market slug"), limitParam(10)},
+		Response: models.StoredSocialSignal{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/events/{slug}", Summary: "A Polymarket event with its markets, most likely outcome first", Tag: "Markets",
+		Params:   []apiParam{pathParam("slug", "Event slug")},
+		Response: eventResponse{},
+	},
+	{
+		Method: "GET", Path: "/api/events/{slug}/dashboard", Summary: "Candidate odds over time, top movers and coverage for an event", Tag: "Markets",
+		Params: []apiParam{
+			pathParam("slug", "Event slug"),
+			limitParam(20),
+			{
+				Name: "days", In: "query", Type: "integer", Default: dashboardDefaultDays,
+				Description: "How many days of probability history each candidate carries",
+				Minimum:     bound(1), Maximum: bound(dashboardMaxDays),
+			},
+		},
+		Response: eventDashboard{},
+	},
+
+	{Method: "GET", Path: "/api/categories", Summary: "Categories", Tag: "Categories", Response: models.Category{}, List: true},
+	{Method: "GET", Path: "/api/categories/sentiment", Summary: "Momentum and sentiment per category", Tag: "Categories", Response: models.CategorySentiment{}, List: true},
+	{
+		Method: "GET", Path: "/api/categories/{slug}", Summary: "A category with its top markets and latest articles", Tag: "Categories",
+		Params:   []apiParam{pathParam("slug", "Category slug")},
+		Response: categoryPage{},
+	},
+	{Method: "GET", Path: "/api/sentiment", Summary: "Momentum and sentiment per category", Tag: "Categories", Response: models.CategorySentiment{}, List: true},
+	{
+		Method: "GET", Path: "/api/sentiment/{category}", Summary: "Momentum and sentiment for one category", Tag: "Categories",
+		Params:   []apiParam{pathParam("category", "Category slug")},
+		Response: models.CategorySentiment{},
+	},
+
+	{
+		Method: "GET", Path: "/api/signals", Summary: "Recent social posts correlated with market moves", Tag: "Signals",
+		Params: []apiParam{
+			limitParam(20),
+			{
+				Name: "hours", In: "query", Type: "integer", Default: signalsDefaultHours,
+				Description: "How many hours back the feed goes",
+				Minimum:     bound(1), Maximum: bound(signalsMaxHours),
+			},
+		},
+		Response: models.StoredSocialSignal{}, List: true,
+	},
+
+	{Method: "GET", Path: "/api/tags", Summary: "Most used tags", Tag: "Tags", Params: []apiParam{limitParam(50)}, Response: models.TagCount{}, List: true},
+	{
+		Method: "GET", Path: "/api/tags/{tag}", Summary: "Latest articles and markets with a tag", Tag: "Tags",
+		Params:   []apiParam{pathParam("tag", "Tag, in any case or spacing"), limitParam(20)},
+		Response: tagResponse{},
+	},
+
+	{
+		Method: "GET", Path: "/api/v1/content/articles", Summary: "Published articles in the v1 delivery schema, newest first", Tag: "Content",
+		Params: []apiParam{
+			limitParam(20),
+			offsetParam(),
+			{Name: "type", In: "query", Type: "string", Description: "Only articles of this type", Enum: articleTypes},
+			{Name: "category", In: "query", Type: "string", Description: "Only articles in this category"},
+			{Name: "updated_since", In: "query", Type: "string", Description: "Only articles changed at or after this RFC 3339 time, for incremental syncs"},
+		},
+		Response: contentArticle{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/v1/content/articles/{slug}", Summary: "A published article in the v1 delivery schema", Tag: "Content",
+		Params: []apiParam{
+			pathParam("slug", "Article slug"),
+			{Name: "preview", In: "query", Type: "string", Description: "Preview token from the admin API; also returns the article as a draft"},
+		},
+		Response: contentArticle{},
+	},
+	{
+		Method: "GET", Path: "/api/v1/content/markets", Summary: "Open markets in the v1 delivery schema, by 24h volume", Tag: "Content",
+		Params: []apiParam{
+			limitParam(20),
+			offsetParam(),
+			{Name: "category", In: "query", Type: "string", Description: "Only markets in this category"},
+		},
+		Response: contentMarket{}, List: true,
+	},
+	{
+		Method: "GET", Path: "/api/v1/content/markets/{slug}", Summary: "A market in the v1 delivery schema", Tag: "Content",
+		Params:   []apiParam{pathParam("slug", "Market slug")},
+		Response: contentMarket{},
+	},
+}
+
+// ============================================================================
+// SPEC
+// ============================================================================
+
+// newOpenAPISpec builds an OpenAPI 3.1 document for operations, deriving
+// response schemas from the payload types' JSON encoding.
+func newOpenAPISpec(operations []apiOperation) map[string]any {
+	b := &schemaBuilder{schemas: make(map[string]any)}
+	b.schemas["Meta"] = b.structSchema(reflect.TypeOf(Meta{}))
+	b.schemas["Error"] = b.structSchema(reflect.TypeOf(APIError{}))
+
+	errorResponse := map[string]any{
+		"description": "The request failed",
+		"content": map[string]any{"application/json": map[string]any{"schema": map[string]any{
+			"type":       "object",
+			"required":   []string{"error"},
+			"properties": map[string]any{"error": schemaRef("Error")},
+		}}},
+	}
+
+	paths := make(map[string]any)
+	for _, op := range operations {
+		data := b.schema(reflect.TypeOf(op.Response))
+		envelope := map[string]any{
+			"type":       "object",
+			"required":   []string{"data"},
+			"properties": map[string]any{"data": data},
+		}
+		if op.List {
+			envelope["required"] = []string{"data", "meta"}
+			envelope["properties"] = map[string]any{
+				"data": map[string]any{"type": "array", "items": data},
+				"meta": schemaRef("Meta"),
+			}
+		}
+
+		params := make([]any, 0, len(op.Params))
+		for _, p := range op.Params {
+			params = append(params, map[string]any{
+				"name":        p.Name,
+				"in":          p.In,
+				"required":    p.In == "path",
+				"description": p.Description,
+				"schema":      p.schema(),
+			})
+		}
+
+		path, _ := paths[op.Path].(map[string]any)
+		if path == nil {
+			path = make(map[string]any)
+			paths[op.Path] = path
+		}
+		path[strings.ToLower(op.Method)] = map[string]any{
+			"summary":    op.Summary,
+			"tags":       []string{op.Tag},
+			"parameters": params,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     map[string]any{"application/json": map[string]any{"schema": envelope}},
+				},
+				"400":     errorResponse,
+				"default": errorResponse,
+			},
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "FutureSignals API",
+			"version":     "1",
+			"description": "Prediction market news. Successful responses carry their payload in data, lists also carry meta; failed requests carry error instead.",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": b.schemas},
+	}
+}
+
+func (p apiParam) schema() map[string]any {
+	s := map[string]any{"type": p.Type}
+	if p.Default != nil {
+		s["default"] = p.Default
+	}
+	if len(p.Enum) > 0 {
+		s["enum"] = p.Enum
+	}
+	for key, v := range map[string]*float64{
+		"minimum":          p.Minimum,
+		"maximum":          p.Maximum,
+		"exclusiveMinimum": p.ExclusiveMinimum,
+		"exclusiveMaximum": p.ExclusiveMaximum,
+	} {
+		if v != nil {
+			s[key] = *v
+		}
+	}
+	return s
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+// schemaBuilder collects named struct schemas as it walks payload types.
+type schemaBuilder struct {
+	schemas map[string]any
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case objectIDType:
+		return map[string]any{"type": "string", "description": "Hex object ID"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := b.schemas[name]; !ok {
+			// Reserve the name first so self-referencing types terminate
+			b.schemas[name] = map[string]any{}
+			b.schemas[name] = b.structSchema(t)
+		}
+		return schemaRef(name)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema describes t's JSON object, with fields not marked omitempty
+// required and embedded structs flattened in.
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	b.addFields(t, properties, &required)
+
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (b *schemaBuilder) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// schemaName is a component name for a named type, e.g. Market for
+// models.Market and ArticleResponse for articleResponse.
+func schemaName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
+
+// ============================================================================
+// VALIDATION
+// ============================================================================
+
+// validateRequests rejects requests to documented operations whose path or
+// query parameters fall outside the spec with 400, before they reach the
+// handler. Routes are resolved against mux, which must be the root router.
+func validateRequests(mux *chi.Mux, operations []apiOperation) func(http.Handler) http.Handler {
+	byRoute := make(map[string]*apiOperation, len(operations))
+	for i, op := range operations {
+		byRoute[op.Method+" "+op.Path] = &operations[i]
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			pattern := mux.Find(rctx, r.Method, r.URL.Path)
+			if pattern != "/" {
+				pattern = strings.TrimSuffix(pattern, "/")
+			}
+
+			op := byRoute[r.Method+" "+pattern]
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			query := r.URL.Query()
+			for _, p := range op.Params {
+				value := query.Get(p.Name)
+				if p.In == "path" {
+					value = rctx.URLParam(p.Name)
+				}
+				if value == "" {
+					continue
+				}
+				if msg := p.check(value); msg != "" {
+					respondError(w, http.StatusBadRequest, msg)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// check returns why value is not valid for p, or "" if it is.
+func (p apiParam) check(value string) string {
+	if len(p.Enum) > 0 {
+		for _, allowed := range p.Enum {
+			if value == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%s must be one of %s", p.Name, strings.Join(p.Enum, ", "))
+	}
+
+	var n float64
+	switch p.Type {
+	case "integer":
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return p.Name + " must be an integer"
+		}
+		n = float64(parsed)
+	case "number":
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return p.Name + " must be a number"
+		}
+		n = parsed
+	default:
+		return ""
+	}
+
+	low, high := p.Minimum, p.Maximum
+	if p.ExclusiveMinimum != nil {
+		low = p.ExclusiveMinimum
+	}
+	if p.ExclusiveMaximum != nil {
+		high = p.ExclusiveMaximum
+	}
+	if (p.Minimum != nil && n < *p.Minimum) ||
+		(p.ExclusiveMinimum != nil && n <= *p.ExclusiveMinimum) ||
+		(p.Maximum != nil && n > *p.Maximum) ||
+		(p.ExclusiveMaximum != nil && n >= *p.ExclusiveMaximum) {
+		switch {
+		case low != nil && high != nil:
+			return fmt.Sprintf("%s must be between %g and %g", p.Name, *low, *high)
+		case low != nil:
+			return fmt.Sprintf("%s must be at least %g", p.Name, *low)
+		default:
+			return fmt.Sprintf("%s must be at most %g", p.Name, *high)
+		}
+	}
+	return ""
+}
+
+// ServeOpenAPI returns the OpenAPI document for the public read API.
+func (s *Server) ServeOpenAPI(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, s.openAPISpec)
+}
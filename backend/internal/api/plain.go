@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/staticsite"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// plainArticle is an article flattened for AMP pages, reader modes and
+// voice assistants: sanitized headline and sections, concatenated as
+// Markdown and as plain text.
+type plainArticle struct {
+	Slug        string    `json:"slug" bson:"slug"`
+	URL         string    `json:"url" bson:"url"`
+	Headline    string    `json:"headline" bson:"headline"`
+	Markdown    string    `json:"markdown" bson:"markdown"`
+	Text        string    `json:"text" bson:"text"`
+	PublishedAt time.Time `json:"published_at" bson:"published_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// GetArticlePlain returns an article's plain content, rendered on the fly
+// and cached until the article changes.
+func (h *Handlers) GetArticlePlain(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	plain, err := cachedRead(r.Context(), h, cache.GroupArticles, "plain:"+slug, func() (plainArticle, error) {
+		article, err := h.store.GetArticleBySlug(r.Context(), slug)
+		if err != nil {
+			return plainArticle{}, err
+		}
+		if article.DeletedAt != nil || !article.Published {
+			return plainArticle{}, mongo.ErrNoDocuments
+		}
+
+		content, err := staticsite.RenderContent(article, h.siteURL)
+		if err != nil {
+			return plainArticle{}, err
+		}
+
+		url := article.CanonicalURL
+		if url == "" {
+			url = h.siteURL + "/article/" + article.Slug
+		}
+		return plainArticle{
+			Slug:        article.Slug,
+			URL:         url,
+			Headline:    content.Headline,
+			Markdown:    content.Markdown,
+			Text:        content.Text,
+			PublishedAt: article.PublishedAt,
+			UpdatedAt:   article.UpdatedAt,
+		}, nil
+	})
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	case err != nil:
+		log.Warn().Err(err).Str("slug", slug).Msg("Failed to render plain article")
+		respondError(w, http.StatusInternalServerError, "Failed to render article")
+		return
+	}
+
+	respondData(w, plain)
+}
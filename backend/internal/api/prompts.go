@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SetGenerator enables prompt previews.
+func (s *Server) SetGenerator(g *content.Generator) {
+	s.generator = g
+}
+
+// AdminListPrompts lists the loaded prompt templates and their versions.
+func (s *Server) AdminListPrompts(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"prompts": prompts.Default().List(),
+	})
+}
+
+// AdminPreviewPrompt renders a prompt for ?market=<slug> as it would be sent
+// to the LLM, without generating an article. ?type= selects an article
+// type's override.
+func (s *Server) AdminPreviewPrompt(w http.ResponseWriter, r *http.Request) {
+	if s.generator == nil {
+		respondError(w, http.StatusServiceUnavailable, "Content generator not available")
+		return
+	}
+
+	slug := r.URL.Query().Get("market")
+	if slug == "" {
+		respondError(w, http.StatusBadRequest, "market is required")
+		return
+	}
+	market, err := s.store.GetMarketBySlug(r.Context(), slug)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	case err != nil:
+		log.Error().Err(err).Str("slug", slug).Msg("Failed to get market")
+		respondError(w, http.StatusInternalServerError, "Failed to get market")
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	prompt, err := s.generator.PreviewPrompt(r.Context(), name, models.ArticleType(r.URL.Query().Get("type")), market)
+	switch {
+	case errors.Is(err, content.ErrNoPreviewArticle):
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prompt)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+)
+
+// rateLimitExemptPaths are /api prefixes outside the public rate limit:
+// admin routes have per-key limits of their own, health checks come from
+// the orchestrator, and a page embeds many proxied images.
+var rateLimitExemptPaths = []string{"/api/admin/", "/api/health", "/api/assets/"}
+
+// RateLimitConfig limits public API requests with token buckets: one per
+// client IP, or one per API key or signed-in user when the request carries
+// valid credentials.
+type RateLimitConfig struct {
+	// Requests per second and burst size per client IP; a zero rate
+	// disables the limit
+	IPRate  float64
+	IPBurst int
+
+	// Requests per second and burst size per credential
+	KeyRate  float64
+	KeyBurst int
+
+	// Exempt lists client IPs, CIDR ranges and API key names that are
+	// never limited. The frontend's server-side renderer belongs here:
+	// its requests carry its own egress IP, shared by every reader.
+	Exempt []string
+}
+
+// rateLimiter applies a RateLimitConfig.
+type rateLimiter struct {
+	config  RateLimitConfig
+	authn   *auth.Authenticator
+	limiter *ratelimit.Limiter
+
+	exemptNets  []*net.IPNet
+	exemptNames map[string]bool
+}
+
+func newRateLimiter(cfg RateLimitConfig, authn *auth.Authenticator) *rateLimiter {
+	rl := &rateLimiter{
+		config:      cfg,
+		authn:       authn,
+		limiter:     ratelimit.New(10 * time.Minute),
+		exemptNames: make(map[string]bool),
+	}
+	var names []string
+	rl.exemptNets, names = parseIPNets(cfg.Exempt)
+	for _, name := range names {
+		rl.exemptNames[name] = true
+	}
+	return rl
+}
+
+// Middleware rejects public API requests over their bucket's budget with
+// 429 and a Retry-After header.
+func (rl *rateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl.config.IPRate <= 0 || !rl.limited(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := clientIP(r)
+		if rl.exemptIP(ip) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		kind, key := "ip", "ip:"+ip
+		rate, burst := rl.config.IPRate, rl.config.IPBurst
+		r, principal := rl.authn.Resolve(r)
+		if principal != nil {
+			if rl.exemptNames[principal.Subject] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			kind, key = "key", "key:"+principal.LimitKey()
+			rate, burst = rl.config.KeyRate, rl.config.KeyBurst
+		}
+
+		if ok, wait := rl.limiter.ReserveRate(key, rate, burst); !ok {
+			metrics.RateLimited.WithLabelValues(kind).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			respondError(w, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limited reports whether path is under the public rate limit.
+func (rl *rateLimiter) limited(path string) bool {
+	if !strings.HasPrefix(path, "/api/") {
+		return false
+	}
+	for _, prefix := range rateLimitExemptPaths {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (rl *rateLimiter) exemptIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && containsIP(rl.exemptNets, parsed)
+}
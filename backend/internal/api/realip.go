@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// parseIPNets splits entries into IP networks, with single IPs as /32 or
+// /128 networks, and the entries that are neither.
+func parseIPNets(entries []string) ([]*net.IPNet, []string) {
+	var nets []*net.IPNet
+	var rest []string
+	for _, entry := range entries {
+		cidr := entry
+		if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+			cidr += "/32"
+		} else if ip != nil {
+			cidr += "/128"
+		}
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			nets = append(nets, ipNet)
+		} else {
+			rest = append(rest, entry)
+		}
+	}
+	return nets, rest
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// realIP sets RemoteAddr to the client address a trusted proxy reports in
+// header: X-Forwarded-For, or a single-address header such as
+// CF-Connecting-IP. Requests from other peers keep their own address, so
+// clients can't pick their IP (and rate limit bucket) with a header.
+func realIP(trusted []*net.IPNet, header string) func(http.Handler) http.Handler {
+	if header == "" {
+		header = "X-Forwarded-For"
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if peer := net.ParseIP(clientIP(r)); peer != nil && containsIP(trusted, peer) {
+				if ip := forwardedIP(r, trusted, header); ip != "" {
+					r.RemoteAddr = ip
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP returns the client address in a trusted proxy's header.
+func forwardedIP(r *http.Request, trusted []*net.IPNet, header string) string {
+	if !strings.EqualFold(header, "X-Forwarded-For") {
+		if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(header))); ip != nil {
+			return ip.String()
+		}
+		return ""
+	}
+
+	// Each proxy appends the address it received from; the first hop from
+	// the right that isn't a trusted proxy is the client
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			return ""
+		}
+		if !containsIP(trusted, ip) {
+			return ip.String()
+		}
+	}
+	return ""
+}
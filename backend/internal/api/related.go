@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// relatedArticlesLimit is how many related articles an article page shows.
+	relatedArticlesLimit = 5
+
+	// relatedCandidateWindow and relatedCandidateLimit bound the articles
+	// considered: recent ones sharing a market, tag or category.
+	relatedCandidateWindow = 30 * 24 * time.Hour
+	relatedCandidateLimit  = 100
+
+	// relatedHalfLife halves a candidate's score for each week of age.
+	relatedHalfLife = 7 * 24 * time.Hour
+
+	// relatedCacheTTL is how long an article's related list is reused, and
+	// relatedCacheSize how many articles' lists are kept.
+	relatedCacheTTL  = 10 * time.Minute
+	relatedCacheSize = 2000
+)
+
+// Signal weights: a shared market says far more than a shared tag. Only the
+// first couple of shared markets and few shared tags count, so briefings
+// covering dozens of markets don't outrank a story on the same market.
+const (
+	relatedMarketWeight   = 3.0
+	relatedMaxMarkets     = 2
+	relatedTagWeight      = 1.0
+	relatedMaxTags        = 3
+	relatedCategoryWeight = 1.0
+)
+
+// genericTags are added to every article of a type by the generator, so
+// sharing one says nothing about the story.
+var genericTags = map[string]bool{
+	"briefing": true, "daily": true, "markets": true, "trending": true,
+	"hot": true, "new": true, "market": true, "digest": true,
+	"analysis": true, "update": true, "resolved": true,
+	"morning": true, "midday": true, "evening": true, "weekly": true,
+}
+
+type cachedRelated struct {
+	articles []models.RelatedArticle
+	expires  time.Time
+}
+
+// relatedArticles recommends articles to read next, scored at read time by
+// shared markets, shared tags and category, decayed by age, and cached per
+// article for a short TTL.
+type relatedArticles struct {
+	store storage.ArticleStore
+
+	mu    sync.Mutex
+	cache map[primitive.ObjectID]cachedRelated
+}
+
+func newRelatedArticles(store storage.ArticleStore) *relatedArticles {
+	return &relatedArticles{store: store, cache: map[primitive.ObjectID]cachedRelated{}}
+}
+
+// For returns the articles related to article, best first.
+func (ra *relatedArticles) For(ctx context.Context, article *models.Article) ([]models.RelatedArticle, error) {
+	ra.mu.Lock()
+	cached, ok := ra.cache[article.ID]
+	ra.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.articles, nil
+	}
+
+	candidates, err := ra.store.GetRelatedArticleCandidates(ctx, article, relatedCandidateWindow, relatedCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	related := rankRelated(article, candidates, time.Now())
+
+	ra.mu.Lock()
+	// Drop everything rather than track recency; the lists are cheap to rebuild
+	if len(ra.cache) >= relatedCacheSize {
+		ra.cache = map[primitive.ObjectID]cachedRelated{}
+	}
+	ra.cache[article.ID] = cachedRelated{articles: related, expires: time.Now().Add(relatedCacheTTL)}
+	ra.mu.Unlock()
+	return related, nil
+}
+
+// rankRelated scores candidates against article and returns the best ones.
+// Articles in the same follow-up chain are left out; the page lists them
+// already.
+func rankRelated(article *models.Article, candidates []models.Article, now time.Time) []models.RelatedArticle {
+	markets := make(map[string]bool, len(article.Markets))
+	for _, m := range article.Markets {
+		markets[m.MarketID] = true
+	}
+	tags := make(map[string]bool, len(article.Tags))
+	for _, t := range article.Tags {
+		if !genericTags[t] {
+			tags[t] = true
+		}
+	}
+	chain := make(map[primitive.ObjectID]bool, len(article.Updates)+1)
+	for _, id := range article.Updates {
+		chain[id] = true
+	}
+	if article.PreviousArticleID != nil {
+		chain[*article.PreviousArticleID] = true
+	}
+
+	related := make([]models.RelatedArticle, 0, len(candidates))
+	for _, c := range candidates {
+		if chain[c.ID] || (c.PreviousArticleID != nil && *c.PreviousArticleID == article.ID) {
+			continue
+		}
+
+		sharedMarkets := 0
+		for _, m := range c.Markets {
+			if markets[m.MarketID] {
+				sharedMarkets++
+			}
+		}
+		sharedTags := 0
+		for _, t := range c.Tags {
+			if tags[t] {
+				sharedTags++
+			}
+		}
+
+		score := relatedMarketWeight*float64(min(sharedMarkets, relatedMaxMarkets)) +
+			relatedTagWeight*float64(min(sharedTags, relatedMaxTags))
+		if c.Category == article.Category {
+			score += relatedCategoryWeight
+		}
+		if score == 0 {
+			continue
+		}
+		age := max(now.Sub(c.PublishedAt), 0)
+		score *= math.Pow(0.5, float64(age)/float64(relatedHalfLife))
+
+		related = append(related, models.RelatedArticle{
+			ID:          c.ID,
+			Slug:        c.Slug,
+			Type:        c.Type,
+			Category:    c.Category,
+			Headline:    c.Headline,
+			Summary:     c.Summary,
+			OGImageURL:  c.OGImageURL,
+			PublishedAt: c.PublishedAt,
+			Score:       math.Round(score*1000) / 1000,
+		})
+	}
+
+	sort.SliceStable(related, func(i, j int) bool { return related[i].Score > related[j].Score })
+	return related[:min(len(related), relatedArticlesLimit)]
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/leeaandrob/futuresignals/internal/storage"
+)
+
+// SetRetention sets the retention policies the retention report is drawn
+// up for.
+func (s *Server) SetRetention(r storage.Retention) {
+	s.retention = r
+}
+
+// AdminGetRetention reports what the next retention purge would delete
+// from each collection, without deleting anything.
+func (s *Server) AdminGetRetention(w http.ResponseWriter, r *http.Request) {
+	reports, err := s.store.ApplyRetention(r.Context(), s.retention, true)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count documents past retention")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"collections": reports,
+		"count":       len(reports),
+	})
+}
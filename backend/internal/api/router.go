@@ -2,62 +2,188 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/newsletter"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
 	"github.com/leeaandrob/futuresignals/internal/scheduler"
+	"github.com/leeaandrob/futuresignals/internal/settings"
 	"github.com/leeaandrob/futuresignals/internal/storage"
 	syncer "github.com/leeaandrob/futuresignals/internal/sync"
 	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Server represents the API server.
 type Server struct {
 	router    *chi.Mux
+	store     *storage.Store
 	handlers  *Handlers
 	syncer    *syncer.Syncer
 	scheduler *scheduler.Scheduler
 	addr      string
 	server    *http.Server
+
+	// Open SSE connections
+	streams atomic.Int32
+
+	// Newsletter signups (nil when no mailer is configured)
+	newsletter *newsletter.Service
+	accounts   *auth.Accounts
+	siteURL    string
+	limiter    *ratelimit.Limiter
+
+	// Renders prompt previews (nil until SetGenerator)
+	generator *content.Generator
+
+	// Runtime settings (nil until SetSettings)
+	settings *settings.Watcher
+
+	// Retention policies for the dry-run report (none until SetRetention)
+	retention storage.Retention
+
+	// Content API draft previews (off without a secret) and change
+	// notifications (nil until SetContentNotifier)
+	previewSecret   string
+	previewTTL      time.Duration
+	contentNotifier ContentNotifier
+
+	// Served at /api/openapi.json
+	openAPISpec map[string]any
+}
+
+// ServerConfig holds configuration for the API server.
+type ServerConfig struct {
+	// Address to listen on, e.g. ":8080"
+	Addr string
+
+	// Public site URL used for links in sitemaps, e.g. "https://futuresignals.news"
+	SiteURL string
+
+	// Admin API authentication: an env-provided bootstrap key with admin
+	// scope, an optional HS256 JWT secret, and the default per-key rate
+	// limit in requests per minute
+	AdminAPIKey    string
+	AdminJWTSecret string
+	AdminRateLimit int
+
+	// SessionSecret verifies user session tokens; user sign-in is off when
+	// empty
+	SessionSecret string
+
+	// OGImageDir is served at /og/ when share images are stored locally
+	OGImageDir string
+
+	// RateLimit limits public API requests per client IP and per credential
+	RateLimit RateLimitConfig
+
+	// TrustedProxies lists the IPs and CIDR ranges of proxies in front of
+	// the API, whose ClientIPHeader (X-Forwarded-For when empty) gives the
+	// client address. Forwarding headers from other peers are ignored.
+	TrustedProxies []string
+	ClientIPHeader string
+
+	// MarketMaxAge is how long clients and CDNs may reuse market responses
+	// before revalidating their ETag (15s when zero)
+	MarketMaxAge time.Duration
+
+	// ContentPreviewSecret signs draft preview tokens for the content API,
+	// which are off when it is empty; tokens last up to ContentPreviewTTL
+	// (24h when zero)
+	ContentPreviewSecret string
+	ContentPreviewTTL    time.Duration
 }
 
 // NewServer creates a new API server.
-func NewServer(store *storage.Store, s *syncer.Syncer, sched *scheduler.Scheduler, addr string) *Server {
-	handlers := NewHandlers(store)
+func NewServer(store *storage.Store, s *syncer.Syncer, sched *scheduler.Scheduler, cfg ServerConfig) *Server {
+	handlers := NewHandlers(store, cfg.SiteURL)
+	handlers.syncer = s
+	sitemap := NewSitemap(store, cfg.SiteURL)
+	if cfg.MarketMaxAge <= 0 {
+		cfg.MarketMaxAge = defaultMarketMaxAge
+	}
+	if cfg.ContentPreviewTTL <= 0 {
+		cfg.ContentPreviewTTL = defaultPreviewTTL
+	}
+	authenticator := auth.NewAuthenticator(store, auth.Config{
+		BootstrapKey:     cfg.AdminAPIKey,
+		JWTSecret:        cfg.AdminJWTSecret,
+		DefaultRateLimit: cfg.AdminRateLimit,
+		SessionSecret:    cfg.SessionSecret,
+	})
 
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	trustedProxies, invalid := parseIPNets(cfg.TrustedProxies)
+	if len(invalid) > 0 {
+		log.Warn().Strs("entries", invalid).Msg("Ignoring trusted proxies that aren't IPs or CIDR ranges")
+	}
+	r.Use(realIP(trustedProxies, cfg.ClientIPHeader))
+	r.Use(metrics.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
+	r.Use(unlessStreaming(middleware.Timeout(30 * time.Second)))
 
 	// CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Content-Type", "Authorization", "X-API-Key", "X-Device-Token", SessionHeader},
+		ExposedHeaders:   []string{"Link", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
 
+	// Public API rate limits, per client IP or credential
+	r.Use(newRateLimiter(cfg.RateLimit, authenticator).Middleware)
+
+	// Reject read API parameters outside the OpenAPI spec
+	r.Use(validateRequests(r, apiOperations))
+
+	// Prometheus metrics
+	r.Handle("/metrics", metrics.Handler())
+
+	// Locally stored share images
+	if cfg.OGImageDir != "" {
+		r.Handle("/og/*", http.StripPrefix("/og/", http.FileServer(http.Dir(cfg.OGImageDir))))
+	}
+
+	// Sitemaps for search engines
+	r.Get("/sitemap.xml", sitemap.ServeIndex)
+	r.Get("/sitemap-articles-{page}.xml", sitemap.ServeArticles)
+	r.Get("/sitemap-markets-{page}.xml", sitemap.ServeMarkets)
+
 	// Routes
 	r.Route("/api", func(r chi.Router) {
 		// Health
 		r.Get("/health", handlers.HealthCheck)
 		r.Get("/stats", handlers.GetStats)
+		r.Get("/stats/accuracy", handlers.GetAccuracy)
+
+		// Proxied market images
+		r.Get("/assets/{hash}", handlers.GetAsset)
 
 		// Home feed
 		r.Get("/feed", handlers.GetHomeFeed)
 
 		// Articles
 		r.Route("/articles", func(r chi.Router) {
+			r.Use(conditionalGET(articleCacheControl))
 			r.Get("/", handlers.GetArticles)
 			r.Get("/today", handlers.GetTodayArticles)
 			r.Get("/breaking", handlers.GetBreakingArticles)
@@ -65,22 +191,41 @@ func NewServer(store *storage.Store, s *syncer.Syncer, sched *scheduler.Schedule
 			r.Get("/featured", handlers.GetFeaturedArticles)
 			r.Get("/type/{type}", handlers.GetArticlesByType)
 			r.Get("/category/{category}", handlers.GetArticlesByCategory)
-			r.Get("/{slug}", handlers.GetArticleBySlug)
+			r.With(authenticator.Identify).Get("/{slug}", handlers.GetArticleBySlug)
+			r.Get("/{slug}/plain", handlers.GetArticlePlain)
 		})
 
 		// Markets
 		r.Route("/markets", func(r chi.Router) {
-			r.Get("/", handlers.GetMarkets)
-			r.Get("/trending", handlers.GetTrendingMarkets)
-			r.Get("/breaking", handlers.GetBreakingMarkets)
-			r.Get("/new", handlers.GetNewMarkets)
-			r.Get("/category/{category}", handlers.GetMarketsByCategory)
-			r.Get("/{slug}", handlers.GetMarketBySlug)
+			r.Group(func(r chi.Router) {
+				r.Use(conditionalGET(marketCacheControl(cfg.MarketMaxAge)))
+				r.Get("/", handlers.GetMarkets)
+				r.Get("/trending", handlers.GetTrendingMarkets)
+				r.Get("/trending/{category}", handlers.GetCategoryTrendingMarkets)
+				r.Get("/breaking", handlers.GetBreakingMarkets)
+				r.Get("/new", handlers.GetNewMarkets)
+				r.Get("/closing-soon", handlers.GetClosingSoonMarkets)
+				r.Get("/category/{category}", handlers.GetMarketsByCategory)
+				r.Get("/{slug}", handlers.GetMarketBySlug)
+				r.Get("/{slug}/related", handlers.GetRelatedMarkets)
+				r.Get("/{slug}/timeline", handlers.GetMarketTimeline)
+				r.Get("/{slug}/signals", handlers.GetMarketSocialSignals)
+			})
+			// A list of articles, with per-reader headline variants
+			r.With(conditionalGET(articleCacheControl)).Get("/{slug}/articles", handlers.GetMarketArticles)
+		})
+
+		// Polymarket events and their markets
+		r.Route("/events", func(r chi.Router) {
+			r.Use(conditionalGET(marketCacheControl(cfg.MarketMaxAge)))
+			r.Get("/{slug}", handlers.GetEventBySlug)
+			r.Get("/{slug}/dashboard", handlers.GetEventDashboard)
 		})
 
 		// Categories
 		r.Route("/categories", func(r chi.Router) {
 			r.Get("/", handlers.GetCategories)
+			r.Get("/sentiment", handlers.GetSentiment)
 			r.Get("/{slug}", handlers.GetCategoryBySlug)
 		})
 
@@ -89,26 +234,182 @@ func NewServer(store *storage.Store, s *syncer.Syncer, sched *scheduler.Schedule
 			r.Get("/", handlers.GetSentiment)
 			r.Get("/{category}", handlers.GetCategorySentiment)
 		})
+
+		// Social signals
+		r.Get("/signals", handlers.GetSocialSignals)
+
+		// Tags
+		r.Route("/tags", func(r chi.Router) {
+			r.Get("/", handlers.GetTags)
+			r.Get("/{tag}", handlers.GetTag)
+		})
 	})
 
 	// Create server instance for admin routes closure
 	srv := &Server{
 		router:    r,
+		store:     store,
 		handlers:  handlers,
 		syncer:    s,
 		scheduler: sched,
-		addr:      addr,
+		addr:      cfg.Addr,
+		siteURL:   strings.TrimRight(cfg.SiteURL, "/"),
+		limiter:   ratelimit.New(time.Hour),
+
+		previewSecret: cfg.ContentPreviewSecret,
+		previewTTL:    cfg.ContentPreviewTTL,
+
+		openAPISpec: newOpenAPISpec(apiOperations),
 	}
 
-	// Admin routes (no auth for development)
+	// OpenAPI spec for the read API
+	r.Get("/api/openapi.json", srv.ServeOpenAPI)
+
+	// Content delivery API for partner sites and headless frontends
+	r.Route("/api/v1/content", func(r chi.Router) {
+		r.With(conditionalGET(articleCacheControl)).Get("/articles", srv.GetContentArticles)
+		r.With(conditionalGET(articleCacheControl)).Get("/articles/{slug}", srv.GetContentArticle)
+		r.With(conditionalGET(marketCacheControl(cfg.MarketMaxAge))).Get("/markets", srv.GetContentMarkets)
+		r.With(conditionalGET(marketCacheControl(cfg.MarketMaxAge))).Get("/markets/{slug}", srv.GetContentMarket)
+	})
+
+	// Reader analytics pings from article pages
+	r.Post("/api/analytics/events", srv.TrackEvent)
+
+	// Home feed ranked for the signed-in reader
+	r.With(authenticator.Identify).Get("/api/feed/personalized", srv.GetPersonalizedFeed)
+
+	// Live market events (Server-Sent Events)
+	r.Get("/api/stream/events", srv.StreamEvents)
+
+	// Newsletter (double opt-in)
+	r.Route("/api/newsletter", func(r chi.Router) {
+		r.Post("/subscribe", srv.Subscribe)
+		r.Get("/confirm", srv.ConfirmSubscription)
+		r.Get("/unsubscribe", srv.Unsubscribe)
+		r.Post("/unsubscribe", srv.Unsubscribe)
+	})
+
+	// User accounts (magic link or Google sign-in)
+	r.Route("/api/auth", func(r chi.Router) {
+		r.Post("/login", srv.RequestLogin)
+		r.Get("/verify", srv.VerifyLoginLink)
+		r.Post("/verify", srv.VerifyLogin)
+		r.Get("/google", srv.GoogleLogin)
+		r.Get("/google/callback", srv.GoogleCallback)
+		r.Post("/logout", srv.Logout)
+		r.With(authenticator.Optional).Get("/me", srv.GetCurrentUser)
+		r.With(authenticator.Optional).Get("/me/preferences", srv.GetNotificationPreferences)
+		r.With(authenticator.Optional).Put("/me/preferences", srv.UpdateNotificationPreferences)
+	})
+
+	// Watchlists, owned by an API key or an anonymous device token
+	r.Route("/api/watchlists", func(r chi.Router) {
+		r.Use(authenticator.Optional)
+
+		r.Get("/", srv.GetWatchlists)
+		r.Post("/", srv.CreateWatchlist)
+		r.Get("/{id}", srv.GetWatchlist)
+		r.Delete("/{id}", srv.DeleteWatchlist)
+		r.Put("/{id}/markets/{marketID}", srv.AddWatchlistMarket)
+		r.Delete("/{id}/markets/{marketID}", srv.RemoveWatchlistMarket)
+		r.Put("/{id}/alerts", srv.SetWatchlistAlerts)
+	})
+
+	// Admin routes (API key or JWT required)
 	r.Route("/api/admin", func(r chi.Router) {
-		// Force sync markets
-		r.Post("/sync", srv.AdminSyncNow)
-		r.Get("/debug", srv.AdminDebugSync)
+		r.Use(authenticator.Middleware)
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(models.ScopeRead))
+
+			r.Get("/debug", srv.AdminDebugSync)
+			r.Get("/sync/status", srv.AdminSyncStatus)
+			r.Get("/settings", srv.AdminGetSettings)
+			r.Get("/jobs", srv.AdminGetJobs)
+			r.Get("/jobs/{name}/runs", srv.AdminGetJobRuns)
+			r.Get("/calendar", srv.AdminListCalendar)
+			r.Get("/calendar/{id}", srv.AdminGetCalendarEntry)
+			r.Get("/explainers", srv.AdminListExplainers)
+			r.Get("/explainers/{id}", srv.AdminGetExplainer)
+			r.Get("/briefings", srv.AdminListBriefings)
+			r.Get("/briefings/{type}", srv.AdminGetBriefing)
+			r.Get("/tracked-accounts", srv.AdminListTrackedAccounts)
+			r.Get("/tracked-accounts/{id}", srv.AdminGetTrackedAccount)
+			r.Get("/events", srv.AdminGetPendingEvents)
+			r.Get("/analytics", srv.AdminGetAnalytics)
+			r.Get("/headline-tests", srv.AdminGetHeadlineTests)
+			r.Get("/retention", srv.AdminGetRetention)
+
+			// LLM cost tracking
+			r.Get("/llm-usage", srv.AdminGetLLMUsage)
+
+			// Prompt templates
+			r.Get("/prompts", srv.AdminListPrompts)
+			r.Get("/prompts/{name}/preview", srv.AdminPreviewPrompt)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(models.ScopeWrite))
+
+			// Force sync markets
+			r.Post("/sync", srv.AdminSyncNow)
+
+			// Runtime settings
+			r.Put("/settings", srv.AdminUpdateSettings)
+
+			// Job management
+			r.Post("/jobs/{name}/run", srv.AdminRunJob)
+
+			// Content calendar
+			r.Post("/calendar", srv.AdminCreateCalendarEntry)
+			r.Put("/calendar/{id}", srv.AdminUpdateCalendarEntry)
+			r.Delete("/calendar/{id}", srv.AdminCancelCalendarEntry)
+
+			// Explainer topic queue
+			r.Post("/explainers", srv.AdminCreateExplainer)
+			r.Post("/explainers/{id}/requeue", srv.AdminRequeueExplainer)
+			r.Delete("/explainers/{id}", srv.AdminDeleteExplainer)
+
+			// Briefing configs
+			r.Post("/briefings", srv.AdminCreateBriefing)
+			r.Patch("/briefings/{type}", srv.AdminUpdateBriefing)
+			r.Delete("/briefings/{type}", srv.AdminDeleteBriefing)
+
+			// Tracked social accounts
+			r.Post("/tracked-accounts", srv.AdminCreateTrackedAccount)
+			r.Patch("/tracked-accounts/{id}", srv.AdminUpdateTrackedAccount)
+			r.Delete("/tracked-accounts/{id}", srv.AdminDeleteTrackedAccount)
+
+			// Event replay
+			r.Post("/events/replay", srv.AdminReplayEvents)
+
+			// Article publication and market archive
+			r.Post("/articles/{slug}/unpublish", srv.AdminUnpublishArticle)
+			r.Post("/articles/{slug}/restore", srv.AdminRestoreArticle)
+			r.Delete("/articles/{slug}", srv.AdminDeleteArticle)
+			r.Post("/articles/{slug}/preview-token", srv.AdminCreatePreviewToken)
+			r.Post("/markets/{slug}/restore", srv.AdminRestoreMarket)
+
+			// Category taxonomy
+			r.Post("/categories", srv.AdminCreateCategory)
+			r.Patch("/categories/{slug}", srv.AdminUpdateCategory)
+			r.Post("/categories/{slug}/keywords", srv.AdminAddCategoryKeywords)
+			r.Delete("/categories/{slug}/keywords/{keyword}", srv.AdminRemoveCategoryKeyword)
+		})
+
+		// API key management
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(models.ScopeAdmin))
 
-		// Job management
-		r.Get("/jobs", srv.AdminGetJobs)
-		r.Post("/jobs/{name}/run", srv.AdminRunJob)
+			r.Get("/keys", srv.AdminListAPIKeys)
+			r.Post("/keys", srv.AdminCreateAPIKey)
+			r.Delete("/keys/{id}", srv.AdminRevokeAPIKey)
+
+			// Subscriber data
+			r.Get("/subscribers", srv.AdminListSubscribers)
+			r.Delete("/subscribers/{id}", srv.AdminDeleteSubscriber)
+		})
 	})
 
 	return srv
@@ -170,6 +471,33 @@ func (s *Server) AdminGetJobs(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// AdminGetJobRuns returns a job's recent attempts, newest first. ?status=
+// filters to succeeded, failed or dead (out of retries) runs.
+func (s *Server) AdminGetJobRuns(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	limit := getLimit(r, 50)
+
+	status := models.JobRunStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", models.JobRunSucceeded, models.JobRunFailed, models.JobRunDead:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be succeeded, failed or dead")
+		return
+	}
+
+	runs, err := s.store.GetJobRuns(r.Context(), name, status, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch job runs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job":   name,
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
 // AdminDebugSync fetches markets from Polymarket and returns debug info.
 func (s *Server) AdminDebugSync(w http.ResponseWriter, r *http.Request) {
 	if s.syncer == nil {
@@ -182,10 +510,22 @@ func (s *Server) AdminDebugSync(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"cached_market_count": len(markets),
+		"sync_stats":          s.syncer.GetStats(),
 		"markets":             markets,
 	})
 }
 
+// AdminSyncStatus reports the market sync's health: when it last
+// succeeded, what its last cycle did, and whether it has gone stale.
+func (s *Server) AdminSyncStatus(w http.ResponseWriter, r *http.Request) {
+	if s.syncer == nil {
+		respondError(w, http.StatusServiceUnavailable, "Syncer not available")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, s.syncer.Status())
+}
+
 // AdminRunJob runs a specific job by name.
 func (s *Server) AdminRunJob(w http.ResponseWriter, r *http.Request) {
 	if s.scheduler == nil {
@@ -209,3 +549,249 @@ func (s *Server) AdminRunJob(w http.ResponseWriter, r *http.Request) {
 		"message": "Job triggered: " + name,
 	})
 }
+
+// AdminGetPendingEvents lists syncer events the scheduler hasn't processed.
+func (s *Server) AdminGetPendingEvents(w http.ResponseWriter, r *http.Request) {
+	if s.syncer == nil {
+		respondError(w, http.StatusServiceUnavailable, "Syncer not available")
+		return
+	}
+
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > 500 {
+			respondError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		limit = parsed
+	}
+
+	events, pending, err := s.syncer.PendingEvents(r.Context(), scheduler.EventSubscriber, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load pending events")
+		return
+	}
+
+	items := make([]map[string]interface{}, len(events))
+	for i, e := range events {
+		items[i] = map[string]interface{}{
+			"id":        e.ID,
+			"type":      e.Type,
+			"market_id": e.Market.MarketID,
+			"question":  e.Market.Question,
+			"timestamp": e.Timestamp,
+			"metadata":  e.Metadata,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"subscriber": scheduler.EventSubscriber,
+		"pending":    pending,
+		"events":     items,
+	})
+}
+
+// AdminReplayEvents replays unprocessed syncer events into the scheduler in
+// the background. An optional limit caps how many are replayed.
+func (s *Server) AdminReplayEvents(w http.ResponseWriter, r *http.Request) {
+	if s.scheduler == nil || s.syncer == nil {
+		respondError(w, http.StatusServiceUnavailable, "Scheduler not available")
+		return
+	}
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			respondError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	_, pending, err := s.syncer.PendingEvents(r.Context(), scheduler.EventSubscriber, 1)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load pending events")
+		return
+	}
+	if limit > 0 && int64(limit) < pending {
+		pending = int64(limit)
+	}
+
+	go func() {
+		if _, err := s.scheduler.ReplayEvents(context.Background(), limit); err != nil {
+			log.Error().Err(err).Msg("Event replay failed")
+		}
+	}()
+
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"status":    "accepted",
+		"replaying": pending,
+	})
+}
+
+// AdminGetLLMUsage returns LLM token usage and estimated cost aggregated by
+// day or week, broken down by article type and model.
+func (s *Server) AdminGetLLMUsage(w http.ResponseWriter, r *http.Request) {
+	unit := "day"
+	days := 30
+	switch r.URL.Query().Get("period") {
+	case "", "daily":
+	case "weekly":
+		unit = "week"
+		days = 84
+	default:
+		respondError(w, http.StatusBadRequest, "period must be daily or weekly")
+		return
+	}
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed <= 0 || parsed > 365 {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	aggregates, err := s.store.GetLLMUsageAggregates(r.Context(), unit, since)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch LLM usage")
+		return
+	}
+
+	// Roll up per article type so briefings vs breaking is visible at a glance
+	type totals struct {
+		Requests      int     `json:"requests"`
+		TotalTokens   int     `json:"total_tokens"`
+		EstimatedCost float64 `json:"estimated_cost"`
+	}
+	var overall totals
+	byType := make(map[string]*totals)
+	for _, a := range aggregates {
+		key := a.ArticleType
+		if key == "" {
+			key = "untagged"
+		}
+		t, ok := byType[key]
+		if !ok {
+			t = &totals{}
+			byType[key] = t
+		}
+		t.Requests += a.Requests
+		t.TotalTokens += a.TotalTokens
+		t.EstimatedCost += a.EstimatedCost
+
+		overall.Requests += a.Requests
+		overall.TotalTokens += a.TotalTokens
+		overall.EstimatedCost += a.EstimatedCost
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"period":          unit,
+		"since":           since,
+		"buckets":         aggregates,
+		"by_article_type": byType,
+		"totals":          overall,
+		"currency":        "USD",
+	})
+}
+
+// AdminListAPIKeys returns all API keys. Hashes are never included.
+func (s *Server) AdminListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.store.ListAPIKeys(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch API keys")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"keys":  keys,
+		"count": len(keys),
+	})
+}
+
+// AdminCreateAPIKey creates an API key. The plaintext key is only returned
+// in this response.
+func (s *Server) AdminCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name      string   `json:"name"`
+		Scopes    []string `json:"scopes"`
+		RateLimit int      `json:"rate_limit"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if len(req.Scopes) == 0 {
+		respondError(w, http.StatusBadRequest, "at least one scope is required")
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(models.ValidScopes, scope) {
+			respondError(w, http.StatusBadRequest, "unknown scope: "+scope)
+			return
+		}
+	}
+	if req.RateLimit < 0 {
+		respondError(w, http.StatusBadRequest, "rate_limit must not be negative")
+		return
+	}
+
+	plaintext, prefix, hash, err := auth.GenerateKey()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+
+	key := &models.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Scopes:    req.Scopes,
+		RateLimit: req.RateLimit,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.store.CreateAPIKey(r.Context(), key); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save API key")
+		return
+	}
+
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		log.Info().Str("by", p.Subject).Str("name", key.Name).Strs("scopes", key.Scopes).Msg("API key created")
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"key":     plaintext,
+		"api_key": key,
+	})
+}
+
+// AdminRevokeAPIKey revokes an API key by ID.
+func (s *Server) AdminRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid key ID")
+		return
+	}
+
+	revoked, err := s.store.RevokeAPIKey(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to revoke API key")
+		return
+	}
+	if !revoked {
+		respondError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "API key revoked",
+	})
+}
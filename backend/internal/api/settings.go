@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/settings"
+	"github.com/rs/zerolog/log"
+)
+
+// SetSettings enables the runtime settings API.
+func (s *Server) SetSettings(w *settings.Watcher) {
+	s.settings = w
+}
+
+// AdminGetSettings returns the stored runtime settings and every setting's
+// value in effect, stored or configured.
+func (s *Server) AdminGetSettings(w http.ResponseWriter, r *http.Request) {
+	if s.settings == nil {
+		respondError(w, http.StatusServiceUnavailable, "Runtime settings not available")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"settings":  s.settings.Settings(),
+		"effective": s.settings.Effective(),
+	})
+}
+
+// AdminUpdateSettings replaces the runtime settings. Settings absent from
+// the body go back to their configured values. Every instance applies the
+// change without a restart.
+func (s *Server) AdminUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if s.settings == nil {
+		respondError(w, http.StatusServiceUnavailable, "Runtime settings not available")
+		return
+	}
+
+	var req models.RuntimeSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.settings.Save(r.Context(), &req); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save runtime settings")
+		return
+	}
+
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		log.Info().Str("by", p.Subject).Msg("Runtime settings updated")
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"settings":  s.settings.Settings(),
+		"effective": s.settings.Effective(),
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// signalsDefaultHours and signalsMaxHours bound the ?hours= window of
+	// the signals feed.
+	signalsDefaultHours = 24
+	signalsMaxHours     = 7 * 24
+)
+
+// GetSocialSignals returns the social signals posted in the last ?hours=
+// (default 24, at most a week), newest first.
+func (h *Handlers) GetSocialSignals(w http.ResponseWriter, r *http.Request) {
+	limit := getLimit(r, 20)
+
+	hours := signalsDefaultHours
+	if v := r.URL.Query().Get("hours"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > signalsMaxHours {
+			respondError(w, http.StatusBadRequest, "hours must be between 1 and 168")
+			return
+		}
+		hours = parsed
+	}
+
+	signals, err := h.store.GetRecentSocialSignals(r.Context(), time.Duration(hours)*time.Hour, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch signals")
+		return
+	}
+
+	respondList(w, signals, Meta{})
+}
+
+// GetMarketSocialSignals returns the latest social signals correlated with
+// a market, newest first, for its "who's posting about this" panel.
+func (h *Handlers) GetMarketSocialSignals(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	limit := getLimit(r, 10)
+
+	market, err := h.store.GetMarketBySlug(r.Context(), slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+
+	signals, err := h.store.GetMarketSocialSignals(r.Context(), market.MarketID, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch signals")
+		return
+	}
+
+	respondList(w, signals, Meta{MarketID: market.MarketID})
+}
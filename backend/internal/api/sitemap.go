@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// sitemapPageSize is the number of URLs per sitemap file (protocol max is 50,000).
+	sitemapPageSize = 10000
+
+	// sitemapCacheTTL is how long a rendered sitemap is served from memory.
+	sitemapCacheTTL = 10 * time.Minute
+
+	sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type cachedSitemap struct {
+	body    []byte
+	expires time.Time
+}
+
+// sitemapSource lists one kind of page in the sitemap.
+type sitemapSource struct {
+	path  string // URL path prefix on the site, e.g. "/article/"
+	count func(ctx context.Context) (int64, error)
+	page  func(ctx context.Context, skip, limit int64) ([]storage.SitemapEntry, error)
+}
+
+// Sitemap serves a sitemap index at /sitemap.xml and paginated sitemaps for
+// articles and markets, cached in memory for a short TTL.
+type Sitemap struct {
+	siteURL string
+	sources map[string]sitemapSource
+
+	mu    sync.Mutex
+	cache map[string]cachedSitemap
+}
+
+// NewSitemap creates a sitemap server for the given public site URL.
+func NewSitemap(store *storage.Store, siteURL string) *Sitemap {
+	return &Sitemap{
+		siteURL: strings.TrimRight(siteURL, "/"),
+		sources: map[string]sitemapSource{
+			"articles": {path: "/article/", count: store.CountSitemapArticles, page: store.GetSitemapArticles},
+			"markets":  {path: "/market/", count: store.CountSitemapMarkets, page: store.GetSitemapMarkets},
+		},
+		cache: make(map[string]cachedSitemap),
+	}
+}
+
+// ServeIndex writes the sitemap index listing every sitemap page.
+func (sm *Sitemap) ServeIndex(w http.ResponseWriter, r *http.Request) {
+	sm.serveCached(w, "index", func() (interface{}, error) {
+		index := sitemapIndex{XMLNS: sitemapXMLNS}
+		for _, kind := range []string{"articles", "markets"} {
+			total, err := sm.sources[kind].count(r.Context())
+			if err != nil {
+				return nil, fmt.Errorf("count %s: %w", kind, err)
+			}
+			pages := (total + sitemapPageSize - 1) / sitemapPageSize
+			for p := int64(1); p <= pages; p++ {
+				index.Sitemaps = append(index.Sitemaps, sitemapRef{
+					Loc: fmt.Sprintf("%s/sitemap-%s-%d.xml", sm.siteURL, kind, p),
+				})
+			}
+		}
+		return index, nil
+	})
+}
+
+// ServeArticles writes one page of the article sitemap.
+func (sm *Sitemap) ServeArticles(w http.ResponseWriter, r *http.Request) {
+	sm.servePage(w, r, "articles")
+}
+
+// ServeMarkets writes one page of the market sitemap.
+func (sm *Sitemap) ServeMarkets(w http.ResponseWriter, r *http.Request) {
+	sm.servePage(w, r, "markets")
+}
+
+func (sm *Sitemap) servePage(w http.ResponseWriter, r *http.Request, kind string) {
+	page, err := strconv.ParseInt(chi.URLParam(r, "page"), 10, 64)
+	if err != nil || page < 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	source := sm.sources[kind]
+	sm.serveCached(w, fmt.Sprintf("%s-%d", kind, page), func() (interface{}, error) {
+		entries, err := source.page(r.Context(), (page-1)*sitemapPageSize, sitemapPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", kind, err)
+		}
+
+		set := urlSet{XMLNS: sitemapXMLNS, URLs: make([]sitemapURL, 0, len(entries))}
+		for _, e := range entries {
+			u := sitemapURL{Loc: sm.siteURL + source.path + e.Slug}
+			if !e.UpdatedAt.IsZero() {
+				u.LastMod = e.UpdatedAt.UTC().Format(time.RFC3339)
+			}
+			set.URLs = append(set.URLs, u)
+		}
+		return set, nil
+	})
+}
+
+// serveCached writes the cached body for key, rebuilding it when expired.
+func (sm *Sitemap) serveCached(w http.ResponseWriter, key string, build func() (interface{}, error)) {
+	sm.mu.Lock()
+	cached, ok := sm.cache[key]
+	sm.mu.Unlock()
+
+	if !ok || time.Now().After(cached.expires) {
+		doc, err := build()
+		if err != nil {
+			log.Error().Err(err).Str("sitemap", key).Msg("Failed to build sitemap")
+			http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+			return
+		}
+
+		body, err := xml.Marshal(doc)
+		if err != nil {
+			log.Error().Err(err).Str("sitemap", key).Msg("Failed to encode sitemap")
+			http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+			return
+		}
+
+		cached = cachedSitemap{
+			body:    append([]byte(xml.Header), body...),
+			expires: time.Now().Add(sitemapCacheTTL),
+		}
+		sm.mu.Lock()
+		sm.cache[key] = cached
+		sm.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(sitemapCacheTTL.Seconds())))
+	w.WriteHeader(http.StatusOK)
+	w.Write(cached.body)
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// Sparkline points: hourly over the last day, every six hours over the
+// last week.
+const (
+	sparklineDayPoints  = 25
+	sparklineWeekPoints = 29
+	sparklineWeek       = 7 * 24 * time.Hour
+)
+
+// Sparkline is a market's recent probability, downsampled for mini-charts
+// on list pages. Points are evenly spaced, oldest first, and end with the
+// current probability; a market younger than the window has fewer.
+type Sparkline struct {
+	Day  []float64 `bson:"day" json:"day"`   // Last 24h, hourly
+	Week []float64 `bson:"week" json:"week"` // Last 7d, every 6h
+}
+
+// sparklineMarket is a market with its sparkline, for ?include=sparkline.
+type sparklineMarket struct {
+	models.Market
+	Sparkline *Sparkline `json:"sparkline,omitempty"`
+}
+
+// marketListFields parses ?fields= for a market list as listFields does,
+// also loading the fields sparklines are drawn from when ?include=sparkline
+// is set.
+func marketListFields(w http.ResponseWriter, r *http.Request) (fieldSelection, bool) {
+	sel, ok := listFields(w, r, marketFields)
+	if !ok {
+		return sel, false
+	}
+	include, ok := includeSparkline(w, r)
+	if !ok {
+		return sel, false
+	}
+	if include && len(sel.fields) > 0 {
+		sel.extra = []string{"market_id", "probability"}
+	}
+	return sel, true
+}
+
+// includeSparkline reports whether ?include= asks for sparklines,
+// answering 400 when it names anything else.
+func includeSparkline(w http.ResponseWriter, r *http.Request) (include, ok bool) {
+	for _, name := range strings.Split(r.URL.Query().Get("include"), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "sparkline":
+			include = true
+		default:
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("unknown include %q", name))
+			return false, false
+		}
+	}
+	return include, true
+}
+
+// respondMarkets writes markets as respondFields does, with each market's
+// sparkline when ?include=sparkline is set. Sparklines are cached with the
+// list under key. sel is parsed by marketListFields.
+func (h *Handlers) respondMarkets(w http.ResponseWriter, r *http.Request, key string, markets []models.Market, meta Meta, sel fieldSelection) {
+	include, ok := includeSparkline(w, r)
+	if !ok {
+		return
+	}
+	if !include {
+		respondFields(w, markets, meta, sel)
+		return
+	}
+
+	sparklines, err := h.sparklines(r.Context(), key, markets)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch sparklines")
+		return
+	}
+	items := make([]sparklineMarket, len(markets))
+	for i := range markets {
+		items[i] = sparklineMarket{Market: markets[i]}
+		if s, ok := sparklines[markets[i].MarketID]; ok {
+			items[i].Sparkline = &s
+		}
+	}
+	if len(sel.fields) > 0 {
+		sel.fields = append(sel.fields, "sparkline")
+	}
+	respondFields(w, items, meta, sel)
+}
+
+// sparklines loads the markets' sparklines from hourly rollups in one
+// query, cached under key.
+func (h *Handlers) sparklines(ctx context.Context, key string, markets []models.Market) (map[string]Sparkline, error) {
+	return cachedRead(ctx, h, cache.GroupMarkets, key+":sparkline", func() (map[string]Sparkline, error) {
+		ids := make([]string, len(markets))
+		for i := range markets {
+			ids[i] = markets[i].MarketID
+		}
+		// An hour more than the week, for the rollup the first point falls in
+		rollups, err := h.store.GetSnapshotRollupsForMarkets(ctx, ids, models.GranularityHour, sparklineWeek+time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		sparklines := make(map[string]Sparkline, len(markets))
+		for i := range markets {
+			m := &markets[i]
+			sparklines[m.MarketID] = Sparkline{
+				Day:  downsample(rollups[m.MarketID], m.Probability, 24*time.Hour, sparklineDayPoints, now),
+				Week: downsample(rollups[m.MarketID], m.Probability, sparklineWeek, sparklineWeekPoints, now),
+			}
+		}
+		return sparklines, nil
+	})
+}
+
+// downsample returns points probabilities evenly spaced over the window
+// ending at now, each the close of the latest rollup (newest first)
+// starting at or before its time, with the last point current. Times
+// before the first rollup are left out.
+func downsample(rollups []models.SnapshotRollup, current float64, window time.Duration, points int, now time.Time) []float64 {
+	step := window / time.Duration(points-1)
+	series := make([]float64, 0, points)
+	next := len(rollups) - 1 // Oldest rollup not yet passed
+	value, seen := 0.0, false
+	for i := 0; i < points-1; i++ {
+		at := now.Add(-window + time.Duration(i)*step)
+		for next >= 0 && !rollups[next].BucketStart.After(at) {
+			value, seen = rollups[next].Close, true
+			next--
+		}
+		if seen {
+			series = append(series, math.Round(value*1e4)/1e4)
+		}
+	}
+	return append(series, math.Round(current*1e4)/1e4)
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// streamPathPrefix is exempt from the request timeout middleware.
+	streamPathPrefix = "/api/stream/"
+
+	// streamHeartbeat is how often an idle stream sends a comment line so
+	// proxies don't close the connection.
+	streamHeartbeat = 15 * time.Second
+
+	// streamWriteTimeout bounds each write. A client that can't take an
+	// event within this window is disconnected rather than stalling.
+	streamWriteTimeout = 10 * time.Second
+
+	// streamRetryMs tells EventSource clients how long to wait before
+	// reconnecting.
+	streamRetryMs = 5000
+
+	// maxStreamClients caps concurrent SSE connections.
+	maxStreamClients = 1000
+)
+
+// streamFilter selects which events a connection receives.
+type streamFilter struct {
+	categories      map[string]bool
+	types           map[syncer.EventType]bool
+	minSignificance models.Significance
+}
+
+// parseStreamFilter reads ?category=, ?type= (both comma-separated) and
+// ?min_significance= from the request.
+func parseStreamFilter(r *http.Request) (*streamFilter, error) {
+	q := r.URL.Query()
+	f := &streamFilter{minSignificance: models.SignificanceLow}
+
+	if v := q.Get("category"); v != "" {
+		f.categories = make(map[string]bool)
+		for _, c := range strings.Split(v, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				f.categories[c] = true
+			}
+		}
+	}
+
+	if v := q.Get("type"); v != "" {
+		f.types = make(map[syncer.EventType]bool)
+		for _, t := range strings.Split(v, ",") {
+			switch et := syncer.EventType(strings.TrimSpace(t)); et {
+			case syncer.EventNewMarket, syncer.EventPriceChange, syncer.EventBreakingMove,
+				syncer.EventVolumeSpike, syncer.EventThresholdCross, syncer.EventTrendingUpdate,
+				syncer.EventWhaleTrade, syncer.EventSocialSignal:
+				f.types[et] = true
+			default:
+				return nil, fmt.Errorf("unknown event type: %s", t)
+			}
+		}
+	}
+
+	if v := q.Get("min_significance"); v != "" {
+		switch sig := models.Significance(v); sig {
+		case models.SignificanceLow, models.SignificanceMedium, models.SignificanceHigh, models.SignificanceBreaking:
+			f.minSignificance = sig
+		default:
+			return nil, fmt.Errorf("unknown significance: %s", v)
+		}
+	}
+
+	return f, nil
+}
+
+// match reports whether the event passes the filter.
+func (f *streamFilter) match(e syncer.Event) bool {
+	if e.Market == nil {
+		return false
+	}
+	if f.types != nil && !f.types[e.Type] {
+		return false
+	}
+	if f.categories != nil && !f.categories[e.Market.Category] {
+		return false
+	}
+	return e.Significance().Rank() >= f.minSignificance.Rank()
+}
+
+// streamEvent is the JSON payload of one SSE message.
+type streamEvent struct {
+	Type         string                 `json:"type"`
+	Significance models.Significance    `json:"significance"`
+	Timestamp    time.Time              `json:"timestamp"`
+	Market       streamMarket           `json:"market"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// streamMarket is the subset of a market a ticker needs.
+type streamMarket struct {
+	MarketID    string  `json:"market_id"`
+	Slug        string  `json:"slug"`
+	Question    string  `json:"question"`
+	Category    string  `json:"category"`
+	Image       string  `json:"image,omitempty"`
+	Probability float64 `json:"probability"`
+	Change24h   float64 `json:"change_24h"`
+	Volume24h   float64 `json:"volume_24h"`
+}
+
+func newStreamEvent(e syncer.Event) streamEvent {
+	return streamEvent{
+		Type:         string(e.Type),
+		Significance: e.Significance(),
+		Timestamp:    e.Timestamp,
+		Metadata:     e.Metadata,
+		Market: streamMarket{
+			MarketID:    e.Market.MarketID,
+			Slug:        e.Market.Slug,
+			Question:    e.Market.Question,
+			Category:    e.Market.Category,
+			Image:       e.Market.Image,
+			Probability: e.Market.Probability,
+			Change24h:   e.Market.Change24h,
+			Volume24h:   e.Market.Volume24h,
+		},
+	}
+}
+
+// StreamEvents streams market events as Server-Sent Events.
+//
+// Query parameters: category and type (comma-separated lists) and
+// min_significance (low, medium, high, breaking). Each message is sent with
+// the event type as its SSE event name.
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if s.syncer == nil {
+		respondError(w, http.StatusServiceUnavailable, "Syncer not available")
+		return
+	}
+
+	filter, err := parseStreamFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if s.streams.Add(1) > maxStreamClients {
+		s.streams.Add(-1)
+		respondError(w, http.StatusServiceUnavailable, "Too many stream connections")
+		return
+	}
+	defer s.streams.Add(-1)
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	// write sends one chunk with a fresh deadline, which also lifts the
+	// server-wide WriteTimeout for this long-lived response.
+	write := func(format string, args ...interface{}) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil && err != http.ErrNotSupported {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := write("retry: %d\n\n", streamRetryMs); err != nil {
+		return
+	}
+
+	events := s.syncer.Subscribe("stream")
+	defer s.syncer.Unsubscribe(events)
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	log.Debug().Str("remote", r.RemoteAddr).Msg("Event stream opened")
+	defer log.Debug().Str("remote", r.RemoteAddr).Msg("Event stream closed")
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if err := write(": heartbeat\n\n"); err != nil {
+				return
+			}
+
+		case event, ok := <-events:
+			if !ok {
+				// Syncer stopped
+				return
+			}
+			if !filter.match(event) {
+				continue
+			}
+
+			payload := newStreamEvent(event)
+			if s.handlers.assets != nil {
+				payload.Market.Image = s.handlers.assets.RewriteURL(r.Context(), payload.Market.Image)
+			}
+			data, err := json.Marshal(payload)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to encode stream event")
+				continue
+			}
+			if err := write("id: %d\nevent: %s\ndata: %s\n\n", event.Timestamp.UnixMilli(), event.Type, data); err != nil {
+				log.Debug().Err(err).Str("remote", r.RemoteAddr).Msg("Dropping slow stream client")
+				return
+			}
+		}
+	}
+}
+
+// unlessStreaming applies mw to every request except SSE streams, which
+// must outlive per-request timeouts.
+func unlessStreaming(mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, streamPathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
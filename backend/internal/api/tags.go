@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// GetTags returns the most used tags with their article and market counts.
+func (h *Handlers) GetTags(w http.ResponseWriter, r *http.Request) {
+	limit := getLimit(r, 50)
+
+	tags, err := h.store.GetTagCounts(r.Context(), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch tags")
+		return
+	}
+
+	respondList(w, tags, Meta{})
+}
+
+// GetTag returns the articles and markets carrying a tag, for tag landing
+// pages. The tag is normalized the way article tags are stored, so
+// /api/tags/Federal%20Reserve finds "federal-reserve".
+func (h *Handlers) GetTag(w http.ResponseWriter, r *http.Request) {
+	tag := models.TagSlug(chi.URLParam(r, "tag"))
+	if tag == "" {
+		respondError(w, http.StatusBadRequest, "Invalid tag")
+		return
+	}
+	limit := getLimit(r, 20)
+
+	articles, err := h.store.GetArticlesByTag(r.Context(), tag, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+	markets, err := h.store.GetMarketsByTag(r.Context(), tag, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+		return
+	}
+	if len(articles) == 0 && len(markets) == 0 {
+		respondError(w, http.StatusNotFound, "Tag not found")
+		return
+	}
+
+	h.proxyImages(r, markets)
+	h.serveHeadlines(r, articles)
+
+	respondData(w, tagResponse{Tag: tag, Articles: articles, Markets: markets})
+}
+
+// tagResponse is a tag with its latest articles and markets.
+type tagResponse struct {
+	Tag      string           `json:"tag"`
+	Articles []models.Article `json:"articles"`
+	Markets  []models.Market  `json:"markets"`
+}
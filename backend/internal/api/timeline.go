@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+)
+
+const (
+	// timelineDefaultDays and timelineMaxDays bound the ?days= window.
+	timelineDefaultDays = 7
+	timelineMaxDays     = 90
+
+	// timelineArticleLimit caps the articles placed on a timeline.
+	timelineArticleLimit = 100
+)
+
+// timelineEvents are the sync events worth annotating a price chart with;
+// routine price changes and trending updates would bury them.
+var timelineEvents = []string{
+	string(syncer.EventNewMarket),
+	string(syncer.EventBreakingMove),
+	string(syncer.EventVolumeSpike),
+	string(syncer.EventThresholdCross),
+	string(syncer.EventWhaleTrade),
+	string(syncer.EventMarketResolved),
+}
+
+// GetMarketTimeline merges a market's price snapshots, the events the syncer
+// emitted for it and the articles covering it into one chronological feed
+// over the last ?days= (default 7, at most 90). Longer windows fall back to
+// hourly or daily price points; events are only retained for
+// models.EventRetention.
+func (h *Handlers) GetMarketTimeline(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	days := timelineDefaultDays
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > timelineMaxDays {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = parsed
+	}
+	window := time.Duration(days) * 24 * time.Hour
+	since := time.Now().Add(-window)
+
+	ctx := r.Context()
+	market, err := h.store.GetMarketBySlug(ctx, slug)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+
+	snapshots, err := h.store.GetSnapshots(ctx, market.MarketID, window)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch snapshots")
+		return
+	}
+	events, err := h.store.GetMarketEvents(ctx, market.MarketID, since, timelineEvents)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch events")
+		return
+	}
+	articles, err := h.store.GetArticlesByMarketID(ctx, market.MarketID, timelineArticleLimit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+
+	entries := make([]models.TimelineEntry, 0, len(snapshots)+len(events)+len(articles))
+	for _, s := range slices.Backward(snapshots) {
+		entries = append(entries, models.TimelineEntry{
+			Time:     s.CapturedAt,
+			Kind:     models.TimelineSnapshot,
+			Snapshot: &models.TimelineSnapshotEntry{Probability: s.Probability, Volume24h: s.Volume24h},
+		})
+	}
+	for _, e := range events {
+		entries = append(entries, models.TimelineEntry{
+			Time:  e.CreatedAt,
+			Kind:  models.TimelineEvent,
+			Event: &models.TimelineEventEntry{ID: e.ID, Type: e.Type, Metadata: e.Metadata},
+		})
+	}
+	for _, a := range articles {
+		if a.PublishedAt.Before(since) {
+			continue
+		}
+		entries = append(entries, models.TimelineEntry{
+			Time:    a.PublishedAt,
+			Kind:    models.TimelineArticle,
+			Article: &models.TimelineArticleEntry{ID: a.ID, Slug: a.Slug, Type: a.Type, Headline: a.Headline},
+		})
+	}
+
+	// Each source is already in order; the stable sort keeps a snapshot
+	// ahead of an event or article stamped at the same moment
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+
+	respondList(w, entries, Meta{MarketID: market.MarketID, Days: days})
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// trackedAccountRequest is the body of tracked account create and edit
+// requests. On edit, absent fields keep their current values.
+type trackedAccountRequest struct {
+	Platform   *string   `json:"platform"`
+	Handle     *string   `json:"handle"`
+	Name       *string   `json:"name"`
+	Categories *[]string `json:"categories"`
+	Weight     *float64  `json:"weight"`
+	Enabled    *bool     `json:"enabled"`
+	Notes      *string   `json:"notes"`
+}
+
+// apply copies the fields present in the request onto account. The
+// platform and handle are only set on create.
+func (req *trackedAccountRequest) apply(account *models.TrackedAccount) {
+	if req.Name != nil {
+		account.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Categories != nil {
+		account.Categories = models.NormalizeKeywords(*req.Categories)
+	}
+	if req.Weight != nil {
+		account.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		account.Enabled = *req.Enabled
+	}
+	if req.Notes != nil {
+		account.Notes = strings.TrimSpace(*req.Notes)
+	}
+}
+
+// AdminListTrackedAccounts lists the locally tracked accounts by ID.
+func (s *Server) AdminListTrackedAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := s.store.GetTrackedAccounts(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch tracked accounts")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"accounts": accounts,
+		"count":    len(accounts),
+	})
+}
+
+// AdminGetTrackedAccount returns a tracked account.
+func (s *Server) AdminGetTrackedAccount(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.trackedAccount(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, account)
+}
+
+// AdminCreateTrackedAccount adds a tracked account. Accounts are created
+// on X, enabled with weight 1, unless the request says otherwise.
+func (s *Server) AdminCreateTrackedAccount(w http.ResponseWriter, r *http.Request) {
+	var req trackedAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	account := &models.TrackedAccount{Platform: models.PlatformX, Weight: 1, Enabled: true}
+	if req.Platform != nil {
+		account.Platform = models.NormalizePlatform(*req.Platform)
+	}
+	if req.Handle != nil {
+		account.Handle = models.NormalizeHandle(*req.Handle)
+	}
+	account.ID = models.TrackedAccountID(account.Platform, account.Handle)
+	req.apply(account)
+	if !s.validTrackedAccount(w, r, account) {
+		return
+	}
+
+	err := s.store.CreateTrackedAccount(r.Context(), account)
+	if errors.Is(err, storage.ErrTrackedAccountExists) {
+		respondError(w, http.StatusConflict, "Tracked account already exists")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create tracked account")
+		return
+	}
+
+	trackedAccountsChanged(r, account.ID, "Tracked account created")
+	respondJSON(w, http.StatusCreated, account)
+}
+
+// AdminUpdateTrackedAccount changes the fields present in the request
+// body. categories, when present, replaces the whole list.
+func (s *Server) AdminUpdateTrackedAccount(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.trackedAccount(w, r)
+	if !ok {
+		return
+	}
+
+	var req trackedAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Platform != nil && models.NormalizePlatform(*req.Platform) != account.Platform {
+		respondError(w, http.StatusBadRequest, "platform cannot be changed")
+		return
+	}
+	if req.Handle != nil && models.NormalizeHandle(*req.Handle) != account.Handle {
+		respondError(w, http.StatusBadRequest, "handle cannot be changed")
+		return
+	}
+
+	req.apply(account)
+	if !s.validTrackedAccount(w, r, account) {
+		return
+	}
+
+	updated, err := s.store.UpdateTrackedAccount(r.Context(), account)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update tracked account")
+		return
+	}
+	if !updated {
+		respondError(w, http.StatusNotFound, "Tracked account not found")
+		return
+	}
+
+	trackedAccountsChanged(r, account.ID, "Tracked account updated")
+	respondJSON(w, http.StatusOK, account)
+}
+
+// AdminDeleteTrackedAccount removes a tracked account. An X handle
+// XTracker tracks goes back to its defaults rather than being muted.
+func (s *Server) AdminDeleteTrackedAccount(w http.ResponseWriter, r *http.Request) {
+	id := strings.ToLower(chi.URLParam(r, "id"))
+	deleted, err := s.store.DeleteTrackedAccount(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete tracked account")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Tracked account not found")
+		return
+	}
+
+	trackedAccountsChanged(r, id, "Tracked account deleted")
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"message": "Tracked account deleted",
+	})
+}
+
+// trackedAccount loads the account named by the id URL parameter,
+// responding 404 if there is none.
+func (s *Server) trackedAccount(w http.ResponseWriter, r *http.Request) (*models.TrackedAccount, bool) {
+	account, err := s.store.GetTrackedAccount(r.Context(), strings.ToLower(chi.URLParam(r, "id")))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch tracked account")
+		return nil, false
+	}
+	if account == nil {
+		respondError(w, http.StatusNotFound, "Tracked account not found")
+		return nil, false
+	}
+	return account, true
+}
+
+// validTrackedAccount checks an account and that its categories exist,
+// responding 400 if not.
+func (s *Server) validTrackedAccount(w http.ResponseWriter, r *http.Request, account *models.TrackedAccount) bool {
+	if err := account.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	for _, category := range account.Categories {
+		if _, err := s.store.GetCategoryBySlug(r.Context(), category); err != nil {
+			respondError(w, http.StatusBadRequest, "Unknown category: "+category)
+			return false
+		}
+	}
+	return true
+}
+
+// trackedAccountsChanged logs a tracked account change. The correlator
+// picks it up when its tracked user cache next expires.
+func trackedAccountsChanged(r *http.Request, id, msg string) {
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		log.Info().Str("by", p.Subject).Str("account", id).Msg(msg)
+	}
+}
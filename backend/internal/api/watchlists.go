@@ -0,0 +1,335 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leeaandrob/futuresignals/internal/alerts"
+	"github.com/leeaandrob/futuresignals/internal/auth"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// deviceTokenHeader carries the anonymous device token that owns a
+// watchlist when the caller has no API key.
+const deviceTokenHeader = "X-Device-Token"
+
+// Device token length bounds; clients generate a random token and keep it.
+const (
+	minDeviceToken = 16
+	maxDeviceToken = 128
+)
+
+// watchlistEventTypes are the event types alerts can be filtered to.
+var watchlistEventTypes = []string{
+	string(syncer.EventNewMarket),
+	string(syncer.EventBreakingMove),
+	string(syncer.EventVolumeSpike),
+	string(syncer.EventThresholdCross),
+	string(syncer.EventMarketResolved),
+	string(syncer.EventWhaleTrade),
+}
+
+// watchlistOwner identifies the caller: the signed-in user, the
+// authenticated API key or JWT subject, else a hash of the device token.
+func watchlistOwner(r *http.Request) (string, bool) {
+	if userID, ok := auth.UserIDFromContext(r.Context()); ok {
+		return models.UserOwner(userID), true
+	}
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if p.KeyID != "" {
+			return "key:" + p.KeyID, true
+		}
+		return "key:" + p.Method + ":" + p.Subject, true
+	}
+
+	token := strings.TrimSpace(r.Header.Get(deviceTokenHeader))
+	if len(token) < minDeviceToken || len(token) > maxDeviceToken {
+		return "", false
+	}
+	return "device:" + auth.HashKey(token), true
+}
+
+// requireWatchlistOwner resolves the owner or writes a 401.
+func requireWatchlistOwner(w http.ResponseWriter, r *http.Request) (string, bool) {
+	owner, ok := watchlistOwner(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Sign in, or send an API key or "+deviceTokenHeader+" header")
+	}
+	return owner, ok
+}
+
+func watchlistID(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, bool) {
+	id, err := primitive.ObjectIDFromHex(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return id, false
+	}
+	return id, true
+}
+
+// GetWatchlists returns the caller's watchlists.
+func (s *Server) GetWatchlists(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+
+	lists, err := s.store.GetWatchlists(r.Context(), owner)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch watchlists")
+		return
+	}
+
+	respondList(w, lists, Meta{})
+}
+
+// CreateWatchlist creates a watchlist, optionally with initial markets.
+func (s *Server) CreateWatchlist(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name      string   `json:"name"`
+		MarketIDs []string `json:"market_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		respondError(w, http.StatusBadRequest, "name must be 1-100 characters")
+		return
+	}
+
+	var marketIDs []string
+	for _, id := range req.MarketIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(marketIDs, id) {
+			marketIDs = append(marketIDs, id)
+		}
+	}
+	if len(marketIDs) > models.MaxMarketsPerWatchlist {
+		respondError(w, http.StatusBadRequest, "Too many markets")
+		return
+	}
+
+	list := &models.Watchlist{Owner: owner, Name: name, MarketIDs: marketIDs}
+	err := s.store.CreateWatchlist(r.Context(), list)
+	if errors.Is(err, storage.ErrWatchlistLimit) {
+		respondError(w, http.StatusConflict, "Watchlist limit reached")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create watchlist")
+		return
+	}
+
+	respondDataStatus(w, http.StatusCreated, list)
+}
+
+// GetWatchlist returns a watchlist with live data for its markets.
+func (s *Server) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.store.GetWatchlist(r.Context(), owner, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch watchlist")
+		return
+	}
+	if list == nil {
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return
+	}
+
+	// Prefer the syncer's cache for the freshest prices
+	markets := make([]models.Market, 0, len(list.MarketIDs))
+	var missing []string
+	for _, marketID := range list.MarketIDs {
+		if s.syncer != nil {
+			if m, ok := s.syncer.GetCachedMarket(marketID); ok {
+				markets = append(markets, *m)
+				continue
+			}
+		}
+		missing = append(missing, marketID)
+	}
+	if len(missing) > 0 {
+		stored, err := s.store.GetMarketsByIDs(r.Context(), missing)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
+			return
+		}
+		markets = append(markets, stored...)
+	}
+
+	// Keep the watchlist's order
+	order := make(map[string]int, len(list.MarketIDs))
+	for i, marketID := range list.MarketIDs {
+		order[marketID] = i
+	}
+	slices.SortFunc(markets, func(a, b models.Market) int {
+		return order[a.MarketID] - order[b.MarketID]
+	})
+	s.handlers.proxyImages(r, markets)
+
+	respondData(w, watchlistResponse{Watchlist: list, Markets: markets})
+}
+
+// watchlistResponse is a watchlist with its markets in list order.
+type watchlistResponse struct {
+	Watchlist *models.Watchlist `json:"watchlist"`
+	Markets   []models.Market   `json:"markets"`
+}
+
+// DeleteWatchlist removes a watchlist.
+func (s *Server) DeleteWatchlist(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := s.store.DeleteWatchlist(r.Context(), owner, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete watchlist")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddWatchlistMarket adds a market to a watchlist.
+func (s *Server) AddWatchlistMarket(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	marketID := chi.URLParam(r, "marketID")
+	if _, err := s.store.GetMarketByID(r.Context(), marketID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			respondError(w, http.StatusNotFound, "Market not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to fetch market")
+		return
+	}
+
+	list, err := s.store.AddWatchlistMarket(r.Context(), owner, id, marketID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update watchlist")
+		return
+	}
+	if list == nil {
+		// Either missing or full; tell them apart for the client
+		if existing, err := s.store.GetWatchlist(r.Context(), owner, id); err == nil && existing != nil {
+			respondError(w, http.StatusConflict, "Watchlist is full")
+			return
+		}
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return
+	}
+
+	respondData(w, list)
+}
+
+// RemoveWatchlistMarket removes a market from a watchlist.
+func (s *Server) RemoveWatchlistMarket(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.store.RemoveWatchlistMarket(r.Context(), owner, id, chi.URLParam(r, "marketID"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update watchlist")
+		return
+	}
+	if list == nil {
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return
+	}
+
+	respondData(w, list)
+}
+
+// SetWatchlistAlerts configures alert delivery for a watchlist's markets.
+// Alerts are posted to webhook_url and, for signed-in users, routed through
+// their notification preferences, optionally only for event_types.
+func (s *Server) SetWatchlistAlerts(w http.ResponseWriter, r *http.Request) {
+	owner, ok := requireWatchlistOwner(w, r)
+	if !ok {
+		return
+	}
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.WatchlistAlerts
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	// Signed-in users can rely on their notification preferences instead
+	// of a webhook
+	_, isUser := auth.UserIDFromContext(r.Context())
+	if req.WebhookURL != "" || (req.Enabled && !isUser) {
+		if err := alerts.ValidateWebhook(r.Context(), req.WebhookURL); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(watchlistEventTypes, t) {
+			respondError(w, http.StatusBadRequest, "Unknown event type: "+t)
+			return
+		}
+	}
+
+	list, err := s.store.SetWatchlistAlerts(r.Context(), owner, id, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update watchlist")
+		return
+	}
+	if list == nil {
+		respondError(w, http.StatusNotFound, "Watchlist not found")
+		return
+	}
+
+	log.Info().Str("watchlist", id.Hex()).Bool("enabled", req.Enabled).Msg("Watchlist alerts updated")
+	respondData(w, list)
+}
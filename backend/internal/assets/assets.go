@@ -0,0 +1,262 @@
+// Package assets proxies remote market images (Polymarket CDN URLs) so
+// clients never hotlink them. Source URLs are registered under a hash as
+// API responses are built; /api/assets/{hash} then fetches each image once,
+// caches it, and serves it resized to a fixed set of widths.
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/blob"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// PathPrefix is where the API serves proxied assets.
+const PathPrefix = "/api/assets/"
+
+// ErrNotFound is returned by Open for unknown hashes.
+var ErrNotFound = errors.New("asset not found")
+
+// Config holds asset proxy configuration.
+type Config struct {
+	// Public API URL that proxied image URLs point at
+	BaseURL string
+
+	// Widths images are resized to; requested widths are rounded up to the
+	// next one, and anything larger gets the largest
+	Widths []int
+
+	// Largest source image accepted, in bytes
+	MaxBytes int64
+
+	// Timeout for fetching a source image
+	Timeout time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		Widths:   []int{64, 128, 256, 512, 1024},
+		MaxBytes: 10 << 20,
+		Timeout:  15 * time.Second,
+	}
+}
+
+// Image is a proxied image ready to serve.
+type Image struct {
+	Data        []byte
+	ContentType string
+}
+
+// Proxy rewrites market image URLs and serves the images they point at.
+type Proxy struct {
+	store      *storage.Store
+	cache      blob.Storage
+	httpClient *http.Client
+	config     Config
+
+	// Hashes known to be registered, to skip the database on hot paths
+	known sync.Map
+}
+
+// NewProxy creates a new asset proxy caching images in cache.
+func NewProxy(store *storage.Store, cache blob.Storage, cfg Config) *Proxy {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if len(cfg.Widths) == 0 {
+		cfg.Widths = DefaultConfig().Widths
+	}
+	slices.Sort(cfg.Widths)
+	return &Proxy{
+		store:      store,
+		cache:      cache,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		config:     cfg,
+	}
+}
+
+// Rewrite points the images and icons of markets at the proxy.
+func (p *Proxy) Rewrite(ctx context.Context, markets []models.Market) {
+	urls := make([]*string, 0, 2*len(markets))
+	for i := range markets {
+		urls = append(urls, &markets[i].Image, &markets[i].Icon)
+	}
+	p.rewrite(ctx, urls)
+}
+
+// RewriteURL returns the proxied URL for src.
+func (p *Proxy) RewriteURL(ctx context.Context, src string) string {
+	p.rewrite(ctx, []*string{&src})
+	return src
+}
+
+// rewrite replaces each remote URL in place, registering new ones first.
+// URLs that can't be registered are left pointing at their source.
+func (p *Proxy) rewrite(ctx context.Context, urls []*string) {
+	var pending []string
+	for _, u := range urls {
+		if proxiable(*u) {
+			if _, ok := p.known.Load(models.AssetHash(*u)); !ok {
+				pending = append(pending, *u)
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		if err := p.store.RegisterAssets(ctx, pending); err != nil {
+			log.Warn().Err(err).Int("count", len(pending)).Msg("Failed to register assets")
+		} else {
+			for _, src := range pending {
+				p.known.Store(models.AssetHash(src), struct{}{})
+			}
+		}
+	}
+
+	for _, u := range urls {
+		if !proxiable(*u) {
+			continue
+		}
+		hash := models.AssetHash(*u)
+		if _, ok := p.known.Load(hash); ok {
+			*u = p.config.BaseURL + PathPrefix + hash
+		}
+	}
+}
+
+// proxiable reports whether src is a remote image URL.
+func proxiable(src string) bool {
+	return strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://")
+}
+
+// Open returns the image registered under hash, at the smallest configured
+// width of at least width, or at its original size when width is 0.
+// Images are never upscaled, and formats the proxy can't decode are served
+// as fetched.
+func (p *Proxy) Open(ctx context.Context, hash string, width int) (*Image, error) {
+	width = p.snapWidth(width)
+	key := cacheKey(hash, width)
+
+	data, contentType, err := p.cache.Get(ctx, key)
+	if err == nil {
+		metrics.AssetRequests.WithLabelValues("cached").Inc()
+		return &Image{Data: data, ContentType: contentType}, nil
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to read cached asset")
+	}
+
+	original, err := p.original(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			metrics.AssetRequests.WithLabelValues("not_found").Inc()
+		} else {
+			metrics.AssetRequests.WithLabelValues("error").Inc()
+		}
+		return nil, err
+	}
+	metrics.AssetRequests.WithLabelValues("miss").Inc()
+	if width == 0 {
+		return original, nil
+	}
+
+	resized, err := resize(original, width)
+	if err != nil {
+		log.Debug().Err(err).Str("hash", hash).Msg("Serving asset without resizing")
+		return original, nil
+	}
+	if _, err := p.cache.Put(ctx, key, resized.ContentType, resized.Data); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to cache resized asset")
+	}
+	return resized, nil
+}
+
+// original returns the image as fetched, from the cache or its source.
+func (p *Proxy) original(ctx context.Context, hash string) (*Image, error) {
+	key := cacheKey(hash, 0)
+	data, contentType, err := p.cache.Get(ctx, key)
+	if err == nil {
+		return &Image{Data: data, ContentType: contentType}, nil
+	}
+
+	asset, err := p.store.GetAsset(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, ErrNotFound
+	}
+
+	img, err := p.fetch(ctx, asset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", asset.URL, err)
+	}
+	if _, err := p.cache.Put(ctx, key, img.ContentType, img.Data); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to cache asset")
+	}
+	return img, nil
+}
+
+// fetch downloads a source image. The content type is sniffed rather than
+// trusted, and only raster formats are accepted, since the image is served
+// from the API's origin.
+func (p *Proxy) fetch(ctx context.Context, src string) (*Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.config.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > p.config.MaxBytes {
+		return nil, fmt.Errorf("larger than %d bytes", p.config.MaxBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	return &Image{Data: data, ContentType: contentType}, nil
+}
+
+// snapWidth rounds width up to a configured width; 0 stays 0.
+func (p *Proxy) snapWidth(width int) int {
+	if width <= 0 {
+		return 0
+	}
+	for _, w := range p.config.Widths {
+		if w >= width {
+			return w
+		}
+	}
+	return p.config.Widths[len(p.config.Widths)-1]
+}
+
+// cacheKey is where an image is cached at a width (0 for the original).
+func cacheKey(hash string, width int) string {
+	if width == 0 {
+		return "assets/" + hash + "/original"
+	}
+	return fmt.Sprintf("assets/%s/w%d", hash, width)
+}
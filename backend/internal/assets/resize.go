@@ -0,0 +1,82 @@
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // register decoder
+	"image/jpeg"
+	"image/png"
+)
+
+// maxPixels caps the size of images the proxy decodes.
+const maxPixels = 40_000_000
+
+// jpegQuality is used when re-encoding resized JPEGs.
+const jpegQuality = 85
+
+// resize scales img down to width, keeping its aspect ratio. JPEGs stay
+// JPEG; other formats are re-encoded as PNG to keep transparency. Images
+// no wider than width are returned as they are.
+func resize(img *Image, width int) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= width {
+		return img, nil
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("image too large: %dx%d", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, err
+	}
+	height := max(1, cfg.Height*width/cfg.Width)
+	dst := downscale(src, width, height)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+		return &Image{Data: buf.Bytes(), ContentType: "image/jpeg"}, err
+	}
+	err = png.Encode(&buf, dst)
+	return &Image{Data: buf.Bytes(), ContentType: "image/png"}, err
+}
+
+// downscale resizes src to width x height with a box filter: each output
+// pixel is the average of the source pixels it covers.
+func downscale(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	in := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(in, in.Bounds(), src, b.Min, draw.Src)
+
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * b.Dy() / height
+		y1 := max(y0+1, (y+1)*b.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := x * b.Dx() / width
+			x1 := max(x0+1, (x+1)*b.Dx()/width)
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := in.Pix[sy*in.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					bl += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+			o := out.Pix[y*out.Stride+x*4:]
+			o[0], o[1], o[2], o[3] = uint8(r/n), uint8(g/n), uint8(bl/n), uint8(a/n)
+		}
+	}
+	return out
+}
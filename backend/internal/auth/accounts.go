@@ -0,0 +1,371 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// SessionCookie holds the session token for browser clients.
+	SessionCookie = "fs_session"
+
+	// sessionIssuer tells session tokens apart from admin JWTs.
+	sessionIssuer = "futuresignals-session"
+
+	// stateIssuer marks the signed OAuth state parameter.
+	stateIssuer = "futuresignals-oauth-state"
+)
+
+// Google OAuth endpoints.
+const (
+	googleAuthURL     = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+)
+
+var (
+	// ErrInvalidEmail is returned when a login address can't be parsed.
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrProviderDisabled is returned for a login method that isn't configured.
+	ErrProviderDisabled = errors.New("login provider not configured")
+
+	// ErrInvalidLogin is returned for unknown, used or expired login tokens
+	// and failed OAuth exchanges.
+	ErrInvalidLogin = errors.New("invalid or expired login")
+)
+
+// UserStore persists user accounts and magic link tokens.
+type UserStore interface {
+	GetUserByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
+	UpsertUserLogin(ctx context.Context, email, provider string, profile models.User) (*models.User, error)
+	SaveLoginToken(ctx context.Context, token *models.LoginToken) error
+	ConsumeLoginToken(ctx context.Context, hash string) (*models.LoginToken, error)
+}
+
+// LinkMailer emails magic sign-in links.
+type LinkMailer interface {
+	SendLoginLink(ctx context.Context, email, link string) error
+}
+
+// AccountConfig holds user account settings.
+type AccountConfig struct {
+	// SessionSecret signs session tokens and OAuth state. Required.
+	SessionSecret string
+
+	// How long sessions and magic links stay valid
+	SessionTTL    time.Duration
+	LoginTokenTTL time.Duration
+
+	// Public API URL for magic link and OAuth callback URLs
+	APIURL string
+
+	// Google OAuth client; Google sign-in is disabled when empty
+	GoogleClientID     string
+	GoogleClientSecret string
+}
+
+// Accounts signs users in with magic links or Google and issues session
+// tokens.
+type Accounts struct {
+	store      UserStore
+	mailer     LinkMailer
+	config     AccountConfig
+	httpClient *http.Client
+}
+
+// NewAccounts creates the account service. Magic links need a mailer, set
+// with SetLinkMailer.
+func NewAccounts(store UserStore, cfg AccountConfig) *Accounts {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 30 * 24 * time.Hour
+	}
+	if cfg.LoginTokenTTL <= 0 {
+		cfg.LoginTokenTTL = 15 * time.Minute
+	}
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+
+	return &Accounts{
+		store:      store,
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// SetLinkMailer enables magic link sign-in.
+func (a *Accounts) SetLinkMailer(m LinkMailer) {
+	a.mailer = m
+}
+
+// SessionTTL returns how long issued sessions last.
+func (a *Accounts) SessionTTL() time.Duration {
+	return a.config.SessionTTL
+}
+
+// GoogleEnabled reports whether Google sign-in is configured.
+func (a *Accounts) GoogleEnabled() bool {
+	return a.config.GoogleClientID != "" && a.config.GoogleClientSecret != ""
+}
+
+// User returns the account for a user ID, or nil if there is none.
+func (a *Accounts) User(ctx context.Context, id string) (*models.User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return a.store.GetUserByID(ctx, oid)
+}
+
+// ============================================================================
+// MAGIC LINKS
+// ============================================================================
+
+// RequestLogin emails a single-use sign-in link to email. The account is
+// created when the link is used, so requesting a link doesn't reveal
+// whether an account exists.
+func (a *Accounts) RequestLogin(ctx context.Context, email string) error {
+	if a.mailer == nil {
+		return ErrProviderDisabled
+	}
+
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return ErrInvalidEmail
+	}
+	email = strings.ToLower(addr.Address)
+
+	token, _, hash, err := GenerateKey()
+	if err != nil {
+		return err
+	}
+	if err := a.store.SaveLoginToken(ctx, &models.LoginToken{
+		Email:     email,
+		Hash:      hash,
+		ExpiresAt: time.Now().Add(a.config.LoginTokenTTL),
+	}); err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+
+	link := a.config.APIURL + "/api/auth/verify?token=" + url.QueryEscape(token)
+	if err := a.mailer.SendLoginLink(ctx, email, link); err != nil {
+		return fmt.Errorf("send login link: %w", err)
+	}
+	return nil
+}
+
+// VerifyLogin consumes a magic link token and returns the signed-in user
+// and a session token.
+func (a *Accounts) VerifyLogin(ctx context.Context, token string) (*models.User, string, error) {
+	if token == "" {
+		return nil, "", ErrInvalidLogin
+	}
+
+	login, err := a.store.ConsumeLoginToken(ctx, HashKey(token))
+	if err != nil {
+		return nil, "", fmt.Errorf("consume login token: %w", err)
+	}
+	if login == nil {
+		return nil, "", ErrInvalidLogin
+	}
+
+	user, err := a.store.UpsertUserLogin(ctx, login.Email, models.AuthProviderEmail, models.User{})
+	if err != nil {
+		return nil, "", fmt.Errorf("save user: %w", err)
+	}
+	return a.session(user)
+}
+
+// ============================================================================
+// GOOGLE OAUTH
+// ============================================================================
+
+// GoogleAuthURL returns the Google consent URL and the state value that
+// must come back on the callback.
+func (a *Accounts) GoogleAuthURL() (string, string, error) {
+	if !a.GoogleEnabled() {
+		return "", "", ErrProviderDisabled
+	}
+
+	state, err := a.sign(jwt.RegisteredClaims{
+		Issuer:    stateIssuer,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute)),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	q := url.Values{
+		"client_id":     {a.config.GoogleClientID},
+		"redirect_uri":  {a.googleRedirectURL()},
+		"response_type": {"code"},
+		"scope":         {"openid email profile"},
+		"state":         {state},
+		"prompt":        {"select_account"},
+	}
+	return googleAuthURL + "?" + q.Encode(), state, nil
+}
+
+// GoogleLogin completes a Google sign-in: it checks the state, exchanges
+// the code and returns the user and a session token.
+func (a *Accounts) GoogleLogin(ctx context.Context, code, state, expectedState string) (*models.User, string, error) {
+	if !a.GoogleEnabled() {
+		return nil, "", ErrProviderDisabled
+	}
+	if state == "" || state != expectedState {
+		return nil, "", ErrInvalidLogin
+	}
+	if _, err := a.parse(state, stateIssuer); err != nil {
+		return nil, "", ErrInvalidLogin
+	}
+
+	accessToken, err := a.exchangeGoogleCode(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	profile, err := a.googleProfile(ctx, accessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if !profile.EmailVerified || profile.Email == "" {
+		return nil, "", ErrInvalidLogin
+	}
+
+	user, err := a.store.UpsertUserLogin(ctx, strings.ToLower(profile.Email), models.AuthProviderGoogle, models.User{
+		Name:     profile.Name,
+		Picture:  profile.Picture,
+		GoogleID: profile.Sub,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("save user: %w", err)
+	}
+	return a.session(user)
+}
+
+func (a *Accounts) googleRedirectURL() string {
+	return a.config.APIURL + "/api/auth/google/callback"
+}
+
+func (a *Accounts) exchangeGoogleCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"code":          {code},
+		"client_id":     {a.config.GoogleClientID},
+		"client_secret": {a.config.GoogleClientSecret},
+		"redirect_uri":  {a.googleRedirectURL()},
+		"grant_type":    {"authorization_code"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := a.doJSON(req, &token); err != nil {
+		return "", fmt.Errorf("exchange google code: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", ErrInvalidLogin
+	}
+	return token.AccessToken, nil
+}
+
+type googleUser struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+func (a *Accounts) googleProfile(ctx context.Context, accessToken string) (*googleUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleUserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	var profile googleUser
+	if err := a.doJSON(req, &profile); err != nil {
+		return nil, fmt.Errorf("fetch google profile: %w", err)
+	}
+	return &profile, nil
+}
+
+func (a *Accounts) doJSON(req *http.Request, out interface{}) error {
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// ============================================================================
+// SESSIONS
+// ============================================================================
+
+// sessionClaims are the claims in a session token.
+type sessionClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// session issues a session token for user.
+func (a *Accounts) session(user *models.User) (*models.User, string, error) {
+	now := time.Now()
+	token, err := a.sign(sessionClaims{
+		Email: user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    sessionIssuer,
+			Subject:   user.ID.Hex(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.config.SessionTTL)),
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+func (a *Accounts) sign(claims jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(a.config.SessionSecret))
+}
+
+func (a *Accounts) parse(token, issuer string) (*sessionClaims, error) {
+	return parseSession(token, a.config.SessionSecret, issuer)
+}
+
+// parseSession validates a token signed with secret for issuer.
+func parseSession(token, secret, issuer string) (*sessionClaims, error) {
+	var claims sessionClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired(), jwt.WithIssuer(issuer))
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
@@ -0,0 +1,361 @@
+// Package auth provides API-key and JWT authentication for the HTTP API.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// KeyPrefix marks FutureSignals API keys so they can be told apart from JWTs.
+	KeyPrefix = "fs_"
+
+	// DefaultRateLimit is the per-minute request budget for credentials
+	// that don't set their own.
+	DefaultRateLimit = 120
+
+	// touchInterval throttles last_used_at writes per key.
+	touchInterval = time.Minute
+)
+
+// Authentication methods reported on a Principal.
+const (
+	MethodAPIKey    = "api_key"
+	MethodJWT       = "jwt"
+	MethodBootstrap = "bootstrap"
+	MethodSession   = "session"
+)
+
+// KeyStore looks up stored API keys.
+type KeyStore interface {
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id primitive.ObjectID) error
+}
+
+// Config holds authentication settings.
+type Config struct {
+	// BootstrapKey is an admin key taken from the environment rather than
+	// the database, used to create the first stored keys. Optional.
+	BootstrapKey string
+
+	// JWTSecret enables HS256 bearer tokens when set.
+	JWTSecret string
+
+	// SessionSecret enables user session tokens issued by Accounts when set.
+	SessionSecret string
+
+	// DefaultRateLimit overrides the package default (requests per minute).
+	DefaultRateLimit int
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	Subject   string
+	Method    string
+	KeyID     string
+	Scopes    []string
+	RateLimit int
+
+	// UserID is set for signed-in users (MethodSession)
+	UserID string
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	return models.HasScope(p.Scopes, scope)
+}
+
+// LimitKey is the rate limit bucket of the principal: its API key, so each
+// of an account's keys is limited on its own, or else its subject.
+func (p *Principal) LimitKey() string {
+	if p.KeyID != "" {
+		return p.Method + ":" + p.KeyID
+	}
+	return p.Method + ":" + p.Subject
+}
+
+type principalKey struct{}
+
+// UserIDFromContext returns the signed-in user's ID, if the caller is a
+// user rather than an API credential.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.UserID == "" {
+		return "", false
+	}
+	return p.UserID, true
+}
+
+// PrincipalFromContext returns the authenticated caller, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// jwtClaims are the claims accepted in bearer JWTs.
+type jwtClaims struct {
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Authenticator validates API keys and JWTs and enforces per-credential
+// rate limits.
+type Authenticator struct {
+	store   KeyStore
+	config  Config
+	limiter *ratelimit.Limiter
+}
+
+// NewAuthenticator creates a new authenticator.
+func NewAuthenticator(store KeyStore, cfg Config) *Authenticator {
+	if cfg.DefaultRateLimit <= 0 {
+		cfg.DefaultRateLimit = DefaultRateLimit
+	}
+
+	return &Authenticator{
+		store:   store,
+		config:  cfg,
+		limiter: ratelimit.New(10 * time.Minute),
+	}
+}
+
+// Middleware authenticates the request and applies the caller's rate limit.
+// Credentials are read from "Authorization: Bearer <token>" or "X-API-Key".
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return a.middleware(next, true)
+}
+
+// Optional is like Middleware but lets requests without credentials through
+// unauthenticated. Requests with invalid credentials are still rejected.
+func (a *Authenticator) Optional(next http.Handler) http.Handler {
+	return a.middleware(next, false)
+}
+
+// Identify attaches the caller's principal when valid credentials are
+// presented and otherwise serves the request anonymously, for public routes
+// that only personalize. Requests are not counted against rate limits.
+func (a *Authenticator) Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, _ = a.Resolve(r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Resolve authenticates the request's credentials, if any, and returns the
+// request with the principal attached so later middleware reuses it. The
+// principal is nil when there are no valid credentials.
+func (a *Authenticator) Resolve(r *http.Request) (*http.Request, *Principal) {
+	if principal, ok := PrincipalFromContext(r.Context()); ok {
+		return r, principal
+	}
+
+	token := credentialFromRequest(r)
+	if token == "" {
+		return r, nil
+	}
+	principal, err := a.authenticate(r.Context(), token)
+	if err != nil {
+		return r, nil
+	}
+
+	ctx := context.WithValue(r.Context(), principalKey{}, principal)
+	return r.WithContext(ctx), principal
+}
+
+func (a *Authenticator) middleware(next http.Handler, required bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reuse a principal resolved earlier in the chain
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			token := credentialFromRequest(r)
+			if token == "" && !required {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if token == "" {
+				writeError(w, http.StatusUnauthorized, "Missing credentials")
+				return
+			}
+
+			var err error
+			principal, err = a.authenticate(r.Context(), token)
+			if err != nil {
+				log.Debug().Err(err).Msg("Authentication failed")
+				writeError(w, http.StatusUnauthorized, "Invalid credentials")
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), principalKey{}, principal))
+		}
+
+		if ok, wait := a.limiter.Reserve(principal.LimitKey(), principal.RateLimit, time.Minute); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireScope rejects authenticated callers that lack scope.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				writeError(w, http.StatusUnauthorized, "Missing credentials")
+				return
+			}
+			if !principal.HasScope(scope) {
+				writeError(w, http.StatusForbidden, "Missing scope: "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticate resolves a token to a principal.
+func (a *Authenticator) authenticate(ctx context.Context, token string) (*Principal, error) {
+	if a.config.BootstrapKey != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(a.config.BootstrapKey)) == 1 {
+		return &Principal{
+			Subject:   "bootstrap",
+			Method:    MethodBootstrap,
+			Scopes:    []string{models.ScopeAdmin},
+			RateLimit: a.config.DefaultRateLimit,
+		}, nil
+	}
+
+	if strings.HasPrefix(token, KeyPrefix) {
+		return a.authenticateKey(ctx, token)
+	}
+
+	if a.config.SessionSecret != "" {
+		if claims, err := parseSession(token, a.config.SessionSecret, sessionIssuer); err == nil {
+			return &Principal{
+				Subject:   claims.Email,
+				Method:    MethodSession,
+				UserID:    claims.Subject,
+				RateLimit: a.config.DefaultRateLimit,
+			}, nil
+		}
+	}
+
+	if a.config.JWTSecret != "" {
+		return a.authenticateJWT(token)
+	}
+
+	return nil, errors.New("unrecognized credential")
+}
+
+func (a *Authenticator) authenticateKey(ctx context.Context, token string) (*Principal, error) {
+	key, err := a.store.GetAPIKeyByHash(ctx, HashKey(token))
+	if err != nil {
+		return nil, fmt.Errorf("lookup api key: %w", err)
+	}
+	if key == nil {
+		return nil, errors.New("unknown api key")
+	}
+	if key.RevokedAt != nil {
+		return nil, errors.New("api key revoked")
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > touchInterval {
+		if err := a.store.TouchAPIKey(ctx, key.ID); err != nil {
+			log.Warn().Err(err).Str("key", key.Prefix).Msg("Failed to record API key use")
+		}
+	}
+
+	rateLimit := key.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = a.config.DefaultRateLimit
+	}
+
+	return &Principal{
+		Subject:   key.Name,
+		Method:    MethodAPIKey,
+		KeyID:     key.ID.Hex(),
+		Scopes:    key.Scopes,
+		RateLimit: rateLimit,
+	}, nil
+}
+
+func (a *Authenticator) authenticateJWT(token string) (*Principal, error) {
+	var claims jwtClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(a.config.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("parse jwt: %w", err)
+	}
+
+	rateLimit := claims.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = a.config.DefaultRateLimit
+	}
+
+	return &Principal{
+		Subject:   claims.Subject,
+		Method:    MethodJWT,
+		Scopes:    claims.Scopes,
+		RateLimit: rateLimit,
+	}, nil
+}
+
+// GenerateKey returns a new random API key, the prefix shown in key
+// listings, and the hash to store.
+func GenerateKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("generate api key: %w", err)
+	}
+	key = KeyPrefix + hex.EncodeToString(buf)
+	return key, key[:len(KeyPrefix)+8], HashKey(key), nil
+}
+
+// HashKey returns the stored form of an API key. Keys carry 256 bits of
+// entropy, so an unsalted SHA-256 is sufficient.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func credentialFromRequest(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	}
+	if key := strings.TrimSpace(r.Header.Get("X-API-Key")); key != "" {
+		return key
+	}
+	if c, err := r.Cookie(SessionCookie); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// writeError writes an error in the API's response envelope.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"status": status, "message": message},
+	})
+}
@@ -0,0 +1,60 @@
+// Package cache holds encoded API read results, in process or in Redis,
+// so hot endpoints don't recompute them from MongoDB on every request.
+// Entries belong to a group; writers invalidate the groups derived from
+// the data they change.
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// Groups of cached read results.
+const (
+	// GroupFeed holds the home feed.
+	GroupFeed = "feed"
+
+	// GroupMarkets holds market lists.
+	GroupMarkets = "markets"
+
+	// GroupCategories holds the category list and category pages.
+	GroupCategories = "categories"
+
+	// GroupArticles holds renderings of single articles, such as their
+	// plain content.
+	GroupArticles = "articles"
+
+	// GroupRelevance holds LLM relevance scores of social posts against
+	// markets. Nothing invalidates it; entries expire.
+	GroupRelevance = "relevance"
+)
+
+// Cache stores encoded values by group and key.
+type Cache interface {
+	// Get returns the value cached under key and whether there was one.
+	Get(ctx context.Context, group, key string) ([]byte, bool, error)
+
+	// Set caches a value under key for ttl.
+	Set(ctx context.Context, group, key string, value []byte, ttl time.Duration) error
+
+	// Invalidate drops every value cached in the groups.
+	Invalidate(ctx context.Context, groups ...string) error
+}
+
+// Invalidate drops the groups from c, logging rather than returning a
+// failure so writers can call it after every write. A nil c is a no-op.
+func Invalidate(ctx context.Context, c Cache, groups ...string) {
+	if c == nil {
+		return
+	}
+	if err := c.Invalidate(ctx, groups...); err != nil {
+		log.Warn().Err(err).Strs("groups", groups).Msg("Failed to invalidate cache")
+		return
+	}
+	for _, group := range groups {
+		metrics.APICacheInvalidations.WithLabelValues(group).Inc()
+	}
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process LRU cache. Its invalidations only reach the
+// process that makes them, so deployments with several instances should
+// share a Redis cache instead.
+type Memory struct {
+	maxEntries int
+
+	mu    sync.Mutex
+	lru   *list.List // front is most recently used
+	items map[string]*list.Element
+}
+
+type memoryItem struct {
+	group     string
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemory creates an in-process cache holding up to maxEntries values.
+func NewMemory(maxEntries int) *Memory {
+	return &Memory{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func memoryKey(group, key string) string {
+	return group + ":" + key
+}
+
+// Get returns a fresh value cached under key.
+func (m *Memory) Get(ctx context.Context, group, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[memoryKey(group, key)]
+	if !ok {
+		return nil, false, nil
+	}
+	item := el.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		m.remove(el)
+		return nil, false, nil
+	}
+	m.lru.MoveToFront(el)
+	return item.value, true, nil
+}
+
+// Set caches a value, evicting the least recently used values beyond
+// maxEntries.
+func (m *Memory) Set(ctx context.Context, group, key string, value []byte, ttl time.Duration) error {
+	if m.maxEntries <= 0 || ttl <= 0 {
+		return nil
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := memoryKey(group, key)
+	if el, ok := m.items[k]; ok {
+		item := el.Value.(*memoryItem)
+		item.value, item.expiresAt = value, expiresAt
+		m.lru.MoveToFront(el)
+		return nil
+	}
+
+	m.items[k] = m.lru.PushFront(&memoryItem{group: group, key: key, value: value, expiresAt: expiresAt})
+	for m.lru.Len() > m.maxEntries {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// Invalidate drops every value in the groups.
+func (m *Memory) Invalidate(ctx context.Context, groups ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for el := m.lru.Front(); el != nil; {
+		next := el.Next()
+		for _, group := range groups {
+			if el.Value.(*memoryItem).group == group {
+				m.remove(el)
+				break
+			}
+		}
+		el = next
+	}
+	return nil
+}
+
+func (m *Memory) remove(el *list.Element) {
+	item := el.Value.(*memoryItem)
+	m.lru.Remove(el)
+	delete(m.items, memoryKey(item.group, item.key))
+}
@@ -0,0 +1,309 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redisKeyPrefix namespaces cache keys in a shared Redis.
+	redisKeyPrefix = "futuresignals:cache:"
+
+	// redisPoolSize is how many idle connections are kept open.
+	redisPoolSize = 8
+
+	// redisTimeout bounds a command, unless the context ends sooner.
+	redisTimeout = 2 * time.Second
+
+	// redisScanCount is the SCAN batch size used by Invalidate.
+	redisScanCount = 500
+)
+
+// Redis is a cache in Redis, shared by every instance that points at it.
+// It speaks just enough of the RESP protocol for GET, SET, SCAN and DEL.
+type Redis struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      bool
+
+	pool chan *redisConn
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// redisError is an error reply from the server.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// NewRedis connects to the Redis at rawURL, e.g.
+// redis://:password@localhost:6379/0 (rediss:// for TLS), and checks it
+// answers a PING.
+func NewRedis(ctx context.Context, rawURL string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("redis url scheme must be redis or rediss, got %q", u.Scheme)
+	}
+
+	c := &Redis{
+		addr: u.Host,
+		tls:  u.Scheme == "rediss",
+		pool: make(chan *redisConn, redisPoolSize),
+	}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("redis url database must be a number, got %q", db)
+		}
+	}
+
+	if _, err := c.do(ctx, "PING"); err != nil {
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return c, nil
+}
+
+func redisKey(group, key string) string {
+	return redisKeyPrefix + group + ":" + key
+}
+
+// Get returns the value cached under key.
+func (c *Redis) Get(ctx context.Context, group, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", redisKey(group, key))
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+// Set caches a value with a millisecond expiry.
+func (c *Redis) Set(ctx context.Context, group, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	_, err := c.do(ctx, "SET", redisKey(group, key), string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Invalidate deletes the keys of each group, found with SCAN so a large
+// keyspace doesn't block the server.
+func (c *Redis) Invalidate(ctx context.Context, groups ...string) error {
+	for _, group := range groups {
+		cursor := "0"
+		for {
+			reply, err := c.do(ctx, "SCAN", cursor, "MATCH", redisKey(group, "*"), "COUNT", strconv.Itoa(redisScanCount))
+			if err != nil {
+				return err
+			}
+			page, ok := reply.([]interface{})
+			if !ok || len(page) != 2 {
+				return fmt.Errorf("redis: unexpected SCAN reply %T", reply)
+			}
+			next, _ := page[0].([]byte)
+			keys, _ := page[1].([]interface{})
+
+			if len(keys) > 0 {
+				args := make([]string, 0, len(keys)+1)
+				args = append(args, "DEL")
+				for _, k := range keys {
+					if b, ok := k.([]byte); ok {
+						args = append(args, string(b))
+					}
+				}
+				if _, err := c.do(ctx, args...); err != nil {
+					return err
+				}
+			}
+
+			cursor = string(next)
+			if cursor == "0" || cursor == "" {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the idle connections.
+func (c *Redis) Close() error {
+	for {
+		select {
+		case rc := <-c.pool:
+			rc.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do runs one command on a pooled connection and returns its reply: nil,
+// a string, an int64, a []byte or a []interface{}.
+func (c *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+	rc, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	rc.conn.SetDeadline(deadline)
+
+	reply, err := rc.roundTrip(args)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection may be mid-reply; don't reuse it
+		rc.conn.Close()
+		return nil, err
+	}
+	c.put(rc)
+	return reply, err
+}
+
+func (c *Redis) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	var conn net.Conn
+	var err error
+	if c.tls {
+		host, _, _ := net.SplitHostPort(c.addr)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", c.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", c.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial redis: %w", err)
+	}
+
+	rc := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	var setup [][]string
+	switch {
+	case c.username != "" && c.password != "":
+		setup = append(setup, []string{"AUTH", c.username, c.password})
+	case c.password != "":
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	for _, args := range setup {
+		if _, err := rc.roundTrip(args); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis %s: %w", strings.ToLower(args[0]), err)
+		}
+	}
+	return rc, nil
+}
+
+func (c *Redis) put(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		rc.conn.Close()
+	}
+}
+
+// roundTrip writes a command as a RESP array of bulk strings and reads
+// the reply.
+func (rc *redisConn) roundTrip(args []string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(rc.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return rc.readReply()
+}
+
+func (rc *redisConn) readReply() (interface{}, error) {
+	line, err := rc.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rc.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad array length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			// Error replies inside an array are kept as values
+			item, err := rc.readReply()
+			var replyErr redisError
+			if err != nil && !errors.As(err, &replyErr) {
+				return nil, err
+			}
+			if err != nil {
+				item = err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
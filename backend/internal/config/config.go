@@ -2,39 +2,306 @@
 package config
 
 import (
+	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/leeaandrob/futuresignals/internal/models"
 	"github.com/rs/zerolog/log"
 )
 
 // Config holds all application configuration.
 type Config struct {
+	// LLM provider selection: qwen, openai or anthropic
+	LLMProvider string
+
+	// LLM resilience: attempts per model, per-attempt timeout, first retry
+	// backoff, and the tiers or models to fall back to
+	LLMMaxAttempts    int
+	LLMTimeout        time.Duration
+	LLMRetryBackoff   time.Duration
+	LLMFallbackModels []string
+
+	// Directory of prompt templates replacing or overriding the embedded
+	// ones; empty uses the embedded templates only
+	PromptsDir string
+
 	// Qwen/DashScope settings
 	DashScopeAPIKey   string
 	DashScopeEndpoint string
 	QwenModel         string
+	QwenFastModel     string
+
+	// OpenAI settings
+	OpenAIAPIKey    string
+	OpenAIEndpoint  string
+	OpenAIModel     string
+	OpenAIFastModel string
+
+	// Anthropic settings
+	AnthropicAPIKey    string
+	AnthropicModel     string
+	AnthropicFastModel string
 
 	// Enrichment API settings
 	TavilyAPIKey    string
 	ExaAPIKey       string
 	FirecrawlAPIKey string
 	EnableEnrichment bool
+	EnableGoogleNews bool
+	EnableReddit     bool
+
+	// Enrichment result freshness per source; 0 disables caching for it
+	EnrichmentCacheTavilyTTL    time.Duration
+	EnrichmentCacheExaTTL       time.Duration
+	EnrichmentCacheGoogleTTL    time.Duration
+	EnrichmentCacheRedditTTL    time.Duration
+	EnrichmentCacheFirecrawlTTL time.Duration
+	EnrichmentCacheMemory       int
 
-	// MongoDB settings
-	MongoURI string
-	MongoDB  string
+	// Embedding relevance scoring: provider is dashscope or openai (reusing
+	// that provider's API key and endpoint); disabled when empty
+	EmbeddingProvider  string
+	EmbeddingModel     string
+	EmbeddingThreshold float64
+
+	// XTracker polling of tracked accounts for social signals; posts are
+	// read back XTrackerLookback on every poll, and each post's impact is
+	// its market's move over the XTrackerImpactDelay after it
+	XTrackerEnabled      bool
+	XTrackerURL          string
+	XTrackerPollInterval time.Duration
+	XTrackerLookback     time.Duration
+	XTrackerImpactDelay  time.Duration
+
+	// LLM confirmation of social signal matches: pairs scoring below the
+	// threshold are dropped, and at most XTrackerRelevanceMaxPairs are sent
+	// per poll
+	XTrackerLLMRelevance       bool
+	XTrackerRelevanceThreshold float64
+	XTrackerRelevanceMaxPairs  int
+
+	// Post sources for tracked accounts on Bluesky and Truth Social
+	BlueskyURL     string
+	TruthSocialURL string
+
+	// MongoDB settings, and whether pending schema migrations are applied
+	// on startup
+	MongoURI         string
+	MongoDB          string
+	MigrateOnStartup bool
 
 	// Detector settings
 	MinProbabilityChange float64
 	MinVolume24h         float64
 	PollInterval         time.Duration
+	SnapshotInterval     time.Duration
+
+	// Event debounce and gating: per-market cooldown for repeating events,
+	// the score gain that bypasses it, and the minimum significance score
+	// for event-driven articles
+	EventCooldown   time.Duration
+	EventEscalation float64
+	EventMinScore   float64
+
+	// Breaking move noise filtering: the minimum market liquidity in USD,
+	// and how many consecutive sync cycles a move must hold
+	BreakingMinLiquidity  float64
+	BreakingConfirmCycles int
+
+	// How long after closing a market is archived; 0 never archives
+	MarketArchiveAfter time.Duration
+
+	// Retention of LLM usage records, raw analytics events, job runs and
+	// enrichment cache entries, purged daily; 0 keeps them
+	RetentionLLMUsage        time.Duration
+	RetentionAnalyticsEvents time.Duration
+	RetentionJobRuns         time.Duration
+	RetentionEnrichmentCache time.Duration
+
+	// Trending score weights and the market age at which the new market
+	// boost is halved
+	TrendingWeights  models.TrendingWeights
+	TrendingHalfLife time.Duration
+
+	// Breaking article deduplication: off, skip, update or follow_up
+	DedupMode            string
+	DedupWindow          time.Duration
+	DedupMinUpdateChange float64
+
+	// Windows of daily odds history breaking articles compare a move with
+	HistoryLookbacks []time.Duration
+
+	// Quality gate: articles failing a check are saved as drafts instead of
+	// published. Figures in an article's lead must be within the tolerances
+	// of the market data (percentage points; fraction of the volume).
+	QualityGateEnabled       bool
+	QualityMaxHeadlineLength int
+	QualityBannedPhrases     []string
+	QualityProbTolerance     float64
+	QualityVolumeTolerance   float64
+
+	// Scheduler locking: each scheduled job run takes a lock in MongoDB so
+	// only one instance runs it. InstanceID names this instance's locks
+	// (hostname and pid when empty).
+	SchedulerLocksEnabled bool
+	SchedulerLockTTL      time.Duration
+	InstanceID            string
+
+	// With scheduler locks on, an article generation on a market also
+	// takes a lock, kept GenerationCooldown afterwards so other instances'
+	// events on the market don't yield a second article
+	GenerationCooldown time.Duration
+
+	// Default retry policy for failed scheduler jobs: tries per run and the
+	// wait before the first retry, doubling after each
+	JobRetryAttempts int
+	JobRetryBackoff  time.Duration
+
+	// Server settings; market responses may be reused by clients and CDNs
+	// for MarketMaxAge before revalidating their ETag
+	HTTPAddr     string
+	SiteURL      string
+	Debug        bool
+	MarketMaxAge time.Duration
+
+	// On shutdown, how long article generations in flight and queued posts
+	// and notifications get to finish before they are cancelled
+	ShutdownTimeout time.Duration
+
+	// Admin API authentication
+	AdminAPIKey    string
+	AdminJWTSecret string
+	AdminRateLimit int
 
-	// Server settings
-	HTTPAddr string
-	Debug    bool
+	// Public API rate limits: requests per second and burst per client IP
+	// and per API key or signed-in user, with exempt IPs, CIDRs and key
+	// names
+	RateLimitIPRate   float64
+	RateLimitIPBurst  int
+	RateLimitKeyRate  float64
+	RateLimitKeyBurst int
+	RateLimitExempt   []string
+
+	// Proxies in front of the API whose ClientIPHeader carries the client
+	// address; forwarding headers from anyone else are ignored
+	TrustedProxies []string
+	ClientIPHeader string
+
+	// User accounts: session signing secret (sign-in is disabled when
+	// empty), session and magic link lifetimes, and an optional Google
+	// OAuth client
+	SessionSecret      string
+	SessionTTL         time.Duration
+	LoginLinkTTL       time.Duration
+	GoogleClientID     string
+	GoogleClientSecret string
+
+	// Public API URL for links in emails (confirm, unsubscribe)
+	PublicAPIURL string
+
+	// Newsletter settings: provider is smtp or ses; disabled when no
+	// SMTP host (smtp) or SMTP credentials (ses) are set
+	NewsletterProvider   string
+	NewsletterFrom       string
+	NewsletterBatchSize  int
+	NewsletterBatchDelay time.Duration
+	SMTPHost             string
+	SMTPPort             int
+	SMTPUsername         string
+	SMTPPassword         string
+	SESRegion            string
+
+	// X (Twitter) auto-posting (disabled unless all four credentials are set)
+	XAPIKey          string
+	XAPISecret       string
+	XAccessToken     string
+	XAccessSecret    string
+	XMinSignificance string
+	XMaxPostsPerHour int
+	XDedupWindow     time.Duration
+
+	// Discord webhooks by category ("default" receives everything); posting
+	// is disabled when empty
+	DiscordWebhooks        map[string][]string
+	DiscordMinSignificance string
+	DiscordEventTypes      []string
+	DiscordMaxPostsPerHour int
+	DiscordDedupWindow     time.Duration
+
+	// Watchlist alert webhooks
+	AlertsEnabled    bool
+	AlertsMaxPerHour int
+
+	// Content delivery API: the secret draft preview tokens are signed
+	// with (previews are off when empty) and their longest lifetime, and
+	// the https endpoints notified of article changes with the secret
+	// their notifications are signed with
+	ContentPreviewSecret string
+	ContentPreviewTTL    time.Duration
+	ContentWebhookURLs   []string
+	ContentWebhookSecret string
+
+	// S3 bucket (or S3-compatible endpoint) for share images and cached
+	// assets, when their storage is s3
+	S3Bucket      string
+	S3Region      string
+	S3Endpoint    string
+	S3AccessKeyID string
+	S3SecretKey   string
+	S3PublicURL   string
+
+	// Article share images: png or svg, stored in a local directory
+	// (served by the API at /og/) or S3, when OGStorage is s3
+	OGImagesEnabled bool
+	OGImageFormat   string
+	OGStorage       string
+	OGImageDir      string
+
+	// Market image proxy: images served from /api/assets/, cached in a
+	// local directory or S3, when AssetCache is s3
+	AssetProxyEnabled bool
+	AssetCache        string
+	AssetCacheDir     string
+
+	// Static site export: published articles rendered nightly to markdown
+	// and/or html files, in a local directory or S3 (under site/), when
+	// SiteExportStorage is s3
+	SiteExportEnabled bool
+	SiteExportFormats []string
+	SiteExportStorage string
+	SiteExportDir     string
+
+	// Headline A/B tests: impressions per variant before a winner is promoted
+	HeadlineTestMinImpressions int
+
+	// API read cache: off, memory (per process) or redis, with a TTL per
+	// group of endpoints
+	CacheBackend       string
+	CacheMemoryEntries int
+	RedisURL           string
+	CacheFeedTTL       time.Duration
+	CacheMarketsTTL    time.Duration
+	CacheCategoriesTTL time.Duration
+	CacheArticlesTTL   time.Duration
+
+	// Per-user notifications (require user accounts)
+	TelegramBotToken string
+	NotifyMaxPerHour int
+
+	// Tracing settings (disabled when OTLPEndpoint is empty)
+	OTLPEndpoint     string
+	OTelServiceName  string
+	TraceSampleRatio float64
+}
+
+// XEnabled reports whether X auto-posting is configured.
+func (c *Config) XEnabled() bool {
+	return c.XAPIKey != "" && c.XAPISecret != "" && c.XAccessToken != "" && c.XAccessSecret != ""
 }
 
 // Load loads configuration from environment variables.
@@ -44,43 +311,584 @@ func Load() (*Config, error) {
 		log.Debug().Msg("No .env file found, using environment variables")
 	}
 
+	// Pull secrets from Vault or AWS Secrets Manager, over the environment
+	if err := loadSecrets(); err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
+		// LLM provider
+		LLMProvider: getEnv("LLM_PROVIDER", "qwen"),
+
+		// LLM retries and fallback
+		LLMMaxAttempts:    getEnvInt("LLM_MAX_ATTEMPTS", 3),
+		LLMTimeout:        getEnvDuration("LLM_TIMEOUT", 60*time.Second),
+		LLMRetryBackoff:   getEnvDuration("LLM_RETRY_BACKOFF", time.Second),
+		LLMFallbackModels: splitList(getEnv("LLM_FALLBACK_MODELS", "fast")),
+
+		// Prompt templates
+		PromptsDir: getEnv("PROMPTS_DIR", ""),
+
 		// Qwen/DashScope
 		DashScopeAPIKey:   getEnv("DASHSCOPE_API_KEY", ""),
 		DashScopeEndpoint: getEnv("DASHSCOPE_ENDPOINT", "https://dashscope-intl.aliyuncs.com/compatible-mode/v1"),
 		QwenModel:         getEnv("QWEN_MODEL", "qwen-plus"),
+		QwenFastModel:     getEnv("QWEN_FAST_MODEL", "qwen-turbo"),
+
+		// OpenAI
+		OpenAIAPIKey:    getEnv("OPENAI_API_KEY", ""),
+		OpenAIEndpoint:  getEnv("OPENAI_ENDPOINT", "https://api.openai.com/v1"),
+		OpenAIModel:     getEnv("OPENAI_MODEL", "gpt-4o"),
+		OpenAIFastModel: getEnv("OPENAI_FAST_MODEL", "gpt-4o-mini"),
+
+		// Anthropic
+		AnthropicAPIKey:    getEnv("ANTHROPIC_API_KEY", ""),
+		AnthropicModel:     getEnv("ANTHROPIC_MODEL", "claude-3-5-sonnet-latest"),
+		AnthropicFastModel: getEnv("ANTHROPIC_FAST_MODEL", "claude-3-5-haiku-latest"),
 
 		// Enrichment APIs
 		TavilyAPIKey:     getEnv("TAVILY_API_KEY", ""),
 		ExaAPIKey:        getEnv("EXA_API_KEY", ""),
 		FirecrawlAPIKey:  getEnv("FIRECRAWL_API_KEY", ""),
 		EnableEnrichment: getEnvBool("ENABLE_ENRICHMENT", true),
+		EnableGoogleNews: getEnvBool("ENABLE_GOOGLE_NEWS", true),
+		EnableReddit:     getEnvBool("ENABLE_REDDIT", true),
+
+		EnrichmentCacheTavilyTTL:    getEnvDuration("ENRICHMENT_CACHE_TAVILY_TTL", 1*time.Hour),
+		EnrichmentCacheExaTTL:       getEnvDuration("ENRICHMENT_CACHE_EXA_TTL", 3*time.Hour),
+		EnrichmentCacheGoogleTTL:    getEnvDuration("ENRICHMENT_CACHE_GOOGLE_NEWS_TTL", 30*time.Minute),
+		EnrichmentCacheRedditTTL:    getEnvDuration("ENRICHMENT_CACHE_REDDIT_TTL", 1*time.Hour),
+		EnrichmentCacheFirecrawlTTL: getEnvDuration("ENRICHMENT_CACHE_FIRECRAWL_TTL", 24*time.Hour),
+		EnrichmentCacheMemory:       getEnvInt("ENRICHMENT_CACHE_MEMORY_ENTRIES", 512),
+
+		// Embeddings
+		EmbeddingProvider:  getEnv("EMBEDDING_PROVIDER", ""),
+		EmbeddingModel:     getEnv("EMBEDDING_MODEL", ""),
+		EmbeddingThreshold: getEnvFloat("EMBEDDING_THRESHOLD", 0.45),
+
+		// XTracker
+		XTrackerEnabled:      getEnvBool("XTRACKER_ENABLED", false),
+		XTrackerURL:          getEnv("XTRACKER_URL", "https://xtracker.polymarket.com/api"),
+		XTrackerPollInterval: getEnvDuration("XTRACKER_POLL_INTERVAL", 5*time.Minute),
+		XTrackerLookback:     getEnvDuration("XTRACKER_LOOKBACK", 2*time.Hour),
+		XTrackerImpactDelay:  getEnvDuration("XTRACKER_IMPACT_DELAY", 30*time.Minute),
+
+		XTrackerLLMRelevance:       getEnvBool("XTRACKER_LLM_RELEVANCE", false),
+		XTrackerRelevanceThreshold: getEnvFloat("XTRACKER_RELEVANCE_THRESHOLD", 0.6),
+		XTrackerRelevanceMaxPairs:  getEnvInt("XTRACKER_RELEVANCE_MAX_PAIRS", 200),
+
+		BlueskyURL:     getEnv("BLUESKY_URL", "https://public.api.bsky.app"),
+		TruthSocialURL: getEnv("TRUTHSOCIAL_URL", "https://truthsocial.com"),
 
 		// MongoDB
-		MongoURI: getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		MongoDB:  getEnv("MONGO_DB", "futuresignals"),
+		MongoURI:         getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDB:          getEnv("MONGO_DB", "futuresignals"),
+		MigrateOnStartup: getEnvBool("MIGRATE_ON_STARTUP", true),
 
 		// Detector
 		MinProbabilityChange: getEnvFloat("MIN_PROBABILITY_CHANGE", 0.07),
 		MinVolume24h:         getEnvFloat("MIN_VOLUME_24H", 50000),
 		PollInterval:         getEnvDuration("POLL_INTERVAL", 5*time.Minute),
+		SnapshotInterval:     getEnvDuration("SNAPSHOT_INTERVAL", 5*time.Minute),
+
+		// Event significance
+		EventCooldown:   getEnvDuration("EVENT_COOLDOWN", time.Hour),
+		EventEscalation: getEnvFloat("EVENT_ESCALATION", 0.15),
+		EventMinScore:   getEnvFloat("EVENT_MIN_SCORE", 0.4),
+
+		// Breaking move noise filtering
+		BreakingMinLiquidity:  getEnvFloat("BREAKING_MIN_LIQUIDITY", 10000),
+		BreakingConfirmCycles: getEnvInt("BREAKING_CONFIRM_CYCLES", 2),
+
+		// Archive
+		MarketArchiveAfter: getEnvDuration("MARKET_ARCHIVE_AFTER", 14*24*time.Hour),
+
+		// Retention
+		RetentionLLMUsage:        getEnvDuration("RETENTION_LLM_USAGE", 180*24*time.Hour),
+		RetentionAnalyticsEvents: getEnvDuration("RETENTION_ANALYTICS_EVENTS", models.AnalyticsEventRetention),
+		RetentionJobRuns:         getEnvDuration("RETENTION_JOB_RUNS", models.JobRunRetention),
+		RetentionEnrichmentCache: getEnvDuration("RETENTION_ENRICHMENT_CACHE", 7*24*time.Hour),
+
+		// Trending score
+		TrendingWeights: models.TrendingWeights{
+			Volume:   getEnvFloat("TRENDING_WEIGHT_VOLUME", 40),
+			Movement: getEnvFloat("TRENDING_WEIGHT_MOVEMENT", 30),
+			Velocity: getEnvFloat("TRENDING_WEIGHT_VELOCITY", 20),
+			Interest: getEnvFloat("TRENDING_WEIGHT_INTEREST", 10),
+			Recency:  getEnvFloat("TRENDING_WEIGHT_RECENCY", 10),
+		},
+		TrendingHalfLife: getEnvDuration("TRENDING_HALF_LIFE", 24*time.Hour),
+
+		// Deduplication
+		DedupMode:            getEnv("DEDUP_MODE", "update"),
+		DedupWindow:          getEnvDuration("DEDUP_WINDOW", 6*time.Hour),
+		DedupMinUpdateChange: getEnvFloat("DEDUP_MIN_UPDATE_CHANGE", 0.03),
+
+		// Odds history
+		HistoryLookbacks: getEnvDurations("HISTORY_LOOKBACKS", []time.Duration{7 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour}),
+
+		// Quality gate
+		QualityGateEnabled:       getEnvBool("QUALITY_GATE_ENABLED", true),
+		QualityMaxHeadlineLength: getEnvInt("QUALITY_MAX_HEADLINE_LENGTH", 120),
+		QualityBannedPhrases:     splitList(getEnv("QUALITY_BANNED_PHRASES", "")),
+		QualityProbTolerance:     getEnvFloat("QUALITY_PROBABILITY_TOLERANCE", 1),
+		QualityVolumeTolerance:   getEnvFloat("QUALITY_VOLUME_TOLERANCE", 0.1),
+
+		// Scheduler locking
+		SchedulerLocksEnabled: getEnvBool("SCHEDULER_LOCKS_ENABLED", true),
+		SchedulerLockTTL:      getEnvDuration("SCHEDULER_LOCK_TTL", time.Minute),
+		GenerationCooldown:    getEnvDuration("GENERATION_COOLDOWN", 5*time.Minute),
+		InstanceID:            getEnv("INSTANCE_ID", ""),
+		JobRetryAttempts:      getEnvInt("JOB_RETRY_ATTEMPTS", 3),
+		JobRetryBackoff:       getEnvDuration("JOB_RETRY_BACKOFF", time.Minute),
 
 		// Server
-		HTTPAddr: getEnv("HTTP_ADDR", ":8080"),
-		Debug:    getEnvBool("DEBUG", false),
+		HTTPAddr:     getEnv("HTTP_ADDR", ":8080"),
+		SiteURL:      getEnv("SITE_URL", "https://futuresignals.news"),
+		Debug:        getEnvBool("DEBUG", false),
+		MarketMaxAge: getEnvDuration("HTTP_MARKET_MAX_AGE", 15*time.Second),
+
+		// Shutdown drain, within Kubernetes' default 30s grace period
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 25*time.Second),
+
+		// Admin auth
+		AdminAPIKey:    getEnv("ADMIN_API_KEY", ""),
+		AdminJWTSecret: getEnv("ADMIN_JWT_SECRET", ""),
+		AdminRateLimit: getEnvInt("ADMIN_RATE_LIMIT", 120),
+
+		// Public rate limits
+		RateLimitIPRate:   getEnvFloat("RATE_LIMIT_IP_RPS", 0),
+		RateLimitIPBurst:  getEnvInt("RATE_LIMIT_IP_BURST", 40),
+		RateLimitKeyRate:  getEnvFloat("RATE_LIMIT_KEY_RPS", 50),
+		RateLimitKeyBurst: getEnvInt("RATE_LIMIT_KEY_BURST", 200),
+		RateLimitExempt:   splitList(getEnv("RATE_LIMIT_EXEMPT", "")),
+		TrustedProxies:    splitList(getEnv("TRUSTED_PROXIES", "")),
+		ClientIPHeader:    getEnv("CLIENT_IP_HEADER", "X-Forwarded-For"),
+
+		// User accounts
+		SessionSecret:      getEnv("SESSION_SECRET", ""),
+		SessionTTL:         getEnvDuration("SESSION_TTL", 30*24*time.Hour),
+		LoginLinkTTL:       getEnvDuration("LOGIN_LINK_TTL", 15*time.Minute),
+		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+
+		PublicAPIURL: getEnv("PUBLIC_API_URL", "http://localhost:8080"),
+
+		// Newsletter
+		NewsletterProvider:   getEnv("NEWSLETTER_PROVIDER", "smtp"),
+		NewsletterFrom:       getEnv("NEWSLETTER_FROM", "FutureSignals <briefing@futuresignals.news>"),
+		NewsletterBatchSize:  getEnvInt("NEWSLETTER_BATCH_SIZE", 50),
+		NewsletterBatchDelay: getEnvDuration("NEWSLETTER_BATCH_DELAY", 5*time.Second),
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		SESRegion:            getEnv("SES_REGION", "us-east-1"),
+
+		// X auto-posting
+		XAPIKey:          getEnv("X_API_KEY", ""),
+		XAPISecret:       getEnv("X_API_SECRET", ""),
+		XAccessToken:     getEnv("X_ACCESS_TOKEN", ""),
+		XAccessSecret:    getEnv("X_ACCESS_SECRET", ""),
+		XMinSignificance: getEnv("X_MIN_SIGNIFICANCE", "high"),
+		XMaxPostsPerHour: getEnvInt("X_MAX_POSTS_PER_HOUR", 4),
+		XDedupWindow:     getEnvDuration("X_DEDUP_WINDOW", 6*time.Hour),
+
+		// Discord
+		DiscordWebhooks:        parseWebhooks(getEnv("DISCORD_WEBHOOKS", "")),
+		DiscordMinSignificance: getEnv("DISCORD_MIN_SIGNIFICANCE", "medium"),
+		DiscordEventTypes:      splitList(getEnv("DISCORD_EVENT_TYPES", "")),
+		DiscordMaxPostsPerHour: getEnvInt("DISCORD_MAX_POSTS_PER_HOUR", 20),
+		DiscordDedupWindow:     getEnvDuration("DISCORD_DEDUP_WINDOW", 2*time.Hour),
+
+		// Watchlist alerts
+		AlertsEnabled:    getEnvBool("ALERTS_ENABLED", true),
+		AlertsMaxPerHour: getEnvInt("ALERTS_MAX_PER_HOUR", 30),
+
+		// Content delivery API
+		ContentPreviewSecret: getEnv("CONTENT_PREVIEW_SECRET", ""),
+		ContentPreviewTTL:    getEnvDuration("CONTENT_PREVIEW_TTL", 24*time.Hour),
+		ContentWebhookURLs:   splitList(getEnv("CONTENT_WEBHOOK_URLS", "")),
+		ContentWebhookSecret: getEnv("CONTENT_WEBHOOK_SECRET", ""),
+
+		// S3 storage
+		S3Bucket:      getEnv("S3_BUCKET", ""),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+		S3AccessKeyID: getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretKey:   getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3PublicURL:   getEnv("S3_PUBLIC_URL", ""),
+
+		// Share images
+		OGImagesEnabled: getEnvBool("OG_IMAGES_ENABLED", true),
+		OGImageFormat:   getEnv("OG_IMAGE_FORMAT", "png"),
+		OGStorage:       getEnv("OG_STORAGE", "file"),
+		OGImageDir:      getEnv("OG_IMAGE_DIR", "./data/og"),
+
+		// Asset proxy
+		AssetProxyEnabled: getEnvBool("ASSET_PROXY_ENABLED", true),
+		AssetCache:        getEnv("ASSET_CACHE", "file"),
+		AssetCacheDir:     getEnv("ASSET_CACHE_DIR", "./data/cache"),
+
+		// Static site export
+		SiteExportEnabled: getEnvBool("SITE_EXPORT_ENABLED", false),
+		SiteExportFormats: splitList(getEnv("SITE_EXPORT_FORMATS", "markdown,html")),
+		SiteExportStorage: getEnv("SITE_EXPORT_STORAGE", "file"),
+		SiteExportDir:     getEnv("SITE_EXPORT_DIR", "./data/site"),
+
+		// Headline tests
+		HeadlineTestMinImpressions: getEnvInt("HEADLINE_TEST_MIN_IMPRESSIONS", 500),
+
+		// Read cache
+		CacheBackend:       getEnv("CACHE_BACKEND", "off"),
+		CacheMemoryEntries: getEnvInt("CACHE_MEMORY_ENTRIES", 1024),
+		RedisURL:           getEnv("REDIS_URL", ""),
+		CacheFeedTTL:       getEnvDuration("CACHE_FEED_TTL", 30*time.Second),
+		CacheMarketsTTL:    getEnvDuration("CACHE_MARKETS_TTL", 30*time.Second),
+		CacheCategoriesTTL: getEnvDuration("CACHE_CATEGORIES_TTL", 5*time.Minute),
+		CacheArticlesTTL:   getEnvDuration("CACHE_ARTICLES_TTL", 5*time.Minute),
+
+		// User notifications
+		TelegramBotToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
+		NotifyMaxPerHour: getEnvInt("NOTIFY_MAX_PER_HOUR", 20),
+
+		// Tracing
+		OTLPEndpoint:     getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OTelServiceName:  getEnv("OTEL_SERVICE_NAME", "futuresignals"),
+		TraceSampleRatio: getEnvFloat("OTEL_TRACES_SAMPLE_RATIO", 1.0),
 	}
 
 	return cfg, nil
 }
 
-// Validate checks if required configuration is present.
+// Validate checks the configuration is complete and consistent. It
+// returns a *ValidationError listing every problem found.
 func (c *Config) Validate() error {
-	if c.DashScopeAPIKey == "" {
-		log.Warn().Msg("DASHSCOPE_API_KEY not set, narrative generation will be disabled")
+	v := &ValidationError{}
+
+	if !validMongoURI(c.MongoURI) {
+		v.addf("MONGO_URI must be a mongodb:// or mongodb+srv:// URI with a host, got %q", redactURI(c.MongoURI))
+	}
+	if c.MongoDB == "" {
+		v.addf("MONGO_DB must not be empty")
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"LLM_TIMEOUT", c.LLMTimeout},
+		{"POLL_INTERVAL", c.PollInterval},
+		{"SNAPSHOT_INTERVAL", c.SnapshotInterval},
+		{"SCHEDULER_LOCK_TTL", c.SchedulerLockTTL},
+		{"SHUTDOWN_TIMEOUT", c.ShutdownTimeout},
+	} {
+		if d.value <= 0 {
+			v.addf("%s must be positive, got %v", d.name, d.value)
+		}
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"EVENT_COOLDOWN", c.EventCooldown},
+		{"TRENDING_HALF_LIFE", c.TrendingHalfLife},
+		{"MARKET_ARCHIVE_AFTER", c.MarketArchiveAfter},
+		{"RETENTION_LLM_USAGE", c.RetentionLLMUsage},
+		{"RETENTION_ANALYTICS_EVENTS", c.RetentionAnalyticsEvents},
+		{"RETENTION_JOB_RUNS", c.RetentionJobRuns},
+		{"RETENTION_ENRICHMENT_CACHE", c.RetentionEnrichmentCache},
+		{"DEDUP_WINDOW", c.DedupWindow},
+		{"GENERATION_COOLDOWN", c.GenerationCooldown},
+	} {
+		if d.value < 0 {
+			v.addf("%s must not be negative, got %v", d.name, d.value)
+		}
+	}
+	for _, d := range c.HistoryLookbacks {
+		if d < 24*time.Hour {
+			v.addf("HISTORY_LOOKBACKS must be at least 24h each, as history is kept in daily rollups, got %v", d)
+		}
+	}
+	if c.PollInterval > 0 && c.SnapshotInterval > 0 && c.PollInterval > c.SnapshotInterval {
+		v.addf("POLL_INTERVAL (%v) must not exceed SNAPSHOT_INTERVAL (%v), or consecutive snapshots repeat the same prices", c.PollInterval, c.SnapshotInterval)
+	}
+
+	if c.MinProbabilityChange <= 0 || c.MinProbabilityChange > 1 {
+		v.addf("MIN_PROBABILITY_CHANGE must be greater than 0 and at most 1, got %v", c.MinProbabilityChange)
+	}
+	if c.MinVolume24h < 0 {
+		v.addf("MIN_VOLUME_24H must not be negative, got %v", c.MinVolume24h)
+	}
+	if c.BreakingMinLiquidity < 0 {
+		v.addf("BREAKING_MIN_LIQUIDITY must not be negative, got %v", c.BreakingMinLiquidity)
+	}
+	if c.BreakingConfirmCycles < 1 {
+		v.addf("BREAKING_CONFIRM_CYCLES must be at least 1, got %d", c.BreakingConfirmCycles)
+	}
+	for _, r := range []struct {
+		name  string
+		value time.Duration
+		ttl   time.Duration
+	}{
+		{"RETENTION_ANALYTICS_EVENTS", c.RetentionAnalyticsEvents, models.AnalyticsEventRetention},
+		{"RETENTION_JOB_RUNS", c.RetentionJobRuns, models.JobRunRetention},
+	} {
+		if r.value > r.ttl {
+			v.addf("%s must not exceed %v, after which a TTL index drops them anyway, got %v", r.name, r.ttl, r.value)
+		}
+	}
+	if err := c.TrendingWeights.Validate(); err != nil {
+		v.addf("TRENDING_WEIGHT_*: %v", err)
+	}
+	for _, f := range []struct {
+		name  string
+		value float64
+	}{
+		{"EVENT_ESCALATION", c.EventEscalation},
+		{"EVENT_MIN_SCORE", c.EventMinScore},
+		{"DEDUP_MIN_UPDATE_CHANGE", c.DedupMinUpdateChange},
+		{"QUALITY_VOLUME_TOLERANCE", c.QualityVolumeTolerance},
+		{"OTEL_TRACES_SAMPLE_RATIO", c.TraceSampleRatio},
+	} {
+		if f.value < 0 || f.value > 1 {
+			v.addf("%s must be between 0 and 1, got %v", f.name, f.value)
+		}
+	}
+	if c.QualityProbTolerance < 0 {
+		v.addf("QUALITY_PROBABILITY_TOLERANCE must not be negative, got %v", c.QualityProbTolerance)
+	}
+	if c.LLMMaxAttempts < 1 {
+		v.addf("LLM_MAX_ATTEMPTS must be at least 1, got %d", c.LLMMaxAttempts)
+	}
+
+	if c.EnableEnrichment && c.TavilyAPIKey == "" && c.ExaAPIKey == "" && c.FirecrawlAPIKey == "" && !c.EnableGoogleNews && !c.EnableReddit {
+		v.addf("ENABLE_ENRICHMENT requires a source: set TAVILY_API_KEY, EXA_API_KEY or FIRECRAWL_API_KEY, or enable ENABLE_GOOGLE_NEWS or ENABLE_REDDIT")
+	}
+
+	switch c.LLMProvider {
+	case "qwen":
+		if c.DashScopeAPIKey == "" {
+			log.Warn().Msg("DASHSCOPE_API_KEY not set, narrative generation will be disabled")
+		}
+	case "openai":
+		if c.OpenAIAPIKey == "" {
+			log.Warn().Msg("OPENAI_API_KEY not set, narrative generation will be disabled")
+		}
+	case "anthropic":
+		if c.AnthropicAPIKey == "" {
+			log.Warn().Msg("ANTHROPIC_API_KEY not set, narrative generation will be disabled")
+		}
+	default:
+		v.addf("unknown LLM_PROVIDER %q (expected qwen, openai or anthropic)", c.LLMProvider)
+	}
+
+	switch c.EmbeddingProvider {
+	case "":
+	case "dashscope":
+		if c.DashScopeAPIKey == "" {
+			v.addf("EMBEDDING_PROVIDER=dashscope requires DASHSCOPE_API_KEY")
+		}
+	case "openai":
+		if c.OpenAIAPIKey == "" {
+			v.addf("EMBEDDING_PROVIDER=openai requires OPENAI_API_KEY")
+		}
+	default:
+		v.addf("unknown EMBEDDING_PROVIDER %q (expected dashscope or openai)", c.EmbeddingProvider)
+	}
+	if c.EmbeddingThreshold <= 0 || c.EmbeddingThreshold > 1 {
+		v.addf("EMBEDDING_THRESHOLD must be between 0 and 1, got %v", c.EmbeddingThreshold)
+	}
+
+	if c.XTrackerEnabled && (c.XTrackerPollInterval <= 0 || c.XTrackerLookback <= 0) {
+		v.addf("XTRACKER_POLL_INTERVAL and XTRACKER_LOOKBACK must be positive when XTRACKER_ENABLED is set")
+	}
+	if c.XTrackerEnabled && (c.XTrackerImpactDelay <= 0 || c.XTrackerImpactDelay >= c.XTrackerLookback) {
+		v.addf("XTRACKER_IMPACT_DELAY must be positive and shorter than XTRACKER_LOOKBACK")
+	}
+	if c.XTrackerRelevanceThreshold <= 0 || c.XTrackerRelevanceThreshold > 1 {
+		v.addf("XTRACKER_RELEVANCE_THRESHOLD must be between 0 and 1, got %v", c.XTrackerRelevanceThreshold)
+	}
+
+	switch c.DedupMode {
+	case "off", "skip", "update", "follow_up":
+	default:
+		v.addf("unknown DEDUP_MODE %q (expected off, skip, update or follow_up)", c.DedupMode)
+	}
+
+	switch c.NewsletterProvider {
+	case "smtp", "ses":
+	default:
+		v.addf("unknown NEWSLETTER_PROVIDER %q (expected smtp or ses)", c.NewsletterProvider)
+	}
+
+	switch c.OGImageFormat {
+	case "png", "svg":
+	default:
+		v.addf("unknown OG_IMAGE_FORMAT %q (expected png or svg)", c.OGImageFormat)
+	}
+	for _, storage := range []struct {
+		name    string
+		value   string
+		enabled bool
+	}{
+		{"OG_STORAGE", c.OGStorage, c.OGImagesEnabled},
+		{"ASSET_CACHE", c.AssetCache, c.AssetProxyEnabled},
+		{"SITE_EXPORT_STORAGE", c.SiteExportStorage, c.SiteExportEnabled},
+	} {
+		switch storage.value {
+		case "file":
+		case "s3":
+			if storage.enabled && (c.S3Bucket == "" || c.S3AccessKeyID == "" || c.S3SecretKey == "") {
+				v.addf("%s=s3 requires S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY", storage.name)
+			}
+		default:
+			v.addf("unknown %s %q (expected file or s3)", storage.name, storage.value)
+		}
+	}
+
+	if c.RateLimitIPRate > 0 && (c.RateLimitIPBurst < 1 || c.RateLimitKeyRate <= 0 || c.RateLimitKeyBurst < 1) {
+		v.addf("RATE_LIMIT_IP_BURST, RATE_LIMIT_KEY_RPS and RATE_LIMIT_KEY_BURST must be positive when RATE_LIMIT_IP_RPS is set")
+	}
+	for _, entry := range c.TrustedProxies {
+		if !validIPNet(entry) {
+			v.addf("TRUSTED_PROXIES entries must be IPs or CIDR ranges, got %q", entry)
+		}
+	}
+
+	switch c.CacheBackend {
+	case "off", "memory":
+	case "redis":
+		if c.RedisURL == "" {
+			v.addf("CACHE_BACKEND=redis requires REDIS_URL")
+		}
+	default:
+		v.addf("unknown CACHE_BACKEND %q (expected off, memory or redis)", c.CacheBackend)
+	}
+
+	for _, significance := range []struct {
+		name  string
+		value string
+	}{
+		{"X_MIN_SIGNIFICANCE", c.XMinSignificance},
+		{"DISCORD_MIN_SIGNIFICANCE", c.DiscordMinSignificance},
+	} {
+		switch significance.value {
+		case "low", "medium", "high", "breaking":
+		default:
+			v.addf("unknown %s %q (expected low, medium, high or breaking)", significance.name, significance.value)
+		}
+	}
+
+	for _, f := range c.SiteExportFormats {
+		switch f {
+		case "markdown", "html":
+		default:
+			v.addf("unknown SITE_EXPORT_FORMATS entry %q (expected markdown or html)", f)
+		}
+	}
+
+	for _, t := range c.DiscordEventTypes {
+		switch t {
+		case "new_market", "price_change", "breaking_move", "volume_spike", "threshold_cross", "trending_update", "market_resolved", "whale_trade":
+		default:
+			v.addf("unknown DISCORD_EVENT_TYPES entry %q", t)
+		}
+	}
+
+	for category, urls := range c.DiscordWebhooks {
+		for _, u := range urls {
+			if !strings.HasPrefix(u, "https://") {
+				v.addf("invalid DISCORD_WEBHOOKS entry for %q: webhook URLs must use https", category)
+			}
+		}
+	}
+
+	if c.SessionSecret != "" && c.SessionSecret == c.AdminJWTSecret {
+		v.addf("SESSION_SECRET must differ from ADMIN_JWT_SECRET")
+	}
+	if c.ContentPreviewSecret != "" && (c.ContentPreviewSecret == c.SessionSecret || c.ContentPreviewSecret == c.AdminJWTSecret) {
+		v.addf("CONTENT_PREVIEW_SECRET must differ from SESSION_SECRET and ADMIN_JWT_SECRET")
+	}
+	if c.ContentPreviewTTL <= 0 {
+		v.addf("CONTENT_PREVIEW_TTL must be positive, got %v", c.ContentPreviewTTL)
+	}
+	for _, u := range c.ContentWebhookURLs {
+		if !strings.HasPrefix(u, "https://") {
+			v.addf("invalid CONTENT_WEBHOOK_URLS entry %q: webhook URLs must use https", u)
+		}
+	}
+	if len(c.ContentWebhookURLs) > 0 && c.ContentWebhookSecret == "" {
+		v.addf("CONTENT_WEBHOOK_URLS requires CONTENT_WEBHOOK_SECRET")
+	}
+
+	if c.AdminAPIKey == "" && c.AdminJWTSecret == "" {
+		log.Warn().Msg("ADMIN_API_KEY and ADMIN_JWT_SECRET not set, admin API only accepts stored API keys")
+	}
+
+	if len(v.Problems) > 0 {
+		return v
 	}
 	return nil
 }
 
+// ValidationError lists every problem Validate found, so they can all be
+// fixed at once.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0]
+	}
+	return fmt.Sprintf("%d configuration problems: %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) addf(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// validIPNet reports whether entry is an IP or a CIDR range.
+func validIPNet(entry string) bool {
+	if net.ParseIP(entry) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(entry)
+	return err == nil
+}
+
+// validMongoURI reports whether uri is a mongodb:// or mongodb+srv:// URI
+// naming at least one host.
+func validMongoURI(uri string) bool {
+	rest, ok := strings.CutPrefix(uri, "mongodb://")
+	if !ok {
+		rest, ok = strings.CutPrefix(uri, "mongodb+srv://")
+	}
+	if !ok {
+		return false
+	}
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	return rest != ""
+}
+
+// redactURI hides the credentials in a URI for error messages.
+func redactURI(uri string) string {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return uri
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 && !strings.ContainsAny(rest[:i], "/?") {
+		rest = "***@" + rest[i+1:]
+	}
+	return scheme + "://" + rest
+}
+
 // Helper functions
 
 func getEnv(key, defaultValue string) string {
@@ -125,3 +933,46 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getEnvDurations parses a comma-separated list of durations, falling back
+// to defaultValue if any is malformed.
+func getEnvDurations(key string, defaultValue []time.Duration) []time.Duration {
+	var durations []time.Duration
+	for _, item := range splitList(os.Getenv(key)) {
+		d, err := time.ParseDuration(item)
+		if err != nil {
+			return defaultValue
+		}
+		durations = append(durations, d)
+	}
+	if len(durations) == 0 {
+		return defaultValue
+	}
+	return durations
+}
+
+// splitList parses a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseWebhooks parses "category=url,category=url,url" into URLs by
+// category. Entries without a category go to "default"; repeat a category
+// to give it several webhooks.
+func parseWebhooks(value string) map[string][]string {
+	webhooks := make(map[string][]string)
+	for _, entry := range splitList(value) {
+		category, url := "default", entry
+		if i := strings.Index(entry, "="); i > 0 && !strings.Contains(entry[:i], "/") {
+			category, url = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		webhooks[category] = append(webhooks[category], url)
+	}
+	return webhooks
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SecretsProvider fetches configuration secrets from an external store.
+// Load sets each secret as the environment variable of the same name, so
+// a store holding DASHSCOPE_API_KEY or MONGO_URI overrides the .env file.
+type SecretsProvider interface {
+	// Secrets returns the stored secrets by environment variable name
+	Secrets(ctx context.Context) (map[string]string, error)
+}
+
+// secretsTimeout bounds fetching secrets at startup.
+const secretsTimeout = 10 * time.Second
+
+// NewSecretsProvider returns the provider named by SECRETS_PROVIDER: vault
+// or aws, configured from the environment. It returns nil for "" or none.
+func NewSecretsProvider(name string) (SecretsProvider, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "vault":
+		p := &VaultProvider{
+			Addr:      strings.TrimRight(os.Getenv("VAULT_ADDR"), "/"),
+			Token:     os.Getenv("VAULT_TOKEN"),
+			Namespace: os.Getenv("VAULT_NAMESPACE"),
+			Mount:     getEnv("VAULT_KV_MOUNT", "secret"),
+			Path:      getEnv("VAULT_SECRET_PATH", "futuresignals"),
+		}
+		if p.Addr == "" || p.Token == "" {
+			return nil, fmt.Errorf("SECRETS_PROVIDER=vault requires VAULT_ADDR and VAULT_TOKEN")
+		}
+		return p, nil
+	case "aws":
+		p := &AWSSecretsManagerProvider{
+			Region:          getEnv("AWS_REGION", os.Getenv("AWS_DEFAULT_REGION")),
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			SecretID:        getEnv("AWS_SECRET_ID", "futuresignals"),
+		}
+		if p.Region == "" || p.AccessKeyID == "" || p.SecretAccessKey == "" {
+			return nil, fmt.Errorf("SECRETS_PROVIDER=aws requires AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q (expected vault or aws)", name)
+	}
+}
+
+// loadSecrets fetches the secrets of the SECRETS_PROVIDER, if any, into
+// the environment.
+func loadSecrets() error {
+	name := os.Getenv("SECRETS_PROVIDER")
+	provider, err := NewSecretsProvider(name)
+	if err != nil || provider == nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+	secrets, err := provider.Secrets(ctx)
+	if err != nil {
+		return fmt.Errorf("loading secrets from %s: %w", name, err)
+	}
+
+	names := make([]string, 0, len(secrets))
+	for key, value := range secrets {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("setting secret %s: %w", key, err)
+		}
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	log.Info().Str("provider", name).Strs("keys", names).Msg("Loaded secrets")
+	return nil
+}
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 secret,
+// whose keys are environment variable names.
+type VaultProvider struct {
+	Addr      string
+	Token     string
+	Namespace string // Vault Enterprise namespace; empty for none
+	Mount     string // KV engine mount, e.g. "secret"
+	Path      string // secret path within the mount
+}
+
+// Secrets returns the latest version of the secret.
+func (p *VaultProvider) Secrets(ctx context.Context) (map[string]string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.Addr, strings.Trim(p.Mount, "/"), strings.Trim(p.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	var result struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := doSecretsRequest(req, &result); err != nil {
+		return nil, err
+	}
+	return stringValues(result.Data.Data), nil
+}
+
+// AWSSecretsManagerProvider reads secrets from an AWS Secrets Manager
+// secret holding a JSON object keyed by environment variable name.
+type AWSSecretsManagerProvider struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // for temporary credentials; empty for none
+	SecretID        string // secret name or ARN
+}
+
+// Secrets returns the secret's current version.
+func (p *AWSSecretsManagerProvider) Secrets(ctx context.Context) (map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": p.SecretID})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", p.Region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if p.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.SessionToken)
+	}
+	p.sign(req, body, time.Now().UTC())
+
+	var result struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := doSecretsRequest(req, &result); err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(result.SecretString), &values); err != nil {
+		return nil, fmt.Errorf("secret %s is not a JSON object: %w", p.SecretID, err)
+	}
+	return stringValues(values), nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req.
+func (p *AWSSecretsManagerProvider) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + p.Region + "/secretsmanager/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.SecretAccessKey), day)
+	key = hmacSHA256(key, p.Region)
+	key = hmacSHA256(key, "secretsmanager")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// doSecretsRequest sends a secrets store request and decodes its JSON
+// response into result.
+func doSecretsRequest(req *http.Request, result interface{}) error {
+	client := &http.Client{Timeout: secretsTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		// Error bodies name the problem, never the secret values
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// stringValues keeps a secret's string values, formatting numbers and
+// booleans as they would be written in an .env file.
+func stringValues(values map[string]interface{}) map[string]string {
+	secrets := make(map[string]string, len(values))
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			secrets[key] = v
+		case float64, bool:
+			secrets[key] = fmt.Sprint(v)
+		}
+	}
+	return secrets
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,121 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ClosingSoonContent is the LLM output for a markets-resolving-this-week
+// article.
+type ClosingSoonContent struct {
+	Headline    string   `json:"headline"`
+	Summary     string   `json:"summary"`
+	Overview    string   `json:"overview"`
+	Analysis    string   `json:"analysis"`
+	WhatToWatch string   `json:"what_to_watch"`
+	Tags        []string `json:"tags"`
+	Sentiment   string   `json:"sentiment"`
+}
+
+// GenerateClosingSoon generates a "Markets Resolving This Week" article
+// listing the deadlines and current odds of up to limit open markets ending
+// within the next days days. It is generated at most once a day.
+func (g *Generator) GenerateClosingSoon(ctx context.Context, days, limit int) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeDigest))
+	ctx, span := tracing.Start(ctx, "content.GenerateClosingSoon", attribute.String("article.type", string(models.ArticleTypeDigest)))
+	defer span.End()
+
+	now := time.Now().UTC()
+	slug := fmt.Sprintf("markets-resolving-this-week-%s", now.Format("2006-01-02"))
+	if existing, err := g.store.GetArticleBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	markets, err := g.store.GetClosingSoonMarkets(ctx, time.Duration(days)*24*time.Hour, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+	data := closingSoonPromptData(days, markets, now)
+	if len(data.Markets) == 0 {
+		return nil, fmt.Errorf("no markets close within %d days", days)
+	}
+
+	log.Info().
+		Int("days", days).
+		Int("markets", len(data.Markets)).
+		Msg("Generating closing-soon article")
+
+	content, err := g.generateClosingSoonContent(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	var deadlines []string
+	refs := make([]models.MarketRef, 0, len(data.Markets))
+	for _, m := range data.Markets {
+		deadlines = append(deadlines, fmt.Sprintf("%s: %s (%.0f%% yes)", m.Deadline, m.Question, m.Probability*100))
+		refs = append(refs, m.MarketRef)
+	}
+
+	article, err := g.saveRoundup(ctx, roundup{
+		Slug:     slug,
+		Type:     models.ArticleTypeDigest,
+		Title:    "Markets Resolving This Week",
+		Dated:    now.Format("January 2, 2006"),
+		Headline: content.Headline,
+		Summary:  content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.Overview,
+			WhyItMatters: content.Analysis,
+			Context:      deadlines,
+			WhatToWatch:  content.WhatToWatch,
+		},
+		Markets:   refs,
+		Tags:      append([]string{"closing-soon", "deadlines", "markets"}, content.Tags...),
+		Sentiment: content.Sentiment,
+		Sources:   data.sources(),
+		Social:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Int("markets", len(refs)).
+		Msg("Closing-soon article generated")
+
+	return article, nil
+}
+
+func (g *Generator) generateClosingSoonContent(ctx context.Context, data closingSoonPrompt) (*ClosingSoonContent, error) {
+	if g.llm == nil {
+		first := data.Markets[0]
+		return &ClosingSoonContent{
+			Headline:    fmt.Sprintf("%d Markets Face Deadlines in the Next %d Days", len(data.Markets), data.Days),
+			Summary:     fmt.Sprintf("%d prediction markets resolve in the next %d days, starting with \"%s\".", len(data.Markets), data.Days, first.Question),
+			Overview:    fmt.Sprintf("The first deadline is %s, when \"%s\" closes with traders at %.0f%%.", first.Deadline, first.Question, first.Probability*100),
+			Analysis:    "Prices this close to a deadline reflect how settled traders think each outcome is.",
+			WhatToWatch: "Watch for late price swings as each deadline approaches.",
+			Tags:        []string{},
+			Sentiment:   "neutral",
+		}, nil
+	}
+
+	var result ClosingSoonContent
+	err := g.chatPrompt(ctx, prompts.ClosingSoon, models.ArticleTypeDigest, data, 800, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
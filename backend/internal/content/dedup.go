@@ -0,0 +1,160 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrDuplicateArticle is returned when generation is skipped because a recent
+// article already covers the same primary market.
+var ErrDuplicateArticle = errors.New("recent article already covers this market")
+
+// DedupMode controls what happens when a breaking event hits a market that
+// already has a recent article.
+type DedupMode string
+
+const (
+	// DedupOff always generates a new article.
+	DedupOff DedupMode = "off"
+	// DedupSkip drops the event.
+	DedupSkip DedupMode = "skip"
+	// DedupUpdate amends the original article if the market moved enough.
+	DedupUpdate DedupMode = "update"
+	// DedupFollowUp writes a linked "Market Update" article if the market moved enough.
+	DedupFollowUp DedupMode = "follow_up"
+)
+
+// DedupConfig holds breaking article deduplication settings.
+type DedupConfig struct {
+	// How far back to look for an existing article on the same market
+	Window time.Duration
+
+	Mode DedupMode
+
+	// Minimum probability move since the article's last recorded value
+	// before an amendment or follow-up is written
+	MinUpdateChange float64
+}
+
+// DefaultDedupConfig returns default deduplication settings.
+func DefaultDedupConfig() DedupConfig {
+	return DedupConfig{
+		Window:          6 * time.Hour,
+		Mode:            DedupUpdate,
+		MinUpdateChange: 0.03,
+	}
+}
+
+// SetDedup sets the deduplication settings for breaking articles.
+func (g *Generator) SetDedup(cfg DedupConfig) {
+	g.dedup = cfg
+}
+
+// findRecentBreaking returns the latest breaking or follow-up article
+// published within the dedup window for the market, or nil if there is none
+// or dedup is off.
+func (g *Generator) findRecentBreaking(ctx context.Context, marketID string) *models.Article {
+	if g.dedup.Mode == DedupOff || g.dedup.Window <= 0 {
+		return nil
+	}
+
+	since := time.Now().Add(-g.dedup.Window)
+	article, err := g.store.GetRecentArticleForMarket(ctx, marketID, since,
+		models.ArticleTypeBreaking, models.ArticleTypeUpdate)
+	if err != nil {
+		// Fail open: a duplicate is better than a missed story
+		log.Warn().Err(err).Str("market_id", marketID).Msg("Failed to check for duplicate article")
+		return nil
+	}
+	return article
+}
+
+// handleDuplicate amends the original article or writes a follow-up to it,
+// depending on the mode, when the market has moved at least MinUpdateChange
+// since the article last reported it. Otherwise, or in skip mode, it returns
+// ErrDuplicateArticle.
+func (g *Generator) handleDuplicate(ctx context.Context, original *models.Article, market *models.Market) (*models.Article, error) {
+	if original.PrimaryMarket == nil {
+		return nil, ErrDuplicateArticle
+	}
+	if abs(market.Probability-original.PrimaryMarket.Probability) < g.dedup.MinUpdateChange {
+		return nil, ErrDuplicateArticle
+	}
+
+	switch g.dedup.Mode {
+	case DedupUpdate:
+		return g.amendBreaking(ctx, original, market)
+	case DedupFollowUp:
+		return g.GenerateFollowUp(ctx, original, market)
+	default:
+		return nil, ErrDuplicateArticle
+	}
+}
+
+// amendBreaking appends an amendment to the original article and refreshes
+// its market figures and, if it is published, its short-form variants. The
+// amendment's figures are fact-checked against the refreshed markets.
+func (g *Generator) amendBreaking(ctx context.Context, original *models.Article, market *models.Market) (*models.Article, error) {
+	lastProb := original.PrimaryMarket.Probability
+
+	// Describe the move since the article, not the full 24h change
+	moved := *market
+	moved.PreviousProb = lastProb
+
+	signal := g.narrativeSignal(ctx, &moved, "", models.ArticleTypeBreaking)
+	narrative, err := g.generateNarrative(ctx, signal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate amendment: %w", err)
+	}
+
+	original.Amendments = append(original.Amendments, models.ArticleAmendment{
+		Headline:     narrative.Headline,
+		WhatChanged:  narrative.WhatChanged,
+		PreviousProb: lastProb,
+		Probability:  market.Probability,
+		AmendedAt:    time.Now(),
+	})
+
+	// Refresh the market figures shown with the article
+	original.PrimaryMarket.Probability = market.Probability
+	original.PrimaryMarket.Change24h = market.Change24h
+	original.PrimaryMarket.Volume24h = market.Volume24h
+	for i := range original.Markets {
+		if original.Markets[i].MarketID == market.MarketID {
+			original.Markets[i].PreviousProb = lastProb
+			original.Markets[i].Probability = market.Probability
+			original.Markets[i].Change24h = market.Change24h
+			original.Markets[i].Volume24h = market.Volume24h
+			original.Markets[i].TotalVolume = market.TotalVolume
+		}
+	}
+	original.Sentiment = narrative.Sentiment
+	if models.Significance(narrative.Significance).Rank() > original.Significance.Rank() {
+		original.Significance = models.Significance(narrative.Significance)
+	}
+	sources := signalSources(signal)
+	g.checkAmendment(original, &original.Amendments[len(original.Amendments)-1], sources)
+	if original.Published {
+		g.addShortForm(ctx, original, sources...)
+	}
+
+	if err := g.store.UpdateArticle(ctx, original); err != nil {
+		return nil, fmt.Errorf("failed to save amendment: %w", err)
+	}
+	g.articlesChanged(ctx)
+	g.articleUpdated(ctx, original)
+
+	log.Info().
+		Str("slug", original.Slug).
+		Int("amendments", len(original.Amendments)).
+		Float64("previous", lastProb).
+		Float64("current", market.Probability).
+		Msg("Breaking article amended")
+
+	return original, nil
+}
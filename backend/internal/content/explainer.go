@@ -0,0 +1,141 @@
+package content
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// explainerMarkets is how many markets in the topic's category an
+// explainer cites as examples.
+const explainerMarkets = 5
+
+// ExplainerContent is the LLM output for an explainer article.
+type ExplainerContent struct {
+	Headline     string   `json:"headline"`
+	Summary      string   `json:"summary"`
+	WhatItIs     string   `json:"what_it_is"`
+	HowItWorks   string   `json:"how_it_works"`
+	WhyItMatters string   `json:"why_it_matters"`
+	KeyPoints    []string `json:"key_points"`
+	WhatToWatch  string   `json:"what_to_watch"`
+	Tags         []string `json:"tags"`
+}
+
+// GenerateExplainer generates an evergreen explainer article on a curated
+// topic, from the explainer enrichment profile's searches and the top
+// markets in the topic's category as examples. The article's slug is the
+// topic's ID, so each topic is explained once.
+func (g *Generator) GenerateExplainer(ctx context.Context, topic *models.ExplainerTopic) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeExplainer))
+	ctx, span := tracing.Start(ctx, "content.GenerateExplainer", attribute.String("article.type", string(models.ArticleTypeExplainer)))
+	defer span.End()
+
+	if existing, err := g.store.GetArticleBySlug(ctx, topic.ID); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	var refs []models.MarketRef
+	if topic.Category != "" {
+		markets, err := g.store.GetMarketsByCategory(ctx, topic.Category, explainerMarkets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get markets: %w", err)
+		}
+		for i := range markets {
+			refs = append(refs, marketRef(&markets[i]))
+		}
+	}
+
+	enrichedCtx := ""
+	var sources []string
+	if g.enricher != nil {
+		query := &models.Market{Question: topic.SearchQuery(), Category: topic.Category}
+		ctx, err := g.enricher.Enrich(ctx, query, models.ArticleTypeExplainer)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to enrich context")
+		} else if ctx != nil {
+			enrichedCtx = ctx.Summary
+			sources = ctx.Sources
+		}
+	}
+
+	log.Info().
+		Str("topic", topic.ID).
+		Int("markets", len(refs)).
+		Msg("Generating explainer article")
+
+	data := explainerPrompt{Title: topic.Title, Notes: topic.Notes, Markets: refs, Context: truncate(enrichedCtx, 8000)}
+	content, err := g.generateExplainerContent(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	category := topic.Category
+	if category == "" {
+		category = "explainer"
+	}
+	article := &models.Article{
+		Slug:        topic.ID,
+		Type:        models.ArticleTypeExplainer,
+		Category:    category,
+		Headline:    content.Headline,
+		Subheadline: content.Summary,
+		Summary:     content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.WhatItIs,
+			WhyItMatters: content.WhyItMatters,
+			Context:      content.KeyPoints,
+			WhatToWatch:  content.WhatToWatch,
+			Analysis:     content.HowItWorks,
+		},
+		Markets:           refs,
+		Tags:              append([]string{"explainer", "prediction-markets"}, content.Tags...),
+		Significance:      models.SignificanceLow,
+		Sentiment:         "neutral",
+		MetaTitle:         fmt.Sprintf("%s | FutureSignals Explainer", content.Headline),
+		MetaDescription:   content.Summary,
+		EnrichmentSources: sources,
+		Published:         true,
+	}
+
+	if err := g.saveArticle(ctx, article, enrichedCtx); err != nil {
+		return nil, fmt.Errorf("failed to save article: %w", err)
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Strs("sources", sources).
+		Msg("Explainer article generated")
+
+	return article, nil
+}
+
+func (g *Generator) generateExplainerContent(ctx context.Context, data explainerPrompt) (*ExplainerContent, error) {
+	if g.llm == nil {
+		return &ExplainerContent{
+			Headline:     data.Title,
+			Summary:      fmt.Sprintf("A guide to %s.", data.Title),
+			WhatItIs:     fmt.Sprintf("This explainer covers %s.", data.Title),
+			HowItWorks:   "Prediction market prices run from 0 to 100 cents and read as the odds traders give an outcome.",
+			WhyItMatters: "Understanding how these markets work helps readers judge what their odds say.",
+			KeyPoints:    []string{},
+			WhatToWatch:  "Follow the markets below to see these ideas at work.",
+			Tags:         []string{},
+		}, nil
+	}
+
+	var result ExplainerContent
+	err := g.chatPrompt(ctx, prompts.Explainer, models.ArticleTypeExplainer, data, 1800, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,329 @@
+package content
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// figureKind is what a figure in generated text measures.
+type figureKind int
+
+const (
+	// figurePercent is a probability or percentage, e.g. "62%"
+	figurePercent figureKind = iota
+	// figurePoints is a change in percentage points, e.g. "12 points"
+	figurePoints
+	// figureDollars is a dollar amount, e.g. "$1.2M"
+	figureDollars
+)
+
+// figure is a number quoted in text.
+type figure struct {
+	Text  string
+	Kind  figureKind
+	Value float64
+
+	// Digits as written, and the place value of the last one in the
+	// figure's units (0.1 for "62.4%", 100K for "$1.2M")
+	Digits string
+	Unit   float64
+
+	// Byte offsets of Text in the text it was found in
+	Start, End int
+}
+
+var (
+	percentPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)[\s-]?(%|percent\b|(?:percentage[- ])?(?:points?|pts)\b)`)
+	dollarPattern  = regexp.MustCompile(`(?i)\$(\d+(?:,\d{3})*(?:\.\d+)?)\s?(k|m|b|thousand|million|billion)?\b`)
+)
+
+// extractFigures returns the percentages, point changes and dollar amounts
+// in text.
+func extractFigures(text string) []figure {
+	var figures []figure
+	for _, loc := range percentPattern.FindAllStringSubmatchIndex(text, -1) {
+		digits, suffix := text[loc[2]:loc[3]], text[loc[4]:loc[5]]
+		value, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			continue
+		}
+		kind := figurePercent
+		if suffix = strings.ToLower(suffix); suffix != "%" && suffix != "percent" {
+			kind = figurePoints
+		}
+		figures = append(figures, figure{
+			Text: text[loc[0]:loc[1]], Kind: kind, Value: value, Digits: digits, Unit: roundingUnit(digits),
+			Start: loc[0], End: loc[1],
+		})
+	}
+	for _, loc := range dollarPattern.FindAllStringSubmatchIndex(text, -1) {
+		digits := strings.ReplaceAll(text[loc[2]:loc[3]], ",", "")
+		value, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			continue
+		}
+		var suffix string
+		if loc[4] >= 0 {
+			suffix = text[loc[4]:loc[5]]
+		}
+		scale := dollarScale(suffix)
+		figures = append(figures, figure{
+			Text: text[loc[0]:loc[1]], Kind: figureDollars, Value: value * scale, Digits: digits, Unit: roundingUnit(digits) * scale,
+			Start: loc[0], End: loc[1],
+		})
+	}
+	return figures
+}
+
+// replaceFigures replaces the figures in text that have corrections. Only
+// whole figures are replaced, so correcting "2 points" leaves "12 points"
+// alone.
+func replaceFigures(text string, corrections map[string]string) string {
+	figures := extractFigures(text)
+	sort.Slice(figures, func(i, j int) bool { return figures[i].Start > figures[j].Start })
+	for _, f := range figures {
+		if corrected, ok := corrections[f.Text]; ok {
+			text = text[:f.Start] + corrected + text[f.End:]
+		}
+	}
+	return text
+}
+
+// sourceFigures are the values figures in an article may legitimately
+// quote.
+type sourceFigures struct {
+	points  []float64 // Probabilities and changes, in percentage points
+	dollars []float64
+
+	// The move a single-market article describes, used to correct wrong
+	// point changes; -1 when unknown
+	change float64
+}
+
+// newSourceFigures collects the market data and the numbers quoted in the
+// context the article was written from.
+func newSourceFigures(markets []models.MarketRef, sources []string) sourceFigures {
+	sf := sourceFigures{change: -1}
+	var volume24h, totalVolume float64
+	for _, m := range markets {
+		sf.points = append(sf.points,
+			m.Probability*100, (1-m.Probability)*100,
+			math.Abs(m.Change24h)*100)
+		if m.PreviousProb > 0 {
+			sf.points = append(sf.points, m.PreviousProb*100, (1-m.PreviousProb)*100, math.Abs(m.Probability-m.PreviousProb)*100)
+		}
+		sf.dollars = append(sf.dollars, m.Volume24h, m.TotalVolume)
+		volume24h += m.Volume24h
+		totalVolume += m.TotalVolume
+	}
+	sf.dollars = append(sf.dollars, volume24h, totalVolume)
+
+	if len(markets) == 1 {
+		if m := markets[0]; m.PreviousProb > 0 {
+			sf.change = math.Abs(m.Probability-m.PreviousProb) * 100
+		} else {
+			sf.change = math.Abs(m.Change24h) * 100
+		}
+	}
+
+	for _, source := range sources {
+		for _, f := range extractFigures(source) {
+			if f.Kind == figureDollars {
+				sf.dollars = append(sf.dollars, f.Value)
+			} else {
+				sf.points = append(sf.points, f.Value)
+			}
+		}
+	}
+	return sf
+}
+
+// verify reports whether f matches a source value within the tolerances,
+// allowing for rounding to the precision f is written with.
+func (sf sourceFigures) verify(f figure, cfg QualityConfig) bool {
+	if f.Kind == figureDollars {
+		return matchesAny(f.Value, sf.dollars, f.Unit/2, cfg.VolumeTolerance)
+	}
+	return matchesAny(f.Value, sf.points, cfg.ProbabilityTolerance+f.Unit/2, 0)
+}
+
+// factCheck cross-checks every percentage and dollar figure in the article
+// against its market data and the source context it was written from,
+// corrects point changes that misstate a single market's move, and stores
+// the report on the article.
+func (g *Generator) factCheck(article *models.Article, sources []string) {
+	sf := newSourceFigures(article.Markets, sources)
+	report := &models.FactCheck{CheckedAt: time.Now()}
+
+	texts := []checkedText{
+		{models.SectionHeadline, &article.Headline},
+		{models.SectionSubheadline, &article.Subheadline},
+		{models.SectionSummary, &article.Summary},
+		{models.SectionWhatHappened, &article.Body.WhatHappened},
+		{models.SectionWhyItMatters, &article.Body.WhyItMatters},
+		{models.SectionWhatToWatch, &article.Body.WhatToWatch},
+	}
+	for i := range article.Body.Context {
+		texts = append(texts, checkedText{models.SectionContext, &article.Body.Context[i]})
+	}
+
+	// Corrections are applied once every figure is checked, to copies of
+	// the lead too
+	corrections, _ := g.checkFigures(sf, texts, report)
+	if len(corrections) > 0 {
+		applyCorrections(texts, corrections)
+		article.MetaTitle = replaceFigures(article.MetaTitle, corrections)
+		article.MetaDescription = replaceFigures(article.MetaDescription, corrections)
+		if article.HeadlineTest != nil {
+			for i := range article.HeadlineTest.Variants {
+				article.HeadlineTest.Variants[i].Headline = replaceFigures(article.HeadlineTest.Variants[i].Headline, corrections)
+			}
+		}
+	}
+
+	article.FactCheck = report
+}
+
+// checkAmendment fact-checks an amendment to the article against the
+// article's refreshed markets and the context the amendment was written
+// from, adding it to the article's report and correcting misstated moves.
+func (g *Generator) checkAmendment(article *models.Article, amendment *models.ArticleAmendment, sources []string) {
+	texts := []checkedText{
+		{models.SectionAmendment, &amendment.Headline},
+		{models.SectionAmendment, &amendment.WhatChanged},
+	}
+	report := addToFactCheck(article)
+	corrected, unverified := report.Corrected, report.Unverified
+
+	corrections, _ := g.checkFigures(newSourceFigures(article.Markets, sources), texts, report)
+	applyCorrections(texts, corrections)
+
+	if report.Corrected > corrected || report.Unverified > unverified {
+		log.Info().
+			Str("slug", article.Slug).
+			Int("corrected", report.Corrected-corrected).
+			Int("unverified", report.Unverified-unverified).
+			Msg("Fact check found mismatched figures in amendment")
+	}
+}
+
+// checkedText is generated text whose figures are fact-checked.
+type checkedText struct {
+	section models.ArticleSection
+	text    *string
+}
+
+// checkFigures checks the figures in texts against sf, counting them in
+// report and listing the ones that don't match. It returns the corrections
+// for point changes that misstate a single market's move, and which texts
+// quote a figure that could be neither verified nor corrected.
+func (g *Generator) checkFigures(sf sourceFigures, texts []checkedText, report *models.FactCheck) (map[string]string, []bool) {
+	corrections := make(map[string]string)
+	unverified := make([]bool, len(texts))
+	for i, t := range texts {
+		for _, f := range extractFigures(*t.text) {
+			if sf.verify(f, g.quality) {
+				report.Verified++
+				continue
+			}
+
+			result := models.FactCheckFigure{Section: t.section, Text: f.Text, Status: models.FactCheckUnverified}
+			if f.Kind == figurePoints && sf.change >= f.Unit {
+				corrected := formatPoints(sf.change, f.Digits) + strings.TrimPrefix(f.Text, f.Digits)
+				corrections[f.Text] = corrected
+				result.Status = models.FactCheckCorrected
+				result.Correction = corrected
+				report.Corrected++
+			} else {
+				unverified[i] = true
+				report.Unverified++
+			}
+			report.Figures = append(report.Figures, result)
+		}
+	}
+	return corrections, unverified
+}
+
+// applyCorrections replaces the corrected figures in texts.
+func applyCorrections(texts []checkedText, corrections map[string]string) {
+	if len(corrections) == 0 {
+		return
+	}
+	for _, t := range texts {
+		*t.text = replaceFigures(*t.text, corrections)
+	}
+}
+
+// addToFactCheck returns the article's fact check report, started if the
+// article has none, for checking text added after it was generated.
+func addToFactCheck(article *models.Article) *models.FactCheck {
+	if article.FactCheck == nil {
+		article.FactCheck = &models.FactCheck{}
+	}
+	article.FactCheck.CheckedAt = time.Now()
+	return article.FactCheck
+}
+
+// signalSources is the context a narrative was written from.
+func signalSources(signal llm.SignalData) []string {
+	return []string{
+		signal.ExternalContext,
+		signal.SocialSignalsContext,
+		signal.RelatedMarketsContext,
+		signal.LiquidityContext,
+		signal.TradeFlowContext,
+		signal.HoldersContext,
+		signal.HistoryContext,
+		signal.TierContext,
+	}
+}
+
+// formatPoints writes a point change with as many decimals as the figure
+// it replaces.
+func formatPoints(value float64, digits string) string {
+	decimals := 0
+	if _, frac, ok := strings.Cut(digits, "."); ok {
+		decimals = len(frac)
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+// matchesAny reports whether value is within abs, or rel of the candidate,
+// of any candidate.
+func matchesAny(value float64, candidates []float64, abs, rel float64) bool {
+	for _, c := range candidates {
+		if math.Abs(value-c) <= max(abs, rel*c) {
+			return true
+		}
+	}
+	return false
+}
+
+// roundingUnit is the place value of the last digit of a written number:
+// 1 for "62", 0.1 for "1.2".
+func roundingUnit(digits string) float64 {
+	if _, frac, ok := strings.Cut(digits, "."); ok {
+		return math.Pow(10, -float64(len(frac)))
+	}
+	return 1
+}
+
+func dollarScale(suffix string) float64 {
+	switch strings.ToLower(suffix) {
+	case "k", "thousand":
+		return 1e3
+	case "m", "million":
+		return 1e6
+	case "b", "billion":
+		return 1e9
+	}
+	return 1
+}
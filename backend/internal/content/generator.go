@@ -7,31 +7,82 @@ import (
 	"strings"
 	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/cache"
 	"github.com/leeaandrob/futuresignals/internal/enrichment"
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
 	"github.com/leeaandrob/futuresignals/internal/models"
-	"github.com/leeaandrob/futuresignals/internal/qwen"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
 	"github.com/leeaandrob/futuresignals/internal/storage"
 	"github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
 	"github.com/leeaandrob/futuresignals/internal/xtracker"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Store is the storage the generator reads markets and writes articles
+// with. *storage.Store implements it, as does storage.MemoryStore.
+type Store interface {
+	storage.MarketStore
+	storage.ArticleStore
+	storage.SnapshotStore
+	storage.BriefingStore
+	storage.SocialSignalStore
+}
+
 // Generator creates articles from market data.
 type Generator struct {
-	store      *storage.Store
+	store      Store
 	syncer     *sync.Syncer
-	llm        *qwen.Client
+	llm        llm.Provider
 	enricher   *enrichment.Enricher
 	correlator *xtracker.Correlator
+	dedup      DedupConfig
+	publishers []Publisher
+	images     ImageRenderer
+	quality    QualityConfig
+	cache      cache.Cache
+
+	// Windows of odds history narratives compare a move with
+	lookbacks []time.Duration
+
+	// Per-market generation locks: in-process, and in the store across
+	// instances when locks is set
+	markets marketLocks
+	locks   LockStore
+	lockCfg GenerationLockConfig
+}
+
+// Publisher distributes published articles to an external channel.
+// PublishArticle is called synchronously after an article is saved, so
+// implementations should queue the work rather than block.
+type Publisher interface {
+	PublishArticle(ctx context.Context, article *models.Article)
+}
+
+// Updater is a Publisher that also distributes changes to published
+// articles, such as amendments.
+type Updater interface {
+	UpdateArticle(ctx context.Context, article *models.Article)
+}
+
+// ImageRenderer renders an article's social share image and returns its
+// URL.
+type ImageRenderer interface {
+	RenderArticle(ctx context.Context, article *models.Article) (string, error)
 }
 
 // NewGenerator creates a new content generator.
-func NewGenerator(store *storage.Store, syncer *sync.Syncer, llm *qwen.Client, enricher *enrichment.Enricher) *Generator {
+func NewGenerator(store Store, syncer *sync.Syncer, provider llm.Provider, enricher *enrichment.Enricher) *Generator {
 	return &Generator{
-		store:    store,
-		syncer:   syncer,
-		llm:      llm,
-		enricher: enricher,
+		store:     store,
+		syncer:    syncer,
+		llm:       provider,
+		enricher:  enricher,
+		dedup:     DefaultDedupConfig(),
+		quality:   DefaultQualityConfig(),
+		lookbacks: defaultHistoryLookbacks,
 	}
 }
 
@@ -40,6 +91,103 @@ func (g *Generator) SetCorrelator(correlator *xtracker.Correlator) {
 	g.correlator = correlator
 }
 
+// AddPublisher registers a publisher for newly published articles.
+func (g *Generator) AddPublisher(p Publisher) {
+	g.publishers = append(g.publishers, p)
+}
+
+// SetCache invalidates the API's cached reads that list or render articles
+// whenever an article is saved or amended.
+func (g *Generator) SetCache(c cache.Cache) {
+	g.cache = c
+}
+
+// articlesChanged drops the cached reads that list or render articles.
+func (g *Generator) articlesChanged(ctx context.Context) {
+	cache.Invalidate(ctx, g.cache, cache.GroupFeed, cache.GroupCategories, cache.GroupArticles)
+}
+
+// articleUpdated hands an amended article to the publishers that
+// distribute changes.
+func (g *Generator) articleUpdated(ctx context.Context, article *models.Article) {
+	if !article.Published {
+		return
+	}
+	for _, p := range g.publishers {
+		if u, ok := p.(Updater); ok {
+			u.UpdateArticle(ctx, article)
+		}
+	}
+}
+
+// SetImageRenderer enables share images for new articles.
+func (g *Generator) SetImageRenderer(r ImageRenderer) {
+	g.images = r
+}
+
+// saveArticle fact-checks a newly generated article against its market data
+// and sources (the context it was written from), then persists it, with its
+// short-form variants and its share image when a renderer is set, and hands
+// it to the registered publishers. Articles failing the quality gate are
+// saved as drafts instead, without short-form variants, so no LLM call is
+// spent on them. The gate checks LLM output, so articles built
+// from the fixed templates used without an LLM skip it rather than all
+// being held.
+func (g *Generator) saveArticle(ctx context.Context, article *models.Article, sources ...string) error {
+	article.Tags = models.NormalizeTags(article.Tags)
+
+	g.factCheck(article, sources)
+	if article.FactCheck.Corrected > 0 || article.FactCheck.Unverified > 0 {
+		log.Info().
+			Str("slug", article.Slug).
+			Int("corrected", article.FactCheck.Corrected).
+			Int("unverified", article.FactCheck.Unverified).
+			Msg("Fact check found mismatched figures")
+	}
+
+	if article.Published && g.quality.Enabled && g.llm != nil {
+		if issues := g.checkQuality(article); len(issues) > 0 {
+			article.Published = false
+			article.QualityIssues = make([]string, len(issues))
+			for i, issue := range issues {
+				article.QualityIssues[i] = issue.String()
+				metrics.ArticlesHeld.WithLabelValues(string(article.Type), issue.Check).Inc()
+			}
+			log.Warn().
+				Str("slug", article.Slug).
+				Str("type", string(article.Type)).
+				Strs("issues", article.QualityIssues).
+				Msg("Article failed quality checks, saved as draft")
+		}
+	}
+
+	// Held drafts aren't posted, so they get no variants
+	if article.Published {
+		g.addShortForm(ctx, article, sources...)
+	}
+
+	if g.images != nil {
+		if url, err := g.images.RenderArticle(ctx, article); err != nil {
+			log.Warn().Err(err).Str("slug", article.Slug).Msg("Failed to render share image")
+		} else {
+			article.OGImageURL = url
+		}
+	}
+
+	if err := g.store.SaveArticle(ctx, article); err != nil {
+		return err
+	}
+	metrics.ArticlesGenerated.WithLabelValues(string(article.Type)).Inc()
+	g.articlesChanged(ctx)
+
+	if article.Published {
+		for _, p := range g.publishers {
+			p.PublishArticle(ctx, article)
+		}
+	}
+	return nil
+}
+
 // enrichWithSocialSignals adds social signals from XTracker to an article.
 func (g *Generator) enrichWithSocialSignals(ctx context.Context, article *models.Article) {
 	if g.correlator == nil {
@@ -53,16 +201,42 @@ func (g *Generator) enrichWithSocialSignals(ctx context.Context, article *models
 
 // GenerateBreaking generates a breaking news article from a market event.
 func (g *Generator) GenerateBreaking(ctx context.Context, event sync.Event) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeBreaking))
+	ctx, span := tracing.Start(ctx, "content.GenerateBreaking", attribute.String("article.type", string(models.ArticleTypeBreaking)))
+	defer span.End()
+
 	log.Info().
 		Str("market", event.Market.Question).
 		Str("type", string(event.Type)).
 		Msg("Generating breaking article")
 
+	// A move in a thinly traded market is usually noise, not news
+	if event.Market.ThinlyTraded() {
+		log.Info().
+			Str("market", event.Market.Question).
+			Float64("liquidity", event.Market.Liquidity).
+			Float64("volume_24h", event.Market.Volume24h).
+			Msg("Skipped breaking article for thinly traded market")
+		return nil, ErrIlliquidMarket
+	}
+
+	// One generation per market at a time
+	release, err := g.lockMarket(ctx, event.Market.MarketID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Amend or skip instead of repeating a story we just ran
+	if original := g.findRecentBreaking(ctx, event.Market.MarketID); original != nil {
+		return g.handleDuplicate(ctx, original, event.Market)
+	}
+
 	// Enrich context
 	enrichedCtx := ""
 	var sources []string
 	if g.enricher != nil {
-		ctx, err := g.enricher.Enrich(ctx, event.Market.Question, event.Market.Category)
+		ctx, err := g.enricher.Enrich(ctx, event.Market, models.ArticleTypeBreaking)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to enrich context")
 		} else if ctx != nil {
@@ -72,7 +246,8 @@ func (g *Generator) GenerateBreaking(ctx context.Context, event sync.Event) (*mo
 	}
 
 	// Generate narrative with LLM
-	narrative, err := g.generateNarrative(ctx, event.Market, enrichedCtx, "breaking")
+	signal := g.narrativeSignal(ctx, event.Market, enrichedCtx, models.ArticleTypeBreaking)
+	narrative, err := g.generateNarrative(ctx, signal)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate narrative: %w", err)
 	}
@@ -90,6 +265,7 @@ func (g *Generator) GenerateBreaking(ctx context.Context, event sync.Event) (*mo
 			WhyItMatters: narrative.WhyItMatters,
 			Context:      []string{narrative.MarketContext},
 			WhatToWatch:  narrative.WhatToWatch,
+			MarketData:   g.marketData(ctx, event.Market, marketDataWindow),
 		},
 		Markets: []models.MarketRef{{
 			MarketID:     event.Market.MarketID,
@@ -117,11 +293,19 @@ func (g *Generator) GenerateBreaking(ctx context.Context, event sync.Event) (*mo
 		EnrichmentSources: sources,
 	}
 
+	// Test the alternative headline against the original
+	if alt := strings.TrimSpace(narrative.AltHeadline); alt != "" && alt != narrative.Headline {
+		article.HeadlineTest = &models.HeadlineTest{
+			Variants:  []models.HeadlineVariant{{Headline: narrative.Headline}, {Headline: alt}},
+			StartedAt: time.Now(),
+		}
+	}
+
 	// Enrich with social signals from XTracker
 	g.enrichWithSocialSignals(ctx, article)
 
 	// Save to database
-	if err := g.store.SaveArticle(ctx, article); err != nil {
+	if err := g.saveArticle(ctx, article, signalSources(signal)...); err != nil {
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
 
@@ -136,7 +320,14 @@ func (g *Generator) GenerateBreaking(ctx context.Context, event sync.Event) (*mo
 
 // GenerateBriefing generates a scheduled briefing article.
 func (g *Generator) GenerateBriefing(ctx context.Context, briefingType models.BriefingType) (*models.Article, error) {
-	config := models.DefaultBriefingConfigs[briefingType]
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeBriefing))
+	ctx, span := tracing.Start(ctx, "content.GenerateBriefing", attribute.String("article.type", string(models.ArticleTypeBriefing)))
+	defer span.End()
+
+	config, err := g.briefingConfig(ctx, briefingType)
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info().
 		Str("type", string(briefingType)).
@@ -205,7 +396,7 @@ func (g *Generator) GenerateBriefing(ctx context.Context, briefingType models.Br
 	// Enrich with social signals from XTracker
 	g.enrichWithSocialSignals(ctx, article)
 
-	if err := g.store.SaveArticle(ctx, article); err != nil {
+	if err := g.saveArticle(ctx, article, briefingPromptData(briefingType, allMarkets).sources()...); err != nil {
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
 
@@ -218,8 +409,24 @@ func (g *Generator) GenerateBriefing(ctx context.Context, briefingType models.Br
 	return article, nil
 }
 
+// briefingConfig returns the stored config for a briefing type.
+func (g *Generator) briefingConfig(ctx context.Context, briefingType models.BriefingType) (*models.BriefingConfig, error) {
+	config, err := g.store.GetBriefingConfig(ctx, briefingType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load briefing config: %w", err)
+	}
+	if config == nil {
+		return nil, fmt.Errorf("unknown briefing type %q", briefingType)
+	}
+	return config, nil
+}
+
 // GenerateTrending generates an article about trending markets.
 func (g *Generator) GenerateTrending(ctx context.Context, limit int) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeTrending))
+	ctx, span := tracing.Start(ctx, "content.GenerateTrending", attribute.String("article.type", string(models.ArticleTypeTrending)))
+	defer span.End()
+
 	log.Info().Int("limit", limit).Msg("Generating trending article")
 
 	// Get trending markets
@@ -280,7 +487,7 @@ func (g *Generator) GenerateTrending(ctx context.Context, limit int) (*models.Ar
 	// Enrich with social signals from XTracker
 	g.enrichWithSocialSignals(ctx, article)
 
-	if err := g.store.SaveArticle(ctx, article); err != nil {
+	if err := g.saveArticle(ctx, article, trendingPromptData(marketRefs).sources()...); err != nil {
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
 
@@ -295,15 +502,26 @@ func (g *Generator) GenerateTrending(ctx context.Context, limit int) (*models.Ar
 
 // GenerateNewMarket generates an article about a new market.
 func (g *Generator) GenerateNewMarket(ctx context.Context, market *models.Market) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeNewMarket))
+	ctx, span := tracing.Start(ctx, "content.GenerateNewMarket", attribute.String("article.type", string(models.ArticleTypeNewMarket)))
+	defer span.End()
+
 	log.Info().
 		Str("market", market.Question).
 		Msg("Generating new market article")
 
+	// One generation per market at a time
+	release, err := g.lockMarket(ctx, market.MarketID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Enrich context
 	enrichedCtx := ""
 	var sources []string
 	if g.enricher != nil {
-		ctx, err := g.enricher.Enrich(ctx, market.Question, market.Category)
+		ctx, err := g.enricher.Enrich(ctx, market, models.ArticleTypeNewMarket)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to enrich context")
 		} else if ctx != nil {
@@ -332,6 +550,7 @@ func (g *Generator) GenerateNewMarket(ctx context.Context, market *models.Market
 			WhyItMatters: content.WhyItMatters,
 			Context:      content.Context,
 			WhatToWatch:  content.WhatToWatch,
+			MarketData:   g.marketData(ctx, market, marketDataWindow),
 		},
 		Markets: []models.MarketRef{{
 			MarketID:    market.MarketID,
@@ -358,7 +577,7 @@ func (g *Generator) GenerateNewMarket(ctx context.Context, market *models.Market
 	// Enrich with social signals from XTracker
 	g.enrichWithSocialSignals(ctx, article)
 
-	if err := g.store.SaveArticle(ctx, article); err != nil {
+	if err := g.saveArticle(ctx, article, enrichedCtx); err != nil {
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
 
@@ -372,6 +591,10 @@ func (g *Generator) GenerateNewMarket(ctx context.Context, market *models.Market
 
 // GenerateCategoryDigest generates a digest for a specific category.
 func (g *Generator) GenerateCategoryDigest(ctx context.Context, category string, limit int) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeDigest))
+	ctx, span := tracing.Start(ctx, "content.GenerateCategoryDigest", attribute.String("article.type", string(models.ArticleTypeDigest)))
+	defer span.End()
+
 	log.Info().
 		Str("category", category).
 		Msg("Generating category digest")
@@ -439,7 +662,7 @@ func (g *Generator) GenerateCategoryDigest(ctx context.Context, category string,
 	// Enrich with social signals from XTracker
 	g.enrichWithSocialSignals(ctx, article)
 
-	if err := g.store.SaveArticle(ctx, article); err != nil {
+	if err := g.saveArticle(ctx, article, digestPromptData(catName, marketRefs).sources()...); err != nil {
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
 
@@ -452,6 +675,97 @@ func (g *Generator) GenerateCategoryDigest(ctx context.Context, category string,
 	return article, nil
 }
 
+// GenerateFollowUp generates a "Market Update" article on new market data for
+// an earlier article's primary market, linked back to the original. It
+// doesn't take the market's generation lock, as GenerateBreaking calls it
+// holding that lock.
+func (g *Generator) GenerateFollowUp(ctx context.Context, original *models.Article, market *models.Market) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeUpdate))
+	ctx, span := tracing.Start(ctx, "content.GenerateFollowUp", attribute.String("article.type", string(models.ArticleTypeUpdate)))
+	defer span.End()
+
+	log.Info().
+		Str("original", original.Slug).
+		Str("market", market.Question).
+		Msg("Generating follow-up article")
+
+	// Describe the move since the original article, not the full 24h change
+	lastProb := market.PreviousProb
+	if original.PrimaryMarket != nil {
+		lastProb = original.PrimaryMarket.Probability
+	}
+	moved := *market
+	moved.PreviousProb = lastProb
+
+	signal := g.narrativeSignal(ctx, &moved, "", models.ArticleTypeUpdate)
+	narrative, err := g.generateNarrative(ctx, signal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate narrative: %w", err)
+	}
+
+	headline := "Market Update: " + narrative.Headline
+	originalID := original.ID
+
+	article := &models.Article{
+		Slug:        g.generateSlug(headline),
+		Type:        models.ArticleTypeUpdate,
+		Category:    market.Category,
+		Headline:    headline,
+		Subheadline: narrative.Subheadline,
+		Summary:     narrative.Subheadline,
+		Body: models.ArticleBody{
+			WhatHappened: narrative.WhatChanged,
+			WhyItMatters: narrative.WhyItMatters,
+			Context:      []string{narrative.MarketContext},
+			WhatToWatch:  narrative.WhatToWatch,
+			MarketData:   g.marketData(ctx, market, marketDataWindow),
+		},
+		Markets: []models.MarketRef{{
+			MarketID:     market.MarketID,
+			Question:     market.Question,
+			Slug:         market.Slug,
+			Probability:  market.Probability,
+			PreviousProb: lastProb,
+			Change24h:    market.Change24h,
+			Volume24h:    market.Volume24h,
+			TotalVolume:  market.TotalVolume,
+		}},
+		PrimaryMarket: &models.MarketRef{
+			MarketID:    market.MarketID,
+			Question:    market.Question,
+			Probability: market.Probability,
+			Change24h:   market.Change24h,
+			Volume24h:   market.Volume24h,
+		},
+		Tags:              append([]string{"update"}, narrative.Tags...),
+		Significance:      models.Significance(narrative.Significance),
+		Sentiment:         narrative.Sentiment,
+		MetaTitle:         headline,
+		MetaDescription:   narrative.Subheadline,
+		Published:         true,
+		EnrichmentSources: original.EnrichmentSources,
+		PreviousArticleID: &originalID,
+	}
+
+	// Enrich with social signals from XTracker
+	g.enrichWithSocialSignals(ctx, article)
+
+	if err := g.saveArticle(ctx, article, signalSources(signal)...); err != nil {
+		return nil, fmt.Errorf("failed to save article: %w", err)
+	}
+
+	if err := g.store.AddArticleUpdate(ctx, original.ID, article.ID); err != nil {
+		log.Warn().Err(err).Str("original", original.Slug).Msg("Failed to link follow-up to original")
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Str("original", original.Slug).
+		Msg("Follow-up article generated")
+
+	return article, nil
+}
+
 // Helper methods
 
 func (g *Generator) generateSlug(headline string) string {
@@ -473,11 +787,15 @@ func (g *Generator) generateSlug(headline string) string {
 	return slug + "-" + time.Now().Format("20060102-1504")
 }
 
-func (g *Generator) generateNarrative(ctx context.Context, market *models.Market, enrichedCtx, contentType string) (*qwen.Narrative, error) {
+func (g *Generator) generateNarrative(ctx context.Context, signal llm.SignalData) (*llm.Narrative, error) {
 	if g.llm == nil {
 		return nil, fmt.Errorf("LLM client not configured")
 	}
+	return g.llm.GenerateNarrative(ctx, signal)
+}
 
+// narrativeSignal gathers the narrative prompt's data for a market.
+func (g *Generator) narrativeSignal(ctx context.Context, market *models.Market, enrichedCtx string, articleType models.ArticleType) llm.SignalData {
 	// Get social signals context if correlator is available
 	socialSignalsCtx := ""
 	if g.correlator != nil {
@@ -487,18 +805,111 @@ func (g *Generator) generateNarrative(ctx context.Context, market *models.Market
 		}
 	}
 
-	return g.llm.GenerateNarrative(ctx, qwen.SignalData{
-		MarketTitle:          market.Question,
-		EventTitle:           market.GroupItemTitle,
-		Category:             market.Category,
-		PreviousProb:         market.PreviousProb,
-		CurrentProb:          market.Probability,
-		TimeFrame:            "24h",
-		Volume24h:            market.Volume24h,
-		TotalVolume:          market.TotalVolume,
-		ExternalContext:      enrichedCtx,
-		SocialSignalsContext: socialSignalsCtx,
-	})
+	relatedMarketsCtx := ""
+	if related, err := g.store.GetRelatedMarkets(ctx, market.MarketID, 3); err == nil && len(related) > 0 {
+		relatedMarketsCtx = formatRelatedMarketsForLLM(related)
+	}
+
+	return llm.SignalData{
+		ArticleType:           string(articleType),
+		MarketTitle:           market.Question,
+		EventTitle:            market.GroupItemTitle,
+		Category:              market.Category,
+		PreviousProb:          market.PreviousProb,
+		CurrentProb:           market.Probability,
+		TimeFrame:             "24h",
+		Volume24h:             market.Volume24h,
+		TotalVolume:           market.TotalVolume,
+		ExternalContext:       enrichedCtx,
+		SocialSignalsContext:  socialSignalsCtx,
+		RelatedMarketsContext: relatedMarketsCtx,
+		LiquidityContext:      formatOrderBookForLLM(market.OrderBook),
+		TradeFlowContext:      formatTradeFlowForLLM(market.TradeFlow),
+		HoldersContext:        formatHoldersForLLM(market.Holders),
+		HistoryContext:        g.oddsHistoryContext(ctx, market),
+		TierContext:           formatTiersForLLM(market),
+	}
+}
+
+// formatHoldersForLLM describes a market's holder concentration for LLM
+// context.
+func formatHoldersForLLM(holders *models.Holders) string {
+	if holders == nil || len(holders.Outcomes) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(holders.Outcomes)+1)
+	for _, oh := range holders.Outcomes {
+		parts = append(parts, fmt.Sprintf("%s: top %d of %d holders own %.0f%% of shares (largest position %.0f shares)",
+			oh.Outcome, min(models.HolderTopN, oh.Holders), oh.Holders, oh.TopShare*100, oh.LargestHolder))
+	}
+	count := fmt.Sprintf("%d unique holders", holders.UniqueHolders)
+	if holders.Capped {
+		count = "at least " + count
+	}
+	parts = append(parts, count)
+	return strings.Join(parts, "; ")
+}
+
+// formatTradeFlowForLLM describes a market's recent trades for LLM context.
+func formatTradeFlowForLLM(flow *models.TradeFlow) string {
+	if flow == nil || flow.Day.Trades == 0 {
+		return ""
+	}
+
+	describe := func(window string, st models.TradeStats) string {
+		if st.Volume() <= 0 {
+			return fmt.Sprintf("%s: %d trades, no volume", window, st.Trades)
+		}
+		text := fmt.Sprintf("%s: %d trades, avg $%.0f, Yes buyers %.0f%% of $%.0f volume",
+			window, st.Trades, st.AvgTradeSize, st.BuyShare()*100, st.Volume())
+		if st.WhaleTrades > 0 {
+			text += fmt.Sprintf(", %d whale trades (largest $%.0f)", st.WhaleTrades, st.LargestTrade)
+		}
+		return text
+	}
+
+	text := describe("last 24h", flow.Day)
+	if flow.Hour.Trades > 0 {
+		text += "; " + describe("last hour", flow.Hour)
+	}
+	return text
+}
+
+// formatOrderBookForLLM describes a market's order book for LLM context.
+func formatOrderBookForLLM(book *models.OrderBook) string {
+	if book == nil {
+		return ""
+	}
+	if !book.Quoted() {
+		return "no quote (one side of the order book is empty)"
+	}
+	return fmt.Sprintf("%s (bid %.0f¢ / ask %.0f¢; $%.0f bids and $%.0f asks within %.0f¢ of the midpoint)",
+		book.Quality(),
+		book.BestBid*100,
+		book.BestAsk*100,
+		book.BidDepth,
+		book.AskDepth,
+		models.OrderBookDepthRange*100)
+}
+
+// formatRelatedMarketsForLLM formats correlated markets for LLM context.
+func formatRelatedMarketsForLLM(related []models.RelatedMarket) string {
+	var sb strings.Builder
+	for _, rm := range related {
+		direction := "moves with"
+		if rm.Correlation < 0 {
+			direction = "moves against"
+		}
+		sb.WriteString(fmt.Sprintf("• \"%s\" at %.1f%% (%+.1f points 24h), %s this market (r=%.2f over %d hours)\n",
+			rm.Market.Question,
+			rm.Market.Probability*100,
+			rm.Market.Change24h*100,
+			direction,
+			rm.Correlation,
+			rm.Samples))
+	}
+	return sb.String()
 }
 
 // formatSocialSignalsForLLM formats social signals for LLM context.
@@ -546,14 +957,14 @@ type TrendingContent struct {
 }
 
 type NewMarketContent struct {
-	Headline    string
-	Summary     string
-	Overview    string
+	Headline     string
+	Summary      string
+	Overview     string
 	WhyItMatters string
-	Context     []string
-	WhatToWatch string
-	Tags        []string
-	Sentiment   string
+	Context      []string
+	WhatToWatch  string
+	Tags         []string
+	Sentiment    string
 }
 
 type CategoryDigestContent struct {
@@ -578,57 +989,7 @@ func (g *Generator) generateBriefingContent(ctx context.Context, briefingType mo
 		}, nil
 	}
 
-	// Build market summary with Bloomberg-style data integration
-	var marketSummary strings.Builder
-	totalVolume := 0.0
-	biggestMover := ""
-	biggestMove := 0.0
-
-	for i, m := range markets {
-		if i >= 10 {
-			break
-		}
-		totalVolume += m.Volume24h
-		if abs(m.Change24h) > abs(biggestMove) {
-			biggestMove = m.Change24h
-			biggestMover = m.Question
-		}
-		marketSummary.WriteString(fmt.Sprintf("• %s: %.0f%% (%+.1fpts, $%.0fK vol)\n",
-			m.Question, m.Probability*100, m.Change24h*100, m.Volume24h/1000))
-	}
-
-	systemPrompt := `You are a senior financial journalist writing a market briefing in Bloomberg wire service style.
-
-STYLE GUIDE:
-- Lead with the most significant development
-- Integrate specific numbers into prose (not bullet points in the output)
-- Short, punchy sentences
-- Answer "so what?" for sophisticated readers
-- Forward-looking closing
-
-Respond ONLY with valid JSON.`
-
-	prompt := fmt.Sprintf(`Write a %s MARKET BRIEFING in Bloomberg style.
-
-═══════════════════════════════════════════════════════════════
-MARKET DATA
-═══════════════════════════════════════════════════════════════
-Total 24h Volume: $%.1fM
-Biggest Mover: %s (%+.1f points)
-
-MARKETS:
-%s
-
-═══════════════════════════════════════════════════════════════
-OUTPUT
-═══════════════════════════════════════════════════════════════
-{
-  "summary": "Bloomberg-style 2-sentence executive summary. Lead with the biggest story. Include specific numbers.",
-  "overview": "3-4 sentences covering main market themes. Weave in specific data. Explain what's driving activity.",
-  "key_insights": "2-3 sentences of analysis. What patterns emerge? What do the odds imply? Connect to real-world events.",
-  "highlights": ["Specific highlight with data", "Another concrete observation", "Forward-looking point"],
-  "what_to_watch": "2 sentences on upcoming catalysts. Be specific about dates/events that could move markets."
-}`, briefingType, totalVolume/1_000_000, biggestMover, biggestMove*100, marketSummary.String())
+	data := briefingPromptData(briefingType, markets)
 
 	var result struct {
 		Summary     string   `json:"summary"`
@@ -638,12 +999,7 @@ OUTPUT
 		WhatToWatch string   `json:"what_to_watch"`
 	}
 
-	err := g.llm.ChatJSON(ctx, qwen.ChatRequest{
-		SystemPrompt: systemPrompt,
-		UserPrompt:   prompt,
-		Temperature:  0.4,
-		MaxTokens:    1000,
-	}, &result)
+	err := g.chatPrompt(ctx, prompts.Briefing, models.ArticleTypeBriefing, data, 1000, &result)
 
 	if err != nil {
 		return nil, err
@@ -678,67 +1034,8 @@ func (g *Generator) generateTrendingContent(ctx context.Context, markets []model
 		}, nil
 	}
 
-	// Calculate aggregate stats
-	var marketSummary strings.Builder
-	totalVolume := 0.0
-	topMarket := ""
-	topVolume := 0.0
-
-	for i, m := range markets {
-		if i >= 10 {
-			break
-		}
-		totalVolume += m.Volume24h
-		if m.Volume24h > topVolume {
-			topVolume = m.Volume24h
-			topMarket = m.Question
-		}
-		marketSummary.WriteString(fmt.Sprintf("• %s: %.0f%% ($%.0fK 24h vol, %+.1fpts)\n",
-			m.Question, m.Probability*100, m.Volume24h/1000, m.Change24h*100))
-	}
-
-	systemPrompt := `You are a senior financial journalist at a wire service covering prediction markets.
-
-STYLE: Bloomberg/Reuters wire service
-- Active voice headlines with specific numbers
-- Lead with the most newsworthy angle
-- Integrate data into narrative prose
-- Answer "why is this trending?" and "so what?"
-- Short, punchy sentences
-
-Respond ONLY with valid JSON.`
-
-	prompt := fmt.Sprintf(`Write a TRENDING MARKETS story in Bloomberg wire style.
-
-═══════════════════════════════════════════════════════════════
-AGGREGATE DATA
-═══════════════════════════════════════════════════════════════
-Combined 24h Volume: $%.1fM
-Top Volume Market: %s ($%.0fK)
-
-TRENDING MARKETS:
-%s
-
-═══════════════════════════════════════════════════════════════
-OUTPUT
-═══════════════════════════════════════════════════════════════
-{
-  "headline": "Active-voice headline with key number. Max 80 chars. Example: 'Prediction Markets See $5M Flow Into Election Bets'",
-  "summary": "2-sentence wire-style summary. Lead with the biggest story, include specific volume/probability figures.",
-  "overview": "3-4 sentences explaining what's driving volume. Connect to real-world events. Why are traders active now?",
-  "analysis": "2-3 sentences of market analysis. What do the odds imply? What's the smart money saying?",
-  "highlights": ["Specific observation with data", "Pattern or trend identified", "Forward-looking point"],
-  "what_to_watch": "2 sentences on upcoming catalysts that could drive more activity.",
-  "tags": ["relevant", "seo", "tags"]
-}`, totalVolume/1_000_000, topMarket, topVolume/1000, marketSummary.String())
-
 	var result TrendingContent
-	err := g.llm.ChatJSON(ctx, qwen.ChatRequest{
-		SystemPrompt: systemPrompt,
-		UserPrompt:   prompt,
-		Temperature:  0.4,
-		MaxTokens:    800,
-	}, &result)
+	err := g.chatPrompt(ctx, prompts.Trending, models.ArticleTypeTrending, trendingPromptData(markets), 800, &result)
 
 	if err != nil {
 		return nil, err
@@ -761,65 +1058,8 @@ func (g *Generator) generateNewMarketContent(ctx context.Context, market *models
 		}, nil
 	}
 
-	// Determine implied odds interpretation
-	impliedOutcome := "uncertain"
-	if market.Probability > 0.7 {
-		impliedOutcome = "likely"
-	} else if market.Probability < 0.3 {
-		impliedOutcome = "unlikely"
-	}
-
-	systemPrompt := `You are a senior financial journalist covering new prediction market listings.
-
-STYLE: Bloomberg/Reuters wire service
-- Explain the market's significance in broader context
-- Connect to current events when possible
-- Integrate the probability data into narrative
-- Short, punchy sentences
-- Answer "why should readers care about this new market?"
-
-Respond ONLY with valid JSON.`
-
-	contextStr := enrichedCtx
-	if contextStr == "" {
-		contextStr = "No additional context available."
-	}
-
-	prompt := fmt.Sprintf(`Write a NEW MARKET LISTING story in Bloomberg wire style.
-
-═══════════════════════════════════════════════════════════════
-NEW MARKET
-═══════════════════════════════════════════════════════════════
-Question: %s
-Category: %s
-Opening Probability: %.0f%% (implied: %s)
-Initial Volume: $%.0fK
-End Date: %s
-
-External Context:
-%s
-
-═══════════════════════════════════════════════════════════════
-OUTPUT
-═══════════════════════════════════════════════════════════════
-{
-  "headline": "Active-voice headline announcing the market. Include the opening odds. Max 80 chars.",
-  "summary": "2-sentence wire-style summary. What is the market, what are opening odds, why now?",
-  "overview": "2-3 sentences explaining the market and its context. Connect to real-world events or decisions.",
-  "why_it_matters": "2-3 sentences on stakes. What happens if this resolves Yes/No? Economic/political implications?",
-  "context": ["Relevant background fact with data", "Another contextual point"],
-  "what_to_watch": "2 sentences on what could move this market. Key dates, events, catalysts.",
-  "tags": ["relevant", "seo", "tags"],
-  "sentiment": "bullish|bearish|neutral"
-}`, market.Question, market.Category, market.Probability*100, impliedOutcome, market.Volume24h/1000, market.EndDate, contextStr)
-
 	var result NewMarketContent
-	err := g.llm.ChatJSON(ctx, qwen.ChatRequest{
-		SystemPrompt: systemPrompt,
-		UserPrompt:   prompt,
-		Temperature:  0.4,
-		MaxTokens:    600,
-	}, &result)
+	err := g.chatPrompt(ctx, prompts.NewMarket, models.ArticleTypeNewMarket, newMarketPromptData(market, enrichedCtx), 600, &result)
 
 	if err != nil {
 		return nil, err
@@ -848,90 +1088,8 @@ func (g *Generator) generateCategoryDigestContent(ctx context.Context, category
 		}, nil
 	}
 
-	// Build market summary with aggregate stats
-	var marketSummary strings.Builder
-	totalVolume := 0.0
-	avgProb := 0.0
-	bullishCount := 0
-	bearishCount := 0
-
-	for i, m := range markets {
-		if i >= 10 {
-			break
-		}
-		totalVolume += m.Volume24h
-		avgProb += m.Probability
-		if m.Change24h > 0.02 {
-			bullishCount++
-		} else if m.Change24h < -0.02 {
-			bearishCount++
-		}
-		marketSummary.WriteString(fmt.Sprintf("• %s: %.0f%% (%+.1fpts, $%.0fK vol)\n",
-			m.Question, m.Probability*100, m.Change24h*100, m.Volume24h/1000))
-	}
-
-	marketCount := len(markets)
-	if marketCount > 10 {
-		marketCount = 10
-	}
-	if marketCount > 0 {
-		avgProb /= float64(marketCount)
-	}
-
-	// Determine overall sentiment
-	overallSentiment := "mixed"
-	if bullishCount > bearishCount*2 {
-		overallSentiment = "bullish"
-	} else if bearishCount > bullishCount*2 {
-		overallSentiment = "bearish"
-	}
-
-	systemPrompt := `You are a senior financial journalist writing a sector digest in Bloomberg wire service style.
-
-STYLE:
-- Lead with the most significant development in this category
-- Integrate specific numbers into prose
-- Connect market movements to real-world events
-- Explain what the odds imply for the category
-- Short, authoritative sentences
-
-Respond ONLY with valid JSON.`
-
-	prompt := fmt.Sprintf(`Write a %s CATEGORY DIGEST in Bloomberg wire style.
-
-═══════════════════════════════════════════════════════════════
-CATEGORY STATS
-═══════════════════════════════════════════════════════════════
-Category: %s
-Combined 24h Volume: $%.1fM
-Average Probability: %.0f%%
-Sentiment: %d bullish / %d bearish moves
-Overall Trend: %s
-
-MARKETS:
-%s
-
-═══════════════════════════════════════════════════════════════
-OUTPUT
-═══════════════════════════════════════════════════════════════
-{
-  "headline": "Active-voice headline capturing category story. Include key data. Max 80 chars.",
-  "summary": "2-sentence wire-style summary. Lead with the biggest story in this category.",
-  "overview": "3-4 sentences on category state. What themes are dominating? Connect to real events.",
-  "analysis": "2-3 sentences of analysis. What do the collective odds suggest? Any patterns?",
-  "highlights": ["Specific highlight with data", "Pattern or trend", "Forward-looking point"],
-  "what_to_watch": "2 sentences on upcoming catalysts for this category.",
-  "tags": ["relevant", "seo", "tags"],
-  "sentiment": "bullish|bearish|neutral"
-}`, catName, catName, totalVolume/1_000_000, avgProb*100, bullishCount, bearishCount, overallSentiment, marketSummary.String())
-
 	var result CategoryDigestContent
-	err := g.llm.ChatJSON(ctx, qwen.ChatRequest{
-		SystemPrompt: systemPrompt,
-		UserPrompt:   prompt,
-		Temperature:  0.4,
-		MaxTokens:    1000,
-	}, &result)
+	err := g.chatPrompt(ctx, prompts.CategoryDigest, models.ArticleTypeDigest, digestPromptData(catName, markets), 1000, &result)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,69 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultHistoryLookbacks are the windows of odds history a move is
+// compared with: a week, a month and a quarter.
+var defaultHistoryLookbacks = []time.Duration{7 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour}
+
+// SetHistoryLookbacks sets the windows of daily odds history breaking and
+// follow-up narratives compare a move with. None disables the comparison.
+func (g *Generator) SetHistoryLookbacks(lookbacks []time.Duration) {
+	g.lookbacks = lookbacks
+}
+
+// oddsHistoryContext describes how a market's current odds compare with
+// its history, or returns "" when there is none to compare with.
+func (g *Generator) oddsHistoryContext(ctx context.Context, market *models.Market) string {
+	if len(g.lookbacks) == 0 {
+		return ""
+	}
+	ranges, err := g.store.GetOddsRanges(ctx, market.MarketID, market.Probability, g.lookbacks)
+	if err != nil {
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to load odds history")
+		return ""
+	}
+	return formatOddsHistoryForLLM(ranges, market.Probability, market.Probability-market.PreviousProb)
+}
+
+// formatOddsHistoryForLLM describes current odds against their range over
+// each lookback, e.g. "past 30 days: 41%-62% (low Sep 2, high Sep 20),
+// above every daily close, highest since Jul 14". Whether the odds are
+// compared with past highs or lows follows the direction of the move.
+func formatOddsHistoryForLLM(ranges []models.OddsRange, current, change float64) string {
+	parts := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		text := fmt.Sprintf("past %d days: %.0f%%-%.0f%% (low %s, high %s)",
+			int(r.Lookback.Hours()/24), r.Min*100, r.Max*100, r.MinAt.Format("Jan 2"), r.MaxAt.Format("Jan 2"))
+
+		switch {
+		case r.Percentile == 1:
+			text += ", above every daily close"
+		case r.Percentile == 0:
+			text += ", below every daily close"
+		default:
+			text += fmt.Sprintf(", above %.0f%% of daily closes", r.Percentile*100)
+		}
+
+		switch {
+		case change > 0 && current > r.Max:
+			text += ", a new high for the period"
+		case change > 0 && r.LastAbove != nil:
+			text += ", highest since " + r.LastAbove.Format("Jan 2")
+		case change < 0 && current < r.Min:
+			text += ", a new low for the period"
+		case change < 0 && r.LastBelow != nil:
+			text += ", lowest since " + r.LastBelow.Format("Jan 2")
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "; ")
+}
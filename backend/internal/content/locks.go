@@ -0,0 +1,140 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrGenerationInProgress is returned when another instance is generating
+// an article on the market, or just has. It wraps ErrDuplicateArticle, so
+// callers treat it as a skipped duplicate.
+var ErrGenerationInProgress = fmt.Errorf("%w: generation in progress on another instance", ErrDuplicateArticle)
+
+// LockStore holds the leases that keep a market's generation to one
+// instance. *storage.Store implements it.
+type LockStore interface {
+	AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (*models.Lock, bool, error)
+	RenewLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+}
+
+// GenerationLockConfig configures the per-market locks shared by
+// instances.
+type GenerationLockConfig struct {
+	// Owner identifies this instance in lock documents
+	Owner string
+
+	// TTL bounds how long a market stays locked if its instance dies
+	// mid-generation.
+	TTL time.Duration
+
+	// Cooldown is how long the lock is kept after a generation, as a marker
+	// that turns other instances' events on the market away.
+	Cooldown time.Duration
+}
+
+// DefaultGenerationLockConfig returns default generation lock settings,
+// owned by this host and process.
+func DefaultGenerationLockConfig() GenerationLockConfig {
+	host, _ := os.Hostname()
+	return GenerationLockConfig{
+		Owner:    fmt.Sprintf("%s-%d", host, os.Getpid()),
+		TTL:      5 * time.Minute,
+		Cooldown: 5 * time.Minute,
+	}
+}
+
+// SetLocks makes article generation on a market take a lock in the store
+// first, so concurrent events on several instances yield one article.
+func (g *Generator) SetLocks(locks LockStore, cfg GenerationLockConfig) {
+	defaults := DefaultGenerationLockConfig()
+	if cfg.Owner == "" {
+		cfg.Owner = defaults.Owner
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaults.TTL
+	}
+	if cfg.Cooldown < 0 {
+		cfg.Cooldown = 0
+	}
+	g.locks = locks
+	g.lockCfg = cfg
+}
+
+// marketLocks tracks the markets with a generation in flight in this
+// instance. Each channel is closed when its generation finishes.
+type marketLocks struct {
+	mu       sync.Mutex
+	inflight map[string]chan struct{}
+}
+
+// lockMarket serializes generation on a market. Within this instance a
+// caller waits for the generation in flight, then goes through the usual
+// duplicate checks, which see the article it saved. Across instances it
+// returns ErrGenerationInProgress while another holds the market's lock or
+// its cooldown. A lock store failure fails open.
+func (g *Generator) lockMarket(ctx context.Context, marketID string) (release func(), err error) {
+	var done chan struct{}
+	for {
+		g.markets.mu.Lock()
+		current, busy := g.markets.inflight[marketID]
+		if !busy {
+			if g.markets.inflight == nil {
+				g.markets.inflight = make(map[string]chan struct{})
+			}
+			done = make(chan struct{})
+			g.markets.inflight[marketID] = done
+			g.markets.mu.Unlock()
+			break
+		}
+		g.markets.mu.Unlock()
+
+		select {
+		case <-current:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	unlock := func() {
+		g.markets.mu.Lock()
+		delete(g.markets.inflight, marketID)
+		g.markets.mu.Unlock()
+		close(done)
+	}
+	if g.locks == nil {
+		return unlock, nil
+	}
+
+	name := "generate:" + marketID
+	previous, acquired, err := g.locks.AcquireLock(ctx, name, g.lockCfg.Owner, g.lockCfg.TTL)
+	if err != nil {
+		log.Warn().Err(err).Str("market_id", marketID).Msg("Failed to take generation lock")
+		return unlock, nil
+	}
+	if !acquired {
+		unlock()
+		owner := ""
+		if previous != nil {
+			owner = previous.Owner
+		}
+		log.Debug().Str("market_id", marketID).Str("owner", owner).Msg("Market locked by another instance")
+		return nil, ErrGenerationInProgress
+	}
+
+	return func() {
+		// Keep the lock for the cooldown; the generation's context may be
+		// done already
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := g.locks.RenewLock(ctx, name, g.lockCfg.Owner, g.lockCfg.Cooldown); err != nil {
+			log.Warn().Err(err).Str("market_id", marketID).Msg("Failed to release generation lock")
+		}
+		unlock()
+	}, nil
+}
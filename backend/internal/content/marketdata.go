@@ -0,0 +1,101 @@
+package content
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// marketDataWindow is how much probability history a market data block
+// covers; served from hourly rollups.
+const marketDataWindow = 7 * 24 * time.Hour
+
+// marketData builds the market data block for an article on market, with
+// window's probability history. Snapshot errors leave the history empty.
+func (g *Generator) marketData(ctx context.Context, market *models.Market, window time.Duration) *models.MarketDataBlock {
+	block := &models.MarketDataBlock{
+		MarketID:    market.MarketID,
+		GeneratedAt: time.Now(),
+		Volume: models.VolumeBreakdown{
+			Volume1h:    market.Volume1h,
+			Volume24h:   market.Volume24h,
+			Volume7d:    market.Volume7d,
+			TotalVolume: market.TotalVolume,
+			Liquidity:   market.Liquidity,
+		},
+	}
+	if market.TradeFlow != nil {
+		block.Volume.BuyVolume24h = market.TradeFlow.Day.BuyVolume
+		block.Volume.SellVolume24h = market.TradeFlow.Day.SellVolume
+	}
+
+	snapshots, err := g.store.GetSnapshots(ctx, market.MarketID, window)
+	if err != nil {
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to get snapshots for market data")
+	}
+	block.History = make([]models.ProbabilityPoint, 0, len(snapshots)+1)
+	for _, s := range slices.Backward(snapshots) {
+		block.History = append(block.History, models.ProbabilityPoint{
+			Time:        s.CapturedAt,
+			Probability: s.Probability,
+			Volume24h:   s.Volume24h,
+		})
+	}
+	// End on the figures the article was written from
+	block.History = append(block.History, models.ProbabilityPoint{
+		Time:        block.GeneratedAt,
+		Probability: market.Probability,
+		Volume24h:   market.Volume24h,
+	})
+
+	block.KeyDates = keyDates(market, block.History)
+	return block
+}
+
+// keyDates lists the dates in the market's life that are known, in
+// chronological order.
+func keyDates(market *models.Market, history []models.ProbabilityPoint) []models.KeyDate {
+	var dates []models.KeyDate
+	add := func(label string, t time.Time) {
+		if !t.IsZero() {
+			dates = append(dates, models.KeyDate{Label: label, Date: t})
+		}
+	}
+
+	add(models.KeyDateOpened, parseMarketDate(market.StartDate))
+	add(models.KeyDateTracked, market.FirstSeenAt)
+	if len(history) > 1 {
+		peak, trough := history[0], history[0]
+		for _, p := range history {
+			if p.Probability > peak.Probability {
+				peak = p
+			}
+			if p.Probability < trough.Probability {
+				trough = p
+			}
+		}
+		if peak.Probability > trough.Probability {
+			add(models.KeyDatePeak, peak.Time)
+			add(models.KeyDateTrough, trough.Time)
+		}
+	}
+	add(models.KeyDateCloses, parseMarketDate(market.EndDate))
+	if market.ResolvedAt != nil {
+		add(models.KeyDateResolved, *market.ResolvedAt)
+	}
+
+	slices.SortStableFunc(dates, func(a, b models.KeyDate) int { return a.Date.Compare(b.Date) })
+	return dates
+}
+
+// parseMarketDate parses a Polymarket start or end date, returning the zero
+// time when it is empty or malformed.
+func parseMarketDate(value string) time.Time {
+	if t := models.ParseMarketDate(value); t != nil {
+		return *t
+	}
+	return time.Time{}
+}
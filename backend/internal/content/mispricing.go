@@ -0,0 +1,202 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Mispricing watch thresholds.
+const (
+	// mispricingMinVolume is the 24h volume in USD an event needs to be
+	// checked, so stale prices in idle events aren't reported
+	mispricingMinVolume = 25_000
+
+	// mispricingEvents is how many of the most traded exclusive events are
+	// checked
+	mispricingEvents = 50
+
+	// mispricingTolerance is how far, in probability, an event's odds may
+	// sum from 100% before it is reported; smaller gaps are spread
+	mispricingTolerance = 0.05
+)
+
+// mispricingDisclaimer closes every mispricing watch, after what to watch.
+const mispricingDisclaimer = "This is analysis of market prices, not financial advice. " +
+	"Gaps between quoted odds can reflect fees, bid-ask spreads, thin order books or stale prices, " +
+	"and may close before they can be traded."
+
+// MispricingContent is the LLM output for a mispricing watch article.
+type MispricingContent struct {
+	Headline    string   `json:"headline"`
+	Summary     string   `json:"summary"`
+	Overview    string   `json:"overview"`
+	Analysis    string   `json:"analysis"`
+	WhatToWatch string   `json:"what_to_watch"`
+	Tags        []string `json:"tags"`
+}
+
+// GenerateMispricingWatch generates an "Odds That Don't Add Up" analysis
+// of the up to limit events with mutually exclusive outcomes whose odds,
+// read from storage, sum furthest from 100%. Each event is listed with its
+// total and outcomes, and the article closes with a disclaimer that it is
+// analysis, not advice. It is generated at most once a week.
+func (g *Generator) GenerateMispricingWatch(ctx context.Context, limit int) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeDigest))
+	ctx, span := tracing.Start(ctx, "content.GenerateMispricingWatch", attribute.String("article.type", string(models.ArticleTypeDigest)))
+	defer span.End()
+
+	week := weekStart(time.Now())
+	slug := fmt.Sprintf("odds-that-dont-add-up-%s", week.Format("2006-01-02"))
+	if existing, err := g.store.GetArticleBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	events, err := g.findMispricings(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no events with inconsistent odds")
+	}
+
+	log.Info().
+		Int("events", len(events)).
+		Float64("largest_gap", events[0].Gap()).
+		Msg("Generating mispricing watch article")
+
+	data := mispricingPrompt{WeekOf: week.Format("January 2"), Events: events}
+	content, err := g.generateMispricingContent(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	var rows []string
+	var refs []models.MarketRef
+	for _, e := range events {
+		rows = append(rows, mispricingRow(e))
+		refs = append(refs, e.Markets...)
+	}
+
+	article, err := g.saveRoundup(ctx, roundup{
+		Slug:     slug,
+		Type:     models.ArticleTypeDigest,
+		Title:    "Odds That Don't Add Up",
+		Dated:    "Week of " + week.Format("January 2, 2006"),
+		Headline: content.Headline,
+		Summary:  content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.Overview,
+			WhyItMatters: content.Analysis,
+			Context:      rows,
+			WhatToWatch:  strings.TrimSpace(content.WhatToWatch + " " + mispricingDisclaimer),
+			Mispricings:  events,
+		},
+		Markets:   refs,
+		Tags:      append([]string{"analysis", "odds-that-dont-add-up", "weekly"}, content.Tags...),
+		Sentiment: "neutral",
+		Sources:   data.sources(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Int("events", len(events)).
+		Msg("Mispricing watch article generated")
+
+	return article, nil
+}
+
+// findMispricings checks the most traded events with mutually exclusive
+// outcomes and returns the up to limit whose open outcomes' odds sum at
+// least mispricingTolerance from 100%, largest gap first. When some of an
+// event's outcomes aren't synced, only a sum over 100% is reported, as the
+// missing outcomes can only add to it.
+func (g *Generator) findMispricings(ctx context.Context, limit int) ([]models.Mispricing, error) {
+	events, err := g.store.GetExclusiveEvents(ctx, mispricingMinVolume, mispricingEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	var found []models.Mispricing
+	for _, event := range events {
+		markets, err := g.store.GetEventMarkets(ctx, event.EventID)
+		if err != nil {
+			log.Warn().Err(err).Str("event", event.EventID).Msg("Failed to get event markets")
+			continue
+		}
+
+		m := models.Mispricing{
+			EventID: event.EventID,
+			Title:   event.Title,
+			URL:     event.PolymarketURL,
+			Partial: len(markets) < len(event.MarketIDs),
+		}
+		for i := range markets {
+			if markets[i].Closed {
+				continue
+			}
+			m.Sum += markets[i].Probability
+			m.Markets = append(m.Markets, marketRef(&markets[i]))
+		}
+		if len(m.Markets) < 2 {
+			continue
+		}
+
+		gap := m.Gap()
+		if abs(gap) < mispricingTolerance || m.Partial && gap < 0 {
+			continue
+		}
+		found = append(found, m)
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return abs(found[i].Gap()) > abs(found[j].Gap()) })
+	return found[:min(len(found), limit)], nil
+}
+
+// mispricingRow formats an event's row, e.g. "Who will win the 2028
+// election?: outcomes sum to 108% (+8.0pts across 6 outcomes)".
+func mispricingRow(m models.Mispricing) string {
+	return fmt.Sprintf("%s: outcomes sum to %.0f%% (%+.1fpts across %d outcomes)", m.Title, m.Sum*100, m.Gap()*100, len(m.Markets))
+}
+
+// weekStart returns midnight UTC on the Monday of now's week.
+func weekStart(now time.Time) time.Time {
+	now = now.UTC()
+	offset := (int(now.Weekday()) + 6) % 7
+	return time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func (g *Generator) generateMispricingContent(ctx context.Context, data mispricingPrompt) (*MispricingContent, error) {
+	if g.llm == nil {
+		lead := data.Events[0]
+		return &MispricingContent{
+			Headline:    fmt.Sprintf("%d Markets Where the Odds Don't Sum to 100%%", len(data.Events)),
+			Summary:     fmt.Sprintf("The outcomes of %d prediction markets are priced inconsistently this week.", len(data.Events)),
+			Overview:    fmt.Sprintf("The widest gap is in \"%s\", whose outcomes sum to %.0f%%.", lead.Title, lead.Sum*100),
+			Analysis:    "Exactly one outcome of each of these events will happen, so their odds should sum to 100%.",
+			WhatToWatch: "Watch whether these gaps close as traders reprice the outcomes.",
+			Tags:        []string{},
+		}, nil
+	}
+
+	var result MispricingContent
+	err := g.chatPrompt(ctx, prompts.Mispricing, models.ArticleTypeDigest, data, 1000, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,142 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// moversMinVolume is the 24h volume in USD a market needs to count as a
+// mover, so thinly traded markets don't fill the table.
+const moversMinVolume = 10_000
+
+// MoversContent is the LLM output for a daily market movers article.
+type MoversContent struct {
+	Headline    string   `json:"headline"`
+	Summary     string   `json:"summary"`
+	Overview    string   `json:"overview"`
+	Analysis    string   `json:"analysis"`
+	WhatToWatch string   `json:"what_to_watch"`
+	Tags        []string `json:"tags"`
+	Sentiment   string   `json:"sentiment"`
+}
+
+// GenerateMovers generates a "Today's Biggest Swings" article on the up to
+// limit open markets in any category whose odds rose most over 24h and
+// the up to limit that fell most, read from storage. The movers are listed
+// in a winners/losers table. It is generated at most once a day.
+func (g *Generator) GenerateMovers(ctx context.Context, limit int) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeDigest))
+	ctx, span := tracing.Start(ctx, "content.GenerateMovers", attribute.String("article.type", string(models.ArticleTypeDigest)))
+	defer span.End()
+
+	now := time.Now().UTC()
+	slug := fmt.Sprintf("todays-biggest-swings-%s", now.Format("2006-01-02"))
+	if existing, err := g.store.GetArticleBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	gainers, losers, err := g.store.GetMarketMovers(ctx, moversMinVolume, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+	data := moversPromptData(gainers, losers)
+	if len(data.Gainers) == 0 && len(data.Losers) == 0 {
+		return nil, fmt.Errorf("no markets moved in the last 24h")
+	}
+
+	log.Info().
+		Int("gainers", len(data.Gainers)).
+		Int("losers", len(data.Losers)).
+		Msg("Generating movers article")
+
+	content, err := g.generateMoversContent(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	var rows []string
+	refs := make([]models.MarketRef, 0, len(data.Gainers)+len(data.Losers))
+	for _, m := range data.Gainers {
+		rows = append(rows, moverRow("Up", m))
+		refs = append(refs, m)
+	}
+	for _, m := range data.Losers {
+		rows = append(rows, moverRow("Down", m))
+		refs = append(refs, m)
+	}
+
+	article, err := g.saveRoundup(ctx, roundup{
+		Slug:     slug,
+		Type:     models.ArticleTypeDigest,
+		Title:    "Today's Biggest Swings",
+		Dated:    now.Format("January 2, 2006"),
+		Headline: content.Headline,
+		Summary:  content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.Overview,
+			WhyItMatters: content.Analysis,
+			Context:      rows,
+			WhatToWatch:  content.WhatToWatch,
+			Movers: &models.MoversTable{
+				Gainers: data.Gainers,
+				Losers:  data.Losers,
+			},
+		},
+		Markets:   refs,
+		Tags:      append([]string{"movers", "biggest-swings", "markets"}, content.Tags...),
+		Sentiment: content.Sentiment,
+		Social:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Int("markets", len(refs)).
+		Msg("Movers article generated")
+
+	return article, nil
+}
+
+// moverRow formats a movers table row, e.g. "Up 12.0pts: Will X happen?
+// (now 64% yes)".
+func moverRow(direction string, m models.MarketRef) string {
+	return fmt.Sprintf("%s %.1fpts: %s (now %.0f%% yes)", direction, abs(m.Change24h)*100, m.Question, m.Probability*100)
+}
+
+func (g *Generator) generateMoversContent(ctx context.Context, data moversPrompt) (*MoversContent, error) {
+	if g.llm == nil {
+		lead := data.lead()
+		direction := "rose"
+		if lead.Change24h < 0 {
+			direction = "fell"
+		}
+		return &MoversContent{
+			Headline:    fmt.Sprintf("%d Prediction Markets Swing Sharply in 24 Hours", len(data.Gainers)+len(data.Losers)),
+			Summary:     fmt.Sprintf("%d markets rose and %d fell sharply over the past 24 hours.", len(data.Gainers), len(data.Losers)),
+			Overview:    fmt.Sprintf("The biggest swing was \"%s\", whose odds %s %.1f points to %.0f%%.", lead.Question, direction, abs(lead.Change24h)*100, lead.Probability*100),
+			Analysis:    "Large one-day swings show where traders changed their minds fastest.",
+			WhatToWatch: "Watch whether today's moves hold or retrace in the coming sessions.",
+			Tags:        []string{},
+			Sentiment:   "neutral",
+		}, nil
+	}
+
+	var result MoversContent
+	err := g.chatPrompt(ctx, prompts.Movers, models.ArticleTypeDigest, data, 800, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
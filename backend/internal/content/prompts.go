@@ -0,0 +1,471 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/xtracker"
+)
+
+// promptMarkets is how many markets list prompts (briefings, trending,
+// digests) include.
+const promptMarkets = 10
+
+// promptTemperature is used for every article prompt.
+const promptTemperature = 0.4
+
+// chatPrompt renders a prompt for an article type and decodes the JSON
+// response into result.
+func (g *Generator) chatPrompt(ctx context.Context, name string, articleType models.ArticleType, data interface{}, maxTokens int, result interface{}) error {
+	prompt, err := prompts.Render(name, string(articleType), data)
+	if err != nil {
+		return err
+	}
+	return g.llm.ChatJSON(ctx, llm.ChatRequest{
+		SystemPrompt: prompt.System,
+		UserPrompt:   prompt.User,
+		Temperature:  promptTemperature,
+		MaxTokens:    maxTokens,
+	}, result)
+}
+
+// briefingPrompt is the data for the briefing prompt.
+type briefingPrompt struct {
+	BriefingType models.BriefingType
+	TotalVolume  float64
+	BiggestMover string
+	BiggestMove  float64
+	Markets      []models.MarketRef
+}
+
+func briefingPromptData(briefingType models.BriefingType, markets []models.MarketRef) briefingPrompt {
+	data := briefingPrompt{BriefingType: briefingType, Markets: firstMarkets(markets)}
+	for _, m := range data.Markets {
+		data.TotalVolume += m.Volume24h
+		if abs(m.Change24h) > abs(data.BiggestMove) {
+			data.BiggestMove = m.Change24h
+			data.BiggestMover = m.Question
+		}
+	}
+	return data
+}
+
+// sources lists the derived figures the briefing may quote.
+func (d briefingPrompt) sources() []string {
+	return []string{dollarSource(d.TotalVolume), pointsSource(d.BiggestMove)}
+}
+
+// trendingPrompt is the data for the trending prompt.
+type trendingPrompt struct {
+	TotalVolume float64
+	TopMarket   string
+	TopVolume   float64
+	Markets     []models.MarketRef
+}
+
+func trendingPromptData(markets []models.MarketRef) trendingPrompt {
+	data := trendingPrompt{Markets: firstMarkets(markets)}
+	for _, m := range data.Markets {
+		data.TotalVolume += m.Volume24h
+		if m.Volume24h > data.TopVolume {
+			data.TopVolume = m.Volume24h
+			data.TopMarket = m.Question
+		}
+	}
+	return data
+}
+
+// sources lists the derived figures the trending article may quote.
+func (d trendingPrompt) sources() []string {
+	return []string{dollarSource(d.TotalVolume)}
+}
+
+// newMarketPrompt is the data for the new market prompt.
+type newMarketPrompt struct {
+	Market          *models.Market
+	ImpliedOutcome  string
+	ExternalContext string
+}
+
+func newMarketPromptData(market *models.Market, enrichedCtx string) newMarketPrompt {
+	// Determine implied odds interpretation
+	impliedOutcome := "uncertain"
+	if market.Probability > 0.7 {
+		impliedOutcome = "likely"
+	} else if market.Probability < 0.3 {
+		impliedOutcome = "unlikely"
+	}
+	return newMarketPrompt{Market: market, ImpliedOutcome: impliedOutcome, ExternalContext: enrichedCtx}
+}
+
+// digestPrompt is the data for the category digest prompt.
+type digestPrompt struct {
+	Category       string
+	TotalVolume    float64
+	AvgProbability float64
+	BullishCount   int
+	BearishCount   int
+	Trend          string
+	Markets        []models.MarketRef
+}
+
+func digestPromptData(categoryName string, markets []models.MarketRef) digestPrompt {
+	data := digestPrompt{Category: categoryName, Markets: firstMarkets(markets)}
+	for _, m := range data.Markets {
+		data.TotalVolume += m.Volume24h
+		data.AvgProbability += m.Probability
+		if m.Change24h > 0.02 {
+			data.BullishCount++
+		} else if m.Change24h < -0.02 {
+			data.BearishCount++
+		}
+	}
+	if len(data.Markets) > 0 {
+		data.AvgProbability /= float64(len(data.Markets))
+	}
+
+	// Determine overall sentiment
+	data.Trend = "mixed"
+	if data.BullishCount > data.BearishCount*2 {
+		data.Trend = "bullish"
+	} else if data.BearishCount > data.BullishCount*2 {
+		data.Trend = "bearish"
+	}
+	return data
+}
+
+// sources lists the derived figures the digest may quote.
+func (d digestPrompt) sources() []string {
+	return []string{dollarSource(d.TotalVolume), pointsSource(d.AvgProbability)}
+}
+
+// resolutionPrompt is the data for the resolution prompt.
+type resolutionPrompt struct {
+	Market           *models.Market
+	ResolutionSource string
+	History          oddsHistory
+}
+
+func resolutionPromptData(market *models.Market, history oddsHistory) resolutionPrompt {
+	return resolutionPrompt{Market: market, ResolutionSource: resolutionSource(market), History: history}
+}
+
+// sources lists the historical odds the resolution article may quote.
+func (d resolutionPrompt) sources() []string {
+	h := d.History
+	odds := []float64{h.Final}
+	if h.HasHistory {
+		odds = append(odds, h.First, h.MonthBefore, h.WeekBefore, h.DayBefore, h.Peak, h.Trough)
+	}
+	sources := make([]string, 0, 2*len(odds))
+	for _, p := range odds {
+		// Moves between any two points are quotable as well
+		sources = append(sources, pointsSource(p))
+		for _, q := range odds {
+			if q < p {
+				sources = append(sources, pointsSource(p-q))
+			}
+		}
+	}
+	return sources
+}
+
+// closingMarket is a market in the closing-soon prompt with its deadline.
+type closingMarket struct {
+	models.MarketRef
+	Deadline  string // e.g. "Fri Oct 16"
+	HoursLeft int
+}
+
+// closingSoonPrompt is the data for the closing-soon prompt.
+type closingSoonPrompt struct {
+	Days        int
+	TotalVolume float64
+	Markets     []closingMarket
+}
+
+func closingSoonPromptData(days int, markets []models.Market, now time.Time) closingSoonPrompt {
+	data := closingSoonPrompt{Days: days}
+	for i := range markets {
+		m := &markets[i]
+		if m.EndDateTS == nil || len(data.Markets) == promptMarkets {
+			continue
+		}
+		ref := marketRef(m)
+		ref.EndDate = m.EndDate
+		data.TotalVolume += ref.Volume24h
+		data.Markets = append(data.Markets, closingMarket{
+			MarketRef: ref,
+			Deadline:  m.EndDateTS.UTC().Format("Mon Jan 2"),
+			HoursLeft: int(m.EndDateTS.Sub(now).Hours()),
+		})
+	}
+	return data
+}
+
+// sources lists the derived figures the closing-soon article may quote.
+func (d closingSoonPrompt) sources() []string {
+	return []string{dollarSource(d.TotalVolume)}
+}
+
+// moversPrompt is the data for the movers prompt: the biggest 24h gainers
+// and losers, biggest move first.
+type moversPrompt struct {
+	Gainers []models.MarketRef
+	Losers  []models.MarketRef
+}
+
+func moversPromptData(gainers, losers []models.Market) moversPrompt {
+	data := moversPrompt{
+		Gainers: make([]models.MarketRef, 0, len(gainers)),
+		Losers:  make([]models.MarketRef, 0, len(losers)),
+	}
+	for i := range gainers {
+		data.Gainers = append(data.Gainers, marketRef(&gainers[i]))
+	}
+	for i := range losers {
+		data.Losers = append(data.Losers, marketRef(&losers[i]))
+	}
+	return data
+}
+
+// lead returns the market with the biggest move either way.
+func (d moversPrompt) lead() models.MarketRef {
+	if len(d.Losers) > 0 && (len(d.Gainers) == 0 || -d.Losers[0].Change24h > d.Gainers[0].Change24h) {
+		return d.Losers[0]
+	}
+	return d.Gainers[0]
+}
+
+// weekAheadDay is a day in the week-ahead prompt with the markets ending
+// on it.
+type weekAheadDay struct {
+	Date    string // YYYY-MM-DD
+	Label   string // e.g. "Monday, Oct 19"
+	Markets []models.MarketRef
+}
+
+// weekAheadCatalyst is a scheduled-event search in the week-ahead prompt
+// with its news summary.
+type weekAheadCatalyst struct {
+	Topic   string
+	Summary string
+}
+
+// weekAheadPrompt is the data for the week-ahead prompt.
+type weekAheadPrompt struct {
+	WeekOf    string // e.g. "October 19"
+	Days      []weekAheadDay
+	Catalysts []weekAheadCatalyst
+}
+
+// weekAheadPromptData groups markets, soonest first, by the UTC day they
+// end on, keeping each day's weekAheadPerDay markets with the most 24h
+// volume.
+func weekAheadPromptData(start time.Time, markets []models.Market, catalysts []weekAheadCatalyst) weekAheadPrompt {
+	data := weekAheadPrompt{WeekOf: start.Format("January 2"), Catalysts: catalysts}
+	for i := range markets {
+		m := &markets[i]
+		if m.EndDateTS == nil {
+			continue
+		}
+		end := m.EndDateTS.UTC()
+		date := end.Format("2006-01-02")
+		if n := len(data.Days); n == 0 || data.Days[n-1].Date != date {
+			data.Days = append(data.Days, weekAheadDay{Date: date, Label: end.Format("Monday, Jan 2")})
+		}
+		ref := marketRef(m)
+		ref.EndDate = m.EndDate
+		day := &data.Days[len(data.Days)-1]
+		day.Markets = append(day.Markets, ref)
+	}
+	for i := range data.Days {
+		day := &data.Days[i]
+		sort.SliceStable(day.Markets, func(a, b int) bool {
+			return day.Markets[a].Volume24h > day.Markets[b].Volume24h
+		})
+		day.Markets = day.Markets[:min(len(day.Markets), weekAheadPerDay)]
+	}
+	return data
+}
+
+// explainerPrompt is the data for the explainer prompt.
+type explainerPrompt struct {
+	Title   string
+	Notes   string // Editor's notes on the topic
+	Markets []models.MarketRef
+	Context string // Enrichment summary; empty without enrichment
+}
+
+// mispricingPrompt is the data for the mispricing prompt.
+type mispricingPrompt struct {
+	WeekOf string
+	Events []models.Mispricing // Largest gap first
+}
+
+// sources lists the derived figures the mispricing watch may quote.
+func (d mispricingPrompt) sources() []string {
+	sources := []string{pointsSource(1)} // The 100% the odds should sum to
+	for _, e := range d.Events {
+		sources = append(sources, pointsSource(e.Sum), pointsSource(e.Gap()))
+	}
+	return sources
+}
+
+// dollarSource and pointsSource write figures for the fact check to parse.
+func dollarSource(v float64) string { return fmt.Sprintf("$%.0f", v) }
+func pointsSource(v float64) string { return fmt.Sprintf("%.2f%%", abs(v)*100) }
+
+func firstMarkets(markets []models.MarketRef) []models.MarketRef {
+	return markets[:min(len(markets), promptMarkets)]
+}
+
+// promptArticleTypes is the article type each prompt is rendered for by
+// default; the relevance prompt doesn't write an article.
+var promptArticleTypes = map[string]models.ArticleType{
+	prompts.Narrative:      models.ArticleTypeBreaking,
+	prompts.Briefing:       models.ArticleTypeBriefing,
+	prompts.Trending:       models.ArticleTypeTrending,
+	prompts.NewMarket:      models.ArticleTypeNewMarket,
+	prompts.CategoryDigest: models.ArticleTypeDigest,
+	prompts.Resolution:     models.ArticleTypeResolution,
+	prompts.ClosingSoon:    models.ArticleTypeDigest,
+	prompts.Movers:         models.ArticleTypeDigest,
+	prompts.WeekAhead:      models.ArticleTypeWeekAhead,
+	prompts.Explainer:      models.ArticleTypeExplainer,
+	prompts.Mispricing:     models.ArticleTypeDigest,
+	prompts.ShortForm:      models.ArticleTypeBreaking,
+	prompts.Relevance:      "",
+}
+
+// ErrNoPreviewArticle is returned when previewing the short-form prompt for
+// a market no article covers.
+var ErrNoPreviewArticle = errors.New("no article covers market")
+
+// PreviewPrompt renders a prompt as it would be sent for market, without
+// calling the LLM or enrichment sources. List prompts (briefing, trending,
+// category digest) are rendered for the top markets in market's category;
+// the closing-soon and week-ahead prompts for the markets closing this week
+// (without news on catalysts), the movers prompt for the day's biggest
+// movers, the mispricing prompt for this week's inconsistent events, the
+// short-form prompt for the latest article on market, the relevance prompt
+// for the posts stored against market, and the explainer prompt for a
+// topic titled after market's question (without enrichment).
+// An empty articleType uses the prompt's usual article type.
+func (g *Generator) PreviewPrompt(ctx context.Context, name string, articleType models.ArticleType, market *models.Market) (*prompts.Prompt, error) {
+	defaultType, ok := promptArticleTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt %q", name)
+	}
+	if articleType == "" {
+		articleType = defaultType
+	}
+
+	switch name {
+	case prompts.Narrative:
+		return llm.NarrativePrompt(g.narrativeSignal(ctx, market, "", articleType))
+	case prompts.NewMarket:
+		return prompts.Render(name, string(articleType), newMarketPromptData(market, ""))
+	case prompts.Resolution:
+		return prompts.Render(name, string(articleType), resolutionPromptData(market, g.oddsHistory(ctx, market)))
+	case prompts.ClosingSoon:
+		markets, err := g.store.GetClosingSoonMarkets(ctx, 7*24*time.Hour, promptMarkets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get markets: %w", err)
+		}
+		return prompts.Render(name, string(articleType), closingSoonPromptData(7, markets, time.Now()))
+	case prompts.Movers:
+		gainers, losers, err := g.store.GetMarketMovers(ctx, moversMinVolume, 5)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get markets: %w", err)
+		}
+		return prompts.Render(name, string(articleType), moversPromptData(gainers, losers))
+	case prompts.WeekAhead:
+		start := weekAheadStart(time.Now())
+		markets, err := g.store.GetClosingSoonMarkets(ctx, 7*24*time.Hour, weekAheadMarkets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get markets: %w", err)
+		}
+		return prompts.Render(name, string(articleType), weekAheadPromptData(start, markets, nil))
+	case prompts.Mispricing:
+		events, err := g.findMispricings(ctx, 5)
+		if err != nil {
+			return nil, err
+		}
+		return prompts.Render(name, string(articleType), mispricingPrompt{WeekOf: weekStart(time.Now()).Format("January 2"), Events: events})
+	case prompts.ShortForm:
+		articles, err := g.store.GetArticlesByMarketIDs(ctx, []string{market.MarketID}, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get articles: %w", err)
+		}
+		if len(articles) == 0 {
+			return nil, fmt.Errorf("%w %q", ErrNoPreviewArticle, market.Slug)
+		}
+		return prompts.Render(name, string(articleType), &articles[0])
+	case prompts.Relevance:
+		signals, err := g.store.GetMarketSocialSignals(ctx, market.MarketID, promptMarkets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get social signals: %w", err)
+		}
+		pairs := make([]xtracker.RelevancePair, len(signals))
+		for i := range signals {
+			pairs[i] = xtracker.RelevancePair{Text: signals[i].Content, Market: market}
+		}
+		return xtracker.RelevancePrompt(pairs)
+	}
+
+	markets, err := g.categoryMarketRefs(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case prompts.Explainer:
+		return prompts.Render(name, string(articleType), explainerPrompt{Title: market.Question, Markets: markets})
+	case prompts.Briefing:
+		return prompts.Render(name, string(articleType), briefingPromptData(models.BriefingMorning, markets))
+	case prompts.Trending:
+		return prompts.Render(name, string(articleType), trendingPromptData(markets))
+	default:
+		catName := market.Category
+		if cat := models.GetCategoryBySlug(market.Category); cat != nil {
+			catName = cat.Name
+		}
+		return prompts.Render(name, string(articleType), digestPromptData(catName, markets))
+	}
+}
+
+// categoryMarketRefs returns market followed by the top markets in its
+// category.
+func (g *Generator) categoryMarketRefs(ctx context.Context, market *models.Market) ([]models.MarketRef, error) {
+	markets, err := g.store.GetMarketsByCategory(ctx, market.Category, promptMarkets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+
+	refs := []models.MarketRef{marketRef(market)}
+	for i := range markets {
+		if len(refs) < promptMarkets && !strings.EqualFold(markets[i].MarketID, market.MarketID) {
+			refs = append(refs, marketRef(&markets[i]))
+		}
+	}
+	return refs, nil
+}
+
+func marketRef(m *models.Market) models.MarketRef {
+	return models.MarketRef{
+		MarketID:    m.MarketID,
+		Question:    m.Question,
+		Slug:        m.Slug,
+		Probability: m.Probability,
+		Change24h:   m.Change24h,
+		Volume24h:   m.Volume24h,
+		TotalVolume: m.TotalVolume,
+	}
+}
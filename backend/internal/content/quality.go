@@ -0,0 +1,120 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// Quality checks, used as metric labels.
+const (
+	CheckHeadline     = "headline"
+	CheckBannedPhrase = "banned_phrase"
+	CheckNumbers      = "numbers"
+	CheckEmptySection = "empty_section"
+)
+
+// QualityConfig holds the checks generated articles must pass before they
+// are published automatically.
+type QualityConfig struct {
+	Enabled bool
+
+	// Headline length bounds, in characters
+	MinHeadlineLength int
+	MaxHeadlineLength int
+
+	// Phrases (case-insensitive) that must not appear anywhere in the article
+	BannedPhrases []string
+
+	// How far a figure may be from the source data and still be verified
+	// by the fact check: probabilities and changes in percentage points,
+	// volumes as a fraction of the value. Rounding to the precision the
+	// figure is written with is always allowed on top.
+	ProbabilityTolerance float64
+	VolumeTolerance      float64
+}
+
+// DefaultQualityConfig returns default quality gate settings.
+func DefaultQualityConfig() QualityConfig {
+	return QualityConfig{
+		Enabled:           true,
+		MinHeadlineLength: 15,
+		MaxHeadlineLength: 120,
+		BannedPhrases: []string{
+			"as an ai",
+			"language model",
+			"i cannot",
+			"i'm sorry",
+			"lorem ipsum",
+			"[insert",
+			"you should buy",
+			"you should sell",
+		},
+		ProbabilityTolerance: 1,
+		VolumeTolerance:      0.1,
+	}
+}
+
+// SetQuality sets the quality gate settings.
+func (g *Generator) SetQuality(cfg QualityConfig) {
+	g.quality = cfg
+}
+
+// QualityIssue is a failed quality check.
+type QualityIssue struct {
+	Check  string
+	Detail string
+}
+
+func (i QualityIssue) String() string {
+	return i.Check + ": " + i.Detail
+}
+
+// checkQuality returns the quality checks article fails.
+func (g *Generator) checkQuality(article *models.Article) []QualityIssue {
+	cfg := g.quality
+	var issues []QualityIssue
+
+	headlineLen := len([]rune(strings.TrimSpace(article.Headline)))
+	if headlineLen < cfg.MinHeadlineLength || (cfg.MaxHeadlineLength > 0 && headlineLen > cfg.MaxHeadlineLength) {
+		issues = append(issues, QualityIssue{CheckHeadline, fmt.Sprintf("headline is %d characters (expected %d-%d)", headlineLen, cfg.MinHeadlineLength, cfg.MaxHeadlineLength)})
+	}
+
+	sections := []struct {
+		name models.ArticleSection
+		text string
+	}{
+		{models.SectionSummary, article.Summary},
+		{models.SectionWhatHappened, article.Body.WhatHappened},
+		{models.SectionWhyItMatters, article.Body.WhyItMatters},
+		{models.SectionWhatToWatch, article.Body.WhatToWatch},
+	}
+	for _, s := range sections {
+		if strings.TrimSpace(s.text) == "" {
+			issues = append(issues, QualityIssue{CheckEmptySection, string(s.name) + " is empty"})
+		}
+	}
+
+	text := strings.ToLower(strings.Join(append([]string{
+		article.Headline, article.Subheadline, article.Summary,
+		article.Body.WhatHappened, article.Body.WhyItMatters, article.Body.WhatToWatch,
+	}, article.Body.Context...), "\n"))
+	for _, phrase := range cfg.BannedPhrases {
+		if phrase = strings.ToLower(strings.TrimSpace(phrase)); phrase != "" && strings.Contains(text, phrase) {
+			issues = append(issues, QualityIssue{CheckBannedPhrase, fmt.Sprintf("contains %q", phrase)})
+		}
+	}
+
+	// Figures the fact check couldn't verify or correct; only the lead
+	// holds an article back, since that's where readers take figures from
+	if article.FactCheck != nil {
+		for _, f := range article.FactCheck.Figures {
+			if f.Status == models.FactCheckUnverified && f.Section.Lead() {
+				issues = append(issues, QualityIssue{CheckNumbers, fmt.Sprintf("%s: %q doesn't match the source data", f.Section, f.Text)})
+			}
+		}
+	}
+
+	return issues
+}
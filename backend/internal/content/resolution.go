@@ -0,0 +1,212 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// resolutionHistoryWindow is how far back odds are reviewed in a resolution article.
+const resolutionHistoryWindow = 90 * 24 * time.Hour
+
+// ResolutionContent is the LLM output for a resolution article.
+type ResolutionContent struct {
+	Headline     string   `json:"headline"`
+	Summary      string   `json:"summary"`
+	Outcome      string   `json:"outcome"`
+	OddsReview   string   `json:"odds_review"`
+	WhyItMatters string   `json:"why_it_matters"`
+	WhatToWatch  string   `json:"what_to_watch"`
+	Tags         []string `json:"tags"`
+	Sentiment    string   `json:"sentiment"`
+}
+
+// oddsHistory is the market's implied probability of the winning outcome
+// at points before resolution.
+type oddsHistory struct {
+	First       float64 // When tracking began
+	FirstSeen   time.Time
+	MonthBefore float64
+	WeekBefore  float64
+	DayBefore   float64
+	Final       float64 // Last synced price before resolution
+	Peak        float64
+	Trough      float64
+	HasHistory  bool
+}
+
+// GenerateResolution generates a wrap-up article for a resolved market,
+// comparing the outcome with the odds the market gave it along the way.
+func (g *Generator) GenerateResolution(ctx context.Context, market *models.Market) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeResolution))
+	ctx, span := tracing.Start(ctx, "content.GenerateResolution", attribute.String("article.type", string(models.ArticleTypeResolution)))
+	defer span.End()
+
+	// One generation per market at a time
+	release, err := g.lockMarket(ctx, market.MarketID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	slug := fmt.Sprintf("resolved-%s", market.Slug)
+	if existing, err := g.store.GetArticleBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	log.Info().
+		Str("market", market.Question).
+		Str("outcome", market.WinningOutcome).
+		Msg("Generating resolution article")
+
+	history := g.oddsHistory(ctx, market)
+
+	content, err := g.generateResolutionContent(ctx, market, history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	article := &models.Article{
+		Slug:        slug,
+		Type:        models.ArticleTypeResolution,
+		Category:    market.Category,
+		Headline:    content.Headline,
+		Subheadline: content.Summary,
+		Summary:     content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.Outcome,
+			WhyItMatters: content.WhyItMatters,
+			Context:      []string{content.OddsReview},
+			WhatToWatch:  content.WhatToWatch,
+			MarketData:   g.marketData(ctx, market, resolutionHistoryWindow),
+		},
+		Markets: []models.MarketRef{{
+			MarketID:     market.MarketID,
+			Question:     market.Question,
+			Slug:         market.Slug,
+			Probability:  market.Probability,
+			PreviousProb: market.PreviousProb,
+			Change24h:    market.Change24h,
+			Volume24h:    market.Volume24h,
+			TotalVolume:  market.TotalVolume,
+		}},
+		PrimaryMarket: &models.MarketRef{
+			MarketID:    market.MarketID,
+			Question:    market.Question,
+			Probability: market.Probability,
+			Change24h:   market.Change24h,
+			Volume24h:   market.Volume24h,
+		},
+		Tags:            append([]string{"resolved"}, content.Tags...),
+		Significance:    models.SignificanceHigh,
+		Sentiment:       content.Sentiment,
+		MetaTitle:       content.Headline + " | FutureSignals",
+		MetaDescription: content.Summary,
+		Published:       true,
+	}
+
+	if err := g.saveArticle(ctx, article, resolutionPromptData(market, history).sources()...); err != nil {
+		return nil, fmt.Errorf("failed to save article: %w", err)
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Str("headline", article.Headline).
+		Msg("Resolution article generated")
+
+	return article, nil
+}
+
+// oddsHistory reads the winning outcome's implied probability from the
+// market's daily rollups.
+func (g *Generator) oddsHistory(ctx context.Context, market *models.Market) oddsHistory {
+	h := oddsHistory{Final: market.WinnerProbability(market.Probability)}
+
+	rollups, err := g.store.GetSnapshotRollups(ctx, market.MarketID, models.GranularityDay, resolutionHistoryWindow)
+	if err != nil {
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to load odds history")
+		return h
+	}
+	if len(rollups) == 0 {
+		return h
+	}
+
+	resolvedAt := time.Now()
+	if market.ResolvedAt != nil {
+		resolvedAt = *market.ResolvedAt
+	}
+
+	// Rollups are newest first; each point takes the last close before it
+	closeBefore := func(d time.Duration) float64 {
+		cutoff := resolvedAt.Add(-d)
+		for _, r := range rollups {
+			if !r.BucketStart.After(cutoff) {
+				return market.WinnerProbability(r.Close)
+			}
+		}
+		return market.WinnerProbability(rollups[len(rollups)-1].Open)
+	}
+
+	oldest := rollups[len(rollups)-1]
+	h.HasHistory = true
+	h.First = market.WinnerProbability(oldest.Open)
+	h.FirstSeen = oldest.BucketStart
+	h.MonthBefore = closeBefore(30 * 24 * time.Hour)
+	h.WeekBefore = closeBefore(7 * 24 * time.Hour)
+	h.DayBefore = closeBefore(24 * time.Hour)
+	h.Peak, h.Trough = h.Final, h.Final
+	for _, r := range rollups {
+		for _, p := range []float64{market.WinnerProbability(r.High), market.WinnerProbability(r.Low)} {
+			if p > h.Peak {
+				h.Peak = p
+			}
+			if p < h.Trough {
+				h.Trough = p
+			}
+		}
+	}
+	return h
+}
+
+func (g *Generator) generateResolutionContent(ctx context.Context, market *models.Market, history oddsHistory) (*ResolutionContent, error) {
+	if g.llm == nil {
+		oddsReview := fmt.Sprintf("Traders last priced %s at %.0f%% before resolution.", market.WinningOutcome, history.Final*100)
+		if history.HasHistory {
+			oddsReview = fmt.Sprintf("A week before resolution, traders gave %s a %.0f%% chance.", market.WinningOutcome, history.WeekBefore*100)
+		}
+		return &ResolutionContent{
+			Headline:     truncate(fmt.Sprintf("Resolved %s: %s", market.WinningOutcome, market.Question), 90),
+			Summary:      fmt.Sprintf("The market \"%s\" resolved %s.", market.Question, market.WinningOutcome),
+			Outcome:      fmt.Sprintf("The market resolved %s after trading $%.1fM in total volume.", market.WinningOutcome, market.TotalVolume/1e6),
+			OddsReview:   oddsReview,
+			WhyItMatters: "Resolved markets show how well prediction markets anticipated the outcome.",
+			WhatToWatch:  "Watch related markets for how traders reprice after this outcome.",
+			Tags:         []string{market.Category},
+			Sentiment:    "neutral",
+		}, nil
+	}
+
+	var result ResolutionContent
+	err := g.chatPrompt(ctx, prompts.Resolution, models.ArticleTypeResolution, resolutionPromptData(market, history), 700, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func resolutionSource(market *models.Market) string {
+	if strings.TrimSpace(market.ResolutionSource) == "" {
+		return "Not specified"
+	}
+	return market.ResolutionSource
+}
@@ -0,0 +1,58 @@
+package content
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// roundup is a briefing article listing markets, such as the day's movers
+// or the week's deadlines. Its Body.Context rows are written from the
+// synced figures rather than left to the LLM, which only writes the prose
+// around them, so the listed odds and dates can't be misquoted.
+type roundup struct {
+	Slug      string
+	Type      models.ArticleType
+	Title     string // e.g. "Today's Biggest Swings", before the headline
+	Dated     string // after the title in the meta title, e.g. the date
+	Headline  string
+	Summary   string
+	Body      models.ArticleBody
+	Markets   []models.MarketRef
+	Tags      []string
+	Sentiment string
+	Sources   []string // the context the prose was written from
+
+	// Social adds the markets' social signals
+	Social bool
+}
+
+// saveRoundup builds a roundup's article and saves it.
+func (g *Generator) saveRoundup(ctx context.Context, r roundup) (*models.Article, error) {
+	article := &models.Article{
+		Slug:            r.Slug,
+		Type:            r.Type,
+		Category:        "briefing",
+		Headline:        fmt.Sprintf("%s: %s", r.Title, r.Headline),
+		Subheadline:     r.Summary,
+		Summary:         r.Summary,
+		Body:            r.Body,
+		Markets:         r.Markets,
+		Tags:            r.Tags,
+		Significance:    models.SignificanceMedium,
+		Sentiment:       r.Sentiment,
+		MetaTitle:       fmt.Sprintf("%s - %s | FutureSignals", r.Title, r.Dated),
+		MetaDescription: r.Summary,
+		Published:       true,
+	}
+
+	if r.Social {
+		g.enrichWithSocialSignals(ctx, article)
+	}
+
+	if err := g.saveArticle(ctx, article, r.Sources...); err != nil {
+		return nil, fmt.Errorf("failed to save article: %w", err)
+	}
+	return article, nil
+}
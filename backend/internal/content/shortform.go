@@ -0,0 +1,98 @@
+package content
+
+import (
+	"context"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/rs/zerolog/log"
+)
+
+// Short-form limits, enforced on the LLM output.
+const (
+	socialMaxLength = 280
+	tldrBullets     = 3
+	tldrMaxLength   = 120
+	pushMaxLength   = 120
+)
+
+// shortFormContent is the LLM output for an article's short-form variants.
+type shortFormContent struct {
+	Social string   `json:"social"`
+	TLDR   []string `json:"tldr"`
+	Push   string   `json:"push"`
+}
+
+// addShortForm condenses a generated article into a social blurb, a TL;DR
+// and a push notification line, so publishers can post them as they are.
+// The variants' figures are fact-checked against the article's markets and
+// sources first. Without an LLM, or if generation fails, the article keeps
+// the variants it had (none for a new article) and publishers format their
+// own.
+func (g *Generator) addShortForm(ctx context.Context, article *models.Article, sources ...string) {
+	if g.llm == nil {
+		return
+	}
+
+	var result shortFormContent
+	if err := g.chatPrompt(ctx, prompts.ShortForm, article.Type, article, 600, &result); err != nil {
+		log.Warn().Err(err).Str("slug", article.Slug).Msg("Failed to generate short-form variants")
+		return
+	}
+
+	short := &models.ShortForm{
+		Social: shortText(result.Social, socialMaxLength),
+		Push:   shortText(result.Push, pushMaxLength),
+	}
+	for _, bullet := range result.TLDR {
+		if bullet = shortText(bullet, tldrMaxLength); bullet != "" && len(short.TLDR) < tldrBullets {
+			short.TLDR = append(short.TLDR, bullet)
+		}
+	}
+	g.checkShortForm(article, short, sources)
+	if short.Social == "" && short.Push == "" && len(short.TLDR) == 0 {
+		return
+	}
+	article.ShortForm = short
+}
+
+// checkShortForm fact-checks short's figures, adding them to the article's
+// report. Misstated point changes are corrected as in the article; a
+// variant quoting a figure that can't be verified is dropped, since it is
+// posted as it is with nobody reading it first.
+func (g *Generator) checkShortForm(article *models.Article, short *models.ShortForm, sources []string) {
+	texts := []checkedText{
+		{models.SectionSocial, &short.Social},
+		{models.SectionPush, &short.Push},
+	}
+	for i := range short.TLDR {
+		texts = append(texts, checkedText{models.SectionTLDR, &short.TLDR[i]})
+	}
+
+	corrections, unverified := g.checkFigures(newSourceFigures(article.Markets, sources), texts, addToFactCheck(article))
+	applyCorrections(texts, corrections)
+	for i, t := range texts {
+		if unverified[i] {
+			log.Warn().Str("slug", article.Slug).Str("variant", string(t.section)).Msg("Dropped short-form variant with unverified figures")
+			*t.text = ""
+		}
+	}
+
+	tldr := short.TLDR[:0]
+	for _, bullet := range short.TLDR {
+		if bullet != "" {
+			tldr = append(tldr, bullet)
+		}
+	}
+	short.TLDR = tldr
+}
+
+// shortText collapses s to one line and cuts it to at most max characters.
+func shortText(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > max {
+		return strings.TrimSpace(string(r[:max-1])) + "…"
+	}
+	return s
+}
@@ -0,0 +1,27 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// ErrIlliquidMarket is returned when a breaking article is skipped because
+// the market is too thinly traded for its move to be news.
+var ErrIlliquidMarket = errors.New("market is too thinly traded for breaking news")
+
+// formatTiersForLLM describes a market's liquidity and volume tiers, e.g.
+// "thinly traded (low liquidity, medium 24h volume)", or returns "" for a
+// market not yet tiered.
+func formatTiersForLLM(market *models.Market) string {
+	if market.Tier == "" {
+		return ""
+	}
+	label := map[models.Tier]string{
+		models.TierLow:    "thinly traded",
+		models.TierMedium: "moderately traded",
+		models.TierHigh:   "heavily traded",
+	}[market.Tier]
+	return fmt.Sprintf("%s (%s liquidity, %s 24h volume)", label, market.LiquidityTier, market.VolumeTier)
+}
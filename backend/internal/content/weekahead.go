@@ -0,0 +1,192 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Markets read for a week-ahead preview, and kept per day.
+const (
+	weekAheadMarkets = 200
+	weekAheadPerDay  = 5
+)
+
+// weekAheadSearches are the scheduled catalysts news is searched for, with
+// the category each search is made in.
+var weekAheadSearches = []struct {
+	topic    string
+	category string
+}{
+	{"Federal Reserve meeting and economic data releases", "finance"},
+	{"elections and votes", "politics"},
+	{"major company earnings reports", "finance"},
+}
+
+// WeekAheadContent is the LLM output for a week-ahead article.
+type WeekAheadContent struct {
+	Headline    string   `json:"headline"`
+	Summary     string   `json:"summary"`
+	Overview    string   `json:"overview"`
+	Catalysts   string   `json:"catalysts"`
+	WhatToWatch string   `json:"what_to_watch"`
+	Tags        []string `json:"tags"`
+	Sentiment   string   `json:"sentiment"`
+}
+
+// GenerateWeekAhead generates a "Week Ahead in Prediction Markets" article
+// on the seven days from tomorrow: the open markets ending each day, and
+// the Fed meetings, elections and earnings in the news for the week when
+// enrichment is configured. It is generated at most once per week start.
+func (g *Generator) GenerateWeekAhead(ctx context.Context) (*models.Article, error) {
+	ctx = llm.WithArticleType(ctx, string(models.ArticleTypeWeekAhead))
+	ctx, span := tracing.Start(ctx, "content.GenerateWeekAhead", attribute.String("article.type", string(models.ArticleTypeWeekAhead)))
+	defer span.End()
+
+	now := time.Now().UTC()
+	start := weekAheadStart(now)
+	end := start.AddDate(0, 0, 7)
+	slug := fmt.Sprintf("week-ahead-%s", start.Format("2006-01-02"))
+	if existing, err := g.store.GetArticleBySlug(ctx, slug); err == nil && existing != nil {
+		return nil, ErrDuplicateArticle
+	}
+
+	closing, err := g.store.GetClosingSoonMarkets(ctx, end.Sub(now), weekAheadMarkets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+	var markets []models.Market
+	for _, m := range closing {
+		if m.EndDateTS != nil && !m.EndDateTS.Before(start) {
+			markets = append(markets, m)
+		}
+	}
+
+	data := weekAheadPromptData(start, markets, g.weekAheadCatalysts(ctx, start))
+	if len(data.Days) == 0 {
+		return nil, fmt.Errorf("no markets end in the week of %s", data.WeekOf)
+	}
+
+	log.Info().
+		Str("week_of", data.WeekOf).
+		Int("days", len(data.Days)).
+		Int("catalysts", len(data.Catalysts)).
+		Msg("Generating week-ahead article")
+
+	content, err := g.generateWeekAheadContent(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	var calendar []string
+	var refs []models.MarketRef
+	days := make([]models.WeekAheadDay, 0, len(data.Days))
+	for _, day := range data.Days {
+		for _, m := range day.Markets {
+			calendar = append(calendar, fmt.Sprintf("%s: %s (%.0f%% yes)", day.Label, m.Question, m.Probability*100))
+			refs = append(refs, m)
+		}
+		days = append(days, models.WeekAheadDay{Date: day.Date, Markets: day.Markets})
+	}
+
+	article, err := g.saveRoundup(ctx, roundup{
+		Slug:     slug,
+		Type:     models.ArticleTypeWeekAhead,
+		Title:    "Week Ahead in Prediction Markets",
+		Dated:    "Week of " + start.Format("January 2, 2006"),
+		Headline: content.Headline,
+		Summary:  content.Summary,
+		Body: models.ArticleBody{
+			WhatHappened: content.Overview,
+			WhyItMatters: content.Catalysts,
+			Context:      calendar,
+			WhatToWatch:  content.WhatToWatch,
+			WeekAhead:    days,
+		},
+		Markets:   refs,
+		Tags:      append([]string{"week-ahead", "calendar", "markets"}, content.Tags...),
+		Sentiment: content.Sentiment,
+		Social:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Int("markets", len(refs)).
+		Msg("Week-ahead article generated")
+
+	return article, nil
+}
+
+// weekAheadStart returns the start of the day after now, in UTC, when a
+// week-ahead preview's week starts.
+func weekAheadStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// weekAheadCatalysts searches the news for the week's scheduled
+// catalysts. Failed searches are skipped; there are none without an
+// enricher.
+func (g *Generator) weekAheadCatalysts(ctx context.Context, start time.Time) []weekAheadCatalyst {
+	if g.enricher == nil {
+		return nil
+	}
+
+	var catalysts []weekAheadCatalyst
+	for _, search := range weekAheadSearches {
+		query := &models.Market{
+			Question: fmt.Sprintf("%s scheduled for the week of %s", search.topic, start.Format("January 2, 2006")),
+			Category: search.category,
+		}
+		enriched, err := g.enricher.Enrich(ctx, query, models.ArticleTypeWeekAhead)
+		if err != nil {
+			log.Warn().Err(err).Str("topic", search.topic).Msg("Failed to search week-ahead catalysts")
+			continue
+		}
+		if enriched.Summary != "" {
+			catalysts = append(catalysts, weekAheadCatalyst{
+				Topic:   search.topic,
+				Summary: truncate(enriched.Summary, 1500),
+			})
+		}
+	}
+	return catalysts
+}
+
+func (g *Generator) generateWeekAheadContent(ctx context.Context, data weekAheadPrompt) (*WeekAheadContent, error) {
+	if g.llm == nil {
+		first := data.Days[0]
+		markets := 0
+		for _, day := range data.Days {
+			markets += len(day.Markets)
+		}
+		return &WeekAheadContent{
+			Headline:    fmt.Sprintf("%d Market Deadlines Over the Next 7 Days", markets),
+			Summary:     fmt.Sprintf("%d prediction markets resolve in the week of %s, starting %s.", markets, data.WeekOf, first.Label),
+			Overview:    fmt.Sprintf("First up on %s is \"%s\", with traders at %.0f%%.", first.Label, first.Markets[0].Question, first.Markets[0].Probability*100),
+			Catalysts:   "Scheduled events this week may move these markets as their deadlines approach.",
+			WhatToWatch: "Watch for price swings around each deadline and scheduled announcement.",
+			Tags:        []string{},
+			Sentiment:   "neutral",
+		}, nil
+	}
+
+	var result WeekAheadContent
+	err := g.chatPrompt(ctx, prompts.WeekAhead, models.ArticleTypeWeekAhead, data, 1000, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
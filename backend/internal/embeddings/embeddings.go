@@ -0,0 +1,161 @@
+// Package embeddings turns text into vectors so market questions can be
+// compared with news and social posts by meaning rather than shared words.
+// DashScope and OpenAI are both reached through their OpenAI-compatible
+// embeddings endpoints.
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Provider names.
+const (
+	ProviderDashScope = "dashscope"
+	ProviderOpenAI    = "openai"
+)
+
+// DefaultModels holds the embedding model used when none is configured.
+var DefaultModels = map[string]string{
+	ProviderDashScope: "text-embedding-v3",
+	ProviderOpenAI:    "text-embedding-3-small",
+}
+
+// maxBatch is how many texts each provider accepts in one request.
+var maxBatch = map[string]int{
+	ProviderDashScope: 10,
+	ProviderOpenAI:    100,
+}
+
+// Provider is implemented by every embedding backend.
+type Provider interface {
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Model identifies the vector space, so vectors from different models
+	// are never compared.
+	Model() string
+}
+
+// Config holds the configuration for an embedding provider.
+type Config struct {
+	// Provider is ProviderDashScope or ProviderOpenAI
+	Provider string
+	APIKey   string
+	Endpoint string
+
+	// Model defaults to DefaultModels[Provider]
+	Model string
+}
+
+// Client talks to an OpenAI-compatible embeddings endpoint.
+type Client struct {
+	client   *openai.Client
+	provider string
+	model    string
+	batch    int
+}
+
+// NewClient creates a new embeddings client.
+func NewClient(cfg Config) (*Client, error) {
+	batch, ok := maxBatch[cfg.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.Provider)
+	}
+	if cfg.APIKey == "" {
+		return nil, errors.New("embedding provider API key is required")
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultModels[cfg.Provider]
+	}
+
+	config := openai.DefaultConfig(cfg.APIKey)
+	if cfg.Endpoint != "" {
+		config.BaseURL = cfg.Endpoint
+	}
+
+	return &Client{
+		client:   openai.NewClientWithConfig(config),
+		provider: cfg.Provider,
+		model:    cfg.Model,
+		batch:    batch,
+	}, nil
+}
+
+// Model returns the embedding model name.
+func (c *Client) Model() string {
+	return c.model
+}
+
+// Embed returns one vector per text, splitting the request to stay within
+// the provider's batch limit.
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	ctx, span := tracing.Start(ctx, "embeddings.Embed",
+		attribute.String("embeddings.provider", c.provider),
+		attribute.String("embeddings.model", c.model),
+		attribute.Int("embeddings.texts", len(texts)),
+	)
+
+	vectors := make([][]float32, 0, len(texts))
+	var err error
+	for start := 0; start < len(texts) && err == nil; start += c.batch {
+		var batch [][]float32
+		batch, err = c.embedBatch(ctx, texts[start:min(start+c.batch, len(texts))])
+		vectors = append(vectors, batch...)
+	}
+
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+func (c *Client) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := c.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input:          texts,
+		Model:          openai.EmbeddingModel(c.model),
+		EncodingFormat: openai.EmbeddingEncodingFormatFloat,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s embeddings request failed: %w", c.provider, err)
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("%s returned %d embeddings for %d texts", c.provider, len(resp.Data), len(texts))
+	}
+
+	// Results carry their input index; don't rely on response order
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("%s returned embedding index %d out of range", c.provider, d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 when they
+// differ in length or either is zero.
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
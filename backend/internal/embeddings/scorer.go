@@ -0,0 +1,137 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultThreshold is the cosine similarity at which text counts as
+// relevant to a market question.
+const DefaultThreshold = 0.45
+
+// Scorer rates how relevant texts are to a market by the cosine similarity
+// of their embeddings. Market question vectors are cached in memory and in
+// the market_embeddings collection.
+type Scorer struct {
+	provider  Provider
+	store     *storage.Store
+	threshold float64
+
+	mu      sync.Mutex
+	markets map[string]*models.MarketEmbedding
+}
+
+// NewScorer creates a scorer. A threshold of zero or less uses
+// DefaultThreshold.
+func NewScorer(provider Provider, store *storage.Store, threshold float64) *Scorer {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &Scorer{
+		provider:  provider,
+		store:     store,
+		threshold: threshold,
+		markets:   make(map[string]*models.MarketEmbedding),
+	}
+}
+
+// Threshold returns the similarity at or above which text is relevant.
+func (s *Scorer) Threshold() float64 {
+	return s.threshold
+}
+
+// Relevant reports whether a similarity score clears the threshold.
+func (s *Scorer) Relevant(score float64) bool {
+	return score >= s.threshold
+}
+
+// Score returns the similarity of each text to the market's question.
+func (s *Scorer) Score(ctx context.Context, market *models.Market, texts []string) ([]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	question, err := s.marketVector(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := s.provider.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(texts))
+	for i, v := range vectors {
+		scores[i] = Cosine(question, v)
+	}
+	return scores, nil
+}
+
+// ScoreMarkets returns the similarity of text to each market's question.
+func (s *Scorer) ScoreMarkets(ctx context.Context, text string, markets []*models.Market) ([]float64, error) {
+	if len(markets) == 0 {
+		return nil, nil
+	}
+
+	vectors, err := s.provider.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(markets))
+	for i, m := range markets {
+		question, err := s.marketVector(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		scores[i] = Cosine(question, vectors[0])
+	}
+	return scores, nil
+}
+
+// marketVector returns the market's question vector, embedding it when it
+// isn't cached for the current question and model.
+func (s *Scorer) marketVector(ctx context.Context, market *models.Market) ([]float32, error) {
+	current := func(e *models.MarketEmbedding) bool {
+		return e != nil && e.Model == s.provider.Model() && e.Question == market.Question
+	}
+
+	s.mu.Lock()
+	cached := s.markets[market.MarketID]
+	s.mu.Unlock()
+	if current(cached) {
+		return cached.Vector, nil
+	}
+
+	stored, err := s.store.GetMarketEmbedding(ctx, market.MarketID)
+	if err != nil {
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to load market embedding")
+	}
+	if !current(stored) {
+		vectors, err := s.provider.Embed(ctx, []string{market.Question})
+		if err != nil {
+			return nil, fmt.Errorf("embed market question: %w", err)
+		}
+		stored = &models.MarketEmbedding{
+			MarketID:  market.MarketID,
+			Model:     s.provider.Model(),
+			Question:  market.Question,
+			Vector:    vectors[0],
+			UpdatedAt: time.Now(),
+		}
+		if err := s.store.SaveMarketEmbedding(ctx, stored); err != nil {
+			log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to save market embedding")
+		}
+	}
+
+	s.mu.Lock()
+	s.markets[market.MarketID] = stored
+	s.mu.Unlock()
+	return stored.Vector, nil
+}
@@ -0,0 +1,202 @@
+package enrichment
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// CacheConfig sets how long each source's results stay fresh. A zero TTL
+// disables caching for that source.
+type CacheConfig struct {
+	TavilyTTL     time.Duration
+	ExaTTL        time.Duration
+	GoogleNewsTTL time.Duration
+	RedditTTL     time.Duration
+	FirecrawlTTL  time.Duration // Per scraped URL
+
+	// Entries kept in the in-memory LRU in front of MongoDB
+	MemoryEntries int
+}
+
+// DefaultCacheConfig returns default cache configuration. News search goes
+// stale fastest; scraped pages rarely change.
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		TavilyTTL:     1 * time.Hour,
+		ExaTTL:        3 * time.Hour,
+		GoogleNewsTTL: 30 * time.Minute,
+		RedditTTL:     1 * time.Hour,
+		FirecrawlTTL:  24 * time.Hour,
+		MemoryEntries: 512,
+	}
+}
+
+// CacheStore persists cache entries across restarts and replicas.
+// *storage.Store implements it.
+type CacheStore interface {
+	GetEnrichmentCache(ctx context.Context, key string) (*models.EnrichmentCacheEntry, error)
+	SaveEnrichmentCache(ctx context.Context, entry *models.EnrichmentCacheEntry) error
+}
+
+// resultCache is a two-level cache of source results: an in-memory LRU
+// backed by an optional CacheStore.
+type resultCache struct {
+	config CacheConfig
+	store  CacheStore
+
+	mu    sync.Mutex
+	lru   *list.List // front is most recently used
+	items map[string]*list.Element
+}
+
+type cacheItem struct {
+	key       string
+	payload   []byte
+	expiresAt time.Time
+}
+
+func newResultCache(config CacheConfig) *resultCache {
+	return &resultCache{
+		config: config,
+		lru:    list.New(),
+		items:  make(map[string]*list.Element),
+	}
+}
+
+func (c *resultCache) ttl(source string) time.Duration {
+	switch source {
+	case SourceTavily:
+		return c.config.TavilyTTL
+	case SourceExa:
+		return c.config.ExaTTL
+	case SourceGoogleNews:
+		return c.config.GoogleNewsTTL
+	case SourceReddit:
+		return c.config.RedditTTL
+	case SourceFirecrawl:
+		return c.config.FirecrawlTTL
+	}
+	return 0
+}
+
+// normalizeQuery lowercases a query and collapses whitespace so trivially
+// different spellings of the same question share an entry.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+func cacheKey(source, query string) string {
+	sum := sha256.Sum256([]byte(normalizeQuery(query)))
+	return source + ":" + hex.EncodeToString(sum[:16])
+}
+
+// get decodes a fresh cached result for the query into v and reports
+// whether one was found.
+func (c *resultCache) get(ctx context.Context, source, query string, v interface{}) bool {
+	if c.ttl(source) <= 0 {
+		return false
+	}
+	key := cacheKey(source, query)
+
+	if payload, ok := c.getMemory(key); ok && json.Unmarshal(payload, v) == nil {
+		metrics.EnrichmentCacheLookups.WithLabelValues(source, "memory").Inc()
+		return true
+	}
+
+	if c.store != nil {
+		entry, err := c.store.GetEnrichmentCache(ctx, key)
+		if err != nil {
+			log.Warn().Err(err).Str("source", source).Msg("Failed to read enrichment cache")
+		} else if entry != nil && json.Unmarshal(entry.Payload, v) == nil {
+			c.putMemory(key, entry.Payload, entry.ExpiresAt)
+			metrics.EnrichmentCacheLookups.WithLabelValues(source, "mongo").Inc()
+			return true
+		}
+	}
+
+	metrics.EnrichmentCacheLookups.WithLabelValues(source, "miss").Inc()
+	return false
+}
+
+// put caches a source result for the query.
+func (c *resultCache) put(ctx context.Context, source, query string, v interface{}) {
+	ttl := c.ttl(source)
+	if ttl <= 0 {
+		return
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		log.Warn().Err(err).Str("source", source).Msg("Failed to encode enrichment cache entry")
+		return
+	}
+
+	now := time.Now()
+	key := cacheKey(source, query)
+	c.putMemory(key, payload, now.Add(ttl))
+
+	if c.store != nil {
+		err := c.store.SaveEnrichmentCache(ctx, &models.EnrichmentCacheEntry{
+			Key:       key,
+			Source:    source,
+			Query:     normalizeQuery(query),
+			Payload:   payload,
+			FetchedAt: now,
+			ExpiresAt: now.Add(ttl),
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("source", source).Msg("Failed to write enrichment cache")
+		}
+	}
+}
+
+func (c *resultCache) getMemory(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := el.Value.(*cacheItem)
+	if time.Now().After(item.expiresAt) {
+		c.lru.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return item.payload, true
+}
+
+func (c *resultCache) putMemory(key string, payload []byte, expiresAt time.Time) {
+	if c.config.MemoryEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		item := el.Value.(*cacheItem)
+		item.payload, item.expiresAt = payload, expiresAt
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.lru.PushFront(&cacheItem{key: key, payload: payload, expiresAt: expiresAt})
+	for c.lru.Len() > c.config.MemoryEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheItem).key)
+	}
+}
@@ -4,11 +4,17 @@ package enrichment
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/embeddings"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // EnrichmentConfig holds configuration for the enricher.
@@ -16,24 +22,41 @@ type EnrichmentConfig struct {
 	TavilyAPIKey    string
 	ExaAPIKey       string
 	FirecrawlAPIKey string
-	MaxNewsResults  int
-	MaxDeepScrapes  int
 	EnableTavily    bool
 	EnableExa       bool
 	EnableFirecrawl bool
+
+	// Google News RSS and Reddit need no API key
+	EnableGoogleNews bool
+	EnableReddit     bool
+
+	// Result caching per source; zero TTLs disable it
+	Cache CacheConfig
+
+	// Budgets per article type, and for types without their own. Default
+	// to DefaultProfiles and DefaultProfile.
+	Profiles       map[models.ArticleType]EnrichmentProfile
+	DefaultProfile EnrichmentProfile
 }
 
 // Enricher orchestrates context enrichment from multiple sources.
 type Enricher struct {
 	tavily    *TavilyClient
 	exa       *ExaClient
-	firecrawl *FirecrawlClient
-	config    EnrichmentConfig
+	firecrawl  *FirecrawlClient
+	googleNews *GoogleNewsClient
+	reddit     *RedditClient
+	config     EnrichmentConfig
+
+	// Drops results unrelated to the market; nil keeps everything
+	scorer *embeddings.Scorer
+
+	cache *resultCache
 }
 
 // EnrichedContext represents the combined context from all sources.
 type EnrichedContext struct {
-	// News articles from Tavily
+	// News articles from Tavily and Google News
 	NewsArticles []NewsArticle `json:"news_articles"`
 
 	// Semantic search results from Exa
@@ -42,6 +65,9 @@ type EnrichedContext struct {
 	// Deep scraped content from Firecrawl
 	DeepContent []DeepContent `json:"deep_content"`
 
+	// Reddit threads and their top comments
+	CommunitySentiment []CommunityThread `json:"community_sentiment,omitempty"`
+
 	// Combined summary for LLM consumption
 	Summary string `json:"summary"`
 
@@ -50,7 +76,7 @@ type EnrichedContext struct {
 	Sources    []string  `json:"sources"`
 }
 
-// NewsArticle represents a news article from Tavily.
+// NewsArticle represents a news article from Tavily or Google News.
 type NewsArticle struct {
 	Title       string    `json:"title"`
 	URL         string    `json:"url"`
@@ -58,6 +84,7 @@ type NewsArticle struct {
 	Published   string    `json:"published,omitempty"`
 	Source      string    `json:"source"`
 	Relevance   float64   `json:"relevance"`
+	Similarity  float64   `json:"similarity,omitempty"` // Embedding similarity to the market question
 }
 
 // SemanticResult represents a semantic search result from Exa.
@@ -69,6 +96,7 @@ type SemanticResult struct {
 	Summary    string   `json:"summary,omitempty"`
 	Published  string   `json:"published,omitempty"`
 	Score      float64  `json:"score"`
+	Similarity float64  `json:"similarity,omitempty"` // Embedding similarity to the market question
 }
 
 // DeepContent represents deeply scraped content from Firecrawl.
@@ -79,10 +107,28 @@ type DeepContent struct {
 	Description string `json:"description,omitempty"`
 }
 
+// CommunityThread represents a Reddit discussion about the market topic.
+type CommunityThread struct {
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	Subreddit   string   `json:"subreddit"`
+	Score       int      `json:"score"`
+	NumComments int      `json:"num_comments"`
+	Published   string   `json:"published,omitempty"`
+	TopComments []string `json:"top_comments,omitempty"`
+}
+
+// Reddit threads per enrichment, and top comments read from each.
+const (
+	redditMaxThreads  = 3
+	redditMaxComments = 3
+)
+
 // NewEnricher creates a new Enricher with the given configuration.
 func NewEnricher(config EnrichmentConfig) *Enricher {
 	e := &Enricher{
 		config: config,
+		cache:  newResultCache(config.Cache),
 	}
 
 	if config.EnableTavily && config.TavilyAPIKey != "" {
@@ -100,23 +146,60 @@ func NewEnricher(config EnrichmentConfig) *Enricher {
 		log.Info().Msg("Firecrawl enrichment enabled")
 	}
 
-	if config.MaxNewsResults <= 0 {
-		e.config.MaxNewsResults = 5
+	if config.EnableGoogleNews {
+		e.googleNews = NewGoogleNewsClient()
+		log.Info().Msg("Google News enrichment enabled")
 	}
-	if config.MaxDeepScrapes <= 0 {
-		e.config.MaxDeepScrapes = 2
+
+	if config.EnableReddit {
+		e.reddit = NewRedditClient()
+		log.Info().Msg("Reddit enrichment enabled")
+	}
+
+	if config.Profiles == nil {
+		e.config.Profiles = DefaultProfiles()
+	}
+	if len(config.DefaultProfile.Sources) == 0 {
+		e.config.DefaultProfile = DefaultProfile()
 	}
 
 	return e
 }
 
-// Enrich gathers context for a market signal from multiple sources.
-func (e *Enricher) Enrich(ctx context.Context, marketQuestion string, category string) (*EnrichedContext, error) {
+// SetCacheStore backs the in-memory result cache with persistent storage,
+// so cached results survive restarts and are shared between replicas.
+func (e *Enricher) SetCacheStore(store CacheStore) {
+	e.cache.store = store
+}
+
+// SetScorer enables filtering search results by embedding similarity to
+// the market question.
+func (e *Enricher) SetScorer(scorer *embeddings.Scorer) {
+	e.scorer = scorer
+}
+
+// Enrich gathers context for a market signal from multiple sources, within
+// the budget of the article type's profile.
+func (e *Enricher) Enrich(ctx context.Context, market *models.Market, articleType models.ArticleType) (*EnrichedContext, error) {
+	marketQuestion, category := market.Question, market.Category
+	profile := e.Profile(articleType)
+	sources := e.selectSources(profile)
+
 	log.Info().
 		Str("market", marketQuestion).
 		Str("category", category).
+		Str("article_type", string(articleType)).
 		Msg("Starting enrichment")
 
+	ctx, span := tracing.Start(ctx, "enrichment.Enrich",
+		attribute.String("category", category),
+		attribute.String("article_type", string(articleType)),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, profile.Timeout)
+	defer cancel()
+
 	result := &EnrichedContext{
 		EnrichedAt: time.Now(),
 		Sources:    []string{},
@@ -127,11 +210,15 @@ func (e *Enricher) Enrich(ctx context.Context, marketQuestion string, category s
 	errs := make([]error, 0)
 
 	// Run all enrichment sources concurrently
-	if e.tavily != nil {
+	if sources[SourceTavily] {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			articles, err := e.enrichFromTavily(ctx, marketQuestion)
+			start := time.Now()
+			sctx, sspan := tracing.Start(ctx, "enrichment.tavily")
+			articles, err := e.enrichFromTavily(sctx, marketQuestion, profile)
+			tracing.End(sspan, err)
+			metrics.EnrichmentDuration.WithLabelValues(SourceTavily, metrics.ResultLabel(err)).Observe(time.Since(start).Seconds())
 			mu.Lock()
 			defer mu.Unlock()
 			if err != nil {
@@ -139,16 +226,20 @@ func (e *Enricher) Enrich(ctx context.Context, marketQuestion string, category s
 				errs = append(errs, err)
 			} else {
 				result.NewsArticles = articles
-				result.Sources = append(result.Sources, "tavily")
+				result.Sources = append(result.Sources, SourceTavily)
 			}
 		}()
 	}
 
-	if e.exa != nil {
+	if sources[SourceExa] {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			semantic, err := e.enrichFromExa(ctx, marketQuestion, category)
+			start := time.Now()
+			sctx, sspan := tracing.Start(ctx, "enrichment.exa")
+			semantic, err := e.enrichFromExa(sctx, marketQuestion, category, profile)
+			tracing.End(sspan, err)
+			metrics.EnrichmentDuration.WithLabelValues(SourceExa, metrics.ResultLabel(err)).Observe(time.Since(start).Seconds())
 			mu.Lock()
 			defer mu.Unlock()
 			if err != nil {
@@ -156,21 +247,76 @@ func (e *Enricher) Enrich(ctx context.Context, marketQuestion string, category s
 				errs = append(errs, err)
 			} else {
 				result.SemanticResults = semantic
-				result.Sources = append(result.Sources, "exa")
+				result.Sources = append(result.Sources, SourceExa)
+			}
+		}()
+	}
+
+	var googleArticles []NewsArticle
+	if sources[SourceGoogleNews] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			sctx, sspan := tracing.Start(ctx, "enrichment.google_news")
+			articles, err := e.enrichFromGoogleNews(sctx, marketQuestion, profile)
+			tracing.End(sspan, err)
+			metrics.EnrichmentDuration.WithLabelValues(SourceGoogleNews, metrics.ResultLabel(err)).Observe(time.Since(start).Seconds())
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Warn().Err(err).Msg("Google News enrichment failed")
+				errs = append(errs, err)
+			} else {
+				googleArticles = articles
+				result.Sources = append(result.Sources, SourceGoogleNews)
+			}
+		}()
+	}
+
+	if sources[SourceReddit] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			sctx, sspan := tracing.Start(ctx, "enrichment.reddit")
+			threads, err := e.enrichFromReddit(sctx, marketQuestion, category)
+			tracing.End(sspan, err)
+			metrics.EnrichmentDuration.WithLabelValues(SourceReddit, metrics.ResultLabel(err)).Observe(time.Since(start).Seconds())
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Warn().Err(err).Msg("Reddit enrichment failed")
+				errs = append(errs, err)
+			} else if len(threads) > 0 {
+				result.CommunitySentiment = threads
+				result.Sources = append(result.Sources, SourceReddit)
 			}
 		}()
 	}
 
 	wg.Wait()
 
+	// Tavily results come first; Google News adds stories it didn't find
+	result.NewsArticles = mergeNewsArticles(result.NewsArticles, googleArticles)
+
+	// Drop off-topic results before deep scraping the top ones
+	if e.scorer != nil {
+		e.filterByRelevance(ctx, market, result)
+	}
+
 	// Deep scrape top URLs if Firecrawl is enabled
-	if e.firecrawl != nil && len(result.NewsArticles) > 0 {
-		deepContent, err := e.enrichWithFirecrawl(ctx, result)
+	if sources[SourceFirecrawl] && len(result.NewsArticles) > 0 {
+		start := time.Now()
+		sctx, sspan := tracing.Start(ctx, "enrichment.firecrawl")
+		deepContent, err := e.enrichWithFirecrawl(sctx, result, profile)
+		tracing.End(sspan, err)
+		metrics.EnrichmentDuration.WithLabelValues(SourceFirecrawl, metrics.ResultLabel(err)).Observe(time.Since(start).Seconds())
 		if err != nil {
 			log.Warn().Err(err).Msg("Firecrawl enrichment failed")
 		} else {
 			result.DeepContent = deepContent
-			result.Sources = append(result.Sources, "firecrawl")
+			result.Sources = append(result.Sources, SourceFirecrawl)
 		}
 	}
 
@@ -181,20 +327,94 @@ func (e *Enricher) Enrich(ctx context.Context, marketQuestion string, category s
 		Int("news_articles", len(result.NewsArticles)).
 		Int("semantic_results", len(result.SemanticResults)).
 		Int("deep_content", len(result.DeepContent)).
+		Int("community_threads", len(result.CommunitySentiment)).
 		Strs("sources", result.Sources).
 		Msg("Enrichment complete")
 
 	return result, nil
 }
 
+// filterByRelevance scores news and semantic results against the market
+// question, drops those below the scorer's threshold and orders the rest by
+// similarity. Results are left as they are if scoring fails.
+func (e *Enricher) filterByRelevance(ctx context.Context, market *models.Market, result *EnrichedContext) {
+	ctx, span := tracing.Start(ctx, "enrichment.relevance")
+	defer span.End()
+
+	texts := make([]string, 0, len(result.NewsArticles)+len(result.SemanticResults))
+	for _, a := range result.NewsArticles {
+		texts = append(texts, truncateString(a.Title+"\n"+a.Content, 1000))
+	}
+	for _, r := range result.SemanticResults {
+		body := r.Summary
+		if body == "" {
+			body = r.Text
+		}
+		texts = append(texts, truncateString(r.Title+"\n"+body, 1000))
+	}
+	if len(texts) == 0 {
+		return
+	}
+
+	scores, err := e.scorer.Score(ctx, market, texts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Embedding relevance failed, keeping all results")
+		return
+	}
+
+	news := result.NewsArticles[:0]
+	for i, a := range result.NewsArticles {
+		if e.scorer.Relevant(scores[i]) {
+			a.Similarity = scores[i]
+			news = append(news, a)
+		}
+	}
+	sort.SliceStable(news, func(i, j int) bool { return news[i].Similarity > news[j].Similarity })
+
+	offset := len(result.NewsArticles)
+	semantic := result.SemanticResults[:0]
+	for i, r := range result.SemanticResults {
+		if e.scorer.Relevant(scores[offset+i]) {
+			r.Similarity = scores[offset+i]
+			semantic = append(semantic, r)
+		}
+	}
+	sort.SliceStable(semantic, func(i, j int) bool { return semantic[i].Similarity > semantic[j].Similarity })
+
+	span.SetAttributes(
+		attribute.Int("dropped", len(texts)-len(news)-len(semantic)),
+	)
+	log.Debug().
+		Int("news_kept", len(news)).
+		Int("news_total", len(result.NewsArticles)).
+		Int("semantic_kept", len(semantic)).
+		Int("semantic_total", len(result.SemanticResults)).
+		Msg("Filtered enrichment by relevance")
+
+	result.NewsArticles = news
+	result.SemanticResults = semantic
+}
+
 // enrichFromTavily fetches news articles from Tavily.
-func (e *Enricher) enrichFromTavily(ctx context.Context, query string) ([]NewsArticle, error) {
-	resp, err := e.tavily.SearchNews(ctx, query, e.config.MaxNewsResults)
+// Advanced depth searches curated news outlets only.
+func (e *Enricher) enrichFromTavily(ctx context.Context, query string, profile EnrichmentProfile) ([]NewsArticle, error) {
+	cacheQuery := fmt.Sprintf("%s|%s|%d", query, profile.Depth, profile.MaxNewsResults)
+
+	var articles []NewsArticle
+	if e.cache.get(ctx, SourceTavily, cacheQuery, &articles) {
+		return articles, nil
+	}
+
+	search := e.tavily.SearchNews
+	if profile.Depth == DepthBasic {
+		search = e.tavily.Search
+	}
+	resp, err := search(ctx, query, profile.MaxNewsResults)
 	if err != nil {
 		return nil, err
 	}
 
-	articles := make([]NewsArticle, 0, len(resp.Results))
+	articles = make([]NewsArticle, 0, len(resp.Results))
 	for _, r := range resp.Results {
 		// Extract domain from URL as source
 		source := extractDomain(r.URL)
@@ -209,18 +429,110 @@ func (e *Enricher) enrichFromTavily(ctx context.Context, query string) ([]NewsAr
 		})
 	}
 
+	e.cache.put(ctx, SourceTavily, cacheQuery, articles)
 	return articles, nil
 }
 
+// enrichFromGoogleNews fetches news articles from the Google News RSS feed.
+func (e *Enricher) enrichFromGoogleNews(ctx context.Context, query string, profile EnrichmentProfile) ([]NewsArticle, error) {
+	cacheQuery := fmt.Sprintf("%s|%d", query, profile.MaxNewsResults)
+
+	var articles []NewsArticle
+	if e.cache.get(ctx, SourceGoogleNews, cacheQuery, &articles) {
+		return articles, nil
+	}
+
+	items, err := e.googleNews.Search(ctx, query, profile.MaxNewsResults)
+	if err != nil {
+		return nil, err
+	}
+
+	articles = googleNewsArticles(items)
+	e.cache.put(ctx, SourceGoogleNews, cacheQuery, articles)
+	return articles, nil
+}
+
+// enrichFromReddit fetches recent threads about the market from the
+// category's subreddits, with their top comments.
+func (e *Enricher) enrichFromReddit(ctx context.Context, question, category string) ([]CommunityThread, error) {
+	query := redditQuery(question)
+	subreddits := subredditsFor(category)
+	cacheQuery := strings.Join(subreddits, "+") + " " + query
+
+	var threads []CommunityThread
+	if e.cache.get(ctx, SourceReddit, cacheQuery, &threads) {
+		return threads, nil
+	}
+
+	posts, err := e.reddit.Search(ctx, query, subreddits, redditMaxThreads)
+	if err != nil {
+		return nil, err
+	}
+
+	threads = make([]CommunityThread, 0, len(posts))
+	for _, p := range posts {
+		thread := CommunityThread{
+			Title:       p.Title,
+			URL:         RedditAPIURL + p.Permalink,
+			Subreddit:   p.Subreddit,
+			Score:       p.Score,
+			NumComments: p.NumComments,
+		}
+		if p.CreatedUTC > 0 {
+			thread.Published = time.Unix(int64(p.CreatedUTC), 0).UTC().Format(time.RFC3339)
+		}
+
+		if p.NumComments > 0 {
+			comments, err := e.reddit.TopComments(ctx, p.ID, redditMaxComments)
+			if err != nil {
+				// A thread without comments is still a signal
+				log.Warn().Err(err).Str("post", p.ID).Msg("Failed to fetch Reddit comments")
+			}
+			for _, c := range comments {
+				thread.TopComments = append(thread.TopComments, truncateString(strings.TrimSpace(c.Body), 400))
+			}
+		}
+		threads = append(threads, thread)
+	}
+
+	e.cache.put(ctx, SourceReddit, cacheQuery, threads)
+	return threads, nil
+}
+
+// mergeNewsArticles appends the extra articles whose headline isn't already
+// among the base articles.
+func mergeNewsArticles(base, extra []NewsArticle) []NewsArticle {
+	seen := make(map[string]bool, len(base))
+	for _, a := range base {
+		seen[normalizeQuery(a.Title)] = true
+	}
+	for _, a := range extra {
+		key := normalizeQuery(a.Title)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		base = append(base, a)
+	}
+	return base
+}
+
 // enrichFromExa fetches semantic search results from Exa.
-func (e *Enricher) enrichFromExa(ctx context.Context, query string, category string) ([]SemanticResult, error) {
+func (e *Enricher) enrichFromExa(ctx context.Context, query string, category string, profile EnrichmentProfile) ([]SemanticResult, error) {
+	cacheQuery := fmt.Sprintf("%s|%d", query, profile.MaxNewsResults)
+
+	var results []SemanticResult
+	if e.cache.get(ctx, SourceExa, cacheQuery, &results) {
+		return results, nil
+	}
+
 	// Search for recent news related to the query
-	resp, err := e.exa.SearchNews(ctx, query, e.config.MaxNewsResults, 7) // Last 7 days
+	resp, err := e.exa.SearchNews(ctx, query, profile.MaxNewsResults, 7) // Last 7 days
 	if err != nil {
 		return nil, err
 	}
 
-	results := make([]SemanticResult, 0, len(resp.Results))
+	results = make([]SemanticResult, 0, len(resp.Results))
 	for _, r := range resp.Results {
 		results = append(results, SemanticResult{
 			Title:      r.Title,
@@ -233,15 +545,17 @@ func (e *Enricher) enrichFromExa(ctx context.Context, query string, category str
 		})
 	}
 
+	e.cache.put(ctx, SourceExa, cacheQuery, results)
 	return results, nil
 }
 
 // enrichWithFirecrawl deep scrapes the top URLs for detailed content.
-func (e *Enricher) enrichWithFirecrawl(ctx context.Context, enriched *EnrichedContext) ([]DeepContent, error) {
+// Pages are cached per URL, so only uncached ones are scraped.
+func (e *Enricher) enrichWithFirecrawl(ctx context.Context, enriched *EnrichedContext, profile EnrichmentProfile) ([]DeepContent, error) {
 	// Collect top URLs from news articles
 	urls := make([]string, 0)
 	for _, article := range enriched.NewsArticles {
-		if len(urls) >= e.config.MaxDeepScrapes {
+		if len(urls) >= profile.MaxDeepScrapes {
 			break
 		}
 		urls = append(urls, article.URL)
@@ -251,24 +565,35 @@ func (e *Enricher) enrichWithFirecrawl(ctx context.Context, enriched *EnrichedCo
 		return nil, nil
 	}
 
-	scraped, err := e.firecrawl.ScrapeMultiple(ctx, urls, e.config.MaxDeepScrapes)
-	if err != nil {
-		return nil, err
-	}
+	content := make([]DeepContent, 0, len(urls))
+	var lastErr error
+	for _, url := range urls {
+		var page DeepContent
+		if e.cache.get(ctx, SourceFirecrawl, url, &page) {
+			content = append(content, page)
+			continue
+		}
 
-	content := make([]DeepContent, 0, len(scraped))
-	for _, s := range scraped {
-		if s == nil {
+		s, err := e.firecrawl.Scrape(ctx, url)
+		if err != nil {
+			log.Warn().Err(err).Str("url", url).Msg("Failed to scrape URL")
+			lastErr = err
 			continue
 		}
-		content = append(content, DeepContent{
+		page = DeepContent{
 			Title:       s.Metadata.Title,
 			URL:         s.Metadata.SourceURL,
 			Markdown:    truncateString(s.Markdown, 3000), // Limit for LLM context
 			Description: s.Metadata.Description,
-		})
+		}
+		e.cache.put(ctx, SourceFirecrawl, url, page)
+		content = append(content, page)
 	}
 
+	// Only fail when nothing could be scraped
+	if len(content) == 0 {
+		return nil, lastErr
+	}
 	return content, nil
 }
 
@@ -320,6 +645,18 @@ func (e *Enricher) generateSummary(enriched *EnrichedContext, query string) stri
 		}
 	}
 
+	if len(enriched.CommunitySentiment) > 0 {
+		sb.WriteString("\n## Community Sentiment (Reddit):\n")
+		for i, thread := range enriched.CommunitySentiment {
+			sb.WriteString(fmt.Sprintf("%d. **%s** (r/%s, %d upvotes, %d comments)\n",
+				i+1, thread.Title, thread.Subreddit, thread.Score, thread.NumComments))
+			for _, c := range thread.TopComments {
+				sb.WriteString(fmt.Sprintf("   - \"%s\"\n", truncateString(strings.Join(strings.Fields(c), " "), 200)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
 	return sb.String()
 }
 
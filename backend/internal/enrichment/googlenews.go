@@ -0,0 +1,145 @@
+// Package enrichment provides context enrichment for signal narratives.
+package enrichment
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	GoogleNewsRSSURL = "https://news.google.com/rss"
+)
+
+// GoogleNewsClient searches Google News through its public RSS feeds. It
+// needs no API key.
+type GoogleNewsClient struct {
+	client *resty.Client
+
+	// Edition parameters, e.g. "en-US", "US" and "US:en"
+	language string
+	country  string
+	edition  string
+}
+
+// GoogleNewsItem represents a single feed item.
+type GoogleNewsItem struct {
+	Title       string           `xml:"title"`
+	Link        string           `xml:"link"`
+	GUID        string           `xml:"guid"`
+	PubDate     string           `xml:"pubDate"`
+	Description string           `xml:"description"`
+	Source      GoogleNewsSource `xml:"source"`
+}
+
+// GoogleNewsSource is the publisher of a feed item.
+type GoogleNewsSource struct {
+	Name string `xml:",chardata"`
+	URL  string `xml:"url,attr"`
+}
+
+type googleNewsFeed struct {
+	Channel struct {
+		Items []GoogleNewsItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// NewGoogleNewsClient creates a new Google News client for the US English
+// edition.
+func NewGoogleNewsClient() *GoogleNewsClient {
+	return &GoogleNewsClient{
+		client: resty.New().
+			SetBaseURL(GoogleNewsRSSURL).
+			SetTimeout(15*time.Second).
+			SetRetryCount(2).
+			SetHeader("User-Agent", "Mozilla/5.0 (compatible; FutureSignals/1.0)"),
+		language: "en-US",
+		country:  "US",
+		edition:  "US:en",
+	}
+}
+
+// Search returns up to maxResults items matching the query, limited to
+// the past week.
+func (c *GoogleNewsClient) Search(ctx context.Context, query string, maxResults int) ([]GoogleNewsItem, error) {
+	log.Debug().
+		Str("query", query).
+		Int("max_results", maxResults).
+		Msg("Google News search")
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"q":    query + " when:7d",
+			"hl":   c.language,
+			"gl":   c.country,
+			"ceid": c.edition,
+		}).
+		Get("/search")
+
+	if err != nil {
+		return nil, fmt.Errorf("google news search failed: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("google news returned %d", resp.StatusCode())
+	}
+
+	var feed googleNewsFeed
+	if err := xml.Unmarshal(resp.Body(), &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse google news feed: %w", err)
+	}
+
+	items := feed.Channel.Items
+	if maxResults > 0 && len(items) > maxResults {
+		items = items[:maxResults]
+	}
+	return items, nil
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// googleNewsArticles normalizes feed items. Feed titles carry a " - Publisher"
+// suffix and descriptions are HTML snippets; both are cleaned up. Items are
+// ranked by feed position, so relevance decays with it.
+func googleNewsArticles(items []GoogleNewsItem) []NewsArticle {
+	articles := make([]NewsArticle, 0, len(items))
+	for i, item := range items {
+		source := strings.TrimSpace(item.Source.Name)
+		title := strings.TrimSpace(item.Title)
+		if source != "" {
+			title = strings.TrimSpace(strings.TrimSuffix(title, " - "+source))
+		} else {
+			source = extractDomain(item.Source.URL)
+		}
+
+		content := html.UnescapeString(htmlTagPattern.ReplaceAllString(item.Description, " "))
+		content = strings.Join(strings.Fields(content), " ")
+		// Descriptions often just repeat the headline and publisher
+		if strings.HasPrefix(content, title) {
+			content = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(content, title), source))
+		}
+
+		published := item.PubDate
+		if t, err := time.Parse(time.RFC1123, item.PubDate); err == nil {
+			published = t.UTC().Format(time.RFC3339)
+		}
+
+		articles = append(articles, NewsArticle{
+			Title:     title,
+			URL:       item.Link,
+			Content:   content,
+			Published: published,
+			Source:    source,
+			Relevance: 1 - float64(i)/float64(len(items)),
+		})
+	}
+	return articles
+}
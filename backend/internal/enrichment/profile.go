@@ -0,0 +1,149 @@
+// Package enrichment provides context enrichment for signal narratives.
+package enrichment
+
+import (
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// Enrichment sources, as used in profiles, cache keys, metrics and
+// EnrichedContext.Sources.
+const (
+	SourceTavily     = "tavily"
+	SourceExa        = "exa"
+	SourceGoogleNews = "google_news"
+	SourceReddit     = "reddit"
+	SourceFirecrawl  = "firecrawl"
+)
+
+// Search depths.
+const (
+	DepthBasic    = "basic"    // Fast general search
+	DepthAdvanced = "advanced" // Slower search over curated news outlets
+)
+
+// EnrichmentProfile is the enrichment budget for one kind of article.
+type EnrichmentProfile struct {
+	// Sources to query, in priority order. Sources that aren't configured
+	// are skipped.
+	Sources []string
+
+	// MaxSources caps how many of Sources are queried; 0 means all
+	MaxSources int
+
+	// Results per search source and top news URLs deep scraped
+	MaxNewsResults int
+	MaxDeepScrapes int
+
+	// Depth is DepthBasic or DepthAdvanced
+	Depth string
+
+	// Timeout bounds the whole enrichment, deep scrapes included
+	Timeout time.Duration
+}
+
+// DefaultProfile returns the profile for article types without their own:
+// every source, with a couple of deep scrapes.
+func DefaultProfile() EnrichmentProfile {
+	return EnrichmentProfile{
+		Sources:        []string{SourceTavily, SourceExa, SourceGoogleNews, SourceReddit, SourceFirecrawl},
+		MaxNewsResults: 5,
+		MaxDeepScrapes: 2,
+		Depth:          DepthAdvanced,
+		Timeout:        45 * time.Second,
+	}
+}
+
+// DefaultProfiles returns the per-article-type profiles. Breaking news
+// must publish quickly, so it gets a shallow news search only; deep dives
+// and explainers can afford more results and scrapes.
+func DefaultProfiles() map[models.ArticleType]EnrichmentProfile {
+	return map[models.ArticleType]EnrichmentProfile{
+		models.ArticleTypeBreaking: {
+			Sources:        []string{SourceTavily, SourceGoogleNews},
+			MaxNewsResults: 5,
+			Depth:          DepthBasic,
+			Timeout:        10 * time.Second,
+		},
+		models.ArticleTypeNewMarket: {
+			Sources:        []string{SourceTavily, SourceExa, SourceGoogleNews, SourceReddit},
+			MaxNewsResults: 5,
+			Depth:          DepthAdvanced,
+			Timeout:        20 * time.Second,
+		},
+		models.ArticleTypeDeepDive: {
+			Sources:        []string{SourceTavily, SourceExa, SourceGoogleNews, SourceReddit, SourceFirecrawl},
+			MaxNewsResults: 8,
+			MaxDeepScrapes: 4,
+			Depth:          DepthAdvanced,
+			Timeout:        90 * time.Second,
+		},
+		models.ArticleTypeExplainer: {
+			Sources:        []string{SourceTavily, SourceExa, SourceGoogleNews, SourceFirecrawl},
+			MaxNewsResults: 8,
+			MaxDeepScrapes: 3,
+			Depth:          DepthAdvanced,
+			Timeout:        60 * time.Second,
+		},
+		models.ArticleTypeWeekAhead: {
+			Sources:        []string{SourceTavily, SourceGoogleNews},
+			MaxNewsResults: 5,
+			Depth:          DepthBasic,
+			Timeout:        20 * time.Second,
+		},
+	}
+}
+
+// withDefaults fills unset limits from DefaultProfile.
+func (p EnrichmentProfile) withDefaults() EnrichmentProfile {
+	d := DefaultProfile()
+	if len(p.Sources) == 0 {
+		p.Sources = d.Sources
+	}
+	if p.MaxNewsResults <= 0 {
+		p.MaxNewsResults = d.MaxNewsResults
+	}
+	if p.MaxDeepScrapes < 0 {
+		p.MaxDeepScrapes = 0
+	}
+	if p.Depth != DepthBasic && p.Depth != DepthAdvanced {
+		p.Depth = d.Depth
+	}
+	if p.Timeout <= 0 {
+		p.Timeout = d.Timeout
+	}
+	return p
+}
+
+// Profile returns the enrichment profile used for an article type.
+func (e *Enricher) Profile(articleType models.ArticleType) EnrichmentProfile {
+	if p, ok := e.config.Profiles[articleType]; ok {
+		return p.withDefaults()
+	}
+	return e.config.DefaultProfile.withDefaults()
+}
+
+// selectSources returns the profile's configured sources, in order and
+// capped at MaxSources. Firecrawl is dropped when the profile allows no
+// deep scrapes.
+func (e *Enricher) selectSources(p EnrichmentProfile) map[string]bool {
+	available := map[string]bool{
+		SourceTavily:     e.tavily != nil,
+		SourceExa:        e.exa != nil,
+		SourceGoogleNews: e.googleNews != nil,
+		SourceReddit:     e.reddit != nil,
+		SourceFirecrawl:  e.firecrawl != nil && p.MaxDeepScrapes > 0,
+	}
+
+	selected := make(map[string]bool)
+	for _, source := range p.Sources {
+		if p.MaxSources > 0 && len(selected) >= p.MaxSources {
+			break
+		}
+		if available[source] {
+			selected[source] = true
+		}
+	}
+	return selected
+}
@@ -0,0 +1,197 @@
+// Package enrichment provides context enrichment for signal narratives.
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RedditAPIURL = "https://www.reddit.com"
+)
+
+// SubredditsByCategory maps market categories to the subreddits where they
+// are discussed. Categories not listed search DefaultSubreddits.
+var SubredditsByCategory = map[string][]string{
+	"politics":    {"politics", "PoliticalDiscussion", "moderatepolitics"},
+	"elections":   {"politics", "PoliticalDiscussion", "fivethirtyeight"},
+	"crypto":      {"CryptoCurrency", "Bitcoin", "ethereum"},
+	"finance":     {"finance", "investing", "stocks"},
+	"economy":     {"economics", "Economics", "investing"},
+	"earnings":    {"stocks", "investing", "wallstreetbets"},
+	"tech":        {"technology", "artificial", "tech"},
+	"sports":      {"sports", "nba", "nfl", "soccer"},
+	"geopolitics": {"geopolitics", "worldnews"},
+	"world":       {"worldnews", "geopolitics"},
+	"culture":     {"popculturechat", "movies", "television"},
+}
+
+// DefaultSubreddits are searched for categories without a mapping.
+var DefaultSubreddits = []string{"news", "worldnews"}
+
+// RedditClient searches Reddit through its public JSON endpoints. It needs
+// no API key, only a descriptive User-Agent.
+type RedditClient struct {
+	client *resty.Client
+}
+
+// RedditPost represents a thread in a search listing.
+type RedditPost struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Permalink   string  `json:"permalink"`
+	Subreddit   string  `json:"subreddit"`
+	SelfText    string  `json:"selftext"`
+	Score       int     `json:"score"`
+	NumComments int     `json:"num_comments"`
+	CreatedUTC  float64 `json:"created_utc"`
+	Over18      bool    `json:"over_18"`
+}
+
+// RedditComment represents a top-level comment.
+type RedditComment struct {
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	Score    int    `json:"score"`
+	Stickied bool   `json:"stickied"`
+}
+
+type redditListing[T any] struct {
+	Data struct {
+		Children []struct {
+			Kind string `json:"kind"`
+			Data T      `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+// NewRedditClient creates a new Reddit client.
+func NewRedditClient() *RedditClient {
+	return &RedditClient{
+		client: resty.New().
+			SetBaseURL(RedditAPIURL).
+			SetTimeout(15*time.Second).
+			SetRetryCount(2).
+			SetHeader("User-Agent", "futuresignals/1.0 (market news enrichment)"),
+	}
+}
+
+// Search returns up to limit threads from the past week matching the query
+// in any of the given subreddits, most relevant first.
+func (c *RedditClient) Search(ctx context.Context, query string, subreddits []string, limit int) ([]RedditPost, error) {
+	log.Debug().
+		Str("query", query).
+		Strs("subreddits", subreddits).
+		Int("limit", limit).
+		Msg("Reddit search")
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("subreddits", strings.Join(subreddits, "+")).
+		SetQueryParams(map[string]string{
+			"q":           query,
+			"restrict_sr": "1",
+			"sort":        "relevance",
+			"t":           "week",
+			"limit":       fmt.Sprintf("%d", limit),
+		}).
+		Get("/r/{subreddits}/search.json")
+
+	if err != nil {
+		return nil, fmt.Errorf("reddit search failed: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("reddit API returned %d", resp.StatusCode())
+	}
+
+	var listing redditListing[RedditPost]
+	if err := json.Unmarshal(resp.Body(), &listing); err != nil {
+		return nil, fmt.Errorf("failed to parse reddit search: %w", err)
+	}
+
+	posts := make([]RedditPost, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		if child.Kind == "t3" && !child.Data.Over18 {
+			posts = append(posts, child.Data)
+		}
+	}
+	return posts, nil
+}
+
+// TopComments returns up to limit of a thread's highest scored top-level
+// comments, skipping stickied, deleted and bot comments.
+func (c *RedditClient) TopComments(ctx context.Context, postID string, limit int) ([]RedditComment, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("id", postID).
+		SetQueryParams(map[string]string{
+			"sort":  "top",
+			"depth": "1",
+			"limit": fmt.Sprintf("%d", limit*2),
+		}).
+		Get("/comments/{id}.json")
+
+	if err != nil {
+		return nil, fmt.Errorf("reddit comments failed: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("reddit API returned %d", resp.StatusCode())
+	}
+
+	// The response is the post listing followed by the comment listing
+	var listings []json.RawMessage
+	if err := json.Unmarshal(resp.Body(), &listings); err != nil {
+		return nil, fmt.Errorf("failed to parse reddit comments: %w", err)
+	}
+	if len(listings) < 2 {
+		return nil, nil
+	}
+
+	var listing redditListing[RedditComment]
+	if err := json.Unmarshal(listings[1], &listing); err != nil {
+		return nil, fmt.Errorf("failed to parse reddit comments: %w", err)
+	}
+
+	comments := make([]RedditComment, 0, limit)
+	for _, child := range listing.Data.Children {
+		if len(comments) >= limit {
+			break
+		}
+		cm := child.Data
+		if child.Kind != "t1" || cm.Stickied || cm.Author == "AutoModerator" ||
+			cm.Body == "[deleted]" || cm.Body == "[removed]" || cm.Body == "" {
+			continue
+		}
+		comments = append(comments, cm)
+	}
+	return comments, nil
+}
+
+// redditQuery shortens a market question to the terms worth searching for;
+// Reddit search matches poorly on full questions.
+func redditQuery(question string) string {
+	q := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(question), "?"))
+	for _, prefix := range []string{"Will ", "Is ", "Does ", "Did ", "Can "} {
+		if strings.HasPrefix(q, prefix) {
+			q = strings.TrimPrefix(q, prefix)
+			break
+		}
+	}
+	return q
+}
+
+// subredditsFor returns the subreddits to search for a market category.
+func subredditsFor(category string) []string {
+	if subs, ok := SubredditsByCategory[category]; ok {
+		return subs
+	}
+	return DefaultSubreddits
+}
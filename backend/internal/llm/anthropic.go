@@ -0,0 +1,181 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultAnthropicEndpoint is the Anthropic API base URL.
+	DefaultAnthropicEndpoint = "https://api.anthropic.com"
+
+	// AnthropicAPIVersion is the Messages API version header value.
+	AnthropicAPIVersion = "2023-06-01"
+
+	// defaultAnthropicMaxTokens is used when a request doesn't set MaxTokens,
+	// since the Messages API requires it.
+	defaultAnthropicMaxTokens = 1024
+)
+
+// AnthropicConfig holds the configuration for the Anthropic provider.
+type AnthropicConfig struct {
+	APIKey   string
+	Endpoint string
+	Models   ModelMap
+}
+
+// AnthropicProvider talks to the Anthropic Messages API.
+type AnthropicProvider struct {
+	client *resty.Client
+	models ModelMap
+}
+
+// NewAnthropicProvider creates a new Anthropic provider.
+func NewAnthropicProvider(cfg AnthropicConfig) *AnthropicProvider {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultAnthropicEndpoint
+	}
+
+	return &AnthropicProvider{
+		client: resty.New().
+			SetBaseURL(cfg.Endpoint).
+			SetTimeout(120*time.Second).
+			SetHeader("x-api-key", cfg.APIKey).
+			SetHeader("anthropic-version", AnthropicAPIVersion).
+			SetHeader("Content-Type", "application/json"),
+		models: cfg.Models.WithDefaults(ProviderAnthropic),
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+}
+
+type anthropicResponse struct {
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Chat sends a chat request to the Messages API.
+func (p *AnthropicProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	return traceChat(ctx, ProviderAnthropic, p.models.Resolve(req.Model), func(ctx context.Context) (*ChatResponse, error) {
+		return p.chat(ctx, req)
+	})
+}
+
+func (p *AnthropicProvider) chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	model := p.models.Resolve(req.Model)
+
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultAnthropicMaxTokens
+	}
+
+	messages := []anthropicMessage{{Role: "user", Content: req.UserPrompt}}
+
+	// The Messages API has no JSON mode; prefilling the assistant turn with
+	// an opening brace reliably keeps the model on a bare JSON object.
+	if req.JSONMode {
+		messages = append(messages, anthropicMessage{Role: "assistant", Content: "{"})
+	}
+
+	body := anthropicRequest{
+		Model:       model,
+		System:      req.SystemPrompt,
+		Messages:    messages,
+		MaxTokens:   maxTokens,
+		Temperature: req.Temperature,
+	}
+
+	log.Debug().
+		Str("provider", ProviderAnthropic).
+		Str("model", model).
+		Bool("json_mode", req.JSONMode).
+		Msg("Sending chat request")
+
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		Post("/v1/messages")
+	if err != nil {
+		return nil, fmt.Errorf("anthropic request failed: %w", err)
+	}
+
+	// Gateways in front of the API can answer errors with non-JSON bodies,
+	// so check the status before the parse error
+	var result anthropicResponse
+	parseErr := json.Unmarshal(resp.Body(), &result)
+
+	if resp.StatusCode() != 200 {
+		message := resp.String()
+		if parseErr == nil && result.Error != nil {
+			message = result.Error.Message
+		}
+		return nil, &StatusError{Provider: ProviderAnthropic, StatusCode: resp.StatusCode(), Message: message}
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("failed to parse anthropic response: %w", parseErr)
+	}
+
+	var sb strings.Builder
+	if req.JSONMode {
+		sb.WriteString("{")
+	}
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			sb.WriteString(block.Text)
+		}
+	}
+
+	if sb.Len() == 0 {
+		return nil, fmt.Errorf("no content in response")
+	}
+
+	return &ChatResponse{
+		Content:      sb.String(),
+		FinishReason: result.StopReason,
+		Model:        model,
+		TokensUsed: TokenUsage{
+			PromptTokens:     result.Usage.InputTokens,
+			CompletionTokens: result.Usage.OutputTokens,
+			TotalTokens:      result.Usage.InputTokens + result.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+// ChatJSON sends a chat request and parses the response as JSON.
+func (p *AnthropicProvider) ChatJSON(ctx context.Context, req ChatRequest, result interface{}) error {
+	return chatJSON(ctx, p, req, result)
+}
+
+// GenerateNarrative generates a narrative for a market signal.
+func (p *AnthropicProvider) GenerateNarrative(ctx context.Context, signal SignalData) (*Narrative, error) {
+	return GenerateNarrative(ctx, p, signal)
+}
@@ -0,0 +1,267 @@
+// Package llm defines the provider abstraction used for content generation.
+// Concrete backends (Qwen/DashScope, OpenAI, Anthropic) implement Provider so
+// the content generator never depends on a specific vendor SDK.
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Provider names.
+const (
+	ProviderQwen      = "qwen"
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+)
+
+// Logical model tiers. Callers request a tier and each provider maps it to a
+// concrete model name, so switching providers does not touch call sites.
+const (
+	ModelDefault = "default"
+	ModelFast    = "fast"
+)
+
+// Provider is implemented by every LLM backend.
+type Provider interface {
+	// Chat sends a chat completion request.
+	Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error)
+
+	// ChatJSON sends a chat request and decodes the response as JSON into result.
+	ChatJSON(ctx context.Context, req ChatRequest, result interface{}) error
+
+	// GenerateNarrative produces a Bloomberg-style narrative for a market signal.
+	GenerateNarrative(ctx context.Context, signal SignalData) (*Narrative, error)
+}
+
+// ChatRequest represents a chat completion request.
+type ChatRequest struct {
+	SystemPrompt string
+	UserPrompt   string
+	Temperature  float32
+	MaxTokens    int
+	JSONMode     bool
+
+	// Model is a logical tier (ModelDefault, ModelFast) or a concrete model
+	// name. Empty means ModelDefault.
+	Model string
+}
+
+// ChatResponse represents a chat completion response.
+type ChatResponse struct {
+	Content      string
+	FinishReason string
+	Model        string
+	TokensUsed   TokenUsage
+}
+
+// TokenUsage represents token usage statistics.
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// ModelMap maps logical model tiers to provider-specific model names.
+type ModelMap map[string]string
+
+// DefaultModels holds the built-in tier mapping for each provider.
+var DefaultModels = map[string]ModelMap{
+	ProviderQwen: {
+		ModelDefault: "qwen-plus",
+		ModelFast:    "qwen-turbo",
+	},
+	ProviderOpenAI: {
+		ModelDefault: "gpt-4o",
+		ModelFast:    "gpt-4o-mini",
+	},
+	ProviderAnthropic: {
+		ModelDefault: "claude-3-5-sonnet-latest",
+		ModelFast:    "claude-3-5-haiku-latest",
+	},
+}
+
+// Resolve returns the concrete model name for a requested tier or model.
+// Unknown names are passed through so callers can pin an exact model.
+func (m ModelMap) Resolve(model string) string {
+	if model == "" {
+		model = ModelDefault
+	}
+	if concrete, ok := m[model]; ok && concrete != "" {
+		return concrete
+	}
+	if model == ModelDefault {
+		return ""
+	}
+	return model
+}
+
+// WithDefaults returns a copy of m with missing tiers filled from the
+// provider's built-in mapping.
+func (m ModelMap) WithDefaults(provider string) ModelMap {
+	merged := ModelMap{}
+	for tier, model := range DefaultModels[provider] {
+		merged[tier] = model
+	}
+	for tier, model := range m {
+		if model != "" {
+			merged[tier] = model
+		}
+	}
+	return merged
+}
+
+// chatter is the subset of Provider needed by the shared helpers.
+type chatter interface {
+	Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error)
+}
+
+// ErrMalformedJSON is returned by ChatJSON when a response isn't valid JSON
+// even after repair.
+var ErrMalformedJSON = errors.New("failed to parse JSON response")
+
+// chatJSON forces JSON mode, sends the request and decodes the response.
+func chatJSON(ctx context.Context, c chatter, req ChatRequest, result interface{}) error {
+	req.JSONMode = true
+
+	resp, err := c.Chat(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	content := stripCodeFence(resp.Content)
+	err = json.Unmarshal([]byte(content), result)
+	if err == nil {
+		return nil
+	}
+	if repaired := repairJSON(content); repaired != content && json.Unmarshal([]byte(repaired), result) == nil {
+		log.Debug().Str("model", resp.Model).Msg("Repaired malformed JSON response")
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrMalformedJSON, err)
+}
+
+// repairJSON fixes the usual ways models break JSON: prose around the
+// object, raw newlines inside strings, trailing commas, and output cut off
+// by the token limit (unterminated strings and unclosed brackets).
+func repairJSON(s string) string {
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return s
+	}
+
+	var (
+		out      []byte
+		closers  []byte
+		inString bool
+		escaped  bool
+	)
+	for i := start; i < len(s); i++ {
+		ch := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			case ch == '\n':
+				out = append(out, '\\', 'n')
+				continue
+			case ch == '\r':
+				out = append(out, '\\', 'r')
+				continue
+			case ch == '\t':
+				out = append(out, '\\', 't')
+				continue
+			}
+			out = append(out, ch)
+			continue
+		}
+
+		switch ch {
+		case '"':
+			inString = true
+		case '{':
+			closers = append(closers, '}')
+		case '[':
+			closers = append(closers, ']')
+		case '}', ']':
+			out = trimTrailingComma(out)
+			if len(closers) == 0 || closers[len(closers)-1] != ch {
+				// Stray closer: drop it
+				continue
+			}
+			closers = closers[:len(closers)-1]
+			if len(closers) == 0 {
+				// Done; anything after is commentary
+				return string(append(out, ch))
+			}
+		}
+		out = append(out, ch)
+	}
+
+	// Truncated: close whatever is still open
+	if inString {
+		if escaped {
+			out = out[:len(out)-1]
+		}
+		out = append(out, '"')
+	}
+	out = trimTrailingComma(out)
+	if trimmed := strings.TrimRight(string(out), " \t\r\n"); strings.HasSuffix(trimmed, ":") {
+		out = append([]byte(trimmed), "null"...)
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		out = append(out, closers[i])
+	}
+	return string(out)
+}
+
+// trimTrailingComma drops trailing whitespace and a final comma.
+func trimTrailingComma(b []byte) []byte {
+	trimmed := strings.TrimRight(string(b), " \t\r\n")
+	if strings.HasSuffix(trimmed, ",") {
+		return []byte(trimmed[:len(trimmed)-1])
+	}
+	return b
+}
+
+// stripCodeFence removes a surrounding markdown code fence, which some models
+// emit even when asked for raw JSON.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimSuffix(s, "```")
+	return strings.TrimSpace(s)
+}
+
+// traceChat runs a provider chat call inside an "llm.chat" span.
+func traceChat(ctx context.Context, provider, model string, call func(context.Context) (*ChatResponse, error)) (*ChatResponse, error) {
+	ctx, span := tracing.Start(ctx, "llm.chat",
+		attribute.String("llm.provider", provider),
+		attribute.String("llm.model", model),
+	)
+
+	resp, err := call(ctx)
+	if err == nil {
+		span.SetAttributes(
+			attribute.Int("llm.prompt_tokens", resp.TokensUsed.PromptTokens),
+			attribute.Int("llm.completion_tokens", resp.TokensUsed.CompletionTokens),
+		)
+	}
+	tracing.End(span, err)
+	return resp, err
+}
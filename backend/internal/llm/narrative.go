@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+)
+
+// GenerateNarrative generates a narrative for a market signal using Bloomberg-style journalism.
+// Providers delegate to this so every backend shares the same prompt.
+func GenerateNarrative(ctx context.Context, c Provider, signal SignalData) (*Narrative, error) {
+	prompt, err := NarrativePrompt(signal)
+	if err != nil {
+		return nil, err
+	}
+
+	var narrative Narrative
+	err = c.ChatJSON(ctx, ChatRequest{
+		SystemPrompt: prompt.System,
+		UserPrompt:   prompt.User,
+		Temperature:  0.4, // Slightly higher for more natural writing
+		MaxTokens:    1200,
+	}, &narrative)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &narrative, nil
+}
+
+// NarrativePrompt renders the narrative prompt for a signal, using the
+// override for its article type if there is one.
+func NarrativePrompt(signal SignalData) (*prompts.Prompt, error) {
+	// Determine the movement narrative
+	change := signal.CurrentProb - signal.PreviousProb
+	moveVerb := "moved"
+	if change > 0.10 {
+		moveVerb = "surged"
+	} else if change > 0.05 {
+		moveVerb = "jumped"
+	} else if change > 0.02 {
+		moveVerb = "rose"
+	} else if change < -0.10 {
+		moveVerb = "plunged"
+	} else if change < -0.05 {
+		moveVerb = "tumbled"
+	} else if change < -0.02 {
+		moveVerb = "fell"
+	} else if change > 0 {
+		moveVerb = "edged higher"
+	} else if change < 0 {
+		moveVerb = "slipped"
+	}
+
+	return prompts.Render(prompts.Narrative, signal.ArticleType, struct {
+		SignalData
+		MoveVerb string
+		Change   float64
+	}{signal, moveVerb, change})
+}
+
+// SignalData represents market signal data for narrative generation.
+type SignalData struct {
+	ArticleType           string // Selects a per-article-type prompt override
+	MarketTitle           string
+	EventTitle            string
+	Category              string
+	PreviousProb          float64
+	CurrentProb           float64
+	TimeFrame             string
+	Volume24h             float64
+	TotalVolume           float64
+	ExternalContext       string
+	SocialSignalsContext  string // Context from XTracker influencer posts
+	RelatedMarketsContext string // Markets with correlated price moves
+	LiquidityContext      string // Order book spread and depth
+	TradeFlowContext      string // Recent buy/sell flow and whale trades
+	HoldersContext        string // Top-holder concentration
+	HistoryContext        string // Current odds against their range over past windows
+	TierContext           string // Liquidity and volume tiers, e.g. "thinly traded"
+}
+
+// Narrative represents a generated narrative.
+type Narrative struct {
+	Headline      string   `json:"headline"`
+	AltHeadline   string   `json:"alt_headline"` // Challenger for headline A/B tests
+	Subheadline   string   `json:"subheadline"`
+	WhatChanged   string   `json:"what_changed"`
+	WhyItMatters  string   `json:"why_it_matters"`
+	MarketContext string   `json:"market_context"`
+	WhatToWatch   string   `json:"what_to_watch"`
+	Tags          []string `json:"tags"`
+	Sentiment     string   `json:"sentiment"`
+	Significance  string   `json:"significance"`
+}
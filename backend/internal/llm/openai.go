@@ -0,0 +1,125 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// DefaultOpenAIEndpoint is the public OpenAI API base URL.
+const DefaultOpenAIEndpoint = "https://api.openai.com/v1"
+
+// OpenAIConfig holds the configuration for an OpenAI-compatible provider.
+type OpenAIConfig struct {
+	APIKey   string
+	Endpoint string
+	Models   ModelMap
+
+	// Name identifies the provider in logs (e.g. "openai", "qwen").
+	Name string
+}
+
+// OpenAIProvider talks to any OpenAI-compatible chat completions endpoint.
+type OpenAIProvider struct {
+	client *openai.Client
+	models ModelMap
+	name   string
+}
+
+// NewOpenAIProvider creates a new OpenAI-compatible provider.
+func NewOpenAIProvider(cfg OpenAIConfig) *OpenAIProvider {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultOpenAIEndpoint
+	}
+	if cfg.Name == "" {
+		cfg.Name = ProviderOpenAI
+	}
+
+	config := openai.DefaultConfig(cfg.APIKey)
+	config.BaseURL = cfg.Endpoint
+
+	return &OpenAIProvider{
+		client: openai.NewClientWithConfig(config),
+		models: cfg.Models.WithDefaults(cfg.Name),
+		name:   cfg.Name,
+	}
+}
+
+// Chat sends a chat completion request.
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	return traceChat(ctx, p.name, p.models.Resolve(req.Model), func(ctx context.Context) (*ChatResponse, error) {
+		return p.chat(ctx, req)
+	})
+}
+
+func (p *OpenAIProvider) chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	messages := []openai.ChatCompletionMessage{}
+
+	if req.SystemPrompt != "" {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: req.SystemPrompt,
+		})
+	}
+
+	messages = append(messages, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: req.UserPrompt,
+	})
+
+	model := p.models.Resolve(req.Model)
+	chatReq := openai.ChatCompletionRequest{
+		Model:       model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+	}
+
+	if req.MaxTokens > 0 {
+		chatReq.MaxTokens = req.MaxTokens
+	}
+
+	if req.JSONMode {
+		chatReq.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}
+	}
+
+	log.Debug().
+		Str("provider", p.name).
+		Str("model", model).
+		Int("messages", len(messages)).
+		Bool("json_mode", req.JSONMode).
+		Msg("Sending chat request")
+
+	resp, err := p.client.CreateChatCompletion(ctx, chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s chat completion failed: %w", p.name, err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	return &ChatResponse{
+		Content:      resp.Choices[0].Message.Content,
+		FinishReason: string(resp.Choices[0].FinishReason),
+		Model:        model,
+		TokensUsed: TokenUsage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+// ChatJSON sends a chat request and parses the response as JSON.
+func (p *OpenAIProvider) ChatJSON(ctx context.Context, req ChatRequest, result interface{}) error {
+	return chatJSON(ctx, p, req, result)
+}
+
+// GenerateNarrative generates a narrative for a market signal.
+func (p *OpenAIProvider) GenerateNarrative(ctx context.Context, signal SignalData) (*Narrative, error) {
+	return GenerateNarrative(ctx, p, signal)
+}
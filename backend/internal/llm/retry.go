@@ -0,0 +1,218 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/rs/zerolog/log"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ErrTimeout is returned when a single attempt exceeds RetryConfig.Timeout.
+var ErrTimeout = errors.New("llm request timed out")
+
+// StatusError is an HTTP error response from a provider API.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s API returned %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// RetryConfig controls retries and model fallback.
+type RetryConfig struct {
+	// Attempts per model, including the first
+	MaxAttempts int
+
+	// Wait before the second attempt, doubling (with jitter) up to
+	// MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Timeout for each attempt; zero leaves only the caller's deadline
+	Timeout time.Duration
+
+	// Tiers or model names tried in order once the requested model has
+	// failed, e.g. ModelFast to fall back from qwen-plus to qwen-turbo
+	Fallbacks []string
+}
+
+// DefaultRetryConfig returns default configuration.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		Backoff:     time.Second,
+		MaxBackoff:  10 * time.Second,
+		Timeout:     60 * time.Second,
+		Fallbacks:   []string{ModelFast},
+	}
+}
+
+// RetryingProvider wraps a Provider with per-attempt timeouts, retries with
+// backoff on transient errors, a fallback model chain, and a retry when a
+// JSON response can't be parsed even after repair.
+type RetryingProvider struct {
+	provider Provider
+	name     string
+	config   RetryConfig
+}
+
+// NewRetryingProvider wraps provider; name labels its metrics and logs.
+func NewRetryingProvider(provider Provider, name string, cfg RetryConfig) *RetryingProvider {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	return &RetryingProvider{provider: provider, name: name, config: cfg}
+}
+
+// Chat sends the request, retrying transient failures and falling back to
+// the next model in the chain when a model keeps failing.
+func (p *RetryingProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	var lastErr error
+	for i, model := range p.chain(req.Model) {
+		if i > 0 {
+			metrics.LLMRetries.WithLabelValues(p.name, "fallback").Inc()
+			log.Warn().Err(lastErr).Str("provider", p.name).Str("model", model).Msg("Falling back to next LLM model")
+		}
+
+		req.Model = model
+		resp, err := p.retry(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// chain is the requested model followed by the fallbacks, without repeats.
+func (p *RetryingProvider) chain(model string) []string {
+	if model == "" {
+		model = ModelDefault
+	}
+	models := []string{model}
+	for _, fallback := range p.config.Fallbacks {
+		seen := false
+		for _, m := range models {
+			seen = seen || m == fallback
+		}
+		if !seen {
+			models = append(models, fallback)
+		}
+	}
+	return models
+}
+
+// retry sends the request to one model up to MaxAttempts times.
+func (p *RetryingProvider) retry(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	backoff := p.config.Backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := p.attempt(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= p.config.MaxAttempts || ctx.Err() != nil || !Retryable(err) {
+			return nil, err
+		}
+
+		metrics.LLMRetries.WithLabelValues(p.name, "retry").Inc()
+		log.Debug().Err(err).
+			Str("provider", p.name).
+			Str("model", req.Model).
+			Int("attempt", attempt).
+			Msg("Retrying LLM request")
+
+		// Full jitter keeps concurrent generations from retrying in lockstep
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1))
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+		backoff = min(2*backoff, p.config.MaxBackoff)
+	}
+}
+
+// attempt sends the request once under the per-attempt timeout.
+func (p *RetryingProvider) attempt(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	if p.config.Timeout <= 0 {
+		return p.provider.Chat(ctx, req)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	resp, err := p.provider.Chat(attemptCtx, req)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w after %s", ErrTimeout, p.config.Timeout)
+	}
+	return resp, err
+}
+
+// ChatJSON sends a chat request and parses the response as JSON, asking
+// again when the response is malformed beyond repair.
+func (p *RetryingProvider) ChatJSON(ctx context.Context, req ChatRequest, result interface{}) error {
+	for attempt := 1; ; attempt++ {
+		err := chatJSON(ctx, p, req, result)
+		if err == nil || !errors.Is(err, ErrMalformedJSON) || attempt >= p.config.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		metrics.LLMRetries.WithLabelValues(p.name, "malformed_json").Inc()
+		log.Debug().Err(err).Str("provider", p.name).Int("attempt", attempt).Msg("Retrying malformed JSON response")
+	}
+}
+
+// GenerateNarrative generates a narrative with retries and fallback.
+func (p *RetryingProvider) GenerateNarrative(ctx context.Context, signal SignalData) (*Narrative, error) {
+	return GenerateNarrative(ctx, p, signal)
+}
+
+// Retryable reports whether err is worth retrying: timeouts, rate limits,
+// server errors and network failures are; other client errors and
+// cancellation are not.
+func Retryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return retryableStatus(statusErr.StatusCode)
+	}
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.HTTPStatusCode)
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return retryableStatus(reqErr.HTTPStatusCode)
+	}
+	return true
+}
+
+// retryableStatus reports whether an HTTP status is transient.
+func retryableStatus(code int) bool {
+	switch {
+	case code == http.StatusRequestTimeout, code == http.StatusConflict, code == http.StatusTooManyRequests:
+		return true
+	case code >= 500:
+		return true
+	case code >= 400:
+		return false
+	}
+	// No status: the request never got a response
+	return true
+}
@@ -0,0 +1,157 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// usageTagsKey is the context key for UsageTags.
+type usageTagsKey struct{}
+
+// UsageTags describe why an LLM request was made.
+type UsageTags struct {
+	ArticleType string
+	Job         string
+}
+
+// WithArticleType tags LLM requests made with ctx with an article type.
+func WithArticleType(ctx context.Context, articleType string) context.Context {
+	tags := TagsFromContext(ctx)
+	tags.ArticleType = articleType
+	return context.WithValue(ctx, usageTagsKey{}, tags)
+}
+
+// WithJob tags LLM requests made with ctx with a job name.
+func WithJob(ctx context.Context, job string) context.Context {
+	tags := TagsFromContext(ctx)
+	tags.Job = job
+	return context.WithValue(ctx, usageTagsKey{}, tags)
+}
+
+// TagsFromContext returns the usage tags attached to ctx.
+func TagsFromContext(ctx context.Context) UsageTags {
+	tags, _ := ctx.Value(usageTagsKey{}).(UsageTags)
+	return tags
+}
+
+// UsageRecorder persists usage records.
+type UsageRecorder interface {
+	SaveLLMUsage(ctx context.Context, usage *models.LLMUsage) error
+}
+
+// ModelPrice is the price in USD per million tokens.
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// Prices lists list prices for known models. Lookups match by prefix so dated
+// variants (e.g. gpt-4o-2024-08-06) resolve to their family.
+var Prices = map[string]ModelPrice{
+	"qwen-turbo":        {Input: 0.05, Output: 0.20},
+	"qwen-plus":         {Input: 0.40, Output: 1.20},
+	"qwen-max":          {Input: 1.60, Output: 6.40},
+	"qwen-long":         {Input: 0.07, Output: 0.28},
+	"gpt-4o-mini":       {Input: 0.15, Output: 0.60},
+	"gpt-4o":            {Input: 2.50, Output: 10.00},
+	"claude-3-5-haiku":  {Input: 0.80, Output: 4.00},
+	"claude-3-5-sonnet": {Input: 3.00, Output: 15.00},
+}
+
+// EstimateCost returns the estimated USD cost of a request.
+func EstimateCost(model string, usage TokenUsage) float64 {
+	price, ok := lookupPrice(model)
+	if !ok {
+		return 0
+	}
+	return float64(usage.PromptTokens)/1_000_000*price.Input +
+		float64(usage.CompletionTokens)/1_000_000*price.Output
+}
+
+// lookupPrice finds the longest price key that prefixes model.
+func lookupPrice(model string) (ModelPrice, bool) {
+	var best string
+	for name := range Prices {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPrice{}, false
+	}
+	return Prices[best], true
+}
+
+// UsageTracker wraps a Provider and records token usage and request metrics
+// for every request.
+type UsageTracker struct {
+	provider Provider
+	name     string
+	recorder UsageRecorder
+}
+
+// NewUsageTracker wraps provider so each Chat call is recorded.
+func NewUsageTracker(provider Provider, name string, recorder UsageRecorder) *UsageTracker {
+	return &UsageTracker{
+		provider: provider,
+		name:     name,
+		recorder: recorder,
+	}
+}
+
+// Chat forwards the request and records its usage.
+func (t *UsageTracker) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	start := time.Now()
+	resp, err := t.provider.Chat(ctx, req)
+	if err != nil {
+		// The resolved model name isn't known on failure; label by tier
+		tier := req.Model
+		if tier == "" {
+			tier = ModelDefault
+		}
+		metrics.LLMRequestDuration.WithLabelValues(t.name, tier, "error").Observe(time.Since(start).Seconds())
+		return nil, err
+	}
+
+	metrics.LLMRequestDuration.WithLabelValues(t.name, resp.Model, "ok").Observe(time.Since(start).Seconds())
+	metrics.LLMTokens.WithLabelValues(t.name, resp.Model, "prompt").Add(float64(resp.TokensUsed.PromptTokens))
+	metrics.LLMTokens.WithLabelValues(t.name, resp.Model, "completion").Add(float64(resp.TokensUsed.CompletionTokens))
+
+	tags := TagsFromContext(ctx)
+	usage := &models.LLMUsage{
+		Provider:         t.name,
+		Model:            resp.Model,
+		ArticleType:      tags.ArticleType,
+		Job:              tags.Job,
+		PromptTokens:     resp.TokensUsed.PromptTokens,
+		CompletionTokens: resp.TokensUsed.CompletionTokens,
+		TotalTokens:      resp.TokensUsed.TotalTokens,
+		EstimatedCost:    EstimateCost(resp.Model, resp.TokensUsed),
+		LatencyMs:        time.Since(start).Milliseconds(),
+	}
+
+	// Record on a detached context so a request deadline that just expired
+	// doesn't lose the record of tokens we already paid for.
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := t.recorder.SaveLLMUsage(saveCtx, usage); err != nil {
+		log.Warn().Err(err).Msg("Failed to record LLM usage")
+	}
+
+	return resp, nil
+}
+
+// ChatJSON sends a chat request and parses the response as JSON.
+func (t *UsageTracker) ChatJSON(ctx context.Context, req ChatRequest, result interface{}) error {
+	return chatJSON(ctx, t, req, result)
+}
+
+// GenerateNarrative generates a narrative, recording usage of the underlying call.
+func (t *UsageTracker) GenerateNarrative(ctx context.Context, signal SignalData) (*Narrative, error) {
+	return GenerateNarrative(ctx, t, signal)
+}
@@ -0,0 +1,425 @@
+// Package metrics defines the Prometheus metrics exported by FutureSignals.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const namespace = "futuresignals"
+
+// ============================================================================
+// SYNC
+// ============================================================================
+
+var (
+	// SyncCycles counts market sync cycles by result (ok, error).
+	SyncCycles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "cycles_total",
+		Help:      "Market sync cycles by result.",
+	}, []string{"result"})
+
+	// SyncDuration observes the duration of a full sync cycle.
+	SyncDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "cycle_duration_seconds",
+		Help:      "Duration of a market sync cycle.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+	})
+
+	// SyncBatchSize observes the number of markets written per cycle.
+	SyncBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "batch_size",
+		Help:      "Markets written per bulk upsert.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 8),
+	})
+
+	// SyncBatchLatency observes the latency of a bulk upsert.
+	SyncBatchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "batch_latency_seconds",
+		Help:      "Latency of the per-cycle bulk market upsert.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// EventsEmitted counts market events by type.
+	EventsEmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "events_emitted_total",
+		Help:      "Market events emitted by type.",
+	}, []string{"type"})
+
+	// EventsDropped counts events dropped because a channel was full.
+	EventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "events_dropped_total",
+		Help:      "Market events dropped by stage (emit, dispatch).",
+	}, []string{"stage"})
+
+	// SubscriberEventsDropped counts events dropped from full subscriber
+	// queues, by subscriber and the type of the event dropped.
+	SubscriberEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "subscriber_events_dropped_total",
+		Help:      "Market events dropped from full subscriber queues by subscriber and event type.",
+	}, []string{"subscriber", "type"})
+
+	// EventsSuppressed counts events held back by their per-market cooldown.
+	EventsSuppressed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "events_suppressed_total",
+		Help:      "Market events suppressed by the per-market cooldown, by type.",
+	}, []string{"type"})
+)
+
+// ============================================================================
+// CONTENT
+// ============================================================================
+
+var (
+	// ArticlesGenerated counts saved articles by type.
+	ArticlesGenerated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "content",
+		Name:      "articles_generated_total",
+		Help:      "Articles generated by type.",
+	}, []string{"type"})
+
+	// ArticlesHeld counts articles saved as drafts by the quality gate, by
+	// type and failed check.
+	ArticlesHeld = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "content",
+		Name:      "articles_held_total",
+		Help:      "Articles held as drafts by failed quality check.",
+	}, []string{"type", "check"})
+)
+
+// ============================================================================
+// SCHEDULER
+// ============================================================================
+
+var (
+	// JobLocks counts scheduled job lock attempts by job and result:
+	// acquired, held (another instance runs it), takeover (of an instance
+	// that stopped renewing) or lost (renewal failed mid-run).
+	JobLocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_locks_total",
+		Help:      "Scheduled job lock attempts by result.",
+	}, []string{"job", "result"})
+
+	// JobRuns counts scheduled job attempts by job and status: succeeded,
+	// failed (retried) or dead (no retries left).
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_runs_total",
+		Help:      "Scheduled job attempts by status.",
+	}, []string{"job", "status"})
+)
+
+// ============================================================================
+// PUBLISHING
+// ============================================================================
+
+var (
+	// SocialPosts counts social publishing attempts by platform and result
+	// (ok, error, duplicate, stale, dropped).
+	SocialPosts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "publisher",
+		Name:      "posts_total",
+		Help:      "Social posts by platform and result.",
+	}, []string{"platform", "result"})
+)
+
+var (
+	// AlertsDelivered counts watchlist alert deliveries by result (ok, error,
+	// dropped).
+	AlertsDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "alerts",
+		Name:      "delivered_total",
+		Help:      "Watchlist alert deliveries by result.",
+	}, []string{"result"})
+
+	// ContentWebhooks counts content change notifications by result (ok,
+	// error, dropped), per endpoint delivery.
+	ContentWebhooks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhooks",
+		Name:      "deliveries_total",
+		Help:      "Content change notification deliveries by result.",
+	}, []string{"result"})
+
+	// NotificationsSent counts user notification deliveries by channel
+	// (email, telegram, webhook) and result (ok, error); undelivered ones
+	// are counted as queue/dropped and all/capped.
+	NotificationsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notify",
+		Name:      "sent_total",
+		Help:      "User notifications by channel and result.",
+	}, []string{"channel", "result"})
+
+	// AnalyticsEvents counts reader analytics events by type (view,
+	// read_depth, outbound_click) and result (written, error, dropped).
+	AnalyticsEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "analytics",
+		Name:      "events_total",
+		Help:      "Reader analytics events by type and result.",
+	}, []string{"type", "result"})
+
+	// AssetRequests counts asset proxy requests by result (cached, miss,
+	// not_found, error).
+	AssetRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "assets",
+		Name:      "requests_total",
+		Help:      "Asset proxy requests by result.",
+	}, []string{"result"})
+
+	// OGImagesRendered counts article share images by result (ok, error).
+	OGImagesRendered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "og",
+		Name:      "images_total",
+		Help:      "Article share images rendered by result.",
+	}, []string{"result"})
+)
+
+// ============================================================================
+// NEWSLETTER
+// ============================================================================
+
+var (
+	// NewsletterEmails counts newsletter emails by result (ok, error).
+	NewsletterEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "newsletter",
+		Name:      "emails_total",
+		Help:      "Newsletter emails sent by result.",
+	}, []string{"result"})
+)
+
+// ============================================================================
+// LLM
+// ============================================================================
+
+var (
+	// LLMRequestDuration observes LLM request latency.
+	LLMRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "request_duration_seconds",
+		Help:      "LLM request latency by provider, model and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10),
+	}, []string{"provider", "model", "result"})
+
+	// LLMTokens counts tokens by provider, model and kind (prompt, completion).
+	LLMTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "tokens_total",
+		Help:      "LLM tokens used by provider, model and kind.",
+	}, []string{"provider", "model", "kind"})
+
+	// LLMRetries counts LLM retries by provider and reason (retry,
+	// fallback, malformed_json).
+	LLMRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "retries_total",
+		Help:      "LLM request retries and model fallbacks by provider and reason.",
+	}, []string{"provider", "reason"})
+)
+
+// ============================================================================
+// ENRICHMENT
+// ============================================================================
+
+var (
+	// EnrichmentDuration observes enrichment source latency.
+	EnrichmentDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "enrichment",
+		Name:      "source_duration_seconds",
+		Help:      "Enrichment source latency by source and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+	}, []string{"source", "result"})
+
+	// EnrichmentCacheLookups counts enrichment cache lookups.
+	EnrichmentCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "enrichment",
+		Name:      "cache_lookups_total",
+		Help:      "Enrichment cache lookups by source and result (memory, mongo, miss).",
+	}, []string{"source", "result"})
+)
+
+// ============================================================================
+// POLYMARKET
+// ============================================================================
+
+var (
+	// PolymarketResponses counts Polymarket API responses by API (gamma,
+	// data, clob) and status class (2xx, 4xx, 429, 5xx, error).
+	PolymarketResponses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "polymarket",
+		Name:      "responses_total",
+		Help:      "Polymarket API responses by API and status class.",
+	}, []string{"api", "status"})
+
+	// PolymarketThrottleWait observes time spent waiting for rate limit
+	// tokens before a request.
+	PolymarketThrottleWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "polymarket",
+		Name:      "throttle_wait_seconds",
+		Help:      "Time spent waiting on the client-side rate limiter.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 10),
+	}, []string{"api"})
+
+	// PolymarketCircuitOpen is 1 while an API's circuit breaker is open.
+	PolymarketCircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "polymarket",
+		Name:      "circuit_open",
+		Help:      "Whether the circuit breaker for a Polymarket API is open.",
+	}, []string{"api"})
+)
+
+// ============================================================================
+// MONGODB
+// ============================================================================
+
+var (
+	// MongoOpDuration observes MongoDB command durations.
+	MongoOpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_duration_seconds",
+		Help:      "MongoDB command duration by command and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"command", "result"})
+)
+
+// MongoMonitor returns a command monitor that records MongoDB command durations.
+func MongoMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			MongoOpDuration.WithLabelValues(e.CommandName, "ok").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			MongoOpDuration.WithLabelValues(e.CommandName, "error").Observe(e.Duration.Seconds())
+		},
+	}
+}
+
+// ============================================================================
+// HTTP
+// ============================================================================
+
+var (
+	// HTTPRequests counts HTTP requests by method, route and status.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes HTTP request latency.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// RateLimited counts public API requests rejected by the rate limit.
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_total",
+		Help:      "Public API requests rejected by the rate limit, by bucket kind (ip, key).",
+	}, []string{"kind"})
+
+	// APICacheLookups counts API read cache lookups.
+	APICacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "cache_lookups_total",
+		Help:      "API read cache lookups by group and result (hit, miss, error).",
+	}, []string{"group", "result"})
+
+	// APICacheInvalidations counts API read cache group invalidations.
+	APICacheInvalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "cache_invalidations_total",
+		Help:      "API read cache invalidations by group.",
+	}, []string{"group"})
+)
+
+// Middleware records request count and latency per chi route pattern, so
+// /api/articles/{slug} is one series rather than one per slug.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// Handler returns the /metrics HTTP handler.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ResultLabel returns "ok" or "error" for an error value.
+func ResultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// Characters that break article URLs
+	badSlugChars = regexp.MustCompile(`[%$@#\+\[\]]`)
+	dashRuns     = regexp.MustCompile(`-+`)
+)
+
+// fixArticleSlugs strips URL-breaking characters from article slugs. An
+// article whose cleaned slug is taken keeps its old one.
+func fixArticleSlugs(ctx context.Context, db *mongo.Database) error {
+	articles := db.Collection("articles")
+	filter := bson.M{"slug": bson.M{"$regex": badSlugChars.String()}}
+	opts := options.Find().SetProjection(bson.M{"slug": 1})
+
+	cursor, err := articles.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("query articles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var article struct {
+			ID   primitive.ObjectID `bson:"_id"`
+			Slug string             `bson:"slug"`
+		}
+		if err := cursor.Decode(&article); err != nil {
+			return fmt.Errorf("decode article: %w", err)
+		}
+
+		slug := badSlugChars.ReplaceAllString(article.Slug, "")
+		slug = dashRuns.ReplaceAllString(slug, "-")
+		slug = strings.TrimRight(slug, "-")
+		if slug == article.Slug {
+			continue
+		}
+
+		_, err := articles.UpdateOne(ctx,
+			bson.M{"_id": article.ID},
+			bson.M{"$set": bson.M{
+				"slug":       slug,
+				"updated_at": time.Now(),
+			}},
+		)
+		if mongo.IsDuplicateKeyError(err) {
+			log.Warn().Str("old", article.Slug).Str("new", slug).Msg("Slug taken; keeping the old one")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("update article %s: %w", article.ID.Hex(), err)
+		}
+		log.Info().Str("old", article.Slug).Str("new", slug).Msg("Fixed slug")
+		updated++
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	log.Info().Int("updated", updated).Msg("Fixed article slugs")
+	return nil
+}
+
+// normalizeArticleTags rewrites the tags of articles saved before tags were
+// normalized on save, so tag pages and counts see one spelling of each.
+func normalizeArticleTags(ctx context.Context, db *mongo.Database) error {
+	articles := db.Collection("articles")
+	filter := bson.M{"tags.0": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"tags": 1})
+
+	cursor, err := articles.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("query articles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var article struct {
+			ID   primitive.ObjectID `bson:"_id"`
+			Tags []string           `bson:"tags"`
+		}
+		if err := cursor.Decode(&article); err != nil {
+			return fmt.Errorf("decode article: %w", err)
+		}
+
+		tags := models.NormalizeTags(article.Tags)
+		if slices.Equal(tags, article.Tags) {
+			continue
+		}
+
+		_, err := articles.UpdateOne(ctx,
+			bson.M{"_id": article.ID},
+			bson.M{"$set": bson.M{
+				"tags":       tags,
+				"updated_at": time.Now(),
+			}},
+		)
+		if err != nil {
+			return fmt.Errorf("update article %s: %w", article.ID.Hex(), err)
+		}
+		updated++
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	log.Info().Int("updated", updated).Msg("Normalized article tags")
+	return nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateIndexes builds the compound list indexes. The single-field ones
+// they cover are kept, as instances still on the previous release query
+// with them during a rollout; `fsctl indexes` drops them once it's done.
+func migrateIndexes(ctx context.Context, db *mongo.Database) error {
+	return storage.BuildIndexes(ctx, db)
+}
+
+// restoreIndexes brings back the single-field indexes, in case they were
+// dropped since, for the code rolled back to.
+func restoreIndexes(ctx context.Context, db *mongo.Database) error {
+	return storage.RestoreObsoleteIndexes(ctx, db)
+}
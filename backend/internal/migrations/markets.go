@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// parseMarketDates fills in start_date_ts and end_date_ts on markets
+// stored before the sync parsed them, from the stored date strings.
+func parseMarketDates(ctx context.Context, db *mongo.Database) error {
+	markets := db.Collection("markets")
+	filter := bson.M{
+		"market_id": bson.M{"$ne": ""},
+		"$or": []bson.M{
+			{"start_date_ts": nil},
+			{"end_date_ts": nil},
+		},
+	}
+	opts := options.Find().SetProjection(bson.M{
+		"market_id":     1,
+		"start_date":    1,
+		"end_date":      1,
+		"start_date_ts": 1,
+		"end_date_ts":   1,
+	})
+
+	cursor, err := markets.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("query markets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var m models.Market
+		if err := cursor.Decode(&m); err != nil {
+			log.Warn().Err(err).Msg("Skipping undecodable market")
+			continue
+		}
+
+		set := bson.M{}
+		if start := models.ParseMarketDate(m.StartDate); start != nil && !sameTime(start, m.StartDateTS) {
+			set["start_date_ts"] = start
+		}
+		if end := models.ParseMarketDate(m.EndDate); end != nil && !sameTime(end, m.EndDateTS) {
+			set["end_date_ts"] = end
+		}
+		if len(set) == 0 {
+			continue
+		}
+
+		if _, err := markets.UpdateOne(ctx, bson.M{"market_id": m.MarketID}, bson.M{"$set": set}); err != nil {
+			return fmt.Errorf("update market %s: %w", m.MarketID, err)
+		}
+		updated++
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	log.Info().Int("updated", updated).Msg("Parsed market dates")
+	return nil
+}
+
+// sameTime reports whether a and b are both nil or the same instant.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
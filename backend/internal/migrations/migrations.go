@@ -0,0 +1,285 @@
+// Package migrations evolves the MongoDB schema through versioned
+// migrations, recording the applied ones in the schema_migrations
+// collection.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// recordsCollection holds one document per applied migration, plus
+	// the lock.
+	recordsCollection = "schema_migrations"
+
+	// lockID is the _id of the lock document taken while migrating.
+	lockID = "lock"
+
+	// lockTTL is how long a lock is honoured; one older than this was
+	// left by a process that died mid-run and is taken over.
+	lockTTL = 30 * time.Minute
+
+	// lockRefresh is how often a running migration refreshes its lock, so
+	// one that takes longer than lockTTL isn't taken over.
+	lockRefresh = lockTTL / 6
+)
+
+var (
+	// ErrIrreversible is returned when rolling back a migration without
+	// a Down function.
+	ErrIrreversible = errors.New("migration can't be rolled back")
+
+	// ErrLocked is returned while another process is migrating.
+	ErrLocked = errors.New("another process is migrating")
+)
+
+// Migration is one schema change. Up applies it and Down reverts it; Down
+// is nil for data fixes that can't be undone. Both must be safe to rerun,
+// since a run that fails midway is retried from the start.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record is the stored trace of an applied migration.
+type Record struct {
+	Version   int       `bson:"_id" json:"version"`
+	Name      string    `bson:"name" json:"name"`
+	AppliedAt time.Time `bson:"applied_at" json:"applied_at"`
+	TookMs    int64     `bson:"took_ms" json:"took_ms"`
+}
+
+// Status is a migration and, if it has been applied, when.
+type Status struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator applies and rolls back migrations on a database.
+type Migrator struct {
+	db         *mongo.Database
+	records    *mongo.Collection
+	migrations []Migration
+	owner      string
+}
+
+// New creates a migrator for db with the registered migrations.
+func New(db *mongo.Database) *Migrator {
+	return newMigrator(db, registry)
+}
+
+func newMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	owner, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		records:    db.Collection(recordsCollection),
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s/%d", owner, os.Getpid()),
+	}
+}
+
+// Status lists every migration, oldest first, with when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		statuses[i] = Status{Version: mig.Version, Name: mig.Name}
+		if r, ok := applied[mig.Version]; ok {
+			statuses[i].AppliedAt = &r.AppliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations not applied yet, oldest first.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations in version order, stopping at the
+// first failure, and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+	defer m.heartbeat(ctx)()
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range pending {
+		log.Info().Int("version", mig.Version).Str("name", mig.Name).Msg("Applying migration")
+
+		start := time.Now()
+		if err := mig.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d %s: %w", mig.Version, mig.Name, err)
+		}
+		record := Record{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			AppliedAt: time.Now(),
+			TookMs:    time.Since(start).Milliseconds(),
+		}
+		if _, err := m.records.InsertOne(ctx, record); err != nil {
+			return done, fmt.Errorf("record migration %d: %w", mig.Version, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down rolls back the most recently applied migration and returns it, or
+// nil if none is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+	defer m.heartbeat(ctx)()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if mig.Down == nil {
+			return &mig, fmt.Errorf("migration %d %s: %w", mig.Version, mig.Name, ErrIrreversible)
+		}
+
+		log.Info().Int("version", mig.Version).Str("name", mig.Name).Msg("Rolling back migration")
+		if err := mig.Down(ctx, m.db); err != nil {
+			return &mig, fmt.Errorf("migration %d %s: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := m.records.DeleteOne(ctx, bson.M{"_id": mig.Version}); err != nil {
+			return &mig, fmt.Errorf("unrecord migration %d: %w", mig.Version, err)
+		}
+		return &mig, nil
+	}
+	return nil, nil
+}
+
+// applied returns the records of applied migrations by version.
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	cursor, err := m.records.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, fmt.Errorf("query applied migrations: %w", err)
+	}
+
+	var records []Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("decode applied migrations: %w", err)
+	}
+
+	byVersion := make(map[int]Record, len(records))
+	for _, r := range records {
+		byVersion[r.Version] = r
+	}
+	return byVersion, nil
+}
+
+// lock takes the migration lock, or returns ErrLocked if another process
+// holds it. A lock older than lockTTL is taken over.
+func (m *Migrator) lock(ctx context.Context) error {
+	now := time.Now()
+	filter := bson.M{
+		"_id": lockID,
+		"$or": []bson.M{
+			{"owner": m.owner},
+			{"acquired_at": bson.M{"$lt": now.Add(-lockTTL)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": m.owner, "acquired_at": now}}
+
+	_, err := m.records.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// The lock exists and isn't ours or stale
+		return ErrLocked
+	}
+	if err != nil {
+		return fmt.Errorf("take migration lock: %w", err)
+	}
+	return nil
+}
+
+// heartbeat refreshes the lock every lockRefresh until the returned stop
+// function is called.
+func (m *Migrator) heartbeat(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lockRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := m.records.UpdateOne(ctx,
+					bson.M{"_id": lockID, "owner": m.owner},
+					bson.M{"$set": bson.M{"acquired_at": time.Now()}},
+				)
+				if err != nil {
+					log.Warn().Err(err).Msg("Failed to refresh migration lock")
+				} else if result.MatchedCount == 0 {
+					log.Warn().Msg("Migration lock was taken over by another process")
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// unlock releases the lock, even if the run's context is done.
+func (m *Migrator) unlock() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := m.records.DeleteOne(ctx, bson.M{"_id": lockID, "owner": m.owner}); err != nil {
+		log.Warn().Err(err).Msg("Failed to release migration lock")
+	}
+}
@@ -0,0 +1,37 @@
+package migrations
+
+// registry is every migration, in the order they were written. Versions
+// are never reused or renumbered once released.
+var registry = []Migration{
+	{
+		Version: 1,
+		Name:    "market-date-timestamps",
+		Up:      parseMarketDates,
+	},
+	{
+		Version: 2,
+		Name:    "article-slug-characters",
+		Up:      fixArticleSlugs,
+	},
+	{
+		Version: 3,
+		Name:    "compound-list-indexes",
+		Up:      migrateIndexes,
+		Down:    restoreIndexes,
+	},
+	{
+		Version: 4,
+		Name:    "snapshot-rollups",
+		Up:      backfillSnapshotRollups,
+	},
+	{
+		Version: 5,
+		Name:    "article-tag-slugs",
+		Up:      normalizeArticleTags,
+	},
+	{
+		Version: 6,
+		Name:    "drop-volume-multiplier",
+		Up:      dropVolumeMultiplier,
+	},
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// dropVolumeMultiplier removes the volume_multiplier runtime setting,
+// which volume_z_score replaced when volume spikes moved to a z-score
+// against each market's baseline. A multiple of the last sync's volume
+// doesn't translate to a z-score, so the stored value is dropped rather
+// than mapped, and spikes use the configured or stored volume_z_score.
+func dropVolumeMultiplier(ctx context.Context, db *mongo.Database) error {
+	var stored bson.M
+	err := db.Collection("settings").FindOneAndUpdate(ctx,
+		bson.M{"_id": models.RuntimeSettingsID, "volume_multiplier": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"volume_multiplier": ""}},
+	).Decode(&stored)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("update settings: %w", err)
+	}
+
+	log.Warn().
+		Interface("volume_multiplier", stored["volume_multiplier"]).
+		Msg("Dropped the volume_multiplier runtime setting; set volume_z_score instead")
+	return nil
+}
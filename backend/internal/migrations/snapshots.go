@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// backfillSnapshotRollups rolls up the snapshot history the rollup job
+// never saw, such as snapshots migrated from the legacy collection, so
+// their odds history and sparklines aren't empty.
+func backfillSnapshotRollups(ctx context.Context, db *mongo.Database) error {
+	windows, err := storage.BackfillSnapshotRollups(ctx, db)
+	if err != nil {
+		return err
+	}
+	log.Info().Int("weeks", windows).Msg("Backfilled snapshot rollups")
+	return nil
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// AccuracyCategoryAll is the category of scores computed over every category.
+const AccuracyCategoryAll = "all"
+
+// AccuracyScore measures how well market prices forecast resolved outcomes
+// for one category at one horizon before resolution.
+type AccuracyScore struct {
+	// "<category>:<horizon>", e.g. "politics:7d"
+	ID string `bson:"_id" json:"-"`
+
+	Category     string `bson:"category" json:"category"`
+	Horizon      string `bson:"horizon" json:"horizon"`
+	HorizonHours int    `bson:"horizon_hours" json:"horizon_hours"`
+
+	// Resolved markets with a price at the horizon
+	Markets int `bson:"markets" json:"markets"`
+
+	// Mean squared error of the forecast (0 is perfect, 0.25 is a coin flip)
+	BrierScore float64 `bson:"brier_score" json:"brier_score"`
+
+	// Mean negative log-likelihood of the outcome
+	LogLoss float64 `bson:"log_loss" json:"log_loss"`
+
+	Calibration []CalibrationBucket `bson:"calibration" json:"calibration"`
+
+	ComputedAt time.Time `bson:"computed_at" json:"computed_at"`
+}
+
+// CalibrationBucket compares forecasts in a probability range with how often
+// those markets actually resolved Yes.
+type CalibrationBucket struct {
+	Lower    float64 `bson:"lower" json:"lower"`
+	Upper    float64 `bson:"upper" json:"upper"`
+	Count    int     `bson:"count" json:"count"`
+	Forecast float64 `bson:"forecast" json:"forecast"` // Mean forecast in the bucket
+	Observed float64 `bson:"observed" json:"observed"` // Share that resolved Yes
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnalyticsEventType is the kind of reader interaction recorded.
+type AnalyticsEventType string
+
+const (
+	// AnalyticsView is an article page view.
+	AnalyticsView AnalyticsEventType = "view"
+
+	// AnalyticsReadDepth is a ping sent as the reader scrolls through an
+	// article, carrying how far they got.
+	AnalyticsReadDepth AnalyticsEventType = "read_depth"
+
+	// AnalyticsOutboundClick is a click from an article through to a
+	// market on Polymarket.
+	AnalyticsOutboundClick AnalyticsEventType = "outbound_click"
+
+	// AnalyticsImpression is an article listed in a feed under a headline
+	// test; views of tested articles count as its clicks.
+	AnalyticsImpression AnalyticsEventType = "impression"
+)
+
+// AnalyticsEventRetention is how long raw analytics events are kept; daily
+// rollups are kept indefinitely.
+const AnalyticsEventRetention = 30 * 24 * time.Hour
+
+// ReadThroughDepth is the read depth, in percent, at which an article
+// counts as read through.
+const ReadThroughDepth = 75
+
+// AnalyticsEvent is a single reader interaction with an article.
+type AnalyticsEvent struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Type      AnalyticsEventType `bson:"type" json:"type"`
+	ArticleID primitive.ObjectID `bson:"article_id" json:"article_id"`
+	Slug      string             `bson:"slug" json:"slug"`
+	Category  string             `bson:"category" json:"category"`
+	MarketID  string             `bson:"market_id,omitempty" json:"market_id,omitempty"` // Outbound clicks
+	Depth     int                `bson:"depth,omitempty" json:"depth,omitempty"`         // Read depth, percent
+	Variant   int                `bson:"variant,omitempty" json:"variant,omitempty"`     // Headline variant served, 1-based
+	Session   string             `bson:"session,omitempty" json:"-"`                     // Hashed reader session, for read depth
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// DailyTraffic is the site-wide totals for one UTC day.
+type DailyTraffic struct {
+	Date         time.Time `bson:"_id" json:"date"`
+	Views        int       `bson:"views" json:"views"`
+	ReadThroughs int       `bson:"read_throughs" json:"read_throughs"`
+	Clicks       int       `bson:"clicks" json:"clicks"`
+}
+
+// ArticleAnalytics is an article's totals over a reporting window.
+type ArticleAnalytics struct {
+	ArticleID    primitive.ObjectID `bson:"_id" json:"article_id"`
+	Slug         string             `bson:"slug" json:"slug"`
+	Category     string             `bson:"category" json:"category"`
+	Views        int                `bson:"views" json:"views"`
+	ReadThroughs int                `bson:"read_throughs" json:"read_throughs"`
+	Clicks       int                `bson:"clicks" json:"clicks"`
+	CTR          float64            `bson:"-" json:"ctr"` // Clicks per view
+}
+
+// CategoryTraffic is a category's totals over a reporting window.
+type CategoryTraffic struct {
+	Category string  `bson:"_id" json:"category"`
+	Views    int     `bson:"views" json:"views"`
+	Clicks   int     `bson:"clicks" json:"clicks"`
+	Articles int     `bson:"articles" json:"articles"`
+	CTR      float64 `bson:"-" json:"ctr"`
+}
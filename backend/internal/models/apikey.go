@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// API key scopes. ScopeAdmin implies every other scope.
+const (
+	ScopeAdmin = "admin"
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// ValidScopes lists the scopes an API key may be granted.
+var ValidScopes = []string{ScopeAdmin, ScopeRead, ScopeWrite}
+
+// APIKey is an API credential. Only the SHA-256 hash of the key is stored;
+// the plaintext is shown once at creation.
+type APIKey struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Name   string `bson:"name" json:"name"`
+	Prefix string `bson:"prefix" json:"prefix"` // first characters, for identifying keys in lists
+	Hash   string `bson:"hash" json:"-"`
+
+	Scopes []string `bson:"scopes" json:"scopes"`
+
+	// Requests per minute; 0 uses the server default
+	RateLimit int `bson:"rate_limit" json:"rate_limit"`
+
+	CreatedAt  time.Time  `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// HasScope reports whether the key grants scope.
+func (k *APIKey) HasScope(scope string) bool {
+	return HasScope(k.Scopes, scope)
+}
+
+// HasScope reports whether scopes grants scope, treating admin as a superset.
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -33,6 +36,15 @@ const (
 
 	// ArticleTypeSocialSignal represents articles triggered by influencer posts.
 	ArticleTypeSocialSignal ArticleType = "social_signal"
+
+	// ArticleTypeUpdate represents a "Market Update" follow-up to an earlier article.
+	ArticleTypeUpdate ArticleType = "update"
+
+	// ArticleTypeResolution represents a wrap-up of a market that has resolved.
+	ArticleTypeResolution ArticleType = "resolution"
+
+	// ArticleTypeWeekAhead represents a preview of the coming week's deadlines and catalysts.
+	ArticleTypeWeekAhead ArticleType = "week_ahead"
 )
 
 // Significance represents the importance level of an article.
@@ -45,6 +57,20 @@ const (
 	SignificanceBreaking Significance = "breaking"
 )
 
+// Rank orders significance levels from low (0) to breaking (3).
+func (s Significance) Rank() int {
+	switch s {
+	case SignificanceBreaking:
+		return 3
+	case SignificanceHigh:
+		return 2
+	case SignificanceMedium:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // Article represents a generated article/news piece.
 type Article struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -62,6 +88,10 @@ type Article struct {
 	Summary     string      `bson:"summary" json:"summary"`
 	Body        ArticleBody `bson:"body" json:"body"`
 
+	// Condensed versions for social posts, chat channels and push
+	// notifications, written once after generation
+	ShortForm *ShortForm `bson:"short_form,omitempty" json:"short_form,omitempty"`
+
 	// Related Markets
 	Markets       []MarketRef `bson:"markets" json:"markets"`
 	PrimaryMarket *MarketRef  `bson:"primary_market,omitempty" json:"primary_market,omitempty"`
@@ -80,6 +110,7 @@ type Article struct {
 	MetaTitle       string `bson:"meta_title" json:"meta_title"`
 	MetaDescription string `bson:"meta_description" json:"meta_description"`
 	CanonicalURL    string `bson:"canonical_url,omitempty" json:"canonical_url,omitempty"`
+	OGImageURL      string `bson:"og_image_url,omitempty" json:"og_image_url,omitempty"` // Rendered share image
 
 	// Stats
 	Views int `bson:"views" json:"views"`
@@ -88,11 +119,108 @@ type Article struct {
 	Published bool `bson:"published" json:"published"`
 	Featured  bool `bson:"featured" json:"featured"`
 
+	// When an admin deleted the article; deleted articles are unpublished
+	// and no longer served, but can be restored
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+
+	// When an admin took the article off the site while it was published;
+	// unset for drafts that were never live, which restore leaves as drafts
+	UnpublishedAt *time.Time `bson:"unpublished_at,omitempty" json:"unpublished_at,omitempty"`
+
+	// Figures checked against the data the article was generated from
+	FactCheck *FactCheck `bson:"fact_check,omitempty" json:"fact_check,omitempty"`
+
+	// Quality checks the article failed when it was held back as a draft
+	QualityIssues []string `bson:"quality_issues,omitempty" json:"quality_issues,omitempty"`
+
 	// Enrichment sources used
 	EnrichmentSources []string `bson:"enrichment_sources,omitempty" json:"enrichment_sources,omitempty"`
 
 	// Social signals from tracked influencers
 	SocialSignals []SocialSignal `bson:"social_signals,omitempty" json:"social_signals,omitempty"`
+
+	// Amendments appended when the primary market moves again shortly after publication
+	Amendments []ArticleAmendment `bson:"amendments,omitempty" json:"amendments,omitempty"`
+
+	// Follow-up chain: the article this one follows, and follow-ups written after it
+	PreviousArticleID *primitive.ObjectID  `bson:"previous_article_id,omitempty" json:"previous_article_id,omitempty"`
+	Updates           []primitive.ObjectID `bson:"updates,omitempty" json:"updates,omitempty"`
+
+	// Headline A/B test; readers are served one of the variants until a
+	// winner is promoted to Headline
+	HeadlineTest *HeadlineTest `bson:"headline_test,omitempty" json:"-"`
+}
+
+// ShortForm holds an article's short-form variants.
+type ShortForm struct {
+	Social string   `bson:"social" json:"social"` // At most 280 characters
+	TLDR   []string `bson:"tldr" json:"tldr"`     // Up to 3 one-sentence bullets
+	Push   string   `bson:"push" json:"push"`     // One line
+}
+
+// HeadlineTest compares alternative headlines for an article. Variants[0]
+// is the original headline.
+type HeadlineTest struct {
+	Variants    []HeadlineVariant `bson:"variants" json:"variants"`
+	StartedAt   time.Time         `bson:"started_at" json:"started_at"`
+	EvaluatedAt *time.Time        `bson:"evaluated_at,omitempty" json:"evaluated_at,omitempty"`
+	DecidedAt   *time.Time        `bson:"decided_at,omitempty" json:"decided_at,omitempty"`
+	Winner      *int              `bson:"winner,omitempty" json:"winner,omitempty"` // Index into Variants
+}
+
+// Running reports whether the test is still serving variants.
+func (t *HeadlineTest) Running() bool {
+	return t != nil && t.DecidedAt == nil && len(t.Variants) > 1
+}
+
+// HeadlineVariant is one headline under test with its counts as of the
+// last evaluation.
+type HeadlineVariant struct {
+	Headline    string  `bson:"headline" json:"headline"`
+	Impressions int     `bson:"impressions" json:"impressions"`
+	Clicks      int     `bson:"clicks" json:"clicks"`
+	CTR         float64 `bson:"ctr" json:"ctr"`
+}
+
+// HeadlineVariantCount is a variant's impressions and clicks from the
+// analytics events.
+type HeadlineVariantCount struct {
+	ArticleID   primitive.ObjectID `bson:"article_id"`
+	Variant     int                `bson:"variant"` // 1-based
+	Impressions int                `bson:"impressions"`
+	Clicks      int                `bson:"clicks"`
+}
+
+// ArticleLink is a lightweight reference to an article in a follow-up chain.
+type ArticleLink struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Slug        string             `bson:"slug" json:"slug"`
+	Type        ArticleType        `bson:"type" json:"type"`
+	Headline    string             `bson:"headline" json:"headline"`
+	PublishedAt time.Time          `bson:"published_at" json:"published_at"`
+	Current     bool               `bson:"-" json:"current"`
+}
+
+// RelatedArticle is an article recommended alongside another.
+type RelatedArticle struct {
+	ID          primitive.ObjectID `json:"id"`
+	Slug        string             `json:"slug"`
+	Type        ArticleType        `json:"type"`
+	Category    string             `json:"category"`
+	Headline    string             `json:"headline"`
+	Summary     string             `json:"summary"`
+	OGImageURL  string             `json:"og_image_url,omitempty"`
+	PublishedAt time.Time          `json:"published_at"`
+	Score       float64            `json:"score"`
+}
+
+// ArticleAmendment is an in-place update to a published article.
+type ArticleAmendment struct {
+	Headline     string    `bson:"headline" json:"headline"`
+	WhatChanged  string    `bson:"what_changed" json:"what_changed"`
+	PreviousProb float64   `bson:"previous_prob" json:"previous_prob"`
+	Probability  float64   `bson:"probability" json:"probability"`
+	AmendedAt    time.Time `bson:"amended_at" json:"amended_at"`
 }
 
 // ArticleBody contains the main content sections.
@@ -102,6 +230,106 @@ type ArticleBody struct {
 	Context      []string `bson:"context" json:"context"`
 	WhatToWatch  string   `bson:"what_to_watch" json:"what_to_watch"`
 	Analysis     string   `bson:"analysis,omitempty" json:"analysis,omitempty"`
+
+	// Primary market data as of generation, for charts in the article
+	MarketData *MarketDataBlock `bson:"market_data,omitempty" json:"market_data,omitempty"`
+
+	// Biggest 24h gainers and losers, for movers reports
+	Movers *MoversTable `bson:"movers,omitempty" json:"movers,omitempty"`
+
+	// Markets ending each day of the coming week, for week-ahead previews
+	WeekAhead []WeekAheadDay `bson:"week_ahead,omitempty" json:"week_ahead,omitempty"`
+
+	// Events whose outcomes' odds don't sum to 100%, for mispricing watches
+	Mispricings []Mispricing `bson:"mispricings,omitempty" json:"mispricings,omitempty"`
+}
+
+// Mispricing is an event with mutually exclusive outcomes whose odds, as
+// synced, don't sum to 100%.
+type Mispricing struct {
+	EventID string `bson:"event_id" json:"event_id"`
+	Title   string `bson:"title" json:"title"`
+	URL     string `bson:"url" json:"url"`
+
+	// Sum of the open outcomes' probabilities, 1 when consistent
+	Sum float64 `bson:"sum" json:"sum"`
+
+	// Partial is set when some outcomes are too small to be synced, so Sum
+	// understates the true total
+	Partial bool `bson:"partial,omitempty" json:"partial,omitempty"`
+
+	// Open outcomes, most likely first
+	Markets []MarketRef `bson:"markets" json:"markets"`
+}
+
+// Gap returns how far the outcomes' odds sum above (positive) or below
+// 100%.
+func (m Mispricing) Gap() float64 {
+	return m.Sum - 1
+}
+
+// WeekAheadDay lists the markets ending on one day of a week-ahead
+// preview.
+type WeekAheadDay struct {
+	Date    string      `bson:"date" json:"date"` // YYYY-MM-DD, UTC
+	Markets []MarketRef `bson:"markets" json:"markets"`
+}
+
+// MoversTable lists the markets whose odds rose and fell most over 24h,
+// as synced when a movers report is generated.
+type MoversTable struct {
+	Gainers []MarketRef `bson:"gainers" json:"gainers"`
+	Losers  []MarketRef `bson:"losers" json:"losers"`
+}
+
+// MarketDataBlock is a machine-readable snapshot of an article's primary
+// market, computed when the article is generated.
+type MarketDataBlock struct {
+	MarketID    string    `bson:"market_id" json:"market_id"`
+	GeneratedAt time.Time `bson:"generated_at" json:"generated_at"`
+
+	// Probability history, oldest first
+	History []ProbabilityPoint `bson:"history" json:"history"`
+
+	Volume   VolumeBreakdown `bson:"volume" json:"volume"`
+	KeyDates []KeyDate       `bson:"key_dates,omitempty" json:"key_dates,omitempty"`
+}
+
+// ProbabilityPoint is the market's probability at a point in time.
+type ProbabilityPoint struct {
+	Time        time.Time `bson:"time" json:"time"`
+	Probability float64   `bson:"probability" json:"probability"`
+	Volume24h   float64   `bson:"volume_24h" json:"volume_24h"`
+}
+
+// VolumeBreakdown is trading volume in USD over several windows.
+type VolumeBreakdown struct {
+	Volume1h    float64 `bson:"volume_1h" json:"volume_1h"`
+	Volume24h   float64 `bson:"volume_24h" json:"volume_24h"`
+	Volume7d    float64 `bson:"volume_7d" json:"volume_7d"`
+	TotalVolume float64 `bson:"total_volume" json:"total_volume"`
+	Liquidity   float64 `bson:"liquidity" json:"liquidity"`
+
+	// Last 24h trade flow split, when trades are tracked for the market
+	BuyVolume24h  float64 `bson:"buy_volume_24h,omitempty" json:"buy_volume_24h,omitempty"`
+	SellVolume24h float64 `bson:"sell_volume_24h,omitempty" json:"sell_volume_24h,omitempty"`
+}
+
+// Key date labels.
+const (
+	KeyDateOpened   = "opened"
+	KeyDateTracked  = "tracked"
+	KeyDatePeak     = "peak"
+	KeyDateTrough   = "trough"
+	KeyDateCloses   = "closes"
+	KeyDateResolved = "resolved"
+)
+
+// KeyDate is a labelled date in the market's life, e.g. when it opened or
+// closes.
+type KeyDate struct {
+	Label string    `bson:"label" json:"label"`
+	Date  time.Time `bson:"date" json:"date"`
 }
 
 // MarketRef references a market within an article.
@@ -119,13 +347,14 @@ type MarketRef struct {
 
 // SocialSignal represents a correlated social signal with market impact.
 type SocialSignal struct {
-	// User info
+	// User info; Platform is empty for X
+	Platform  string `bson:"platform,omitempty" json:"platform,omitempty"`
 	Handle    string `bson:"handle" json:"handle"`
 	Name      string `bson:"name" json:"name"`
 	AvatarURL string `bson:"avatar_url" json:"avatar_url"`
 	Verified  bool   `bson:"verified" json:"verified"`
 
-	// Post content
+	// Post content; TweetURL is the post's URL on any platform
 	Content  string    `bson:"content" json:"content"`
 	TweetURL string    `bson:"tweet_url" json:"tweet_url"`
 	PostedAt time.Time `bson:"posted_at" json:"posted_at"`
@@ -139,12 +368,16 @@ type SocialSignal struct {
 	MarketImpact float64 `bson:"market_impact" json:"market_impact"`
 	ImpactWindow string  `bson:"impact_window" json:"impact_window"`
 
+	// Ranking weight of the account, from its tracked account entry
+	Weight float64 `bson:"weight,omitempty" json:"weight,omitempty"`
+
 	// Affected markets
 	AffectedMarkets []MarketMovement `bson:"affected_markets,omitempty" json:"affected_markets,omitempty"`
 }
 
 // MarketMovement represents a market that moved after a social signal.
 type MarketMovement struct {
+	MarketID    string  `bson:"market_id,omitempty" json:"market_id,omitempty"`
 	MarketSlug  string  `bson:"market_slug" json:"market_slug"`
 	MarketTitle string  `bson:"market_title" json:"market_title"`
 	Category    string  `bson:"category" json:"category"`
@@ -164,16 +397,52 @@ const (
 	BriefingWeekly  BriefingType = "weekly"
 )
 
-// BriefingConfig holds configuration for briefing generation.
+// BriefingConfig holds configuration for briefing generation. Configs are
+// stored in MongoDB and editable at runtime; the scheduler runs each enabled
+// briefing daily at Hour:Minute UTC, or only on Days (0 = Sunday) if set.
 type BriefingConfig struct {
-	Type           BriefingType
-	Title          string
-	MarketsPerCat  int
-	Categories     []string
-	IncludeSummary bool
+	Type           BriefingType `bson:"_id" json:"type"`
+	Title          string       `bson:"title" json:"title"`
+	MarketsPerCat  int          `bson:"markets_per_category" json:"markets_per_category"`
+	Categories     []string     `bson:"categories" json:"categories"`
+	IncludeSummary bool         `bson:"include_summary" json:"include_summary"`
+
+	// Schedule
+	Enabled bool  `bson:"enabled" json:"enabled"`
+	Hour    int   `bson:"hour" json:"hour"`
+	Minute  int   `bson:"minute" json:"minute"`
+	Days    []int `bson:"days,omitempty" json:"days,omitempty"`
+
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// briefingTypePattern matches briefing types, which end up in article slugs.
+var briefingTypePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Validate checks the config can be scheduled and generated.
+func (c *BriefingConfig) Validate() error {
+	switch {
+	case !briefingTypePattern.MatchString(string(c.Type)):
+		return errors.New("type must be lowercase letters, digits and dashes")
+	case strings.TrimSpace(c.Title) == "":
+		return errors.New("title is required")
+	case c.MarketsPerCat < 1 || c.MarketsPerCat > 20:
+		return errors.New("markets_per_category must be between 1 and 20")
+	case len(c.Categories) == 0:
+		return errors.New("at least one category is required")
+	case c.Hour < 0 || c.Hour > 23 || c.Minute < 0 || c.Minute > 59:
+		return errors.New("hour must be 0-23 and minute 0-59")
+	}
+	for _, d := range c.Days {
+		if d < 0 || d > 6 {
+			return errors.New("days must be 0 (Sunday) to 6 (Saturday)")
+		}
+	}
+	return nil
 }
 
-// DefaultBriefingConfigs returns the default briefing configurations.
+// DefaultBriefingConfigs are the briefing configurations seeded into an
+// empty database.
 var DefaultBriefingConfigs = map[BriefingType]BriefingConfig{
 	BriefingMorning: {
 		Type:           BriefingMorning,
@@ -181,6 +450,8 @@ var DefaultBriefingConfigs = map[BriefingType]BriefingConfig{
 		MarketsPerCat:  3,
 		Categories:     []string{"politics", "crypto", "finance", "tech", "sports"},
 		IncludeSummary: true,
+		Enabled:        true,
+		Hour:           8,
 	},
 	BriefingMidday: {
 		Type:           BriefingMidday,
@@ -188,6 +459,8 @@ var DefaultBriefingConfigs = map[BriefingType]BriefingConfig{
 		MarketsPerCat:  2,
 		Categories:     []string{"politics", "crypto", "finance"},
 		IncludeSummary: false,
+		Enabled:        true,
+		Hour:           12,
 	},
 	BriefingEvening: {
 		Type:           BriefingEvening,
@@ -195,6 +468,8 @@ var DefaultBriefingConfigs = map[BriefingType]BriefingConfig{
 		MarketsPerCat:  3,
 		Categories:     []string{"politics", "crypto", "finance", "tech", "sports"},
 		IncludeSummary: true,
+		Enabled:        true,
+		Hour:           18,
 	},
 	BriefingWeekly: {
 		Type:           BriefingWeekly,
@@ -202,5 +477,8 @@ var DefaultBriefingConfigs = map[BriefingType]BriefingConfig{
 		MarketsPerCat:  5,
 		Categories:     []string{"politics", "crypto", "finance", "tech", "sports", "geopolitics"},
 		IncludeSummary: true,
+		Enabled:        true,
+		Hour:           10,
+		Days:           []int{int(time.Monday)},
 	},
 }
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Asset is a remote image served through the asset proxy. Its ID is
+// AssetHash of the source URL, so the public path reveals nothing about
+// where the image came from.
+type Asset struct {
+	Hash      string    `bson:"_id" json:"hash"`
+	URL       string    `bson:"url" json:"url"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// AssetHash is the asset id for a source URL.
+func AssetHash(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:16])
+}
@@ -1,16 +1,47 @@
 // Package models defines the core data structures for FutureSignals.
 package models
 
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
 // Category represents a content category.
 type Category struct {
-	ID          string `bson:"_id" json:"id"`
-	Slug        string `bson:"slug" json:"slug"`
-	Name        string `bson:"name" json:"name"`
-	Description string `bson:"description" json:"description"`
-	Icon        string `bson:"icon" json:"icon"`
-	Color       string `bson:"color" json:"color"`
-	Order       int    `bson:"order" json:"order"`
-	Dynamic     bool   `bson:"dynamic" json:"dynamic"` // trending, breaking, new are dynamic
+	ID          string   `bson:"_id" json:"id"`
+	Slug        string   `bson:"slug" json:"slug"`
+	Name        string   `bson:"name" json:"name"`
+	Description string   `bson:"description" json:"description"`
+	Icon        string   `bson:"icon" json:"icon"`
+	Color       string   `bson:"color" json:"color"`
+	Order       int      `bson:"order" json:"order"`
+	Dynamic     bool     `bson:"dynamic" json:"dynamic"`                       // trending, breaking, new are dynamic
+	Keywords    []string `bson:"keywords,omitempty" json:"keywords,omitempty"` // Lowercase phrases matched against market questions
+}
+
+// CategoryProvider supplies the category taxonomy, ordered by Order.
+type CategoryProvider interface {
+	Categories() []Category
+}
+
+var categoryProvider atomic.Pointer[CategoryProvider]
+
+// SetCategoryProvider installs the taxonomy used by DetectCategory and the
+// category lookups. Until one is set, or while it returns nothing, the
+// built-in SeedCategories are used.
+func SetCategoryProvider(p CategoryProvider) {
+	categoryProvider.Store(&p)
+}
+
+// Taxonomy returns the current categories, ordered by Order.
+func Taxonomy() []Category {
+	if p := categoryProvider.Load(); p != nil && *p != nil {
+		if categories := (*p).Categories(); len(categories) > 0 {
+			return categories
+		}
+	}
+	return seedCategories
 }
 
 // DefaultCategories mirrors Polymarket's category structure.
@@ -34,7 +65,8 @@ var DefaultCategories = []Category{
 	{Slug: "culture", Name: "Culture", Description: "Pop culture and entertainment", Icon: "movie", Color: "#E84393", Order: 60},
 }
 
-// CategoryKeywords maps keywords to categories for auto-detection.
+// CategoryKeywords holds the built-in keyword lists for auto-detection.
+// They seed the categories collection; edits made at runtime live there.
 var CategoryKeywords = map[string][]string{
 	"politics": {
 		"president", "congress", "senate", "house", "vote", "trump", "biden",
@@ -89,9 +121,39 @@ var CategoryKeywords = map[string][]string{
 	},
 }
 
+var seedCategories = SeedCategories()
+
+// SeedCategories returns DefaultCategories with their CategoryKeywords,
+// keyed by slug, ready to be written to the categories collection.
+func SeedCategories() []Category {
+	categories := make([]Category, len(DefaultCategories))
+	for i, cat := range DefaultCategories {
+		cat.ID = cat.Slug
+		cat.Keywords = CategoryKeywords[cat.Slug]
+		categories[i] = cat
+	}
+	return categories
+}
+
+// NormalizeKeywords lowercases and trims keywords, dropping blanks and
+// duplicates, so they match DetectCategory's lowercased questions.
+func NormalizeKeywords(keywords []string) []string {
+	seen := make(map[string]bool, len(keywords))
+	normalized := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		normalized = append(normalized, k)
+	}
+	return normalized
+}
+
 // GetCategoryBySlug returns a category by its slug.
 func GetCategoryBySlug(slug string) *Category {
-	for _, cat := range DefaultCategories {
+	for _, cat := range Taxonomy() {
 		if cat.Slug == slug {
 			return &cat
 		}
@@ -102,7 +164,7 @@ func GetCategoryBySlug(slug string) *Category {
 // GetStaticCategories returns non-dynamic categories.
 func GetStaticCategories() []Category {
 	var static []Category
-	for _, cat := range DefaultCategories {
+	for _, cat := range Taxonomy() {
 		if !cat.Dynamic {
 			static = append(static, cat)
 		}
@@ -113,7 +175,7 @@ func GetStaticCategories() []Category {
 // GetDynamicCategories returns dynamic categories.
 func GetDynamicCategories() []Category {
 	var dynamic []Category
-	for _, cat := range DefaultCategories {
+	for _, cat := range Taxonomy() {
 		if cat.Dynamic {
 			dynamic = append(dynamic, cat)
 		}
@@ -135,4 +197,5 @@ type CategorySentiment struct {
 	TopMoverSlug   string  `bson:"top_mover_slug,omitempty" json:"top_mover_slug,omitempty"` // Slug for link
 	TopMoverChange float64 `bson:"top_mover_change" json:"top_mover_change"`                 // Change of top mover
 	AvgChange24h   float64 `bson:"avg_change_24h" json:"avg_change_24h"`   // Simple average change
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`         // When the aggregate was computed
 }
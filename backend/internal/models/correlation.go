@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MarketCorrelation is the rolling correlation between two markets'
+// hourly probability changes. MarketA sorts before MarketB.
+type MarketCorrelation struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+
+	MarketA string `bson:"market_a" json:"market_a"`
+	MarketB string `bson:"market_b" json:"market_b"`
+
+	// Pearson coefficient (-1 to 1) and its absolute value, for sorting
+	Correlation float64 `bson:"correlation" json:"correlation"`
+	Strength    float64 `bson:"strength" json:"strength"`
+
+	// Number of paired hourly changes and the window they cover
+	Samples     int `bson:"samples" json:"samples"`
+	WindowHours int `bson:"window_hours" json:"window_hours"`
+
+	// Why the pair was compared
+	SharedCategory string   `bson:"shared_category,omitempty" json:"shared_category,omitempty"`
+	SharedTags     []string `bson:"shared_tags,omitempty" json:"shared_tags,omitempty"`
+
+	ComputedAt time.Time `bson:"computed_at" json:"computed_at"`
+}
+
+// Other returns the market ID paired with marketID.
+func (c *MarketCorrelation) Other(marketID string) string {
+	if c.MarketA == marketID {
+		return c.MarketB
+	}
+	return c.MarketA
+}
+
+// RelatedMarket is a market correlated with another.
+type RelatedMarket struct {
+	Market      *Market  `json:"market"`
+	Correlation float64  `json:"correlation"`
+	Samples     int      `json:"samples"`
+	SharedTags  []string `json:"shared_tags,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// MarketEmbedding caches the embedding vector of a market's question. It is
+// recomputed when the question or the embedding model changes.
+type MarketEmbedding struct {
+	MarketID  string    `bson:"_id" json:"market_id"`
+	Model     string    `bson:"model" json:"model"`
+	Question  string    `bson:"question" json:"question"`
+	Vector    []float32 `bson:"vector" json:"-"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// EnrichmentCacheEntry is one cached enrichment source response. Payload
+// holds the source's results as JSON; entries are removed by a TTL index
+// once ExpiresAt passes.
+type EnrichmentCacheEntry struct {
+	Key       string    `bson:"_id"`
+	Source    string    `bson:"source"`
+	Query     string    `bson:"query"`
+	Payload   []byte    `bson:"payload"`
+	FetchedAt time.Time `bson:"fetched_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventRetention is how long persisted market events are kept for replay.
+const EventRetention = 7 * 24 * time.Hour
+
+// StoredEvent is a market event persisted by the syncer so subscribers can
+// catch up on events they missed. IDs increase in emission order.
+type StoredEvent struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Type      string                 `bson:"type" json:"type"`
+	MarketID  string                 `bson:"market_id" json:"market_id"`
+	Market    *Market                `bson:"market" json:"market"` // As emitted
+	Previous  *Snapshot              `bson:"previous,omitempty" json:"previous,omitempty"`
+	Metadata  map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// EventOffset is a durable subscriber's progress: every event up to
+// LastEventID is handled, as are the later events in Acked, which were
+// acknowledged before an earlier one.
+type EventOffset struct {
+	Subscriber  string               `bson:"_id" json:"subscriber"`
+	LastEventID primitive.ObjectID   `bson:"last_event_id" json:"last_event_id"`
+	Acked       []primitive.ObjectID `bson:"acked,omitempty" json:"acked,omitempty"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+}
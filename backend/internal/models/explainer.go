@@ -0,0 +1,80 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ExplainerStatus is where an explainer topic is in the queue.
+type ExplainerStatus string
+
+const (
+	ExplainerQueued    ExplainerStatus = "queued"
+	ExplainerRunning   ExplainerStatus = "running"
+	ExplainerPublished ExplainerStatus = "published"
+	ExplainerFailed    ExplainerStatus = "failed"
+)
+
+// ExplainerTopic is a curated topic for an evergreen explainer article.
+// The scheduler generates queued topics one at a time, highest priority
+// first, independent of market movement.
+type ExplainerTopic struct {
+	// ID is the slug of Title, and the explainer article's slug
+	ID    string `bson:"_id" json:"id"`
+	Title string `bson:"title" json:"title"`
+
+	// Query is searched for enrichment; Title when empty
+	Query string `bson:"query,omitempty" json:"query,omitempty"`
+
+	// Category of the article, and of the markets cited as examples; empty
+	// for none
+	Category string `bson:"category,omitempty" json:"category,omitempty"`
+
+	// Priority orders the queue, highest first, then oldest first
+	Priority int    `bson:"priority" json:"priority"`
+	Notes    string `bson:"notes,omitempty" json:"notes,omitempty"`
+
+	Status ExplainerStatus `bson:"status" json:"status"`
+
+	// Outcome
+	ArticleSlug string     `bson:"article_slug,omitempty" json:"article_slug,omitempty"`
+	Error       string     `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt   *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	FinishedAt  *time.Time `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+
+	CreatedBy string    `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// SearchQuery returns the query searched for the topic's enrichment.
+func (t *ExplainerTopic) SearchQuery() string {
+	if t.Query != "" {
+		return t.Query
+	}
+	return t.Title
+}
+
+// Validate checks the topic before it is queued.
+func (t *ExplainerTopic) Validate() error {
+	switch {
+	case len(t.Title) < 10 || len(t.Title) > 120:
+		return errors.New("title must be 10 to 120 characters")
+	case t.ID == "":
+		return errors.New("title must contain letters or digits")
+	case len(t.Query) > 300:
+		return errors.New("query must be at most 300 characters")
+	case t.Priority < -100 || t.Priority > 100:
+		return errors.New("priority must be between -100 and 100")
+	}
+	return nil
+}
+
+// DefaultExplainerTopics are queued into an empty topic collection.
+var DefaultExplainerTopics = []ExplainerTopic{
+	{Title: "How Polymarket odds work", Query: "how Polymarket prediction market prices and odds work", Category: "finance", Priority: 10},
+	{Title: "What moves Fed rate markets", Query: "what moves Federal Reserve interest rate prediction markets", Category: "finance", Priority: 5},
+	{Title: "How prediction markets resolve", Query: "how Polymarket markets are resolved UMA oracle disputes", Category: "finance"},
+	{Title: "Why election odds swing", Query: "why election prediction market odds move polls news", Category: "politics"},
+	{Title: "How crypto price markets work", Query: "Polymarket crypto price prediction markets Bitcoin Ethereum", Category: "crypto"},
+}
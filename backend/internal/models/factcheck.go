@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// ArticleSection names a part of an article's text.
+type ArticleSection string
+
+const (
+	SectionHeadline     ArticleSection = "headline"
+	SectionSubheadline  ArticleSection = "subheadline"
+	SectionSummary      ArticleSection = "summary"
+	SectionWhatHappened ArticleSection = "what_happened"
+	SectionWhyItMatters ArticleSection = "why_it_matters"
+	SectionContext      ArticleSection = "context"
+	SectionWhatToWatch  ArticleSection = "what_to_watch"
+
+	// Text added after the article was generated
+	SectionAmendment ArticleSection = "amendment"
+	SectionSocial    ArticleSection = "social"
+	SectionTLDR      ArticleSection = "tldr"
+	SectionPush      ArticleSection = "push"
+)
+
+// Lead reports whether the section is part of the article's lead: the
+// headline, subheadline and summary shown in listings and previews.
+func (s ArticleSection) Lead() bool {
+	return s == SectionHeadline || s == SectionSubheadline || s == SectionSummary
+}
+
+// FactCheckStatus is the outcome of checking a figure.
+type FactCheckStatus string
+
+const (
+	// FactCheckCorrected figures were rewritten to match the source data.
+	FactCheckCorrected FactCheckStatus = "corrected"
+	// FactCheckUnverified figures match nothing in the source data.
+	FactCheckUnverified FactCheckStatus = "unverified"
+)
+
+// FactCheck reports how the figures in an article compare with the market
+// data and context it was generated from.
+type FactCheck struct {
+	CheckedAt  time.Time `bson:"checked_at" json:"checked_at"`
+	Verified   int       `bson:"verified" json:"verified"`
+	Corrected  int       `bson:"corrected" json:"corrected"`
+	Unverified int       `bson:"unverified" json:"unverified"`
+
+	// Figures that were corrected or couldn't be verified
+	Figures []FactCheckFigure `bson:"figures,omitempty" json:"figures,omitempty"`
+}
+
+// FactCheckFigure is a percentage, point change or dollar amount that
+// didn't match the source data.
+type FactCheckFigure struct {
+	Section    ArticleSection  `bson:"section" json:"section"`
+	Text       string          `bson:"text" json:"text"`
+	Status     FactCheckStatus `bson:"status" json:"status"`
+	Correction string          `bson:"correction,omitempty" json:"correction,omitempty"`
+}
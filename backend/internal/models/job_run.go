@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobRunRetention is how long scheduler job run history is kept.
+const JobRunRetention = 30 * 24 * time.Hour
+
+// JobRunStatus is the outcome of a job attempt.
+type JobRunStatus string
+
+const (
+	JobRunSucceeded JobRunStatus = "succeeded"
+	// JobRunFailed attempts are retried.
+	JobRunFailed JobRunStatus = "failed"
+	// JobRunDead attempts failed with no retries left; the run is
+	// abandoned until the job's next scheduled time.
+	JobRunDead JobRunStatus = "dead"
+)
+
+// JobRun records one attempt at running a scheduled job.
+type JobRun struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Job      string     `bson:"job" json:"job"`
+	Slot     *time.Time `bson:"slot,omitempty" json:"slot,omitempty"` // Scheduled time; nil for manual runs
+	Instance string     `bson:"instance,omitempty" json:"instance,omitempty"`
+	Attempt  int        `bson:"attempt" json:"attempt"`
+	Attempts int        `bson:"attempts" json:"attempts"` // Allowed by the retry policy
+
+	Status     JobRunStatus `bson:"status" json:"status"`
+	Error      string       `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt  time.Time    `bson:"started_at" json:"started_at"`
+	FinishedAt time.Time    `bson:"finished_at" json:"finished_at"`
+	DurationMs int64        `bson:"duration_ms" json:"duration_ms"`
+}
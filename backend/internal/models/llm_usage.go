@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LLMUsage records token usage for a single LLM request.
+type LLMUsage struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	// What was called
+	Provider string `bson:"provider" json:"provider"`
+	Model    string `bson:"model" json:"model"`
+
+	// Why it was called
+	ArticleType string `bson:"article_type,omitempty" json:"article_type,omitempty"`
+	Job         string `bson:"job,omitempty" json:"job,omitempty"`
+
+	// Usage
+	PromptTokens     int     `bson:"prompt_tokens" json:"prompt_tokens"`
+	CompletionTokens int     `bson:"completion_tokens" json:"completion_tokens"`
+	TotalTokens      int     `bson:"total_tokens" json:"total_tokens"`
+	EstimatedCost    float64 `bson:"estimated_cost" json:"estimated_cost"` // USD
+	LatencyMs        int64   `bson:"latency_ms" json:"latency_ms"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// LLMUsageAggregate summarizes usage for one period bucket.
+type LLMUsageAggregate struct {
+	Period           time.Time `bson:"period" json:"period"`
+	ArticleType      string    `bson:"article_type" json:"article_type"`
+	Model            string    `bson:"model" json:"model"`
+	Requests         int       `bson:"requests" json:"requests"`
+	PromptTokens     int       `bson:"prompt_tokens" json:"prompt_tokens"`
+	CompletionTokens int       `bson:"completion_tokens" json:"completion_tokens"`
+	TotalTokens      int       `bson:"total_tokens" json:"total_tokens"`
+	EstimatedCost    float64   `bson:"estimated_cost" json:"estimated_cost"`
+}
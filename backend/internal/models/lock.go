@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// LockRetention is how long lock documents are kept after they're taken,
+// so a run completed on one instance isn't repeated by another.
+const LockRetention = 7 * 24 * time.Hour
+
+// Lock is a lease held by one instance. It lapses at ExpiresAt unless its
+// owner renews it, after which another instance may take it over.
+type Lock struct {
+	Name        string     `bson:"_id" json:"name"`
+	Owner       string     `bson:"owner" json:"owner"`
+	AcquiredAt  time.Time  `bson:"acquired_at" json:"acquired_at"`
+	ExpiresAt   time.Time  `bson:"expires_at" json:"expires_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
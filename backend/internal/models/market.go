@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -33,6 +34,7 @@ type Market struct {
 
 	// Classification
 	Category       string          `bson:"category" json:"category"`
+	CategorySource string          `bson:"category_source,omitempty" json:"category_source,omitempty"` // CategorySourceKeyword or CategorySourceLLM
 	Tags           []string        `bson:"tags" json:"tags"`                                           // Our detected tags
 	PolymarketTags []PolymarketTag `bson:"polymarket_tags,omitempty" json:"polymarket_tags,omitempty"` // Tags from Polymarket
 
@@ -40,17 +42,20 @@ type Market struct {
 	Probability    float64 `bson:"probability" json:"probability"` // Current yes price
 	PreviousProb   float64 `bson:"previous_prob" json:"previous_prob"`
 	LastTradePrice float64 `bson:"last_trade_price,omitempty" json:"last_trade_price,omitempty"`
-	Change1h       float64 `bson:"change_1h" json:"change_1h"`
+	Change1h       float64 `bson:"change_1h" json:"change_1h"` // From snapshots
+	Change6h       float64 `bson:"change_6h" json:"change_6h"` // From snapshots
 	Change24h      float64 `bson:"change_24h" json:"change_24h"`
 	Change7d       float64 `bson:"change_7d" json:"change_7d"`
 
 	// Volume
-	Volume1h    float64 `bson:"volume_1h" json:"volume_1h"`
+	Volume1h    float64 `bson:"volume_1h" json:"volume_1h"` // Total volume gained over the past hour
 	Volume24h   float64 `bson:"volume_24h" json:"volume_24h"`
 	Volume7d    float64 `bson:"volume_7d" json:"volume_7d"`
 	TotalVolume float64 `bson:"total_volume" json:"total_volume"`
 
 	// Event-level data (for multi-outcome markets)
+	EventID        string  `bson:"event_id,omitempty" json:"event_id,omitempty"`
+	EventSlug      string  `bson:"event_slug,omitempty" json:"event_slug,omitempty"`
 	EventVolume    float64 `bson:"event_volume,omitempty" json:"event_volume,omitempty"`
 	EventVolume24h float64 `bson:"event_volume_24h,omitempty" json:"event_volume_24h,omitempty"`
 	EventTitle     string  `bson:"event_title,omitempty" json:"event_title,omitempty"`
@@ -58,7 +63,18 @@ type Market struct {
 	SeriesSlug     string  `bson:"series_slug,omitempty" json:"series_slug,omitempty"`
 
 	// Liquidity
-	Liquidity float64 `bson:"liquidity" json:"liquidity"`
+	Liquidity float64    `bson:"liquidity" json:"liquidity"`
+	OrderBook *OrderBook `bson:"order_book,omitempty" json:"order_book,omitempty"` // Yes-token book, top markets only
+	TradeFlow *TradeFlow `bson:"trade_flow,omitempty" json:"trade_flow,omitempty"` // Top markets only
+	Holders   *Holders   `bson:"holders,omitempty" json:"holders,omitempty"`       // Top markets only
+
+	// Tiers, recalculated on every sync; Tier is the lower of the two
+	LiquidityTier Tier `bson:"liquidity_tier,omitempty" json:"liquidity_tier,omitempty"`
+	VolumeTier    Tier `bson:"volume_tier,omitempty" json:"volume_tier,omitempty"`
+	Tier          Tier `bson:"tier,omitempty" json:"tier,omitempty"`
+
+	// CLOB outcome token IDs, in outcome order
+	ClobTokenIDs []string `bson:"clob_token_ids,omitempty" json:"clob_token_ids,omitempty"`
 
 	// Status
 	Active       bool   `bson:"active" json:"active"`
@@ -68,9 +84,21 @@ type Market struct {
 	StartDate    string `bson:"start_date,omitempty" json:"start_date,omitempty"`
 	EndDate      string `bson:"end_date,omitempty" json:"end_date,omitempty"`
 
+	// When the market was archived, some time after closing; kept when an
+	// admin restores it, so it isn't archived again
+	ArchivedAt *time.Time `bson:"archived_at,omitempty" json:"archived_at,omitempty"`
+
+	// StartDate and EndDate parsed, for range queries; nil when missing or
+	// unparseable
+	StartDateTS *time.Time `bson:"start_date_ts,omitempty" json:"start_date_ts,omitempty"`
+	EndDateTS   *time.Time `bson:"end_date_ts,omitempty" json:"end_date_ts,omitempty"`
+
 	// Resolution
-	ResolutionSource string `bson:"resolution_source,omitempty" json:"resolution_source,omitempty"`
-	CompetitorCount  int    `bson:"competitor_count,omitempty" json:"competitor_count,omitempty"`
+	ResolutionSource string     `bson:"resolution_source,omitempty" json:"resolution_source,omitempty"`
+	CompetitorCount  int        `bson:"competitor_count,omitempty" json:"competitor_count,omitempty"`
+	Resolved         bool       `bson:"resolved" json:"resolved"`
+	WinningOutcome   string     `bson:"winning_outcome,omitempty" json:"winning_outcome,omitempty"`
+	ResolvedAt       *time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
 
 	// Outcomes (for multi-outcome markets)
 	Outcomes      []string  `bson:"outcomes" json:"outcomes"`
@@ -88,6 +116,136 @@ type Market struct {
 	PolymarketURL string `bson:"polymarket_url" json:"polymarket_url"`
 }
 
+// OrderBookDepthRange is how far from the midpoint resting orders count
+// toward OrderBook depth.
+const OrderBookDepthRange = 0.05
+
+// OrderBook summarizes a market's CLOB order book for its first outcome.
+// A one-sided or empty book has no quote: its Midpoint, Spread and depths
+// are 0.
+type OrderBook struct {
+	BestBid   float64   `bson:"best_bid" json:"best_bid"`
+	BestAsk   float64   `bson:"best_ask" json:"best_ask"`
+	Midpoint  float64   `bson:"midpoint" json:"midpoint"`
+	Spread    float64   `bson:"spread" json:"spread"`       // BestAsk - BestBid
+	BidDepth  float64   `bson:"bid_depth" json:"bid_depth"` // USD within OrderBookDepthRange of the midpoint
+	AskDepth  float64   `bson:"ask_depth" json:"ask_depth"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Liquidity quality thresholds: combined depth near the midpoint, in USD.
+const (
+	thinBookDepth = 5000
+	deepBookDepth = 50000
+)
+
+// Quoted reports whether the book has both bids and asks, so its midpoint
+// and spread mean something.
+func (b *OrderBook) Quoted() bool {
+	return b.BestBid > 0 && b.BestAsk > 0
+}
+
+// Quality describes how tradeable the book is, e.g. "thin book, 4-cent
+// spread", or "no quote" for a one-sided or empty book.
+func (b *OrderBook) Quality() string {
+	if !b.Quoted() {
+		return "no quote"
+	}
+
+	depth := "moderate depth"
+	switch total := b.BidDepth + b.AskDepth; {
+	case total < thinBookDepth:
+		depth = "thin book"
+	case total >= deepBookDepth:
+		depth = "deep book"
+	}
+
+	cents := b.Spread * 100
+	if cents < 1 {
+		return depth + ", sub-cent spread"
+	}
+	return fmt.Sprintf("%s, %.0f-cent spread", depth, cents)
+}
+
+// TradeFlow summarizes a market's recent trades over rolling windows.
+type TradeFlow struct {
+	Hour      TradeStats `bson:"hour" json:"hour"`
+	Day       TradeStats `bson:"day" json:"day"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// TradeStats aggregates trades in one window. Buys of the first outcome and
+// sells of the others count as buy (Yes) volume; the rest as sell volume.
+type TradeStats struct {
+	Trades       int     `bson:"trades" json:"trades"`
+	BuyVolume    float64 `bson:"buy_volume" json:"buy_volume"`   // USD
+	SellVolume   float64 `bson:"sell_volume" json:"sell_volume"` // USD
+	Imbalance    float64 `bson:"imbalance" json:"imbalance"`     // (buy - sell) / (buy + sell), -1 to 1
+	AvgTradeSize float64 `bson:"avg_trade_size" json:"avg_trade_size"`
+	WhaleTrades  int     `bson:"whale_trades" json:"whale_trades"`
+	LargestTrade float64 `bson:"largest_trade" json:"largest_trade"`
+}
+
+// Volume is the window's total traded USD.
+func (s TradeStats) Volume() float64 {
+	return s.BuyVolume + s.SellVolume
+}
+
+// BuyShare is the fraction of the window's volume that was buy (Yes)
+// volume, 0 when nothing traded.
+func (s TradeStats) BuyShare() float64 {
+	if s.Volume() <= 0 {
+		return 0
+	}
+	return s.BuyVolume / s.Volume()
+}
+
+// HolderTopN is how many of the largest holders count toward holder
+// concentration.
+const HolderTopN = 10
+
+// Holders summarizes who holds a market's outcome tokens.
+type Holders struct {
+	Outcomes []OutcomeHolders `bson:"outcomes" json:"outcomes"`
+
+	// Distinct wallets across outcomes. Capped is set when the API's holder
+	// limit was reached, making counts a lower bound.
+	UniqueHolders int       `bson:"unique_holders" json:"unique_holders"`
+	Capped        bool      `bson:"capped,omitempty" json:"capped,omitempty"`
+	UpdatedAt     time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// OutcomeHolders summarizes the holders of one outcome token.
+type OutcomeHolders struct {
+	Outcome       string  `bson:"outcome" json:"outcome"`
+	Holders       int     `bson:"holders" json:"holders"`
+	Shares        float64 `bson:"shares" json:"shares"`                 // Held by the counted holders
+	TopShare      float64 `bson:"top_share" json:"top_share"`           // Fraction of Shares held by the HolderTopN largest, 0-1
+	LargestHolder float64 `bson:"largest_holder" json:"largest_holder"` // Shares
+}
+
+// ResolutionKnown reports whether the market's winning outcome is known
+// and can be matched against its outcomes.
+func (m *Market) ResolutionKnown() bool {
+	return m.WinningOutcome != "" && len(m.Outcomes) > 0
+}
+
+// ResolvedYes reports whether the market resolved to its first outcome, the
+// one its probability quotes. It is false when the resolution isn't known.
+func (m *Market) ResolvedYes() bool {
+	return m.ResolutionKnown() && strings.EqualFold(m.WinningOutcome, m.Outcomes[0])
+}
+
+// WinnerProbability converts a yes-price into the implied probability of the
+// winning outcome. Markets quote the first outcome ("Yes"), so a market that
+// resolved to any other outcome is read from the other side.
+func (m *Market) WinnerProbability(yesPrice float64) float64 {
+	if m.ResolutionKnown() && !m.ResolvedYes() {
+		return 1 - yesPrice
+	}
+	return yesPrice
+}
+
 // Snapshot represents a historical snapshot of market data.
 type Snapshot struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -100,77 +258,129 @@ type Snapshot struct {
 	CapturedAt  time.Time `bson:"captured_at" json:"captured_at"`
 }
 
+// How a market's category was assigned.
+const (
+	CategorySourceKeyword = "keyword"
+	CategorySourceLLM     = "llm"
+)
+
+// Rollup granularities for downsampled snapshots.
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// SnapshotRollup is a downsampled bucket of snapshots for one market.
+type SnapshotRollup struct {
+	MarketID    string    `bson:"market_id" json:"market_id"`
+	Granularity string    `bson:"granularity" json:"granularity"`
+	BucketStart time.Time `bson:"bucket_start" json:"bucket_start"`
+
+	// Probability OHLC over the bucket
+	Open  float64 `bson:"open" json:"open"`
+	High  float64 `bson:"high" json:"high"`
+	Low   float64 `bson:"low" json:"low"`
+	Close float64 `bson:"close" json:"close"`
+
+	// Last observed values in the bucket
+	Volume24h   float64 `bson:"volume_24h" json:"volume_24h"`
+	TotalVolume float64 `bson:"total_volume" json:"total_volume"`
+	Liquidity   float64 `bson:"liquidity" json:"liquidity"`
+
+	Samples int `bson:"samples" json:"samples"`
+}
+
+// ToSnapshot flattens a rollup into a snapshot at the bucket start using the
+// closing probability.
+func (r SnapshotRollup) ToSnapshot() Snapshot {
+	return Snapshot{
+		MarketID:    r.MarketID,
+		Probability: r.Close,
+		Volume24h:   r.Volume24h,
+		TotalVolume: r.TotalVolume,
+		Liquidity:   r.Liquidity,
+		CapturedAt:  r.BucketStart,
+	}
+}
+
+// OddsRange summarizes a market's daily probability over a lookback window
+// ending the day before the current reading, for comparing a move with the
+// market's history.
+type OddsRange struct {
+	Lookback time.Duration `json:"lookback"`
+	Days     int           `json:"days"` // Days with readings
+
+	Min   float64   `json:"min"`
+	MinAt time.Time `json:"min_at"`
+	Max   float64   `json:"max"`
+	MaxAt time.Time `json:"max_at"`
+
+	// Share of daily closes at or below the current probability, 0-1
+	Percentile float64 `json:"percentile"`
+
+	// Latest days the probability reached or passed the current reading,
+	// from above and below; nil if it never did within the window
+	LastAbove *time.Time `json:"last_above,omitempty"`
+	LastBelow *time.Time `json:"last_below,omitempty"`
+}
+
 // TrendingMetrics holds data for trending calculation.
 type TrendingMetrics struct {
 	VolumeScore    float64 // Based on recent volume
 	MovementScore  float64 // Based on price movement
 	VelocityScore  float64 // Based on rate of change
-	RecencyScore   float64 // Based on how recent the activity is
+	InterestScore  float64 // Based on closeness to 50%
+	RecencyScore   float64 // Based on how recently the market was listed
 	TotalScore     float64 // Combined score
 }
 
-// CalculateTrendingScore calculates a trending score for the market.
-func (m *Market) CalculateTrendingScore() float64 {
-	// Volume component (0-40 points)
-	volumeScore := 0.0
-	switch {
-	case m.Volume24h >= 1000000:
-		volumeScore = 40
-	case m.Volume24h >= 500000:
-		volumeScore = 30
-	case m.Volume24h >= 100000:
-		volumeScore = 20
-	case m.Volume24h >= 50000:
-		volumeScore = 10
-	}
-
-	// Movement component (0-30 points)
-	movementScore := 0.0
-	absChange := abs(m.Change24h)
-	switch {
-	case absChange >= 0.15:
-		movementScore = 30
-	case absChange >= 0.10:
-		movementScore = 25
-	case absChange >= 0.05:
-		movementScore = 15
-	case absChange >= 0.02:
-		movementScore = 10
-	}
-
-	// Velocity component - hourly vs daily (0-20 points)
-	velocityScore := 0.0
-	if m.Volume24h > 0 && m.Volume1h > 0 {
-		hourlyRatio := m.Volume1h / (m.Volume24h / 24)
-		switch {
-		case hourlyRatio >= 5:
-			velocityScore = 20
-		case hourlyRatio >= 3:
-			velocityScore = 15
-		case hourlyRatio >= 2:
-			velocityScore = 10
-		}
+// DetectCategory attempts to categorize the market based on its question,
+// checking the static categories of the taxonomy in order.
+func (m *Market) DetectCategory() string {
+	if matches := m.CategoryMatches(); len(matches) > 0 {
+		return matches[0]
 	}
-
-	// Probability interest (0-10 points) - markets near 50% are more interesting
-	interestScore := 10 - abs(m.Probability-0.5)*20
-
-	return volumeScore + movementScore + velocityScore + interestScore
+	return "other"
 }
 
-// DetectCategory attempts to categorize the market based on its question.
-func (m *Market) DetectCategory() string {
+// CategoryMatches returns every static category with a keyword in the
+// market's question, in taxonomy order. More than one match means keyword
+// detection is ambiguous for this market.
+func (m *Market) CategoryMatches() []string {
 	questionLower := strings.ToLower(m.Question)
 
-	for category, keywords := range CategoryKeywords {
-		for _, keyword := range keywords {
+	var matches []string
+	for _, category := range Taxonomy() {
+		if category.Dynamic {
+			continue
+		}
+		for _, keyword := range category.Keywords {
 			if strings.Contains(questionLower, keyword) {
-				return category
+				matches = append(matches, category.Slug)
+				break
 			}
 		}
 	}
+	return matches
+}
 
-	return "other"
+// marketDateLayouts are the formats Polymarket gives start and end dates
+// in.
+var marketDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// ParseMarketDate parses a Polymarket start or end date, returning nil when
+// s is empty or in no known format. Dates without a zone are UTC.
+func ParseMarketDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	for _, layout := range marketDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
 }
 
 // IsNew returns true if the market was first seen within the given duration.
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PolymarketEvent mirrors a Polymarket event: the question its markets
+// answer together, e.g. "Who will win the 2028 election?" with one market
+// per candidate. Markets link back to it by EventID.
+type PolymarketEvent struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	// Polymarket identifiers
+	EventID    string `bson:"event_id" json:"event_id"`
+	Slug       string `bson:"slug" json:"slug"`
+	SeriesSlug string `bson:"series_slug,omitempty" json:"series_slug,omitempty"`
+
+	// Content
+	Title       string `bson:"title" json:"title"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	Image       string `bson:"image,omitempty" json:"image,omitempty"`
+	Icon        string `bson:"icon,omitempty" json:"icon,omitempty"`
+
+	// Classification
+	PolymarketTags []PolymarketTag `bson:"polymarket_tags,omitempty" json:"polymarket_tags,omitempty"`
+
+	// Volume and liquidity across all of the event's markets
+	Volume    float64 `bson:"volume" json:"volume"`
+	Volume24h float64 `bson:"volume_24h" json:"volume_24h"`
+	Volume7d  float64 `bson:"volume_7d" json:"volume_7d"`
+	Liquidity float64 `bson:"liquidity" json:"liquidity"`
+
+	CommentCount     int    `bson:"comment_count,omitempty" json:"comment_count,omitempty"`
+	CompetitorCount  int    `bson:"competitor_count,omitempty" json:"competitor_count,omitempty"`
+	ResolutionSource string `bson:"resolution_source,omitempty" json:"resolution_source,omitempty"`
+
+	// Status
+	Active      bool       `bson:"active" json:"active"`
+	Closed      bool       `bson:"closed" json:"closed"`
+	StartDate   string     `bson:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate     string     `bson:"end_date,omitempty" json:"end_date,omitempty"`
+	StartDateTS *time.Time `bson:"start_date_ts,omitempty" json:"start_date_ts,omitempty"`
+	EndDateTS   *time.Time `bson:"end_date_ts,omitempty" json:"end_date_ts,omitempty"`
+
+	// NegRisk events have mutually exclusive outcomes, exactly one of
+	// which resolves yes, so their markets' odds should sum to 100%
+	NegRisk bool `bson:"neg_risk" json:"neg_risk"`
+
+	// Polymarket IDs of the event's markets, including ones too small to
+	// be synced
+	MarketIDs []string `bson:"market_ids" json:"market_ids"`
+
+	// Timing
+	FirstSeenAt time.Time `bson:"first_seen_at,omitempty" json:"first_seen_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+
+	// URL
+	PolymarketURL string `bson:"polymarket_url" json:"polymarket_url"`
+}
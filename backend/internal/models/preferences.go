@@ -0,0 +1,79 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationPreferences controls which articles and watchlist alerts a
+// user is notified about, and where.
+type NotificationPreferences struct {
+	UserID primitive.ObjectID `bson:"_id" json:"-"`
+	Email  string             `bson:"email" json:"-"` // Copied from the account for email delivery
+
+	// Categories of interest; empty means every category
+	Categories []string `bson:"categories" json:"categories"`
+
+	// Only articles and alerts at least this significant are delivered
+	MinSignificance Significance `bson:"min_significance" json:"min_significance"`
+
+	// Deliver watchlist alert hits, not just articles
+	Alerts bool `bson:"alerts" json:"alerts"`
+
+	Channels   NotificationChannels `bson:"channels" json:"channels"`
+	QuietHours *QuietHours          `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// NotificationChannels are the delivery channels a user has turned on.
+type NotificationChannels struct {
+	Email          bool   `bson:"email" json:"email"`
+	TelegramChatID string `bson:"telegram_chat_id,omitempty" json:"telegram_chat_id,omitempty"`
+	WebhookURL     string `bson:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+}
+
+// Any reports whether at least one channel is on.
+func (c NotificationChannels) Any() bool {
+	return c.Email || c.TelegramChatID != "" || c.WebhookURL != ""
+}
+
+// QuietHours is a daily window, in the user's time zone, during which
+// nothing is delivered. Start may be after End to span midnight.
+type QuietHours struct {
+	Start    int    `bson:"start" json:"start"` // Hour, 0-23
+	End      int    `bson:"end" json:"end"`     // Hour, 0-23, exclusive
+	Timezone string `bson:"timezone" json:"timezone"`
+}
+
+// Active reports whether t falls within the quiet hours.
+func (q *QuietHours) Active(t time.Time) bool {
+	if q == nil || q.Start == q.End {
+		return false
+	}
+	if loc, err := time.LoadLocation(q.Timezone); err == nil {
+		t = t.In(loc)
+	}
+	h := t.Hour()
+	if q.Start < q.End {
+		return h >= q.Start && h < q.End
+	}
+	return h >= q.Start || h < q.End
+}
+
+// Wants reports whether a notification in category at significance should
+// be delivered at t.
+func (p *NotificationPreferences) Wants(category string, significance Significance, t time.Time) bool {
+	if !p.Channels.Any() {
+		return false
+	}
+	if len(p.Categories) > 0 && !slices.Contains(p.Categories, category) {
+		return false
+	}
+	if significance.Rank() < p.MinSignificance.Rank() {
+		return false
+	}
+	return !p.QuietHours.Active(t)
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScheduledContentStatus is where a content calendar entry is in its life.
+type ScheduledContentStatus string
+
+const (
+	ScheduledPending  ScheduledContentStatus = "pending"
+	ScheduledRunning  ScheduledContentStatus = "running"
+	ScheduledDone     ScheduledContentStatus = "done"
+	ScheduledFailed   ScheduledContentStatus = "failed"
+	ScheduledCanceled ScheduledContentStatus = "canceled"
+)
+
+// ScheduledContent is a one-off article generation on the content
+// calendar, run by the scheduler once RunAt passes. Only pending entries
+// can be edited or canceled.
+type ScheduledContent struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	// What to generate: market articles take MarketSlug, digests Category
+	// and briefings BriefingType
+	Type         ArticleType  `bson:"type" json:"type"`
+	MarketSlug   string       `bson:"market_slug,omitempty" json:"market_slug,omitempty"`
+	Category     string       `bson:"category,omitempty" json:"category,omitempty"`
+	BriefingType BriefingType `bson:"briefing_type,omitempty" json:"briefing_type,omitempty"`
+	Notes        string       `bson:"notes,omitempty" json:"notes,omitempty"`
+
+	RunAt  time.Time              `bson:"run_at" json:"run_at"`
+	Status ScheduledContentStatus `bson:"status" json:"status"`
+
+	// Outcome
+	ArticleSlug string     `bson:"article_slug,omitempty" json:"article_slug,omitempty"`
+	Error       string     `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt   *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	FinishedAt  *time.Time `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+
+	CreatedBy string    `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate checks the entry names a type the calendar can generate, with
+// the inputs that type needs.
+func (c *ScheduledContent) Validate() error {
+	switch c.Type {
+	case ArticleTypeBreaking, ArticleTypeNewMarket, ArticleTypeResolution:
+		if c.MarketSlug == "" {
+			return errors.New("market is required for " + string(c.Type) + " articles")
+		}
+	case ArticleTypeDigest:
+		if c.Category == "" {
+			return errors.New("category is required for digest articles")
+		}
+	case ArticleTypeBriefing:
+		if c.BriefingType == "" {
+			return errors.New("briefing_type is required for briefing articles")
+		}
+	case ArticleTypeTrending:
+	default:
+		return errors.New("type must be breaking, new_market, resolution, briefing, trending or digest")
+	}
+	if c.RunAt.IsZero() {
+		return errors.New("run_at is required")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// RuntimeSettingsID is the _id of the single runtime settings document.
+const RuntimeSettingsID = "runtime"
+
+// RuntimeSettings override detection thresholds without a restart. A nil
+// field keeps the value configured by environment.
+type RuntimeSettings struct {
+	ID string `bson:"_id" json:"-"`
+
+	// How often markets are synced from Polymarket
+	SyncIntervalSeconds *int `bson:"sync_interval_seconds,omitempty" json:"sync_interval_seconds,omitempty"`
+
+	// 24h probability change that makes a breaking move, e.g. 0.05 for 5%
+	BreakingThreshold *float64 `bson:"breaking_threshold,omitempty" json:"breaking_threshold,omitempty"`
+
+	// Standard deviations above a market's baseline 24h volume that make a
+	// volume spike
+	VolumeZScore *float64 `bson:"volume_z_score,omitempty" json:"volume_z_score,omitempty"`
+
+	// Markets below this 24h volume in USD are not synced
+	MinVolume24h *float64 `bson:"min_volume_24h,omitempty" json:"min_volume_24h,omitempty"`
+
+	// Repeat events on a market within the cooldown need a score this much
+	// higher than the last
+	EventCooldownSeconds *int     `bson:"event_cooldown_seconds,omitempty" json:"event_cooldown_seconds,omitempty"`
+	EventEscalation      *float64 `bson:"event_escalation,omitempty" json:"event_escalation,omitempty"`
+
+	// Significance score breaking and threshold events need for an article
+	MinEventScore *float64 `bson:"min_event_score,omitempty" json:"min_event_score,omitempty"`
+
+	// Points each term of the trending score is worth; all are replaced
+	// together, so an omitted weight is zero
+	TrendingWeights *TrendingWeights `bson:"trending_weights,omitempty" json:"trending_weights,omitempty"`
+
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate checks the settings are within the ranges the syncer and
+// scheduler handle.
+func (s *RuntimeSettings) Validate() error {
+	switch {
+	case s.SyncIntervalSeconds != nil && (*s.SyncIntervalSeconds < 10 || *s.SyncIntervalSeconds > 3600):
+		return errors.New("sync_interval_seconds must be between 10 and 3600")
+	case s.BreakingThreshold != nil && (*s.BreakingThreshold <= 0 || *s.BreakingThreshold > 1):
+		return errors.New("breaking_threshold must be greater than 0 and at most 1")
+	case s.VolumeZScore != nil && (*s.VolumeZScore <= 0 || *s.VolumeZScore > 20):
+		return errors.New("volume_z_score must be greater than 0 and at most 20")
+	case s.MinVolume24h != nil && *s.MinVolume24h < 0:
+		return errors.New("min_volume_24h must not be negative")
+	case s.EventCooldownSeconds != nil && (*s.EventCooldownSeconds < 0 || *s.EventCooldownSeconds > 86400):
+		return errors.New("event_cooldown_seconds must be between 0 and 86400")
+	case s.EventEscalation != nil && (*s.EventEscalation < 0 || *s.EventEscalation > 1):
+		return errors.New("event_escalation must be between 0 and 1")
+	case s.MinEventScore != nil && (*s.MinEventScore < 0 || *s.MinEventScore > 1):
+		return errors.New("min_event_score must be between 0 and 1")
+	case s.TrendingWeights != nil:
+		return s.TrendingWeights.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Social platforms articles are published to.
+const (
+	PlatformX       = "x"
+	PlatformDiscord = "discord"
+)
+
+// SocialPost records an article posted to a social platform, for duplicate
+// suppression and posting caps.
+type SocialPost struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Platform  string             `bson:"platform" json:"platform"`
+	ArticleID primitive.ObjectID `bson:"article_id" json:"article_id"`
+	MarketID  string             `bson:"market_id,omitempty" json:"market_id,omitempty"`
+
+	// ID of the post on the platform, when it returns one
+	PostID string `bson:"post_id,omitempty" json:"post_id,omitempty"`
+	Text   string `bson:"text" json:"text"`
+
+	PostedAt time.Time `bson:"posted_at" json:"posted_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// StoredSocialSignal is a social signal the correlator found, persisted
+// once per post in social_signals. MarketIDs accumulates every market the
+// post has been correlated with.
+type StoredSocialSignal struct {
+	SocialSignal `bson:",inline"`
+
+	MarketIDs   []string  `bson:"market_ids" json:"market_ids"`
+	FirstSeenAt time.Time `bson:"first_seen_at" json:"first_seen_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
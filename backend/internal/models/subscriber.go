@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubscriberStatus is the state of a newsletter subscription.
+type SubscriberStatus string
+
+const (
+	// SubscriberPending has signed up but not yet confirmed by email.
+	SubscriberPending SubscriberStatus = "pending"
+	// SubscriberConfirmed receives the newsletter.
+	SubscriberConfirmed SubscriberStatus = "confirmed"
+	// SubscriberUnsubscribed has opted out.
+	SubscriberUnsubscribed SubscriberStatus = "unsubscribed"
+)
+
+// Subscriber is a newsletter subscriber. Subscriptions use double opt-in:
+// a confirmation email carrying ConfirmToken must be acted on before any
+// newsletter is sent.
+type Subscriber struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Email  string           `bson:"email" json:"email"`
+	Status SubscriberStatus `bson:"status" json:"status"`
+
+	// Tokens for the confirmation and unsubscribe links
+	ConfirmToken     string `bson:"confirm_token,omitempty" json:"-"`
+	UnsubscribeToken string `bson:"unsubscribe_token" json:"-"`
+
+	CreatedAt      time.Time  `bson:"created_at" json:"created_at"`
+	ConfirmedAt    *time.Time `bson:"confirmed_at,omitempty" json:"confirmed_at,omitempty"`
+	UnsubscribedAt *time.Time `bson:"unsubscribed_at,omitempty" json:"unsubscribed_at,omitempty"`
+
+	// Last newsletter delivered, so a re-run skips subscribers already sent to
+	LastSentArticleID *primitive.ObjectID `bson:"last_sent_article_id,omitempty" json:"-"`
+	LastSentAt        *time.Time          `bson:"last_sent_at,omitempty" json:"last_sent_at,omitempty"`
+}
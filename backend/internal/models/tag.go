@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TagCount is how many published articles and open markets carry a tag.
+type TagCount struct {
+	Tag      string `json:"tag"`
+	Articles int64  `json:"articles"`
+	Markets  int64  `json:"markets"`
+}
+
+// Total is the tag's article and market count combined.
+func (t TagCount) Total() int64 {
+	return t.Articles + t.Markets
+}
+
+// TagSlug normalizes a tag to the form stored on articles and used in tag
+// URLs: lowercase, with runs of anything but letters and digits replaced by
+// a single hyphen ("Federal Reserve" becomes "federal-reserve").
+func TagSlug(tag string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	return b.String()
+}
+
+// NormalizeTags slugs tags, dropping empty and duplicate ones.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slug := TagSlug(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		normalized = append(normalized, slug)
+	}
+	return normalized
+}
@@ -0,0 +1,77 @@
+package models
+
+// Tier buckets a market by how much money trades in it.
+type Tier string
+
+const (
+	TierLow    Tier = "low"
+	TierMedium Tier = "medium"
+	TierHigh   Tier = "high"
+)
+
+// Tier thresholds in USD: below the low bound is TierLow, at or above the
+// high bound TierHigh.
+const (
+	liquidityTierLow  = 10_000
+	liquidityTierHigh = 100_000
+	volumeTierLow     = 10_000
+	volumeTierHigh    = 250_000
+)
+
+// ValidTier reports whether t is a known tier.
+func ValidTier(t string) bool {
+	switch Tier(t) {
+	case TierLow, TierMedium, TierHigh:
+		return true
+	}
+	return false
+}
+
+// LiquidityTierFor returns the tier of a market's liquidity.
+func LiquidityTierFor(liquidity float64) Tier {
+	return tierFor(liquidity, liquidityTierLow, liquidityTierHigh)
+}
+
+// VolumeTierFor returns the tier of a market's 24h volume.
+func VolumeTierFor(volume24h float64) Tier {
+	return tierFor(volume24h, volumeTierLow, volumeTierHigh)
+}
+
+func tierFor(v, low, high float64) Tier {
+	switch {
+	case v < low:
+		return TierLow
+	case v >= high:
+		return TierHigh
+	}
+	return TierMedium
+}
+
+// rank orders tiers, low first.
+func (t Tier) rank() int {
+	switch t {
+	case TierMedium:
+		return 1
+	case TierHigh:
+		return 2
+	}
+	return 0
+}
+
+// SetTiers recalculates the market's liquidity, volume and overall tiers
+// from its current figures.
+func (m *Market) SetTiers() {
+	m.LiquidityTier = LiquidityTierFor(m.Liquidity)
+	m.VolumeTier = VolumeTierFor(m.Volume24h)
+	m.Tier = m.LiquidityTier
+	if m.VolumeTier.rank() < m.Tier.rank() {
+		m.Tier = m.VolumeTier
+	}
+}
+
+// ThinlyTraded reports whether the market's liquidity or volume is in the
+// low tier, so its price moves on little money. Markets not yet tiered are
+// not thinly traded.
+func (m *Market) ThinlyTraded() bool {
+	return m.Tier == TierLow
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Timeline entry kinds.
+const (
+	TimelineSnapshot = "snapshot"
+	TimelineEvent    = "event"
+	TimelineArticle  = "article"
+)
+
+// TimelineEntry is one point on a market's timeline: a price snapshot, a
+// sync event or a published article, exactly one of which is set.
+type TimelineEntry struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+
+	Snapshot *TimelineSnapshotEntry `json:"snapshot,omitempty"`
+	Event    *TimelineEventEntry    `json:"event,omitempty"`
+	Article  *TimelineArticleEntry  `json:"article,omitempty"`
+}
+
+// TimelineSnapshotEntry is the market's price at a point in time.
+type TimelineSnapshotEntry struct {
+	Probability float64 `json:"probability"`
+	Volume24h   float64 `json:"volume_24h"`
+}
+
+// TimelineEventEntry is an event the syncer emitted for the market.
+type TimelineEventEntry struct {
+	ID       primitive.ObjectID     `json:"id"`
+	Type     string                 `json:"type"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TimelineArticleEntry is an article covering the market.
+type TimelineArticleEntry struct {
+	ID       primitive.ObjectID `json:"id"`
+	Slug     string             `json:"slug"`
+	Type     ArticleType        `json:"type"`
+	Headline string             `json:"headline"`
+}
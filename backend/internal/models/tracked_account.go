@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Social platforms a tracked account can be read from besides PlatformX.
+const (
+	PlatformBluesky     = "bluesky"
+	PlatformTruthSocial = "truthsocial"
+)
+
+// TrackedAccount is a social account editors track for social signals. X
+// accounts are merged with XTracker's list: for a handle XTracker already
+// tracks it overrides the categories and weight, and a disabled account is
+// left out entirely. Accounts on other platforms are read from that
+// platform directly.
+type TrackedAccount struct {
+	// ID is the handle for X accounts and "platform:handle" otherwise
+	ID       string `bson:"_id" json:"id"`
+	Platform string `bson:"platform" json:"platform"`
+	Handle   string `bson:"handle" json:"handle"`
+	Name     string `bson:"name,omitempty" json:"name,omitempty"`
+
+	// Market categories the account's posts are correlated with; empty
+	// means the correlator's defaults
+	Categories []string `bson:"categories,omitempty" json:"categories,omitempty"`
+
+	// Weight ranks the account's signals against others' (1 is neutral)
+	Weight float64 `bson:"weight" json:"weight"`
+
+	Enabled bool   `bson:"enabled" json:"enabled"`
+	Notes   string `bson:"notes,omitempty" json:"notes,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Handle formats by platform, all stored lowercase: X allows up to 15
+// letters, digits and underscores, Truth Social (a Mastodon fork) up to 30,
+// and Bluesky handles are domain names.
+var handlePatterns = map[string]*regexp.Regexp{
+	PlatformX:           regexp.MustCompile(`^[a-z0-9_]{1,15}$`),
+	PlatformTruthSocial: regexp.MustCompile(`^[a-z0-9_]{1,30}$`),
+	PlatformBluesky:     regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`),
+}
+
+// NormalizeHandle lowercases a handle and strips a leading @.
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// NormalizePlatform lowercases a platform name, defaulting to X.
+func NormalizePlatform(platform string) string {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" {
+		return PlatformX
+	}
+	return platform
+}
+
+// TrackedAccountID returns the ID of the account for a handle on a
+// platform, both already normalized.
+func TrackedAccountID(platform, handle string) string {
+	if platform == PlatformX {
+		return handle
+	}
+	return platform + ":" + handle
+}
+
+// Validate checks the account before it is stored.
+func (a *TrackedAccount) Validate() error {
+	pattern, ok := handlePatterns[a.Platform]
+	switch {
+	case !ok:
+		return errors.New("platform must be x, bluesky or truthsocial")
+	case len(a.Handle) > 253 || !pattern.MatchString(a.Handle):
+		return errors.New("handle is not a valid " + a.Platform + " handle")
+	case a.Weight <= 0 || a.Weight > 10:
+		return errors.New("weight must be greater than 0 and at most 10")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Trending score scales: each term rises continuously between its bounds
+// and is capped at 1 before it is weighted.
+const (
+	trendingVolumeFloor = 10_000    // 24h volume in USD scoring nothing
+	trendingVolumeCap   = 1_000_000 // 24h volume in USD scoring in full
+	trendingMoveUnit    = 0.01      // One point of 24h change
+	trendingMoveCap     = 0.20      // 24h change scoring in full
+	trendingVelocityCap = 5         // Past-hour volume, as a multiple of the hourly average, scoring in full
+)
+
+// TrendingWeights are the points each term of the trending score is worth.
+type TrendingWeights struct {
+	Volume   float64 `bson:"volume" json:"volume"`     // 24h volume, log-scaled
+	Movement float64 `bson:"movement" json:"movement"` // 24h probability change, log-scaled
+	Velocity float64 `bson:"velocity" json:"velocity"` // Past-hour volume against the 24h hourly average
+	Interest float64 `bson:"interest" json:"interest"` // Closeness to 50%
+	Recency  float64 `bson:"recency" json:"recency"`   // Newly listed, decaying with age
+}
+
+// DefaultTrendingWeights returns the weights scores are calculated with
+// unless configured otherwise; they sum to 110, the recency boost on top
+// of 100 for an established market.
+func DefaultTrendingWeights() TrendingWeights {
+	return TrendingWeights{Volume: 40, Movement: 30, Velocity: 20, Interest: 10, Recency: 10}
+}
+
+// Validate checks no weight is negative and at least one counts.
+func (w TrendingWeights) Validate() error {
+	for _, v := range []float64{w.Volume, w.Movement, w.Velocity, w.Interest, w.Recency} {
+		if v < 0 {
+			return errors.New("trending weights must not be negative")
+		}
+	}
+	if w.Volume+w.Movement+w.Velocity+w.Interest+w.Recency == 0 {
+		return errors.New("trending weights must not all be zero")
+	}
+	return nil
+}
+
+// TrendingScorer calculates market trending scores.
+type TrendingScorer struct {
+	Weights TrendingWeights
+
+	// HalfLife is the market age at which the recency boost is halved;
+	// zero disables it
+	HalfLife time.Duration
+}
+
+// Score returns the market's trending score at now.
+func (s TrendingScorer) Score(m *Market, now time.Time) float64 {
+	return s.Metrics(m, now).TotalScore
+}
+
+// Metrics breaks the market's trending score at now into its terms.
+func (s TrendingScorer) Metrics(m *Market, now time.Time) TrendingMetrics {
+	metrics := TrendingMetrics{
+		VolumeScore:   s.Weights.Volume * logScale(m.Volume24h/trendingVolumeFloor, trendingVolumeCap/trendingVolumeFloor),
+		MovementScore: s.Weights.Movement * logScale(1+abs(m.Change24h)/trendingMoveUnit, 1+trendingMoveCap/trendingMoveUnit),
+		InterestScore: s.Weights.Interest * (1 - min(1, abs(m.Probability-0.5)*2)),
+	}
+
+	// Velocity - past-hour volume against the 24h hourly average
+	if m.Volume24h > 0 && m.Volume1h > 0 {
+		metrics.VelocityScore = s.Weights.Velocity * logScale(m.Volume1h/(m.Volume24h/24), trendingVelocityCap)
+	}
+
+	// Recency - newly listed markets get a boost that halves every HalfLife
+	if s.HalfLife > 0 && !m.FirstSeenAt.IsZero() {
+		age := max(0, now.Sub(m.FirstSeenAt))
+		metrics.RecencyScore = s.Weights.Recency * math.Exp2(-age.Hours()/s.HalfLife.Hours())
+	}
+
+	metrics.TotalScore = metrics.VolumeScore + metrics.MovementScore + metrics.VelocityScore + metrics.InterestScore + metrics.RecencyScore
+	return metrics
+}
+
+// logScale maps x in [1, limit] onto [0, 1] logarithmically, clamping
+// values outside it.
+func logScale(x, limit float64) float64 {
+	if x <= 1 {
+		return 0
+	}
+	return min(1, math.Log(x)/math.Log(limit))
+}
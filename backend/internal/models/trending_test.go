@@ -0,0 +1,146 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const scoreTolerance = 1e-9
+
+func TestTrendingMetricsTerms(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	scorer := TrendingScorer{Weights: DefaultTrendingWeights()}
+
+	tests := []struct {
+		name   string
+		market Market
+		term   func(TrendingMetrics) float64
+		want   float64
+	}{
+		{"volume at floor", Market{Volume24h: 10_000}, volumeTerm, 0},
+		{"volume under floor", Market{Volume24h: 5_000}, volumeTerm, 0},
+		{"volume halfway on log scale", Market{Volume24h: 100_000}, volumeTerm, 20},
+		{"volume at cap", Market{Volume24h: 1_000_000}, volumeTerm, 40},
+		{"volume over cap", Market{Volume24h: 5_000_000}, volumeTerm, 40},
+
+		{"no movement", Market{Change24h: 0}, movementTerm, 0},
+		{"movement log-scaled", Market{Change24h: 0.04}, movementTerm, 30 * math.Log(5) / math.Log(21)},
+		{"movement at cap", Market{Change24h: 0.20}, movementTerm, 30},
+		{"downward movement counts", Market{Change24h: -0.20}, movementTerm, 30},
+		{"movement over cap", Market{Change24h: 0.50}, movementTerm, 30},
+
+		{"velocity at hourly average", Market{Volume24h: 24_000, Volume1h: 1_000}, velocityTerm, 0},
+		{"velocity log-scaled", Market{Volume24h: 24_000, Volume1h: 2_000}, velocityTerm, 20 * math.Log(2) / math.Log(5)},
+		{"velocity at cap", Market{Volume24h: 24_000, Volume1h: 5_000}, velocityTerm, 20},
+		{"velocity over cap", Market{Volume24h: 24_000, Volume1h: 20_000}, velocityTerm, 20},
+		{"velocity without past-hour volume", Market{Volume24h: 24_000}, velocityTerm, 0},
+		{"velocity without 24h volume", Market{Volume1h: 1_000}, velocityTerm, 0},
+
+		{"interest at 50%", Market{Probability: 0.5}, interestTerm, 10},
+		{"interest at 75%", Market{Probability: 0.75}, interestTerm, 5},
+		{"interest at 25%", Market{Probability: 0.25}, interestTerm, 5},
+		{"interest at 0%", Market{Probability: 0}, interestTerm, 0},
+		{"interest at 100%", Market{Probability: 1}, interestTerm, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.term(scorer.Metrics(&tt.market, now))
+			if math.Abs(got-tt.want) > scoreTolerance {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendingRecencyHalfLife(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		halfLife time.Duration
+		seen     time.Time
+		want     float64
+	}{
+		{"just listed", 24 * time.Hour, now, 10},
+		{"one half-life old", 24 * time.Hour, now.Add(-24 * time.Hour), 5},
+		{"two half-lives old", 24 * time.Hour, now.Add(-48 * time.Hour), 2.5},
+		{"shorter half-life decays faster", 6 * time.Hour, now.Add(-24 * time.Hour), 10.0 / 16},
+		{"first seen in the future", 24 * time.Hour, now.Add(time.Hour), 10},
+		{"first seen unknown", 24 * time.Hour, time.Time{}, 0},
+		{"disabled", 0, now, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := TrendingScorer{Weights: DefaultTrendingWeights(), HalfLife: tt.halfLife}
+			got := scorer.Metrics(&Market{FirstSeenAt: tt.seen}, now).RecencyScore
+			if math.Abs(got-tt.want) > scoreTolerance {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendingWeights(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A market maxing out every term
+	market := Market{
+		Volume24h:   1_000_000,
+		Volume1h:    500_000,
+		Change24h:   0.20,
+		Probability: 0.5,
+		FirstSeenAt: now,
+	}
+
+	tests := []struct {
+		name    string
+		weights TrendingWeights
+		want    float64
+	}{
+		{"defaults", DefaultTrendingWeights(), 110},
+		{"volume only", TrendingWeights{Volume: 100}, 100},
+		{"movement only", TrendingWeights{Movement: 100}, 100},
+		{"custom", TrendingWeights{Volume: 1, Movement: 2, Velocity: 3, Interest: 4, Recency: 5}, 15},
+		{"zero", TrendingWeights{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := TrendingScorer{Weights: tt.weights, HalfLife: 24 * time.Hour}
+			if got := scorer.Score(&market, now); math.Abs(got-tt.want) > scoreTolerance {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendingWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights TrendingWeights
+		wantErr bool
+	}{
+		{"defaults", DefaultTrendingWeights(), false},
+		{"single weight", TrendingWeights{Interest: 1}, false},
+		{"all zero", TrendingWeights{}, true},
+		{"negative", TrendingWeights{Volume: 40, Recency: -1}, true},
+		{"negative with positive sum", TrendingWeights{Volume: 40, Movement: -10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.weights.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func volumeTerm(m TrendingMetrics) float64   { return m.VolumeScore }
+func movementTerm(m TrendingMetrics) float64 { return m.MovementScore }
+func velocityTerm(m TrendingMetrics) float64 { return m.VelocityScore }
+func interestTerm(m TrendingMetrics) float64 { return m.InterestScore }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// How a user signed in.
+const (
+	AuthProviderEmail  = "email"
+	AuthProviderGoogle = "google"
+)
+
+// User is a reader account. Accounts are keyed by email; signing in with a
+// magic link or Google for the same address reaches the same account.
+type User struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Email     string   `bson:"email" json:"email"`
+	Name      string   `bson:"name,omitempty" json:"name,omitempty"`
+	Picture   string   `bson:"picture,omitempty" json:"picture,omitempty"`
+	GoogleID  string   `bson:"google_id,omitempty" json:"-"`
+	Providers []string `bson:"providers" json:"providers"`
+
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	LastLoginAt time.Time `bson:"last_login_at" json:"last_login_at"`
+}
+
+// LoginToken is a single-use magic link token. Only its SHA-256 hash is
+// stored.
+type LoginToken struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	Email     string     `bson:"email" json:"email"`
+	Hash      string     `bson:"hash" json:"-"`
+	ExpiresAt time.Time  `bson:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+}
+
+// ViewHistoryRetention is how long a user's article views are kept for
+// personalization.
+const ViewHistoryRetention = 90 * 24 * time.Hour
+
+// ArticleView records that a signed-in user read an article. Repeat reads
+// of the same article update ViewedAt.
+type ArticleView struct {
+	UserID    primitive.ObjectID `bson:"user_id" json:"-"`
+	ArticleID primitive.ObjectID `bson:"article_id" json:"article_id"`
+	Category  string             `bson:"category" json:"category"`
+	ViewedAt  time.Time          `bson:"viewed_at" json:"viewed_at"`
+}
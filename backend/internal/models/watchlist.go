@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Watchlist limits per owner.
+const (
+	MaxWatchlistsPerOwner  = 20
+	MaxMarketsPerWatchlist = 100
+)
+
+// Watchlist is a named set of markets followed by an anonymous device or an
+// API key.
+type Watchlist struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+
+	// Owner is "user:" and the user ID, "key:" and the API key ID, or
+	// "device:" and a hash of the device token; never a raw credential
+	Owner string `bson:"owner" json:"-"`
+
+	Name      string          `bson:"name" json:"name"`
+	MarketIDs []string        `bson:"market_ids" json:"market_ids"`
+	Alerts    WatchlistAlerts `bson:"alerts" json:"alerts"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// UserOwner is the watchlist owner for a signed-in user.
+func UserOwner(userID string) string {
+	return "user:" + userID
+}
+
+// WatchlistAlerts configures delivery of market events for a watchlist's
+// markets.
+type WatchlistAlerts struct {
+	Enabled    bool     `bson:"enabled" json:"enabled"`
+	WebhookURL string   `bson:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	EventTypes []string `bson:"event_types,omitempty" json:"event_types,omitempty"` // Empty means all
+}
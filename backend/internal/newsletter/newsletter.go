@@ -0,0 +1,333 @@
+// Package newsletter manages newsletter subscribers and emails the morning
+// briefing to them.
+package newsletter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html"))
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt"))
+)
+
+// ErrInvalidEmail is returned when a subscription address can't be parsed.
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// maxNewsletterMarkets is how many briefing markets are listed in the email.
+const maxNewsletterMarkets = 5
+
+// Config holds newsletter settings.
+type Config struct {
+	// Public site URL for article links, e.g. "https://futuresignals.news"
+	SiteURL string
+
+	// Public API URL for confirm and unsubscribe links
+	APIURL string
+
+	// Subscribers fetched and sent per batch
+	BatchSize int
+
+	// Pause between batches, to stay under provider send rates
+	BatchDelay time.Duration
+}
+
+// SendResult summarizes one newsletter send.
+type SendResult struct {
+	ArticleID primitive.ObjectID `json:"article_id"`
+	Sent      int                `json:"sent"`
+	Failed    int                `json:"failed"`
+}
+
+// Service manages subscriptions and newsletter delivery.
+type Service struct {
+	store  *storage.Store
+	mailer Mailer
+	config Config
+}
+
+// NewService creates a new newsletter service.
+func NewService(store *storage.Store, mailer Mailer, cfg Config) *Service {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	cfg.SiteURL = strings.TrimRight(cfg.SiteURL, "/")
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+
+	return &Service{
+		store:  store,
+		mailer: mailer,
+		config: cfg,
+	}
+}
+
+// Subscribe starts a double opt-in subscription and emails the confirmation
+// link. Subscribing an already confirmed address is a no-op, so the endpoint
+// doesn't reveal who is subscribed.
+func (s *Service) Subscribe(ctx context.Context, email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	confirmToken, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.store.GetSubscriberByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("lookup subscriber: %w", err)
+	}
+
+	switch {
+	case sub == nil:
+		unsubscribeToken, err := newToken()
+		if err != nil {
+			return err
+		}
+		sub = &models.Subscriber{
+			Email:            email,
+			Status:           models.SubscriberPending,
+			ConfirmToken:     confirmToken,
+			UnsubscribeToken: unsubscribeToken,
+		}
+		if err := s.store.CreateSubscriber(ctx, sub); err != nil {
+			return fmt.Errorf("create subscriber: %w", err)
+		}
+	case sub.Status == models.SubscriberConfirmed:
+		return nil
+	default:
+		// Pending (resend) or unsubscribed (re-subscribe): issue a new token
+		if err := s.store.ResetSubscriberConfirmation(ctx, sub.ID, confirmToken); err != nil {
+			return fmt.Errorf("reset subscriber: %w", err)
+		}
+	}
+
+	return s.sendConfirmation(ctx, email, confirmToken)
+}
+
+// Confirm completes a subscription. It returns nil if the token is unknown
+// or already used.
+func (s *Service) Confirm(ctx context.Context, token string) (*models.Subscriber, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return s.store.ConfirmSubscriber(ctx, token)
+}
+
+// Unsubscribe opts out the subscriber holding token. It returns false if
+// the token is unknown or already unsubscribed.
+func (s *Service) Unsubscribe(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	return s.store.UnsubscribeSubscriber(ctx, token)
+}
+
+// SendMorningBriefing emails today's morning briefing to confirmed
+// subscribers. It is safe to re-run: subscribers already sent today's
+// briefing are skipped.
+func (s *Service) SendMorningBriefing(ctx context.Context) error {
+	slug := fmt.Sprintf("%s-briefing-%s", models.BriefingMorning, time.Now().Format("2006-01-02"))
+	article, err := s.store.GetArticleBySlug(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("morning briefing %s not found: %w", slug, err)
+	}
+
+	result, err := s.SendBriefing(ctx, article)
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("newsletter sent to %d subscribers, %d failed", result.Sent, result.Failed)
+	}
+	return nil
+}
+
+// SendBriefing emails article to every confirmed subscriber who hasn't
+// received it yet, in batches.
+func (s *Service) SendBriefing(ctx context.Context, article *models.Article) (*SendResult, error) {
+	result := &SendResult{ArticleID: article.ID}
+	subject := article.Headline
+	data := s.briefingData(article)
+
+	var afterID primitive.ObjectID
+	for {
+		batch, err := s.store.GetNewsletterRecipients(ctx, article.ID, afterID, int64(s.config.BatchSize))
+		if err != nil {
+			return result, fmt.Errorf("fetch recipients: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		sent := make([]primitive.ObjectID, 0, len(batch))
+		for _, sub := range batch {
+			afterID = sub.ID
+
+			data.UnsubscribeURL = s.unsubscribeURL(sub.UnsubscribeToken)
+			msg, err := renderMessage("briefing", data)
+			if err != nil {
+				return result, err
+			}
+			msg.To = sub.Email
+			msg.Subject = subject
+			msg.Headers = map[string]string{
+				"List-Unsubscribe":      "<" + data.UnsubscribeURL + ">",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			}
+
+			if err := s.mailer.Send(ctx, msg); err != nil {
+				log.Warn().Err(err).Str("subscriber", sub.ID.Hex()).Msg("Failed to send newsletter")
+				metrics.NewsletterEmails.WithLabelValues("error").Inc()
+				result.Failed++
+				continue
+			}
+			metrics.NewsletterEmails.WithLabelValues("ok").Inc()
+			sent = append(sent, sub.ID)
+			result.Sent++
+		}
+
+		if err := s.store.MarkNewsletterSent(ctx, sent, article.ID); err != nil {
+			return result, fmt.Errorf("mark sent: %w", err)
+		}
+
+		if len(batch) < s.config.BatchSize {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(s.config.BatchDelay):
+		}
+	}
+
+	log.Info().
+		Str("slug", article.Slug).
+		Int("sent", result.Sent).
+		Int("failed", result.Failed).
+		Msg("Newsletter sent")
+
+	return result, nil
+}
+
+func (s *Service) sendConfirmation(ctx context.Context, email, token string) error {
+	msg, err := renderMessage("confirm", struct{ ConfirmURL string }{
+		ConfirmURL: s.config.APIURL + "/api/newsletter/confirm?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = email
+	msg.Subject = "Confirm your FutureSignals subscription"
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("send confirmation: %w", err)
+	}
+	return nil
+}
+
+// SendLoginLink emails a magic sign-in link. It lets the account service
+// send mail through the newsletter's mailer and templates.
+func (s *Service) SendLoginLink(ctx context.Context, email, link string) error {
+	msg, err := renderMessage("login", struct{ LoginURL string }{LoginURL: link})
+	if err != nil {
+		return err
+	}
+	msg.To = email
+	msg.Subject = "Sign in to FutureSignals"
+	return s.mailer.Send(ctx, msg)
+}
+
+func (s *Service) unsubscribeURL(token string) string {
+	return s.config.APIURL + "/api/newsletter/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// briefingData is the template data for the briefing email.
+type briefingData struct {
+	Article        *models.Article
+	ArticleURL     string
+	Markets        []briefingMarket
+	UnsubscribeURL string
+}
+
+type briefingMarket struct {
+	Question    string
+	URL         string
+	Probability string
+	Change      string
+	Up          bool
+}
+
+func (s *Service) briefingData(article *models.Article) *briefingData {
+	data := &briefingData{
+		Article:    article,
+		ArticleURL: s.config.SiteURL + "/article/" + article.Slug,
+	}
+	for i, m := range article.Markets {
+		if i == maxNewsletterMarkets {
+			break
+		}
+		data.Markets = append(data.Markets, briefingMarket{
+			Question:    m.Question,
+			URL:         s.config.SiteURL + "/market/" + m.Slug,
+			Probability: fmt.Sprintf("%.0f%%", m.Probability*100),
+			Change:      fmt.Sprintf("%+.1f pts", m.Change24h*100),
+			Up:          m.Change24h >= 0,
+		})
+	}
+	return data
+}
+
+// renderMessage renders the name.html and name.txt templates.
+func renderMessage(name string, data interface{}) (*Message, error) {
+	var html, text bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("render %s.html: %w", name, err)
+	}
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return nil, fmt.Errorf("render %s.txt: %w", name, err)
+	}
+	return &Message{HTML: html.String(), Text: text.String()}, nil
+}
+
+// normalizeEmail validates a bare address and lowercases it.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > 254 {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(email), nil
+}
+
+// newToken returns a random URL-safe token.
+func newToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,395 @@
+// Package notify routes generated articles and watchlist alert hits to
+// signed-in users according to their notification preferences.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/alerts"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/newsletter"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Delivery channels, as reported in metrics.
+const (
+	ChannelEmail    = "email"
+	ChannelTelegram = "telegram"
+	ChannelWebhook  = "webhook"
+)
+
+// Notification kinds.
+const (
+	KindArticle = "article"
+	KindAlert   = "alert"
+)
+
+const (
+	// queueSize is the number of notifications buffered for delivery.
+	queueSize = 500
+
+	telegramAPIURL = "https://api.telegram.org"
+)
+
+// Config holds notification delivery configuration.
+type Config struct {
+	// Public site URL for article and market links
+	SiteURL string
+
+	// Telegram bot token; the Telegram channel is disabled when empty
+	TelegramBotToken string
+
+	// Delivery cap per user per rolling hour (0 = unlimited)
+	MaxPerHour int
+}
+
+// Notification is one article or alert hit to deliver.
+type Notification struct {
+	Kind         string              `json:"kind"`
+	Title        string              `json:"title"`
+	Summary      string              `json:"summary,omitempty"`
+	URL          string              `json:"url"`
+	Category     string              `json:"category"`
+	Significance models.Significance `json:"significance"`
+	Timestamp    time.Time           `json:"timestamp"`
+
+	// The article's social blurb and push notification line, when it has
+	// short-form variants
+	Social string `json:"social,omitempty"`
+	Push   string `json:"push,omitempty"`
+
+	// Alert is set for alert hits
+	Alert *alerts.Payload `json:"alert,omitempty"`
+}
+
+// PreferenceStore reads users' notification preferences. *storage.Store
+// implements it.
+type PreferenceStore interface {
+	GetNotificationPreferences(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error)
+	GetArticleRecipients(ctx context.Context, category string) ([]models.NotificationPreferences, error)
+}
+
+// job is a queued notification with its recipients; a nil userID means
+// recipients are looked up by category.
+type job struct {
+	note   Notification
+	userID *primitive.ObjectID
+}
+
+// Dispatcher delivers notifications over each user's enabled channels,
+// honoring categories, minimum significance and quiet hours.
+type Dispatcher struct {
+	store      PreferenceStore
+	mailer     newsletter.Mailer
+	config     Config
+	httpClient *http.Client
+	limiter    *ratelimit.Limiter
+
+	queue  chan job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// Closed by Drain to deliver what is queued, then stop
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+// NewDispatcher creates a dispatcher. Email delivery needs a mailer, set
+// with SetMailer. Call Start to begin delivering.
+func NewDispatcher(store PreferenceStore, cfg Config) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg.SiteURL = strings.TrimRight(cfg.SiteURL, "/")
+
+	return &Dispatcher{
+		store:      store,
+		config:     cfg,
+		httpClient: alerts.WebhookClient(10 * time.Second),
+		limiter:    ratelimit.New(2 * time.Hour),
+		queue:      make(chan job, queueSize),
+		ctx:        ctx,
+		cancel:     cancel,
+		draining:   make(chan struct{}),
+	}
+}
+
+// SetMailer enables the email channel.
+func (d *Dispatcher) SetMailer(m newsletter.Mailer) {
+	d.mailer = m
+}
+
+// Start starts the delivery loop.
+func (d *Dispatcher) Start() {
+	log.Info().
+		Bool("email", d.mailer != nil).
+		Bool("telegram", d.config.TelegramBotToken != "").
+		Msg("Starting notification dispatcher")
+
+	d.wg.Add(1)
+	go d.run()
+}
+
+// Stop stops delivering. Queued notifications are discarded.
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// Drain delivers the queued notifications, then stops. Deliveries still
+// in progress when ctx is done are cancelled and the rest discarded.
+func (d *Dispatcher) Drain(ctx context.Context) {
+	d.drainOnce.Do(func() { close(d.draining) })
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn().Int("queued", len(d.queue)).Msg("Notification drain timed out, discarding queued notifications")
+		d.cancel()
+		<-done
+	}
+	d.cancel()
+}
+
+// PublishArticle queues a newly published article for every interested
+// user. It implements content.Publisher.
+func (d *Dispatcher) PublishArticle(_ context.Context, article *models.Article) {
+	note := Notification{
+		Kind:         KindArticle,
+		Title:        article.Headline,
+		Summary:      article.Summary,
+		URL:          d.config.SiteURL + "/article/" + article.Slug,
+		Category:     article.Category,
+		Significance: article.Significance,
+		Timestamp:    article.PublishedAt,
+	}
+	if s := article.ShortForm; s != nil {
+		note.Social, note.Push = s.Social, s.Push
+	}
+	d.enqueue(job{note: note})
+}
+
+// NotifyAlert queues a watchlist alert hit for the watchlist's owner. It
+// implements alerts.Notifier.
+func (d *Dispatcher) NotifyAlert(_ context.Context, userID string, alert alerts.Payload, significance models.Significance, category string) {
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return
+	}
+	d.enqueue(job{
+		userID: &id,
+		note: Notification{
+			Kind:         KindAlert,
+			Title:        alertTitle(alert),
+			URL:          alert.URL,
+			Category:     category,
+			Significance: significance,
+			Timestamp:    alert.Timestamp,
+			Alert:        &alert,
+		},
+	})
+}
+
+func (d *Dispatcher) enqueue(j job) {
+	select {
+	case d.queue <- j:
+	default:
+		log.Warn().Str("kind", j.note.Kind).Msg("Notification queue full, dropping notification")
+		metrics.NotificationsSent.WithLabelValues("queue", "dropped").Inc()
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case j := <-d.queue:
+			d.dispatchJob(j)
+		case <-d.draining:
+			for {
+				select {
+				case j := <-d.queue:
+					if d.ctx.Err() != nil {
+						return
+					}
+					d.dispatchJob(j)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// dispatchJob dispatches one queued job under its own timeout.
+func (d *Dispatcher) dispatchJob(j job) {
+	ctx, cancel := context.WithTimeout(d.ctx, 2*time.Minute)
+	defer cancel()
+	d.dispatch(ctx, j)
+}
+
+// dispatch resolves a job's recipients and delivers to each one who wants it.
+func (d *Dispatcher) dispatch(ctx context.Context, j job) {
+	var recipients []models.NotificationPreferences
+	if j.userID != nil {
+		prefs, err := d.store.GetNotificationPreferences(ctx, *j.userID)
+		if err != nil {
+			log.Warn().Err(err).Str("user", j.userID.Hex()).Msg("Failed to load notification preferences")
+			return
+		}
+		if prefs == nil || !prefs.Alerts {
+			return
+		}
+		recipients = append(recipients, *prefs)
+	} else {
+		var err error
+		recipients, err = d.store.GetArticleRecipients(ctx, j.note.Category)
+		if err != nil {
+			log.Warn().Err(err).Str("category", j.note.Category).Msg("Failed to load notification recipients")
+			return
+		}
+	}
+
+	now := time.Now()
+	for i := range recipients {
+		prefs := &recipients[i]
+		if !prefs.Wants(j.note.Category, j.note.Significance, now) {
+			continue
+		}
+		if !d.limiter.Allow(prefs.UserID.Hex(), d.config.MaxPerHour, time.Hour) {
+			metrics.NotificationsSent.WithLabelValues("all", "capped").Inc()
+			continue
+		}
+		d.deliver(ctx, prefs, j.note)
+	}
+}
+
+// deliver sends a notification over each of the user's channels.
+func (d *Dispatcher) deliver(ctx context.Context, prefs *models.NotificationPreferences, note Notification) {
+	send := func(channel string, fn func() error) {
+		if err := fn(); err != nil {
+			log.Warn().Err(err).Str("user", prefs.UserID.Hex()).Str("channel", channel).Msg("Notification delivery failed")
+			metrics.NotificationsSent.WithLabelValues(channel, "error").Inc()
+			return
+		}
+		metrics.NotificationsSent.WithLabelValues(channel, "ok").Inc()
+	}
+
+	if prefs.Channels.Email && prefs.Email != "" && d.mailer != nil {
+		send(ChannelEmail, func() error { return d.sendEmail(ctx, prefs.Email, note) })
+	}
+	if prefs.Channels.TelegramChatID != "" && d.config.TelegramBotToken != "" {
+		send(ChannelTelegram, func() error { return d.sendTelegram(ctx, prefs.Channels.TelegramChatID, note) })
+	}
+	if prefs.Channels.WebhookURL != "" {
+		send(ChannelWebhook, func() error { return d.sendWebhook(ctx, prefs.Channels.WebhookURL, note) })
+	}
+}
+
+// ============================================================================
+// CHANNELS
+// ============================================================================
+
+func (d *Dispatcher) sendEmail(ctx context.Context, to string, note Notification) error {
+	text := note.Title + "\n\n"
+	if note.Summary != "" {
+		text += note.Summary + "\n\n"
+	}
+	text += note.URL + "\n\nManage notifications in your FutureSignals account settings.\n"
+
+	body := "<p style=\"font-size:17px;font-weight:600;\">" + html.EscapeString(note.Title) + "</p>"
+	if note.Summary != "" {
+		body += "<p style=\"font-size:15px;line-height:1.6;\">" + html.EscapeString(note.Summary) + "</p>"
+	}
+	body += "<p><a href=\"" + html.EscapeString(note.URL) + "\">Read on FutureSignals</a></p>" +
+		"<p style=\"font-size:13px;color:#888888;\">Manage notifications in your FutureSignals account settings.</p>"
+
+	return d.mailer.Send(ctx, &newsletter.Message{
+		To:      to,
+		Subject: note.Title,
+		HTML:    "<!DOCTYPE html><html><body style=\"font-family:sans-serif;color:#111111;\">" + body + "</body></html>",
+		Text:    text,
+	})
+}
+
+func (d *Dispatcher) sendTelegram(ctx context.Context, chatID string, note Notification) error {
+	text := note.Title
+	if note.Social != "" {
+		text = note.Social
+	}
+	text += "\n" + note.URL
+	return d.postJSON(ctx, telegramAPIURL+"/bot"+d.config.TelegramBotToken+"/sendMessage", map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	})
+}
+
+func (d *Dispatcher) sendWebhook(ctx context.Context, url string, note Notification) error {
+	return d.postJSON(ctx, url, note)
+}
+
+func (d *Dispatcher) postJSON(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		// Don't log the URL; Telegram URLs carry the bot token
+		return fmt.Errorf("post notification failed")
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 16<<10))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func alertTitle(alert alerts.Payload) string {
+	switch syncer.EventType(alert.Type) {
+	case syncer.EventBreakingMove:
+		return "Breaking move: " + alert.Question
+	case syncer.EventVolumeSpike:
+		return "Volume spike: " + alert.Question
+	case syncer.EventThresholdCross:
+		return "Threshold crossed: " + alert.Question
+	case syncer.EventWhaleTrade:
+		return "Whale trade: " + alert.Question
+	case syncer.EventMarketResolved:
+		return "Resolved: " + alert.Question
+	case syncer.EventSocialSignal:
+		return "Social signal: " + alert.Question
+	default:
+		return alert.Question
+	}
+}
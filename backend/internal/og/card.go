@@ -0,0 +1,149 @@
+package og
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// Card dimensions, the size social platforms expect for large previews.
+const (
+	cardWidth  = 1200
+	cardHeight = 630
+)
+
+var (
+	backgroundColor = color.RGBA{0x0B, 0x0E, 0x14, 0xFF}
+	textColor       = color.RGBA{0xF5, 0xF6, 0xFA, 0xFF}
+	mutedColor      = color.RGBA{0x8A, 0x90, 0x9C, 0xFF}
+	trackColor      = color.RGBA{0x2A, 0x2F, 0x3A, 0xFF}
+	upColor         = color.RGBA{0x2E, 0xD5, 0x73, 0xFF}
+	downColor       = color.RGBA{0xFF, 0x47, 0x57, 0xFF}
+	brandColor      = color.RGBA{0x53, 0x52, 0xED, 0xFF}
+)
+
+// brandName is drawn in the top-right corner of every card.
+const brandName = "FUTURESIGNALS"
+
+// card is what a share image shows for an article.
+type card struct {
+	Headline string
+	Category string
+	Accent   color.RGBA
+
+	// Primary market odds; HasMarket is false for articles without one
+	HasMarket   bool
+	Probability float64
+	Change24h   float64
+}
+
+// cardFor builds the card for an article, using its primary market (or
+// first market) for the gauge.
+func cardFor(article *models.Article) card {
+	c := card{
+		Headline: article.Headline,
+		Category: strings.ToUpper(article.Category),
+		Accent:   brandColor,
+	}
+	if cat := models.GetCategoryBySlug(article.Category); cat != nil {
+		c.Category = strings.ToUpper(cat.Name)
+		if accent, ok := parseHexColor(cat.Color); ok {
+			c.Accent = accent
+		}
+	}
+
+	market := article.PrimaryMarket
+	if market == nil && len(article.Markets) > 0 {
+		market = &article.Markets[0]
+	}
+	if market != nil {
+		c.HasMarket = true
+		c.Probability = clamp01(market.Probability)
+		c.Change24h = market.Change24h
+	}
+	return c
+}
+
+// probabilityLabel formats the gauge reading, e.g. "72%".
+func (c card) probabilityLabel() string {
+	return fmt.Sprintf("%.0f%%", c.Probability*100)
+}
+
+// changeLabel formats the 24h change in points, e.g. "+5.2 PTS".
+func (c card) changeLabel() string {
+	return fmt.Sprintf("%+.1f PTS", c.Change24h*100)
+}
+
+// changeColor is green for gains and red for losses.
+func (c card) changeColor() color.RGBA {
+	if c.Change24h < 0 {
+		return downColor
+	}
+	return upColor
+}
+
+// wrapText breaks text into at most maxLines lines of at most maxChars
+// characters on word boundaries, ending with "..." when it doesn't fit.
+func wrapText(text string, maxChars, maxLines int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for len([]rune(word)) > maxChars {
+			// Break words longer than a line
+			r := []rune(word)
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, string(r[:maxChars]))
+			word = string(r[maxChars:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= maxChars:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		if len(last) > maxChars-3 {
+			last = last[:maxChars-3]
+		}
+		lines[maxLines-1] = strings.TrimRight(string(last), " ") + "..."
+	}
+	return lines
+}
+
+// parseHexColor parses "#RRGGBB".
+func parseHexColor(s string) (color.RGBA, bool) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xFF}, true
+}
+
+// hexColor formats c as "#RRGGBB".
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
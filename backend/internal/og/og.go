@@ -0,0 +1,94 @@
+// Package og renders social share (Open Graph) images for articles: the
+// headline, a probability gauge and 24h change for the primary market, in
+// the article's category color.
+package og
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// Image formats.
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// Config holds share image configuration.
+type Config struct {
+	// FormatPNG or FormatSVG. Most social platforms only accept raster
+	// images, so PNG is the default.
+	Format string
+
+	// Timeout for rendering and storing one image
+	Timeout time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		Format:  FormatPNG,
+		Timeout: 30 * time.Second,
+	}
+}
+
+// Storage saves a rendered image and returns its public URL; see the blob
+// package.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+}
+
+// Renderer renders share images and stores them. It implements
+// content.ImageRenderer.
+type Renderer struct {
+	storage Storage
+	config  Config
+}
+
+// NewRenderer creates a new renderer.
+func NewRenderer(storage Storage, cfg Config) *Renderer {
+	if cfg.Format != FormatSVG {
+		cfg.Format = FormatPNG
+	}
+	return &Renderer{storage: storage, config: cfg}
+}
+
+// RenderArticle renders an article's share image, stores it under the
+// article's slug and returns its URL.
+func (r *Renderer) RenderArticle(ctx context.Context, article *models.Article) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	c := cardFor(article)
+
+	var (
+		data        []byte
+		contentType string
+		err         error
+	)
+	switch r.config.Format {
+	case FormatSVG:
+		data, contentType = renderSVG(c), "image/svg+xml"
+	default:
+		data, err = renderPNG(c)
+		contentType = "image/png"
+	}
+	if err != nil {
+		metrics.OGImagesRendered.WithLabelValues("error").Inc()
+		return "", fmt.Errorf("render share image: %w", err)
+	}
+
+	key := fmt.Sprintf("articles/%s.%s", article.Slug, r.config.Format)
+	url, err := r.storage.Put(ctx, key, contentType, data)
+	if err != nil {
+		metrics.OGImagesRendered.WithLabelValues("error").Inc()
+		return "", fmt.Errorf("store share image: %w", err)
+	}
+
+	metrics.OGImagesRendered.WithLabelValues("ok").Inc()
+	return url, nil
+}
@@ -0,0 +1,102 @@
+package og
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// Layout shared by the PNG and SVG renderers.
+const (
+	marginX       = 64
+	accentWidth   = 16
+	headlineTop   = 140
+	headlineScale = 6 // Bitmap font scale; glyph cells are 6x6 pixels
+	headlineChars = 29
+	headlineLines = 4
+	headlineStep  = 60
+
+	gaugeX     = 220
+	gaugeY     = 580
+	gaugeOuter = 150
+	gaugeInner = 112
+)
+
+// renderPNG draws a card with the built-in bitmap font.
+func renderPNG(c card) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{backgroundColor}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, accentWidth, cardHeight), &image.Uniform{c.Accent}, image.Point{}, draw.Src)
+
+	drawText(img, marginX, 56, c.Category, 4, c.Accent)
+	drawText(img, cardWidth-marginX-textWidth(brandName, 4), 56, brandName, 4, mutedColor)
+
+	for i, line := range wrapText(c.Headline, headlineChars, headlineLines) {
+		drawText(img, marginX, headlineTop+i*headlineStep, line, headlineScale, textColor)
+	}
+
+	if c.HasMarket {
+		drawGauge(img, c.Probability, c.Accent)
+		label := c.probabilityLabel()
+		drawText(img, gaugeX-textWidth(label, 7)/2, gaugeY-glyphHeight*7, label, 7, textColor)
+
+		drawText(img, 460, 470, "24H CHANGE", 3, mutedColor)
+		drawText(img, 460, 505, c.changeLabel(), 8, c.changeColor())
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawGauge draws a half-ring filled from the left in proportion to p.
+func drawGauge(img *image.RGBA, p float64, fill color.RGBA) {
+	for y := gaugeY - gaugeOuter; y <= gaugeY; y++ {
+		for x := gaugeX - gaugeOuter; x <= gaugeX+gaugeOuter; x++ {
+			dx, dy := float64(x-gaugeX), float64(gaugeY-y)
+			r := math.Hypot(dx, dy)
+			if r < gaugeInner || r > gaugeOuter {
+				continue
+			}
+			// Share of the arc from the left end to this point
+			share := 1 - math.Atan2(dy, dx)/math.Pi
+			if share <= p {
+				img.SetRGBA(x, y, fill)
+			} else {
+				img.SetRGBA(x, y, trackColor)
+			}
+		}
+	}
+}
+
+// drawText draws text with its top-left corner at (x, y), each font cell
+// scale pixels square.
+func drawText(img *image.RGBA, x, y int, text string, scale int, c color.RGBA) {
+	for _, r := range text {
+		g := glyph(r)
+		for row, bits := range g {
+			for col, bit := range bits {
+				if bit != '#' {
+					continue
+				}
+				cell := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(img, cell, &image.Uniform{c}, image.Point{}, draw.Src)
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+// textWidth is the width drawText takes for text at scale.
+func textWidth(text string, scale int) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return (n*(glyphWidth+1) - 1) * scale
+}
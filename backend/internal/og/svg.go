@@ -0,0 +1,61 @@
+package og
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+)
+
+// svgFont is the font stack for SVG cards; renderers substitute their own
+// sans-serif if none is installed.
+const svgFont = "Helvetica, Arial, sans-serif"
+
+// renderSVG draws a card as SVG with the same layout as renderPNG.
+func renderSVG(c card) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s">`,
+		cardWidth, cardHeight, cardWidth, cardHeight, svgFont)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(backgroundColor))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`, accentWidth, cardHeight, hexColor(c.Accent))
+
+	fmt.Fprintf(&b, `<text x="%d" y="84" font-size="30" font-weight="700" letter-spacing="3" fill="%s">%s</text>`,
+		marginX, hexColor(c.Accent), html.EscapeString(c.Category))
+	fmt.Fprintf(&b, `<text x="%d" y="84" font-size="30" font-weight="700" letter-spacing="3" text-anchor="end" fill="%s">%s</text>`,
+		cardWidth-marginX, hexColor(mutedColor), brandName)
+
+	for i, line := range wrapText(c.Headline, headlineChars+6, headlineLines) {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="54" font-weight="700" fill="%s">%s</text>`,
+			marginX, headlineTop+42+i*headlineStep, hexColor(textColor), html.EscapeString(line))
+	}
+
+	if c.HasMarket {
+		r := float64(gaugeOuter+gaugeInner) / 2
+		width := gaugeOuter - gaugeInner
+		fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" stroke-width="%d"/>`,
+			arcPath(r, 1), hexColor(trackColor), width)
+		if c.Probability > 0 {
+			fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" stroke-width="%d"/>`,
+				arcPath(r, c.Probability), hexColor(c.Accent), width)
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="60" font-weight="700" text-anchor="middle" fill="%s">%s</text>`,
+			gaugeX, gaugeY-4, hexColor(textColor), html.EscapeString(c.probabilityLabel()))
+
+		fmt.Fprintf(&b, `<text x="460" y="490" font-size="24" font-weight="700" letter-spacing="2" fill="%s">24H CHANGE</text>`,
+			hexColor(mutedColor))
+		fmt.Fprintf(&b, `<text x="460" y="560" font-size="64" font-weight="700" fill="%s">%s</text>`,
+			hexColor(c.changeColor()), html.EscapeString(c.changeLabel()))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.Bytes()
+}
+
+// arcPath is the gauge's half-ring of radius r from the left end through
+// share p of the way to the right end.
+func arcPath(r, p float64) string {
+	theta := math.Pi * (1 - p)
+	endX := float64(gaugeX) + r*math.Cos(theta)
+	endY := float64(gaugeY) - r*math.Sin(theta)
+	return fmt.Sprintf("M %.1f %d A %.1f %.1f 0 0 1 %.1f %.1f", float64(gaugeX)-r, gaugeY, r, r, endX, endY)
+}
@@ -23,6 +23,7 @@ const (
 	// Rate limits (requests per 10 seconds)
 	GammaRateLimit  = 750
 	DataRateLimit   = 200
+	CLOBRateLimit   = 500
 	MarketsLimit    = 125
 	EventsLimit     = 100
 )
@@ -34,24 +35,23 @@ type Client struct {
 	clob  *resty.Client
 }
 
-// NewClient creates a new Polymarket client.
+// NewClient creates a new Polymarket client with the default rate limits
+// and circuit breakers.
 func NewClient() *Client {
+	return NewClientWithConfig(DefaultThrottleConfig())
+}
+
+// NewClientWithConfig creates a new Polymarket client with the given rate
+// limits and circuit breaker settings.
+func NewClientWithConfig(config ThrottleConfig) *Client {
 	return &Client{
-		gamma: resty.New().
-			SetBaseURL(GammaAPIBase).
-			SetTimeout(30 * time.Second).
-			SetRetryCount(3).
-			SetRetryWaitTime(1 * time.Second),
-		data: resty.New().
-			SetBaseURL(DataAPIBase).
-			SetTimeout(30 * time.Second).
-			SetRetryCount(3).
-			SetRetryWaitTime(1 * time.Second),
-		clob: resty.New().
-			SetBaseURL(CLOBAPIBase).
-			SetTimeout(30 * time.Second).
-			SetRetryCount(3).
-			SetRetryWaitTime(1 * time.Second),
+		gamma: newAPIClient("gamma", GammaAPIBase, map[string]int{
+			"":         config.GammaLimit,
+			"/markets": config.MarketsLimit,
+			"/events":  config.EventsLimit,
+		}, config),
+		data: newAPIClient("data", DataAPIBase, map[string]int{"": config.DataLimit}, config),
+		clob: newAPIClient("clob", CLOBAPIBase, map[string]int{"": config.CLOBLimit}, config),
 	}
 }
 
@@ -124,6 +124,9 @@ type Market struct {
 	OneWeekPriceChange    float64         `json:"oneWeekPriceChange"`
 	ResolutionSource      string          `json:"resolutionSource"`
 
+	// Parent events, included when a market is fetched on its own
+	Events                []Event         `json:"events,omitempty"`
+
 	// Computed fields
 	YesPrice              float64         `json:"-"`
 	NoPrice               float64         `json:"-"`
@@ -152,6 +155,7 @@ type Event struct {
 	Tags             []Tag     `json:"tags"`
 	SeriesSlug       string    `json:"seriesSlug"`
 	ResolutionSource string    `json:"resolutionSource"`
+	NegRisk          bool      `json:"negRisk"`
 	CreatedAt        time.Time `json:"-"`
 }
 
@@ -162,19 +166,127 @@ type Tag struct {
 	Slug  string `json:"slug"`
 }
 
-// Trade represents a single trade.
+// Trade represents a single trade as reported by the Data API.
 type Trade struct {
-	ID            string    `json:"id"`
-	TakerOrderID  string    `json:"taker_order_id"`
-	MarketID      string    `json:"market"`
-	AssetID       string    `json:"asset_id"`
-	Side          string    `json:"side"`
-	Size          string    `json:"size"`
-	Price         string    `json:"price"`
-	Outcome       string    `json:"outcome"`
-	FeeRateBps    string    `json:"fee_rate_bps"`
-	Timestamp     int64     `json:"timestamp"`
-	TransactionID string    `json:"transaction_hash"`
+	ProxyWallet     string  `json:"proxyWallet"`
+	Side            string  `json:"side"` // "BUY" or "SELL" of the outcome token
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	Size            float64 `json:"size"`  // Shares
+	Price           float64 `json:"price"` // Per share, 0-1
+	Timestamp       int64   `json:"timestamp"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	TransactionHash string  `json:"transactionHash"`
+	Pseudonym       string  `json:"pseudonym,omitempty"`
+}
+
+// Notional returns the trade's value in USD.
+func (t *Trade) Notional() float64 {
+	return t.Size * t.Price
+}
+
+// TokenHolders lists the largest holders of one outcome token.
+type TokenHolders struct {
+	Token   string   `json:"token"`
+	Holders []Holder `json:"holders"`
+}
+
+// Holder is a wallet's position in an outcome token.
+type Holder struct {
+	ProxyWallet  string  `json:"proxyWallet"`
+	Amount       float64 `json:"amount"` // Shares
+	OutcomeIndex int     `json:"outcomeIndex"`
+	Name         string  `json:"name,omitempty"`
+	Pseudonym    string  `json:"pseudonym,omitempty"`
+}
+
+// CLOBMarket is a market as reported by the CLOB API, which flags the
+// winning token once the market resolves.
+type CLOBMarket struct {
+	ConditionID string      `json:"condition_id"`
+	Question    string      `json:"question"`
+	Active      bool        `json:"active"`
+	Closed      bool        `json:"closed"`
+	Tokens      []CLOBToken `json:"tokens"`
+}
+
+// CLOBToken is one outcome token of a CLOB market.
+type CLOBToken struct {
+	TokenID string  `json:"token_id"`
+	Outcome string  `json:"outcome"`
+	Price   float64 `json:"price"`
+	Winner  bool    `json:"winner"`
+}
+
+// WinningOutcome returns the outcome of the winning token, or "" if the
+// market hasn't resolved.
+func (m *CLOBMarket) WinningOutcome() string {
+	for _, t := range m.Tokens {
+		if t.Winner {
+			return t.Outcome
+		}
+	}
+	return ""
+}
+
+// OrderBook is the CLOB order book for one outcome token. Prices and
+// sizes come as decimal strings.
+type OrderBook struct {
+	Market    string       `json:"market"`
+	AssetID   string       `json:"asset_id"`
+	Bids      []OrderLevel `json:"bids"`
+	Asks      []OrderLevel `json:"asks"`
+	Hash      string       `json:"hash"`
+	Timestamp string       `json:"timestamp"`
+}
+
+// OrderLevel is the resting size at one price.
+type OrderLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// BestBid returns the highest bid price, or 0 if there are no bids.
+func (b *OrderBook) BestBid() float64 {
+	best := 0.0
+	for _, l := range b.Bids {
+		if p, err := strconv.ParseFloat(l.Price, 64); err == nil && p > best {
+			best = p
+		}
+	}
+	return best
+}
+
+// BestAsk returns the lowest ask price, or 0 if there are no asks.
+func (b *OrderBook) BestAsk() float64 {
+	best := 0.0
+	for _, l := range b.Asks {
+		if p, err := strconv.ParseFloat(l.Price, 64); err == nil && (best == 0 || p < best) {
+			best = p
+		}
+	}
+	return best
+}
+
+// Depth returns the dollar value of bids priced at or above minBid and of
+// asks priced at or below maxAsk.
+func (b *OrderBook) Depth(minBid, maxAsk float64) (bids, asks float64) {
+	for _, l := range b.Bids {
+		p, perr := strconv.ParseFloat(l.Price, 64)
+		size, serr := strconv.ParseFloat(l.Size, 64)
+		if perr == nil && serr == nil && p >= minBid {
+			bids += p * size
+		}
+	}
+	for _, l := range b.Asks {
+		p, perr := strconv.ParseFloat(l.Price, 64)
+		size, serr := strconv.ParseFloat(l.Size, 64)
+		if perr == nil && serr == nil && p <= maxAsk {
+			asks += p * size
+		}
+	}
+	return bids, asks
 }
 
 // MarketFilters represents filters for market queries.
@@ -187,6 +299,7 @@ type MarketFilters struct {
 	Ascending   bool
 	TagSlug     string
 	TextQuery   string
+	IDs         []string // Fetch these market IDs only
 }
 
 // EventFilters represents filters for event queries.
@@ -231,6 +344,9 @@ func (c *Client) GetMarkets(ctx context.Context, filters MarketFilters) ([]Marke
 	if filters.TextQuery != "" {
 		params.Set("_q", filters.TextQuery)
 	}
+	for _, id := range filters.IDs {
+		params.Add("id", id)
+	}
 
 	log.Debug().
 		Str("endpoint", "/markets").
@@ -396,10 +512,87 @@ func (c *Client) GetEvent(ctx context.Context, slug string) (*Event, error) {
 	return &event, nil
 }
 
-// GetTrades retrieves recent trades from Data API.
-func (c *Client) GetTrades(ctx context.Context, marketID string, limit int) ([]Trade, error) {
+// GetCLOBMarket retrieves a market from the CLOB API by condition ID.
+func (c *Client) GetCLOBMarket(ctx context.Context, conditionID string) (*CLOBMarket, error) {
+	resp, err := c.clob.R().
+		SetContext(ctx).
+		Get("/markets/" + conditionID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clob market: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("clob market API returned %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var market CLOBMarket
+	if err := json.Unmarshal(resp.Body(), &market); err != nil {
+		return nil, fmt.Errorf("failed to parse clob market: %w", err)
+	}
+
+	return &market, nil
+}
+
+// GetOrderBook retrieves the order book for an outcome token from the CLOB
+// API.
+func (c *Client) GetOrderBook(ctx context.Context, tokenID string) (*OrderBook, error) {
+	resp, err := c.clob.R().
+		SetContext(ctx).
+		SetQueryParam("token_id", tokenID).
+		Get("/book")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order book: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("order book API returned %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var book OrderBook
+	if err := json.Unmarshal(resp.Body(), &book); err != nil {
+		return nil, fmt.Errorf("failed to parse order book: %w", err)
+	}
+
+	return &book, nil
+}
+
+// GetMidpoint retrieves the midpoint between the best bid and ask for an
+// outcome token from the CLOB API.
+func (c *Client) GetMidpoint(ctx context.Context, tokenID string) (float64, error) {
+	resp, err := c.clob.R().
+		SetContext(ctx).
+		SetQueryParam("token_id", tokenID).
+		Get("/midpoint")
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch midpoint: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return 0, fmt.Errorf("midpoint API returned %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var result struct {
+		Mid string `json:"mid"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return 0, fmt.Errorf("failed to parse midpoint: %w", err)
+	}
+
+	mid, err := strconv.ParseFloat(result.Mid, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse midpoint %q: %w", result.Mid, err)
+	}
+	return mid, nil
+}
+
+// GetTrades retrieves a market's most recent trades, newest first, from
+// Data API. Markets are identified by condition ID.
+func (c *Client) GetTrades(ctx context.Context, conditionID string, limit int) ([]Trade, error) {
 	params := url.Values{}
-	params.Set("market", marketID)
+	params.Set("market", conditionID)
 	if limit > 0 {
 		params.Set("limit", strconv.Itoa(limit))
 	}
@@ -425,6 +618,37 @@ func (c *Client) GetTrades(ctx context.Context, marketID string, limit int) ([]T
 	return trades, nil
 }
 
+// GetHolders retrieves the largest holders of each of a market's outcome
+// tokens, up to limit per token, from Data API. Markets are identified by
+// condition ID.
+func (c *Client) GetHolders(ctx context.Context, conditionID string, limit int) ([]TokenHolders, error) {
+	params := url.Values{}
+	params.Set("market", conditionID)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := c.data.R().
+		SetContext(ctx).
+		SetQueryParamsFromValues(params).
+		Get("/holders")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holders: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("holders API returned %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var holders []TokenHolders
+	if err := json.Unmarshal(resp.Body(), &holders); err != nil {
+		return nil, fmt.Errorf("failed to parse holders: %w", err)
+	}
+
+	return holders, nil
+}
+
 // GetTopMarketsByVolume retrieves top markets by 24h volume.
 func (c *Client) GetTopMarketsByVolume(ctx context.Context, limit int) ([]Market, error) {
 	active := true
@@ -453,6 +677,15 @@ func (c *Client) GetActiveEventsByCategory(ctx context.Context, category string,
 	})
 }
 
+// GetMarketsByID retrieves specific markets in one request. Markets that
+// no longer exist are omitted from the result.
+func (c *Client) GetMarketsByID(ctx context.Context, ids []string) ([]Market, error) {
+	return c.GetMarkets(ctx, MarketFilters{
+		IDs:   ids,
+		Limit: len(ids),
+	})
+}
+
 // SearchMarkets searches for markets by text query.
 func (c *Client) SearchMarkets(ctx context.Context, query string, limit int) ([]Market, error) {
 	return c.GetMarkets(ctx, MarketFilters{
@@ -0,0 +1,283 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrCircuitOpen is returned without contacting the API while its circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("polymarket: circuit breaker open")
+
+// ThrottleConfig holds the client-side rate limits and circuit breaker
+// settings. Limits are requests per RateWindow; zero disables a limit.
+type ThrottleConfig struct {
+	RateWindow   time.Duration
+	GammaLimit   int
+	DataLimit    int
+	CLOBLimit    int
+	MarketsLimit int // Gamma /markets, within GammaLimit
+	EventsLimit  int // Gamma /events, within GammaLimit
+
+	// Consecutive 429/5xx/transport failures that open the breaker
+	FailureThreshold int
+
+	// How long the breaker stays open after its first trip, doubling on
+	// each further trip without a success, up to MaxOpenTimeout
+	OpenTimeout    time.Duration
+	MaxOpenTimeout time.Duration
+
+	// Retry backoff bounds; waits grow exponentially with jitter
+	RetryCount   int
+	RetryWait    time.Duration
+	RetryMaxWait time.Duration
+}
+
+// DefaultThrottleConfig returns limits matching Polymarket's documented
+// per-10-second budgets.
+func DefaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		RateWindow:       10 * time.Second,
+		GammaLimit:       GammaRateLimit,
+		DataLimit:        DataRateLimit,
+		CLOBLimit:        CLOBRateLimit,
+		MarketsLimit:     MarketsLimit,
+		EventsLimit:      EventsLimit,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		MaxOpenTimeout:   10 * time.Minute,
+		RetryCount:       3,
+		RetryWait:        1 * time.Second,
+		RetryMaxWait:     30 * time.Second,
+	}
+}
+
+// newAPIClient creates a resty client for one Polymarket API that waits for
+// rate limit tokens, fails fast while the API's breaker is open, and
+// retries 429/5xx responses with jittered exponential backoff.
+func newAPIClient(api, baseURL string, limits map[string]int, config ThrottleConfig) *resty.Client {
+	t := &throttle{
+		api:     api,
+		base:    http.DefaultTransport,
+		limiter: ratelimit.New(0),
+		limits:  limits,
+		window:  config.RateWindow,
+		breaker: &breaker{
+			api:        api,
+			threshold:  config.FailureThreshold,
+			timeout:    config.OpenTimeout,
+			maxTimeout: config.MaxOpenTimeout,
+		},
+	}
+
+	return resty.New().
+		SetBaseURL(baseURL).
+		SetTimeout(30 * time.Second).
+		SetTransport(t).
+		SetRetryCount(config.RetryCount).
+		SetRetryWaitTime(config.RetryWait).
+		SetRetryMaxWaitTime(config.RetryMaxWait).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(shouldRetry)
+}
+
+// shouldRetry retries transport errors, 429s and 5xx responses, but not
+// requests refused by an open breaker.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen)
+	}
+	return resp != nil && isFailureStatus(resp.StatusCode())
+}
+
+// retryAfter honors a Retry-After header given in seconds; zero falls back
+// to jittered exponential backoff.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil {
+		return 0, nil
+	}
+	if secs, err := strconv.Atoi(resp.Header().Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return 0, nil
+}
+
+func isFailureStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// throttle is an http.RoundTripper applying the rate limits and circuit
+// breaker to every attempt, retries included.
+type throttle struct {
+	api     string
+	base    http.RoundTripper
+	limiter *ratelimit.Limiter
+	limits  map[string]int // path prefix ("" for the whole API) -> limit
+	window  time.Duration
+	breaker *breaker
+}
+
+func (t *throttle) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	if err := t.wait(req.Context(), req.URL.Path); err != nil {
+		t.breaker.release()
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Canceled by the caller; says nothing about the API
+		t.breaker.release()
+	case err != nil:
+		metrics.PolymarketResponses.WithLabelValues(t.api, "error").Inc()
+		t.breaker.record(false)
+	default:
+		metrics.PolymarketResponses.WithLabelValues(t.api, statusClass(resp.StatusCode)).Inc()
+		t.breaker.record(!isFailureStatus(resp.StatusCode))
+	}
+	return resp, err
+}
+
+// wait blocks until the API-wide bucket and any bucket for the request
+// path have a token.
+func (t *throttle) wait(ctx context.Context, path string) error {
+	start := time.Now()
+	defer func() {
+		metrics.PolymarketThrottleWait.WithLabelValues(t.api).Observe(time.Since(start).Seconds())
+	}()
+
+	for prefix, limit := range t.limits {
+		if prefix != "" && !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		for {
+			ok, delay := t.limiter.Reserve(t.api+prefix, limit, t.window)
+			if ok {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+	}
+	return nil
+}
+
+func statusClass(code int) string {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return "429"
+	case code >= 500:
+		return "5xx"
+	case code >= 400:
+		return "4xx"
+	default:
+		return "2xx"
+	}
+}
+
+// breaker is a circuit breaker. It opens after threshold consecutive
+// failures, then lets a single probe through once the open timeout has
+// passed: success closes it, failure reopens it for twice as long.
+type breaker struct {
+	api        string
+	threshold  int
+	timeout    time.Duration
+	maxTimeout time.Duration
+
+	mu        sync.Mutex
+	failures  int       // consecutive failures while closed
+	trips     int       // consecutive trips without a success
+	openUntil time.Time // zero while closed
+	probing   bool
+}
+
+// allow returns ErrCircuitOpen unless the breaker is closed or this call
+// is the half-open probe.
+func (b *breaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+// release gives up a probe slot without recording an outcome.
+func (b *breaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// record counts the outcome of an attempt.
+func (b *breaker) record(ok bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ok {
+		if !b.openUntil.IsZero() {
+			log.Info().Str("api", b.api).Msg("Polymarket circuit breaker closed")
+			metrics.PolymarketCircuitOpen.WithLabelValues(b.api).Set(0)
+		}
+		b.failures, b.trips, b.openUntil, b.probing = 0, 0, time.Time{}, false
+		return
+	}
+
+	b.failures++
+	if b.probing || b.failures >= b.threshold {
+		b.trip()
+	}
+}
+
+// trip opens the breaker. Caller holds mu.
+func (b *breaker) trip() {
+	b.trips++
+	timeout := b.timeout << min(b.trips-1, 16)
+	if b.maxTimeout > 0 && timeout > b.maxTimeout {
+		timeout = b.maxTimeout
+	}
+	// Jitter so replicas don't all probe at once
+	timeout = timeout/2 + time.Duration(rand.Int63n(int64(timeout/2)+1))
+
+	b.openUntil = time.Now().Add(timeout)
+	b.failures = 0
+	b.probing = false
+
+	metrics.PolymarketCircuitOpen.WithLabelValues(b.api).Set(1)
+	log.Warn().
+		Str("api", b.api).
+		Int("trips", b.trips).
+		Dur("open_for", timeout).
+		Msg("Polymarket circuit breaker opened")
+}
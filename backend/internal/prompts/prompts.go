@@ -0,0 +1,275 @@
+// Package prompts renders the LLM prompts used for article generation and
+// social post relevance scoring from text/template files. The defaults are embedded in the binary; a directory
+// of .tmpl files (PROMPTS_DIR) can replace them, or add per-article-type
+// overrides, without a rebuild.
+//
+// Each file defines three templates: "version", "system" and "user". A file
+// named <prompt>.<article_type>.tmpl overrides <prompt>.tmpl for that
+// article type and only needs to define the templates it changes.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+// Prompt names.
+const (
+	Narrative      = "narrative"
+	Briefing       = "briefing"
+	Trending       = "trending"
+	NewMarket      = "new_market"
+	CategoryDigest = "category_digest"
+	Resolution     = "resolution"
+	ClosingSoon    = "closing_soon"
+	Movers         = "movers"
+	WeekAhead      = "week_ahead"
+	Explainer      = "explainer"
+	Mispricing     = "mispricing"
+	ShortForm      = "short_form"
+	Relevance      = "relevance"
+)
+
+// Sources a template can be loaded from.
+const (
+	SourceEmbedded  = "embedded"
+	SourceDirectory = "directory"
+)
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// Prompt is a rendered prompt.
+type Prompt struct {
+	Name string `json:"name"`
+
+	// Article type whose override was used; empty for the base prompt
+	ArticleType string `json:"article_type,omitempty"`
+
+	Version string `json:"version"`
+	System  string `json:"system"`
+	User    string `json:"user"`
+}
+
+// Info describes a loaded prompt.
+type Info struct {
+	Name      string            `json:"name"`
+	Version   string            `json:"version"`
+	Source    string            `json:"source"`
+	Overrides map[string]string `json:"overrides,omitempty"` // Article type -> version
+}
+
+// entry is one parsed template file.
+type entry struct {
+	tmpl    *template.Template
+	text    string
+	version string
+	source  string
+}
+
+// Library holds parsed prompt templates.
+type Library struct {
+	base      map[string]*entry
+	overrides map[string]map[string]*entry // Prompt name -> article type -> override
+}
+
+// funcs are available to every template.
+var funcs = template.FuncMap{
+	// percent turns a 0-1 probability or change into percentage points
+	"percent": func(v float64) float64 { return v * 100 },
+	// thousands and millions scale dollar volumes for "$12K" / "$1.2M"
+	"thousands": func(v float64) float64 { return v / 1_000 },
+	"millions":  func(v float64) float64 { return v / 1_000_000 },
+	// volume formats a dollar volume compactly, e.g. "1.2M"
+	"volume": formatVolume,
+}
+
+// Load parses the embedded templates, then the .tmpl files in dir (if not
+// empty), which replace embedded files of the same name.
+func Load(dir string) (*Library, error) {
+	files := map[string]string{}
+	sources := map[string]string{}
+
+	embeddedFiles, err := fs.Glob(embedded, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range embeddedFiles {
+		data, err := embedded.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(path)
+		files[name], sources[name] = string(data), SourceEmbedded
+	}
+
+	if dir != "" {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			name := filepath.Base(path)
+			files[name], sources[name] = string(data), SourceDirectory
+		}
+	}
+
+	lib := &Library{
+		base:      map[string]*entry{},
+		overrides: map[string]map[string]*entry{},
+	}
+
+	// Base prompts first, so overrides can build on them
+	for file, text := range files {
+		stem := strings.TrimSuffix(file, ".tmpl")
+		if strings.Contains(stem, ".") {
+			continue
+		}
+		tmpl, err := template.New(stem).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		e, err := newEntry(tmpl, text, sources[file])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		lib.base[stem] = e
+	}
+
+	for file, text := range files {
+		stem := strings.TrimSuffix(file, ".tmpl")
+		name, articleType, ok := strings.Cut(stem, ".")
+		if !ok {
+			continue
+		}
+		base, exists := lib.base[name]
+		if !exists {
+			return nil, fmt.Errorf("%s overrides unknown prompt %q", file, name)
+		}
+		// Parse the base again rather than cloning it: templates can't be
+		// cloned once executed, and newEntry executes "version"
+		tmpl, err := template.Must(template.New(stem).Funcs(funcs).Parse(base.text)).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		e, err := newEntry(tmpl, text, sources[file])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if lib.overrides[name] == nil {
+			lib.overrides[name] = map[string]*entry{}
+		}
+		lib.overrides[name][articleType] = e
+	}
+
+	return lib, nil
+}
+
+// newEntry checks a parsed file defines the required templates.
+func newEntry(tmpl *template.Template, text, source string) (*entry, error) {
+	for _, name := range []string{"version", "system", "user"} {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("missing {{define %q}}", name)
+		}
+	}
+	var version bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&version, "version", nil); err != nil {
+		return nil, err
+	}
+	return &entry{tmpl: tmpl, text: text, version: strings.TrimSpace(version.String()), source: source}, nil
+}
+
+// Render renders a prompt with data, using the article type's override
+// when there is one.
+func (l *Library) Render(name, articleType string, data interface{}) (*Prompt, error) {
+	e, ok := l.overrides[name][articleType]
+	if !ok {
+		articleType = ""
+		if e, ok = l.base[name]; !ok {
+			return nil, fmt.Errorf("unknown prompt %q", name)
+		}
+	}
+
+	var system, user bytes.Buffer
+	if err := e.tmpl.ExecuteTemplate(&system, "system", data); err != nil {
+		return nil, fmt.Errorf("render %s system prompt: %w", name, err)
+	}
+	if err := e.tmpl.ExecuteTemplate(&user, "user", data); err != nil {
+		return nil, fmt.Errorf("render %s user prompt: %w", name, err)
+	}
+
+	return &Prompt{
+		Name:        name,
+		ArticleType: articleType,
+		Version:     e.version,
+		System:      strings.TrimSpace(system.String()),
+		User:        strings.TrimSpace(user.String()),
+	}, nil
+}
+
+// List describes the loaded prompts, sorted by name.
+func (l *Library) List() []Info {
+	infos := make([]Info, 0, len(l.base))
+	for name, e := range l.base {
+		info := Info{Name: name, Version: e.version, Source: e.source}
+		for articleType, o := range l.overrides[name] {
+			if info.Overrides == nil {
+				info.Overrides = map[string]string{}
+			}
+			info.Overrides[articleType] = o.version
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// current is the library Render and Default use.
+var current atomic.Pointer[Library]
+
+func init() {
+	lib, err := Load("")
+	if err != nil {
+		panic(fmt.Sprintf("prompts: embedded templates: %v", err))
+	}
+	current.Store(lib)
+}
+
+// Default returns the library in use: the embedded templates unless
+// SetDefault replaced it.
+func Default() *Library {
+	return current.Load()
+}
+
+// SetDefault replaces the library in use.
+func SetDefault(lib *Library) {
+	current.Store(lib)
+}
+
+// Render renders a prompt from the library in use.
+func Render(name, articleType string, data interface{}) (*Prompt, error) {
+	return Default().Render(name, articleType, data)
+}
+
+func formatVolume(v float64) string {
+	switch {
+	case v >= 1_000_000:
+		return fmt.Sprintf("%.1fM", v/1_000_000)
+	case v >= 1_000:
+		return fmt.Sprintf("%.1fK", v/1_000)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
@@ -0,0 +1,409 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/ratelimit"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DiscordDefaultWebhooks is the webhook key that receives every category.
+	DiscordDefaultWebhooks = "default"
+
+	// discordFallbackColor is used for categories without a color.
+	discordFallbackColor = 0x636E72
+
+	// discordMaxTitle and discordMaxDescription are the embed title and
+	// description length limits; Discord rejects longer embeds.
+	discordMaxTitle       = 256
+	discordMaxDescription = 4096
+)
+
+// DiscordConfig holds Discord webhook publishing configuration.
+type DiscordConfig struct {
+	// Webhook URLs by category slug. URLs under DiscordDefaultWebhooks
+	// receive every post in addition to the category's own webhooks.
+	Webhooks map[string][]string
+
+	// Public site URL for article and market links
+	SiteURL string
+
+	// Only articles at least this significant are posted
+	MinSignificance models.Significance
+
+	// Market event types to post directly (none by default)
+	EventTypes []syncer.EventType
+
+	// Posting cap per rolling hour for articles and for events (0 = unlimited)
+	MaxPostsPerHour int
+
+	// Don't post the same market twice within this window
+	DedupWindow time.Duration
+}
+
+// DiscordStore is the storage the Discord publisher records posts in and
+// reads market images from. *storage.Store implements it.
+type DiscordStore interface {
+	PostStore
+	GetMarketByID(ctx context.Context, marketID string) (*models.Market, error)
+}
+
+// DiscordPublisher sends rich embeds for new articles and selected market
+// events to Discord webhooks.
+type DiscordPublisher struct {
+	store      DiscordStore
+	syncer     *syncer.Syncer
+	config     DiscordConfig
+	httpClient *http.Client
+	worker     *worker
+
+	// Event posting state
+	eventTypes   map[syncer.EventType]bool
+	limiter      *ratelimit.Limiter
+	lastEvent    map[string]time.Time // market_id -> last event post
+	lastEventMux sync.Mutex
+	events       <-chan syncer.Event
+	wg           sync.WaitGroup
+}
+
+// NewDiscordPublisher creates a new Discord publisher. sync may be nil when
+// no event types are configured. Call Start to begin posting.
+func NewDiscordPublisher(store DiscordStore, sync *syncer.Syncer, cfg DiscordConfig) *DiscordPublisher {
+	if cfg.MinSignificance == "" {
+		cfg.MinSignificance = models.SignificanceMedium
+	}
+
+	p := &DiscordPublisher{
+		store:      store,
+		syncer:     sync,
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		eventTypes: make(map[syncer.EventType]bool),
+		limiter:    ratelimit.New(2 * time.Hour),
+		lastEvent:  make(map[string]time.Time),
+	}
+	for _, t := range cfg.EventTypes {
+		p.eventTypes[t] = true
+	}
+	p.worker = newWorker(models.PlatformDiscord, store, p.postArticle, cfg.MaxPostsPerHour, cfg.DedupWindow)
+	return p
+}
+
+// Start starts the article queue and, if event types are configured, the
+// event subscription.
+func (p *DiscordPublisher) Start() {
+	log.Info().
+		Int("categories", len(p.config.Webhooks)).
+		Int("event_types", len(p.eventTypes)).
+		Msg("Starting Discord publisher")
+
+	p.worker.start()
+
+	if len(p.eventTypes) > 0 && p.syncer != nil {
+		p.events = p.syncer.Subscribe("discord")
+		p.wg.Add(1)
+		go p.eventLoop()
+	}
+}
+
+// Stop stops posting. Queued articles are discarded.
+func (p *DiscordPublisher) Stop() {
+	p.worker.stop()
+	p.stopEvents()
+}
+
+// Drain stops posting events, then posts the queued articles and stops.
+// Articles not posted when ctx is done are discarded.
+func (p *DiscordPublisher) Drain(ctx context.Context) {
+	p.stopEvents()
+	p.worker.drain(ctx)
+}
+
+// stopEvents ends the event subscription, waiting for a post in progress.
+func (p *DiscordPublisher) stopEvents() {
+	if p.events != nil {
+		p.syncer.Unsubscribe(p.events)
+		p.wg.Wait()
+	}
+}
+
+// PublishArticle queues article for posting if it is significant enough
+// and has a webhook.
+func (p *DiscordPublisher) PublishArticle(_ context.Context, article *models.Article) {
+	if !meetsSignificance(article, p.config.MinSignificance) {
+		return
+	}
+	if len(p.webhooksFor(article.Category)) == 0 {
+		return
+	}
+	p.worker.enqueue(article)
+}
+
+// ============================================================================
+// EMBEDS
+// ============================================================================
+
+type discordMessage struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+	Thumbnail   *discordEmbedImage  `json:"thumbnail,omitempty"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbedImage struct {
+	URL string `json:"url"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// postArticle sends an article embed to its category's webhooks, with the
+// summary followed by the TL;DR bullets when the article has them.
+func (p *DiscordPublisher) postArticle(ctx context.Context, article *models.Article) (string, string, error) {
+	description := article.Summary
+	if s := article.ShortForm; s != nil && len(s.TLDR) > 0 {
+		description += "\n\n• " + strings.Join(s.TLDR, "\n• ")
+	}
+
+	embed := discordEmbed{
+		Title:       truncate(article.Headline, discordMaxTitle),
+		URL:         articleURL(p.config.SiteURL, article),
+		Description: truncate(description, discordMaxDescription),
+		Color:       categoryColor(article.Category),
+		Footer:      &discordEmbedFooter{Text: "FutureSignals · " + categoryName(article.Category)},
+		Timestamp:   article.PublishedAt.UTC().Format(time.RFC3339),
+	}
+
+	if m := article.PrimaryMarket; m != nil {
+		embed.Fields = marketFields(m.Probability, m.Change24h, m.Volume24h)
+
+		// Market image lives on the market, not the article's market ref
+		if market, err := p.store.GetMarketByID(ctx, m.MarketID); err == nil && market.Image != "" {
+			embed.Thumbnail = &discordEmbedImage{URL: market.Image}
+		}
+	}
+
+	if err := p.send(ctx, article.Category, embed); err != nil {
+		return "", "", err
+	}
+	return "", article.Headline, nil
+}
+
+// eventLoop posts configured market events.
+func (p *DiscordPublisher) eventLoop() {
+	defer p.wg.Done()
+
+	for event := range p.events {
+		if !p.eventTypes[event.Type] || event.Market == nil {
+			continue
+		}
+		if !p.allowEvent(event.Market.MarketID) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := p.send(ctx, event.Market.Category, p.eventEmbed(event))
+		cancel()
+
+		if err != nil {
+			log.Error().Err(err).Str("type", string(event.Type)).Msg("Failed to post event to Discord")
+			metrics.SocialPosts.WithLabelValues(models.PlatformDiscord, "error").Inc()
+			continue
+		}
+		metrics.SocialPosts.WithLabelValues(models.PlatformDiscord, "ok").Inc()
+	}
+}
+
+// allowEvent applies the per-market dedup window and the hourly cap.
+func (p *DiscordPublisher) allowEvent(marketID string) bool {
+	p.lastEventMux.Lock()
+	defer p.lastEventMux.Unlock()
+
+	now := time.Now()
+	if last, ok := p.lastEvent[marketID]; ok && now.Sub(last) < p.config.DedupWindow {
+		metrics.SocialPosts.WithLabelValues(models.PlatformDiscord, "duplicate").Inc()
+		return false
+	}
+	if !p.limiter.Allow("events", p.config.MaxPostsPerHour, time.Hour) {
+		metrics.SocialPosts.WithLabelValues(models.PlatformDiscord, "dropped").Inc()
+		return false
+	}
+
+	p.lastEvent[marketID] = now
+	for id, t := range p.lastEvent {
+		if now.Sub(t) > p.config.DedupWindow {
+			delete(p.lastEvent, id)
+		}
+	}
+	return true
+}
+
+func (p *DiscordPublisher) eventEmbed(event syncer.Event) discordEmbed {
+	m := event.Market
+
+	var title string
+	switch event.Type {
+	case syncer.EventBreakingMove:
+		title = "Breaking move: " + m.Question
+	case syncer.EventVolumeSpike:
+		title = "Volume spike: " + m.Question
+	case syncer.EventThresholdCross:
+		title = "Threshold crossed: " + m.Question
+	case syncer.EventNewMarket:
+		title = "New market: " + m.Question
+	case syncer.EventWhaleTrade:
+		title = "Whale trade: " + m.Question
+	case syncer.EventMarketResolved:
+		title = "Resolved " + m.WinningOutcome + ": " + m.Question
+	default:
+		title = m.Question
+	}
+
+	embed := discordEmbed{
+		Title:     truncate(title, discordMaxTitle),
+		URL:       strings.TrimRight(p.config.SiteURL, "/") + "/market/" + m.Slug,
+		Color:     categoryColor(m.Category),
+		Fields:    marketFields(m.Probability, m.Change24h, m.Volume24h),
+		Footer:    &discordEmbedFooter{Text: "FutureSignals · " + categoryName(m.Category)},
+		Timestamp: event.Timestamp.UTC().Format(time.RFC3339),
+	}
+	if m.Image != "" {
+		embed.Thumbnail = &discordEmbedImage{URL: m.Image}
+	}
+	return embed
+}
+
+func marketFields(probability, change24h, volume24h float64) []discordEmbedField {
+	return []discordEmbedField{
+		{Name: "Probability", Value: fmt.Sprintf("%.0f%%", probability*100), Inline: true},
+		{Name: "24h change", Value: fmt.Sprintf("%+.1f pts", change24h*100), Inline: true},
+		{Name: "24h volume", Value: formatVolume(volume24h), Inline: true},
+	}
+}
+
+// ============================================================================
+// WEBHOOKS
+// ============================================================================
+
+// webhooksFor returns the category's webhooks plus the default ones.
+func (p *DiscordPublisher) webhooksFor(category string) []string {
+	urls := append([]string{}, p.config.Webhooks[DiscordDefaultWebhooks]...)
+	if category != DiscordDefaultWebhooks {
+		urls = append(urls, p.config.Webhooks[category]...)
+	}
+	return urls
+}
+
+// send posts embed to every webhook for category. It fails only if every
+// webhook fails.
+func (p *DiscordPublisher) send(ctx context.Context, category string, embed discordEmbed) error {
+	body, err := json.Marshal(discordMessage{Username: "FutureSignals", Embeds: []discordEmbed{embed}})
+	if err != nil {
+		return err
+	}
+
+	urls := p.webhooksFor(category)
+	var lastErr error
+	delivered := 0
+	for _, url := range urls {
+		if err := p.sendWebhook(ctx, url, body); err != nil {
+			log.Warn().Err(err).Str("category", category).Msg("Discord webhook failed")
+			lastErr = err
+			continue
+		}
+		delivered++
+	}
+	if delivered == 0 && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
+
+// sendWebhook posts body to one webhook, retrying once after a 429.
+func (p *DiscordPublisher) sendWebhook(ctx context.Context, url string, body []byte) error {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("post webhook: %w", err)
+		}
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 16<<10))
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt == 0 {
+			wait := time.Second
+			if s, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+				wait = time.Duration(s * float64(time.Second))
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, respBody)
+		}
+		return nil
+	}
+}
+
+// categoryColor returns the category's color as a Discord embed integer.
+func categoryColor(slug string) int {
+	if c := models.GetCategoryBySlug(slug); c != nil {
+		if v, err := strconv.ParseInt(strings.TrimPrefix(c.Color, "#"), 16, 32); err == nil {
+			return int(v)
+		}
+	}
+	return discordFallbackColor
+}
+
+func categoryName(slug string) string {
+	if c := models.GetCategoryBySlug(slug); c != nil {
+		return c.Name
+	}
+	return slug
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,253 @@
+// Package publisher distributes published articles to social platforms.
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultQueueSize is the number of articles buffered per platform.
+	defaultQueueSize = 100
+
+	// defaultMaxAge drops queued articles that waited too long to be news.
+	defaultMaxAge = 2 * time.Hour
+)
+
+// PostStore records social posts, for duplicate suppression and the
+// hourly cap. *storage.Store implements it.
+type PostStore interface {
+	SaveSocialPost(ctx context.Context, post *models.SocialPost) error
+	HasSocialPost(ctx context.Context, platform string, articleID primitive.ObjectID, marketID string, since time.Time) (bool, error)
+	GetSocialPostTimes(ctx context.Context, platform string, since time.Time) ([]time.Time, error)
+}
+
+// postFunc posts an article and returns the platform's post ID (if any)
+// and the text that was posted.
+type postFunc func(ctx context.Context, article *models.Article) (postID, text string, err error)
+
+// queuedArticle is an article waiting to be posted.
+type queuedArticle struct {
+	article  *models.Article
+	queuedAt time.Time
+}
+
+// worker posts queued articles to one platform, one at a time, enforcing
+// duplicate suppression and an hourly posting cap recorded in social_posts.
+type worker struct {
+	platform    string
+	store       PostStore
+	post        postFunc
+	maxPerHour  int
+	dedupWindow time.Duration
+	maxAge      time.Duration
+
+	queue chan queuedArticle
+
+	// Lifecycle; draining is closed to post what is queued, then stop
+	ctx       context.Context
+	cancel    context.CancelFunc
+	draining  chan struct{}
+	drainOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newWorker(platform string, store PostStore, post postFunc, maxPerHour int, dedupWindow time.Duration) *worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &worker{
+		platform:    platform,
+		store:       store,
+		post:        post,
+		maxPerHour:  maxPerHour,
+		dedupWindow: dedupWindow,
+		maxAge:      defaultMaxAge,
+		queue:       make(chan queuedArticle, defaultQueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+		draining:    make(chan struct{}),
+	}
+}
+
+// enqueue adds an article without blocking, dropping it if the queue is full.
+func (w *worker) enqueue(article *models.Article) {
+	select {
+	case w.queue <- queuedArticle{article: article, queuedAt: time.Now()}:
+	default:
+		log.Warn().Str("platform", w.platform).Str("article", article.Slug).Msg("Publish queue full, dropping article")
+		metrics.SocialPosts.WithLabelValues(w.platform, "dropped").Inc()
+	}
+}
+
+func (w *worker) start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+func (w *worker) stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+// drain posts the articles already queued, then stops. Posting still in
+// progress when ctx is done is cancelled and the rest discarded.
+func (w *worker) drain(ctx context.Context) {
+	w.drainOnce.Do(func() { close(w.draining) })
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn().Str("platform", w.platform).Int("queued", len(w.queue)).Msg("Publish queue drain timed out, discarding queued articles")
+		w.cancel()
+		<-done
+	}
+	w.cancel()
+}
+
+func (w *worker) run() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case item := <-w.queue:
+			w.process(item)
+		case <-w.draining:
+			for {
+				select {
+				case item := <-w.queue:
+					if w.ctx.Err() != nil {
+						return
+					}
+					w.process(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// process waits for room under the hourly cap, then posts the article
+// unless it is stale or a duplicate.
+func (w *worker) process(item queuedArticle) {
+	article := item.article
+
+	if err := w.waitForSlot(); err != nil {
+		return
+	}
+
+	if time.Since(item.queuedAt) > w.maxAge {
+		log.Info().Str("platform", w.platform).Str("article", article.Slug).Msg("Skipping stale queued article")
+		metrics.SocialPosts.WithLabelValues(w.platform, "stale").Inc()
+		return
+	}
+
+	marketID := ""
+	if article.PrimaryMarket != nil {
+		marketID = article.PrimaryMarket.MarketID
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, 30*time.Second)
+	defer cancel()
+
+	dup, err := w.store.HasSocialPost(ctx, w.platform, article.ID, marketID, time.Now().Add(-w.dedupWindow))
+	if err != nil {
+		log.Error().Err(err).Str("platform", w.platform).Msg("Failed to check for duplicate post")
+		return
+	}
+	if dup {
+		log.Debug().Str("platform", w.platform).Str("article", article.Slug).Msg("Skipping duplicate post")
+		metrics.SocialPosts.WithLabelValues(w.platform, "duplicate").Inc()
+		return
+	}
+
+	postID, text, err := w.post(ctx, article)
+	if err != nil {
+		log.Error().Err(err).Str("platform", w.platform).Str("article", article.Slug).Msg("Failed to post article")
+		metrics.SocialPosts.WithLabelValues(w.platform, "error").Inc()
+		return
+	}
+	metrics.SocialPosts.WithLabelValues(w.platform, "ok").Inc()
+
+	if err := w.store.SaveSocialPost(ctx, &models.SocialPost{
+		Platform:  w.platform,
+		ArticleID: article.ID,
+		MarketID:  marketID,
+		PostID:    postID,
+		Text:      text,
+	}); err != nil {
+		log.Warn().Err(err).Str("platform", w.platform).Msg("Failed to record social post")
+	}
+
+	log.Info().
+		Str("platform", w.platform).
+		Str("article", article.Slug).
+		Str("post_id", postID).
+		Msg("Article posted")
+}
+
+// waitForSlot blocks until another post fits under the hourly cap.
+func (w *worker) waitForSlot() error {
+	if w.maxPerHour <= 0 {
+		return nil
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(w.ctx, 10*time.Second)
+		times, err := w.store.GetSocialPostTimes(ctx, w.platform, time.Now().Add(-time.Hour))
+		cancel()
+		if err != nil {
+			log.Error().Err(err).Str("platform", w.platform).Msg("Failed to check posting cap")
+			return err
+		}
+		if len(times) < w.maxPerHour {
+			return nil
+		}
+
+		// The slot frees up when the oldest post in the window turns an hour old
+		wait := time.Until(times[len(times)-w.maxPerHour].Add(time.Hour))
+		log.Debug().Str("platform", w.platform).Dur("wait", wait).Msg("Hourly posting cap reached")
+
+		select {
+		case <-w.ctx.Done():
+			return w.ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// meetsSignificance reports whether article is at least min.
+func meetsSignificance(article *models.Article, min models.Significance) bool {
+	return article.Significance.Rank() >= min.Rank()
+}
+
+// articleURL returns the public URL of an article.
+func articleURL(siteURL string, article *models.Article) string {
+	return strings.TrimRight(siteURL, "/") + "/article/" + article.Slug
+}
+
+func formatVolume(v float64) string {
+	switch {
+	case v >= 1_000_000:
+		return fmt.Sprintf("$%.1fM", v/1_000_000)
+	case v >= 1_000:
+		return fmt.Sprintf("$%.1fK", v/1_000)
+	default:
+		return fmt.Sprintf("$%.0f", v)
+	}
+}
@@ -0,0 +1,214 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// xTweetsURL is the X API v2 create-post endpoint.
+	xTweetsURL = "https://api.twitter.com/2/tweets"
+
+	// xMaxLength is the post length limit; links count as xURLLength.
+	xMaxLength = 280
+	xURLLength = 23
+)
+
+// XConfig holds X (Twitter) publishing configuration. Posting uses OAuth
+// 1.0a user context, so all four credentials are required.
+type XConfig struct {
+	APIKey       string
+	APISecret    string
+	AccessToken  string
+	AccessSecret string
+
+	// Public site URL for article links
+	SiteURL string
+
+	// Only articles at least this significant are posted
+	MinSignificance models.Significance
+
+	// Posting cap per rolling hour (0 = unlimited)
+	MaxPostsPerHour int
+
+	// Don't post the same market twice within this window
+	DedupWindow time.Duration
+}
+
+// XPublisher posts headlines to X for high-significance articles.
+type XPublisher struct {
+	config     XConfig
+	httpClient *http.Client
+	worker     *worker
+}
+
+// NewXPublisher creates a new X publisher. Call Start to begin posting.
+func NewXPublisher(store PostStore, cfg XConfig) *XPublisher {
+	if cfg.MinSignificance == "" {
+		cfg.MinSignificance = models.SignificanceHigh
+	}
+
+	p := &XPublisher{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 20 * time.Second},
+	}
+	p.worker = newWorker(models.PlatformX, store, p.post, cfg.MaxPostsPerHour, cfg.DedupWindow)
+	return p
+}
+
+// Start starts the posting queue.
+func (p *XPublisher) Start() {
+	log.Info().
+		Str("min_significance", string(p.config.MinSignificance)).
+		Int("max_per_hour", p.config.MaxPostsPerHour).
+		Msg("Starting X publisher")
+	p.worker.start()
+}
+
+// Stop stops the posting queue. Queued articles are discarded.
+func (p *XPublisher) Stop() {
+	p.worker.stop()
+}
+
+// Drain posts the queued articles, then stops. Articles not posted when
+// ctx is done are discarded.
+func (p *XPublisher) Drain(ctx context.Context) {
+	p.worker.drain(ctx)
+}
+
+// PublishArticle queues article for posting if it is significant enough.
+func (p *XPublisher) PublishArticle(_ context.Context, article *models.Article) {
+	if !meetsSignificance(article, p.config.MinSignificance) {
+		return
+	}
+	p.worker.enqueue(article)
+}
+
+// post creates the post via the X API.
+func (p *XPublisher) post(ctx context.Context, article *models.Article) (string, string, error) {
+	text := p.formatPost(article)
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, xTweetsURL, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", p.oauthHeader(http.MethodPost, xTweetsURL))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("post to X: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("X API returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", "", fmt.Errorf("decode X response: %w", err)
+	}
+	return result.Data.ID, text, nil
+}
+
+// formatPost builds "social blurb / link" when the article has short-form
+// variants, or else "headline / odds and volume / link", trimming the text
+// to fit the length limit.
+func (p *XPublisher) formatPost(article *models.Article) string {
+	link := articleURL(p.config.SiteURL, article)
+	if s := article.ShortForm; s != nil && s.Social != "" {
+		return truncate(s.Social, xMaxLength-xURLLength-2) + "\n\n" + link
+	}
+
+	stats := ""
+	if m := article.PrimaryMarket; m != nil {
+		stats = fmt.Sprintf("Odds: %.0f%% (%+.0f pts in 24h) | Vol: %s",
+			m.Probability*100, m.Change24h*100, formatVolume(m.Volume24h))
+	}
+
+	// Separators: two blank lines between sections
+	budget := xMaxLength - xURLLength - 2
+	if stats != "" {
+		budget -= len([]rune(stats)) + 2
+	}
+
+	parts := []string{truncate(article.Headline, budget)}
+	if stats != "" {
+		parts = append(parts, stats)
+	}
+	parts = append(parts, link)
+	return strings.Join(parts, "\n\n")
+}
+
+// oauthHeader returns an OAuth 1.0a HMAC-SHA1 Authorization header. JSON
+// bodies aren't part of the signature, so only the oauth_* parameters are
+// signed.
+func (p *XPublisher) oauthHeader(method, rawURL string) string {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	params := map[string]string{
+		"oauth_consumer_key":     p.config.APIKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_token":            p.config.AccessToken,
+		"oauth_version":          "1.0",
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = oauthEscape(k) + "=" + oauthEscape(params[k])
+	}
+	base := method + "&" + oauthEscape(rawURL) + "&" + oauthEscape(strings.Join(pairs, "&"))
+
+	key := oauthEscape(p.config.APISecret) + "&" + oauthEscape(p.config.AccessSecret)
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	params["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	header := make([]string, 0, len(params))
+	for _, k := range append(keys, "oauth_signature") {
+		header = append(header, fmt.Sprintf(`%s="%s"`, oauthEscape(k), oauthEscape(params[k])))
+	}
+	return "OAuth " + strings.Join(header, ", ")
+}
+
+// oauthEscape percent-encodes per RFC 3986, as OAuth 1.0a requires.
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
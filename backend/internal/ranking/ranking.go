@@ -0,0 +1,144 @@
+// Package ranking orders articles for a reader from what they have read and
+// the markets they watch.
+package ranking
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Config weights the feed scoring model. An article's score is the weighted
+// sum of the reader's affinity for its category, its significance and its
+// freshness, each scaled to 0-1, less SeenPenalty if it has been read.
+type Config struct {
+	AffinityWeight     float64
+	SignificanceWeight float64
+	RecencyWeight      float64
+
+	// Age at which an article's freshness halves
+	RecencyHalfLife time.Duration
+
+	// Age at which a view's contribution to affinity halves
+	ViewHalfLife time.Duration
+
+	// Affinity added per watched market, in units of one fresh view
+	WatchlistWeight float64
+
+	// Subtracted from articles the reader has already opened
+	SeenPenalty float64
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		AffinityWeight:     0.5,
+		SignificanceWeight: 0.2,
+		RecencyWeight:      0.3,
+		RecencyHalfLife:    12 * time.Hour,
+		ViewHalfLife:       14 * 24 * time.Hour,
+		WatchlistWeight:    3,
+		SeenPenalty:        0.3,
+	}
+}
+
+// Affinities maps category slugs to the share of a reader's interest, summing
+// to 1.
+type Affinities map[string]float64
+
+// Signals is what is known about a reader.
+type Signals struct {
+	Views   []models.ArticleView
+	Watched []models.Market
+}
+
+// Ranker scores articles against a reader's affinities.
+type Ranker struct {
+	config Config
+}
+
+// NewRanker creates a new ranker.
+func NewRanker(cfg Config) *Ranker {
+	return &Ranker{config: cfg}
+}
+
+// Affinities derives category affinities from a reader's signals. Views
+// decay with age; watched markets count at full weight.
+func (r *Ranker) Affinities(sig Signals, now time.Time) Affinities {
+	raw := make(map[string]float64)
+	for _, v := range sig.Views {
+		if v.Category != "" {
+			raw[v.Category] += decay(now.Sub(v.ViewedAt), r.config.ViewHalfLife)
+		}
+	}
+	for _, m := range sig.Watched {
+		if m.Category != "" {
+			raw[m.Category] += r.config.WatchlistWeight
+		}
+	}
+
+	var total float64
+	for _, w := range raw {
+		total += w
+	}
+	aff := make(Affinities, len(raw))
+	if total == 0 {
+		return aff
+	}
+	for category, w := range raw {
+		aff[category] = w / total
+	}
+	return aff
+}
+
+// Rank returns articles ordered by score, best first. Ties keep the input
+// order.
+func (r *Ranker) Rank(articles []models.Article, aff Affinities, seen map[primitive.ObjectID]bool, now time.Time) []models.Article {
+	// Scale affinity so the reader's favourite category scores 1
+	var top float64
+	for _, share := range aff {
+		top = math.Max(top, share)
+	}
+
+	scores := make([]float64, len(articles))
+	for i, a := range articles {
+		var affinity float64
+		if top > 0 {
+			affinity = aff[a.Category] / top
+		}
+		significance := float64(a.Significance.Rank()) / float64(models.SignificanceBreaking.Rank())
+		recency := decay(now.Sub(a.PublishedAt), r.config.RecencyHalfLife)
+
+		scores[i] = r.config.AffinityWeight*affinity +
+			r.config.SignificanceWeight*significance +
+			r.config.RecencyWeight*recency
+		if seen[a.ID] {
+			scores[i] -= r.config.SeenPenalty
+		}
+	}
+
+	order := make([]int, len(articles))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	ranked := make([]models.Article, len(articles))
+	for i, idx := range order {
+		ranked[i] = articles[idx]
+	}
+	return ranked
+}
+
+// decay halves per halfLife of age; future timestamps count as new.
+func decay(age, halfLife time.Duration) float64 {
+	if age <= 0 || halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, age.Hours()/halfLife.Hours())
+}
@@ -0,0 +1,98 @@
+// Package ratelimit provides in-memory token bucket rate limiting keyed by
+// caller (API key, IP address, upstream host, ...).
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket that refills continuously.
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// Limiter holds one token bucket per key. Buckets idle for longer than the
+// idle timeout are evicted so the map doesn't grow without bound.
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	idle    time.Duration
+	lastGC  time.Time
+}
+
+// New creates a limiter that evicts buckets idle for longer than idle.
+func New(idle time.Duration) *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		idle:    idle,
+		lastGC:  time.Now(),
+	}
+}
+
+// Allow reports whether a request for key is allowed under a budget of
+// limit requests per period, consuming a token if so. A limit of zero or
+// less means unlimited.
+func (l *Limiter) Allow(key string, limit int, per time.Duration) bool {
+	ok, _ := l.Reserve(key, limit, per)
+	return ok
+}
+
+// Reserve is like Allow but also returns how long until the next token is
+// available when the request is denied.
+func (l *Limiter) Reserve(key string, limit int, per time.Duration) (bool, time.Duration) {
+	if limit <= 0 || per <= 0 {
+		return true, 0
+	}
+	return l.ReserveRate(key, float64(limit)/per.Seconds(), limit)
+}
+
+// ReserveRate is like Reserve for a bucket of burst tokens refilled at rate
+// tokens per second. A rate or burst of zero or less means unlimited.
+func (l *Limiter) ReserveRate(key string, rate float64, burst int) (bool, time.Duration) {
+	if rate <= 0 || burst <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.gc(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	// Refill since last request, capped at the bucket size
+	b.tokens += now.Sub(b.lastSeen).Seconds() * rate
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, wait
+}
+
+// gc evicts idle buckets at most once per idle period. Caller holds mu.
+func (l *Limiter) gc(now time.Time) {
+	if l.idle <= 0 || now.Sub(l.lastGC) < l.idle {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idle {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastGC = now
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// BriefingConfigStore holds the briefing configs. *storage.Store
+// implements it.
+type BriefingConfigStore interface {
+	GetBriefingConfigs(ctx context.Context) ([]models.BriefingConfig, error)
+}
+
+// briefingJobNames keeps the job names the built-in briefings were
+// scheduled under, so their run history and locks carry over.
+var briefingJobNames = map[models.BriefingType]string{
+	models.BriefingMorning: "morning-briefing",
+	models.BriefingMidday:  "midday-pulse",
+	models.BriefingEvening: "evening-wrap",
+	models.BriefingWeekly:  "weekly-digest",
+}
+
+// briefingJobName returns the name of the job generating a briefing type.
+func briefingJobName(briefingType models.BriefingType) string {
+	if name, ok := briefingJobNames[briefingType]; ok {
+		return name
+	}
+	return string(briefingType) + "-briefing"
+}
+
+// SetBriefings schedules briefings from the configs in store in place of
+// the built-in defaults, and registers the briefing-configs job, which
+// picks up configs changed on any instance. Each instance keeps its own
+// briefing jobs, so the reload runs on all of them rather than on the one
+// holding the job lock.
+func (s *Scheduler) SetBriefings(store BriefingConfigStore) {
+	s.briefings = store
+
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
+	defer cancel()
+	if err := s.ReloadBriefings(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to load briefing configs, keeping defaults")
+	}
+
+	s.AddJob(&Job{
+		Name: "briefing-configs",
+		Schedule: Schedule{
+			Type:     ScheduleInterval,
+			Interval: time.Minute,
+		},
+		Handler: s.ReloadBriefings,
+		Retry:   RetryPolicy{Attempts: 1}, // The next reload is a minute away
+		Local:   true,
+	})
+}
+
+// ReloadBriefings rebuilds the briefing jobs from the stored configs. Jobs
+// whose schedule is unchanged are kept as they are.
+func (s *Scheduler) ReloadBriefings(ctx context.Context) error {
+	if s.briefings == nil {
+		return nil
+	}
+	configs, err := s.briefings.GetBriefingConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load briefing configs: %w", err)
+	}
+	s.setBriefingJobs(configs)
+	return nil
+}
+
+// setBriefingJobs replaces the briefing jobs with one per enabled config.
+func (s *Scheduler) setBriefingJobs(configs []models.BriefingConfig) {
+	want := make(map[string]Schedule)
+	added := make([]models.BriefingConfig, 0, len(configs))
+	for _, cfg := range configs {
+		if cfg.Enabled {
+			want[briefingJobName(cfg.Type)] = briefingSchedule(cfg)
+			added = append(added, cfg)
+		}
+	}
+
+	s.jobsMux.Lock()
+	defer s.jobsMux.Unlock()
+
+	jobs := s.jobs[:0]
+	for _, job := range s.jobs {
+		if !job.briefing {
+			jobs = append(jobs, job)
+			continue
+		}
+		if schedule, ok := want[job.Name]; ok && sameSchedule(job.Schedule, schedule) {
+			jobs = append(jobs, job)
+			delete(want, job.Name)
+			continue
+		}
+		log.Info().Str("job", job.Name).Msg("Briefing job removed")
+	}
+	clear(s.jobs[len(jobs):])
+	s.jobs = jobs
+
+	// Register new and rescheduled briefings in time-of-day order
+	slices.SortFunc(added, func(a, b models.BriefingConfig) int {
+		return cmp.Or(cmp.Compare(a.Hour, b.Hour), cmp.Compare(a.Minute, b.Minute), cmp.Compare(a.Type, b.Type))
+	})
+	for _, cfg := range added {
+		name := briefingJobName(cfg.Type)
+		if _, ok := want[name]; !ok {
+			continue
+		}
+		briefingType := cfg.Type
+		s.addJob(&Job{
+			Name:     name,
+			Schedule: want[name],
+			Handler: func(ctx context.Context) error {
+				_, err := s.generator.GenerateBriefing(ctx, briefingType)
+				return err
+			},
+			Retry:    briefingRetry,
+			briefing: true,
+		})
+	}
+}
+
+// briefingSchedule returns when a briefing config runs: daily, or weekly on
+// its days if it has any.
+func briefingSchedule(cfg models.BriefingConfig) Schedule {
+	schedule := Schedule{Type: ScheduleDaily, Hour: cfg.Hour, Minute: cfg.Minute}
+	if len(cfg.Days) > 0 {
+		schedule.Type = ScheduleWeekly
+		schedule.Days = cfg.Days
+	}
+	return schedule
+}
+
+func sameSchedule(a, b Schedule) bool {
+	return a.Type == b.Type && a.Hour == b.Hour && a.Minute == b.Minute && slices.Equal(a.Days, b.Days)
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// calendarBatch caps the calendar entries generated per check, so a
+	// backlog doesn't run past the job timeout.
+	calendarBatch = 3
+
+	// calendarStaleAfter is how long an entry may stay running before it's
+	// assumed abandoned and claimed again.
+	calendarStaleAfter = 15 * time.Minute
+)
+
+// CalendarStore holds the content calendar and the markets its entries
+// cover. *storage.Store implements it.
+type CalendarStore interface {
+	ClaimScheduledContent(ctx context.Context, stale time.Duration) (*models.ScheduledContent, error)
+	FinishScheduledContent(ctx context.Context, id primitive.ObjectID, articleSlug string, genErr error) error
+	GetMarketBySlug(ctx context.Context, slug string) (*models.Market, error)
+}
+
+// SetCalendar registers the content-calendar job, which generates the
+// calendar entries in store as they come due.
+func (s *Scheduler) SetCalendar(store CalendarStore) {
+	s.calendar = store
+	s.AddJob(&Job{
+		Name: "content-calendar",
+		Schedule: Schedule{
+			Type:     ScheduleInterval,
+			Interval: time.Minute,
+		},
+		Handler: s.runCalendar,
+		Retry:   RetryPolicy{Attempts: 1}, // Failed entries are recorded, not retried
+	})
+}
+
+// runCalendar generates up to calendarBatch due calendar entries. Entries
+// are claimed one at a time, so several instances can share the work.
+func (s *Scheduler) runCalendar(ctx context.Context) error {
+	for range calendarBatch {
+		item, err := s.calendar.ClaimScheduledContent(ctx, calendarStaleAfter)
+		if err != nil {
+			return fmt.Errorf("failed to claim calendar entry: %w", err)
+		}
+		if item == nil {
+			return nil
+		}
+
+		log.Info().
+			Str("id", item.ID.Hex()).
+			Str("type", string(item.Type)).
+			Time("run_at", item.RunAt).
+			Msg("Generating scheduled content")
+
+		article, genErr := s.generateScheduled(ctx, item)
+		slug := ""
+		if genErr == nil {
+			slug = article.Slug
+		} else {
+			log.Error().Err(genErr).Str("id", item.ID.Hex()).Msg("Scheduled content failed")
+		}
+		s.finishScheduled(ctx, item, slug, genErr)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// finishScheduled records a calendar entry's outcome. It runs even when
+// ctx was cancelled or timed out during generation, which is when the
+// outcome most needs recording; otherwise the entry stays running until
+// it goes stale and is retried.
+func (s *Scheduler) finishScheduled(ctx context.Context, item *models.ScheduledContent, slug string, genErr error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if err := s.calendar.FinishScheduledContent(ctx, item.ID, slug, genErr); err != nil {
+		log.Warn().Err(err).Str("id", item.ID.Hex()).Msg("Failed to record scheduled content outcome")
+	}
+}
+
+// generateScheduled generates the article a calendar entry asks for.
+func (s *Scheduler) generateScheduled(ctx context.Context, item *models.ScheduledContent) (*models.Article, error) {
+	var market *models.Market
+	if item.MarketSlug != "" {
+		m, err := s.calendar.GetMarketBySlug(ctx, item.MarketSlug)
+		if err != nil {
+			return nil, fmt.Errorf("market %s: %w", item.MarketSlug, err)
+		}
+		market = m
+	}
+
+	switch item.Type {
+	case models.ArticleTypeBreaking:
+		return s.generator.GenerateBreaking(ctx, syncer.Event{
+			Type:      syncer.EventBreakingMove,
+			Market:    market,
+			Timestamp: time.Now(),
+		})
+	case models.ArticleTypeNewMarket:
+		return s.generator.GenerateNewMarket(ctx, market)
+	case models.ArticleTypeResolution:
+		return s.generator.GenerateResolution(ctx, market)
+	case models.ArticleTypeBriefing:
+		return s.generator.GenerateBriefing(ctx, item.BriefingType)
+	case models.ArticleTypeTrending:
+		return s.generator.GenerateTrending(ctx, 10)
+	case models.ArticleTypeDigest:
+		return s.generator.GenerateCategoryDigest(ctx, item.Category, 10)
+	}
+	return nil, errors.New("unsupported article type " + string(item.Type))
+}
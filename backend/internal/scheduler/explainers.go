@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// explainerStaleAfter is how long a topic may stay running before it's
+// assumed abandoned and claimed again. Explainers enrich heavily, so it is
+// longer than the calendar's.
+const explainerStaleAfter = 30 * time.Minute
+
+// ExplainerStore holds the explainer topic queue. *storage.Store
+// implements it.
+type ExplainerStore interface {
+	ClaimExplainerTopic(ctx context.Context, stale time.Duration) (*models.ExplainerTopic, error)
+	FinishExplainerTopic(ctx context.Context, id, articleSlug string, genErr error) error
+}
+
+// SetExplainers registers the explainer job, which generates the next
+// queued explainer topic in store twice a week. Explainers are evergreen,
+// so the cadence is slow and independent of market movement.
+func (s *Scheduler) SetExplainers(store ExplainerStore) {
+	s.explainers = store
+	s.AddJob(&Job{
+		Name: "explainer",
+		Schedule: Schedule{
+			Type:   ScheduleWeekly,
+			Hour:   14,
+			Minute: 0,
+			Days:   []int{int(time.Tuesday), int(time.Friday)},
+		},
+		Handler: s.runExplainer,
+		Retry:   RetryPolicy{Attempts: 1}, // Failed topics are recorded, not retried
+	})
+}
+
+// runExplainer generates the first queued explainer topic, if any.
+func (s *Scheduler) runExplainer(ctx context.Context) error {
+	topic, err := s.explainers.ClaimExplainerTopic(ctx, explainerStaleAfter)
+	if err != nil {
+		return fmt.Errorf("failed to claim explainer topic: %w", err)
+	}
+	if topic == nil {
+		log.Info().Msg("No explainer topics queued")
+		return nil
+	}
+
+	log.Info().
+		Str("topic", topic.ID).
+		Int("priority", topic.Priority).
+		Msg("Generating explainer")
+
+	article, genErr := s.generator.GenerateExplainer(ctx, topic)
+	slug := ""
+	switch {
+	case genErr == nil:
+		slug = article.Slug
+	case errors.Is(genErr, content.ErrDuplicateArticle):
+		// Already explained, by an earlier run that didn't record it
+		slug, genErr = topic.ID, nil
+	default:
+		log.Error().Err(genErr).Str("topic", topic.ID).Msg("Explainer failed")
+	}
+	if err := s.explainers.FinishExplainerTopic(ctx, topic.ID, slug, genErr); err != nil {
+		log.Warn().Err(err).Str("topic", topic.ID).Msg("Failed to record explainer outcome")
+	}
+	return genErr
+}
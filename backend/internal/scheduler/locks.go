@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// pendingRunWindow is how long a run held by another instance is watched
+// for a takeover; that instance's job timeout has long passed by then.
+const pendingRunWindow = time.Hour
+
+// LockStore holds the leases that keep each scheduled job run to one
+// instance. *storage.Store implements it.
+type LockStore interface {
+	AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (*models.Lock, bool, error)
+	RenewLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	CompleteLock(ctx context.Context, name, owner string) error
+}
+
+// LockConfig configures the locks that keep each scheduled job run to one
+// instance.
+type LockConfig struct {
+	// Owner identifies this instance in lock documents
+	Owner string
+
+	// TTL is how long a lock outlives its last renewal, and so how soon a
+	// run is taken over after its instance dies. Locks are renewed every
+	// third of it.
+	TTL time.Duration
+}
+
+// DefaultLockConfig returns default lock settings, owned by this host and
+// process.
+func DefaultLockConfig() LockConfig {
+	host, _ := os.Hostname()
+	return LockConfig{
+		Owner: fmt.Sprintf("%s-%d", host, os.Getpid()),
+		TTL:   time.Minute,
+	}
+}
+
+// SetLocks makes every scheduled job run take a lock in the store first, so
+// only one of several instances runs it. Runs started with RunJobNow aren't
+// locked.
+func (s *Scheduler) SetLocks(store LockStore, cfg LockConfig) {
+	defaults := DefaultLockConfig()
+	if cfg.Owner == "" {
+		cfg.Owner = defaults.Owner
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaults.TTL
+	}
+	s.locks = store
+	s.lockCfg = cfg
+}
+
+// pendingRun is a scheduled run whose lock another instance holds. It is
+// retried every tick and taken over if that instance stops renewing.
+type pendingRun struct {
+	job  *Job
+	slot time.Time
+}
+
+// jobLockName names the lock for a job's run scheduled at slot. Instances
+// compute the same slots, so they contend for the same lock.
+func jobLockName(job *Job, slot time.Time) string {
+	return "job:" + job.Name + ":" + slot.UTC().Format(time.RFC3339)
+}
+
+// lockJob takes the lock for a job's run scheduled at slot. It reports
+// false if another instance holds it or has already completed the run.
+func (s *Scheduler) lockJob(ctx context.Context, job *Job, slot time.Time) bool {
+	name := jobLockName(job, slot)
+	previous, acquired, err := s.locks.AcquireLock(ctx, name, s.lockCfg.Owner, s.lockCfg.TTL)
+	if err != nil {
+		log.Warn().Err(err).Str("job", job.Name).Msg("Failed to take job lock, will retry")
+		s.setPending(name, job, slot)
+		return false
+	}
+
+	if !acquired {
+		if previous != nil && previous.CompletedAt == nil {
+			if s.setPending(name, job, slot) {
+				metrics.JobLocks.WithLabelValues(job.Name, "held").Inc()
+				log.Info().Str("job", job.Name).Str("owner", previous.Owner).Msg("Job running on another instance, skipped")
+			}
+		} else {
+			s.clearPending(name)
+		}
+		return false
+	}
+
+	s.clearPending(name)
+	if previous != nil && previous.Owner != s.lockCfg.Owner {
+		metrics.JobLocks.WithLabelValues(job.Name, "takeover").Inc()
+		log.Warn().
+			Str("job", job.Name).
+			Str("previous_owner", previous.Owner).
+			Time("expired_at", previous.ExpiresAt).
+			Msg("Took over job from unresponsive instance")
+	} else {
+		metrics.JobLocks.WithLabelValues(job.Name, "acquired").Inc()
+	}
+	return true
+}
+
+// holdLock renews a job's lock until the returned release is called, which
+// then marks the run completed. If the lock is lost to another instance,
+// cancel stops the job.
+func (s *Scheduler) holdLock(ctx context.Context, cancel context.CancelFunc, job *Job, name string) (release func()) {
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(s.lockCfg.TTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				held, err := s.locks.RenewLock(ctx, name, s.lockCfg.Owner, s.lockCfg.TTL)
+				if err != nil {
+					log.Warn().Err(err).Str("job", job.Name).Msg("Failed to renew job lock")
+					continue
+				}
+				if !held {
+					metrics.JobLocks.WithLabelValues(job.Name, "lost").Inc()
+					log.Warn().Str("job", job.Name).Msg("Lost job lock to another instance, stopping job")
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		wg.Wait()
+
+		// The job's context may be done already
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.locks.CompleteLock(ctx, name, s.lockCfg.Owner); err != nil {
+			log.Warn().Err(err).Str("job", job.Name).Msg("Failed to complete job lock")
+		}
+	}
+}
+
+// setPending records a run to retry, reporting whether it is new.
+func (s *Scheduler) setPending(name string, job *Job, slot time.Time) bool {
+	s.pendingMux.Lock()
+	defer s.pendingMux.Unlock()
+	if _, ok := s.pending[name]; ok {
+		return false
+	}
+	s.pending[name] = pendingRun{job: job, slot: slot}
+	return true
+}
+
+func (s *Scheduler) clearPending(name string) {
+	s.pendingMux.Lock()
+	delete(s.pending, name)
+	s.pendingMux.Unlock()
+}
+
+// retryPending retries runs held by other instances, giving up on ones
+// older than pendingRunWindow.
+func (s *Scheduler) retryPending(now time.Time) {
+	s.pendingMux.Lock()
+	defer s.pendingMux.Unlock()
+	for name, run := range s.pending {
+		if now.Sub(run.slot) > pendingRunWindow {
+			delete(s.pending, name)
+			continue
+		}
+		s.startRun(run.job, run.slot)
+	}
+}
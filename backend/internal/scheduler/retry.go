@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// RunStore records job runs. *storage.Store implements it.
+type RunStore interface {
+	SaveJobRun(ctx context.Context, run *models.JobRun) error
+}
+
+// RetryPolicy controls how a failed job run is retried.
+type RetryPolicy struct {
+	// Attempts is the number of tries per run, including the first
+	Attempts int
+
+	// Backoff is the wait before the first retry; it doubles after each
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy for jobs that don't
+// set their own.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Attempts: 3, Backoff: time.Minute}
+}
+
+// SetRetryPolicy sets the retry policy for jobs that don't set their own.
+func (s *Scheduler) SetRetryPolicy(policy RetryPolicy) {
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	s.retry = policy
+}
+
+// SetRunHistory records every job attempt in the store's job_runs
+// collection.
+func (s *Scheduler) SetRunHistory(store RunStore) {
+	s.runs = store
+}
+
+// runAttempts runs a job until it succeeds, fails in a way retrying can't
+// fix, or runs out of attempts. A run that gives up is recorded as dead; an
+// interval job also gives up when a retry would come after its next run.
+func (s *Scheduler) runAttempts(ctx context.Context, job *Job, slot time.Time) {
+	policy := job.Retry
+	if policy.Attempts <= 0 {
+		policy = s.retry
+	}
+
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		err := s.attemptJob(ctx, job, attempt)
+		finished := time.Now()
+
+		status := models.JobRunSucceeded
+		var wait time.Duration
+		if err != nil {
+			status = models.JobRunDead
+			if attempt < policy.Attempts && retryable(err) {
+				wait = policy.Backoff << (attempt - 1)
+				nextRun := slot.Add(job.Schedule.Interval)
+				if job.Schedule.Type != ScheduleInterval || slot.IsZero() || finished.Add(wait).Before(nextRun) {
+					status = models.JobRunFailed
+				}
+			}
+		}
+		s.recordRun(job, slot, attempt, policy.Attempts, status, err, started, finished)
+
+		switch status {
+		case models.JobRunSucceeded:
+			log.Info().Str("job", job.Name).Int("attempt", attempt).Dur("duration", finished.Sub(started)).Msg("Job completed")
+			return
+		case models.JobRunDead:
+			log.Error().Err(err).Str("job", job.Name).Int("attempt", attempt).Msg("Job failed, giving up")
+			return
+		}
+
+		log.Warn().Err(err).Str("job", job.Name).Int("attempt", attempt).Dur("retry_in", wait).Msg("Job failed, retrying")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryable reports whether a failed attempt is worth repeating. Duplicate
+// articles won't stop being duplicates, and a cancelled run was stopped on
+// purpose.
+func retryable(err error) bool {
+	return !errors.Is(err, content.ErrDuplicateArticle) && !errors.Is(err, context.Canceled)
+}
+
+// recordRun counts a job attempt and stores it in the run history.
+func (s *Scheduler) recordRun(job *Job, slot time.Time, attempt, attempts int, status models.JobRunStatus, err error, started, finished time.Time) {
+	metrics.JobRuns.WithLabelValues(job.Name, string(status)).Inc()
+	if s.runs == nil {
+		return
+	}
+
+	run := &models.JobRun{
+		Job:        job.Name,
+		Instance:   s.lockCfg.Owner,
+		Attempt:    attempt,
+		Attempts:   attempts,
+		Status:     status,
+		StartedAt:  started,
+		FinishedAt: finished,
+		DurationMs: finished.Sub(started).Milliseconds(),
+	}
+	if !slot.IsZero() {
+		run.Slot = &slot
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	// The run's context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.runs.SaveJobRun(ctx, run); err != nil {
+		log.Warn().Err(err).Str("job", job.Name).Msg("Failed to record job run")
+	}
+}
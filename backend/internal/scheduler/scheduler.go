@@ -3,13 +3,18 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/leeaandrob/futuresignals/internal/content"
+	"github.com/leeaandrob/futuresignals/internal/llm"
 	"github.com/leeaandrob/futuresignals/internal/models"
 	syncer "github.com/leeaandrob/futuresignals/internal/sync"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Job represents a scheduled job.
@@ -17,13 +22,18 @@ type Job struct {
 	Name     string
 	Schedule Schedule
 	Handler  func(ctx context.Context) error
+	Retry    RetryPolicy // Zero uses the scheduler's default policy
+	Local    bool        // Runs on every instance, without taking the job lock
 	LastRun  time.Time
 	NextRun  time.Time
+
+	briefing bool // Built from a briefing config; replaced on reload
 }
 
 // Schedule defines when a job should run.
 type Schedule struct {
-	// For fixed-interval jobs
+	// For fixed-interval jobs; runs fall on multiples of the interval so
+	// every instance schedules them at the same times
 	Interval time.Duration
 
 	// For time-of-day jobs (in UTC)
@@ -46,6 +56,25 @@ const (
 	ScheduleWeekly     ScheduleType = "weekly"
 )
 
+// EventSubscriber is the scheduler's durable subscriber name; its offset
+// tracks which syncer events have been processed.
+const EventSubscriber = "scheduler"
+
+// briefingRetry gives briefings and digests, which miss their slot
+// entirely if they fail, more room to ride out LLM outages.
+var briefingRetry = RetryPolicy{Attempts: 4, Backoff: 2 * time.Minute}
+
+// DefaultMinEventScore is the significance score an event needs before it
+// triggers an article.
+const DefaultMinEventScore = 0.4
+
+// ErrNoSyncer is returned by event operations when the scheduler has no
+// syncer.
+var ErrNoSyncer = errors.New("scheduler has no syncer")
+
+// ErrJobNotFound is returned by RunJobNow for an unknown job.
+var ErrJobNotFound = errors.New("job not found")
+
 // Scheduler manages scheduled jobs and event-driven content generation.
 type Scheduler struct {
 	generator *content.Generator
@@ -56,11 +85,39 @@ type Scheduler struct {
 
 	// Event processing
 	eventChan <-chan syncer.Event
-
-	// Lifecycle
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	minScore  float64
+
+	// Configured minScore, which runtime settings override; minScore is
+	// guarded by minScoreMux
+	baseMinScore float64
+	minScoreMux  sync.RWMutex
+
+	// Distributed locking; nil locks runs every job on this instance
+	locks      LockStore
+	lockCfg    LockConfig
+	pending    map[string]pendingRun
+	pendingMux sync.Mutex
+
+	// Retries and run history; nil runs keeps no history
+	retry RetryPolicy
+	runs  RunStore
+
+	// Content calendar; nil runs no scheduled content
+	calendar CalendarStore
+
+	// Explainer topic queue; nil generates no explainers
+	explainers ExplainerStore
+
+	// Briefing configs; nil schedules the built-in defaults
+	briefings BriefingConfigStore
+
+	// Lifecycle. ctx stops the loops; runCtx cancels the job runs and
+	// event handling in flight, which Drain lets finish first
+	ctx        context.Context
+	cancel     context.CancelFunc
+	runCtx     context.Context
+	cancelRuns context.CancelFunc
+	wg         sync.WaitGroup
 }
 
 // NewScheduler creates a new scheduler.
@@ -71,13 +128,19 @@ func NewScheduler(generator *content.Generator, sync *syncer.Syncer) *Scheduler
 		generator: generator,
 		syncer:    sync,
 		jobs:      make([]*Job, 0),
+		minScore:  DefaultMinEventScore,
+		pending:   make(map[string]pendingRun),
+		lockCfg:   DefaultLockConfig(),
+		retry:     DefaultRetryPolicy(),
 		ctx:       ctx,
 		cancel:    cancel,
 	}
+	s.runCtx, s.cancelRuns = context.WithCancel(context.Background())
+	s.baseMinScore = s.minScore
 
 	// Subscribe to syncer events
 	if sync != nil {
-		s.eventChan = sync.Subscribe()
+		s.eventChan = sync.SubscribeDurable(EventSubscriber)
 	}
 
 	// Register default jobs
@@ -86,104 +149,161 @@ func NewScheduler(generator *content.Generator, sync *syncer.Syncer) *Scheduler
 	return s
 }
 
+// SetMinEventScore sets the significance score breaking and threshold
+// events need before they trigger an article.
+func (s *Scheduler) SetMinEventScore(score float64) {
+	s.minScoreMux.Lock()
+	defer s.minScoreMux.Unlock()
+	s.minScore = score
+	s.baseMinScore = score
+}
+
+// significant reports whether an event's score clears the minimum. Events
+// without a score, such as ones persisted before scoring, pass.
+func (s *Scheduler) significant(event syncer.Event) bool {
+	s.minScoreMux.RLock()
+	minScore := s.minScore
+	s.minScoreMux.RUnlock()
+
+	score, ok := event.Metadata["score"].(float64)
+	if !ok || score >= minScore {
+		return true
+	}
+	log.Debug().
+		Str("type", string(event.Type)).
+		Str("market", event.Market.Question).
+		Float64("score", score).
+		Msg("Skipped low significance event")
+	return false
+}
+
 // registerDefaultJobs sets up the default content generation schedule.
 func (s *Scheduler) registerDefaultJobs() {
-	// Morning briefing at 8:00 UTC
+	// Briefings, until SetBriefings replaces them with the stored configs
+	defaults := make([]models.BriefingConfig, 0, len(models.DefaultBriefingConfigs))
+	for _, cfg := range models.DefaultBriefingConfigs {
+		defaults = append(defaults, cfg)
+	}
+	s.setBriefingJobs(defaults)
+
+	// Trending update every 2 hours
 	s.AddJob(&Job{
-		Name: "morning-briefing",
+		Name: "trending-update",
 		Schedule: Schedule{
-			Type:   ScheduleDaily,
-			Hour:   8,
-			Minute: 0,
+			Type:     ScheduleInterval,
+			Interval: 2 * time.Hour,
 		},
 		Handler: func(ctx context.Context) error {
-			_, err := s.generator.GenerateBriefing(ctx, models.BriefingMorning)
+			_, err := s.generator.GenerateTrending(ctx, 10)
 			return err
 		},
 	})
 
-	// Midday pulse at 12:00 UTC
+	// Category digests - one per category per day, staggered
+	categories := []string{"crypto", "politics", "tech", "sports", "finance"}
+	for i, cat := range categories {
+		category := cat // capture for closure
+		hour := 9 + i   // Stagger: 9:00, 10:00, 11:00, etc.
+
+		s.AddJob(&Job{
+			Name: category + "-digest",
+			Schedule: Schedule{
+				Type:   ScheduleDaily,
+				Hour:   hour,
+				Minute: 30,
+			},
+			Handler: func(ctx context.Context) error {
+				_, err := s.generator.GenerateCategoryDigest(ctx, category, 10)
+				return err
+			},
+			Retry: briefingRetry,
+		})
+	}
+
+	// Markets resolving this week, once a day before the morning briefing
 	s.AddJob(&Job{
-		Name: "midday-pulse",
+		Name: "closing-soon",
 		Schedule: Schedule{
 			Type:   ScheduleDaily,
-			Hour:   12,
-			Minute: 0,
+			Hour:   7,
+			Minute: 30,
 		},
 		Handler: func(ctx context.Context) error {
-			_, err := s.generator.GenerateBriefing(ctx, models.BriefingMidday)
+			_, err := s.generator.GenerateClosingSoon(ctx, 7, 10)
+			if errors.Is(err, content.ErrDuplicateArticle) {
+				return nil
+			}
 			return err
 		},
+		Retry: briefingRetry,
 	})
 
-	// Evening wrap at 18:00 UTC
+	// Day's biggest gainers and losers, after the evening briefing
 	s.AddJob(&Job{
-		Name: "evening-wrap",
+		Name: "movers",
 		Schedule: Schedule{
 			Type:   ScheduleDaily,
-			Hour:   18,
+			Hour:   19,
 			Minute: 0,
 		},
 		Handler: func(ctx context.Context) error {
-			_, err := s.generator.GenerateBriefing(ctx, models.BriefingEvening)
+			_, err := s.generator.GenerateMovers(ctx, 5)
+			if errors.Is(err, content.ErrDuplicateArticle) {
+				return nil
+			}
 			return err
 		},
+		Retry: briefingRetry,
 	})
 
-	// Weekly digest on Monday at 10:00 UTC
+	// Coming week's deadlines and catalysts, every Sunday
 	s.AddJob(&Job{
-		Name: "weekly-digest",
+		Name: "week-ahead",
 		Schedule: Schedule{
 			Type:   ScheduleWeekly,
-			Hour:   10,
+			Hour:   16,
 			Minute: 0,
-			Days:   []int{1}, // Monday
+			Days:   []int{int(time.Sunday)},
 		},
 		Handler: func(ctx context.Context) error {
-			_, err := s.generator.GenerateBriefing(ctx, models.BriefingWeekly)
+			_, err := s.generator.GenerateWeekAhead(ctx)
+			if errors.Is(err, content.ErrDuplicateArticle) {
+				return nil
+			}
 			return err
 		},
+		Retry: briefingRetry,
 	})
 
-	// Trending update every 2 hours
+	// Events whose outcomes' odds don't add up, every Thursday
 	s.AddJob(&Job{
-		Name: "trending-update",
+		Name: "mispricing-watch",
 		Schedule: Schedule{
-			Type:     ScheduleInterval,
-			Interval: 2 * time.Hour,
+			Type:   ScheduleWeekly,
+			Hour:   15,
+			Minute: 0,
+			Days:   []int{int(time.Thursday)},
 		},
 		Handler: func(ctx context.Context) error {
-			_, err := s.generator.GenerateTrending(ctx, 10)
+			_, err := s.generator.GenerateMispricingWatch(ctx, 5)
+			if errors.Is(err, content.ErrDuplicateArticle) {
+				return nil
+			}
 			return err
 		},
+		Retry: briefingRetry,
 	})
-
-	// Category digests - one per category per day, staggered
-	categories := []string{"crypto", "politics", "tech", "sports", "finance"}
-	for i, cat := range categories {
-		category := cat // capture for closure
-		hour := 9 + i   // Stagger: 9:00, 10:00, 11:00, etc.
-
-		s.AddJob(&Job{
-			Name: category + "-digest",
-			Schedule: Schedule{
-				Type:   ScheduleDaily,
-				Hour:   hour,
-				Minute: 30,
-			},
-			Handler: func(ctx context.Context) error {
-				_, err := s.generator.GenerateCategoryDigest(ctx, category, 10)
-				return err
-			},
-		})
-	}
 }
 
 // AddJob adds a job to the scheduler.
 func (s *Scheduler) AddJob(job *Job) {
 	s.jobsMux.Lock()
 	defer s.jobsMux.Unlock()
+	s.addJob(job)
+}
 
+// addJob adds a job; the caller holds jobsMux.
+func (s *Scheduler) addJob(job *Job) {
 	job.NextRun = s.calculateNextRun(job.Schedule)
 	s.jobs = append(s.jobs, job)
 
@@ -208,13 +328,61 @@ func (s *Scheduler) Start() {
 	}
 }
 
-// Stop stops the scheduler.
+// Stop stops the scheduler, cancelling job runs and article generations
+// in flight.
 func (s *Scheduler) Stop() {
 	log.Info().Msg("Stopping scheduler")
-	s.cancel()
+	s.stopLoops()
+	s.cancelRuns()
 	s.wg.Wait()
 }
 
+// Drain stops the scheduler taking events and starting job runs, then
+// waits for the job runs and article generations in flight to finish.
+// Those still going when ctx is done are cancelled. Events left in the
+// queue stay unacknowledged, pending replay.
+func (s *Scheduler) Drain(ctx context.Context) {
+	log.Info().Msg("Draining scheduler")
+	s.stopLoops()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Info().Msg("Scheduler drained")
+	case <-ctx.Done():
+		log.Warn().Msg("Scheduler drain timed out, cancelling runs in flight")
+		s.cancelRuns()
+		<-done
+	}
+	s.cancelRuns()
+}
+
+// stopLoops stops the job and event loops. Taking jobsMux once the
+// context is cancelled ensures no run starts afterwards.
+func (s *Scheduler) stopLoops() {
+	s.cancel()
+	s.jobsMux.Lock()
+	s.jobsMux.Unlock()
+}
+
+// startRun runs a job's run in the background, unless the scheduler is
+// stopping. The caller holds jobsMux.
+func (s *Scheduler) startRun(job *Job, slot time.Time) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJob(job, slot)
+	}()
+}
+
 // jobLoop checks and runs scheduled jobs.
 func (s *Scheduler) jobLoop() {
 	defer s.wg.Done()
@@ -241,7 +409,7 @@ func (s *Scheduler) checkAndRunJobs() {
 
 	for _, job := range s.jobs {
 		if now.After(job.NextRun) || now.Equal(job.NextRun) {
-			go s.runJob(job)
+			s.startRun(job, job.NextRun)
 			job.LastRun = now
 			job.NextRun = s.calculateNextRun(job.Schedule)
 
@@ -251,20 +419,45 @@ func (s *Scheduler) checkAndRunJobs() {
 				Msg("Job scheduled for next run")
 		}
 	}
-}
 
-// runJob executes a job.
-func (s *Scheduler) runJob(job *Job) {
-	log.Info().Str("job", job.Name).Msg("Running job")
+	if s.locks != nil {
+		s.retryPending(now)
+	}
+}
 
-	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
+// runJob executes a job's run scheduled at slot, retrying failed attempts
+// per its retry policy. With locking enabled, the run only goes ahead on the
+// instance holding its lock; a zero slot or a local job runs unlocked.
+func (s *Scheduler) runJob(job *Job, slot time.Time) {
+	ctx, cancel := context.WithCancel(s.runCtx)
 	defer cancel()
 
-	if err := job.Handler(ctx); err != nil {
-		log.Error().Err(err).Str("job", job.Name).Msg("Job failed")
-	} else {
-		log.Info().Str("job", job.Name).Msg("Job completed")
+	if s.locks != nil && !slot.IsZero() && !job.Local {
+		if !s.lockJob(ctx, job, slot) {
+			return
+		}
+		release := s.holdLock(ctx, cancel, job, jobLockName(job, slot))
+		defer release()
 	}
+
+	s.runAttempts(ctx, job, slot)
+}
+
+// attemptJob runs a job's handler once.
+func (s *Scheduler) attemptJob(ctx context.Context, job *Job, attempt int) error {
+	log.Info().Str("job", job.Name).Int("attempt", attempt).Msg("Running job")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	ctx = llm.WithJob(ctx, job.Name)
+
+	ctx, span := tracing.Start(ctx, "scheduler.job",
+		attribute.String("job", job.Name),
+		attribute.Int("attempt", attempt),
+	)
+	err := job.Handler(ctx)
+	tracing.End(span, err)
+	return err
 }
 
 // calculateNextRun calculates the next run time for a schedule.
@@ -273,7 +466,7 @@ func (s *Scheduler) calculateNextRun(schedule Schedule) time.Time {
 
 	switch schedule.Type {
 	case ScheduleInterval:
-		return now.Add(schedule.Interval)
+		return now.Truncate(schedule.Interval).Add(schedule.Interval)
 
 	case ScheduleDaily:
 		next := time.Date(now.Year(), now.Month(), now.Day(),
@@ -317,11 +510,27 @@ func (s *Scheduler) eventLoop() {
 			if !ok {
 				return
 			}
+			// A replay may already have handled it
+			if !s.syncer.Claim(s.runCtx, EventSubscriber, event) {
+				continue
+			}
 			s.processEvent(event)
+			if err := s.syncer.Ack(s.runCtx, EventSubscriber, event); err != nil {
+				log.Warn().Err(err).Str("event", event.ID).Msg("Failed to acknowledge event")
+			}
 		}
 	}
 }
 
+// ReplayEvents processes events the scheduler hasn't acknowledged, oldest
+// first, up to limit (0 for all). It returns how many were replayed.
+func (s *Scheduler) ReplayEvents(ctx context.Context, limit int) (int, error) {
+	if s.syncer == nil {
+		return 0, ErrNoSyncer
+	}
+	return s.syncer.Replay(ctx, EventSubscriber, limit, s.processEvent)
+}
+
 // processEvent handles a market event and generates content if appropriate.
 func (s *Scheduler) processEvent(event syncer.Event) {
 	log.Debug().
@@ -329,14 +538,31 @@ func (s *Scheduler) processEvent(event syncer.Event) {
 		Str("market", event.Market.Question).
 		Msg("Processing event")
 
-	ctx, cancel := context.WithTimeout(s.ctx, 2*time.Minute)
+	ctx, cancel := context.WithTimeout(s.runCtx, 2*time.Minute)
 	defer cancel()
+	ctx = llm.WithJob(ctx, "event:"+string(event.Type))
+
+	// Continue the trace of the sync cycle that emitted the event
+	ctx, span := tracing.Start(trace.ContextWithRemoteSpanContext(ctx, event.SpanContext), "scheduler.process_event",
+		attribute.String("event.type", string(event.Type)),
+		attribute.String("market.id", event.Market.MarketID),
+	)
+	defer span.End()
 
 	switch event.Type {
 	case syncer.EventBreakingMove:
 		// Generate breaking news for significant movements
+		if !s.significant(event) {
+			return
+		}
 		if _, err := s.generator.GenerateBreaking(ctx, event); err != nil {
-			log.Error().Err(err).Msg("Failed to generate breaking article")
+			if errors.Is(err, content.ErrDuplicateArticle) {
+				log.Debug().Str("market", event.Market.Question).Msg("Skipped duplicate breaking article")
+			} else if errors.Is(err, content.ErrIlliquidMarket) {
+				log.Debug().Str("market", event.Market.Question).Msg("Skipped breaking article for thinly traded market")
+			} else {
+				log.Error().Err(err).Msg("Failed to generate breaking article")
+			}
 		}
 
 	case syncer.EventNewMarket:
@@ -350,19 +576,48 @@ func (s *Scheduler) processEvent(event syncer.Event) {
 	case syncer.EventThresholdCross:
 		// Generate article when market crosses key thresholds
 		threshold := event.Metadata["threshold"].(float64)
-		if threshold >= 0.75 || threshold <= 0.25 {
+		if (threshold >= 0.75 || threshold <= 0.25) && s.significant(event) {
 			// Only for extreme thresholds
 			if _, err := s.generator.GenerateBreaking(ctx, event); err != nil {
-				log.Error().Err(err).Msg("Failed to generate threshold article")
+				if errors.Is(err, content.ErrDuplicateArticle) {
+					log.Debug().Str("market", event.Market.Question).Msg("Skipped duplicate threshold article")
+				} else if errors.Is(err, content.ErrIlliquidMarket) {
+					log.Debug().Str("market", event.Market.Question).Msg("Skipped threshold article for thinly traded market")
+				} else {
+					log.Error().Err(err).Msg("Failed to generate threshold article")
+				}
+			}
+		}
+
+	case syncer.EventMarketResolved:
+		// Wrap up resolved markets that drew real money
+		if event.Market.TotalVolume >= 100000 {
+			if _, err := s.generator.GenerateResolution(ctx, event.Market); err != nil {
+				if errors.Is(err, content.ErrDuplicateArticle) {
+					log.Debug().Str("market", event.Market.Question).Msg("Skipped duplicate resolution article")
+				} else {
+					log.Error().Err(err).Msg("Failed to generate resolution article")
+				}
 			}
 		}
 
 	case syncer.EventVolumeSpike:
 		// Could generate article for volume spikes
+		// Events stored before baselines carry no z-score
+		z, _ := event.Metadata["z_score"].(float64)
 		log.Info().
 			Str("market", event.Market.Question).
 			Float64("multiplier", event.Metadata["multiplier"].(float64)).
+			Float64("z_score", z).
 			Msg("Volume spike detected")
+
+	case syncer.EventWhaleTrade:
+		// Large trades feed breaking articles through the market's trade flow
+		log.Info().
+			Str("market", event.Market.Question).
+			Float64("size_usd", event.Metadata["size_usd"].(float64)).
+			Str("side", event.Metadata["side"].(string)).
+			Msg("Whale trade detected")
 	}
 }
 
@@ -373,12 +628,12 @@ func (s *Scheduler) RunJobNow(name string) error {
 
 	for _, job := range s.jobs {
 		if job.Name == name {
-			go s.runJob(job)
+			s.startRun(job, time.Time{})
 			return nil
 		}
 	}
 
-	return nil
+	return ErrJobNotFound
 }
 
 // GetJobStatus returns the status of all jobs.
@@ -388,10 +643,16 @@ func (s *Scheduler) GetJobStatus() []map[string]interface{} {
 
 	status := make([]map[string]interface{}, len(s.jobs))
 	for i, job := range s.jobs {
+		retry := job.Retry
+		if retry.Attempts <= 0 {
+			retry = s.retry
+		}
 		status[i] = map[string]interface{}{
 			"name":     job.Name,
 			"last_run": job.LastRun,
 			"next_run": job.NextRun,
+			"attempts": retry.Attempts,
+			"backoff":  retry.Backoff.String(),
 		}
 	}
 	return status
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// ApplySettings overrides the configured minimum event score with the
+// runtime settings' one, or restores it when the setting is nil.
+func (s *Scheduler) ApplySettings(rs models.RuntimeSettings) {
+	s.minScoreMux.Lock()
+	defer s.minScoreMux.Unlock()
+	s.minScore = s.baseMinScore
+	if rs.MinEventScore != nil {
+		s.minScore = *rs.MinEventScore
+	}
+}
+
+// EffectiveSettings fills in the scheduler's fields of rs with the values
+// in effect.
+func (s *Scheduler) EffectiveSettings(rs *models.RuntimeSettings) {
+	s.minScoreMux.RLock()
+	defer s.minScoreMux.RUnlock()
+	score := s.minScore
+	rs.MinEventScore = &score
+}
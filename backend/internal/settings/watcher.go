@@ -0,0 +1,179 @@
+// Package settings reloads the runtime settings stored in MongoDB into the
+// components they tune, without a restart.
+package settings
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Target is a component tuned by runtime settings.
+type Target interface {
+	// ApplySettings puts settings in effect; nil fields restore the
+	// configured values
+	ApplySettings(models.RuntimeSettings)
+
+	// EffectiveSettings fills in the target's fields of rs with the values
+	// in effect
+	EffectiveSettings(rs *models.RuntimeSettings)
+}
+
+// Config holds settings watcher configuration.
+type Config struct {
+	// How often settings are reloaded when change streams are unavailable,
+	// as on a standalone MongoDB server
+	PollInterval time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: time.Minute,
+	}
+}
+
+// Watcher applies the stored runtime settings to its targets, and again
+// whenever they change on any instance. Changes arrive through a MongoDB
+// change stream, or every PollInterval without one.
+type Watcher struct {
+	store   *storage.Store
+	config  Config
+	targets []Target
+
+	// Settings last applied; mu also serializes reloads
+	mu      sync.Mutex
+	current models.RuntimeSettings
+	loaded  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatcher creates a settings watcher. Add targets, then call Start.
+func NewWatcher(store *storage.Store, cfg Config) *Watcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultConfig().PollInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Watcher{
+		store:  store,
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// AddTarget gives a component the runtime settings. Call before Start.
+func (w *Watcher) AddTarget(t Target) {
+	w.targets = append(w.targets, t)
+}
+
+// Start applies the stored settings, then watches for changes.
+func (w *Watcher) Start() {
+	ctx, cancel := context.WithTimeout(w.ctx, 10*time.Second)
+	if err := w.Reload(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to load runtime settings, keeping configured values")
+	}
+	cancel()
+
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops watching.
+func (w *Watcher) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	polling := false
+	for {
+		err := w.store.WatchRuntimeSettings(w.ctx, w.reload)
+		if w.ctx.Err() != nil {
+			return
+		}
+		if !polling {
+			log.Info().Err(err).Dur("interval", w.config.PollInterval).Msg("Runtime settings change stream unavailable, polling")
+			polling = true
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(w.config.PollInterval):
+		}
+		w.reload()
+	}
+}
+
+// reload reloads the settings, logging failures.
+func (w *Watcher) reload() {
+	ctx, cancel := context.WithTimeout(w.ctx, 10*time.Second)
+	defer cancel()
+	if err := w.Reload(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to reload runtime settings")
+	}
+}
+
+// Reload loads the stored settings and applies them to the targets if
+// they changed.
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stored, err := w.store.GetRuntimeSettings(ctx)
+	if err != nil {
+		return err
+	}
+	var settings models.RuntimeSettings
+	if stored != nil {
+		settings = *stored
+	}
+	if w.loaded && reflect.DeepEqual(settings, w.current) {
+		return nil
+	}
+
+	for _, t := range w.targets {
+		t.ApplySettings(settings)
+	}
+	w.current = settings
+	w.loaded = true
+	return nil
+}
+
+// Save stores new settings, replacing the old ones, and applies them on
+// this instance at once; other instances pick them up from the store.
+func (w *Watcher) Save(ctx context.Context, settings *models.RuntimeSettings) error {
+	if err := w.store.SaveRuntimeSettings(ctx, settings); err != nil {
+		return err
+	}
+	return w.Reload(ctx)
+}
+
+// Settings returns the runtime settings in effect as stored: nil fields
+// are at their configured values.
+func (w *Watcher) Settings() models.RuntimeSettings {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Effective returns every setting's value in effect, stored or
+// configured.
+func (w *Watcher) Effective() models.RuntimeSettings {
+	settings := models.RuntimeSettings{UpdatedAt: w.Settings().UpdatedAt}
+	for _, t := range w.targets {
+		t.EffectiveSettings(&settings)
+	}
+	return settings
+}
@@ -0,0 +1,341 @@
+// Package staticsite renders published articles to Markdown and HTML files
+// with front matter, for a static site generator or an archive in object
+// storage, and flattens single articles to Markdown and plain text for
+// reader modes.
+package staticsite
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Output formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// pageSize is how many articles are read per query.
+const pageSize = 100
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var (
+	textFuncs = texttemplate.FuncMap{
+		"date":       formatDate,
+		"day":        formatDay,
+		"percent":    percent,
+		"paragraphs": paragraphs,
+	}
+
+	markdownTemplate = texttemplate.Must(texttemplate.New("article.md").Funcs(textFuncs).
+				ParseFS(templateFS, "templates/article.md", "templates/body.md"))
+
+	textTemplate = texttemplate.Must(texttemplate.New("body.txt").Funcs(textFuncs).
+			ParseFS(templateFS, "templates/body.txt"))
+
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("article.html").Funcs(htmltemplate.FuncMap{
+		"date":       formatDate,
+		"percent":    percent,
+		"paragraphs": paragraphs,
+	}).ParseFS(templateFS, "templates/article.html"))
+)
+
+// ValidFormat reports whether format is a supported output format.
+func ValidFormat(format string) bool {
+	return format == FormatMarkdown || format == FormatHTML
+}
+
+// Config holds static export configuration.
+type Config struct {
+	// Public site URL for article and market links
+	SiteURL string
+
+	// FormatMarkdown, FormatHTML or both
+	Formats []string
+
+	// Key prefix files are written under, e.g. "site/" in a bucket shared
+	// with share images
+	Prefix string
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		SiteURL: "https://futuresignals.news",
+		Formats: []string{FormatMarkdown, FormatHTML},
+	}
+}
+
+// ArticleSource pages through published and taken down articles.
+type ArticleSource interface {
+	// GetPublishedArticles returns published articles updated at or after
+	// since (all if zero), in a stable order.
+	GetPublishedArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error)
+
+	// GetTakenDownArticles returns articles unpublished or deleted at or
+	// after since (all if zero), in a stable order.
+	GetTakenDownArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error)
+}
+
+// Storage saves and removes exported files; see the blob package.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Result summarizes an export.
+type Result struct {
+	Articles int `json:"articles"`
+	Files    int `json:"files"`
+	Removed  int `json:"removed"` // Taken down articles whose files were removed
+	Failed   int `json:"failed"`
+}
+
+// Exporter writes articles to storage.
+type Exporter struct {
+	source  ArticleSource
+	storage Storage
+	config  Config
+}
+
+// NewExporter creates a new exporter.
+func NewExporter(source ArticleSource, storage Storage, cfg Config) *Exporter {
+	cfg.SiteURL = strings.TrimRight(cfg.SiteURL, "/")
+	if len(cfg.Formats) == 0 {
+		cfg.Formats = DefaultConfig().Formats
+	}
+	return &Exporter{source: source, storage: storage, config: cfg}
+}
+
+// Export renders the published articles updated since since, or all of
+// them if since is zero, and removes the files of articles taken down in
+// that time. Articles that fail to render, store or remove are logged and
+// counted; the export goes on.
+func (e *Exporter) Export(ctx context.Context, since time.Time) (Result, error) {
+	var result Result
+	if err := e.removeTakenDown(ctx, since, &result); err != nil {
+		return result, err
+	}
+
+	for skip := int64(0); ; skip += pageSize {
+		articles, err := e.source.GetPublishedArticles(ctx, since, skip, pageSize)
+		if err != nil {
+			return result, fmt.Errorf("load articles: %w", err)
+		}
+
+		for i := range articles {
+			files, err := e.exportArticle(ctx, &articles[i])
+			result.Files += files
+			if err != nil {
+				log.Warn().Err(err).Str("slug", articles[i].Slug).Msg("Failed to export article")
+				result.Failed++
+				continue
+			}
+			result.Articles++
+		}
+
+		if len(articles) < pageSize {
+			return result, nil
+		}
+	}
+}
+
+// removeTakenDown removes the files of articles taken down since since, in
+// every format, as the formats may have changed since they were exported.
+func (e *Exporter) removeTakenDown(ctx context.Context, since time.Time, result *Result) error {
+	for skip := int64(0); ; skip += pageSize {
+		articles, err := e.source.GetTakenDownArticles(ctx, since, skip, pageSize)
+		if err != nil {
+			return fmt.Errorf("load taken down articles: %w", err)
+		}
+
+		for _, article := range articles {
+			if err := e.removeArticle(ctx, article.Slug); err != nil {
+				log.Warn().Err(err).Str("slug", article.Slug).Msg("Failed to remove taken down article")
+				result.Failed++
+				continue
+			}
+			result.Removed++
+		}
+
+		if len(articles) < pageSize {
+			return nil
+		}
+	}
+}
+
+func (e *Exporter) removeArticle(ctx context.Context, slug string) error {
+	for _, ext := range []string{"md", "html"} {
+		key := fmt.Sprintf("%sarticles/%s.%s", e.config.Prefix, slug, ext)
+		if err := e.storage.Delete(ctx, key); err != nil {
+			return fmt.Errorf("remove %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// exportArticle writes one article in each format, returning how many
+// files were stored.
+func (e *Exporter) exportArticle(ctx context.Context, article *models.Article) (int, error) {
+	data := newPageData(article, e.config.SiteURL)
+
+	files := 0
+	for _, format := range e.config.Formats {
+		var (
+			buf         bytes.Buffer
+			err         error
+			ext         string
+			contentType string
+		)
+		switch format {
+		case FormatMarkdown:
+			err = markdownTemplate.ExecuteTemplate(&buf, "article.md", data)
+			ext, contentType = "md", "text/markdown; charset=utf-8"
+		case FormatHTML:
+			err = htmlTemplate.ExecuteTemplate(&buf, "article.html", data)
+			ext, contentType = "html", "text/html; charset=utf-8"
+		default:
+			continue
+		}
+		if err != nil {
+			return files, fmt.Errorf("render %s: %w", format, err)
+		}
+
+		key := fmt.Sprintf("%sarticles/%s.%s", e.config.Prefix, article.Slug, ext)
+		if _, err := e.storage.Put(ctx, key, contentType, buf.Bytes()); err != nil {
+			return files, fmt.Errorf("store %s: %w", key, err)
+		}
+		files++
+	}
+	return files, nil
+}
+
+// Content is an article flattened, without front matter: its cleaned
+// headline, and headline and sections in Markdown and in plain text for
+// voice assistants.
+type Content struct {
+	Headline string
+	Markdown string
+	Text     string
+}
+
+// RenderContent flattens article, linking its markets under siteURL.
+func RenderContent(article *models.Article, siteURL string) (Content, error) {
+	data := newPageData(article, strings.TrimRight(siteURL, "/"))
+
+	var md, text bytes.Buffer
+	if err := markdownTemplate.ExecuteTemplate(&md, "body.md", data); err != nil {
+		return Content{}, fmt.Errorf("render markdown: %w", err)
+	}
+	if err := textTemplate.ExecuteTemplate(&text, "body.txt", data); err != nil {
+		return Content{}, fmt.Errorf("render text: %w", err)
+	}
+	return Content{Headline: data.Headline, Markdown: md.String(), Text: text.String()}, nil
+}
+
+// pageData is what the article templates render. Its generated text is
+// cleaned of markup and control characters.
+type pageData struct {
+	*models.Article
+	URL     string
+	Markets []pageMarket
+}
+
+// pageMarket is a market the article covers, with its link.
+type pageMarket struct {
+	models.MarketRef
+	URL string
+}
+
+func newPageData(article *models.Article, siteURL string) pageData {
+	// Clean a copy; the caller's article is left as it is
+	a := *article
+	a.Headline = clean(a.Headline)
+	a.Subheadline = clean(a.Subheadline)
+	a.Summary = clean(a.Summary)
+	a.Body.WhatHappened = clean(a.Body.WhatHappened)
+	a.Body.WhyItMatters = clean(a.Body.WhyItMatters)
+	a.Body.WhatToWatch = clean(a.Body.WhatToWatch)
+	a.Body.Analysis = clean(a.Body.Analysis)
+	a.Body.Context = make([]string, 0, len(article.Body.Context))
+	for _, c := range article.Body.Context {
+		if c = clean(c); c != "" {
+			a.Body.Context = append(a.Body.Context, c)
+		}
+	}
+	a.Amendments = make([]models.ArticleAmendment, len(article.Amendments))
+	for i, am := range article.Amendments {
+		am.Headline = clean(am.Headline)
+		am.WhatChanged = clean(am.WhatChanged)
+		a.Amendments[i] = am
+	}
+
+	data := pageData{
+		Article: &a,
+		URL:     a.CanonicalURL,
+	}
+	if data.URL == "" {
+		data.URL = siteURL + "/article/" + a.Slug
+	}
+	for _, m := range a.Markets {
+		m.Question = clean(m.Question)
+		data.Markets = append(data.Markets, pageMarket{
+			MarketRef: m,
+			URL:       siteURL + "/market/" + m.Slug,
+		})
+	}
+	return data
+}
+
+// htmlTag matches an HTML tag, comment, or script or style element in
+// generated text.
+var htmlTag = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>|<!--.*?-->|</?[a-z][^<>]*>`)
+
+// clean strips HTML, and control characters other than newlines and tabs,
+// from generated text.
+func clean(text string) string {
+	text = htmlTag.ReplaceAllString(text, "")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+}
+
+func formatDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatDay formats t as a spoken date, e.g. "March 3, 2025".
+func formatDay(t time.Time) string {
+	return t.UTC().Format("January 2, 2006")
+}
+
+func percent(p float64) string {
+	return fmt.Sprintf("%.0f%%", p*100)
+}
+
+// paragraphs splits generated text on blank lines.
+func paragraphs(text string) []string {
+	var out []string
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SiteArticles pages through articles for the static site export. The
+// Store serves the scheduled export through it; fsctl export-site, which
+// doesn't open a Store, uses it on the database directly.
+type SiteArticles struct {
+	articles *mongo.Collection
+}
+
+// NewSiteArticles reads db's articles for the static site export.
+func NewSiteArticles(db *mongo.Database) SiteArticles {
+	return SiteArticles{articles: db.Collection("articles")}
+}
+
+// GetPublishedArticles returns a page of published articles updated at or
+// after since (all if zero), in insertion order so pages stay stable.
+func (a SiteArticles) GetPublishedArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error) {
+	return a.find(ctx, sinceFilter(bson.M{"published": true}, since), skip, limit)
+}
+
+// GetTakenDownArticles returns a page of articles an admin unpublished or
+// deleted at or after since (all if zero), in insertion order.
+func (a SiteArticles) GetTakenDownArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error) {
+	filter := bson.M{"published": false, "$or": bson.A{
+		bson.M{"unpublished_at": bson.M{"$exists": true}},
+		bson.M{"deleted_at": bson.M{"$exists": true}},
+	}}
+	return a.find(ctx, sinceFilter(filter, since), skip, limit)
+}
+
+// CountPublishedArticles counts the published articles updated at or after
+// since (all if zero).
+func (a SiteArticles) CountPublishedArticles(ctx context.Context, since time.Time) (int64, error) {
+	return a.articles.CountDocuments(ctx, sinceFilter(bson.M{"published": true}, since))
+}
+
+func (a SiteArticles) find(ctx context.Context, filter bson.M, skip, limit int64) ([]models.Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cursor, err := a.articles.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []models.Article
+	if err := cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// sinceFilter adds to filter an updated_at of at least since, unless since
+// is zero.
+func sinceFilter(filter bson.M, since time.Time) bson.M {
+	if !since.IsZero() {
+		filter["updated_at"] = bson.M{"$gte": since}
+	}
+	return filter
+}
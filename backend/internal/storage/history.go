@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// summarizeOdds computes an OddsRange per lookback from a market's daily
+// rollups, newest first, comparing them with the current probability. Each
+// window covers the lookback's days before today; today's bucket, holding
+// the move itself, is left out. Lookbacks without readings are skipped.
+func summarizeOdds(rollups []models.SnapshotRollup, current float64, lookbacks []time.Duration, now time.Time) []models.OddsRange {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	var ranges []models.OddsRange
+	for _, lookback := range lookbacks {
+		cutoff := today.Add(-lookback)
+		r := models.OddsRange{Lookback: lookback}
+		atOrBelow := 0
+		for _, b := range rollups {
+			if !b.BucketStart.Before(today) {
+				continue
+			}
+			if b.BucketStart.Before(cutoff) {
+				break
+			}
+
+			if r.Days == 0 || b.Low < r.Min {
+				r.Min, r.MinAt = b.Low, b.BucketStart
+			}
+			if r.Days == 0 || b.High > r.Max {
+				r.Max, r.MaxAt = b.High, b.BucketStart
+			}
+			if b.Close <= current {
+				atOrBelow++
+			}
+			if r.LastAbove == nil && b.High >= current {
+				r.LastAbove = &b.BucketStart
+			}
+			if r.LastBelow == nil && b.Low <= current {
+				r.LastBelow = &b.BucketStart
+			}
+			r.Days++
+		}
+		if r.Days == 0 {
+			continue
+		}
+		r.Percentile = float64(atOrBelow) / float64(r.Days)
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// historySince returns how far back rollups are read for lookbacks: the
+// longest plus today's partial day.
+func historySince(lookbacks []time.Duration) time.Duration {
+	if len(lookbacks) == 0 {
+		return 0
+	}
+	return slices.Max(lookbacks) + 24*time.Hour
+}
@@ -0,0 +1,473 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collectionIndexes lists the indexes a collection should have.
+type collectionIndexes struct {
+	collection string
+	indexes    []mongo.IndexModel
+
+	// Indexes newer ones in the list have replaced; MigrateIndexes drops
+	// them, RestoreObsoleteIndexes brings them back
+	obsolete []mongo.IndexModel
+}
+
+// indexPlan is every index the queries in this package rely on. Compound
+// indexes follow the query shapes: equality fields first, then the sort.
+var indexPlan = []collectionIndexes{
+	// Markets indexes
+	{
+		collection: "markets",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "market_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "trending_score", Value: -1}}},
+			{Keys: bson.D{{Key: "volume_24h", Value: -1}}},
+			{Keys: bson.D{{Key: "change_24h", Value: -1}}},
+			{Keys: bson.D{{Key: "first_seen_at", Value: -1}}},
+			{Keys: bson.D{{Key: "resolved_at", Value: -1}}, Options: options.Index().SetSparse(true)},
+			{Keys: bson.D{{Key: "tags", Value: 1}}},
+			{Keys: bson.D{{Key: "polymarket_tags.slug", Value: 1}}},
+			{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "probability", Value: -1}}},
+
+			// Open market lists (ListMarkets), one per sort order, and by
+			// category. market_id is ListMarkets' tiebreak, so the sort is
+			// read off the index rather than done in memory; the prefixes
+			// serve the single-key sorts of GetTrendingMarkets and friends,
+			// and the end date one also GetClosingSoonMarkets
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "volume_24h", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "trending_score", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "change_24h", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "liquidity", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "end_date_ts", Value: 1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "first_seen_at", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "volume_24h", Value: -1}, {Key: "market_id", Value: 1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "trending_score", Value: -1}, {Key: "market_id", Value: 1}}},
+		},
+		// Prefixes of the list indexes, and those without the tiebreak
+		obsolete: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "category", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "volume_24h", Value: -1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "trending_score", Value: -1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "change_24h", Value: -1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "liquidity", Value: -1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "end_date_ts", Value: 1}}},
+			{Keys: bson.D{{Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "first_seen_at", Value: -1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "active", Value: 1}, {Key: "closed", Value: 1}, {Key: "volume_24h", Value: -1}}},
+		},
+	},
+	// Polymarket events indexes
+	{
+		collection: "polymarket_events",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "event_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "slug", Value: 1}}},
+		},
+	},
+	// Snapshots indexes
+	{
+		collection: "snapshots",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "market_id", Value: 1}, {Key: "captured_at", Value: -1}}},
+			{Keys: bson.D{{Key: "captured_at", Value: -1}}},
+		},
+	},
+	// Snapshot rollup indexes ($merge needs the unique key)
+	{
+		collection: "snapshot_rollups",
+		indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "market_id", Value: 1}, {Key: "granularity", Value: 1}, {Key: "bucket_start", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Articles indexes
+	{
+		collection: "articles",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "primary_market.market_id", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "previous_article_id", Value: 1}}},
+			{Keys: bson.D{{Key: "markets.market_id", Value: 1}, {Key: "published_at", Value: -1}}},
+
+			// Published article lists, newest first: all of them
+			// (GetRecentArticles, GetTodayArticles, the sitemap count) and
+			// by type, category, featured flag and tag
+			{Keys: bson.D{{Key: "published", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "type", Value: 1}, {Key: "published", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "published", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "featured", Value: 1}, {Key: "published", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "tags", Value: 1}, {Key: "published", Value: 1}, {Key: "published_at", Value: -1}}},
+		},
+		// Prefixes of the list indexes
+		obsolete: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "type", Value: 1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}}},
+			{Keys: bson.D{{Key: "published", Value: 1}}},
+			{Keys: bson.D{{Key: "featured", Value: 1}}},
+			{Keys: bson.D{{Key: "tags", Value: 1}}},
+		},
+	},
+	// LLM usage indexes
+	{
+		collection: "llm_usage",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "created_at", Value: -1}}},
+			{Keys: bson.D{{Key: "article_type", Value: 1}, {Key: "created_at", Value: -1}}},
+		},
+	},
+	// API key indexes
+	{
+		collection: "api_keys",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+	},
+	// Subscriber indexes (tokens are sparse: confirm_token is unset once used)
+	{
+		collection: "subscribers",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "confirm_token", Value: 1}}, Options: options.Index().SetSparse(true)},
+			{Keys: bson.D{{Key: "unsubscribe_token", Value: 1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+	},
+	// Social post indexes (one post per article per platform)
+	{
+		collection: "social_posts",
+		indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "platform", Value: 1}, {Key: "article_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{Keys: bson.D{{Key: "platform", Value: 1}, {Key: "posted_at", Value: -1}}},
+			{Keys: bson.D{{Key: "platform", Value: 1}, {Key: "market_id", Value: 1}, {Key: "posted_at", Value: -1}}},
+		},
+	},
+	// Social signal indexes (one signal per post)
+	{
+		collection: "social_signals",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "tweet_url", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "posted_at", Value: -1}}},
+			{Keys: bson.D{{Key: "market_ids", Value: 1}, {Key: "posted_at", Value: -1}}},
+		},
+	},
+	// Market correlation indexes
+	{
+		collection: "market_correlations",
+		indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "market_a", Value: 1}, {Key: "market_b", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{Keys: bson.D{{Key: "market_a", Value: 1}, {Key: "strength", Value: -1}}},
+			{Keys: bson.D{{Key: "market_b", Value: 1}, {Key: "strength", Value: -1}}},
+			{Keys: bson.D{{Key: "computed_at", Value: 1}}},
+		},
+	},
+	// Category indexes
+	{
+		collection: "categories",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+	},
+	// Enrichment cache indexes (entries expire at expires_at; retention
+	// purges by fetched_at)
+	{
+		collection: "enrichment_cache",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+			{Keys: bson.D{{Key: "fetched_at", Value: 1}}},
+		},
+	},
+	// Event indexes (events expire after the replay window)
+	{
+		collection: "events",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(models.EventRetention.Seconds()))},
+			{Keys: bson.D{{Key: "type", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "market_id", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+	},
+	// Watchlist indexes
+	{
+		collection: "watchlists",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "owner", Value: 1}, {Key: "created_at", Value: 1}}},
+			{Keys: bson.D{{Key: "market_ids", Value: 1}, {Key: "alerts.enabled", Value: 1}}},
+		},
+	},
+	// User indexes
+	{
+		collection: "users",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "google_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+		},
+	},
+	// Login token indexes (tokens expire on their own)
+	{
+		collection: "login_tokens",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		},
+	},
+	// Notification preference indexes
+	{
+		collection: "notification_preferences",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "categories", Value: 1}}},
+		},
+	},
+	// Article view indexes (views expire after ViewHistoryRetention)
+	{
+		collection: "article_views",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "article_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "viewed_at", Value: -1}}},
+			{Keys: bson.D{{Key: "viewed_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(models.ViewHistoryRetention.Seconds()))},
+		},
+	},
+	// Analytics indexes (raw events expire; rollups are kept and $merge
+	// needs their unique key)
+	{
+		collection: "analytics_events",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(models.AnalyticsEventRetention.Seconds()))},
+		},
+	},
+	{
+		collection: "analytics_daily",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "date", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "date", Value: 1}}},
+		},
+	},
+	// Accuracy indexes
+	{
+		collection: "accuracy",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "horizon_hours", Value: 1}}},
+		},
+	},
+	// Lock indexes (locks are dropped after the retention window)
+	{
+		collection: "locks",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "acquired_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(models.LockRetention.Seconds()))},
+		},
+	},
+	// Job run indexes (runs expire after the retention window)
+	{
+		collection: "job_runs",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "job", Value: 1}, {Key: "started_at", Value: -1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "started_at", Value: -1}}},
+			{Keys: bson.D{{Key: "started_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(models.JobRunRetention.Seconds()))},
+		},
+	},
+	// Content calendar indexes
+	{
+		collection: "content_calendar",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}}},
+			{Keys: bson.D{{Key: "run_at", Value: 1}}},
+		},
+	},
+	// Explainer topic indexes
+	{
+		collection: "explainer_topics",
+		indexes: []mongo.IndexModel{
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}},
+		},
+	},
+}
+
+// IndexAudit is how one collection's indexes differ from the plan.
+type IndexAudit struct {
+	Collection string   `json:"collection"`
+	Missing    []string `json:"missing,omitempty"`
+	Obsolete   []string `json:"obsolete,omitempty"`
+}
+
+// AuditIndexes compares the indexes in db with the plan, returning the
+// collections that are missing an index or still carry an obsolete one.
+func AuditIndexes(ctx context.Context, db *mongo.Database) ([]IndexAudit, error) {
+	var audits []IndexAudit
+	for _, c := range indexPlan {
+		specs, err := db.Collection(c.collection).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list %s indexes: %w", c.collection, err)
+		}
+		existing := make(map[string]bool, len(specs))
+		for _, spec := range specs {
+			existing[spec.Name] = true
+		}
+
+		audit := IndexAudit{Collection: c.collection}
+		for _, model := range c.indexes {
+			if name := indexName(model); !existing[name] {
+				audit.Missing = append(audit.Missing, name)
+			}
+		}
+		for _, model := range c.obsolete {
+			if name := indexName(model); existing[name] {
+				audit.Obsolete = append(audit.Obsolete, name)
+			}
+		}
+		if len(audit.Missing) > 0 || len(audit.Obsolete) > 0 {
+			audits = append(audits, audit)
+		}
+	}
+	return audits, nil
+}
+
+// MigrateIndexes brings db's indexes in line with the plan: it creates the
+// missing ones, one at a time so a conflicting index doesn't hold up the
+// rest, then drops the obsolete ones. A collection's obsolete indexes are
+// kept if any of its new ones failed to build, so its queries aren't left
+// without an index. It returns the audit it worked from; with dryRun it
+// changes nothing.
+func MigrateIndexes(ctx context.Context, db *mongo.Database, dryRun bool) ([]IndexAudit, error) {
+	audits, err := AuditIndexes(ctx, db)
+	if err != nil || dryRun {
+		return audits, err
+	}
+	return audits, applyIndexes(ctx, db, audits, true)
+}
+
+// BuildIndexes creates db's missing planned indexes and leaves the obsolete
+// ones in place, for instances still running code that relies on them;
+// MigrateIndexes drops them once every instance has moved on.
+func BuildIndexes(ctx context.Context, db *mongo.Database) error {
+	audits, err := AuditIndexes(ctx, db)
+	if err != nil {
+		return err
+	}
+	return applyIndexes(ctx, db, audits, false)
+}
+
+// applyIndexes creates the audited missing indexes and, with drop, drops
+// the obsolete ones of collections whose new indexes all built.
+func applyIndexes(ctx context.Context, db *mongo.Database, audits []IndexAudit, drop bool) error {
+	var errs []error
+	for _, audit := range audits {
+		indexes := db.Collection(audit.Collection).Indexes()
+		built := true
+		for _, model := range planned(audit.Collection, audit.Missing) {
+			if _, err := indexes.CreateOne(ctx, model); err != nil {
+				errs = append(errs, fmt.Errorf("create %s.%s: %w", audit.Collection, indexName(model), err))
+				built = false
+			}
+		}
+		if !drop {
+			continue
+		}
+		if !built {
+			if len(audit.Obsolete) > 0 {
+				log.Warn().
+					Str("collection", audit.Collection).
+					Strs("obsolete", audit.Obsolete).
+					Msg("Keeping obsolete indexes until their replacements are built")
+			}
+			continue
+		}
+		for _, name := range audit.Obsolete {
+			if _, err := indexes.DropOne(ctx, name); err != nil {
+				errs = append(errs, fmt.Errorf("drop %s.%s: %w", audit.Collection, name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RestoreObsoleteIndexes recreates the obsolete indexes MigrateIndexes
+// drops, for rolling back to code that relies on them.
+func RestoreObsoleteIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, c := range indexPlan {
+		if len(c.obsolete) == 0 {
+			continue
+		}
+		if _, err := db.Collection(c.collection).Indexes().CreateMany(ctx, c.obsolete); err != nil {
+			return fmt.Errorf("create %s indexes: %w", c.collection, err)
+		}
+	}
+	return nil
+}
+
+// planned returns the collection's planned indexes with the given names.
+func planned(collection string, names []string) []mongo.IndexModel {
+	var found []mongo.IndexModel
+	for _, c := range indexPlan {
+		if c.collection != collection {
+			continue
+		}
+		for _, model := range c.indexes {
+			for _, name := range names {
+				if indexName(model) == name {
+					found = append(found, model)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// indexName is the name MongoDB gives an index: its own if set, otherwise
+// its keys and directions joined by underscores.
+func indexName(model mongo.IndexModel) string {
+	if model.Options != nil && model.Options.Name != nil {
+		return *model.Options.Name
+	}
+	var parts []string
+	for _, key := range model.Keys.(bson.D) {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+// createIndexes creates the planned indexes. Existing ones are left as
+// they are; indexes that conflict with them are logged and skipped.
+func (s *Store) createIndexes(ctx context.Context) {
+	for _, c := range indexPlan {
+		if _, err := s.db.Collection(c.collection).Indexes().CreateMany(ctx, c.indexes); err != nil {
+			log.Warn().Err(err).Str("collection", c.collection).Msg("Failed to create indexes")
+		}
+	}
+}
+
+// checkIndexes logs the indexes still missing or obsolete after
+// createIndexes, for fsctl indexes to fix.
+func (s *Store) checkIndexes(ctx context.Context) {
+	audits, err := AuditIndexes(ctx, s.db)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to audit indexes")
+		return
+	}
+	for _, audit := range audits {
+		if len(audit.Missing) > 0 {
+			log.Warn().Str("collection", audit.Collection).Strs("indexes", audit.Missing).Msg("Missing indexes; run fsctl indexes to create them")
+		}
+		if len(audit.Obsolete) > 0 {
+			log.Info().Str("collection", audit.Collection).Strs("indexes", audit.Obsolete).Msg("Obsolete indexes; run fsctl indexes to drop them")
+		}
+	}
+}
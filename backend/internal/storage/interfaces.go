@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The interfaces below split the store by domain, so consumers can depend
+// on the parts they use. *Store implements them against MongoDB and
+// MemoryStore in memory, for tests. Lookups of a single market or article
+// return mongo.ErrNoDocuments when there is none.
+
+// MarketStore reads and writes markets.
+type MarketStore interface {
+	UpsertMarket(ctx context.Context, market *models.Market) error
+	BulkUpsertMarkets(ctx context.Context, markets []*models.Market) (*BulkResult, error)
+	SetMarketCategory(ctx context.Context, marketID, category, source string) error
+	SetMarketOrderBook(ctx context.Context, marketID string, book *models.OrderBook) error
+	SetMarketTradeFlow(ctx context.Context, marketID string, flow *models.TradeFlow) error
+	SetMarketHolders(ctx context.Context, marketID string, holders *models.Holders) error
+	SetMarketIntradayChanges(ctx context.Context, markets []*models.Market) error
+	MarkMarketResolved(ctx context.Context, marketID, outcome string, resolvedAt time.Time) error
+
+	GetMarketByID(ctx context.Context, marketID string) (*models.Market, error)
+	GetMarketBySlug(ctx context.Context, slug string) (*models.Market, error)
+	GetMarketsByIDs(ctx context.Context, marketIDs []string) ([]models.Market, error)
+	GetTrendingMarkets(ctx context.Context, limit int) ([]models.Market, error)
+	GetTrendingMarketsByCategory(ctx context.Context, perCategory int) (map[string][]models.Market, error)
+	GetMarketsByCategory(ctx context.Context, category string, limit int) ([]models.Market, error)
+	GetMarketsByTag(ctx context.Context, tag string, limit int) ([]models.Market, error)
+	GetNewMarkets(ctx context.Context, since time.Duration, limit int) ([]models.Market, error)
+	GetBreakingMarkets(ctx context.Context, window string, threshold float64, limit int) ([]models.Market, error)
+	GetTopMarketsByVolume(ctx context.Context, limit int) ([]models.Market, error)
+	ListMarkets(ctx context.Context, q MarketQuery) ([]models.Market, error)
+	GetClosingSoonMarkets(ctx context.Context, within time.Duration, limit int) ([]models.Market, error)
+	GetMarketMovers(ctx context.Context, minVolume float64, limit int) (gainers, losers []models.Market, err error)
+	GetAllActiveMarkets(ctx context.Context) ([]models.Market, error)
+	GetRelatedMarkets(ctx context.Context, marketID string, limit int) ([]models.RelatedMarket, error)
+
+	BulkUpsertPolymarketEvents(ctx context.Context, events []*models.PolymarketEvent) (*BulkResult, error)
+	GetPolymarketEventBySlug(ctx context.Context, slug string) (*models.PolymarketEvent, error)
+	GetEventMarkets(ctx context.Context, eventID string) ([]models.Market, error)
+	GetExclusiveEvents(ctx context.Context, minVolume float64, limit int) ([]models.PolymarketEvent, error)
+}
+
+// ArticleStore reads and writes articles.
+type ArticleStore interface {
+	SaveArticle(ctx context.Context, article *models.Article) error
+	UpdateArticle(ctx context.Context, article *models.Article) error
+	AddArticleUpdate(ctx context.Context, originalID, updateID primitive.ObjectID) error
+
+	GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error)
+	GetRecentArticleForMarket(ctx context.Context, marketID string, since time.Time, articleTypes ...models.ArticleType) (*models.Article, error)
+	GetArticlesByMarketID(ctx context.Context, marketID string, limit int) ([]models.Article, error)
+	GetArticlesByMarketIDs(ctx context.Context, marketIDs []string, limit int) ([]models.Article, error)
+	GetArticleChain(ctx context.Context, article *models.Article) ([]models.ArticleLink, error)
+	GetRelatedArticleCandidates(ctx context.Context, article *models.Article, window time.Duration, limit int) ([]models.Article, error)
+	GetRecentArticles(ctx context.Context, limit int) ([]models.Article, error)
+	GetArticlesByType(ctx context.Context, articleType models.ArticleType, limit int) ([]models.Article, error)
+	GetArticlesByCategory(ctx context.Context, category string, limit int) ([]models.Article, error)
+	GetArticlesByTag(ctx context.Context, tag string, limit int) ([]models.Article, error)
+	GetFeaturedArticles(ctx context.Context, limit int) ([]models.Article, error)
+	GetTodayArticles(ctx context.Context) ([]models.Article, error)
+}
+
+// SnapshotStore records market snapshots and their rollups.
+type SnapshotStore interface {
+	SaveSnapshot(ctx context.Context, snapshot *models.Snapshot) error
+	GetSnapshots(ctx context.Context, marketID string, since time.Duration) ([]models.Snapshot, error)
+	GetSnapshotsAt(ctx context.Context, at time.Time, tolerance time.Duration) (map[string]models.Snapshot, error)
+	GetSnapshotRollups(ctx context.Context, marketID, granularity string, since time.Duration) ([]models.SnapshotRollup, error)
+	GetSnapshotRollupsForMarkets(ctx context.Context, marketIDs []string, granularity string, since time.Duration) (map[string][]models.SnapshotRollup, error)
+	GetOddsRanges(ctx context.Context, marketID string, current float64, lookbacks []time.Duration) ([]models.OddsRange, error)
+	RollupSnapshots(ctx context.Context, granularity string, from time.Time) error
+	CleanOldSnapshots(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// EventStore persists market events and durable subscriber offsets.
+type EventStore interface {
+	SaveEvent(ctx context.Context, event *models.StoredEvent) error
+	GetEventsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]models.StoredEvent, error)
+	GetRecentEvents(ctx context.Context, eventType string, limit int) ([]models.StoredEvent, error)
+	GetMarketEvents(ctx context.Context, marketID string, since time.Time, eventTypes []string) ([]models.StoredEvent, error)
+	CountEventsAfter(ctx context.Context, after primitive.ObjectID) (int64, error)
+	GetEventOffset(ctx context.Context, subscriber string) (*models.EventOffset, error)
+	SaveEventOffset(ctx context.Context, offset *models.EventOffset) error
+}
+
+// SocialSignalStore persists the social signals the correlator finds.
+type SocialSignalStore interface {
+	SaveSocialSignals(ctx context.Context, signals []models.StoredSocialSignal) (*BulkResult, error)
+	GetRecentSocialSignals(ctx context.Context, since time.Duration, limit int) ([]models.StoredSocialSignal, error)
+	GetMarketSocialSignals(ctx context.Context, marketID string, limit int) ([]models.StoredSocialSignal, error)
+}
+
+// CorrelationStore persists the market correlations the analyzer finds.
+type CorrelationStore interface {
+	SaveCorrelations(ctx context.Context, correlations []models.MarketCorrelation, computedAt time.Time) error
+	GetCorrelationsForMarket(ctx context.Context, marketID string, limit int) ([]models.MarketCorrelation, error)
+}
+
+// TrackedAccountStore reads the accounts editors track for social signals.
+type TrackedAccountStore interface {
+	GetTrackedAccounts(ctx context.Context) ([]models.TrackedAccount, error)
+}
+
+// CategoryStore reads the category taxonomy and its sentiment.
+type CategoryStore interface {
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error)
+	GetCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error)
+}
+
+// BriefingStore reads briefing configs.
+type BriefingStore interface {
+	GetBriefingConfig(ctx context.Context, briefingType models.BriefingType) (*models.BriefingConfig, error)
+}
+
+var (
+	_ MarketStore         = (*Store)(nil)
+	_ ArticleStore        = (*Store)(nil)
+	_ SnapshotStore       = (*Store)(nil)
+	_ EventStore          = (*Store)(nil)
+	_ CategoryStore       = (*Store)(nil)
+	_ SocialSignalStore   = (*Store)(nil)
+	_ CorrelationStore    = (*Store)(nil)
+	_ TrackedAccountStore = (*Store)(nil)
+	_ BriefingStore       = (*Store)(nil)
+)
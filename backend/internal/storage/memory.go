@@ -0,0 +1,1575 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryStore implements the domain store interfaces in memory, for tests
+// that shouldn't need MongoDB. Documents are stored BSON-encoded, so
+// callers never share state with the store and writes behave like their
+// MongoDB counterparts ($set keeps fields the update leaves out).
+// Projections aren't applied, and no sentiment or accuracy scores are kept.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	markets   map[string][]byte    // By market ID
+	pmEvents  map[string][]byte    // By event ID
+	signals   map[string][]byte    // By tweet URL
+	pairs     map[[2]string][]byte // Correlations by market A and B
+	snapshots [][]byte
+	rollups   map[rollupKey][]byte
+	articles  []primitive.ObjectID // Insertion order
+	byID      map[primitive.ObjectID][]byte
+	events    [][]byte // By ID
+	offsets   map[string]models.EventOffset
+	views     map[viewKey]time.Time
+
+	categories []models.Category
+	briefings  map[models.BriefingType]models.BriefingConfig
+}
+
+type rollupKey struct {
+	marketID    string
+	granularity string
+	bucketStart time.Time
+}
+
+type viewKey struct {
+	userID    primitive.ObjectID
+	articleID primitive.ObjectID
+}
+
+// NewMemoryStore creates an empty in-memory store holding the seed
+// categories and default briefing configs, as NewStore does.
+func NewMemoryStore() *MemoryStore {
+	briefings := make(map[models.BriefingType]models.BriefingConfig, len(models.DefaultBriefingConfigs))
+	for t, cfg := range models.DefaultBriefingConfigs {
+		briefings[t] = cfg
+	}
+	categories := models.SeedCategories()
+	sort.SliceStable(categories, func(i, j int) bool { return categories[i].Order < categories[j].Order })
+
+	return &MemoryStore{
+		markets:    make(map[string][]byte),
+		pmEvents:   make(map[string][]byte),
+		signals:    make(map[string][]byte),
+		pairs:      make(map[[2]string][]byte),
+		rollups:    make(map[rollupKey][]byte),
+		byID:       make(map[primitive.ObjectID][]byte),
+		offsets:    make(map[string]models.EventOffset),
+		views:      make(map[viewKey]time.Time),
+		categories: categories,
+		briefings:  briefings,
+	}
+}
+
+var (
+	_ MarketStore         = (*MemoryStore)(nil)
+	_ ArticleStore        = (*MemoryStore)(nil)
+	_ SnapshotStore       = (*MemoryStore)(nil)
+	_ EventStore          = (*MemoryStore)(nil)
+	_ CategoryStore       = (*MemoryStore)(nil)
+	_ SocialSignalStore   = (*MemoryStore)(nil)
+	_ CorrelationStore    = (*MemoryStore)(nil)
+	_ TrackedAccountStore = (*MemoryStore)(nil)
+	_ BriefingStore       = (*MemoryStore)(nil)
+)
+
+// errDuplicateKey is what a unique index violation looks like to
+// mongo.IsDuplicateKeyError.
+func errDuplicateKey(field string) error {
+	return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key: " + field}}}
+}
+
+// decode decodes a stored document.
+func decode[T any](doc []byte) (T, error) {
+	var v T
+	err := bson.Unmarshal(doc, &v)
+	return v, err
+}
+
+// decodeAll decodes stored documents, keeping those matching keep.
+func decodeAll[T any](docs [][]byte, keep func(*T) bool) ([]T, error) {
+	var out []T
+	for _, doc := range docs {
+		v, err := decode[T](doc)
+		if err != nil {
+			return nil, err
+		}
+		if keep(&v) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// setFields applies update to doc the way $set does: top-level fields
+// present in update replace doc's, the rest are kept.
+func setFields(doc []byte, update interface{}) ([]byte, error) {
+	fields, err := bson.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return fields, nil
+	}
+
+	var merged, set bson.M
+	if err := bson.Unmarshal(doc, &merged); err != nil {
+		return nil, err
+	}
+	if err := bson.Unmarshal(fields, &set); err != nil {
+		return nil, err
+	}
+	for k, v := range set {
+		merged[k] = v
+	}
+	return bson.Marshal(merged)
+}
+
+// limited trims items to limit; like MongoDB, a limit of 0 means no limit.
+func limited[T any](items []T, limit int) []T {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// ============================================================================
+// MARKET OPERATIONS
+// ============================================================================
+
+// UpsertMarket inserts or updates a market.
+func (m *MemoryStore) UpsertMarket(ctx context.Context, market *models.Market) error {
+	market.UpdatedAt = time.Now()
+	if market.FirstSeenAt.IsZero() {
+		market.FirstSeenAt = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := m.upsertMarket(market)
+	return err
+}
+
+// upsertMarket writes a market, reporting whether it was already stored;
+// the caller holds mu.
+func (m *MemoryStore) upsertMarket(market *models.Market) (bool, error) {
+	update := interface{}(market)
+	existing, ok := m.markets[market.MarketID]
+	if !ok {
+		for _, doc := range m.markets {
+			other, err := decode[models.Market](doc)
+			if err != nil {
+				return false, err
+			}
+			if other.Slug == market.Slug {
+				return false, errDuplicateKey("slug")
+			}
+		}
+
+		// An upsert gets a generated _id the caller doesn't see
+		stored := *market
+		if stored.ID.IsZero() {
+			stored.ID = primitive.NewObjectID()
+		}
+		update = stored
+	}
+
+	doc, err := setFields(existing, update)
+	if err != nil {
+		return false, err
+	}
+	m.markets[market.MarketID] = doc
+	return ok, nil
+}
+
+// BulkUpsertMarkets inserts or updates many markets. Failed writes don't
+// stop the rest; on a partial failure both the result and the first error
+// are returned.
+func (m *MemoryStore) BulkUpsertMarkets(ctx context.Context, markets []*models.Market) (*BulkResult, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := &BulkResult{}
+	var firstErr error
+	for _, market := range markets {
+		market.UpdatedAt = now
+		if market.FirstSeenAt.IsZero() {
+			market.FirstSeenAt = now
+		}
+		matched, err := m.upsertMarket(market)
+		switch {
+		case err != nil:
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		case matched:
+			result.Matched++
+			result.Modified++
+		default:
+			result.Upserted++
+		}
+	}
+	return result, firstErr
+}
+
+// updateMarket applies fn to a stored market; a market that isn't stored
+// is left alone, as an UpdateOne without a match is.
+func (m *MemoryStore) updateMarket(marketID string, fn func(*models.Market)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	doc, ok := m.markets[marketID]
+	if !ok {
+		return nil
+	}
+	market, err := decode[models.Market](doc)
+	if err != nil {
+		return err
+	}
+	fn(&market)
+	if doc, err = bson.Marshal(market); err != nil {
+		return err
+	}
+	m.markets[marketID] = doc
+	return nil
+}
+
+// SetMarketCategory records a market's category and how it was assigned.
+func (m *MemoryStore) SetMarketCategory(ctx context.Context, marketID, category, source string) error {
+	return m.updateMarket(marketID, func(market *models.Market) {
+		market.Category = category
+		market.CategorySource = source
+	})
+}
+
+// SetMarketOrderBook records a market's latest order book summary.
+func (m *MemoryStore) SetMarketOrderBook(ctx context.Context, marketID string, book *models.OrderBook) error {
+	return m.updateMarket(marketID, func(market *models.Market) { market.OrderBook = book })
+}
+
+// SetMarketTradeFlow records a market's latest trade flow summary.
+func (m *MemoryStore) SetMarketTradeFlow(ctx context.Context, marketID string, flow *models.TradeFlow) error {
+	return m.updateMarket(marketID, func(market *models.Market) { market.TradeFlow = flow })
+}
+
+// SetMarketHolders records a market's latest holder summary.
+func (m *MemoryStore) SetMarketHolders(ctx context.Context, marketID string, holders *models.Holders) error {
+	return m.updateMarket(marketID, func(market *models.Market) { market.Holders = holders })
+}
+
+// SetMarketIntradayChanges stores the snapshot-derived 1h/6h changes and
+// hourly volume of the given markets.
+func (m *MemoryStore) SetMarketIntradayChanges(ctx context.Context, markets []*models.Market) error {
+	for _, changed := range markets {
+		err := m.updateMarket(changed.MarketID, func(market *models.Market) {
+			market.Change1h = changed.Change1h
+			market.Change6h = changed.Change6h
+			market.Volume1h = changed.Volume1h
+		})
+		if err != nil {
+			return fmt.Errorf("write intraday changes: %w", err)
+		}
+	}
+	return nil
+}
+
+// MarkMarketResolved records a market's resolution.
+func (m *MemoryStore) MarkMarketResolved(ctx context.Context, marketID, outcome string, resolvedAt time.Time) error {
+	return m.updateMarket(marketID, func(market *models.Market) {
+		market.Closed = true
+		market.Resolved = true
+		market.WinningOutcome = outcome
+		market.ResolvedAt = &resolvedAt
+		market.UpdatedAt = time.Now()
+	})
+}
+
+// findMarkets returns the stored markets matching keep, sorted by less and
+// trimmed to limit. Ties are broken by market ID so results are stable.
+// Archived markets are left out.
+func (m *MemoryStore) findMarkets(keep func(*models.Market) bool, less func(a, b *models.Market) bool, limit int) ([]models.Market, error) {
+	keep = notArchived(keep)
+
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.markets))
+	for _, doc := range m.markets {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	markets, err := decodeAll(docs, keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(markets, func(i, j int) bool {
+		a, b := &markets[i], &markets[j]
+		if less != nil && less(a, b) != less(b, a) {
+			return less(a, b)
+		}
+		return a.MarketID < b.MarketID
+	})
+	return limited(markets, limit), nil
+}
+
+// notArchived wraps keep to also drop archived markets.
+func notArchived(keep func(*models.Market) bool) func(*models.Market) bool {
+	return func(market *models.Market) bool {
+		return !market.Archived && keep(market)
+	}
+}
+
+func openMarket(market *models.Market) bool {
+	return market.Active && !market.Closed
+}
+
+// byVolume24h sorts markets by 24h volume, highest first.
+func byVolume24h(a, b *models.Market) bool {
+	return a.Volume24h > b.Volume24h
+}
+
+// GetMarketByID returns a market by its Polymarket ID.
+func (m *MemoryStore) GetMarketByID(ctx context.Context, marketID string) (*models.Market, error) {
+	m.mu.RLock()
+	doc, ok := m.markets[marketID]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	market, err := decode[models.Market](doc)
+	if err != nil {
+		return nil, err
+	}
+	return &market, nil
+}
+
+// GetMarketBySlug returns a market by its slug.
+func (m *MemoryStore) GetMarketBySlug(ctx context.Context, slug string) (*models.Market, error) {
+	markets, err := m.findMarkets(func(market *models.Market) bool { return market.Slug == slug }, nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &markets[0], nil
+}
+
+// GetMarketsByIDs retrieves markets by market ID.
+func (m *MemoryStore) GetMarketsByIDs(ctx context.Context, marketIDs []string) ([]models.Market, error) {
+	if len(marketIDs) == 0 {
+		return nil, nil
+	}
+	return m.findMarkets(func(market *models.Market) bool {
+		return slices.Contains(marketIDs, market.MarketID)
+	}, nil, 0)
+}
+
+// GetTrendingMarkets returns open markets sorted by trending score.
+func (m *MemoryStore) GetTrendingMarkets(ctx context.Context, limit int) ([]models.Market, error) {
+	return m.findMarkets(openMarket, func(a, b *models.Market) bool {
+		return a.TrendingScore > b.TrendingScore
+	}, limit)
+}
+
+// GetTrendingMarketsByCategory returns the up to perCategory open markets
+// with the highest trending score in each category.
+func (m *MemoryStore) GetTrendingMarketsByCategory(ctx context.Context, perCategory int) (map[string][]models.Market, error) {
+	markets, err := m.GetTrendingMarkets(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[string][]models.Market)
+	for _, market := range markets {
+		if len(byCategory[market.Category]) < perCategory {
+			byCategory[market.Category] = append(byCategory[market.Category], market)
+		}
+	}
+	return byCategory, nil
+}
+
+// GetMarketsByCategory returns open markets in a category by 24h volume.
+func (m *MemoryStore) GetMarketsByCategory(ctx context.Context, category string, limit int) ([]models.Market, error) {
+	return m.findMarkets(func(market *models.Market) bool {
+		return market.Category == category && openMarket(market)
+	}, byVolume24h, limit)
+}
+
+// GetMarketsByTag returns open markets carrying tag, ours or Polymarket's,
+// by 24h volume.
+func (m *MemoryStore) GetMarketsByTag(ctx context.Context, tag string, limit int) ([]models.Market, error) {
+	return m.findMarkets(func(market *models.Market) bool {
+		return openMarket(market) && slices.Contains(marketTags(market), tag)
+	}, byVolume24h, limit)
+}
+
+// GetNewMarkets returns open markets first seen within since, newest first.
+func (m *MemoryStore) GetNewMarkets(ctx context.Context, since time.Duration, limit int) ([]models.Market, error) {
+	cutoff := time.Now().Add(-since)
+	return m.findMarkets(func(market *models.Market) bool {
+		return openMarket(market) && !market.FirstSeenAt.Before(cutoff)
+	}, func(a, b *models.Market) bool {
+		return a.FirstSeenAt.After(b.FirstSeenAt)
+	}, limit)
+}
+
+// GetBreakingMarkets returns open markets whose price moved by at least
+// threshold over the given window (24h if empty or unknown), biggest rise
+// first.
+func (m *MemoryStore) GetBreakingMarkets(ctx context.Context, window string, threshold float64, limit int) ([]models.Market, error) {
+	change := func(market *models.Market) float64 {
+		switch window {
+		case ChangeWindow1h:
+			return market.Change1h
+		case ChangeWindow6h:
+			return market.Change6h
+		}
+		return market.Change24h
+	}
+	return m.findMarkets(func(market *models.Market) bool {
+		c := change(market)
+		return openMarket(market) && (c >= threshold || c <= -threshold)
+	}, func(a, b *models.Market) bool {
+		return change(a) > change(b)
+	}, limit)
+}
+
+// GetTopMarketsByVolume returns open markets by 24h volume.
+func (m *MemoryStore) GetTopMarketsByVolume(ctx context.Context, limit int) ([]models.Market, error) {
+	return m.findMarkets(openMarket, byVolume24h, limit)
+}
+
+// GetClosingSoonMarkets returns open markets ending within the next within,
+// soonest first.
+func (m *MemoryStore) GetClosingSoonMarkets(ctx context.Context, within time.Duration, limit int) ([]models.Market, error) {
+	now := time.Now()
+	return m.findMarkets(func(market *models.Market) bool {
+		end := market.EndDateTS
+		return openMarket(market) && end != nil && !end.Before(now) && !end.After(now.Add(within))
+	}, func(a, b *models.Market) bool {
+		return a.EndDateTS.Before(*b.EndDateTS)
+	}, limit)
+}
+
+// GetMarketMovers returns the open markets with at least minVolume 24h
+// volume that rose most over 24h, and that fell most.
+func (m *MemoryStore) GetMarketMovers(ctx context.Context, minVolume float64, limit int) (gainers, losers []models.Market, err error) {
+	gainers, err = m.findMarkets(func(market *models.Market) bool {
+		return openMarket(market) && market.Volume24h >= minVolume && market.Change24h > 0
+	}, func(a, b *models.Market) bool {
+		return a.Change24h > b.Change24h
+	}, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	losers, err = m.findMarkets(func(market *models.Market) bool {
+		return openMarket(market) && market.Volume24h >= minVolume && market.Change24h < 0
+	}, func(a, b *models.Market) bool {
+		return a.Change24h < b.Change24h
+	}, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gainers, losers, nil
+}
+
+// ListMarkets returns the open markets matching q in its sort order.
+func (m *MemoryStore) ListMarkets(ctx context.Context, q MarketQuery) ([]models.Market, error) {
+	now := time.Now()
+	byEndDate := q.Sort == MarketSortEndDate
+
+	less := byVolume24h
+	switch q.Sort {
+	case MarketSortTrending:
+		less = func(a, b *models.Market) bool { return a.TrendingScore > b.TrendingScore }
+	case MarketSortChange24h:
+		less = func(a, b *models.Market) bool { return a.Change24h > b.Change24h }
+	case MarketSortLiquidity:
+		less = func(a, b *models.Market) bool { return a.Liquidity > b.Liquidity }
+	case MarketSortEndDate:
+		less = func(a, b *models.Market) bool { return a.EndDateTS.Before(*b.EndDateTS) }
+	case MarketSortNewest:
+		less = func(a, b *models.Market) bool { return a.FirstSeenAt.After(b.FirstSeenAt) }
+	}
+
+	markets, err := m.findMarkets(func(market *models.Market) bool {
+		switch {
+		case !openMarket(market),
+			q.Category != "" && market.Category != q.Category,
+			market.Volume24h < q.MinVolume,
+			q.MaxProbability != nil && market.Probability > *q.MaxProbability,
+			q.Tier != "" && market.Tier != q.Tier,
+			q.LiquidityTier != "" && market.LiquidityTier != q.LiquidityTier,
+			q.VolumeTier != "" && market.VolumeTier != q.VolumeTier:
+			return false
+		case q.EndingWithin > 0 || byEndDate:
+			end := market.EndDateTS
+			return end != nil && !end.Before(now) && (q.EndingWithin == 0 || !end.After(now.Add(q.EndingWithin)))
+		}
+		return true
+	}, less, 0)
+	if err != nil {
+		return nil, err
+	}
+	if q.Offset >= len(markets) {
+		return nil, nil
+	}
+	return limited(markets[q.Offset:], q.Limit), nil
+}
+
+// BulkUpsertPolymarketEvents writes events by event ID, keeping the
+// FirstSeenAt of ones already stored.
+func (m *MemoryStore) BulkUpsertPolymarketEvents(ctx context.Context, events []*models.PolymarketEvent) (*BulkResult, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := &BulkResult{}
+	for _, event := range events {
+		event.UpdatedAt = now
+		event.FirstSeenAt = now
+		stored := *event
+		if doc, ok := m.pmEvents[event.EventID]; ok {
+			existing, err := decode[models.PolymarketEvent](doc)
+			if err != nil {
+				return result, err
+			}
+			stored.ID, stored.FirstSeenAt = existing.ID, existing.FirstSeenAt
+			result.Matched++
+			result.Modified++
+		} else {
+			stored.ID = primitive.NewObjectID()
+			result.Upserted++
+		}
+
+		doc, err := bson.Marshal(&stored)
+		if err != nil {
+			return result, err
+		}
+		m.pmEvents[event.EventID] = doc
+	}
+	return result, nil
+}
+
+// GetPolymarketEventBySlug returns an event by its Polymarket slug.
+func (m *MemoryStore) GetPolymarketEventBySlug(ctx context.Context, slug string) (*models.PolymarketEvent, error) {
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.pmEvents))
+	for _, doc := range m.pmEvents {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	events, err := decodeAll(docs, func(event *models.PolymarketEvent) bool { return event.Slug == slug })
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &events[0], nil
+}
+
+// GetEventMarkets returns the markets of an event, most likely outcome
+// first.
+func (m *MemoryStore) GetEventMarkets(ctx context.Context, eventID string) ([]models.Market, error) {
+	return m.findMarkets(func(market *models.Market) bool {
+		return market.EventID == eventID
+	}, func(a, b *models.Market) bool {
+		return a.Probability > b.Probability
+	}, 0)
+}
+
+// GetExclusiveEvents returns the open neg-risk events with at least
+// minVolume 24h volume, most traded first.
+func (m *MemoryStore) GetExclusiveEvents(ctx context.Context, minVolume float64, limit int) ([]models.PolymarketEvent, error) {
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.pmEvents))
+	for _, doc := range m.pmEvents {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	events, err := decodeAll(docs, func(event *models.PolymarketEvent) bool {
+		return event.NegRisk && !event.Closed && event.Volume24h >= minVolume
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Volume24h > events[j].Volume24h })
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// GetAllActiveMarkets returns all open markets.
+func (m *MemoryStore) GetAllActiveMarkets(ctx context.Context) ([]models.Market, error) {
+	return m.findMarkets(openMarket, nil, 0)
+}
+
+// GetRelatedMarkets returns no markets, as MemoryStore keeps no
+// correlations.
+func (m *MemoryStore) GetRelatedMarkets(ctx context.Context, marketID string, limit int) ([]models.RelatedMarket, error) {
+	correlations, err := m.GetCorrelationsForMarket(ctx, marketID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]models.RelatedMarket, 0, len(correlations))
+	for _, c := range correlations {
+		market, err := m.GetMarketByID(ctx, c.Other(marketID))
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !openMarket(market) {
+			continue
+		}
+		related = append(related, models.RelatedMarket{
+			Market:      market,
+			Correlation: c.Correlation,
+			Samples:     c.Samples,
+			SharedTags:  c.SharedTags,
+		})
+	}
+	return related, nil
+}
+
+// marketTags returns a market's tags and Polymarket tag slugs.
+func marketTags(market *models.Market) []string {
+	tags := slices.Clone(market.Tags)
+	for _, t := range market.PolymarketTags {
+		if !slices.Contains(tags, t.Slug) {
+			tags = append(tags, t.Slug)
+		}
+	}
+	return tags
+}
+
+// ============================================================================
+// SNAPSHOT OPERATIONS
+// ============================================================================
+
+// SaveSnapshot saves a market snapshot.
+func (m *MemoryStore) SaveSnapshot(ctx context.Context, snapshot *models.Snapshot) error {
+	snapshot.CapturedAt = time.Now()
+	stored := *snapshot
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	doc, err := bson.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.snapshots = append(m.snapshots, doc)
+	m.mu.Unlock()
+	return nil
+}
+
+// AddSnapshots stores snapshots as captured at their CapturedAt, which
+// SaveSnapshot always sets to now, so tests can seed a price history.
+func (m *MemoryStore) AddSnapshots(snapshots ...models.Snapshot) error {
+	docs := make([][]byte, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s.ID.IsZero() {
+			s.ID = primitive.NewObjectID()
+		}
+		doc, err := bson.Marshal(s)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+	}
+
+	m.mu.Lock()
+	m.snapshots = append(m.snapshots, docs...)
+	m.mu.Unlock()
+	return nil
+}
+
+// findSnapshots returns the stored snapshots matching keep, in capture
+// order.
+func (m *MemoryStore) findSnapshots(keep func(*models.Snapshot) bool) ([]models.Snapshot, error) {
+	m.mu.RLock()
+	docs := slices.Clone(m.snapshots)
+	m.mu.RUnlock()
+
+	snapshots, err := decodeAll(docs, keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CapturedAt.Before(snapshots[j].CapturedAt)
+	})
+	return snapshots, nil
+}
+
+// GetSnapshotsAt returns, per market, the latest snapshot captured within
+// tolerance before at.
+func (m *MemoryStore) GetSnapshotsAt(ctx context.Context, at time.Time, tolerance time.Duration) (map[string]models.Snapshot, error) {
+	from := at.Add(-tolerance)
+	snapshots, err := m.findSnapshots(func(s *models.Snapshot) bool {
+		return !s.CapturedAt.Before(from) && !s.CapturedAt.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]models.Snapshot)
+	for _, s := range snapshots {
+		latest[s.MarketID] = s
+	}
+	return latest, nil
+}
+
+// GetSnapshots returns snapshots for a market within a time range, newest
+// first, served from rollups for ranges beyond RawSnapshotRange as
+// Store.GetSnapshots does.
+func (m *MemoryStore) GetSnapshots(ctx context.Context, marketID string, since time.Duration) ([]models.Snapshot, error) {
+	if since > RawSnapshotRange {
+		granularity := models.GranularityDay
+		if since <= HourlyRollupRange {
+			granularity = models.GranularityHour
+		}
+
+		rollups, err := m.GetSnapshotRollups(ctx, marketID, granularity, since)
+		if err != nil {
+			return nil, err
+		}
+		snapshots := make([]models.Snapshot, 0, len(rollups))
+		for _, r := range rollups {
+			snapshots = append(snapshots, r.ToSnapshot())
+		}
+		return snapshots, nil
+	}
+
+	cutoff := time.Now().Add(-since)
+	snapshots, err := m.findSnapshots(func(s *models.Snapshot) bool {
+		return s.MarketID == marketID && !s.CapturedAt.Before(cutoff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(snapshots)
+	return snapshots, nil
+}
+
+// GetSnapshotRollups returns rollups of one granularity for a market,
+// newest first.
+func (m *MemoryStore) GetSnapshotRollups(ctx context.Context, marketID, granularity string, since time.Duration) ([]models.SnapshotRollup, error) {
+	cutoff := time.Now().Add(-since)
+
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.rollups))
+	for _, doc := range m.rollups {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	rollups, err := decodeAll(docs, func(r *models.SnapshotRollup) bool {
+		return r.MarketID == marketID && r.Granularity == granularity && !r.BucketStart.Before(cutoff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		return rollups[i].BucketStart.After(rollups[j].BucketStart)
+	})
+	return rollups, nil
+}
+
+// GetSnapshotRollupsForMarkets returns the rollups of several markets by
+// market ID, each newest first.
+func (m *MemoryStore) GetSnapshotRollupsForMarkets(ctx context.Context, marketIDs []string, granularity string, since time.Duration) (map[string][]models.SnapshotRollup, error) {
+	byMarket := make(map[string][]models.SnapshotRollup, len(marketIDs))
+	for _, id := range marketIDs {
+		rollups, err := m.GetSnapshotRollups(ctx, id, granularity, since)
+		if err != nil {
+			return nil, err
+		}
+		if len(rollups) > 0 {
+			byMarket[id] = rollups
+		}
+	}
+	return byMarket, nil
+}
+
+// GetOddsRanges summarizes a market's daily rollups over each lookback.
+func (m *MemoryStore) GetOddsRanges(ctx context.Context, marketID string, current float64, lookbacks []time.Duration) ([]models.OddsRange, error) {
+	rollups, err := m.GetSnapshotRollups(ctx, marketID, models.GranularityDay, historySince(lookbacks))
+	if err != nil {
+		return nil, err
+	}
+	return summarizeOdds(rollups, current, lookbacks, time.Now()), nil
+}
+
+// RollupSnapshots aggregates raw snapshots captured at or after from into
+// buckets of the given granularity, replacing the buckets they fall in.
+func (m *MemoryStore) RollupSnapshots(ctx context.Context, granularity string, from time.Time) error {
+	snapshots, err := m.findSnapshots(func(s *models.Snapshot) bool { return !s.CapturedAt.Before(from) })
+	if err != nil {
+		return fmt.Errorf("rollup %s snapshots: %w", granularity, err)
+	}
+
+	buckets := make(map[rollupKey]*models.SnapshotRollup)
+	for _, s := range snapshots {
+		start := s.CapturedAt.UTC().Truncate(time.Hour)
+		if granularity == models.GranularityDay {
+			start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		key := rollupKey{s.MarketID, granularity, start}
+
+		r, ok := buckets[key]
+		if !ok {
+			r = &models.SnapshotRollup{
+				MarketID:    s.MarketID,
+				Granularity: granularity,
+				BucketStart: start,
+				Open:        s.Probability,
+				High:        s.Probability,
+				Low:         s.Probability,
+			}
+			buckets[key] = r
+		}
+		r.High = max(r.High, s.Probability)
+		r.Low = min(r.Low, s.Probability)
+		r.Close = s.Probability
+		r.Volume24h = s.Volume24h
+		r.TotalVolume = s.TotalVolume
+		r.Liquidity = s.Liquidity
+		r.Samples++
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, r := range buckets {
+		doc, err := bson.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("rollup %s snapshots: %w", granularity, err)
+		}
+		m.rollups[key] = doc
+	}
+	return nil
+}
+
+// CleanOldSnapshots removes snapshots older than the given duration.
+func (m *MemoryStore) CleanOldSnapshots(ctx context.Context, olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.snapshots[:0]
+	var deleted int64
+	for _, doc := range m.snapshots {
+		s, err := decode[models.Snapshot](doc)
+		if err != nil {
+			return deleted, err
+		}
+		if s.CapturedAt.Before(cutoff) {
+			deleted++
+			continue
+		}
+		kept = append(kept, doc)
+	}
+	clear(m.snapshots[len(kept):])
+	m.snapshots = kept
+	return deleted, nil
+}
+
+// ============================================================================
+// ARTICLE OPERATIONS
+// ============================================================================
+
+// SaveArticle saves a new article and sets its ID.
+func (m *MemoryStore) SaveArticle(ctx context.Context, article *models.Article) error {
+	article.CreatedAt = time.Now()
+	article.UpdatedAt = time.Now()
+	if article.PublishedAt.IsZero() && article.Published {
+		article.PublishedAt = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byID[article.ID]; ok {
+		return errDuplicateKey("_id")
+	}
+	for _, doc := range m.byID {
+		other, err := decode[models.Article](doc)
+		if err != nil {
+			return err
+		}
+		if other.Slug == article.Slug {
+			return errDuplicateKey("slug")
+		}
+	}
+
+	stored := *article
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	doc, err := bson.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	m.byID[stored.ID] = doc
+	m.articles = append(m.articles, stored.ID)
+	article.ID = stored.ID
+	return nil
+}
+
+// UpdateArticle updates an existing article.
+func (m *MemoryStore) UpdateArticle(ctx context.Context, article *models.Article) error {
+	article.UpdatedAt = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.byID[article.ID]
+	if !ok {
+		return nil
+	}
+	doc, err := setFields(existing, article)
+	if err != nil {
+		return err
+	}
+	m.byID[article.ID] = doc
+	return nil
+}
+
+// AddArticleUpdate links a follow-up article to the article it follows.
+func (m *MemoryStore) AddArticleUpdate(ctx context.Context, originalID, updateID primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	doc, ok := m.byID[originalID]
+	if !ok {
+		return nil
+	}
+	article, err := decode[models.Article](doc)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(article.Updates, updateID) {
+		article.Updates = append(article.Updates, updateID)
+	}
+	article.UpdatedAt = time.Now()
+	if doc, err = bson.Marshal(article); err != nil {
+		return err
+	}
+	m.byID[originalID] = doc
+	return nil
+}
+
+// findArticles returns the stored articles matching keep, newest published
+// first, trimmed to limit.
+func (m *MemoryStore) findArticles(keep func(*models.Article) bool, limit int) ([]models.Article, error) {
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.articles))
+	for _, id := range m.articles {
+		docs = append(docs, m.byID[id])
+	}
+	m.mu.RUnlock()
+
+	articles, err := decodeAll(docs, keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].PublishedAt.After(articles[j].PublishedAt)
+	})
+	return limited(articles, limit), nil
+}
+
+// getArticle returns a stored article by ID, or nil if there is none.
+func (m *MemoryStore) getArticle(id primitive.ObjectID) (*models.Article, error) {
+	m.mu.RLock()
+	doc, ok := m.byID[id]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	article, err := decode[models.Article](doc)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// GetArticleBySlug returns an article by its slug.
+func (m *MemoryStore) GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	articles, err := m.findArticles(func(a *models.Article) bool { return a.Slug == slug }, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &articles[0], nil
+}
+
+// GetRecentArticleForMarket returns the newest article of one of the given
+// types whose primary market is marketID and that was published after since,
+// or nil if none.
+func (m *MemoryStore) GetRecentArticleForMarket(ctx context.Context, marketID string, since time.Time, articleTypes ...models.ArticleType) (*models.Article, error) {
+	articles, err := m.findArticles(func(a *models.Article) bool {
+		return a.PrimaryMarket != nil && a.PrimaryMarket.MarketID == marketID &&
+			slices.Contains(articleTypes, a.Type) && !a.PublishedAt.Before(since)
+	}, 1)
+	if err != nil || len(articles) == 0 {
+		return nil, err
+	}
+	return &articles[0], nil
+}
+
+// referencesMarket reports whether an article references marketID.
+func referencesMarket(article *models.Article, marketID string) bool {
+	return slices.ContainsFunc(article.Markets, func(ref models.MarketRef) bool {
+		return ref.MarketID == marketID
+	})
+}
+
+// GetArticlesByMarketID returns the latest limit published articles that
+// reference marketID, oldest first.
+func (m *MemoryStore) GetArticlesByMarketID(ctx context.Context, marketID string, limit int) ([]models.Article, error) {
+	articles, err := m.findArticles(func(a *models.Article) bool {
+		return a.Published && referencesMarket(a, marketID)
+	}, limit)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(articles)
+	return articles, nil
+}
+
+// GetArticlesByMarketIDs returns the latest limit published articles that
+// reference any of marketIDs, newest first.
+func (m *MemoryStore) GetArticlesByMarketIDs(ctx context.Context, marketIDs []string, limit int) ([]models.Article, error) {
+	if len(marketIDs) == 0 {
+		return nil, nil
+	}
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && slices.ContainsFunc(marketIDs, func(id string) bool { return referencesMarket(a, id) })
+	}, limit)
+}
+
+// GetArticleChain returns the follow-up chain an article belongs to, oldest
+// first, as Store.GetArticleChain does.
+func (m *MemoryStore) GetArticleChain(ctx context.Context, article *models.Article) ([]models.ArticleLink, error) {
+	link := func(a *models.Article) models.ArticleLink {
+		return models.ArticleLink{ID: a.ID, Slug: a.Slug, Type: a.Type, Headline: a.Headline, PublishedAt: a.PublishedAt}
+	}
+
+	// Walk back to the original story
+	var before []models.ArticleLink
+	prevID := article.PreviousArticleID
+	for prevID != nil && len(before) < maxArticleChain {
+		prev, err := m.getArticle(*prevID)
+		if err != nil {
+			return nil, err
+		}
+		if prev == nil {
+			break
+		}
+		before = append([]models.ArticleLink{link(prev)}, before...)
+		prevID = prev.PreviousArticleID
+	}
+
+	current := link(article)
+	current.Current = true
+	chain := append(before, current)
+
+	// Walk forward through follow-ups, level by level
+	next := article.Updates
+	for len(next) > 0 && len(chain) < maxArticleChain {
+		level, err := m.findArticles(func(a *models.Article) bool { return slices.Contains(next, a.ID) }, 0)
+		if err != nil {
+			return nil, err
+		}
+		slices.Reverse(level)
+
+		next = nil
+		for i := range level {
+			chain = append(chain, link(&level[i]))
+			next = append(next, level[i].Updates...)
+		}
+	}
+
+	return chain, nil
+}
+
+// GetRelatedArticleCandidates returns up to limit published articles from
+// the last window that share a market, a tag or the category with article,
+// newest first.
+func (m *MemoryStore) GetRelatedArticleCandidates(ctx context.Context, article *models.Article, window time.Duration, limit int) ([]models.Article, error) {
+	cutoff := time.Now().Add(-window)
+	return m.findArticles(func(a *models.Article) bool {
+		if a.ID == article.ID || !a.Published || a.PublishedAt.Before(cutoff) {
+			return false
+		}
+		if a.Category == article.Category {
+			return true
+		}
+		for _, tag := range article.Tags {
+			if slices.Contains(a.Tags, tag) {
+				return true
+			}
+		}
+		for _, ref := range article.Markets {
+			if referencesMarket(a, ref.MarketID) {
+				return true
+			}
+		}
+		return false
+	}, limit)
+}
+
+// GetRecentArticles returns the most recent published articles.
+func (m *MemoryStore) GetRecentArticles(ctx context.Context, limit int) ([]models.Article, error) {
+	return m.findArticles(func(a *models.Article) bool { return a.Published }, limit)
+}
+
+// GetArticlesByType returns published articles of a specific type.
+func (m *MemoryStore) GetArticlesByType(ctx context.Context, articleType models.ArticleType, limit int) ([]models.Article, error) {
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && a.Type == articleType
+	}, limit)
+}
+
+// GetArticlesByCategory returns published articles in a category.
+func (m *MemoryStore) GetArticlesByCategory(ctx context.Context, category string, limit int) ([]models.Article, error) {
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && a.Category == category
+	}, limit)
+}
+
+// GetArticlesByTag returns published articles carrying tag, newest first.
+func (m *MemoryStore) GetArticlesByTag(ctx context.Context, tag string, limit int) ([]models.Article, error) {
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && slices.Contains(a.Tags, tag)
+	}, limit)
+}
+
+// GetFeaturedArticles returns published featured articles.
+func (m *MemoryStore) GetFeaturedArticles(ctx context.Context, limit int) ([]models.Article, error) {
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && a.Featured
+	}, limit)
+}
+
+// GetTodayArticles returns articles published today.
+func (m *MemoryStore) GetTodayArticles(ctx context.Context) ([]models.Article, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	return m.findArticles(func(a *models.Article) bool {
+		return a.Published && !a.PublishedAt.Before(today)
+	}, 0)
+}
+
+// RecordArticleView records that a user read an article.
+func (m *MemoryStore) RecordArticleView(ctx context.Context, userID primitive.ObjectID, article *models.Article) error {
+	m.mu.Lock()
+	m.views[viewKey{userID, article.ID}] = time.Now()
+	m.mu.Unlock()
+	return nil
+}
+
+// ============================================================================
+// EVENT OPERATIONS
+// ============================================================================
+
+// SaveEvent persists a market event and sets its ID.
+func (m *MemoryStore) SaveEvent(ctx context.Context, event *models.StoredEvent) error {
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	doc, err := bson.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events, err := decodeAll(m.events, func(*models.StoredEvent) bool { return true })
+	if err != nil {
+		return err
+	}
+	i, found := slices.BinarySearchFunc(events, event.ID, func(e models.StoredEvent, id primitive.ObjectID) int {
+		return bytes.Compare(e.ID[:], id[:])
+	})
+	if found {
+		return errDuplicateKey("_id")
+	}
+	m.events = slices.Insert(m.events, i, doc)
+	return nil
+}
+
+// findEvents returns the stored events matching keep, oldest first.
+func (m *MemoryStore) findEvents(keep func(*models.StoredEvent) bool) ([]models.StoredEvent, error) {
+	m.mu.RLock()
+	docs := slices.Clone(m.events)
+	m.mu.RUnlock()
+	return decodeAll(docs, keep)
+}
+
+// eventAfter reports whether an event was emitted after the given ID; every
+// event is after a zero ID.
+func eventAfter(event *models.StoredEvent, after primitive.ObjectID) bool {
+	return after.IsZero() || bytes.Compare(event.ID[:], after[:]) > 0
+}
+
+// GetEventsAfter returns up to limit events emitted after the given ID,
+// oldest first. A zero ID returns the oldest retained events.
+func (m *MemoryStore) GetEventsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]models.StoredEvent, error) {
+	events, err := m.findEvents(func(e *models.StoredEvent) bool { return eventAfter(e, after) })
+	if err != nil {
+		return nil, err
+	}
+	return limited(events, limit), nil
+}
+
+// GetRecentEvents returns the most recent events, newest first, optionally
+// of one type.
+func (m *MemoryStore) GetRecentEvents(ctx context.Context, eventType string, limit int) ([]models.StoredEvent, error) {
+	events, err := m.findEvents(func(e *models.StoredEvent) bool {
+		return eventType == "" || e.Type == eventType
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(events)
+	return limited(events, limit), nil
+}
+
+// GetMarketEvents returns the events of the given types emitted for a
+// market since the given time, oldest first, without the market documents
+// they carry.
+func (m *MemoryStore) GetMarketEvents(ctx context.Context, marketID string, since time.Time, eventTypes []string) ([]models.StoredEvent, error) {
+	from := primitive.NewObjectIDFromTimestamp(since)
+	events, err := m.findEvents(func(e *models.StoredEvent) bool {
+		return e.MarketID == marketID && slices.Contains(eventTypes, e.Type) && bytes.Compare(e.ID[:], from[:]) >= 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].Market, events[i].Previous = nil, nil
+	}
+	return events, nil
+}
+
+// CountEventsAfter counts events emitted after the given ID.
+func (m *MemoryStore) CountEventsAfter(ctx context.Context, after primitive.ObjectID) (int64, error) {
+	events, err := m.findEvents(func(e *models.StoredEvent) bool { return eventAfter(e, after) })
+	return int64(len(events)), err
+}
+
+// GetEventOffset returns a subscriber's offset, or nil if it has never
+// acknowledged an event.
+func (m *MemoryStore) GetEventOffset(ctx context.Context, subscriber string) (*models.EventOffset, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	offset, ok := m.offsets[subscriber]
+	if !ok {
+		return nil, nil
+	}
+	return &offset, nil
+}
+
+// SaveEventOffset stores a subscriber's offset, replacing the previous one.
+func (m *MemoryStore) SaveEventOffset(ctx context.Context, offset *models.EventOffset) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	offset.UpdatedAt = time.Now()
+	saved := *offset
+	saved.Acked = slices.Clone(offset.Acked)
+	m.offsets[offset.Subscriber] = saved
+	return nil
+}
+
+// ============================================================================
+// CATEGORY AND BRIEFING OPERATIONS
+// ============================================================================
+
+// GetCategories returns the seed categories, by order.
+func (m *MemoryStore) GetCategories(ctx context.Context) ([]models.Category, error) {
+	return slices.Clone(m.categories), nil
+}
+
+// GetCategoryBySlug returns a seed category by its slug.
+func (m *MemoryStore) GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	for _, c := range m.categories {
+		if c.Slug == slug {
+			return &c, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// GetCategorySentiments returns no sentiment, as MemoryStore keeps none.
+func (m *MemoryStore) GetCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error) {
+	return nil, nil
+}
+
+// GetBriefingConfig returns the default config for a briefing type, or nil
+// if there is none.
+func (m *MemoryStore) GetBriefingConfig(ctx context.Context, briefingType models.BriefingType) (*models.BriefingConfig, error) {
+	cfg, ok := m.briefings[briefingType]
+	if !ok {
+		return nil, nil
+	}
+	cfg.Categories = slices.Clone(cfg.Categories)
+	cfg.Days = slices.Clone(cfg.Days)
+	return &cfg, nil
+}
+
+// GetTrackedAccounts returns no accounts, as MemoryStore keeps none.
+func (m *MemoryStore) GetTrackedAccounts(ctx context.Context) ([]models.TrackedAccount, error) {
+	return nil, nil
+}
+
+// ============================================================================
+// STATS OPERATIONS
+// ============================================================================
+
+// GetAccuracyScores returns no scores, as MemoryStore keeps none.
+func (m *MemoryStore) GetAccuracyScores(ctx context.Context, category, horizon string) ([]models.AccuracyScore, error) {
+	return nil, nil
+}
+
+// GetTagCounts returns the tags on published articles and open markets with
+// how often each is used, most used first.
+func (m *MemoryStore) GetTagCounts(ctx context.Context, limit int) ([]models.TagCount, error) {
+	articles, err := m.findArticles(func(a *models.Article) bool { return a.Published }, 0)
+	if err != nil {
+		return nil, err
+	}
+	markets, err := m.GetAllActiveMarkets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byTag := make(map[string]*models.TagCount)
+	get := func(tag string) *models.TagCount {
+		if c, ok := byTag[tag]; ok {
+			return c
+		}
+		c := &models.TagCount{Tag: tag}
+		byTag[tag] = c
+		return c
+	}
+	for _, a := range articles {
+		for _, tag := range a.Tags {
+			if tag != "" {
+				get(tag).Articles++
+			}
+		}
+	}
+	for i := range markets {
+		for _, tag := range marketTags(&markets[i]) {
+			if tag != "" {
+				get(tag).Markets++
+			}
+		}
+	}
+
+	counts := make([]models.TagCount, 0, len(byTag))
+	for _, c := range byTag {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total() != counts[j].Total() {
+			return counts[i].Total() > counts[j].Total()
+		}
+		return counts[i].Tag < counts[j].Tag
+	})
+	return limited(counts, limit), nil
+}
+
+// GetStats returns general statistics.
+func (m *MemoryStore) GetStats(ctx context.Context) (*Stats, error) {
+	m.mu.RLock()
+	stats := &Stats{
+		TotalMarkets:   int64(len(m.markets)),
+		TotalSnapshots: int64(len(m.snapshots)),
+	}
+	m.mu.RUnlock()
+
+	active, err := m.GetAllActiveMarkets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.ActiveMarkets = int64(len(active))
+
+	articles, err := m.GetRecentArticles(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalArticles = int64(len(articles))
+
+	today, err := m.GetTodayArticles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.TodayArticles = int64(len(today))
+	return stats, nil
+}
+
+// SaveSocialSignals upserts signals by post, adding to the markets and
+// market movements each is correlated with, as Store.SaveSocialSignals does.
+func (m *MemoryStore) SaveSocialSignals(ctx context.Context, signals []models.StoredSocialSignal) (*BulkResult, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := &BulkResult{}
+	for _, signal := range signals {
+		stored := signal
+		stored.FirstSeenAt, stored.UpdatedAt = now, now
+		stored.MarketIDs, stored.AffectedMarkets = nil, nil
+		if doc, ok := m.signals[signal.TweetURL]; ok {
+			existing, err := decode[models.StoredSocialSignal](doc)
+			if err != nil {
+				return result, err
+			}
+			stored.FirstSeenAt, stored.MarketIDs = existing.FirstSeenAt, existing.MarketIDs
+			stored.AffectedMarkets = existing.AffectedMarkets
+			result.Matched++
+			result.Modified++
+		} else {
+			result.Upserted++
+		}
+		for _, id := range signal.MarketIDs {
+			if !slices.Contains(stored.MarketIDs, id) {
+				stored.MarketIDs = append(stored.MarketIDs, id)
+			}
+		}
+		for _, movement := range signal.AffectedMarkets {
+			if !slices.Contains(stored.AffectedMarkets, movement) {
+				stored.AffectedMarkets = append(stored.AffectedMarkets, movement)
+			}
+		}
+
+		doc, err := bson.Marshal(&stored)
+		if err != nil {
+			return result, err
+		}
+		m.signals[signal.TweetURL] = doc
+	}
+	return result, nil
+}
+
+// GetRecentSocialSignals returns signals posted within since, newest first.
+func (m *MemoryStore) GetRecentSocialSignals(ctx context.Context, since time.Duration, limit int) ([]models.StoredSocialSignal, error) {
+	from := time.Now().Add(-since)
+	return m.findSocialSignals(func(s *models.StoredSocialSignal) bool { return !s.PostedAt.Before(from) }, limit)
+}
+
+// GetMarketSocialSignals returns the latest signals correlated with a
+// market, newest first.
+func (m *MemoryStore) GetMarketSocialSignals(ctx context.Context, marketID string, limit int) ([]models.StoredSocialSignal, error) {
+	return m.findSocialSignals(func(s *models.StoredSocialSignal) bool { return slices.Contains(s.MarketIDs, marketID) }, limit)
+}
+
+func (m *MemoryStore) findSocialSignals(keep func(*models.StoredSocialSignal) bool, limit int) ([]models.StoredSocialSignal, error) {
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.signals))
+	for _, doc := range m.signals {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	signals, err := decodeAll(docs, keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(signals, func(i, j int) bool {
+		if !signals[i].PostedAt.Equal(signals[j].PostedAt) {
+			return signals[i].PostedAt.After(signals[j].PostedAt)
+		}
+		return signals[i].TweetURL < signals[j].TweetURL
+	})
+	return limited(signals, limit), nil
+}
+
+// ============================================================================
+// CORRELATION OPERATIONS
+// ============================================================================
+
+// SaveCorrelations upserts the given pairs and removes pairs from earlier
+// runs that no longer clear the threshold. Every pair must carry computedAt.
+func (m *MemoryStore) SaveCorrelations(ctx context.Context, correlations []models.MarketCorrelation, computedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range correlations {
+		doc, err := bson.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("write correlations: %w", err)
+		}
+		m.pairs[[2]string{c.MarketA, c.MarketB}] = doc
+	}
+
+	// Stored times have millisecond precision, as in MongoDB
+	cutoff := computedAt.Truncate(time.Millisecond)
+	for key, doc := range m.pairs {
+		c, err := decode[models.MarketCorrelation](doc)
+		if err != nil {
+			return fmt.Errorf("prune correlations: %w", err)
+		}
+		if c.ComputedAt.Before(cutoff) {
+			delete(m.pairs, key)
+		}
+	}
+	return nil
+}
+
+// GetCorrelationsForMarket returns the strongest correlations involving a market.
+func (m *MemoryStore) GetCorrelationsForMarket(ctx context.Context, marketID string, limit int) ([]models.MarketCorrelation, error) {
+	m.mu.RLock()
+	docs := make([][]byte, 0, len(m.pairs))
+	for _, doc := range m.pairs {
+		docs = append(docs, doc)
+	}
+	m.mu.RUnlock()
+
+	correlations, err := decodeAll(docs, func(c *models.MarketCorrelation) bool {
+		return c.MarketA == marketID || c.MarketB == marketID
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(correlations, func(i, j int) bool {
+		return correlations[i].Strength > correlations[j].Strength
+	})
+	return limited(correlations, limit), nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Retention is how long documents are kept in the collections purged on a
+// schedule. Zero keeps a collection's documents; analytics events and job
+// runs are also dropped by their TTL indexes once past
+// models.AnalyticsEventRetention and models.JobRunRetention. Webhook
+// deliveries, alert and content alike, aren't stored, so have no policy.
+type Retention struct {
+	LLMUsage        time.Duration // llm_usage, by created_at
+	AnalyticsEvents time.Duration // analytics_events, by created_at
+	JobRuns         time.Duration // job_runs, by started_at
+	EnrichmentCache time.Duration // enrichment_cache, by fetched_at, whatever the entry's own expiry
+}
+
+// RetentionReport is what a retention purge deleted from a collection, or
+// would delete in a dry run.
+type RetentionReport struct {
+	Collection string    `json:"collection"`
+	Field      string    `json:"field"`
+	Cutoff     time.Time `json:"cutoff"`
+	Documents  int64     `json:"documents"`
+	DryRun     bool      `json:"dry_run"`
+}
+
+// ApplyRetention deletes the documents older than r allows from each
+// collection with a retention set, or with dryRun only counts them.
+func (s *Store) ApplyRetention(ctx context.Context, r Retention, dryRun bool) ([]RetentionReport, error) {
+	policies := []struct {
+		coll   *mongo.Collection
+		field  string
+		maxAge time.Duration
+	}{
+		{s.llmUsage, "created_at", r.LLMUsage},
+		{s.analytics, "created_at", r.AnalyticsEvents},
+		{s.jobRuns, "started_at", r.JobRuns},
+		{s.enrichment, "fetched_at", r.EnrichmentCache},
+	}
+
+	now := time.Now()
+	var reports []RetentionReport
+	for _, p := range policies {
+		if p.maxAge <= 0 {
+			continue
+		}
+		report := RetentionReport{Collection: p.coll.Name(), Field: p.field, Cutoff: now.Add(-p.maxAge), DryRun: dryRun}
+		filter := bson.M{p.field: bson.M{"$lt": report.Cutoff}}
+
+		if dryRun {
+			count, err := p.coll.CountDocuments(ctx, filter)
+			if err != nil {
+				return reports, fmt.Errorf("count %s: %w", report.Collection, err)
+			}
+			report.Documents = count
+		} else {
+			result, err := p.coll.DeleteMany(ctx, filter)
+			if err != nil {
+				return reports, fmt.Errorf("purge %s: %w", report.Collection, err)
+			}
+			report.Documents = result.DeletedCount
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
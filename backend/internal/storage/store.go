@@ -3,29 +3,69 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/metrics"
 	"github.com/leeaandrob/futuresignals/internal/models"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 // Store provides access to all MongoDB collections.
 type Store struct {
-	client     *mongo.Client
-	db         *mongo.Database
-	markets    *mongo.Collection
-	snapshots  *mongo.Collection
-	articles   *mongo.Collection
-	categories *mongo.Collection
+	client       *mongo.Client
+	db           *mongo.Database
+	markets      *mongo.Collection
+	pmEvents     *mongo.Collection
+	snapshots    *mongo.Collection
+	rollups      *mongo.Collection
+	articles     *mongo.Collection
+	categories   *mongo.Collection
+	llmUsage     *mongo.Collection
+	apiKeys      *mongo.Collection
+	subscribers  *mongo.Collection
+	socialPosts  *mongo.Collection
+	signals      *mongo.Collection
+	sentiment    *mongo.Collection
+	correlations *mongo.Collection
+	accuracy     *mongo.Collection
+	embeddings   *mongo.Collection
+	enrichment   *mongo.Collection
+	events       *mongo.Collection
+	eventOffsets *mongo.Collection
+	watchlists   *mongo.Collection
+	users        *mongo.Collection
+	loginTokens  *mongo.Collection
+	preferences  *mongo.Collection
+	views        *mongo.Collection
+	analytics    *mongo.Collection
+	analyticsDay *mongo.Collection
+	assets       *mongo.Collection
+	locks        *mongo.Collection
+	jobRuns      *mongo.Collection
+	calendar     *mongo.Collection
+	explainers   *mongo.Collection
+	briefings    *mongo.Collection
+	accounts     *mongo.Collection
+	settings     *mongo.Collection
+
+	categoryCache *CategoryCache
 }
 
 // NewStore creates a new storage connection.
 func NewStore(ctx context.Context, uri, dbName string) (*Store, error) {
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	monitor := combineMonitors(metrics.MongoMonitor(), otelmongo.NewMonitor())
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetMonitor(monitor))
 	if err != nil {
 		return nil, err
 	}
@@ -38,84 +78,251 @@ func NewStore(ctx context.Context, uri, dbName string) (*Store, error) {
 	log.Info().Str("db", dbName).Msg("Connected to MongoDB")
 
 	store := &Store{
-		client:     client,
-		db:         db,
-		markets:    db.Collection("markets"),
-		snapshots:  db.Collection("snapshots"),
-		articles:   db.Collection("articles"),
-		categories: db.Collection("categories"),
+		client:       client,
+		db:           db,
+		markets:      db.Collection("markets"),
+		pmEvents:     db.Collection("polymarket_events"),
+		snapshots:    db.Collection("snapshots"),
+		rollups:      db.Collection("snapshot_rollups"),
+		articles:     db.Collection("articles"),
+		categories:   db.Collection("categories"),
+		llmUsage:     db.Collection("llm_usage"),
+		apiKeys:      db.Collection("api_keys"),
+		subscribers:  db.Collection("subscribers"),
+		socialPosts:  db.Collection("social_posts"),
+		signals:      db.Collection("social_signals"),
+		sentiment:    db.Collection("category_sentiment"),
+		correlations: db.Collection("market_correlations"),
+		accuracy:     db.Collection("accuracy"),
+		embeddings:   db.Collection("market_embeddings"),
+		enrichment:   db.Collection("enrichment_cache"),
+		events:       db.Collection("events"),
+		eventOffsets: db.Collection("event_offsets"),
+		watchlists:   db.Collection("watchlists"),
+		users:        db.Collection("users"),
+		loginTokens:  db.Collection("login_tokens"),
+		preferences:  db.Collection("notification_preferences"),
+		views:        db.Collection("article_views"),
+		analytics:    db.Collection("analytics_events"),
+		analyticsDay: db.Collection("analytics_daily"),
+		assets:       db.Collection("assets"),
+		locks:        db.Collection("locks"),
+		jobRuns:      db.Collection("job_runs"),
+		calendar:     db.Collection("content_calendar"),
+		explainers:   db.Collection("explainer_topics"),
+		briefings:    db.Collection("briefing_configs"),
+		accounts:     db.Collection("tracked_accounts"),
+		settings:     db.Collection("settings"),
 	}
+	store.categoryCache = &CategoryCache{store: store, ttl: categoryCacheTTL}
 
-	// Initialize indexes
-	if err := store.createIndexes(ctx); err != nil {
-		log.Warn().Err(err).Msg("Failed to create some indexes")
+	// Snapshots live in a time-series collection; convert a legacy one first
+	if err := store.ensureSnapshotCollection(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to prepare snapshots time-series collection")
 	}
 
+	// Initialize indexes and log any still missing
+	store.createIndexes(ctx)
+	store.checkIndexes(ctx)
+
 	// Initialize default categories
 	if err := store.initCategories(ctx); err != nil {
 		log.Warn().Err(err).Msg("Failed to initialize categories")
 	}
 
+	// Initialize default briefings
+	if err := store.initBriefingConfigs(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to initialize briefing configs")
+	}
+
+	// Initialize default explainer topics
+	if err := store.initExplainerTopics(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to initialize explainer topics")
+	}
+
 	return store, nil
 }
 
+// combineMonitors fans command events out to several monitors, since the
+// driver accepts only one.
+func combineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
+
+// Database returns the underlying database, for migrations.
+func (s *Store) Database() *mongo.Database {
+	return s.db
+}
+
 // Close closes the database connection.
 func (s *Store) Close(ctx context.Context) error {
 	return s.client.Disconnect(ctx)
 }
 
-// createIndexes creates necessary indexes for efficient queries.
-func (s *Store) createIndexes(ctx context.Context) error {
-	// Markets indexes
-	marketIndexes := []mongo.IndexModel{
-		{Keys: bson.D{{Key: "market_id", Value: 1}}, Options: options.Index().SetUnique(true)},
-		{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
-		{Keys: bson.D{{Key: "category", Value: 1}}},
-		{Keys: bson.D{{Key: "trending_score", Value: -1}}},
-		{Keys: bson.D{{Key: "volume_24h", Value: -1}}},
-		{Keys: bson.D{{Key: "change_24h", Value: -1}}},
-		{Keys: bson.D{{Key: "first_seen_at", Value: -1}}},
-		{Keys: bson.D{{Key: "active", Value: 1}}},
+// legacySnapshotsName is where a pre time-series snapshots collection is
+// parked while its documents are copied over.
+const legacySnapshotsName = "snapshots_legacy"
+
+// ensureSnapshotCollection makes sure snapshots is a time-series collection,
+// migrating documents from a regular collection if one exists. The copy
+// resumes from the newest migrated snapshot, so an interrupted migration is
+// picked up on the next start.
+func (s *Store) ensureSnapshotCollection(ctx context.Context) error {
+	specs, err := s.db.ListCollectionSpecifications(ctx, bson.M{
+		"name": bson.M{"$in": []string{"snapshots", legacySnapshotsName}},
+	})
+	if err != nil {
+		return fmt.Errorf("list collections: %w", err)
+	}
+
+	var current, legacy *mongo.CollectionSpecification
+	for _, spec := range specs {
+		switch spec.Name {
+		case "snapshots":
+			current = spec
+		case legacySnapshotsName:
+			legacy = spec
+		}
+	}
+
+	if current != nil && current.Type != "timeseries" {
+		if legacy != nil {
+			return fmt.Errorf("both snapshots and %s are regular collections", legacySnapshotsName)
+		}
+		log.Info().Msg("Moving legacy snapshots collection aside for time-series migration")
+		rename := bson.D{
+			{Key: "renameCollection", Value: s.db.Name() + ".snapshots"},
+			{Key: "to", Value: s.db.Name() + "." + legacySnapshotsName},
+		}
+		if err := s.client.Database("admin").RunCommand(ctx, rename).Err(); err != nil {
+			return fmt.Errorf("rename legacy snapshots: %w", err)
+		}
+		legacy, current = current, nil
+	}
+
+	if current == nil {
+		opts := options.CreateCollection().SetTimeSeriesOptions(
+			options.TimeSeries().
+				SetTimeField("captured_at").
+				SetMetaField("market_id").
+				SetGranularity("minutes"),
+		)
+		if err := s.db.CreateCollection(ctx, "snapshots", opts); err != nil {
+			return fmt.Errorf("create snapshots time-series: %w", err)
+		}
+		log.Info().Msg("Created snapshots time-series collection")
+	}
+
+	if legacy != nil {
+		return s.migrateLegacySnapshots(ctx)
 	}
-	if _, err := s.markets.Indexes().CreateMany(ctx, marketIndexes); err != nil {
-		log.Warn().Err(err).Msg("Failed to create market indexes")
+	return nil
+}
+
+// migrateLegacySnapshots copies legacy snapshots newer than the latest
+// time-series snapshot, then drops the legacy collection. Their rollups are
+// backfilled by the snapshot-rollups migration.
+func (s *Store) migrateLegacySnapshots(ctx context.Context) error {
+	legacy := s.db.Collection(legacySnapshotsName)
+
+	filter := bson.M{}
+	var newest models.Snapshot
+	opts := options.FindOne().SetSort(bson.D{{Key: "captured_at", Value: -1}})
+	if err := s.snapshots.FindOne(ctx, bson.M{}, opts).Decode(&newest); err == nil {
+		filter["captured_at"] = bson.M{"$gt": newest.CapturedAt}
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("find newest snapshot: %w", err)
 	}
 
-	// Snapshots indexes
-	snapshotIndexes := []mongo.IndexModel{
-		{Keys: bson.D{{Key: "market_id", Value: 1}, {Key: "captured_at", Value: -1}}},
-		{Keys: bson.D{{Key: "captured_at", Value: -1}}},
+	cursor, err := legacy.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "captured_at", Value: 1}}))
+	if err != nil {
+		return fmt.Errorf("read legacy snapshots: %w", err)
 	}
-	if _, err := s.snapshots.Indexes().CreateMany(ctx, snapshotIndexes); err != nil {
-		log.Warn().Err(err).Msg("Failed to create snapshot indexes")
+	defer cursor.Close(ctx)
+
+	const batchSize = 1000
+	batch := make([]interface{}, 0, batchSize)
+	copied := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := s.snapshots.InsertMany(ctx, batch); err != nil {
+			return fmt.Errorf("copy snapshots: %w", err)
+		}
+		copied += len(batch)
+		batch = batch[:0]
+		return nil
 	}
 
-	// Articles indexes
-	articleIndexes := []mongo.IndexModel{
-		{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
-		{Keys: bson.D{{Key: "type", Value: 1}}},
-		{Keys: bson.D{{Key: "category", Value: 1}}},
-		{Keys: bson.D{{Key: "published_at", Value: -1}}},
-		{Keys: bson.D{{Key: "published", Value: 1}}},
-		{Keys: bson.D{{Key: "featured", Value: 1}}},
-		{Keys: bson.D{{Key: "tags", Value: 1}}},
+	for cursor.Next(ctx) {
+		var snapshot models.Snapshot
+		if err := cursor.Decode(&snapshot); err != nil {
+			return fmt.Errorf("decode legacy snapshot: %w", err)
+		}
+		batch = append(batch, snapshot)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("read legacy snapshots: %w", err)
+	}
+	if err := flush(); err != nil {
+		return err
 	}
-	if _, err := s.articles.Indexes().CreateMany(ctx, articleIndexes); err != nil {
-		log.Warn().Err(err).Msg("Failed to create article indexes")
+
+	if err := legacy.Drop(ctx); err != nil {
+		return fmt.Errorf("drop legacy snapshots: %w", err)
 	}
 
+	log.Info().Int("copied", copied).Msg("Migrated snapshots to time-series collection")
 	return nil
 }
 
-// initCategories initializes default categories if not present.
+// initCategories initializes default categories if not present, and gives
+// categories stored before keywords moved to the database their built-in
+// keyword list. Categories edited since are left alone.
 func (s *Store) initCategories(ctx context.Context) error {
-	for _, cat := range models.DefaultCategories {
+	for _, cat := range models.SeedCategories() {
 		filter := bson.M{"slug": cat.Slug}
 		update := bson.M{"$setOnInsert": cat}
 		opts := options.Update().SetUpsert(true)
 		if _, err := s.categories.UpdateOne(ctx, filter, update, opts); err != nil {
 			return err
 		}
+
+		if len(cat.Keywords) == 0 {
+			continue
+		}
+		filter["keywords"] = bson.M{"$exists": false}
+		if _, err := s.categories.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"keywords": cat.Keywords}}); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -139,6 +346,167 @@ func (s *Store) UpsertMarket(ctx context.Context, market *models.Market) error {
 	return err
 }
 
+// BulkResult summarizes a bulk write.
+type BulkResult struct {
+	Matched  int64
+	Modified int64
+	Upserted int64
+	Failed   int
+}
+
+// BulkUpsertMarkets inserts or updates many markets in a single round trip.
+// The write is unordered so one bad document doesn't block the rest; on a
+// partial failure both the result and the error are returned.
+func (s *Store) BulkUpsertMarkets(ctx context.Context, markets []*models.Market) (*BulkResult, error) {
+	if len(markets) == 0 {
+		return &BulkResult{}, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(markets))
+	for _, market := range markets {
+		market.UpdatedAt = now
+		if market.FirstSeenAt.IsZero() {
+			market.FirstSeenAt = now
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"market_id": market.MarketID}).
+			SetUpdate(bson.M{"$set": market}).
+			SetUpsert(true))
+	}
+
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := s.markets.BulkWrite(ctx, writes, opts)
+
+	result := &BulkResult{}
+	if res != nil {
+		result.Matched = res.MatchedCount
+		result.Modified = res.ModifiedCount
+		result.Upserted = res.UpsertedCount
+	}
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			result.Failed = len(bulkErr.WriteErrors)
+		} else {
+			result.Failed = len(markets)
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// SetMarketCategory records a market's category and how it was assigned.
+func (s *Store) SetMarketCategory(ctx context.Context, marketID, category, source string) error {
+	_, err := s.markets.UpdateOne(ctx,
+		bson.M{"market_id": marketID},
+		bson.M{"$set": bson.M{
+			"category":        category,
+			"category_source": source,
+		}},
+	)
+	return err
+}
+
+// SetMarketOrderBook records a market's latest order book summary.
+func (s *Store) SetMarketOrderBook(ctx context.Context, marketID string, book *models.OrderBook) error {
+	_, err := s.markets.UpdateOne(ctx,
+		bson.M{"market_id": marketID},
+		bson.M{"$set": bson.M{"order_book": book}},
+	)
+	return err
+}
+
+// SetMarketTradeFlow records a market's latest trade flow summary.
+func (s *Store) SetMarketTradeFlow(ctx context.Context, marketID string, flow *models.TradeFlow) error {
+	_, err := s.markets.UpdateOne(ctx,
+		bson.M{"market_id": marketID},
+		bson.M{"$set": bson.M{"trade_flow": flow}},
+	)
+	return err
+}
+
+// SetMarketHolders records a market's latest holder summary.
+func (s *Store) SetMarketHolders(ctx context.Context, marketID string, holders *models.Holders) error {
+	_, err := s.markets.UpdateOne(ctx,
+		bson.M{"market_id": marketID},
+		bson.M{"$set": bson.M{"holders": holders}},
+	)
+	return err
+}
+
+// SetMarketIntradayChanges stores the snapshot-derived 1h/6h changes and
+// hourly volume of the given markets.
+func (s *Store) SetMarketIntradayChanges(ctx context.Context, markets []*models.Market) error {
+	if len(markets) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(markets))
+	for _, m := range markets {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"market_id": m.MarketID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"change_1h": m.Change1h,
+				"change_6h": m.Change6h,
+				"volume_1h": m.Volume1h,
+			}}))
+	}
+
+	if _, err := s.markets.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("write intraday changes: %w", err)
+	}
+	return nil
+}
+
+// MarkMarketResolved records a market's resolution. The market keeps its last
+// synced prices but, being closed, drops out of active queries.
+func (s *Store) MarkMarketResolved(ctx context.Context, marketID, outcome string, resolvedAt time.Time) error {
+	_, err := s.markets.UpdateOne(ctx,
+		bson.M{"market_id": marketID},
+		bson.M{"$set": bson.M{
+			"closed":          true,
+			"resolved":        true,
+			"winning_outcome": outcome,
+			"resolved_at":     resolvedAt,
+			"updated_at":      time.Now(),
+		}},
+	)
+	return err
+}
+
+// ArchiveClosedMarkets archives the markets closed for longer than
+// closedFor, dropping them from market lists, and returns how many it
+// archived. Markets restored from the archive aren't archived again.
+func (s *Store) ArchiveClosedMarkets(ctx context.Context, closedFor time.Duration) (int64, error) {
+	now := time.Now()
+	filter := bson.M{
+		"closed":      true,
+		"archived":    bson.M{"$ne": true},
+		"archived_at": bson.M{"$exists": false},
+		"updated_at":  bson.M{"$lt": now.Add(-closedFor)},
+	}
+	result, err := s.markets.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"archived": true, "archived_at": now}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// RestoreMarket takes a market out of the archive. It reports false if no
+// archived market has the slug.
+func (s *Store) RestoreMarket(ctx context.Context, slug string) (bool, error) {
+	result, err := s.markets.UpdateOne(ctx,
+		bson.M{"slug": slug, "archived": true},
+		bson.M{"$set": bson.M{"archived": false, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 // GetMarketByID returns a market by its Polymarket ID.
 func (s *Store) GetMarketByID(ctx context.Context, marketID string) (*models.Market, error) {
 	var market models.Market
@@ -169,6 +537,38 @@ func (s *Store) GetTrendingMarkets(ctx context.Context, limit int) ([]models.Mar
 	return s.findMarkets(ctx, filter, opts)
 }
 
+// GetTrendingMarketsByCategory returns the up to perCategory open markets
+// with the highest trending score in each category. Each category is a
+// limited query on the category trending index, so only the markets
+// returned are read rather than every open market being grouped in memory.
+func (s *Store) GetTrendingMarketsByCategory(ctx context.Context, perCategory int) (map[string][]models.Market, error) {
+	open := bson.M{"active": true, "closed": false, "archived": bson.M{"$ne": true}}
+	categories, err := s.markets.Distinct(ctx, "category", open)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[string][]models.Market, len(categories))
+	for _, c := range categories {
+		category, ok := c.(string)
+		if !ok {
+			continue
+		}
+
+		opts := options.Find().
+			SetSort(bson.D{{Key: "trending_score", Value: -1}}).
+			SetLimit(int64(perCategory))
+
+		filter := bson.M{"category": category, "active": true, "closed": false}
+		markets, err := s.findMarkets(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		byCategory[category] = markets
+	}
+	return byCategory, nil
+}
+
 // GetMarketsByCategory returns markets for a specific category.
 func (s *Store) GetMarketsByCategory(ctx context.Context, category string, limit int) ([]models.Market, error) {
 	opts := options.Find().
@@ -193,16 +593,42 @@ func (s *Store) GetNewMarkets(ctx context.Context, since time.Duration, limit in
 	return s.findMarkets(ctx, filter, opts)
 }
 
-// GetBreakingMarkets returns markets with significant price movements.
-func (s *Store) GetBreakingMarkets(ctx context.Context, threshold float64, limit int) ([]models.Market, error) {
+// Change windows for GetBreakingMarkets.
+const (
+	ChangeWindow1h  = "1h"
+	ChangeWindow6h  = "6h"
+	ChangeWindow24h = "24h"
+)
+
+// changeFields maps change windows to their market fields.
+var changeFields = map[string]string{
+	ChangeWindow1h:  "change_1h",
+	ChangeWindow6h:  "change_6h",
+	ChangeWindow24h: "change_24h",
+}
+
+// ValidChangeWindow reports whether window is a supported change window.
+func ValidChangeWindow(window string) bool {
+	_, ok := changeFields[window]
+	return ok
+}
+
+// GetBreakingMarkets returns markets whose price moved by at least threshold
+// over the given window (ChangeWindow1h, 6h or 24h; 24h if empty).
+func (s *Store) GetBreakingMarkets(ctx context.Context, window string, threshold float64, limit int) ([]models.Market, error) {
+	field, ok := changeFields[window]
+	if !ok {
+		field = changeFields[ChangeWindow24h]
+	}
+
 	opts := options.Find().
-		SetSort(bson.D{{Key: "change_24h", Value: -1}}).
+		SetSort(bson.D{{Key: field, Value: -1}}).
 		SetLimit(int64(limit))
 
 	filter := bson.M{
 		"$or": []bson.M{
-			{"change_24h": bson.M{"$gte": threshold}},
-			{"change_24h": bson.M{"$lte": -threshold}},
+			{field: bson.M{"$gte": threshold}},
+			{field: bson.M{"$lte": -threshold}},
 		},
 		"active": true,
 		"closed": false,
@@ -220,14 +646,171 @@ func (s *Store) GetTopMarketsByVolume(ctx context.Context, limit int) ([]models.
 	return s.findMarkets(ctx, filter, opts)
 }
 
+// GetClosingSoonMarkets returns open markets whose end date falls within
+// the next within, soonest first.
+func (s *Store) GetClosingSoonMarkets(ctx context.Context, within time.Duration, limit int) ([]models.Market, error) {
+	now := time.Now()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "end_date_ts", Value: 1}}).
+		SetLimit(int64(limit))
+
+	filter := bson.M{
+		"end_date_ts": bson.M{"$gte": now, "$lte": now.Add(within)},
+		"active":      true,
+		"closed":      false,
+	}
+	return s.findMarkets(ctx, filter, opts)
+}
+
+// GetMarketMovers returns the open markets with at least minVolume 24h
+// volume that rose most over 24h, biggest rise first, and that fell most,
+// biggest fall first.
+func (s *Store) GetMarketMovers(ctx context.Context, minVolume float64, limit int) (gainers, losers []models.Market, err error) {
+	movers := func(cmp string, order int) ([]models.Market, error) {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "change_24h", Value: order}}).
+			SetLimit(int64(limit))
+
+		filter := bson.M{
+			"change_24h": bson.M{cmp: 0},
+			"volume_24h": bson.M{"$gte": minVolume},
+			"active":     true,
+			"closed":     false,
+		}
+		return s.findMarkets(ctx, filter, opts)
+	}
+
+	if gainers, err = movers("$gt", -1); err != nil {
+		return nil, nil, err
+	}
+	if losers, err = movers("$lt", 1); err != nil {
+		return nil, nil, err
+	}
+	return gainers, losers, nil
+}
+
+// Sort orders for ListMarkets.
+const (
+	MarketSortVolume    = "volume"     // 24h volume, highest first
+	MarketSortTrending  = "trending"   // Trending score, highest first
+	MarketSortChange24h = "change_24h" // 24h change, biggest rise first
+	MarketSortLiquidity = "liquidity"  // Liquidity, deepest first
+	MarketSortEndDate   = "end_date"   // End date, soonest first
+	MarketSortNewest    = "newest"     // First seen, newest first
+)
+
+// marketSorts maps ListMarkets sort orders to their sort keys.
+var marketSorts = map[string]bson.E{
+	MarketSortVolume:    {Key: "volume_24h", Value: -1},
+	MarketSortTrending:  {Key: "trending_score", Value: -1},
+	MarketSortChange24h: {Key: "change_24h", Value: -1},
+	MarketSortLiquidity: {Key: "liquidity", Value: -1},
+	MarketSortEndDate:   {Key: "end_date_ts", Value: 1},
+	MarketSortNewest:    {Key: "first_seen_at", Value: -1},
+}
+
+// ValidMarketSort reports whether sort is a supported ListMarkets order.
+func ValidMarketSort(sort string) bool {
+	_, ok := marketSorts[sort]
+	return ok
+}
+
+// MarketQuery selects open markets for ListMarkets. Zero fields don't
+// filter.
+type MarketQuery struct {
+	Sort     string // A MarketSort order; MarketSortVolume if empty
+	Category string
+
+	// MinVolume is the lowest 24h volume, MaxProbability the highest yes
+	// price (unset when nil)
+	MinVolume      float64
+	MaxProbability *float64
+
+	// EndingWithin keeps markets whose end date falls between now and now
+	// plus EndingWithin
+	EndingWithin time.Duration
+
+	// Keep markets in these tiers; any tier when empty
+	Tier          models.Tier
+	LiquidityTier models.Tier
+	VolumeTier    models.Tier
+
+	// Offset skips the first matches, for paging
+	Offset int
+	Limit  int
+}
+
+// ListMarkets returns the open markets matching q in its sort order. The
+// end date order and EndingWithin leave out markets already past their
+// end date or without one.
+func (s *Store) ListMarkets(ctx context.Context, q MarketQuery) ([]models.Market, error) {
+	sortKey, ok := marketSorts[q.Sort]
+	if !ok {
+		sortKey = marketSorts[MarketSortVolume]
+	}
+
+	filter := bson.M{"active": true, "closed": false}
+	if q.Category != "" {
+		filter["category"] = q.Category
+	}
+	if q.MinVolume > 0 {
+		filter["volume_24h"] = bson.M{"$gte": q.MinVolume}
+	}
+	if q.MaxProbability != nil {
+		filter["probability"] = bson.M{"$lte": *q.MaxProbability}
+	}
+	for field, tier := range map[string]models.Tier{"tier": q.Tier, "liquidity_tier": q.LiquidityTier, "volume_tier": q.VolumeTier} {
+		if tier != "" {
+			filter[field] = tier
+		}
+	}
+	if q.EndingWithin > 0 || q.Sort == MarketSortEndDate {
+		now := time.Now()
+		endDate := bson.M{"$gte": now}
+		if q.EndingWithin > 0 {
+			endDate["$lte"] = now.Add(q.EndingWithin)
+		}
+		filter["end_date_ts"] = endDate
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{sortKey, {Key: "market_id", Value: 1}}).
+		SetSkip(int64(q.Offset)).
+		SetLimit(int64(q.Limit))
+	return s.findMarkets(ctx, filter, opts)
+}
+
 // GetAllActiveMarkets returns all active markets.
 func (s *Store) GetAllActiveMarkets(ctx context.Context) ([]models.Market, error) {
 	filter := bson.M{"active": true, "closed": false}
 	return s.findMarkets(ctx, filter, nil)
 }
 
+// GetResolvedMarkets returns markets resolved within the given period,
+// newest first.
+func (s *Store) GetResolvedMarkets(ctx context.Context, since time.Duration) ([]models.Market, error) {
+	filter := bson.M{
+		"resolved":    true,
+		"resolved_at": bson.M{"$gte": time.Now().Add(-since)},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "resolved_at", Value: -1}})
+	return s.findMarkets(ctx, filter, opts)
+}
+
+// GetMarketsByIDs retrieves markets by market ID, in no particular order.
+func (s *Store) GetMarketsByIDs(ctx context.Context, marketIDs []string) ([]models.Market, error) {
+	if len(marketIDs) == 0 {
+		return nil, nil
+	}
+	return s.findMarkets(ctx, bson.M{"market_id": bson.M{"$in": marketIDs}}, nil)
+}
+
 func (s *Store) findMarkets(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Market, error) {
-	cursor, err := s.markets.Find(ctx, filter, opts)
+	// Archived markets are left out unless the filter asks for them
+	if _, ok := filter["archived"]; !ok {
+		filter["archived"] = bson.M{"$ne": true}
+	}
+	cursor, err := s.markets.Find(ctx, filter, projectFields(ctx, marketFieldsKey{}, opts))
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +823,130 @@ func (s *Store) findMarkets(ctx context.Context, filter bson.M, opts *options.Fi
 	return markets, nil
 }
 
+type (
+	marketFieldsKey  struct{}
+	articleFieldsKey struct{}
+)
+
+// WithMarketFields returns a context under which market list queries load
+// only the given BSON fields, plus _id, for sparse API responses.
+func WithMarketFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, marketFieldsKey{}, fields)
+}
+
+// WithArticleFields is WithMarketFields for article list queries.
+func WithArticleFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, articleFieldsKey{}, fields)
+}
+
+// projectFields replaces opts' projection with the fields selected in ctx
+// under key, if any.
+func projectFields(ctx context.Context, key any, opts *options.FindOptions) *options.FindOptions {
+	fields, _ := ctx.Value(key).([]string)
+	if len(fields) == 0 {
+		return opts
+	}
+	if opts == nil {
+		opts = options.Find()
+	}
+	projection := make(bson.M, len(fields))
+	for _, f := range fields {
+		projection[f] = 1
+	}
+	return opts.SetProjection(projection)
+}
+
+// ============================================================================
+// POLYMARKET EVENT OPERATIONS
+// ============================================================================
+
+// BulkUpsertPolymarketEvents writes events in one unordered bulk write,
+// keyed by event ID. FirstSeenAt is set when an event is first stored and
+// kept after.
+func (s *Store) BulkUpsertPolymarketEvents(ctx context.Context, events []*models.PolymarketEvent) (*BulkResult, error) {
+	if len(events) == 0 {
+		return &BulkResult{}, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		event.UpdatedAt = now
+		event.FirstSeenAt = time.Time{} // Left out of $set
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"event_id": event.EventID}).
+			SetUpdate(bson.M{
+				"$set":         event,
+				"$setOnInsert": bson.M{"first_seen_at": now},
+			}).
+			SetUpsert(true))
+	}
+
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := s.pmEvents.BulkWrite(ctx, writes, opts)
+
+	result := &BulkResult{}
+	if res != nil {
+		result.Matched = res.MatchedCount
+		result.Modified = res.ModifiedCount
+		result.Upserted = res.UpsertedCount
+	}
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			result.Failed = len(bulkErr.WriteErrors)
+		} else {
+			result.Failed = len(events)
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// GetPolymarketEventBySlug returns an event by its Polymarket slug.
+func (s *Store) GetPolymarketEventBySlug(ctx context.Context, slug string) (*models.PolymarketEvent, error) {
+	var event models.PolymarketEvent
+	err := s.pmEvents.FindOne(ctx, bson.M{"slug": slug}).Decode(&event)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetEventMarkets returns the synced markets of an event, most likely
+// outcome first.
+func (s *Store) GetEventMarkets(ctx context.Context, eventID string) ([]models.Market, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "probability", Value: -1}, {Key: "market_id", Value: 1}})
+	return s.findMarkets(ctx, bson.M{"event_id": eventID}, opts)
+}
+
+// GetExclusiveEvents returns the open neg-risk events, whose outcomes are
+// mutually exclusive, with at least minVolume 24h volume, most traded
+// first.
+func (s *Store) GetExclusiveEvents(ctx context.Context, minVolume float64, limit int) ([]models.PolymarketEvent, error) {
+	filter := bson.M{
+		"neg_risk":   true,
+		"closed":     false,
+		"volume_24h": bson.M{"$gte": minVolume},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "volume_24h", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.pmEvents.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.PolymarketEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
 // ============================================================================
 // SNAPSHOT OPERATIONS
 // ============================================================================
@@ -251,8 +958,74 @@ func (s *Store) SaveSnapshot(ctx context.Context, snapshot *models.Snapshot) err
 	return err
 }
 
-// GetSnapshots returns snapshots for a market within a time range.
+// GetSnapshotsAt returns, per market, the latest snapshot captured within
+// tolerance before at.
+func (s *Store) GetSnapshotsAt(ctx context.Context, at time.Time, tolerance time.Duration) (map[string]models.Snapshot, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"captured_at": bson.M{
+			"$gte": at.Add(-tolerance),
+			"$lte": at,
+		}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "captured_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$market_id",
+			"snapshot": bson.M{"$first": "$$ROOT"},
+		}}},
+	}
+
+	cursor, err := s.snapshots.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Snapshot models.Snapshot `bson:"snapshot"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]models.Snapshot, len(results))
+	for _, r := range results {
+		snapshots[r.Snapshot.MarketID] = r.Snapshot
+	}
+	return snapshots, nil
+}
+
+// Raw snapshots are returned for ranges up to RawSnapshotRange, hourly
+// rollups up to HourlyRollupRange, and daily rollups beyond that.
+const (
+	RawSnapshotRange  = 48 * time.Hour
+	HourlyRollupRange = 30 * 24 * time.Hour
+)
+
+// GetSnapshots returns snapshots for a market within a time range, newest
+// first. Longer ranges are served from rollups so the result stays small;
+// rollup points carry the bucket's closing probability.
 func (s *Store) GetSnapshots(ctx context.Context, marketID string, since time.Duration) ([]models.Snapshot, error) {
+	if since > RawSnapshotRange {
+		granularity := models.GranularityDay
+		if since <= HourlyRollupRange {
+			granularity = models.GranularityHour
+		}
+
+		rollups, err := s.GetSnapshotRollups(ctx, marketID, granularity, since)
+		if err != nil {
+			return nil, err
+		}
+		snapshots := make([]models.Snapshot, 0, len(rollups))
+		for _, r := range rollups {
+			snapshots = append(snapshots, r.ToSnapshot())
+		}
+		return snapshots, nil
+	}
+
+	return s.getRawSnapshots(ctx, marketID, since)
+}
+
+// getRawSnapshots returns unaggregated snapshots for a market, newest first.
+func (s *Store) getRawSnapshots(ctx context.Context, marketID string, since time.Duration) ([]models.Snapshot, error) {
 	filter := bson.M{
 		"market_id":   marketID,
 		"captured_at": bson.M{"$gte": time.Now().Add(-since)},
@@ -293,8 +1066,170 @@ func (s *Store) CleanOldSnapshots(ctx context.Context, olderThan time.Duration)
 	return result.DeletedCount, nil
 }
 
-// ============================================================================
-// ARTICLE OPERATIONS
+// GetSnapshotRollups returns rollups of one granularity for a market, newest first.
+func (s *Store) GetSnapshotRollups(ctx context.Context, marketID, granularity string, since time.Duration) ([]models.SnapshotRollup, error) {
+	filter := bson.M{
+		"market_id":    marketID,
+		"granularity":  granularity,
+		"bucket_start": bson.M{"$gte": time.Now().Add(-since)},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "bucket_start", Value: -1}})
+
+	cursor, err := s.rollups.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rollups []models.SnapshotRollup
+	if err := cursor.All(ctx, &rollups); err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// GetSnapshotRollupsForMarkets returns the rollups of several markets in
+// one query, by market ID, each newest first.
+func (s *Store) GetSnapshotRollupsForMarkets(ctx context.Context, marketIDs []string, granularity string, since time.Duration) (map[string][]models.SnapshotRollup, error) {
+	byMarket := make(map[string][]models.SnapshotRollup, len(marketIDs))
+	if len(marketIDs) == 0 {
+		return byMarket, nil
+	}
+
+	filter := bson.M{
+		"market_id":    bson.M{"$in": marketIDs},
+		"granularity":  granularity,
+		"bucket_start": bson.M{"$gte": time.Now().Add(-since)},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "bucket_start", Value: -1}})
+
+	cursor, err := s.rollups.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rollups []models.SnapshotRollup
+	if err := cursor.All(ctx, &rollups); err != nil {
+		return nil, err
+	}
+	for _, r := range rollups {
+		byMarket[r.MarketID] = append(byMarket[r.MarketID], r)
+	}
+	return byMarket, nil
+}
+
+// GetOddsRanges summarizes a market's daily rollups over each lookback:
+// the low and high before today, and where current falls among them.
+func (s *Store) GetOddsRanges(ctx context.Context, marketID string, current float64, lookbacks []time.Duration) ([]models.OddsRange, error) {
+	rollups, err := s.GetSnapshotRollups(ctx, marketID, models.GranularityDay, historySince(lookbacks))
+	if err != nil {
+		return nil, err
+	}
+	return summarizeOdds(rollups, current, lookbacks, time.Now()), nil
+}
+
+// RollupSnapshots aggregates raw snapshots captured at or after from into
+// buckets of the given granularity and merges them into snapshot_rollups.
+// Buckets are recomputed in full, so re-running over the same range is safe.
+func (s *Store) RollupSnapshots(ctx context.Context, granularity string, from time.Time) error {
+	return rollupSnapshots(ctx, s.snapshots, granularity, from, time.Time{})
+}
+
+// rollupBackfillWindow is how much snapshot history BackfillSnapshotRollups
+// aggregates at a time. It is a whole number of days, so daily buckets
+// never straddle two windows.
+const rollupBackfillWindow = 7 * 24 * time.Hour
+
+// BackfillSnapshotRollups computes the hourly and daily rollups of every
+// snapshot in db, a window at a time, for snapshots that predate the
+// rollup job, such as those copied from a legacy collection. It returns
+// how many windows were rolled up.
+func BackfillSnapshotRollups(ctx context.Context, db *mongo.Database) (int, error) {
+	snapshots := db.Collection("snapshots")
+
+	var oldest models.Snapshot
+	opts := options.FindOne().SetSort(bson.D{{Key: "captured_at", Value: 1}})
+	if err := snapshots.FindOne(ctx, bson.M{}, opts).Decode(&oldest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("find oldest snapshot: %w", err)
+	}
+
+	windows := 0
+	now := time.Now()
+	for from := oldest.CapturedAt.UTC().Truncate(24 * time.Hour); from.Before(now); from = from.Add(rollupBackfillWindow) {
+		to := from.Add(rollupBackfillWindow)
+		for _, granularity := range []string{models.GranularityHour, models.GranularityDay} {
+			if err := rollupSnapshots(ctx, snapshots, granularity, from, to); err != nil {
+				return windows, err
+			}
+		}
+		windows++
+	}
+	return windows, nil
+}
+
+// rollupSnapshots merges the granularity buckets of the snapshots captured
+// in [from, to) into snapshot_rollups; a zero to leaves the range open.
+func rollupSnapshots(ctx context.Context, snapshots *mongo.Collection, granularity string, from, to time.Time) error {
+	captured := bson.M{"$gte": from}
+	if !to.IsZero() {
+		captured["$lt"] = to
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"captured_at": captured}}},
+		{{Key: "$sort", Value: bson.D{{Key: "captured_at", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"market_id": "$market_id",
+				"bucket_start": bson.M{"$dateTrunc": bson.M{
+					"date": "$captured_at",
+					"unit": granularity,
+				}},
+			},
+			"open":         bson.M{"$first": "$probability"},
+			"high":         bson.M{"$max": "$probability"},
+			"low":          bson.M{"$min": "$probability"},
+			"close":        bson.M{"$last": "$probability"},
+			"volume_24h":   bson.M{"$last": "$volume_24h"},
+			"total_volume": bson.M{"$last": "$total_volume"},
+			"liquidity":    bson.M{"$last": "$liquidity"},
+			"samples":      bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":          0,
+			"market_id":    "$_id.market_id",
+			"granularity":  bson.M{"$literal": granularity},
+			"bucket_start": "$_id.bucket_start",
+			"open":         1,
+			"high":         1,
+			"low":          1,
+			"close":        1,
+			"volume_24h":   1,
+			"total_volume": 1,
+			"liquidity":    1,
+			"samples":      1,
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           "snapshot_rollups",
+			"on":             []string{"market_id", "granularity", "bucket_start"},
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+
+	cursor, err := snapshots.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("rollup %s snapshots: %w", granularity, err)
+	}
+	return cursor.Close(ctx)
+}
+
+// ============================================================================
+// ARTICLE OPERATIONS
 // ============================================================================
 
 // SaveArticle saves a new article.
@@ -305,8 +1240,14 @@ func (s *Store) SaveArticle(ctx context.Context, article *models.Article) error
 		article.PublishedAt = time.Now()
 	}
 
-	_, err := s.articles.InsertOne(ctx, article)
-	return err
+	result, err := s.articles.InsertOne(ctx, article)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		article.ID = id
+	}
+	return nil
 }
 
 // UpdateArticle updates an existing article.
@@ -318,6 +1259,58 @@ func (s *Store) UpdateArticle(ctx context.Context, article *models.Article) erro
 	return err
 }
 
+// UnpublishArticle takes an article off the site, leaving it as a draft.
+// It reports false if no article that isn't deleted has the slug.
+func (s *Store) UnpublishArticle(ctx context.Context, slug string) (bool, error) {
+	return s.setArticleStatus(ctx, slug, bson.M{"published": false})
+}
+
+// DeleteArticle soft-deletes an article: it is unpublished and no longer
+// served, but kept so it can be restored. It reports false if no article
+// that isn't deleted has the slug.
+func (s *Store) DeleteArticle(ctx context.Context, slug string) (bool, error) {
+	return s.setArticleStatus(ctx, slug, bson.M{"published": false, "deleted_at": time.Now()})
+}
+
+// RestoreArticle undoes an admin's unpublish or delete: an article that was
+// live goes back on the site, and a deleted draft goes back to being a
+// draft, so restoring never publishes a draft held by the quality gate. It
+// reports false if no article with the slug was unpublished or deleted.
+func (s *Store) RestoreArticle(ctx context.Context, slug string) (bool, error) {
+	filter := bson.M{"slug": slug, "$or": bson.A{
+		bson.M{"unpublished_at": bson.M{"$exists": true}},
+		bson.M{"deleted_at": bson.M{"$exists": true}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"published":  bson.M{"$gt": bson.A{"$unpublished_at", nil}},
+			"updated_at": time.Now(),
+		}}},
+		{{Key: "$unset", Value: bson.A{"unpublished_at", "deleted_at"}}},
+	}
+	result, err := s.articles.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// setArticleStatus sets fields on the article with the slug unless it is
+// deleted. A live article taken down is marked unpublished_at, so
+// RestoreArticle knows to put it back on the site.
+func (s *Store) setArticleStatus(ctx context.Context, slug string, fields bson.M) (bool, error) {
+	now := time.Now()
+	fields["updated_at"] = now
+	fields["unpublished_at"] = bson.M{"$cond": bson.A{"$published", now, "$unpublished_at"}}
+	update := mongo.Pipeline{{{Key: "$set", Value: fields}}}
+
+	result, err := s.articles.UpdateOne(ctx, bson.M{"slug": slug, "deleted_at": bson.M{"$exists": false}}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 // GetArticleBySlug returns an article by its slug.
 func (s *Store) GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
 	var article models.Article
@@ -338,11 +1331,182 @@ func (s *Store) GetArticleByID(ctx context.Context, id primitive.ObjectID) (*mod
 	return &article, nil
 }
 
+// GetRecentArticleForMarket returns the newest article of one of the given
+// types whose primary market is marketID and that was published after since,
+// or nil if none.
+func (s *Store) GetRecentArticleForMarket(ctx context.Context, marketID string, since time.Time, articleTypes ...models.ArticleType) (*models.Article, error) {
+	filter := bson.M{
+		"primary_market.market_id": marketID,
+		"type":                     bson.M{"$in": articleTypes},
+		"published_at":             bson.M{"$gte": since},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "published_at", Value: -1}})
+
+	var article models.Article
+	err := s.articles.FindOne(ctx, filter, opts).Decode(&article)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// GetArticlesByMarketID returns the latest limit published articles that
+// reference marketID, as primary market or otherwise, oldest first.
+func (s *Store) GetArticlesByMarketID(ctx context.Context, marketID string, limit int) ([]models.Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
+
+	filter := bson.M{"markets.market_id": marketID, "published": true}
+	articles, err := s.findArticles(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(articles)
+	return articles, nil
+}
+
+// GetArticlesByMarketIDs returns the latest limit published articles that
+// reference any of marketIDs, newest first.
+func (s *Store) GetArticlesByMarketIDs(ctx context.Context, marketIDs []string, limit int) ([]models.Article, error) {
+	if len(marketIDs) == 0 {
+		return nil, nil
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
+
+	filter := bson.M{"markets.market_id": bson.M{"$in": marketIDs}, "published": true}
+	return s.findArticles(ctx, filter, opts)
+}
+
+// AddArticleUpdate links a follow-up article to the article it follows.
+func (s *Store) AddArticleUpdate(ctx context.Context, originalID, updateID primitive.ObjectID) error {
+	filter := bson.M{"_id": originalID}
+	update := bson.M{
+		"$addToSet": bson.M{"updates": updateID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+	_, err := s.articles.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// maxArticleChain bounds how many articles GetArticleChain will walk.
+const maxArticleChain = 50
+
+// GetArticleChain returns the follow-up chain an article belongs to, oldest
+// first: its predecessors back to the original story, the article itself,
+// and every follow-up written after it.
+func (s *Store) GetArticleChain(ctx context.Context, article *models.Article) ([]models.ArticleLink, error) {
+	linkOpts := options.FindOne().SetProjection(bson.M{
+		"slug": 1, "type": 1, "headline": 1, "published_at": 1, "previous_article_id": 1,
+	})
+
+	// Walk back to the original story
+	var before []models.ArticleLink
+	prevID := article.PreviousArticleID
+	for prevID != nil && len(before) < maxArticleChain {
+		var prev struct {
+			models.ArticleLink `bson:",inline"`
+			PreviousArticleID  *primitive.ObjectID `bson:"previous_article_id"`
+		}
+		err := s.articles.FindOne(ctx, bson.M{"_id": *prevID}, linkOpts).Decode(&prev)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		before = append([]models.ArticleLink{prev.ArticleLink}, before...)
+		prevID = prev.PreviousArticleID
+	}
+
+	chain := append(before, models.ArticleLink{
+		ID:          article.ID,
+		Slug:        article.Slug,
+		Type:        article.Type,
+		Headline:    article.Headline,
+		PublishedAt: article.PublishedAt,
+		Current:     true,
+	})
+
+	// Walk forward through follow-ups, level by level
+	next := article.Updates
+	for len(next) > 0 && len(chain) < maxArticleChain {
+		cursor, err := s.articles.Find(ctx,
+			bson.M{"_id": bson.M{"$in": next}},
+			options.Find().
+				SetSort(bson.D{{Key: "published_at", Value: 1}}).
+				SetProjection(bson.M{"slug": 1, "type": 1, "headline": 1, "published_at": 1, "updates": 1}),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var level []struct {
+			models.ArticleLink `bson:",inline"`
+			Updates            []primitive.ObjectID `bson:"updates"`
+		}
+		err = cursor.All(ctx, &level)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		next = nil
+		for _, a := range level {
+			chain = append(chain, a.ArticleLink)
+			next = append(next, a.Updates...)
+		}
+	}
+
+	return chain, nil
+}
+
+// GetRelatedArticleCandidates returns up to limit published articles from
+// the last window that share a market, a tag or the category with article,
+// newest first, without their bodies.
+func (s *Store) GetRelatedArticleCandidates(ctx context.Context, article *models.Article, window time.Duration, limit int) ([]models.Article, error) {
+	match := []bson.M{{"category": article.Category}}
+	if len(article.Tags) > 0 {
+		match = append(match, bson.M{"tags": bson.M{"$in": article.Tags}})
+	}
+	marketIDs := make([]string, 0, len(article.Markets))
+	for _, m := range article.Markets {
+		marketIDs = append(marketIDs, m.MarketID)
+	}
+	if len(marketIDs) > 0 {
+		match = append(match, bson.M{"markets.market_id": bson.M{"$in": marketIDs}})
+	}
+
+	filter := bson.M{
+		"_id":          bson.M{"$ne": article.ID},
+		"published":    true,
+		"published_at": bson.M{"$gte": time.Now().Add(-window)},
+		"$or":          match,
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"body": 0, "headline_test": 0, "social_signals": 0, "fact_check": 0})
+	return s.findArticles(ctx, filter, opts)
+}
+
+// articleListProjection leaves market data blocks out of article lists;
+// they're only needed on the article page.
+var articleListProjection = bson.M{"body.market_data": 0}
+
 // GetRecentArticles returns the most recent published articles.
 func (s *Store) GetRecentArticles(ctx context.Context, limit int) ([]models.Article, error) {
 	opts := options.Find().
 		SetSort(bson.D{{Key: "published_at", Value: -1}}).
-		SetLimit(int64(limit))
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
 
 	filter := bson.M{"published": true}
 	return s.findArticles(ctx, filter, opts)
@@ -352,7 +1516,8 @@ func (s *Store) GetRecentArticles(ctx context.Context, limit int) ([]models.Arti
 func (s *Store) GetArticlesByType(ctx context.Context, articleType models.ArticleType, limit int) ([]models.Article, error) {
 	opts := options.Find().
 		SetSort(bson.D{{Key: "published_at", Value: -1}}).
-		SetLimit(int64(limit))
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
 
 	filter := bson.M{"type": articleType, "published": true}
 	return s.findArticles(ctx, filter, opts)
@@ -362,7 +1527,8 @@ func (s *Store) GetArticlesByType(ctx context.Context, articleType models.Articl
 func (s *Store) GetArticlesByCategory(ctx context.Context, category string, limit int) ([]models.Article, error) {
 	opts := options.Find().
 		SetSort(bson.D{{Key: "published_at", Value: -1}}).
-		SetLimit(int64(limit))
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
 
 	filter := bson.M{"category": category, "published": true}
 	return s.findArticles(ctx, filter, opts)
@@ -372,7 +1538,8 @@ func (s *Store) GetArticlesByCategory(ctx context.Context, category string, limi
 func (s *Store) GetFeaturedArticles(ctx context.Context, limit int) ([]models.Article, error) {
 	opts := options.Find().
 		SetSort(bson.D{{Key: "published_at", Value: -1}}).
-		SetLimit(int64(limit))
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
 
 	filter := bson.M{"featured": true, "published": true}
 	return s.findArticles(ctx, filter, opts)
@@ -385,20 +1552,60 @@ func (s *Store) GetTodayArticles(ctx context.Context) ([]models.Article, error)
 		"published_at": bson.M{"$gte": today},
 		"published":    true,
 	}
-	opts := options.Find().SetSort(bson.D{{Key: "published_at", Value: -1}})
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetProjection(articleListProjection)
 	return s.findArticles(ctx, filter, opts)
 }
 
-// IncrementArticleViews increments the view count for an article.
-func (s *Store) IncrementArticleViews(ctx context.Context, id primitive.ObjectID) error {
-	filter := bson.M{"_id": id}
-	update := bson.M{"$inc": bson.M{"views": 1}}
-	_, err := s.articles.UpdateOne(ctx, filter, update)
-	return err
+// GetPublishedArticles returns a page of published articles updated at or
+// after since (all if zero), in insertion order so pages stay stable.
+func (s *Store) GetPublishedArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error) {
+	return SiteArticles{s.articles}.GetPublishedArticles(ctx, since, skip, limit)
+}
+
+// GetTakenDownArticles returns a page of articles an admin unpublished or
+// deleted at or after since (all if zero), in insertion order.
+func (s *Store) GetTakenDownArticles(ctx context.Context, since time.Time, skip, limit int64) ([]models.Article, error) {
+	return SiteArticles{s.articles}.GetTakenDownArticles(ctx, since, skip, limit)
+}
+
+// ArticleQuery selects published articles for ListArticles. Empty fields
+// don't filter.
+type ArticleQuery struct {
+	Type     models.ArticleType
+	Category string
+
+	// UpdatedSince keeps articles changed at or after it
+	UpdatedSince time.Time
+
+	// Offset skips the first matches, for paging
+	Offset int
+	Limit  int
+}
+
+// ListArticles returns the published articles matching q, newest first,
+// with their full bodies.
+func (s *Store) ListArticles(ctx context.Context, q ArticleQuery) ([]models.Article, error) {
+	filter := bson.M{"published": true}
+	if q.Type != "" {
+		filter["type"] = q.Type
+	}
+	if q.Category != "" {
+		filter["category"] = q.Category
+	}
+	if !q.UpdatedSince.IsZero() {
+		filter["updated_at"] = bson.M{"$gte": q.UpdatedSince}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(q.Offset)).
+		SetLimit(int64(q.Limit))
+	return s.findArticles(ctx, filter, opts)
 }
 
 func (s *Store) findArticles(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Article, error) {
-	cursor, err := s.articles.Find(ctx, filter, opts)
+	cursor, err := s.articles.Find(ctx, filter, projectFields(ctx, articleFieldsKey{}, opts))
 	if err != nil {
 		return nil, err
 	}
@@ -441,120 +1648,417 @@ func (s *Store) GetCategoryBySlug(ctx context.Context, slug string) (*models.Cat
 	return &category, nil
 }
 
-// ============================================================================
-// STATS OPERATIONS
-// ============================================================================
+// ErrCategoryExists is returned when creating a category whose slug is
+// already taken.
+var ErrCategoryExists = errors.New("category already exists")
 
-// Stats holds general statistics.
-type Stats struct {
-	TotalMarkets   int64 `json:"total_markets"`
-	ActiveMarkets  int64 `json:"active_markets"`
-	TotalArticles  int64 `json:"total_articles"`
-	TodayArticles  int64 `json:"today_articles"`
-	TotalSnapshots int64 `json:"total_snapshots"`
+// CreateCategory inserts a new category keyed by its slug.
+func (s *Store) CreateCategory(ctx context.Context, category *models.Category) error {
+	category.ID = category.Slug
+	if _, err := s.categories.InsertOne(ctx, category); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrCategoryExists
+		}
+		return err
+	}
+	s.categoryCache.Invalidate()
+	return nil
 }
 
-// ============================================================================
-// SENTIMENT/MOMENTUM OPERATIONS
-// ============================================================================
+// CategoryUpdate holds the category fields to change; nil fields are left
+// as they are. Keywords, when set, replace the whole list.
+type CategoryUpdate struct {
+	Name        *string
+	Description *string
+	Icon        *string
+	Color       *string
+	Order       *int
+	Keywords    []string
+}
 
-// GetCategorySentiments calculates momentum/sentiment for each category.
-// Uses volume-weighted price changes as the primary signal.
-func (s *Store) GetCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error) {
-	// MongoDB aggregation pipeline to calculate per-category metrics
-	pipeline := mongo.Pipeline{
-		// Stage 1: Filter active, non-closed markets
-		{{Key: "$match", Value: bson.M{
-			"active": true,
-			"closed": false,
-		}}},
-		// Stage 2: Group by category
-		{{Key: "$group", Value: bson.M{
-			"_id":                "$category",
-			"total_volume_24h":   bson.M{"$sum": "$volume_24h"},
-			"market_count":       bson.M{"$sum": 1},
-			"sum_weighted_change": bson.M{"$sum": bson.M{"$multiply": []interface{}{"$change_24h", "$volume_24h"}}},
-			"avg_change":         bson.M{"$avg": "$change_24h"},
-			"markets": bson.M{"$push": bson.M{
-				"question":   "$question",
-				"slug":       "$slug",
-				"change_24h": "$change_24h",
-				"volume_24h": "$volume_24h",
-			}},
-		}}},
-		// Stage 3: Calculate momentum and sort
-		{{Key: "$project", Value: bson.M{
-			"category":         "$_id",
-			"total_volume_24h": 1,
-			"market_count":     1,
-			"avg_change":       1,
-			"momentum": bson.M{"$cond": bson.M{
-				"if":   bson.M{"$eq": []interface{}{"$total_volume_24h", 0}},
-				"then": 0,
-				"else": bson.M{"$divide": []interface{}{"$sum_weighted_change", "$total_volume_24h"}},
-			}},
-			"markets": 1,
-		}}},
-		// Stage 4: Sort by total volume
-		{{Key: "$sort", Value: bson.M{"total_volume_24h": -1}}},
+// UpdateCategory applies an update to the category with the given slug and
+// returns the result, or nil if there is no such category.
+func (s *Store) UpdateCategory(ctx context.Context, slug string, u CategoryUpdate) (*models.Category, error) {
+	set := bson.M{}
+	if u.Name != nil {
+		set["name"] = *u.Name
+	}
+	if u.Description != nil {
+		set["description"] = *u.Description
+	}
+	if u.Icon != nil {
+		set["icon"] = *u.Icon
+	}
+	if u.Color != nil {
+		set["color"] = *u.Color
+	}
+	if u.Order != nil {
+		set["order"] = *u.Order
+	}
+	if u.Keywords != nil {
+		set["keywords"] = u.Keywords
+	}
+	if len(set) == 0 {
+		category, err := s.GetCategoryBySlug(ctx, slug)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return category, err
 	}
+	return s.modifyCategory(ctx, slug, bson.M{"$set": set})
+}
 
-	cursor, err := s.markets.Aggregate(ctx, pipeline)
+// AddCategoryKeywords adds keywords to a category, skipping ones it already
+// has, and returns the result, or nil if there is no such category.
+func (s *Store) AddCategoryKeywords(ctx context.Context, slug string, keywords []string) (*models.Category, error) {
+	return s.modifyCategory(ctx, slug, bson.M{"$addToSet": bson.M{"keywords": bson.M{"$each": keywords}}})
+}
+
+// RemoveCategoryKeyword removes a keyword from a category and returns the
+// result, or nil if there is no such category.
+func (s *Store) RemoveCategoryKeyword(ctx context.Context, slug, keyword string) (*models.Category, error) {
+	return s.modifyCategory(ctx, slug, bson.M{"$pull": bson.M{"keywords": keyword}})
+}
+
+func (s *Store) modifyCategory(ctx context.Context, slug string, update bson.M) (*models.Category, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var category models.Category
+	err := s.categories.FindOneAndUpdate(ctx, bson.M{"slug": slug}, update, opts).Decode(&category)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	s.categoryCache.Invalidate()
+	return &category, nil
+}
 
-	var results []struct {
-		Category       string  `bson:"category"`
-		TotalVolume24h float64 `bson:"total_volume_24h"`
-		MarketCount    int     `bson:"market_count"`
-		Momentum       float64 `bson:"momentum"`
-		AvgChange      float64 `bson:"avg_change"`
-		Markets        []struct {
-			Question  string  `bson:"question"`
-			Slug      string  `bson:"slug"`
-			Change24h float64 `bson:"change_24h"`
-			Volume24h float64 `bson:"volume_24h"`
-		} `bson:"markets"`
+// categoryCacheTTL bounds how stale the cached taxonomy can get, including
+// edits made by other replicas.
+const categoryCacheTTL = 5 * time.Minute
+
+// CategoryCache serves the category taxonomy from the categories
+// collection, reloading it at most once per TTL. It implements
+// models.CategoryProvider; if a reload fails the previous taxonomy is kept.
+type CategoryCache struct {
+	store *Store
+	ttl   time.Duration
+
+	mu         sync.Mutex
+	categories []models.Category
+	loadedAt   time.Time
+}
+
+// CategoryCache returns the store's taxonomy cache.
+func (s *Store) CategoryCache() *CategoryCache {
+	return s.categoryCache
+}
+
+// Categories returns the cached taxonomy, ordered by Order. Callers must
+// not modify the result.
+func (c *CategoryCache) Categories() []models.Category {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Since(c.loadedAt) < c.ttl {
+		return c.categories
 	}
 
-	if err := cursor.All(ctx, &results); err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Retry after a full TTL either way so an outage doesn't stall callers
+	c.loadedAt = time.Now()
+	categories, err := c.store.GetCategories(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to reload categories, keeping cached taxonomy")
+		return c.categories
 	}
+	c.categories = categories
+	return categories
+}
 
-	// Convert to CategorySentiment with enriched data
-	sentiments := make([]models.CategorySentiment, 0, len(results))
-	for _, r := range results {
-		// Skip empty/dynamic categories
-		cat := models.GetCategoryBySlug(r.Category)
-		if cat == nil || cat.Dynamic {
-			continue
-		}
+// Invalidate makes the next Categories call reload from the database.
+func (c *CategoryCache) Invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.mu.Unlock()
+}
 
-		// Find top mover and count breaking markets
-		var topMover, topMoverSlug string
-		var topMoverChange float64
-		breakingCount := 0
-		breakingThreshold := 0.10 // 10%
+// ============================================================================
+// TAG OPERATIONS
+// ============================================================================
 
-		for _, m := range r.Markets {
-			absChange := m.Change24h
-			if absChange < 0 {
-				absChange = -absChange
-			}
-			if absChange > breakingThreshold {
-				breakingCount++
-			}
-			currentTop := topMoverChange
-			if currentTop < 0 {
-				currentTop = -currentTop
-			}
-			if absChange > currentTop {
-				topMover = m.Question
-				topMoverSlug = m.Slug
-				topMoverChange = m.Change24h
-			}
+// GetTagCounts returns the tags on published articles and open markets with
+// how often each is used, most used first. Markets count both our tags and
+// Polymarket's tag slugs.
+func (s *Store) GetTagCounts(ctx context.Context, limit int) ([]models.TagCount, error) {
+	articlePipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"published": true}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+	}
+	articleCounts, err := s.countTags(ctx, s.articles, articlePipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	marketPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"active": true, "closed": false}}},
+		// A market tagged the same by us and Polymarket counts once
+		{{Key: "$project", Value: bson.M{"tag": bson.M{"$setUnion": bson.A{
+			bson.M{"$ifNull": bson.A{"$tags", bson.A{}}},
+			bson.M{"$ifNull": bson.A{"$polymarket_tags.slug", bson.A{}}},
+		}}}}},
+		{{Key: "$unwind", Value: "$tag"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tag", "count": bson.M{"$sum": 1}}}},
+	}
+	marketCounts, err := s.countTags(ctx, s.markets, marketPipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	byTag := make(map[string]*models.TagCount, len(articleCounts)+len(marketCounts))
+	get := func(tag string) *models.TagCount {
+		if c, ok := byTag[tag]; ok {
+			return c
+		}
+		c := &models.TagCount{Tag: tag}
+		byTag[tag] = c
+		return c
+	}
+	for tag, n := range articleCounts {
+		get(tag).Articles = n
+	}
+	for tag, n := range marketCounts {
+		get(tag).Markets = n
+	}
+	counts := make([]models.TagCount, 0, len(byTag))
+	for _, c := range byTag {
+		counts = append(counts, *c)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total() != counts[j].Total() {
+			return counts[i].Total() > counts[j].Total()
+		}
+		return counts[i].Tag < counts[j].Tag
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+// countTags runs a pipeline grouping tags into {_id: tag, count} documents.
+func (s *Store) countTags(ctx context.Context, coll *mongo.Collection, pipeline mongo.Pipeline) (map[string]int64, error) {
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Tag   string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, r := range results {
+		if r.Tag != "" {
+			counts[r.Tag] = r.Count
+		}
+	}
+	return counts, nil
+}
+
+// GetArticlesByTag returns published articles carrying tag, newest first.
+func (s *Store) GetArticlesByTag(ctx context.Context, tag string, limit int) ([]models.Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(articleListProjection)
+
+	filter := bson.M{"tags": tag, "published": true}
+	return s.findArticles(ctx, filter, opts)
+}
+
+// GetMarketsByTag returns open markets carrying tag, ours or Polymarket's,
+// by 24h volume.
+func (s *Store) GetMarketsByTag(ctx context.Context, tag string, limit int) ([]models.Market, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "volume_24h", Value: -1}}).
+		SetLimit(int64(limit))
+
+	filter := bson.M{
+		"active": true,
+		"closed": false,
+		"$or": []bson.M{
+			{"tags": tag},
+			{"polymarket_tags.slug": tag},
+		},
+	}
+	return s.findMarkets(ctx, filter, opts)
+}
+
+// ============================================================================
+// STATS OPERATIONS
+// ============================================================================
+
+// Stats holds general statistics.
+type Stats struct {
+	TotalMarkets   int64 `json:"total_markets"`
+	ActiveMarkets  int64 `json:"active_markets"`
+	TotalArticles  int64 `json:"total_articles"`
+	TodayArticles  int64 `json:"today_articles"`
+	TotalSnapshots int64 `json:"total_snapshots"`
+}
+
+// ============================================================================
+// SENTIMENT/MOMENTUM OPERATIONS
+// ============================================================================
+
+// SentimentBreakingThreshold is the |24h change| above which a market counts
+// as breaking in category sentiment.
+const SentimentBreakingThreshold = 0.10
+
+// GetCategorySentiments returns the cached category sentiment, computing it
+// on the spot if the refresh job hasn't populated the cache yet.
+func (s *Store) GetCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "total_volume_24h", Value: -1}})
+	cursor, err := s.sentiment.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sentiments []models.CategorySentiment
+	if err := cursor.All(ctx, &sentiments); err != nil {
+		return nil, err
+	}
+	if len(sentiments) > 0 {
+		return sentiments, nil
+	}
+
+	return s.ComputeCategorySentiments(ctx)
+}
+
+// RefreshCategorySentiments recomputes category sentiment and replaces the
+// cached copy in the category_sentiment collection.
+func (s *Store) RefreshCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error) {
+	sentiments, err := s.ComputeCategorySentiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, 0, len(sentiments))
+	writes := make([]mongo.WriteModel, 0, len(sentiments))
+	for _, cs := range sentiments {
+		categories = append(categories, cs.Category)
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": cs.Category}).
+			SetReplacement(cs).
+			SetUpsert(true))
+	}
+
+	if len(writes) > 0 {
+		if _, err := s.sentiment.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return nil, fmt.Errorf("write category sentiment: %w", err)
+		}
+	}
+
+	// Drop categories that no longer have active markets
+	if _, err := s.sentiment.DeleteMany(ctx, bson.M{"_id": bson.M{"$nin": categories}}); err != nil {
+		return nil, fmt.Errorf("prune category sentiment: %w", err)
+	}
+
+	return sentiments, nil
+}
+
+// ComputeCategorySentiments calculates momentum/sentiment for each category.
+// Uses volume-weighted price changes as the primary signal.
+func (s *Store) ComputeCategorySentiments(ctx context.Context) ([]models.CategorySentiment, error) {
+	absChange := bson.M{"$abs": "$change_24h"}
+
+	// MongoDB aggregation pipeline to calculate per-category metrics
+	pipeline := mongo.Pipeline{
+		// Stage 1: Filter active, non-closed markets
+		{{Key: "$match", Value: bson.M{
+			"active": true,
+			"closed": false,
+		}}},
+		// Stage 2: Biggest movers first, so $first picks each category's top mover
+		{{Key: "$addFields", Value: bson.M{"abs_change": absChange}}},
+		{{Key: "$sort", Value: bson.D{{Key: "abs_change", Value: -1}}}},
+		// Stage 3: Group by category
+		{{Key: "$group", Value: bson.M{
+			"_id":                 "$category",
+			"total_volume_24h":    bson.M{"$sum": "$volume_24h"},
+			"market_count":        bson.M{"$sum": 1},
+			"sum_weighted_change": bson.M{"$sum": bson.M{"$multiply": []interface{}{"$change_24h", "$volume_24h"}}},
+			"avg_change":          bson.M{"$avg": "$change_24h"},
+			"breaking_count": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$abs_change", SentimentBreakingThreshold}}, 1, 0,
+			}}},
+			"top_mover":        bson.M{"$first": "$question"},
+			"top_mover_slug":   bson.M{"$first": "$slug"},
+			"top_mover_change": bson.M{"$first": "$change_24h"},
+		}}},
+		// Stage 4: Calculate momentum
+		{{Key: "$project", Value: bson.M{
+			"category":         "$_id",
+			"total_volume_24h": 1,
+			"market_count":     1,
+			"avg_change":       1,
+			"breaking_count":   1,
+			"top_mover":        1,
+			"top_mover_slug":   1,
+			"top_mover_change": 1,
+			"momentum": bson.M{"$cond": bson.M{
+				"if":   bson.M{"$eq": []interface{}{"$total_volume_24h", 0}},
+				"then": 0,
+				"else": bson.M{"$divide": []interface{}{"$sum_weighted_change", "$total_volume_24h"}},
+			}},
+		}}},
+		// Stage 5: Sort by total volume
+		{{Key: "$sort", Value: bson.M{"total_volume_24h": -1}}},
+	}
+
+	cursor, err := s.markets.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Category       string  `bson:"category"`
+		TotalVolume24h float64 `bson:"total_volume_24h"`
+		MarketCount    int     `bson:"market_count"`
+		Momentum       float64 `bson:"momentum"`
+		AvgChange      float64 `bson:"avg_change"`
+		BreakingCount  int     `bson:"breaking_count"`
+		TopMover       string  `bson:"top_mover"`
+		TopMoverSlug   string  `bson:"top_mover_slug"`
+		TopMoverChange float64 `bson:"top_mover_change"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	// Convert to CategorySentiment with category metadata
+	now := time.Now()
+	sentiments := make([]models.CategorySentiment, 0, len(results))
+	for _, r := range results {
+		// Skip empty/dynamic categories
+		cat := models.GetCategoryBySlug(r.Category)
+		if cat == nil || cat.Dynamic {
+			continue
 		}
 
 		sentiments = append(sentiments, models.CategorySentiment{
@@ -565,11 +2069,12 @@ func (s *Store) GetCategorySentiments(ctx context.Context) ([]models.CategorySen
 			Momentum:       r.Momentum,
 			TotalVolume24h: r.TotalVolume24h,
 			MarketCount:    r.MarketCount,
-			BreakingCount:  breakingCount,
-			TopMover:       topMover,
-			TopMoverSlug:   topMoverSlug,
-			TopMoverChange: topMoverChange,
+			BreakingCount:  r.BreakingCount,
+			TopMover:       r.TopMover,
+			TopMoverSlug:   r.TopMoverSlug,
+			TopMoverChange: r.TopMoverChange,
 			AvgChange24h:   r.AvgChange,
+			UpdatedAt:      now,
 		})
 	}
 
@@ -612,3 +2117,2065 @@ func (s *Store) GetStats(ctx context.Context) (*Stats, error) {
 
 	return stats, nil
 }
+
+// ============================================================================
+// SITEMAP OPERATIONS
+// ============================================================================
+
+// SitemapEntry is the minimum needed to list a page in a sitemap.
+type SitemapEntry struct {
+	Slug      string    `bson:"slug"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// CountSitemapArticles returns how many published articles can be listed.
+func (s *Store) CountSitemapArticles(ctx context.Context) (int64, error) {
+	return s.articles.CountDocuments(ctx, bson.M{"published": true})
+}
+
+// GetSitemapArticles returns a page of published articles in insertion order,
+// so pages stay stable as new articles are added.
+func (s *Store) GetSitemapArticles(ctx context.Context, skip, limit int64) ([]SitemapEntry, error) {
+	return s.findSitemapEntries(ctx, s.articles, bson.M{"published": true}, skip, limit)
+}
+
+// CountSitemapMarkets returns how many markets can be listed.
+func (s *Store) CountSitemapMarkets(ctx context.Context) (int64, error) {
+	return s.markets.CountDocuments(ctx, bson.M{"slug": bson.M{"$ne": ""}})
+}
+
+// GetSitemapMarkets returns a page of markets in insertion order.
+func (s *Store) GetSitemapMarkets(ctx context.Context, skip, limit int64) ([]SitemapEntry, error) {
+	return s.findSitemapEntries(ctx, s.markets, bson.M{"slug": bson.M{"$ne": ""}}, skip, limit)
+}
+
+func (s *Store) findSitemapEntries(ctx context.Context, coll *mongo.Collection, filter bson.M, skip, limit int64) ([]SitemapEntry, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit).
+		SetProjection(bson.M{"slug": 1, "updated_at": 1})
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []SitemapEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ============================================================================
+// LLM USAGE OPERATIONS
+// ============================================================================
+
+// SaveLLMUsage records token usage for a single LLM request.
+func (s *Store) SaveLLMUsage(ctx context.Context, usage *models.LLMUsage) error {
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = time.Now()
+	}
+
+	_, err := s.llmUsage.InsertOne(ctx, usage)
+	return err
+}
+
+// GetLLMUsageAggregates groups usage since the given time into day or week
+// buckets (UTC, weeks starting Monday) per article type and model.
+func (s *Store) GetLLMUsageAggregates(ctx context.Context, unit string, since time.Time) ([]models.LLMUsageAggregate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"period": bson.M{"$dateTrunc": bson.M{
+					"date":        "$created_at",
+					"unit":        unit,
+					"startOfWeek": "monday",
+				}},
+				"article_type": "$article_type",
+				"model":        "$model",
+			},
+			"requests":          bson.M{"$sum": 1},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"total_tokens":      bson.M{"$sum": "$total_tokens"},
+			"estimated_cost":    bson.M{"$sum": "$estimated_cost"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":               0,
+			"period":            "$_id.period",
+			"article_type":      "$_id.article_type",
+			"model":             "$_id.model",
+			"requests":          1,
+			"prompt_tokens":     1,
+			"completion_tokens": 1,
+			"total_tokens":      1,
+			"estimated_cost":    1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "period", Value: -1}, {Key: "estimated_cost", Value: -1}}}},
+	}
+
+	cursor, err := s.llmUsage.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.LLMUsageAggregate
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ============================================================================
+// API KEY OPERATIONS
+// ============================================================================
+
+// CreateAPIKey saves a new API key.
+func (s *Store) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	key.CreatedAt = time.Now()
+	result, err := s.apiKeys.InsertOne(ctx, key)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		key.ID = id
+	}
+	return nil
+}
+
+// GetAPIKeyByHash returns the API key with the given hash, or nil if none.
+func (s *Store) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := s.apiKeys.FindOne(ctx, bson.M{"hash": hash}).Decode(&key)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListAPIKeys returns all API keys, newest first.
+func (s *Store) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.apiKeys.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey marks an API key as revoked. It returns false if no active
+// key has that ID.
+func (s *Store) RevokeAPIKey(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+	result, err := s.apiKeys.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// TouchAPIKey records that an API key was just used.
+func (s *Store) TouchAPIKey(ctx context.Context, id primitive.ObjectID) error {
+	_, err := s.apiKeys.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": time.Now()}})
+	return err
+}
+
+// ============================================================================
+// SUBSCRIBER OPERATIONS
+// ============================================================================
+
+// CreateSubscriber saves a new newsletter subscriber.
+func (s *Store) CreateSubscriber(ctx context.Context, sub *models.Subscriber) error {
+	sub.CreatedAt = time.Now()
+	result, err := s.subscribers.InsertOne(ctx, sub)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		sub.ID = id
+	}
+	return nil
+}
+
+// GetSubscriberByEmail returns the subscriber with the given email, or nil if none.
+func (s *Store) GetSubscriberByEmail(ctx context.Context, email string) (*models.Subscriber, error) {
+	var sub models.Subscriber
+	err := s.subscribers.FindOne(ctx, bson.M{"email": email}).Decode(&sub)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ResetSubscriberConfirmation puts a subscriber back into pending with a
+// fresh confirmation token, e.g. when someone who unsubscribed signs up again.
+func (s *Store) ResetSubscriberConfirmation(ctx context.Context, id primitive.ObjectID, confirmToken string) error {
+	update := bson.M{
+		"$set":   bson.M{"status": models.SubscriberPending, "confirm_token": confirmToken},
+		"$unset": bson.M{"unsubscribed_at": ""},
+	}
+	_, err := s.subscribers.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// ConfirmSubscriber confirms the pending subscriber holding confirmToken.
+// It returns nil if no pending subscriber has that token.
+func (s *Store) ConfirmSubscriber(ctx context.Context, confirmToken string) (*models.Subscriber, error) {
+	filter := bson.M{"confirm_token": confirmToken, "status": models.SubscriberPending}
+	update := bson.M{
+		"$set":   bson.M{"status": models.SubscriberConfirmed, "confirmed_at": time.Now()},
+		"$unset": bson.M{"confirm_token": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var sub models.Subscriber
+	err := s.subscribers.FindOneAndUpdate(ctx, filter, update, opts).Decode(&sub)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// UnsubscribeSubscriber opts out the subscriber holding unsubscribeToken.
+// It returns false if no subscribed (pending or confirmed) subscriber has it.
+func (s *Store) UnsubscribeSubscriber(ctx context.Context, unsubscribeToken string) (bool, error) {
+	filter := bson.M{
+		"unsubscribe_token": unsubscribeToken,
+		"status":            bson.M{"$ne": models.SubscriberUnsubscribed},
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": models.SubscriberUnsubscribed, "unsubscribed_at": time.Now()},
+		"$unset": bson.M{"confirm_token": ""},
+	}
+	result, err := s.subscribers.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ListSubscribers returns subscribers, newest first, optionally filtered by status.
+func (s *Store) ListSubscribers(ctx context.Context, status models.SubscriberStatus, skip, limit int64) ([]models.Subscriber, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := s.subscribers.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subs []models.Subscriber
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// CountSubscribers counts subscribers, optionally filtered by status.
+func (s *Store) CountSubscribers(ctx context.Context, status models.SubscriberStatus) (int64, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return s.subscribers.CountDocuments(ctx, filter)
+}
+
+// DeleteSubscriber permanently removes a subscriber. It returns false if
+// none has that ID.
+func (s *Store) DeleteSubscriber(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := s.subscribers.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// GetNewsletterRecipients returns up to limit confirmed subscribers with an
+// ID above afterID that haven't been sent articleID yet, in ID order, so a
+// send can page through the list and resume after an interruption.
+func (s *Store) GetNewsletterRecipients(ctx context.Context, articleID, afterID primitive.ObjectID, limit int64) ([]models.Subscriber, error) {
+	filter := bson.M{
+		"status":               models.SubscriberConfirmed,
+		"_id":                  bson.M{"$gt": afterID},
+		"last_sent_article_id": bson.M{"$ne": articleID},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+
+	cursor, err := s.subscribers.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subs []models.Subscriber
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// MarkNewsletterSent records that articleID was delivered to the given subscribers.
+func (s *Store) MarkNewsletterSent(ctx context.Context, ids []primitive.ObjectID, articleID primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	update := bson.M{"$set": bson.M{"last_sent_article_id": articleID, "last_sent_at": time.Now()}}
+	_, err := s.subscribers.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, update)
+	return err
+}
+
+// ============================================================================
+// SOCIAL POST OPERATIONS
+// ============================================================================
+
+// SaveSocialPost records a post to a social platform.
+func (s *Store) SaveSocialPost(ctx context.Context, post *models.SocialPost) error {
+	if post.PostedAt.IsZero() {
+		post.PostedAt = time.Now()
+	}
+	result, err := s.socialPosts.InsertOne(ctx, post)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		post.ID = id
+	}
+	return nil
+}
+
+// HasSocialPost reports whether the article was already posted to platform,
+// or its market was posted to platform after since.
+func (s *Store) HasSocialPost(ctx context.Context, platform string, articleID primitive.ObjectID, marketID string, since time.Time) (bool, error) {
+	or := bson.A{bson.M{"article_id": articleID}}
+	if marketID != "" {
+		or = append(or, bson.M{"market_id": marketID, "posted_at": bson.M{"$gte": since}})
+	}
+	filter := bson.M{"platform": platform, "$or": or}
+
+	count, err := s.socialPosts.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetSocialPostTimes returns when posts were made to platform after since,
+// oldest first.
+func (s *Store) GetSocialPostTimes(ctx context.Context, platform string, since time.Time) ([]time.Time, error) {
+	filter := bson.M{"platform": platform, "posted_at": bson.M{"$gte": since}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "posted_at", Value: 1}}).
+		SetProjection(bson.M{"posted_at": 1})
+
+	cursor, err := s.socialPosts.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []models.SocialPost
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, len(posts))
+	for i, p := range posts {
+		times[i] = p.PostedAt
+	}
+	return times, nil
+}
+
+// ============================================================================
+// SOCIAL SIGNAL OPERATIONS
+// ============================================================================
+
+// SaveSocialSignals upserts signals by post, so a post found again updates
+// its signal rather than adding another, and adds to the markets and market
+// movements it is correlated with.
+func (s *Store) SaveSocialSignals(ctx context.Context, signals []models.StoredSocialSignal) (*BulkResult, error) {
+	if len(signals) == 0 {
+		return &BulkResult{}, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(signals))
+	for _, signal := range signals {
+		// $each needs arrays
+		marketIDs := signal.MarketIDs
+		if marketIDs == nil {
+			marketIDs = []string{}
+		}
+		movements := signal.AffectedMarkets
+		if movements == nil {
+			movements = []models.MarketMovement{}
+		}
+		set := signal.SocialSignal
+		set.AffectedMarkets = nil // Added to below, not replaced
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"tweet_url": signal.TweetURL}).
+			SetUpdate(bson.M{
+				"$set": set,
+				"$addToSet": bson.M{
+					"market_ids":       bson.M{"$each": marketIDs},
+					"affected_markets": bson.M{"$each": movements},
+				},
+				"$currentDate": bson.M{"updated_at": true},
+				"$setOnInsert": bson.M{"first_seen_at": now},
+			}).
+			SetUpsert(true))
+	}
+
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := s.signals.BulkWrite(ctx, writes, opts)
+
+	result := &BulkResult{}
+	if res != nil {
+		result.Matched = res.MatchedCount
+		result.Modified = res.ModifiedCount
+		result.Upserted = res.UpsertedCount
+	}
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			result.Failed = len(bulkErr.WriteErrors)
+		} else {
+			result.Failed = len(signals)
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// GetRecentSocialSignals returns signals posted within since, newest first.
+func (s *Store) GetRecentSocialSignals(ctx context.Context, since time.Duration, limit int) ([]models.StoredSocialSignal, error) {
+	filter := bson.M{"posted_at": bson.M{"$gte": time.Now().Add(-since)}}
+	return s.findSocialSignals(ctx, filter, limit)
+}
+
+// GetMarketSocialSignals returns the latest signals correlated with a
+// market, newest first.
+func (s *Store) GetMarketSocialSignals(ctx context.Context, marketID string, limit int) ([]models.StoredSocialSignal, error) {
+	return s.findSocialSignals(ctx, bson.M{"market_ids": marketID}, limit)
+}
+
+func (s *Store) findSocialSignals(ctx context.Context, filter bson.M, limit int) ([]models.StoredSocialSignal, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "posted_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.signals.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var signals []models.StoredSocialSignal
+	if err := cursor.All(ctx, &signals); err != nil {
+		return nil, err
+	}
+	return signals, nil
+}
+
+// ============================================================================
+// CORRELATION OPERATIONS
+// ============================================================================
+
+// SaveCorrelations upserts the given pairs and removes pairs from earlier
+// runs that no longer clear the threshold. Every pair must carry computedAt.
+func (s *Store) SaveCorrelations(ctx context.Context, correlations []models.MarketCorrelation, computedAt time.Time) error {
+	if len(correlations) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(correlations))
+		for _, c := range correlations {
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"market_a": c.MarketA, "market_b": c.MarketB}).
+				SetReplacement(c).
+				SetUpsert(true))
+		}
+		if _, err := s.correlations.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("write correlations: %w", err)
+		}
+	}
+
+	if _, err := s.correlations.DeleteMany(ctx, bson.M{"computed_at": bson.M{"$lt": computedAt}}); err != nil {
+		return fmt.Errorf("prune correlations: %w", err)
+	}
+	return nil
+}
+
+// GetCorrelationsForMarket returns the strongest correlations involving a market.
+func (s *Store) GetCorrelationsForMarket(ctx context.Context, marketID string, limit int) ([]models.MarketCorrelation, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"market_a": marketID},
+		bson.M{"market_b": marketID},
+	}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "strength", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.correlations.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var correlations []models.MarketCorrelation
+	if err := cursor.All(ctx, &correlations); err != nil {
+		return nil, err
+	}
+	return correlations, nil
+}
+
+// GetRelatedMarkets returns active markets correlated with marketID,
+// strongest first.
+func (s *Store) GetRelatedMarkets(ctx context.Context, marketID string, limit int) ([]models.RelatedMarket, error) {
+	correlations, err := s.GetCorrelationsForMarket(ctx, marketID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(correlations))
+	for i := range correlations {
+		ids[i] = correlations[i].Other(marketID)
+	}
+	markets, err := s.GetMarketsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Market, len(markets))
+	for i := range markets {
+		byID[markets[i].MarketID] = &markets[i]
+	}
+
+	related := make([]models.RelatedMarket, 0, len(correlations))
+	for _, c := range correlations {
+		m, ok := byID[c.Other(marketID)]
+		if !ok || !m.Active || m.Closed {
+			continue
+		}
+		related = append(related, models.RelatedMarket{
+			Market:      m,
+			Correlation: c.Correlation,
+			Samples:     c.Samples,
+			SharedTags:  c.SharedTags,
+		})
+	}
+	return related, nil
+}
+
+// ============================================================================
+// ACCURACY OPERATIONS
+// ============================================================================
+
+// SaveAccuracyScores replaces the stored scores with the given set.
+func (s *Store) SaveAccuracyScores(ctx context.Context, scores []models.AccuracyScore) error {
+	ids := make([]string, 0, len(scores))
+	writes := make([]mongo.WriteModel, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.ID)
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": score.ID}).
+			SetReplacement(score).
+			SetUpsert(true))
+	}
+
+	if len(writes) > 0 {
+		if _, err := s.accuracy.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("write accuracy scores: %w", err)
+		}
+	}
+
+	// Drop categories/horizons that no longer have enough resolved markets
+	if _, err := s.accuracy.DeleteMany(ctx, bson.M{"_id": bson.M{"$nin": ids}}); err != nil {
+		return fmt.Errorf("prune accuracy scores: %w", err)
+	}
+	return nil
+}
+
+// GetAccuracyScores returns stored accuracy scores, optionally filtered by
+// category and horizon, ordered by category then horizon.
+func (s *Store) GetAccuracyScores(ctx context.Context, category, horizon string) ([]models.AccuracyScore, error) {
+	filter := bson.M{}
+	if category != "" {
+		filter["category"] = category
+	}
+	if horizon != "" {
+		filter["horizon"] = horizon
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "category", Value: 1}, {Key: "horizon_hours", Value: 1}})
+
+	cursor, err := s.accuracy.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scores []models.AccuracyScore
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// ============================================================================
+// EMBEDDING OPERATIONS
+// ============================================================================
+
+// GetMarketEmbedding returns the cached question embedding for a market, or
+// nil if there is none.
+func (s *Store) GetMarketEmbedding(ctx context.Context, marketID string) (*models.MarketEmbedding, error) {
+	var embedding models.MarketEmbedding
+	err := s.embeddings.FindOne(ctx, bson.M{"_id": marketID}).Decode(&embedding)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &embedding, nil
+}
+
+// SaveMarketEmbedding stores a market's question embedding, replacing any
+// previous one.
+func (s *Store) SaveMarketEmbedding(ctx context.Context, embedding *models.MarketEmbedding) error {
+	_, err := s.embeddings.ReplaceOne(ctx,
+		bson.M{"_id": embedding.MarketID},
+		embedding,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// ============================================================================
+// ENRICHMENT CACHE OPERATIONS
+// ============================================================================
+
+// GetEnrichmentCache returns an unexpired enrichment cache entry, or nil if
+// there is none. The TTL monitor runs about once a minute, so expiry is
+// also checked here.
+func (s *Store) GetEnrichmentCache(ctx context.Context, key string) (*models.EnrichmentCacheEntry, error) {
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var entry models.EnrichmentCacheEntry
+	err := s.enrichment.FindOne(ctx, filter).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SaveEnrichmentCache stores an enrichment cache entry, replacing any
+// previous one with the same key.
+func (s *Store) SaveEnrichmentCache(ctx context.Context, entry *models.EnrichmentCacheEntry) error {
+	_, err := s.enrichment.ReplaceOne(ctx,
+		bson.M{"_id": entry.Key},
+		entry,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// ============================================================================
+// EVENT OPERATIONS
+// ============================================================================
+
+// SaveEvent persists a market event and sets its ID.
+func (s *Store) SaveEvent(ctx context.Context, event *models.StoredEvent) error {
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	_, err := s.events.InsertOne(ctx, event)
+	return err
+}
+
+// GetEventsAfter returns up to limit events emitted after the given ID,
+// oldest first. A zero ID returns the oldest retained events.
+func (s *Store) GetEventsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]models.StoredEvent, error) {
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.events.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.StoredEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetRecentEvents returns the most recent events, newest first, optionally
+// of one type.
+func (s *Store) GetRecentEvents(ctx context.Context, eventType string, limit int) ([]models.StoredEvent, error) {
+	filter := bson.M{}
+	if eventType != "" {
+		filter["type"] = eventType
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.events.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.StoredEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetMarketEvents returns the events of the given types emitted for a
+// market since the given time, oldest first, without the market documents
+// they carry.
+func (s *Store) GetMarketEvents(ctx context.Context, marketID string, since time.Time, eventTypes []string) ([]models.StoredEvent, error) {
+	filter := bson.M{
+		"market_id": marketID,
+		"type":      bson.M{"$in": eventTypes},
+		"_id":       bson.M{"$gte": primitive.NewObjectIDFromTimestamp(since)},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"market": 0, "previous": 0})
+
+	cursor, err := s.events.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.StoredEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CountEventsAfter counts events emitted after the given ID.
+func (s *Store) CountEventsAfter(ctx context.Context, after primitive.ObjectID) (int64, error) {
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	return s.events.CountDocuments(ctx, filter)
+}
+
+// GetEventOffset returns a subscriber's offset, or nil if it has never
+// acknowledged an event.
+func (s *Store) GetEventOffset(ctx context.Context, subscriber string) (*models.EventOffset, error) {
+	var offset models.EventOffset
+	err := s.eventOffsets.FindOne(ctx, bson.M{"_id": subscriber}).Decode(&offset)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offset, nil
+}
+
+// SaveEventOffset stores a subscriber's offset, replacing the previous one.
+func (s *Store) SaveEventOffset(ctx context.Context, offset *models.EventOffset) error {
+	offset.UpdatedAt = time.Now()
+	_, err := s.eventOffsets.ReplaceOne(ctx, bson.M{"_id": offset.Subscriber}, offset, options.Replace().SetUpsert(true))
+	return err
+}
+
+// ============================================================================
+// WATCHLIST OPERATIONS
+// ============================================================================
+
+// ErrWatchlistLimit is returned when an owner already has the maximum number
+// of watchlists.
+var ErrWatchlistLimit = errors.New("watchlist limit reached")
+
+// CreateWatchlist saves a new watchlist and sets its ID.
+func (s *Store) CreateWatchlist(ctx context.Context, list *models.Watchlist) error {
+	count, err := s.watchlists.CountDocuments(ctx, bson.M{"owner": list.Owner})
+	if err != nil {
+		return err
+	}
+	if count >= models.MaxWatchlistsPerOwner {
+		return ErrWatchlistLimit
+	}
+
+	now := time.Now()
+	list.ID = primitive.NewObjectID()
+	list.CreatedAt = now
+	list.UpdatedAt = now
+	if list.MarketIDs == nil {
+		list.MarketIDs = []string{}
+	}
+	_, err = s.watchlists.InsertOne(ctx, list)
+	return err
+}
+
+// GetWatchlists returns an owner's watchlists, oldest first.
+func (s *Store) GetWatchlists(ctx context.Context, owner string) ([]models.Watchlist, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := s.watchlists.Find(ctx, bson.M{"owner": owner}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	lists := []models.Watchlist{}
+	if err := cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// GetWatchlist returns one of an owner's watchlists, or nil if there is no
+// such watchlist.
+func (s *Store) GetWatchlist(ctx context.Context, owner string, id primitive.ObjectID) (*models.Watchlist, error) {
+	var list models.Watchlist
+	err := s.watchlists.FindOne(ctx, bson.M{"_id": id, "owner": owner}).Decode(&list)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// DeleteWatchlist removes one of an owner's watchlists and reports whether
+// it existed.
+func (s *Store) DeleteWatchlist(ctx context.Context, owner string, id primitive.ObjectID) (bool, error) {
+	res, err := s.watchlists.DeleteOne(ctx, bson.M{"_id": id, "owner": owner})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// AddWatchlistMarket adds a market to a watchlist that isn't full and
+// returns the result, or nil if there is no such watchlist or it is full.
+func (s *Store) AddWatchlistMarket(ctx context.Context, owner string, id primitive.ObjectID, marketID string) (*models.Watchlist, error) {
+	filter := bson.M{
+		"_id":   id,
+		"owner": owner,
+		"$or": []bson.M{
+			{"market_ids": marketID},
+			{fmt.Sprintf("market_ids.%d", models.MaxMarketsPerWatchlist-1): bson.M{"$exists": false}},
+		},
+	}
+	return s.modifyWatchlist(ctx, filter, bson.M{"$addToSet": bson.M{"market_ids": marketID}})
+}
+
+// RemoveWatchlistMarket removes a market from a watchlist and returns the
+// result, or nil if there is no such watchlist.
+func (s *Store) RemoveWatchlistMarket(ctx context.Context, owner string, id primitive.ObjectID, marketID string) (*models.Watchlist, error) {
+	filter := bson.M{"_id": id, "owner": owner}
+	return s.modifyWatchlist(ctx, filter, bson.M{"$pull": bson.M{"market_ids": marketID}})
+}
+
+// SetWatchlistAlerts replaces a watchlist's alert settings and returns the
+// result, or nil if there is no such watchlist.
+func (s *Store) SetWatchlistAlerts(ctx context.Context, owner string, id primitive.ObjectID, alerts models.WatchlistAlerts) (*models.Watchlist, error) {
+	filter := bson.M{"_id": id, "owner": owner}
+	return s.modifyWatchlist(ctx, filter, bson.M{"$set": bson.M{"alerts": alerts}})
+}
+
+func (s *Store) modifyWatchlist(ctx context.Context, filter, update bson.M) (*models.Watchlist, error) {
+	if set, ok := update["$set"].(bson.M); ok {
+		set["updated_at"] = time.Now()
+	} else {
+		update["$set"] = bson.M{"updated_at": time.Now()}
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var list models.Watchlist
+	err := s.watchlists.FindOneAndUpdate(ctx, filter, update, opts).Decode(&list)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetAlertWatchlists returns the watchlists with alerts enabled that
+// contain the market.
+func (s *Store) GetAlertWatchlists(ctx context.Context, marketID string) ([]models.Watchlist, error) {
+	cursor, err := s.watchlists.Find(ctx, bson.M{
+		"market_ids":     marketID,
+		"alerts.enabled": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var lists []models.Watchlist
+	if err := cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// ============================================================================
+// USER OPERATIONS
+// ============================================================================
+
+// GetUserByID returns a user, or nil if there is no such user.
+func (s *Store) GetUserByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+	var user models.User
+	err := s.users.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpsertUserLogin records a sign-in for email, creating the account on
+// first sign-in, and returns the user. Profile fields that are empty are
+// left unchanged.
+func (s *Store) UpsertUserLogin(ctx context.Context, email, provider string, profile models.User) (*models.User, error) {
+	now := time.Now()
+	set := bson.M{"last_login_at": now}
+	if profile.Name != "" {
+		set["name"] = profile.Name
+	}
+	if profile.Picture != "" {
+		set["picture"] = profile.Picture
+	}
+	if profile.GoogleID != "" {
+		set["google_id"] = profile.GoogleID
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var user models.User
+	err := s.users.FindOneAndUpdate(ctx,
+		bson.M{"email": email},
+		bson.M{
+			"$set":         set,
+			"$addToSet":    bson.M{"providers": provider},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		opts,
+	).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SaveLoginToken stores a magic link token.
+func (s *Store) SaveLoginToken(ctx context.Context, token *models.LoginToken) error {
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+	_, err := s.loginTokens.InsertOne(ctx, token)
+	return err
+}
+
+// ConsumeLoginToken marks an unexpired, unused login token as used and
+// returns it, or nil if there is no such token.
+func (s *Store) ConsumeLoginToken(ctx context.Context, hash string) (*models.LoginToken, error) {
+	now := time.Now()
+	var token models.LoginToken
+	err := s.loginTokens.FindOneAndUpdate(ctx,
+		bson.M{
+			"hash":       hash,
+			"used_at":    bson.M{"$exists": false},
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{"used_at": now}},
+	).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ============================================================================
+// NOTIFICATION PREFERENCE OPERATIONS
+// ============================================================================
+
+// GetNotificationPreferences returns a user's preferences, or nil if they
+// haven't set any.
+func (s *Store) GetNotificationPreferences(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	err := s.preferences.FindOne(ctx, bson.M{"_id": userID}).Decode(&prefs)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SaveNotificationPreferences replaces a user's preferences.
+func (s *Store) SaveNotificationPreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	prefs.UpdatedAt = time.Now()
+	if prefs.Categories == nil {
+		prefs.Categories = []string{}
+	}
+	_, err := s.preferences.ReplaceOne(ctx,
+		bson.M{"_id": prefs.UserID},
+		prefs,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// GetArticleRecipients returns the preferences of users interested in a
+// category: those who listed it and those who listed none.
+func (s *Store) GetArticleRecipients(ctx context.Context, category string) ([]models.NotificationPreferences, error) {
+	cursor, err := s.preferences.Find(ctx, bson.M{"$or": []bson.M{
+		{"categories": category},
+		{"categories": bson.M{"$size": 0}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prefs []models.NotificationPreferences
+	if err := cursor.All(ctx, &prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// ============================================================================
+// ARTICLE VIEW OPERATIONS
+// ============================================================================
+
+// RecordArticleView records that a user read an article.
+func (s *Store) RecordArticleView(ctx context.Context, userID primitive.ObjectID, article *models.Article) error {
+	_, err := s.views.UpdateOne(ctx,
+		bson.M{"user_id": userID, "article_id": article.ID},
+		bson.M{"$set": bson.M{
+			"category":  article.Category,
+			"viewed_at": time.Now(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// GetArticleViews returns a user's most recent article views.
+func (s *Store) GetArticleViews(ctx context.Context, userID primitive.ObjectID, limit int) ([]models.ArticleView, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "viewed_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.views.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var views []models.ArticleView
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// ============================================================================
+// ANALYTICS OPERATIONS
+// ============================================================================
+
+// InsertAnalyticsEvents saves a batch of reader interactions and adds the
+// views among them to their articles' view counts.
+func (s *Store) InsertAnalyticsEvents(ctx context.Context, events []models.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(events))
+	views := make(map[primitive.ObjectID]int)
+	for i, e := range events {
+		docs[i] = e
+		if e.Type == models.AnalyticsView {
+			views[e.ArticleID]++
+		}
+	}
+	if _, err := s.analytics.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false)); err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(views))
+	for id, n := range views {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$inc": bson.M{"views": n}}))
+	}
+	_, err := s.articles.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// RollupAnalytics aggregates analytics events recorded at or after from
+// into per-article daily totals (views, read-depth pings and their average,
+// read-throughs and outbound clicks) and merges them into analytics_daily.
+// Read-throughs count reader sessions that reached ReadThroughDepth, not
+// pings, since one reader sends a ping per depth mark. Days are recomputed
+// in full, so from should be the start of a day; re-running over the same
+// range is safe.
+func (s *Store) RollupAnalytics(ctx context.Context, from time.Time) error {
+	countOf := func(t models.AnalyticsEventType) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", t}}, 1, 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": from}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"article_id": "$article_id",
+				"date": bson.M{"$dateTrunc": bson.M{
+					"date": "$created_at",
+					"unit": "day",
+				}},
+			},
+			"slug":        bson.M{"$last": "$slug"},
+			"category":    bson.M{"$last": "$category"},
+			"views":       countOf(models.AnalyticsView),
+			"depth_pings": countOf(models.AnalyticsReadDepth),
+			"avg_depth": bson.M{"$avg": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$type", models.AnalyticsReadDepth}}, "$depth", nil,
+			}}},
+			// Pings recorded before sessions were tracked count alone
+			"read_through_sessions": bson.M{"$addToSet": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$type", models.AnalyticsReadDepth}},
+					bson.M{"$gte": bson.A{"$depth", models.ReadThroughDepth}},
+				}}, bson.M{"$ifNull": bson.A{"$session", "$_id"}}, nil,
+			}}},
+			"clicks": countOf(models.AnalyticsOutboundClick),
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":           0,
+			"article_id":    "$_id.article_id",
+			"date":          "$_id.date",
+			"slug":          1,
+			"category":      1,
+			"views":         1,
+			"depth_pings":   1,
+			"avg_depth":     bson.M{"$ifNull": bson.A{"$avg_depth", 0}},
+			"read_throughs": bson.M{"$size": bson.M{"$setDifference": bson.A{"$read_through_sessions", bson.A{nil}}}},
+			"clicks":        1,
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           "analytics_daily",
+			"on":             []string{"article_id", "date"},
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+
+	cursor, err := s.analytics.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("rollup analytics: %w", err)
+	}
+	return cursor.Close(ctx)
+}
+
+// GetTopArticleAnalytics returns the most viewed articles in the daily
+// rollups since the given day.
+func (s *Store) GetTopArticleAnalytics(ctx context.Context, since time.Time, limit int) ([]models.ArticleAnalytics, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"date": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$article_id",
+			"slug":          bson.M{"$last": "$slug"},
+			"category":      bson.M{"$last": "$category"},
+			"views":         bson.M{"$sum": "$views"},
+			"read_throughs": bson.M{"$sum": "$read_throughs"},
+			"clicks":        bson.M{"$sum": "$clicks"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "views", Value: -1}, {Key: "clicks", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := s.analyticsDay.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var top []models.ArticleAnalytics
+	if err := cursor.All(ctx, &top); err != nil {
+		return nil, err
+	}
+	for i := range top {
+		if top[i].Views > 0 {
+			top[i].CTR = float64(top[i].Clicks) / float64(top[i].Views)
+		}
+	}
+	return top, nil
+}
+
+// GetCategoryTraffic returns views and outbound clicks per category in the
+// daily rollups since the given day, busiest first.
+func (s *Store) GetCategoryTraffic(ctx context.Context, since time.Time) ([]models.CategoryTraffic, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"date": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$category",
+			"views":    bson.M{"$sum": "$views"},
+			"clicks":   bson.M{"$sum": "$clicks"},
+			"articles": bson.M{"$addToSet": "$article_id"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"views":    1,
+			"clicks":   1,
+			"articles": bson.M{"$size": "$articles"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "views", Value: -1}}}},
+	}
+
+	cursor, err := s.analyticsDay.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var traffic []models.CategoryTraffic
+	if err := cursor.All(ctx, &traffic); err != nil {
+		return nil, err
+	}
+	for i := range traffic {
+		if traffic[i].Views > 0 {
+			traffic[i].CTR = float64(traffic[i].Clicks) / float64(traffic[i].Views)
+		}
+	}
+	return traffic, nil
+}
+
+// GetDailyTraffic returns site-wide totals per day since the given day,
+// oldest first.
+func (s *Store) GetDailyTraffic(ctx context.Context, since time.Time) ([]models.DailyTraffic, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"date": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$date",
+			"views":         bson.M{"$sum": "$views"},
+			"read_throughs": bson.M{"$sum": "$read_throughs"},
+			"clicks":        bson.M{"$sum": "$clicks"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := s.analyticsDay.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var days []models.DailyTraffic
+	if err := cursor.All(ctx, &days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// ============================================================================
+// HEADLINE TEST OPERATIONS
+// ============================================================================
+
+// GetHeadlineTests returns articles with headline tests, newest first:
+// running tests, or decided ones when decided is set.
+func (s *Store) GetHeadlineTests(ctx context.Context, decided bool, limit int) ([]models.Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "headline_test.started_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"body": 0})
+
+	filter := bson.M{
+		"headline_test":            bson.M{"$exists": true},
+		"headline_test.decided_at": bson.M{"$exists": decided},
+	}
+	return s.findArticles(ctx, filter, opts)
+}
+
+// GetHeadlineVariantCounts counts impressions and clicks (views) per
+// headline variant for the given articles.
+func (s *Store) GetHeadlineVariantCounts(ctx context.Context, articleIDs []primitive.ObjectID) ([]models.HeadlineVariantCount, error) {
+	if len(articleIDs) == 0 {
+		return nil, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"article_id": bson.M{"$in": articleIDs},
+			"variant":    bson.M{"$gt": 0},
+			"type":       bson.M{"$in": bson.A{models.AnalyticsImpression, models.AnalyticsView}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"article_id": "$article_id", "variant": "$variant"},
+			"impressions": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$type", models.AnalyticsImpression}}, 1, 0,
+			}}},
+			"clicks": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$type", models.AnalyticsView}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"article_id":  "$_id.article_id",
+			"variant":     "$_id.variant",
+			"impressions": 1,
+			"clicks":      1,
+		}}},
+	}
+
+	cursor, err := s.analytics.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []models.HeadlineVariantCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SaveHeadlineTest stores an article's headline test and, once a winner is
+// promoted, the winning headline.
+func (s *Store) SaveHeadlineTest(ctx context.Context, id primitive.ObjectID, test *models.HeadlineTest) error {
+	set := bson.M{"headline_test": test}
+	if test.Winner != nil {
+		headline := test.Variants[*test.Winner].Headline
+		set["headline"] = headline
+		set["meta_title"] = headline
+		set["updated_at"] = time.Now()
+	}
+	_, err := s.articles.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+// ============================================================================
+// ASSET OPERATIONS
+// ============================================================================
+
+// RegisterAssets records source URLs for the asset proxy. URLs already
+// registered are left untouched.
+func (s *Store) RegisterAssets(ctx context.Context, urls []string) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(urls))
+	for _, url := range urls {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": models.AssetHash(url)}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{"url": url, "created_at": now}}).
+			SetUpsert(true))
+	}
+	_, err := s.assets.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// GetAsset returns the asset with the given hash, or nil if none is
+// registered.
+func (s *Store) GetAsset(ctx context.Context, hash string) (*models.Asset, error) {
+	var asset models.Asset
+	err := s.assets.FindOne(ctx, bson.M{"_id": hash}).Decode(&asset)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// ============================================================================
+// LOCK OPERATIONS
+// ============================================================================
+
+// AcquireLock takes the named lock for owner until ttl from now. It succeeds
+// when the lock is new, expired or already owner's, and never once the lock
+// is completed. previous is the lock as it was before the call (nil if it
+// didn't exist), or its current holder when acquisition fails.
+func (s *Store) AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (previous *models.Lock, acquired bool, err error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id":          name,
+		"completed_at": bson.M{"$exists": false},
+		"$or": []bson.M{
+			{"owner": owner},
+			{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"owner":       owner,
+		"acquired_at": now,
+		"expires_at":  now.Add(ttl),
+	}}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	var lock models.Lock
+	err = s.locks.FindOneAndUpdate(ctx, filter, update, opts).Decode(&lock)
+	switch {
+	case err == nil:
+		return &lock, true, nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// Upserted: nobody held it before
+		return nil, true, nil
+	case mongo.IsDuplicateKeyError(err):
+		// The upsert collided with a live or completed lock
+		current, err := s.GetLock(ctx, name)
+		return current, false, err
+	default:
+		return nil, false, err
+	}
+}
+
+// RenewLock extends owner's hold on the named lock to ttl from now. It
+// reports false if owner no longer holds it.
+func (s *Store) RenewLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	filter := bson.M{"_id": name, "owner": owner, "completed_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"expires_at": time.Now().Add(ttl)}}
+	result, err := s.locks.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// CompleteLock marks owner's work under the named lock as done, so the lock
+// can't be acquired again.
+func (s *Store) CompleteLock(ctx context.Context, name, owner string) error {
+	now := time.Now()
+	filter := bson.M{"_id": name, "owner": owner}
+	update := bson.M{"$set": bson.M{"completed_at": now, "expires_at": now}}
+	_, err := s.locks.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// GetLock returns the named lock, or nil if it doesn't exist.
+func (s *Store) GetLock(ctx context.Context, name string) (*models.Lock, error) {
+	var lock models.Lock
+	err := s.locks.FindOne(ctx, bson.M{"_id": name}).Decode(&lock)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// ============================================================================
+// JOB RUN OPERATIONS
+// ============================================================================
+
+// SaveJobRun records a scheduler job attempt.
+func (s *Store) SaveJobRun(ctx context.Context, run *models.JobRun) error {
+	if run.ID.IsZero() {
+		run.ID = primitive.NewObjectID()
+	}
+	_, err := s.jobRuns.InsertOne(ctx, run)
+	return err
+}
+
+// GetJobRuns returns a job's most recent attempts, newest first, optionally
+// only those with the given status.
+func (s *Store) GetJobRuns(ctx context.Context, job string, status models.JobRunStatus, limit int) ([]models.JobRun, error) {
+	filter := bson.M{"job": job}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.jobRuns.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []models.JobRun
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// ============================================================================
+// CONTENT CALENDAR OPERATIONS
+// ============================================================================
+
+// CreateScheduledContent adds a pending entry to the content calendar.
+func (s *Store) CreateScheduledContent(ctx context.Context, item *models.ScheduledContent) error {
+	now := time.Now()
+	item.ID = primitive.NewObjectID()
+	item.Status = models.ScheduledPending
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	_, err := s.calendar.InsertOne(ctx, item)
+	return err
+}
+
+// GetScheduledContent returns a calendar entry, or nil if there is none.
+func (s *Store) GetScheduledContent(ctx context.Context, id primitive.ObjectID) (*models.ScheduledContent, error) {
+	var item models.ScheduledContent
+	err := s.calendar.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListScheduledContent returns calendar entries running between from and to
+// (either may be zero for no bound), optionally with one status, in run
+// order.
+func (s *Store) ListScheduledContent(ctx context.Context, status models.ScheduledContentStatus, from, to time.Time, limit int) ([]models.ScheduledContent, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	runAt := bson.M{}
+	if !from.IsZero() {
+		runAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		runAt["$lt"] = to
+	}
+	if len(runAt) > 0 {
+		filter["run_at"] = runAt
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.calendar.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []models.ScheduledContent
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateScheduledContent replaces the editable fields of a pending calendar
+// entry and returns the result, or nil if the entry isn't pending (anymore).
+func (s *Store) UpdateScheduledContent(ctx context.Context, item *models.ScheduledContent) (*models.ScheduledContent, error) {
+	filter := bson.M{"_id": item.ID, "status": models.ScheduledPending}
+	update := bson.M{"$set": bson.M{
+		"type":          item.Type,
+		"market_slug":   item.MarketSlug,
+		"category":      item.Category,
+		"briefing_type": item.BriefingType,
+		"notes":         item.Notes,
+		"run_at":        item.RunAt,
+		"updated_at":    time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.ScheduledContent
+	err := s.calendar.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// CancelScheduledContent cancels a pending calendar entry. It reports false
+// if the entry isn't pending.
+func (s *Store) CancelScheduledContent(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "status": models.ScheduledPending}
+	update := bson.M{"$set": bson.M{"status": models.ScheduledCanceled, "updated_at": time.Now()}}
+	result, err := s.calendar.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ClaimScheduledContent marks the earliest due calendar entry running and
+// returns it, or nil if none is due. Entries left running for longer than
+// stale, by an instance that died mid-generation, are claimed again.
+func (s *Store) ClaimScheduledContent(ctx context.Context, stale time.Duration) (*models.ScheduledContent, error) {
+	now := time.Now()
+	filter := bson.M{
+		"run_at": bson.M{"$lte": now},
+		"$or": []bson.M{
+			{"status": models.ScheduledPending},
+			{"status": models.ScheduledRunning, "started_at": bson.M{"$lt": now.Add(-stale)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":     models.ScheduledRunning,
+		"started_at": now,
+		"updated_at": now,
+	}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var item models.ScheduledContent
+	err := s.calendar.FindOneAndUpdate(ctx, filter, update, opts).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FinishScheduledContent records the outcome of a running calendar entry:
+// the generated article's slug, or the error it failed with.
+func (s *Store) FinishScheduledContent(ctx context.Context, id primitive.ObjectID, articleSlug string, genErr error) error {
+	now := time.Now()
+	set := bson.M{
+		"status":      models.ScheduledDone,
+		"finished_at": now,
+		"updated_at":  now,
+	}
+	if genErr != nil {
+		set["status"] = models.ScheduledFailed
+		set["error"] = genErr.Error()
+	} else {
+		set["article_slug"] = articleSlug
+	}
+	_, err := s.calendar.UpdateOne(ctx, bson.M{"_id": id, "status": models.ScheduledRunning}, bson.M{"$set": set})
+	return err
+}
+
+// ============================================================================
+// EXPLAINER TOPIC OPERATIONS
+// ============================================================================
+
+// ErrExplainerTopicExists is returned when queuing an explainer topic whose
+// ID is already taken.
+var ErrExplainerTopicExists = errors.New("explainer topic already exists")
+
+// initExplainerTopics queues the default explainer topics into an empty
+// collection. Once any topic is stored the collection is left alone, so
+// deleted defaults stay deleted.
+func (s *Store) initExplainerTopics(ctx context.Context) error {
+	count, err := s.explainers.CountDocuments(ctx, bson.M{})
+	if err != nil || count > 0 {
+		return err
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(models.DefaultExplainerTopics))
+	for _, topic := range models.DefaultExplainerTopics {
+		topic.ID = models.TagSlug(topic.Title)
+		topic.Status = models.ExplainerQueued
+		topic.CreatedAt = now
+		topic.UpdatedAt = now
+		docs = append(docs, topic)
+	}
+	_, err = s.explainers.InsertMany(ctx, docs)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil // Another instance seeded them first
+	}
+	return err
+}
+
+// CreateExplainerTopic queues a new explainer topic keyed by its ID.
+func (s *Store) CreateExplainerTopic(ctx context.Context, topic *models.ExplainerTopic) error {
+	now := time.Now()
+	topic.Status = models.ExplainerQueued
+	topic.CreatedAt = now
+	topic.UpdatedAt = now
+	_, err := s.explainers.InsertOne(ctx, topic)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrExplainerTopicExists
+	}
+	return err
+}
+
+// GetExplainerTopic returns an explainer topic, or nil if there is none.
+func (s *Store) GetExplainerTopic(ctx context.Context, id string) (*models.ExplainerTopic, error) {
+	var topic models.ExplainerTopic
+	err := s.explainers.FindOne(ctx, bson.M{"_id": id}).Decode(&topic)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+// ListExplainerTopics returns explainer topics, optionally with one status,
+// in queue order.
+func (s *Store) ListExplainerTopics(ctx context.Context, status models.ExplainerStatus, limit int) ([]models.ExplainerTopic, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.explainers.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []models.ExplainerTopic
+	if err := cursor.All(ctx, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+// RequeueExplainerTopic queues a failed explainer topic again. It reports
+// false if the topic hasn't failed.
+func (s *Store) RequeueExplainerTopic(ctx context.Context, id string) (bool, error) {
+	filter := bson.M{"_id": id, "status": models.ExplainerFailed}
+	update := bson.M{
+		"$set":   bson.M{"status": models.ExplainerQueued, "updated_at": time.Now()},
+		"$unset": bson.M{"error": "", "started_at": "", "finished_at": ""},
+	}
+	result, err := s.explainers.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteExplainerTopic removes an explainer topic that isn't running,
+// reporting whether there was one. Its article, if any, is kept.
+func (s *Store) DeleteExplainerTopic(ctx context.Context, id string) (bool, error) {
+	filter := bson.M{"_id": id, "status": bson.M{"$ne": models.ExplainerRunning}}
+	result, err := s.explainers.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// ClaimExplainerTopic marks the first queued explainer topic running and
+// returns it, or nil if the queue is empty. Topics left running for longer
+// than stale, by an instance that died mid-generation, are claimed again.
+func (s *Store) ClaimExplainerTopic(ctx context.Context, stale time.Duration) (*models.ExplainerTopic, error) {
+	now := time.Now()
+	filter := bson.M{
+		"$or": []bson.M{
+			{"status": models.ExplainerQueued},
+			{"status": models.ExplainerRunning, "started_at": bson.M{"$lt": now.Add(-stale)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":     models.ExplainerRunning,
+		"started_at": now,
+		"updated_at": now,
+	}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var topic models.ExplainerTopic
+	err := s.explainers.FindOneAndUpdate(ctx, filter, update, opts).Decode(&topic)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+// FinishExplainerTopic records the outcome of a running explainer topic:
+// the published article's slug, or the error it failed with.
+func (s *Store) FinishExplainerTopic(ctx context.Context, id, articleSlug string, genErr error) error {
+	now := time.Now()
+	set := bson.M{
+		"status":      models.ExplainerPublished,
+		"finished_at": now,
+		"updated_at":  now,
+	}
+	if genErr != nil {
+		set["status"] = models.ExplainerFailed
+		set["error"] = genErr.Error()
+	} else {
+		set["article_slug"] = articleSlug
+	}
+	_, err := s.explainers.UpdateOne(ctx, bson.M{"_id": id, "status": models.ExplainerRunning}, bson.M{"$set": set})
+	return err
+}
+
+// ============================================================================
+// BRIEFING CONFIG OPERATIONS
+// ============================================================================
+
+// ErrBriefingConfigExists is returned when creating a briefing config whose
+// type is already taken.
+var ErrBriefingConfigExists = errors.New("briefing config already exists")
+
+// initBriefingConfigs seeds the default briefing configs into an empty
+// collection. Once any config is stored the collection is left alone, so
+// deleted defaults stay deleted.
+func (s *Store) initBriefingConfigs(ctx context.Context) error {
+	count, err := s.briefings.CountDocuments(ctx, bson.M{})
+	if err != nil || count > 0 {
+		return err
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(models.DefaultBriefingConfigs))
+	for _, cfg := range models.DefaultBriefingConfigs {
+		cfg.UpdatedAt = now
+		docs = append(docs, cfg)
+	}
+	_, err = s.briefings.InsertMany(ctx, docs)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil // Another instance seeded them first
+	}
+	return err
+}
+
+// GetBriefingConfigs returns all briefing configs, in order of their
+// scheduled time of day.
+func (s *Store) GetBriefingConfigs(ctx context.Context) ([]models.BriefingConfig, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "hour", Value: 1}, {Key: "minute", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.briefings.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var configs []models.BriefingConfig
+	if err := cursor.All(ctx, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// GetBriefingConfig returns the config for a briefing type, or nil if there
+// is none.
+func (s *Store) GetBriefingConfig(ctx context.Context, briefingType models.BriefingType) (*models.BriefingConfig, error) {
+	var cfg models.BriefingConfig
+	err := s.briefings.FindOne(ctx, bson.M{"_id": briefingType}).Decode(&cfg)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// CreateBriefingConfig inserts a new briefing config keyed by its type.
+func (s *Store) CreateBriefingConfig(ctx context.Context, cfg *models.BriefingConfig) error {
+	cfg.UpdatedAt = time.Now()
+	if _, err := s.briefings.InsertOne(ctx, cfg); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrBriefingConfigExists
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateBriefingConfig replaces a stored briefing config, reporting whether
+// there was one to replace.
+func (s *Store) UpdateBriefingConfig(ctx context.Context, cfg *models.BriefingConfig) (bool, error) {
+	cfg.UpdatedAt = time.Now()
+	result, err := s.briefings.ReplaceOne(ctx, bson.M{"_id": cfg.Type}, cfg)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteBriefingConfig removes a briefing config, reporting whether there
+// was one.
+func (s *Store) DeleteBriefingConfig(ctx context.Context, briefingType models.BriefingType) (bool, error) {
+	result, err := s.briefings.DeleteOne(ctx, bson.M{"_id": briefingType})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// ============================================================================
+// TRACKED ACCOUNT OPERATIONS
+// ============================================================================
+
+// ErrTrackedAccountExists is returned when creating a tracked account whose
+// handle is already taken on its platform.
+var ErrTrackedAccountExists = errors.New("tracked account already exists")
+
+// GetTrackedAccounts returns all tracked accounts by ID.
+func (s *Store) GetTrackedAccounts(ctx context.Context) ([]models.TrackedAccount, error) {
+	cursor, err := s.accounts.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var accounts []models.TrackedAccount
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetTrackedAccount returns the tracked account with an ID, or nil if
+// there is none.
+func (s *Store) GetTrackedAccount(ctx context.Context, id string) (*models.TrackedAccount, error) {
+	var account models.TrackedAccount
+	err := s.accounts.FindOne(ctx, bson.M{"_id": id}).Decode(&account)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateTrackedAccount inserts a new tracked account keyed by its ID.
+func (s *Store) CreateTrackedAccount(ctx context.Context, account *models.TrackedAccount) error {
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = account.CreatedAt
+	if _, err := s.accounts.InsertOne(ctx, account); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrTrackedAccountExists
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateTrackedAccount replaces a stored tracked account, reporting whether
+// there was one to replace.
+func (s *Store) UpdateTrackedAccount(ctx context.Context, account *models.TrackedAccount) (bool, error) {
+	account.UpdatedAt = time.Now()
+	result, err := s.accounts.ReplaceOne(ctx, bson.M{"_id": account.ID}, account)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteTrackedAccount removes a tracked account, reporting whether there
+// was one.
+func (s *Store) DeleteTrackedAccount(ctx context.Context, id string) (bool, error) {
+	result, err := s.accounts.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// ============================================================================
+// RUNTIME SETTINGS OPERATIONS
+// ============================================================================
+
+// GetRuntimeSettings returns the stored runtime settings, or nil if none
+// have been saved.
+func (s *Store) GetRuntimeSettings(ctx context.Context) (*models.RuntimeSettings, error) {
+	var settings models.RuntimeSettings
+	err := s.settings.FindOne(ctx, bson.M{"_id": models.RuntimeSettingsID}).Decode(&settings)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveRuntimeSettings replaces the runtime settings.
+func (s *Store) SaveRuntimeSettings(ctx context.Context, settings *models.RuntimeSettings) error {
+	settings.ID = models.RuntimeSettingsID
+	settings.UpdatedAt = time.Now()
+	_, err := s.settings.ReplaceOne(ctx, bson.M{"_id": settings.ID}, settings, options.Replace().SetUpsert(true))
+	return err
+}
+
+// WatchRuntimeSettings calls onChange for each change to the runtime
+// settings until ctx is done or the change stream fails. Change streams
+// need a replica set; on a standalone server it fails at once.
+func (s *Store) WatchRuntimeSettings(ctx context.Context, onChange func()) error {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"documentKey._id": models.RuntimeSettingsID}}}}
+	stream, err := s.settings.Watch(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		onChange()
+	}
+	return stream.Err()
+}
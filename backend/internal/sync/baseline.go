@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"math"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// volumeStdDevFloor is the smallest standard deviation a baseline is given,
+// as a share of its mean, so a market whose volume barely moved doesn't
+// spike on an ordinary uptick.
+const volumeStdDevFloor = 0.10
+
+// volumeBaseline is the mean and standard deviation of a market's 24h
+// volume over its hourly rollups.
+type volumeBaseline struct {
+	Mean    float64
+	StdDev  float64
+	Samples int
+}
+
+// refreshVolumeBaselines recomputes the cached markets' volume baselines
+// from the hourly rollups of the last VolumeBaselineWindow, in one query.
+// It runs after each rollup.
+func (s *Syncer) refreshVolumeBaselines() {
+	ctx, span := tracing.Start(s.ctx, "sync.volume_baselines")
+	defer span.End()
+
+	s.cacheMux.RLock()
+	ids := make([]string, 0, len(s.marketCache))
+	for id := range s.marketCache {
+		ids = append(ids, id)
+	}
+	s.cacheMux.RUnlock()
+	if len(ids) == 0 {
+		return
+	}
+
+	rollups, err := s.store.GetSnapshotRollupsForMarkets(ctx, ids, models.GranularityHour, s.config.VolumeBaselineWindow)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load volume baselines")
+		return
+	}
+
+	baselines := make(map[string]volumeBaseline, len(rollups))
+	for id, rs := range rollups {
+		baselines[id] = newVolumeBaseline(rs)
+	}
+
+	s.baselineMux.Lock()
+	s.volumeBaselines = baselines
+	s.baselineMux.Unlock()
+
+	log.Debug().Int("markets", len(baselines)).Msg("Volume baselines updated")
+}
+
+// newVolumeBaseline computes a baseline from the last 24h volume in each
+// rollup.
+func newVolumeBaseline(rollups []models.SnapshotRollup) volumeBaseline {
+	b := volumeBaseline{Samples: len(rollups)}
+	if b.Samples == 0 {
+		return b
+	}
+	for _, r := range rollups {
+		b.Mean += r.Volume24h
+	}
+	b.Mean /= float64(b.Samples)
+	for _, r := range rollups {
+		b.StdDev += (r.Volume24h - b.Mean) * (r.Volume24h - b.Mean)
+	}
+	b.StdDev = math.Sqrt(b.StdDev / float64(b.Samples))
+	return b
+}
+
+// volumeSpike compares a market's 24h volume with its baseline, returning
+// the z-score, the multiple of the baseline mean, and whether the z-score
+// reaches VolumeZScore. Markets with fewer than VolumeBaselineMinSamples
+// hourly rollups have no baseline yet and never spike.
+func (s *Syncer) volumeSpike(market *models.Market) (z, multiple float64, spike bool) {
+	cfg := s.cfg()
+
+	s.baselineMux.RLock()
+	b, ok := s.volumeBaselines[market.MarketID]
+	s.baselineMux.RUnlock()
+	if !ok || b.Samples < cfg.VolumeBaselineMinSamples || b.Mean <= 0 {
+		return 0, 0, false
+	}
+
+	z = (market.Volume24h - b.Mean) / max(b.StdDev, volumeStdDevFloor*b.Mean)
+	return z, market.Volume24h / b.Mean, z >= cfg.VolumeZScore
+}
+
+// baselineVolume returns a market's baseline mean 24h volume, or 0 when it
+// has none.
+func (s *Syncer) baselineVolume(marketID string) float64 {
+	s.baselineMux.RLock()
+	defer s.baselineMux.RUnlock()
+	return s.volumeBaselines[marketID].Mean
+}
@@ -0,0 +1,217 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// classifyRetryAfter is how long a market the LLM failed to classify waits
+// before it is sent again.
+const classifyRetryAfter = 24 * time.Hour
+
+// SetClassifier enables LLM categorization of markets that keyword
+// detection leaves uncategorized or matches to several categories. Call it
+// before Start; without it, keyword detection alone assigns categories.
+func (s *Syncer) SetClassifier(provider llm.Provider) {
+	s.llm = provider
+}
+
+// categorize assigns a market's category: the LLM's answer once it has
+// classified the market, keyword detection until then.
+func (s *Syncer) categorize(m *models.Market) {
+	s.classifyMux.Lock()
+	category, ok := s.llmCategories[m.MarketID]
+	s.classifyMux.Unlock()
+
+	if ok {
+		m.Category = category
+		m.CategorySource = models.CategorySourceLLM
+		return
+	}
+	m.Category = m.DetectCategory()
+	m.CategorySource = models.CategorySourceKeyword
+}
+
+// needsClassification reports whether keyword detection couldn't settle on
+// a single category for the market.
+func needsClassification(m *models.Market) bool {
+	if m.CategorySource == models.CategorySourceLLM {
+		return false
+	}
+	return m.Category == "other" || len(m.CategoryMatches()) > 1
+}
+
+// classificationLoop periodically sends markets that need classification to
+// the LLM.
+func (s *Syncer) classificationLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.ClassifyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.classifyMarkets()
+		}
+	}
+}
+
+// classifyMarkets classifies up to ClassifyMaxPerRun cached markets, highest
+// 24h volume first, in batches of ClassifyBatchSize. A failed batch keeps
+// its keyword categories and is retried after classifyRetryAfter.
+func (s *Syncer) classifyMarkets() {
+	ctx, span := tracing.Start(s.ctx, "sync.classify")
+	defer span.End()
+	ctx = llm.WithJob(ctx, "market-classification")
+
+	now := time.Now()
+
+	s.cacheMux.RLock()
+	var candidates []*models.Market
+	for _, m := range s.marketCache {
+		if needsClassification(m) {
+			candidates = append(candidates, m)
+		}
+	}
+	s.cacheMux.RUnlock()
+
+	s.classifyMux.Lock()
+	pending := candidates[:0]
+	for _, m := range candidates {
+		if now.Sub(s.classifyAttempts[m.MarketID]) >= classifyRetryAfter {
+			pending = append(pending, m)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Volume24h > pending[j].Volume24h
+	})
+	if len(pending) > s.config.ClassifyMaxPerRun {
+		pending = pending[:s.config.ClassifyMaxPerRun]
+	}
+	for _, m := range pending {
+		s.classifyAttempts[m.MarketID] = now
+	}
+	s.classifyMux.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	span.SetAttributes(attribute.Int("markets", len(pending)))
+
+	classified := 0
+	for start := 0; start < len(pending); start += s.config.ClassifyBatchSize {
+		batch := pending[start:min(start+s.config.ClassifyBatchSize, len(pending))]
+
+		categories, err := s.classifyBatch(ctx, batch)
+		if err != nil {
+			log.Warn().Err(err).Int("markets", len(batch)).Msg("LLM classification failed, keeping keyword categories")
+			continue
+		}
+
+		for id, category := range categories {
+			if err := s.store.SetMarketCategory(ctx, id, category, models.CategorySourceLLM); err != nil {
+				log.Warn().Err(err).Str("market", id).Msg("Failed to save market category")
+				continue
+			}
+			s.applyCategory(id, category)
+			classified++
+		}
+	}
+
+	log.Info().
+		Int("candidates", len(pending)).
+		Int("classified", classified).
+		Msg("Classified markets with LLM")
+}
+
+// applyCategory records an LLM category so later syncs keep it, and updates
+// the cached market.
+func (s *Syncer) applyCategory(marketID, category string) {
+	s.classifyMux.Lock()
+	s.llmCategories[marketID] = category
+	s.classifyMux.Unlock()
+
+	s.cacheMux.Lock()
+	if m, ok := s.marketCache[marketID]; ok {
+		updated := *m
+		updated.Category = category
+		updated.CategorySource = models.CategorySourceLLM
+		s.marketCache[marketID] = &updated
+	}
+	s.cacheMux.Unlock()
+}
+
+// classificationResponse is the JSON the LLM answers with.
+type classificationResponse struct {
+	Markets []struct {
+		ID       string `json:"id"`
+		Category string `json:"category"`
+	} `json:"markets"`
+}
+
+// classifyBatch asks the LLM to categorize a batch of markets. Answers for
+// unknown markets or categories are dropped.
+func (s *Syncer) classifyBatch(ctx context.Context, batch []*models.Market) (map[string]string, error) {
+	valid := map[string]bool{"other": true}
+	var categoryList strings.Builder
+	for _, c := range models.GetStaticCategories() {
+		valid[c.Slug] = true
+		fmt.Fprintf(&categoryList, "- %s: %s\n", c.Slug, c.Description)
+	}
+
+	requested := make(map[string]bool, len(batch))
+	var marketList strings.Builder
+	for _, m := range batch {
+		requested[m.MarketID] = true
+		fmt.Fprintf(&marketList, "- id %s: %s", m.MarketID, m.Question)
+		if m.EventTitle != "" && m.EventTitle != m.Question {
+			fmt.Fprintf(&marketList, " (event: %s)", m.EventTitle)
+		}
+		marketList.WriteString("\n")
+	}
+
+	prompt := fmt.Sprintf(`Assign each prediction market below to the single category that best describes what it is about.
+
+Categories:
+%s- other: none of the above
+
+Judge by the subject being predicted, not by the names mentioned. "Will Apple beat earnings?" is earnings, not tech; "Will Trump win Pennsylvania?" is elections, not politics.
+
+Markets:
+%s
+Respond with JSON only, one entry per market:
+{"markets": [{"id": "<market id>", "category": "<category slug>"}]}`, categoryList.String(), marketList.String())
+
+	var resp classificationResponse
+	err := s.llm.ChatJSON(ctx, llm.ChatRequest{
+		SystemPrompt: "You classify prediction markets for a news site. Answer with valid JSON only.",
+		UserPrompt:   prompt,
+		Temperature:  0,
+		MaxTokens:    60 * len(batch),
+		Model:        llm.ModelFast,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]string, len(resp.Markets))
+	for _, r := range resp.Markets {
+		category := strings.ToLower(strings.TrimSpace(r.Category))
+		if requested[r.ID] && valid[category] {
+			categories[r.ID] = category
+		}
+	}
+	return categories, nil
+}
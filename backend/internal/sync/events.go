@@ -0,0 +1,328 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// replayBatchSize is how many persisted events are loaded per query while
+// replaying.
+const replayBatchSize = 100
+
+// ErrReplayInProgress is returned when a replay is requested while another
+// is still running.
+var ErrReplayInProgress = errors.New("event replay already in progress")
+
+// SubscribeDurable returns a channel that receives market events for a
+// named subscriber whose progress survives restarts. The subscriber calls
+// Claim before handling each event and Ack after; events it misses,
+// because its queue was full or the process restarted, stay pending until
+// replayed with Replay. A subscriber seen for the first time starts from
+// the latest event rather than the whole retention window.
+func (s *Syncer) SubscribeDurable(name string) <-chan Event {
+	s.initOffset(name)
+
+	s.eventMux.Lock()
+	defer s.eventMux.Unlock()
+
+	sub := newSubscription(name, true)
+	s.subscribers = append(s.subscribers, sub)
+	return sub.out
+}
+
+// offsetState is a durable subscriber's progress in memory. last is a
+// contiguous high-water mark: every persisted event up to it is handled.
+// Events handled past a gap, such as live events after one dropped from
+// the queue, are held in acked until the gap is replayed.
+type offsetState struct {
+	last     primitive.ObjectID
+	acked    map[primitive.ObjectID]bool
+	handling map[primitive.ObjectID]bool
+}
+
+func (s *Syncer) initOffset(name string) {
+	s.offsetMux.Lock()
+	defer s.offsetMux.Unlock()
+
+	offset, err := s.store.GetEventOffset(s.ctx, name)
+	if err != nil {
+		log.Warn().Err(err).Str("subscriber", name).Msg("Failed to load event offset")
+		return
+	}
+	if offset != nil {
+		st := s.offsetLocked(name, offset)
+		pending, err := s.store.CountEventsAfter(s.ctx, st.last)
+		if pending -= int64(len(st.acked)); err == nil && pending > 0 {
+			log.Info().
+				Str("subscriber", name).
+				Int64("pending", pending).
+				Msg("Subscriber has unprocessed events; replay to catch up")
+		}
+		return
+	}
+
+	latest, err := s.store.GetRecentEvents(s.ctx, "", 1)
+	if err != nil || len(latest) == 0 {
+		return
+	}
+	st := s.offsetLocked(name, &models.EventOffset{Subscriber: name, LastEventID: latest[0].ID})
+	if err := s.saveOffsetLocked(s.ctx, name, st); err != nil {
+		log.Warn().Err(err).Str("subscriber", name).Msg("Failed to initialize event offset")
+	}
+}
+
+// offsetLocked returns the subscriber's offset state, creating it from
+// stored (which may be nil) the first time. The caller holds offsetMux.
+func (s *Syncer) offsetLocked(name string, stored *models.EventOffset) *offsetState {
+	if st, ok := s.offsets[name]; ok {
+		return st
+	}
+	st := &offsetState{
+		acked:    make(map[primitive.ObjectID]bool),
+		handling: make(map[primitive.ObjectID]bool),
+	}
+	if stored != nil {
+		st.last = stored.LastEventID
+		for _, id := range stored.Acked {
+			st.acked[id] = true
+		}
+	}
+	s.offsets[name] = st
+	return st
+}
+
+// offset returns the subscriber's offset state, loading it from the store
+// the first time.
+func (s *Syncer) offset(ctx context.Context, name string) (*offsetState, error) {
+	s.offsetMux.Lock()
+	defer s.offsetMux.Unlock()
+
+	if st, ok := s.offsets[name]; ok {
+		return st, nil
+	}
+	stored, err := s.store.GetEventOffset(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event offset: %w", err)
+	}
+	return s.offsetLocked(name, stored), nil
+}
+
+// Claim reports whether a durable subscriber should handle event: false if
+// it has already acknowledged the event or is handling it, as when a
+// replay and live delivery both carry it. Handlers call Claim first so
+// each event is handled once, and Ack after; events that weren't
+// persisted are always claimed.
+func (s *Syncer) Claim(ctx context.Context, name string, event Event) bool {
+	id, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return true
+	}
+	st, err := s.offset(ctx, name)
+	if err != nil {
+		log.Warn().Err(err).Str("subscriber", name).Msg("Failed to load event offset")
+		return true
+	}
+
+	s.offsetMux.Lock()
+	defer s.offsetMux.Unlock()
+	if bytes.Compare(id[:], st.last[:]) <= 0 || st.acked[id] || st.handling[id] {
+		return false
+	}
+	st.handling[id] = true
+	return true
+}
+
+// Ack records that a durable subscriber has handled an event. The stored
+// offset only advances over a contiguous run of handled events, so an
+// event acknowledged after a gap is remembered rather than letting the
+// offset skip the events missed before it.
+func (s *Syncer) Ack(ctx context.Context, name string, event Event) error {
+	id, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return nil // Not persisted; nothing to acknowledge
+	}
+	st, err := s.offset(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	s.offsetMux.Lock()
+	defer s.offsetMux.Unlock()
+
+	delete(st.handling, id)
+	if bytes.Compare(id[:], st.last[:]) <= 0 || st.acked[id] {
+		return nil
+	}
+	st.acked[id] = true
+	if err := s.advanceLocked(ctx, st); err != nil {
+		return err
+	}
+	return s.saveOffsetLocked(ctx, name, st)
+}
+
+// advanceLocked moves the high-water mark over the acknowledged events
+// that directly follow it. The caller holds offsetMux.
+func (s *Syncer) advanceLocked(ctx context.Context, st *offsetState) error {
+	for len(st.acked) > 0 {
+		next, err := s.store.GetEventsAfter(ctx, st.last, replayBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load pending events: %w", err)
+		}
+		for i := range next {
+			if !st.acked[next[i].ID] {
+				return nil
+			}
+			delete(st.acked, next[i].ID)
+			st.last = next[i].ID
+		}
+		if len(next) < replayBatchSize {
+			// The rest were purged; nothing is left to wait for
+			clear(st.acked)
+			return nil
+		}
+	}
+	return nil
+}
+
+// saveOffsetLocked stores the subscriber's offset. The caller holds
+// offsetMux.
+func (s *Syncer) saveOffsetLocked(ctx context.Context, name string, st *offsetState) error {
+	offset := &models.EventOffset{Subscriber: name, LastEventID: st.last}
+	for id := range st.acked {
+		offset.Acked = append(offset.Acked, id)
+	}
+	slices.SortFunc(offset.Acked, func(a, b primitive.ObjectID) int { return bytes.Compare(a[:], b[:]) })
+	if err := s.store.SaveEventOffset(ctx, offset); err != nil {
+		return fmt.Errorf("failed to save event offset: %w", err)
+	}
+	return nil
+}
+
+// PendingEvents returns up to limit events a durable subscriber hasn't
+// acknowledged, oldest first, and the total number pending.
+func (s *Syncer) PendingEvents(ctx context.Context, name string, limit int) ([]Event, int64, error) {
+	st, err := s.offset(ctx, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.offsetMux.Lock()
+	after, acked := st.last, maps.Clone(st.acked)
+	s.offsetMux.Unlock()
+
+	total, err := s.store.CountEventsAfter(ctx, after)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pending events: %w", err)
+	}
+	total -= int64(len(acked))
+
+	var events []Event
+	for len(events) < limit {
+		stored, err := s.store.GetEventsAfter(ctx, after, replayBatchSize)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to load pending events: %w", err)
+		}
+		for i := range stored {
+			if !acked[stored[i].ID] && len(events) < limit {
+				events = append(events, eventFromStored(&stored[i]))
+			}
+			after = stored[i].ID
+		}
+		if len(stored) < replayBatchSize {
+			break
+		}
+	}
+	return events, max(total, 0), nil
+}
+
+// Replay hands a durable subscriber's unacknowledged events to handle in
+// emission order, acknowledging each one after it returns. Events already
+// acknowledged, or being handled from live delivery, are skipped. It stops
+// after limit events (0 for all) and returns how many were replayed.
+func (s *Syncer) Replay(ctx context.Context, name string, limit int, handle func(Event)) (int, error) {
+	if !s.replayMux.TryLock() {
+		return 0, ErrReplayInProgress
+	}
+	defer s.replayMux.Unlock()
+
+	st, err := s.offset(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	s.offsetMux.Lock()
+	after := st.last
+	s.offsetMux.Unlock()
+
+	replayed := 0
+	for limit <= 0 || replayed < limit {
+		stored, err := s.store.GetEventsAfter(ctx, after, replayBatchSize)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to load pending events: %w", err)
+		}
+		if len(stored) == 0 {
+			break
+		}
+
+		for i := range stored {
+			if ctx.Err() != nil {
+				return replayed, ctx.Err()
+			}
+			if limit > 0 && replayed >= limit {
+				break
+			}
+			after = stored[i].ID
+
+			event := eventFromStored(&stored[i])
+			if !s.Claim(ctx, name, event) {
+				continue
+			}
+			handle(event)
+			if err := s.Ack(ctx, name, event); err != nil {
+				return replayed, fmt.Errorf("failed to acknowledge event: %w", err)
+			}
+			replayed++
+		}
+	}
+
+	log.Info().
+		Str("subscriber", name).
+		Int("replayed", replayed).
+		Msg("Replayed events")
+	return replayed, nil
+}
+
+// persistEvent stores an event so durable subscribers can replay it, and
+// sets its ID.
+func (s *Syncer) persistEvent(ctx context.Context, event *Event) {
+	stored := &models.StoredEvent{
+		Type:      string(event.Type),
+		MarketID:  event.Market.MarketID,
+		Market:    event.Market,
+		Previous:  event.Previous,
+		Metadata:  event.Metadata,
+		CreatedAt: event.Timestamp,
+	}
+	if err := s.store.SaveEvent(ctx, stored); err != nil {
+		log.Warn().Err(err).Str("type", string(event.Type)).Msg("Failed to persist event")
+		return
+	}
+	event.ID = stored.ID.Hex()
+}
+
+func eventFromStored(e *models.StoredEvent) Event {
+	return Event{
+		ID:        e.ID.Hex(),
+		Type:      EventType(e.Type),
+		Market:    e.Market,
+		Previous:  e.Previous,
+		Timestamp: e.CreatedAt,
+		Metadata:  e.Metadata,
+	}
+}
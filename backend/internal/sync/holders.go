@@ -0,0 +1,150 @@
+package sync
+
+import (
+	"sort"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// holderFetchLimit is how many of the largest holders are fetched per
+// outcome token.
+const holderFetchLimit = 100
+
+// holderLoop periodically refreshes holder concentration for the highest
+// volume markets.
+func (s *Syncer) holderLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.HolderInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshHolders()
+		}
+	}
+}
+
+// refreshHolders fetches the largest holders of up to HolderMaxMarkets
+// cached markets, highest 24h volume first, and stores a summary on each.
+func (s *Syncer) refreshHolders() {
+	ctx, span := tracing.Start(s.ctx, "sync.holders")
+	defer span.End()
+
+	s.cacheMux.RLock()
+	var markets []*models.Market
+	for _, m := range s.marketCache {
+		if m.ConditionID != "" && !m.Closed {
+			markets = append(markets, m)
+		}
+	}
+	s.cacheMux.RUnlock()
+
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Volume24h > markets[j].Volume24h
+	})
+	if len(markets) > s.config.HolderMaxMarkets {
+		markets = markets[:s.config.HolderMaxMarkets]
+	}
+	span.SetAttributes(attribute.Int("markets", len(markets)))
+
+	updated := 0
+	for _, m := range markets {
+		tokens, err := s.client.GetHolders(ctx, m.ConditionID, holderFetchLimit)
+		if err != nil {
+			s.countAPIError()
+			log.Debug().Err(err).Str("market", m.MarketID).Msg("Failed to fetch holders")
+			continue
+		}
+
+		holders := summarizeHolders(m, tokens)
+		if err := s.store.SetMarketHolders(ctx, m.MarketID, holders); err != nil {
+			log.Warn().Err(err).Str("market", m.MarketID).Msg("Failed to save holders")
+			continue
+		}
+		s.applyHolders(m.MarketID, holders)
+		updated++
+	}
+
+	log.Debug().
+		Int("markets", len(markets)).
+		Int("updated", updated).
+		Msg("Refreshed holders")
+}
+
+// summarizeHolders computes per-outcome concentration and the number of
+// distinct wallets.
+func summarizeHolders(m *models.Market, tokens []polymarket.TokenHolders) *models.Holders {
+	summary := &models.Holders{UpdatedAt: time.Now()}
+	wallets := make(map[string]bool)
+
+	for _, token := range tokens {
+		if len(token.Holders) == 0 {
+			continue
+		}
+		if len(token.Holders) >= holderFetchLimit {
+			summary.Capped = true
+		}
+
+		amounts := make([]float64, 0, len(token.Holders))
+		for _, h := range token.Holders {
+			wallets[h.ProxyWallet] = true
+			amounts = append(amounts, h.Amount)
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(amounts)))
+
+		oh := models.OutcomeHolders{
+			Outcome:       outcomeName(m, token.Holders[0].OutcomeIndex),
+			Holders:       len(amounts),
+			LargestHolder: amounts[0],
+		}
+		var top float64
+		for i, a := range amounts {
+			oh.Shares += a
+			if i < models.HolderTopN {
+				top += a
+			}
+		}
+		if oh.Shares > 0 {
+			oh.TopShare = top / oh.Shares
+		}
+		summary.Outcomes = append(summary.Outcomes, oh)
+	}
+
+	sort.Slice(summary.Outcomes, func(i, j int) bool {
+		return summary.Outcomes[i].Shares > summary.Outcomes[j].Shares
+	})
+	summary.UniqueHolders = len(wallets)
+	return summary
+}
+
+// outcomeName returns the market's name for an outcome index.
+func outcomeName(m *models.Market, index int) string {
+	if index >= 0 && index < len(m.Outcomes) {
+		return m.Outcomes[index]
+	}
+	if index == 0 {
+		return "Yes"
+	}
+	return "No"
+}
+
+// applyHolders updates the cached market's holder summary.
+func (s *Syncer) applyHolders(marketID string, holders *models.Holders) {
+	s.cacheMux.Lock()
+	defer s.cacheMux.Unlock()
+
+	if m, ok := s.marketCache[marketID]; ok {
+		updated := *m
+		updated.Holders = holders
+		s.marketCache[marketID] = &updated
+	}
+}
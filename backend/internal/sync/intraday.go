@@ -0,0 +1,64 @@
+package sync
+
+import (
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// refreshIntradayChanges computes each cached market's 1h and 6h price
+// change and its past-hour volume against the nearest earlier snapshots,
+// which Polymarket's feed doesn't provide. Markets without a snapshot near
+// a window's start get no change for that window.
+func (s *Syncer) refreshIntradayChanges() {
+	ctx, span := tracing.Start(s.ctx, "sync.intraday")
+	defer span.End()
+
+	now := time.Now()
+	// Allow for a missed snapshot or two around each window's start
+	tolerance := max(2*s.config.SnapshotInterval, 10*time.Minute)
+
+	hourAgo, err := s.store.GetSnapshotsAt(ctx, now.Add(-time.Hour), tolerance)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load 1h snapshots")
+		return
+	}
+	sixHoursAgo, err := s.store.GetSnapshotsAt(ctx, now.Add(-6*time.Hour), tolerance)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load 6h snapshots")
+		return
+	}
+
+	s.cacheMux.Lock()
+	updated := make([]*models.Market, 0, len(s.marketCache))
+	for id, m := range s.marketCache {
+		next := *m
+		next.Change1h, next.Change6h, next.Volume1h = 0, 0, 0
+		if snap, ok := hourAgo[id]; ok {
+			next.Change1h = m.Probability - snap.Probability
+			next.Volume1h = max(0, m.TotalVolume-snap.TotalVolume)
+		}
+		if snap, ok := sixHoursAgo[id]; ok {
+			next.Change6h = m.Probability - snap.Probability
+		}
+		if next.Change1h == m.Change1h && next.Change6h == m.Change6h && next.Volume1h == m.Volume1h {
+			continue
+		}
+		s.marketCache[id] = &next
+		updated = append(updated, &next)
+	}
+	s.cacheMux.Unlock()
+
+	if err := s.store.SetMarketIntradayChanges(ctx, updated); err != nil {
+		log.Warn().Err(err).Msg("Failed to save intraday changes")
+		return
+	}
+
+	log.Debug().
+		Int("updated", len(updated)).
+		Int("with_1h", len(hourAgo)).
+		Int("with_6h", len(sixHoursAgo)).
+		Msg("Refreshed intraday changes")
+}
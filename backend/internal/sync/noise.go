@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// confirmBreaking reports whether a market's 24h move is a breaking move:
+// past the breaking threshold, in a market with at least
+// BreakingMinLiquidity, and in the same direction for BreakingConfirmCycles
+// consecutive sync cycles, so a single small trade against a thin book
+// doesn't fire an event. It is called once per market per cycle.
+func (s *Syncer) confirmBreaking(market *models.Market) (cycles int, confirmed bool) {
+	cfg := s.cfg()
+
+	s.streakMux.Lock()
+	defer s.streakMux.Unlock()
+
+	if abs(market.Change24h) < cfg.BreakingThreshold {
+		delete(s.breakingStreaks, market.MarketID)
+		return 0, false
+	}
+	if market.Liquidity < cfg.BreakingMinLiquidity {
+		delete(s.breakingStreaks, market.MarketID)
+		log.Debug().
+			Str("market", market.MarketID).
+			Float64("liquidity", market.Liquidity).
+			Float64("change", market.Change24h).
+			Msg("Ignored breaking move in illiquid market")
+		return 0, false
+	}
+
+	// Streaks are signed by direction; a reversal starts a new one
+	dir := 1
+	if market.Change24h < 0 {
+		dir = -1
+	}
+	streak := s.breakingStreaks[market.MarketID]
+	if streak*dir > 0 {
+		streak += dir
+	} else {
+		streak = dir
+	}
+	s.breakingStreaks[market.MarketID] = streak
+
+	cycles = streak * dir
+	return cycles, cycles >= cfg.BreakingConfirmCycles
+}
+
+// forgetStreak drops a market's breaking move streak.
+func (s *Syncer) forgetStreak(marketID string) {
+	s.streakMux.Lock()
+	delete(s.breakingStreaks, marketID)
+	s.streakMux.Unlock()
+}
@@ -0,0 +1,121 @@
+package sync
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// orderBookLoop periodically refreshes the order books of the highest
+// volume markets.
+func (s *Syncer) orderBookLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.OrderBookInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshOrderBooks()
+		}
+	}
+}
+
+// refreshOrderBooks fetches the Yes-token order book of up to
+// OrderBookMaxMarkets cached markets, highest 24h volume first, and stores
+// a summary on each. Markets whose book can't be fetched keep the last one.
+func (s *Syncer) refreshOrderBooks() {
+	ctx, span := tracing.Start(s.ctx, "sync.orderbooks")
+	defer span.End()
+
+	s.cacheMux.RLock()
+	var markets []*models.Market
+	for _, m := range s.marketCache {
+		if m.AcceptingBid && len(m.ClobTokenIDs) > 0 {
+			markets = append(markets, m)
+		}
+	}
+	s.cacheMux.RUnlock()
+
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Volume24h > markets[j].Volume24h
+	})
+	if len(markets) > s.config.OrderBookMaxMarkets {
+		markets = markets[:s.config.OrderBookMaxMarkets]
+	}
+	span.SetAttributes(attribute.Int("markets", len(markets)))
+
+	updated := 0
+	for _, m := range markets {
+		book, err := s.fetchOrderBook(ctx, m.ClobTokenIDs[0])
+		if err != nil {
+			log.Debug().Err(err).Str("market", m.MarketID).Msg("Failed to fetch order book")
+			continue
+		}
+		if err := s.store.SetMarketOrderBook(ctx, m.MarketID, book); err != nil {
+			log.Warn().Err(err).Str("market", m.MarketID).Msg("Failed to save order book")
+			continue
+		}
+		s.applyOrderBook(m.MarketID, book)
+		updated++
+	}
+
+	log.Debug().
+		Int("markets", len(markets)).
+		Int("updated", updated).
+		Msg("Refreshed order books")
+}
+
+// fetchOrderBook summarizes the order book for an outcome token.
+func (s *Syncer) fetchOrderBook(ctx context.Context, tokenID string) (*models.OrderBook, error) {
+	raw, err := s.client.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		s.countAPIError()
+		return nil, err
+	}
+
+	book := &models.OrderBook{
+		BestBid:   raw.BestBid(),
+		BestAsk:   raw.BestAsk(),
+		UpdatedAt: time.Now(),
+	}
+	if !book.Quoted() {
+		// A missing side isn't a price of 0; leave the book unquoted
+		return book, nil
+	}
+	book.Spread = book.BestAsk - book.BestBid
+
+	// Prefer the midpoint endpoint; fall back to the book's own quotes if
+	// it fails
+	book.Midpoint, err = s.client.GetMidpoint(ctx, tokenID)
+	if err != nil {
+		s.countAPIError()
+		book.Midpoint = (book.BestBid + book.BestAsk) / 2
+	}
+
+	book.BidDepth, book.AskDepth = raw.Depth(
+		book.Midpoint-models.OrderBookDepthRange,
+		book.Midpoint+models.OrderBookDepthRange,
+	)
+	return book, nil
+}
+
+// applyOrderBook updates the cached market's order book.
+func (s *Syncer) applyOrderBook(marketID string, book *models.OrderBook) {
+	s.cacheMux.Lock()
+	defer s.cacheMux.Unlock()
+
+	if m, ok := s.marketCache[marketID]; ok {
+		updated := *m
+		updated.OrderBook = book
+		s.marketCache[marketID] = &updated
+	}
+}
@@ -0,0 +1,174 @@
+package sync
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// resolutionLoop periodically looks up tracked markets that have stopped
+// appearing in the sync feed. The feed only returns open events, so this is
+// how most resolutions are noticed.
+func (s *Syncer) resolutionLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.ResolutionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkStaleMarkets()
+		}
+	}
+}
+
+// checkStaleMarkets looks up cached markets not refreshed by recent syncs,
+// least recently checked first, and resolves the ones that have closed.
+func (s *Syncer) checkStaleMarkets() {
+	ctx, span := tracing.Start(s.ctx, "sync.resolution_check")
+	defer span.End()
+
+	cutoff := time.Now().Add(-3 * s.cfg().SyncInterval)
+
+	s.cacheMux.RLock()
+	var stale []*models.Market
+	for _, m := range s.marketCache {
+		if m.UpdatedAt.Before(cutoff) {
+			stale = append(stale, m)
+		}
+	}
+	s.cacheMux.RUnlock()
+
+	if len(stale) == 0 {
+		return
+	}
+
+	s.resolutionMux.Lock()
+	sort.Slice(stale, func(i, j int) bool {
+		return s.resolutionChecks[stale[i].MarketID].Before(s.resolutionChecks[stale[j].MarketID])
+	})
+	s.resolutionMux.Unlock()
+
+	if len(stale) > s.config.ResolutionBatchSize {
+		stale = stale[:s.config.ResolutionBatchSize]
+	}
+	span.SetAttributes(attribute.Int("markets", len(stale)))
+
+	for _, m := range stale {
+		s.claimResolutionCheck(m.MarketID, 0)
+
+		pm, err := s.client.GetMarket(ctx, m.MarketID)
+		if err != nil {
+			s.countAPIError()
+			log.Warn().Err(err).Str("market", m.MarketID).Msg("Failed to look up stale market")
+			continue
+		}
+
+		switch {
+		case pm.Closed:
+			s.resolveMarket(ctx, m, pm)
+		case !pm.Active:
+			// Delisted without resolving; stop tracking it
+			s.forgetMarket(m.MarketID)
+		}
+	}
+}
+
+// claimResolutionCheck records a resolution lookup for marketID, returning
+// false if one was made less than minGap ago.
+func (s *Syncer) claimResolutionCheck(marketID string, minGap time.Duration) bool {
+	s.resolutionMux.Lock()
+	defer s.resolutionMux.Unlock()
+
+	if last, ok := s.resolutionChecks[marketID]; ok && time.Since(last) < minGap {
+		return false
+	}
+	s.resolutionChecks[marketID] = time.Now()
+	return true
+}
+
+// resolveMarket marks a closed market resolved once it has a winning outcome
+// and emits EventMarketResolved. Closed markets still awaiting settlement
+// stay tracked and are looked up again later.
+func (s *Syncer) resolveMarket(ctx context.Context, existing *models.Market, pm *polymarket.Market) {
+	outcome := s.winningOutcome(ctx, pm)
+	if outcome == "" {
+		log.Debug().Str("market", existing.Question).Msg("Market closed, awaiting resolution")
+		return
+	}
+
+	resolvedAt := time.Now()
+	if err := s.store.MarkMarketResolved(ctx, existing.MarketID, outcome, resolvedAt); err != nil {
+		log.Error().Err(err).Str("market", existing.MarketID).Msg("Failed to save market resolution")
+		return
+	}
+
+	market := *existing
+	market.Closed = true
+	market.Resolved = true
+	market.WinningOutcome = outcome
+	market.ResolvedAt = &resolvedAt
+
+	s.forgetMarket(existing.MarketID)
+
+	log.Info().
+		Str("market", market.Question).
+		Str("outcome", outcome).
+		Float64("final_probability", existing.Probability).
+		Msg("Market resolved")
+
+	s.emitEvent(ctx, Event{
+		Type:      EventMarketResolved,
+		Market:    &market,
+		Timestamp: resolvedAt,
+		Metadata: map[string]interface{}{
+			"outcome":           outcome,
+			"final_probability": existing.Probability,
+		},
+	})
+}
+
+// winningOutcome returns the outcome a closed market resolved to, or "" if it
+// hasn't settled. The CLOB API is authoritative; Gamma's settled outcome
+// prices are used if it is unavailable.
+func (s *Syncer) winningOutcome(ctx context.Context, pm *polymarket.Market) string {
+	if pm.ConditionID != "" {
+		clob, err := s.client.GetCLOBMarket(ctx, pm.ConditionID)
+		if err == nil {
+			return clob.WinningOutcome()
+		}
+		s.countAPIError()
+		log.Debug().Err(err).Str("market", pm.ID).Msg("CLOB lookup failed, falling back to outcome prices")
+	}
+
+	for i, p := range pm.OutcomePrices {
+		price, err := strconv.ParseFloat(p, 64)
+		if err == nil && price >= 0.99 && i < len(pm.Outcomes) {
+			return pm.Outcomes[i]
+		}
+	}
+	return ""
+}
+
+// forgetMarket stops tracking a market.
+func (s *Syncer) forgetMarket(marketID string) {
+	s.cacheMux.Lock()
+	delete(s.marketCache, marketID)
+	s.cacheMux.Unlock()
+
+	s.resolutionMux.Lock()
+	delete(s.resolutionChecks, marketID)
+	s.resolutionMux.Unlock()
+
+	s.forgetStreak(marketID)
+}
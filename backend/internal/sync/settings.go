@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ApplySettings overrides the syncer's configured thresholds and sync
+// interval with the runtime settings; nil fields go back to their
+// configured values. A new sync interval takes effect from the next tick.
+func (s *Syncer) ApplySettings(rs models.RuntimeSettings) {
+	s.configMux.Lock()
+	previous := s.config.SyncInterval
+	s.config.SyncInterval = overrideSeconds(rs.SyncIntervalSeconds, s.base.SyncInterval)
+	s.config.BreakingThreshold = override(rs.BreakingThreshold, s.base.BreakingThreshold)
+	s.config.VolumeZScore = override(rs.VolumeZScore, s.base.VolumeZScore)
+	s.config.MinVolume24h = override(rs.MinVolume24h, s.base.MinVolume24h)
+	s.config.EventCooldown = overrideSeconds(rs.EventCooldownSeconds, s.base.EventCooldown)
+	s.config.EventEscalation = override(rs.EventEscalation, s.base.EventEscalation)
+	s.config.TrendingWeights = s.base.TrendingWeights
+	if rs.TrendingWeights != nil {
+		s.config.TrendingWeights = *rs.TrendingWeights
+	}
+	cfg := s.config
+	s.configMux.Unlock()
+
+	if cfg.SyncInterval != previous {
+		select {
+		case s.intervalChanged <- struct{}{}:
+		default:
+		}
+	}
+
+	log.Info().
+		Dur("sync_interval", cfg.SyncInterval).
+		Float64("breaking_threshold", cfg.BreakingThreshold).
+		Float64("volume_z_score", cfg.VolumeZScore).
+		Float64("min_volume_24h", cfg.MinVolume24h).
+		Dur("event_cooldown", cfg.EventCooldown).
+		Float64("event_escalation", cfg.EventEscalation).
+		Interface("trending_weights", cfg.TrendingWeights).
+		Msg("Applied syncer settings")
+}
+
+// EffectiveSettings fills in the syncer's fields of rs with the values in
+// effect.
+func (s *Syncer) EffectiveSettings(rs *models.RuntimeSettings) {
+	cfg := s.cfg()
+	interval := int(cfg.SyncInterval / time.Second)
+	cooldown := int(cfg.EventCooldown / time.Second)
+	rs.SyncIntervalSeconds = &interval
+	rs.BreakingThreshold = &cfg.BreakingThreshold
+	rs.VolumeZScore = &cfg.VolumeZScore
+	rs.MinVolume24h = &cfg.MinVolume24h
+	rs.EventCooldownSeconds = &cooldown
+	rs.EventEscalation = &cfg.EventEscalation
+	rs.TrendingWeights = &cfg.TrendingWeights
+}
+
+// cfg returns the syncer's config with the runtime settings in effect.
+func (s *Syncer) cfg() SyncerConfig {
+	s.configMux.RLock()
+	defer s.configMux.RUnlock()
+	return s.config
+}
+
+func override(v *float64, fallback float64) float64 {
+	if v == nil {
+		return fallback
+	}
+	return *v
+}
+
+func overrideSeconds(v *int, fallback time.Duration) time.Duration {
+	if v == nil {
+		return fallback
+	}
+	return time.Duration(*v) * time.Second
+}
@@ -0,0 +1,36 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+)
+
+// EmitSocialSignal emits an EventSocialSignal for each market a social
+// signal moved, and returns how many were emitted. Markets the syncer
+// doesn't track are skipped.
+func (s *Syncer) EmitSocialSignal(ctx context.Context, signal models.SocialSignal) int {
+	emitted := 0
+	for _, movement := range signal.AffectedMarkets {
+		m, ok := s.GetCachedMarket(movement.MarketID)
+		if !ok {
+			continue
+		}
+
+		s.emitEvent(ctx, Event{
+			Type:   EventSocialSignal,
+			Market: m,
+			Metadata: map[string]interface{}{
+				"handle":      signal.Handle,
+				"tweet_url":   signal.TweetURL,
+				"content":     signal.Content,
+				"posted_at":   signal.PostedAt,
+				"change":      movement.Change,
+				"prob_before": movement.ProbBefore,
+				"prob_after":  movement.ProbAfter,
+			},
+		})
+		emitted++
+	}
+	return emitted
+}
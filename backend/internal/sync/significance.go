@@ -0,0 +1,144 @@
+package sync
+
+import (
+	"math"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Significance score weights; they sum to 1 so scores stay within [0, 1].
+const (
+	scoreWeightMagnitude = 0.50
+	scoreWeightVolume    = 0.20
+	scoreWeightLiquidity = 0.15
+	scoreWeightRecency   = 0.15
+)
+
+// scoreReferenceUSD is the 24h volume or liquidity that earns a full
+// volume or liquidity component. Smaller amounts scale logarithmically.
+const scoreReferenceUSD = 1_000_000
+
+// debouncedEvents are the event types that can refire every sync while a
+// market hovers around a threshold; the others are one-offs per market or
+// per trade.
+var debouncedEvents = map[EventType]bool{
+	EventBreakingMove:   true,
+	EventPriceChange:    true,
+	EventVolumeSpike:    true,
+	EventThresholdCross: true,
+}
+
+// lastEmit records the last debounced event of one type for a market.
+type lastEmit struct {
+	at    time.Time
+	score float64
+}
+
+// scoreEvent computes the event's significance score from its change
+// magnitude, the market's volume and liquidity, and the time since the
+// market's previous event, and stores it as Metadata["score"]. It reports
+// false when the event falls within its type's per-market cooldown and
+// doesn't beat the last emitted score by EventEscalation.
+func (s *Syncer) scoreEvent(event *Event) bool {
+	now := event.Timestamp
+	marketID := event.Market.MarketID
+
+	cfg := s.cfg()
+
+	s.emitMux.Lock()
+	defer s.emitMux.Unlock()
+
+	recency := 1.0
+	if last, ok := s.lastEvent[marketID]; ok && cfg.EventCooldown > 0 {
+		recency = min(1, now.Sub(last).Seconds()/cfg.EventCooldown.Seconds())
+	}
+
+	score := scoreWeightMagnitude*eventMagnitude(event, cfg) +
+		scoreWeightVolume*scaleUSD(event.Market.Volume24h) +
+		scoreWeightLiquidity*scaleUSD(event.Market.Liquidity) +
+		scoreWeightRecency*recency
+	score = math.Round(score*1000) / 1000
+
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]interface{})
+	}
+	event.Metadata["score"] = score
+
+	if debouncedEvents[event.Type] {
+		key := marketID + ":" + string(event.Type)
+		if last, ok := s.lastEmitted[key]; ok &&
+			now.Sub(last.at) < cfg.EventCooldown &&
+			score < last.score+cfg.EventEscalation {
+			log.Debug().
+				Str("type", string(event.Type)).
+				Str("market", marketID).
+				Float64("score", score).
+				Dur("since_last", now.Sub(last.at)).
+				Msg("Event suppressed by cooldown")
+			return false
+		}
+		s.lastEmitted[key] = lastEmit{at: now, score: score}
+	}
+
+	s.lastEvent[marketID] = now
+	return true
+}
+
+// eventMagnitude rates the size of the move behind an event in [0, 1].
+func eventMagnitude(event *Event, config SyncerConfig) float64 {
+	switch event.Type {
+	case EventBreakingMove, EventPriceChange:
+		// A 20-point move is as big as it gets
+		return min(1, abs(event.Market.Change24h)/0.20)
+	case EventThresholdCross:
+		// Extreme thresholds matter more than the midpoint
+		t, _ := event.Metadata["threshold"].(float64)
+		return min(1, 0.5+abs(t-0.5)*1.25)
+	case EventVolumeSpike:
+		z, _ := event.Metadata["z_score"].(float64)
+		return clamp01(z / (3 * max(config.VolumeZScore, 1)))
+	case EventWhaleTrade:
+		m, _ := event.Metadata["multiple"].(float64)
+		return clamp01(m / 10)
+	case EventSocialSignal:
+		c, _ := event.Metadata["change"].(float64)
+		return min(1, abs(c)/0.20)
+	case EventMarketResolved:
+		return 1
+	default:
+		return 0.5
+	}
+}
+
+// scaleUSD maps a dollar amount onto [0, 1] logarithmically, reaching 1 at
+// scoreReferenceUSD.
+func scaleUSD(v float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+	return clamp01(math.Log10(1+v) / math.Log10(scoreReferenceUSD))
+}
+
+func clamp01(v float64) float64 {
+	return max(0, min(1, v))
+}
+
+// pruneEmitHistory forgets cooldown state older than the cooldown itself.
+func (s *Syncer) pruneEmitHistory(now time.Time) {
+	cooldown := s.cfg().EventCooldown
+
+	s.emitMux.Lock()
+	defer s.emitMux.Unlock()
+
+	for key, last := range s.lastEmitted {
+		if now.Sub(last.at) >= cooldown {
+			delete(s.lastEmitted, key)
+		}
+	}
+	for id, at := range s.lastEvent {
+		if now.Sub(at) >= cooldown {
+			delete(s.lastEvent, id)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"time"
+)
+
+// staleSyncIntervals is how many sync intervals may pass without a
+// successful sync before the syncer reports itself degraded.
+const staleSyncIntervals = 3
+
+// Sync health states reported by Status.
+const (
+	SyncStateHealthy  = "healthy"
+	SyncStateDegraded = "degraded"
+)
+
+// SyncStatus reports how the syncer's market sync is keeping up.
+type SyncStatus struct {
+	State string `json:"state"`
+
+	// Last completed sync cycle, and last attempt whether or not it failed
+	LastSuccessAt time.Time `json:"last_success_at"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+
+	// Cycles failed in a row since the last success
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	IntervalSeconds float64 `json:"interval_seconds"`
+
+	// Markets kept by the last successful cycle
+	MarketsProcessed int `json:"markets_processed"`
+
+	// Events emitted between the last two cycles, by any sync loop
+	EventsLastCycle int64 `json:"events_last_cycle"`
+
+	// Polymarket API errors in the last cycle's window and since start
+	APIErrorsLastCycle int64 `json:"api_errors_last_cycle"`
+	APIErrorsTotal     int64 `json:"api_errors_total"`
+
+	// Markets held in the syncer's cache
+	CacheSize int `json:"cache_size"`
+
+	Writes SyncStats `json:"writes"`
+}
+
+// cycleStatus is the syncer's record of its sync cycles, guarded by
+// statsMux.
+type cycleStatus struct {
+	startedAt           time.Time
+	lastSuccessAt       time.Time
+	lastAttemptAt       time.Time
+	lastError           string
+	consecutiveFailures int
+	marketsProcessed    int
+	eventsLastCycle     int64
+	apiErrorsLastCycle  int64
+}
+
+// countAPIError records a failed Polymarket API call.
+func (s *Syncer) countAPIError() {
+	s.apiErrors.Add(1)
+}
+
+// recordCycle records the end of a sync cycle. err is nil when the cycle
+// completed; markets is the number of markets it kept.
+func (s *Syncer) recordCycle(markets int, err error) {
+	total := s.apiErrors.Load()
+	events := s.cycleEvents.Swap(0)
+
+	s.statsMux.Lock()
+	defer s.statsMux.Unlock()
+
+	c := &s.cycle
+	c.lastAttemptAt = time.Now()
+	c.eventsLastCycle = events
+	c.apiErrorsLastCycle = total - s.apiErrorsSeen
+	s.apiErrorsSeen = total
+	if err != nil {
+		c.lastError = err.Error()
+		c.consecutiveFailures++
+		return
+	}
+	c.lastSuccessAt = c.lastAttemptAt
+	c.lastError = ""
+	c.consecutiveFailures = 0
+	c.marketsProcessed = markets
+}
+
+// Status reports the syncer's health. It is degraded when no sync has
+// succeeded for staleSyncIntervals intervals, counting from start if none
+// has yet.
+func (s *Syncer) Status() SyncStatus {
+	s.cacheMux.RLock()
+	cacheSize := len(s.marketCache)
+	s.cacheMux.RUnlock()
+
+	s.statsMux.RLock()
+	c := s.cycle
+	writes := s.stats
+	s.statsMux.RUnlock()
+
+	status := SyncStatus{
+		State:               SyncStateHealthy,
+		LastSuccessAt:       c.lastSuccessAt,
+		LastAttemptAt:       c.lastAttemptAt,
+		LastError:           c.lastError,
+		ConsecutiveFailures: c.consecutiveFailures,
+		IntervalSeconds:     s.cfg().SyncInterval.Seconds(),
+		MarketsProcessed:    c.marketsProcessed,
+		EventsLastCycle:     c.eventsLastCycle,
+		APIErrorsLastCycle:  c.apiErrorsLastCycle,
+		APIErrorsTotal:      s.apiErrors.Load(),
+		CacheSize:           cacheSize,
+		Writes:              writes,
+	}
+
+	since := c.lastSuccessAt
+	if since.IsZero() {
+		since = c.startedAt
+	}
+	if !since.IsZero() && time.Since(since) > staleSyncIntervals*s.cfg().SyncInterval {
+		status.State = SyncStateDegraded
+	}
+	return status
+}
@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"sync"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// subscriberQueueSize is how many undelivered events a subscriber holds
+// before lower-priority ones are dropped.
+const subscriberQueueSize = 100
+
+// EventPriority orders events for a subscriber that falls behind: when
+// its queue is full, the oldest event of the lowest priority goes first.
+type EventPriority int
+
+const (
+	// PriorityLow events are dropped first.
+	PriorityLow EventPriority = iota
+	PriorityNormal
+	PriorityHigh
+	// PriorityCritical events are never dropped; a full queue grows to
+	// hold them.
+	PriorityCritical
+)
+
+// Priority returns the delivery priority of an event type.
+func (t EventType) Priority() EventPriority {
+	switch t {
+	case EventBreakingMove, EventMarketResolved:
+		return PriorityCritical
+	case EventNewMarket, EventThresholdCross:
+		return PriorityHigh
+	case EventVolumeSpike, EventWhaleTrade, EventSocialSignal:
+		return PriorityNormal
+	default:
+		return PriorityLow
+	}
+}
+
+// subscription queues events for one subscriber and delivers them in
+// emission order on out, so a slow subscriber never blocks the dispatcher.
+type subscription struct {
+	name    string // metrics label; the durable subscriber name if durable
+	durable bool
+	out     chan Event
+
+	mu     sync.Mutex
+	queue  []Event
+	wake   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newSubscription(name string, durable bool) *subscription {
+	q := &subscription{
+		name:    name,
+		durable: durable,
+		out:     make(chan Event),
+		wake:    make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// push queues an event. If the queue is full it drops the oldest queued
+// event of the lowest priority below critical, or the event itself if
+// every queued event outranks it. It returns the type of the event
+// dropped, if any.
+func (q *subscription) push(event Event) (dropped EventType, ok bool) {
+	q.mu.Lock()
+	defer func() {
+		q.mu.Unlock()
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}()
+
+	if len(q.queue) < subscriberQueueSize {
+		q.queue = append(q.queue, event)
+		return "", false
+	}
+
+	victim := -1
+	lowest := event.Type.Priority()
+	for i, e := range q.queue {
+		if p := e.Type.Priority(); p < lowest || (victim < 0 && p == lowest) {
+			victim, lowest = i, p
+		}
+	}
+	switch {
+	case lowest == PriorityCritical:
+		// Only critical events queued and incoming: grow
+		q.queue = append(q.queue, event)
+		return "", false
+	case victim < 0:
+		return event.Type, true
+	default:
+		dropped = q.queue[victim].Type
+		q.queue = append(q.queue[:victim], q.queue[victim+1:]...)
+		q.queue = append(q.queue, event)
+		return dropped, true
+	}
+}
+
+// run delivers queued events until the subscription is closed, then
+// closes out.
+func (q *subscription) run() {
+	defer close(q.out)
+	for {
+		event, ok := q.next()
+		if !ok {
+			return
+		}
+		select {
+		case q.out <- event:
+		case <-q.closed:
+			return
+		}
+	}
+}
+
+// next waits for the oldest queued event.
+func (q *subscription) next() (Event, bool) {
+	for {
+		q.mu.Lock()
+		if len(q.queue) > 0 {
+			event := q.queue[0]
+			q.queue[0] = Event{}
+			q.queue = q.queue[1:]
+			q.mu.Unlock()
+			return event, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.wake:
+		case <-q.closed:
+			return Event{}, false
+		}
+	}
+}
+
+// close stops delivery; out is closed once the delivery goroutine exits.
+// Undelivered events are discarded.
+func (q *subscription) close() {
+	q.once.Do(func() { close(q.closed) })
+}
+
+// dispatch queues an event for every subscriber, counting what full
+// queues drop. The caller holds eventMux.
+func (s *Syncer) dispatch(event Event) {
+	for _, sub := range s.subscribers {
+		dropped, ok := sub.push(event)
+		if !ok {
+			continue
+		}
+		log.Warn().
+			Str("subscriber", sub.name).
+			Str("dropped", string(dropped)).
+			Msg("Subscriber queue full, dropping event")
+		metrics.EventsDropped.WithLabelValues("dispatch").Inc()
+		metrics.SubscriberEventsDropped.WithLabelValues(sub.name, string(dropped)).Inc()
+	}
+}
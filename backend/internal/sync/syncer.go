@@ -5,12 +5,19 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/metrics"
 	"github.com/leeaandrob/futuresignals/internal/models"
 	"github.com/leeaandrob/futuresignals/internal/polymarket"
 	"github.com/leeaandrob/futuresignals/internal/storage"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Event types for the event bus.
@@ -23,15 +30,66 @@ const (
 	EventVolumeSpike    EventType = "volume_spike"
 	EventThresholdCross EventType = "threshold_cross"
 	EventTrendingUpdate EventType = "trending_update"
+	EventMarketResolved EventType = "market_resolved"
+	EventWhaleTrade     EventType = "whale_trade"
+	EventSocialSignal   EventType = "social_signal"
 )
 
 // Event represents a market event.
 type Event struct {
+	ID        string // Persisted event ID; empty if it couldn't be stored
 	Type      EventType
 	Market    *models.Market
 	Previous  *models.Snapshot
 	Timestamp time.Time
 	Metadata  map[string]interface{}
+
+	// SpanContext links handlers to the sync cycle that emitted the event
+	SpanContext trace.SpanContext
+}
+
+// Significance grades how notable the event is, for consumers that filter
+// out minor moves.
+func (e Event) Significance() models.Significance {
+	switch e.Type {
+	case EventBreakingMove:
+		change := 0.0
+		if e.Market != nil {
+			change = abs(e.Market.Change24h)
+		}
+		switch {
+		case change >= 0.15:
+			return models.SignificanceBreaking
+		case change >= 0.10:
+			return models.SignificanceHigh
+		default:
+			return models.SignificanceMedium
+		}
+	case EventVolumeSpike:
+		if m, ok := e.Metadata["multiplier"].(float64); ok && m >= 5 {
+			return models.SignificanceHigh
+		}
+		return models.SignificanceMedium
+	case EventThresholdCross:
+		if t, ok := e.Metadata["threshold"].(float64); ok && t >= 0.90 {
+			return models.SignificanceHigh
+		}
+		return models.SignificanceMedium
+	case EventMarketResolved:
+		return models.SignificanceHigh
+	case EventSocialSignal:
+		if c, ok := e.Metadata["change"].(float64); ok && abs(c) >= 0.10 {
+			return models.SignificanceHigh
+		}
+		return models.SignificanceMedium
+	case EventWhaleTrade:
+		if m, ok := e.Metadata["multiple"].(float64); ok && m >= 5 {
+			return models.SignificanceHigh
+		}
+		return models.SignificanceMedium
+	default:
+		return models.SignificanceLow
+	}
 }
 
 // SyncerConfig holds configuration for the syncer.
@@ -42,11 +100,62 @@ type SyncerConfig struct {
 	// How often to take snapshots
 	SnapshotInterval time.Duration
 
+	// How often to recompute hourly/daily snapshot rollups
+	RollupInterval time.Duration
+
+	// How often to look up markets that dropped out of the sync feed, and
+	// how many to look up per pass
+	ResolutionInterval  time.Duration
+	ResolutionBatchSize int
+
+	// How often to send uncategorized or ambiguous markets to the LLM, how
+	// many go in one request, and how many are classified per pass
+	ClassifyInterval  time.Duration
+	ClassifyBatchSize int
+	ClassifyMaxPerRun int
+
+	// How often to refresh order books, and for how many of the highest
+	// volume markets
+	OrderBookInterval   time.Duration
+	OrderBookMaxMarkets int
+
+	// How often to ingest trades, for how many of the highest volume
+	// markets, and the trade size in USD that counts as a whale trade
+	TradeInterval   time.Duration
+	TradeMaxMarkets int
+	WhaleTradeUSD   float64
+
+	// How often to refresh holder concentration, for how many of the
+	// highest volume markets
+	HolderInterval   time.Duration
+	HolderMaxMarkets int
+
 	// Thresholds for event detection
 	BreakingThreshold   float64 // e.g., 0.05 = 5% change
-	VolumeMultiplier    float64 // e.g., 3.0 = 3x normal volume
+	VolumeZScore        float64 // e.g., 3.0 = 3 standard deviations above the baseline
 	TrendingThreshold   float64 // Minimum trending score
 
+	// Trending score weights, and the market age at which the recency
+	// boost is halved
+	TrendingWeights  models.TrendingWeights
+	TrendingHalfLife time.Duration
+
+	// Volume spike baselines: the window of hourly rollups a market's
+	// baseline 24h volume is drawn from, and how many it needs
+	VolumeBaselineWindow     time.Duration
+	VolumeBaselineMinSamples int
+
+	// Noise filtering for breaking moves: the liquidity in USD a market
+	// needs, and how many consecutive sync cycles the move must hold
+	BreakingMinLiquidity  float64
+	BreakingConfirmCycles int
+
+	// Debounce for repeating events: a market's event of one type is
+	// suppressed within EventCooldown of the last unless its significance
+	// score beats the last one by EventEscalation
+	EventCooldown   time.Duration
+	EventEscalation float64
+
 	// Cleanup
 	SnapshotRetention time.Duration // How long to keep snapshots
 
@@ -59,29 +168,119 @@ func DefaultSyncerConfig() SyncerConfig {
 	return SyncerConfig{
 		SyncInterval:        30 * time.Second,
 		SnapshotInterval:    5 * time.Minute,
+		RollupInterval:      1 * time.Hour,
+		ResolutionInterval:  10 * time.Minute,
+		ResolutionBatchSize: 25,
+		ClassifyInterval:    15 * time.Minute,
+		ClassifyBatchSize:   20,
+		ClassifyMaxPerRun:   100,
+		OrderBookInterval:   2 * time.Minute,
+		OrderBookMaxMarkets: 50,
+		TradeInterval:       1 * time.Minute,
+		TradeMaxMarkets:     25,
+		WhaleTradeUSD:       10000,
+		HolderInterval:      30 * time.Minute,
+		HolderMaxMarkets:    50,
 		BreakingThreshold:   0.05,
-		VolumeMultiplier:    3.0,
+		VolumeZScore:        3.0,
 		TrendingThreshold:   50.0,
+		TrendingWeights:     models.DefaultTrendingWeights(),
+		TrendingHalfLife:    24 * time.Hour,
+		VolumeBaselineWindow: 7 * 24 * time.Hour,
+		VolumeBaselineMinSamples: 24,
+		BreakingMinLiquidity: 10000,
+		BreakingConfirmCycles: 2,
+		EventCooldown:       1 * time.Hour,
+		EventEscalation:     0.15,
 		SnapshotRetention:   7 * 24 * time.Hour,
 		MinVolume24h:        10000,
 	}
 }
 
+// SyncStats tracks the syncer's batched market writes.
+type SyncStats struct {
+	LastSyncAt          time.Time     `json:"last_sync_at"`
+	LastBatchSize       int           `json:"last_batch_size"`
+	LastBatchLatencyMs  int64         `json:"last_batch_latency_ms"`
+	TotalBatches        int64         `json:"total_batches"`
+	TotalMarketsWritten int64         `json:"total_markets_written"`
+	TotalWriteErrors    int64         `json:"total_write_errors"`
+}
+
+// Store is the storage the syncer writes markets, snapshots and events to.
+// *storage.Store implements it, as does storage.MemoryStore.
+type Store interface {
+	storage.MarketStore
+	storage.SnapshotStore
+	storage.EventStore
+}
+
 // Syncer continuously syncs market data from Polymarket.
 type Syncer struct {
 	client *polymarket.Client
-	store  *storage.Store
+	store  Store
 	config SyncerConfig
 
+	// Configured values that runtime settings override; the overridable
+	// fields of config are guarded by configMux
+	base            SyncerConfig
+	configMux       sync.RWMutex
+	intervalChanged chan struct{}
+
 	// Event channels
 	events     chan Event
 	eventMux   sync.RWMutex
-	subscribers []chan Event
+	subscribers []*subscription
+
+	// Durable subscriber state
+	offsets   map[string]*offsetState
+	offsetMux sync.Mutex
+	replayMux sync.Mutex
 
 	// Market state cache
 	marketCache   map[string]*models.Market
 	cacheMux      sync.RWMutex
 
+	// Last resolution lookup per market
+	resolutionChecks map[string]time.Time
+	resolutionMux    sync.Mutex
+
+	// LLM categorization; llm is nil when only keywords are used
+	llm              llm.Provider
+	llmCategories    map[string]string // market_id -> LLM-assigned category
+	classifyAttempts map[string]time.Time
+	classifyMux      sync.Mutex
+
+	// Debounce state for scoreEvent
+	lastEmitted map[string]lastEmit  // market_id:type -> last emitted event
+	lastEvent   map[string]time.Time // market_id -> last event of any type
+	emitMux     sync.Mutex
+
+	// Consecutive cycles each market's move has held past the breaking
+	// threshold, negative for drops
+	breakingStreaks map[string]int
+	streakMux       sync.Mutex
+
+	// 24h volume baselines for volume spikes, rebuilt after each rollup
+	volumeBaselines map[string]volumeBaseline
+	baselineMux     sync.RWMutex
+
+	// Rolling trade windows per market; used only by tradeFlowLoop
+	trades map[string]*tradeWindow
+
+	// Sync write stats and cycle health
+	stats    SyncStats
+	cycle    cycleStatus
+	statsMux sync.RWMutex
+
+	// Polymarket API errors and emitted events, counted for Status
+	apiErrors     atomic.Int64
+	apiErrorsSeen int64 // apiErrors at the last cycle; guarded by statsMux
+	cycleEvents   atomic.Int64
+
+	// API read cache, invalidated after each market batch (nil when off)
+	cache cache.Cache
+
 	// Lifecycle
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -89,38 +288,76 @@ type Syncer struct {
 }
 
 // NewSyncer creates a new market syncer.
-func NewSyncer(client *polymarket.Client, store *storage.Store, config SyncerConfig) *Syncer {
+func NewSyncer(client *polymarket.Client, store Store, config SyncerConfig) *Syncer {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Syncer{
-		client:      client,
-		store:       store,
-		config:      config,
-		events:      make(chan Event, 1000),
-		subscribers: make([]chan Event, 0),
-		marketCache: make(map[string]*models.Market),
-		ctx:         ctx,
-		cancel:      cancel,
+		client:           client,
+		store:            store,
+		config:           config,
+		base:             config,
+		intervalChanged:  make(chan struct{}, 1),
+		events:           make(chan Event, 1000),
+		subscribers:      make([]*subscription, 0),
+		offsets:          make(map[string]*offsetState),
+		marketCache:      make(map[string]*models.Market),
+		resolutionChecks: make(map[string]time.Time),
+		llmCategories:    make(map[string]string),
+		classifyAttempts: make(map[string]time.Time),
+		trades:           make(map[string]*tradeWindow),
+		breakingStreaks:  make(map[string]int),
+		volumeBaselines:  make(map[string]volumeBaseline),
+		lastEmitted:      make(map[string]lastEmit),
+		lastEvent:        make(map[string]time.Time),
+		ctx:              ctx,
+		cancel:           cancel,
 	}
 }
 
-// Subscribe returns a channel that receives market events.
-func (s *Syncer) Subscribe() <-chan Event {
+// SetCache invalidates the API's cached market reads whenever a sync
+// cycle writes markets.
+func (s *Syncer) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// Subscribe returns a channel that receives market events. name labels
+// the subscriber's dropped-event metrics. Events queue while the
+// subscriber is busy; past subscriberQueueSize, lower-priority events are
+// dropped to make room (see EventType.Priority).
+func (s *Syncer) Subscribe(name string) <-chan Event {
 	s.eventMux.Lock()
 	defer s.eventMux.Unlock()
 
-	ch := make(chan Event, 100)
-	s.subscribers = append(s.subscribers, ch)
-	return ch
+	sub := newSubscription(name, false)
+	s.subscribers = append(s.subscribers, sub)
+	return sub.out
+}
+
+// Unsubscribe removes and closes a channel returned by Subscribe.
+func (s *Syncer) Unsubscribe(sub <-chan Event) {
+	s.eventMux.Lock()
+	defer s.eventMux.Unlock()
+
+	for i, q := range s.subscribers {
+		if q.out == sub {
+			s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+			q.close()
+			return
+		}
+	}
 }
 
 // Start begins the sync loops.
 func (s *Syncer) Start() {
 	log.Info().
-		Dur("sync_interval", s.config.SyncInterval).
+		Dur("sync_interval", s.cfg().SyncInterval).
 		Dur("snapshot_interval", s.config.SnapshotInterval).
 		Msg("Starting market syncer")
 
+	s.statsMux.Lock()
+	s.cycle.startedAt = time.Now()
+	s.statsMux.Unlock()
+
 	// Load existing markets into cache
 	s.loadMarketCache()
 
@@ -139,6 +376,32 @@ func (s *Syncer) Start() {
 	// Start the cleanup loop
 	s.wg.Add(1)
 	go s.cleanupLoop()
+
+	// Start the snapshot rollup loop
+	s.wg.Add(1)
+	go s.rollupLoop()
+
+	// Start the resolution loop
+	s.wg.Add(1)
+	go s.resolutionLoop()
+
+	// Start the order book loop
+	s.wg.Add(1)
+	go s.orderBookLoop()
+
+	// Start the trade flow loop
+	s.wg.Add(1)
+	go s.tradeFlowLoop()
+
+	// Start the holder concentration loop
+	s.wg.Add(1)
+	go s.holderLoop()
+
+	// Start the LLM classification loop
+	if s.llm != nil {
+		s.wg.Add(1)
+		go s.classificationLoop()
+	}
 }
 
 // Stop stops the syncer.
@@ -150,9 +413,10 @@ func (s *Syncer) Stop() {
 
 	// Close subscriber channels
 	s.eventMux.Lock()
-	for _, ch := range s.subscribers {
-		close(ch)
+	for _, q := range s.subscribers {
+		q.close()
 	}
+	s.subscribers = nil
 	s.eventMux.Unlock()
 }
 
@@ -166,9 +430,14 @@ func (s *Syncer) loadMarketCache() {
 
 	s.cacheMux.Lock()
 	defer s.cacheMux.Unlock()
+	s.classifyMux.Lock()
+	defer s.classifyMux.Unlock()
 
 	for i := range markets {
 		s.marketCache[markets[i].MarketID] = &markets[i]
+		if markets[i].CategorySource == models.CategorySourceLLM {
+			s.llmCategories[markets[i].MarketID] = markets[i].Category
+		}
 	}
 
 	log.Info().Int("markets", len(markets)).Msg("Loaded market cache")
@@ -178,7 +447,7 @@ func (s *Syncer) loadMarketCache() {
 func (s *Syncer) syncLoop() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.config.SyncInterval)
+	ticker := time.NewTicker(s.cfg().SyncInterval)
 	defer ticker.Stop()
 
 	// Initial sync
@@ -190,6 +459,8 @@ func (s *Syncer) syncLoop() {
 			return
 		case <-ticker.C:
 			s.syncMarkets()
+		case <-s.intervalChanged:
+			ticker.Reset(s.cfg().SyncInterval)
 		}
 	}
 }
@@ -197,40 +468,76 @@ func (s *Syncer) syncLoop() {
 // syncMarkets fetches and processes market data.
 func (s *Syncer) syncMarkets() {
 	log.Debug().Msg("Syncing markets")
+	start := time.Now()
+
+	ctx, span := tracing.Start(s.ctx, "sync.cycle")
+	defer span.End()
 
 	// Fetch top events by volume to get correct event slugs for URLs
 	active := true
 	closed := false
-	events, err := s.client.GetEvents(s.ctx, polymarket.EventFilters{
+	fetchCtx, fetchSpan := tracing.Start(ctx, "polymarket.GetEvents")
+	events, err := s.client.GetEvents(fetchCtx, polymarket.EventFilters{
 		Active:    &active,
 		Closed:    &closed,
 		Limit:     100,
 		Order:     "volume24hr",
 		Ascending: false,
 	})
+	fetchSpan.SetAttributes(attribute.Int("events", len(events)))
+	tracing.End(fetchSpan, err)
 	if err != nil {
+		span.RecordError(err)
 		log.Error().Err(err).Msg("Failed to fetch events")
+		metrics.SyncCycles.WithLabelValues("error").Inc()
+		s.countAPIError()
+		s.recordCycle(0, err)
 		return
 	}
 
 	log.Debug().Int("count", len(events)).Msg("Fetched events from Polymarket")
 
 	// Process all markets from events with correct event slugs and event volume
+	var batch []*models.Market
 	for _, event := range events {
 		for _, pm := range event.Markets {
-			s.processMarketWithEvent(pm, event)
+			if market := s.processMarketWithEvent(ctx, pm, event); market != nil {
+				batch = append(batch, market)
+			}
 		}
 	}
 
+	// Save the whole cycle in one round trip
+	s.saveBatch(ctx, batch)
+	s.saveEvents(ctx, events)
+
 	// Update trending scores
 	s.updateTrendingScores()
+
+	s.recordCycle(len(batch), nil)
+	metrics.SyncCycles.WithLabelValues("ok").Inc()
+	metrics.SyncDuration.Observe(time.Since(start).Seconds())
 }
 
 // processMarketWithEvent processes a single market update with full event data.
-func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.Event) {
+// It returns the converted market for the caller to persist, or nil if the
+// market was skipped.
+func (s *Syncer) processMarketWithEvent(ctx context.Context, pm polymarket.Market, event polymarket.Event) *models.Market {
+	// A tracked market closing means it has resolved (or is about to),
+	// regardless of its volume
+	if pm.Closed {
+		s.cacheMux.RLock()
+		existing, tracked := s.marketCache[pm.ID]
+		s.cacheMux.RUnlock()
+		if tracked && s.claimResolutionCheck(pm.ID, s.config.ResolutionInterval) {
+			s.resolveMarket(ctx, existing, &pm)
+		}
+		return nil
+	}
+
 	// Skip low volume markets
-	if pm.Volume24hr < s.config.MinVolume24h {
-		return
+	if pm.Volume24hr < s.cfg().MinVolume24h {
+		return nil
 	}
 
 	// Convert to our model with event data (slug + volumes)
@@ -244,7 +551,7 @@ func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.E
 	if !exists {
 		// New market detected
 		market.FirstSeenAt = time.Now()
-		s.emitEvent(Event{
+		s.emitEvent(ctx, Event{
 			Type:      EventNewMarket,
 			Market:    market,
 			Timestamp: time.Now(),
@@ -253,11 +560,18 @@ func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.E
 		// Preserve firstSeenAt and track previous probability
 		market.FirstSeenAt = existing.FirstSeenAt
 		market.PreviousProb = existing.Probability
+		market.OrderBook = existing.OrderBook
+		market.TradeFlow = existing.TradeFlow
+		market.Holders = existing.Holders
+		market.Change1h = existing.Change1h
+		market.Change6h = existing.Change6h
+		market.Volume1h = existing.Volume1h
 		// Note: Change24h is already set from Polymarket API's oneDayPriceChange
 
-		// Check for breaking move using API-provided 24h change
-		if abs(market.Change24h) >= s.config.BreakingThreshold {
-			s.emitEvent(Event{
+		// Check for breaking move using API-provided 24h change, once it has
+		// held long enough in a liquid market
+		if cycles, ok := s.confirmBreaking(market); ok {
+			s.emitEvent(ctx, Event{
 				Type:      EventBreakingMove,
 				Market:    market,
 				Timestamp: time.Now(),
@@ -265,20 +579,22 @@ func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.E
 					"change":       market.Change24h,
 					"previous":     existing.Probability,
 					"current":      market.Probability,
+					"cycles":       cycles,
 				},
 			})
 		}
 
-		// Check for volume spike
-		if existing.Volume24h > 0 && market.Volume24h/existing.Volume24h >= s.config.VolumeMultiplier {
-			s.emitEvent(Event{
+		// Check for volume spike against the market's rolling baseline
+		if z, multiple, spike := s.volumeSpike(market); spike {
+			s.emitEvent(ctx, Event{
 				Type:      EventVolumeSpike,
 				Market:    market,
 				Timestamp: time.Now(),
 				Metadata: map[string]interface{}{
-					"previous_volume": existing.Volume24h,
+					"baseline_volume": s.baselineVolume(market.MarketID),
 					"current_volume":  market.Volume24h,
-					"multiplier":      market.Volume24h / existing.Volume24h,
+					"multiplier":      multiple,
+					"z_score":         z,
 				},
 			})
 		}
@@ -287,7 +603,7 @@ func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.E
 		thresholds := []float64{0.50, 0.75, 0.90}
 		for _, t := range thresholds {
 			if crossedThreshold(existing.Probability, market.Probability, t) {
-				s.emitEvent(Event{
+				s.emitEvent(ctx, Event{
 					Type:      EventThresholdCross,
 					Market:    market,
 					Timestamp: time.Now(),
@@ -300,21 +616,65 @@ func (s *Syncer) processMarketWithEvent(pm polymarket.Market, event polymarket.E
 		}
 	}
 
+	// Score once the carried-over past-hour volume is in place
+	market.TrendingScore = s.trendingScorer().Score(market, time.Now())
+
 	// Update cache
 	s.cacheMux.Lock()
 	s.marketCache[market.MarketID] = market
 	s.cacheMux.Unlock()
 
-	// Save to database
-	if err := s.store.UpsertMarket(s.ctx, market); err != nil {
-		log.Error().Err(err).Str("market_id", market.MarketID).Msg("Failed to save market")
+	return market
+}
+
+// saveBatch persists one sync cycle's markets with a single bulk write.
+func (s *Syncer) saveBatch(ctx context.Context, batch []*models.Market) {
+	if len(batch) == 0 {
+		return
+	}
+
+	start := time.Now()
+	result, err := s.store.BulkUpsertMarkets(ctx, batch)
+	latency := time.Since(start)
+
+	metrics.SyncBatchSize.Observe(float64(len(batch)))
+	metrics.SyncBatchLatency.Observe(latency.Seconds())
+
+	s.statsMux.Lock()
+	s.stats.LastSyncAt = time.Now()
+	s.stats.LastBatchSize = len(batch)
+	s.stats.LastBatchLatencyMs = latency.Milliseconds()
+	s.stats.TotalBatches++
+	s.stats.TotalMarketsWritten += int64(len(batch))
+	if result != nil {
+		s.stats.TotalWriteErrors += int64(result.Failed)
+	}
+	s.statsMux.Unlock()
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Int("batch_size", len(batch)).
+			Dur("latency", latency).
+			Msg("Failed to save market batch")
+		return
 	}
+
+	// Markets appear in market lists, category pages and the home feed
+	cache.Invalidate(ctx, s.cache, cache.GroupMarkets, cache.GroupCategories, cache.GroupFeed)
+
+	log.Debug().
+		Int("batch_size", len(batch)).
+		Int64("upserted", result.Upserted).
+		Int64("modified", result.Modified).
+		Dur("latency", latency).
+		Msg("Market batch saved")
 }
 
 // processMarket processes a single market update (legacy, without event slug).
 func (s *Syncer) processMarket(pm polymarket.Market) {
 	// Skip low volume markets
-	if pm.Volume24hr < s.config.MinVolume24h {
+	if pm.Volume24hr < s.cfg().MinVolume24h {
 		return
 	}
 
@@ -329,7 +689,7 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 	if !exists {
 		// New market detected
 		market.FirstSeenAt = time.Now()
-		s.emitEvent(Event{
+		s.emitEvent(s.ctx, Event{
 			Type:      EventNewMarket,
 			Market:    market,
 			Timestamp: time.Now(),
@@ -338,11 +698,18 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 		// Preserve firstSeenAt and track previous probability
 		market.FirstSeenAt = existing.FirstSeenAt
 		market.PreviousProb = existing.Probability
+		market.OrderBook = existing.OrderBook
+		market.TradeFlow = existing.TradeFlow
+		market.Holders = existing.Holders
+		market.Change1h = existing.Change1h
+		market.Change6h = existing.Change6h
+		market.Volume1h = existing.Volume1h
 		// Note: Change24h is already set from Polymarket API's oneDayPriceChange
 
-		// Check for breaking move using API-provided 24h change
-		if abs(market.Change24h) >= s.config.BreakingThreshold {
-			s.emitEvent(Event{
+		// Check for breaking move using API-provided 24h change, once it has
+		// held long enough in a liquid market
+		if cycles, ok := s.confirmBreaking(market); ok {
+			s.emitEvent(s.ctx, Event{
 				Type:      EventBreakingMove,
 				Market:    market,
 				Timestamp: time.Now(),
@@ -350,20 +717,22 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 					"change":       market.Change24h,
 					"previous":     existing.Probability,
 					"current":      market.Probability,
+					"cycles":       cycles,
 				},
 			})
 		}
 
-		// Check for volume spike
-		if existing.Volume24h > 0 && market.Volume24h/existing.Volume24h >= s.config.VolumeMultiplier {
-			s.emitEvent(Event{
+		// Check for volume spike against the market's rolling baseline
+		if z, multiple, spike := s.volumeSpike(market); spike {
+			s.emitEvent(s.ctx, Event{
 				Type:      EventVolumeSpike,
 				Market:    market,
 				Timestamp: time.Now(),
 				Metadata: map[string]interface{}{
-					"previous_volume": existing.Volume24h,
+					"baseline_volume": s.baselineVolume(market.MarketID),
 					"current_volume":  market.Volume24h,
-					"multiplier":      market.Volume24h / existing.Volume24h,
+					"multiplier":      multiple,
+					"z_score":         z,
 				},
 			})
 		}
@@ -372,7 +741,7 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 		thresholds := []float64{0.50, 0.75, 0.90}
 		for _, t := range thresholds {
 			if crossedThreshold(existing.Probability, market.Probability, t) {
-				s.emitEvent(Event{
+				s.emitEvent(s.ctx, Event{
 					Type:      EventThresholdCross,
 					Market:    market,
 					Timestamp: time.Now(),
@@ -385,6 +754,9 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 		}
 	}
 
+	// Score once the carried-over past-hour volume is in place
+	market.TrendingScore = s.trendingScorer().Score(market, time.Now())
+
 	// Update cache
 	s.cacheMux.Lock()
 	s.marketCache[market.MarketID] = market
@@ -396,6 +768,60 @@ func (s *Syncer) processMarket(pm polymarket.Market) {
 	}
 }
 
+// saveEvents stores the fetched events, which group the synced markets.
+func (s *Syncer) saveEvents(ctx context.Context, events []polymarket.Event) {
+	batch := make([]*models.PolymarketEvent, 0, len(events))
+	for _, event := range events {
+		batch = append(batch, convertEvent(event))
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	if _, err := s.store.BulkUpsertPolymarketEvents(ctx, batch); err != nil {
+		log.Error().Err(err).Int("events", len(batch)).Msg("Failed to save events")
+	}
+}
+
+// convertEvent converts a Polymarket event to our model.
+func convertEvent(event polymarket.Event) *models.PolymarketEvent {
+	var tags []models.PolymarketTag
+	for _, tag := range event.Tags {
+		tags = append(tags, models.PolymarketTag{Label: tag.Label, Slug: tag.Slug})
+	}
+	marketIDs := make([]string, 0, len(event.Markets))
+	for _, pm := range event.Markets {
+		marketIDs = append(marketIDs, pm.ID)
+	}
+
+	return &models.PolymarketEvent{
+		EventID:          event.ID,
+		Slug:             event.Slug,
+		SeriesSlug:       event.SeriesSlug,
+		Title:            event.Title,
+		Description:      event.Description,
+		Image:            event.Image,
+		Icon:             event.Icon,
+		PolymarketTags:   tags,
+		Volume:           event.Volume,
+		Volume24h:        event.Volume24hr,
+		Volume7d:         event.Volume1wk,
+		Liquidity:        event.Liquidity,
+		CommentCount:     event.CommentCount,
+		CompetitorCount:  event.CompetitorCount,
+		ResolutionSource: event.ResolutionSource,
+		Active:           event.Active,
+		Closed:           event.Closed,
+		StartDate:        event.StartDate,
+		EndDate:          event.EndDate,
+		StartDateTS:      models.ParseMarketDate(event.StartDate),
+		EndDateTS:        models.ParseMarketDate(event.EndDate),
+		NegRisk:          event.NegRisk,
+		MarketIDs:        marketIDs,
+		PolymarketURL:    "https://polymarket.com/event/" + event.Slug,
+	}
+}
+
 // convertMarketWithEvent converts a Polymarket market to our model with full event data.
 func (s *Syncer) convertMarketWithEvent(pm polymarket.Market, event polymarket.Event) *models.Market {
 	// Convert outcome prices from strings to floats
@@ -451,6 +877,8 @@ func (s *Syncer) convertMarketWithEvent(pm polymarket.Market, event polymarket.E
 		EventVolume24h: event.Volume24hr,
 
 		// Event data
+		EventID:      event.ID,
+		EventSlug:    event.Slug,
 		EventTitle:   event.Title,
 		CommentCount: event.CommentCount,
 		SeriesSlug:   event.SeriesSlug,
@@ -466,6 +894,8 @@ func (s *Syncer) convertMarketWithEvent(pm polymarket.Market, event polymarket.E
 		AcceptingBid: pm.AcceptingOrders,
 		StartDate:    pm.StartDate,
 		EndDate:      pm.EndDate,
+		StartDateTS:  models.ParseMarketDate(pm.StartDate),
+		EndDateTS:    models.ParseMarketDate(pm.EndDate),
 
 		// Resolution
 		ResolutionSource: pm.ResolutionSource,
@@ -474,6 +904,7 @@ func (s *Syncer) convertMarketWithEvent(pm polymarket.Market, event polymarket.E
 		// Outcomes
 		Outcomes:      []string(pm.Outcomes),
 		OutcomePrices: outcomePrices,
+		ClobTokenIDs:  []string(pm.ClobTokenIds),
 
 		// Meta
 		UpdatedAt:     time.Now(),
@@ -481,13 +912,12 @@ func (s *Syncer) convertMarketWithEvent(pm polymarket.Market, event polymarket.E
 	}
 
 	// Detect category
-	market.Category = market.DetectCategory()
+	s.categorize(market)
 
 	// Generate slug
 	market.Slug = market.GenerateSlug()
 
-	// Calculate trending score
-	market.TrendingScore = market.CalculateTrendingScore()
+	market.SetTiers()
 
 	return market
 }
@@ -518,35 +948,47 @@ func (s *Syncer) convertMarket(pm polymarket.Market) *models.Market {
 		Closed:         pm.Closed,
 		Archived:       false,
 		AcceptingBid:   pm.AcceptingOrders,
+		StartDate:      pm.StartDate,
 		EndDate:        pm.EndDate,
+		StartDateTS:    models.ParseMarketDate(pm.StartDate),
+		EndDateTS:      models.ParseMarketDate(pm.EndDate),
 		Outcomes:       []string(pm.Outcomes),
 		OutcomePrices:  outcomePrices,
+		ClobTokenIDs:   []string(pm.ClobTokenIds),
 		UpdatedAt:      time.Now(),
 		PolymarketURL:  "https://polymarket.com/event/" + pm.Slug,
 	}
 
 	// Detect category
-	market.Category = market.DetectCategory()
+	s.categorize(market)
 
 	// Generate slug
 	market.Slug = market.GenerateSlug()
 
-	// Calculate trending score
-	market.TrendingScore = market.CalculateTrendingScore()
+	market.SetTiers()
 
 	return market
 }
 
 // updateTrendingScores recalculates trending scores for all cached markets.
 func (s *Syncer) updateTrendingScores() {
+	scorer := s.trendingScorer()
+	now := time.Now()
+
 	s.cacheMux.Lock()
 	defer s.cacheMux.Unlock()
 
 	for _, market := range s.marketCache {
-		market.TrendingScore = market.CalculateTrendingScore()
+		market.TrendingScore = scorer.Score(market, now)
 	}
 }
 
+// trendingScorer returns a scorer with the trending weights in effect.
+func (s *Syncer) trendingScorer() models.TrendingScorer {
+	cfg := s.cfg()
+	return models.TrendingScorer{Weights: cfg.TrendingWeights, HalfLife: cfg.TrendingHalfLife}
+}
+
 // snapshotLoop takes periodic snapshots of market data.
 func (s *Syncer) snapshotLoop() {
 	defer s.wg.Done()
@@ -560,6 +1002,7 @@ func (s *Syncer) snapshotLoop() {
 			return
 		case <-ticker.C:
 			s.takeSnapshots()
+			s.refreshIntradayChanges()
 		}
 	}
 }
@@ -605,8 +1048,10 @@ func (s *Syncer) cleanupLoop() {
 	}
 }
 
-// cleanup removes old snapshots.
+// cleanup removes old snapshots and expired event cooldowns.
 func (s *Syncer) cleanup() {
+	s.pruneEmitHistory(time.Now())
+
 	deleted, err := s.store.CleanOldSnapshots(s.ctx, s.config.SnapshotRetention)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to clean old snapshots")
@@ -618,6 +1063,45 @@ func (s *Syncer) cleanup() {
 	}
 }
 
+// rollupLoop periodically downsamples snapshots into hourly and daily rollups.
+func (s *Syncer) rollupLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.RollupInterval)
+	defer ticker.Stop()
+
+	// Initial rollup so a restart doesn't leave a gap
+	s.rollupSnapshots()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.rollupSnapshots()
+		}
+	}
+}
+
+// rollupSnapshots recomputes the current and previous hourly and daily buckets.
+func (s *Syncer) rollupSnapshots() {
+	now := time.Now().UTC()
+
+	hourFrom := now.Truncate(time.Hour).Add(-time.Hour)
+	if err := s.store.RollupSnapshots(s.ctx, models.GranularityHour, hourFrom); err != nil {
+		log.Error().Err(err).Msg("Failed to roll up hourly snapshots")
+	}
+
+	dayFrom := now.Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	if err := s.store.RollupSnapshots(s.ctx, models.GranularityDay, dayFrom); err != nil {
+		log.Error().Err(err).Msg("Failed to roll up daily snapshots")
+	}
+
+	log.Debug().Msg("Snapshot rollups updated")
+
+	s.refreshVolumeBaselines()
+}
+
 // eventDispatcher dispatches events to subscribers.
 func (s *Syncer) eventDispatcher() {
 	defer s.wg.Done()
@@ -632,29 +1116,61 @@ func (s *Syncer) eventDispatcher() {
 			}
 
 			s.eventMux.RLock()
-			for _, sub := range s.subscribers {
-				select {
-				case sub <- event:
-				default:
-					log.Warn().Msg("Subscriber channel full, dropping event")
-				}
-			}
+			s.dispatch(event)
 			s.eventMux.RUnlock()
 		}
 	}
 }
 
-// emitEvent sends an event to the event channel.
-func (s *Syncer) emitEvent(event Event) {
+// emitEvent persists an event and sends it to the event channel, carrying
+// the current span so event handlers continue the same trace. Critical
+// events wait for room in the channel; others dropped here remain
+// replayable from storage.
+func (s *Syncer) emitEvent(ctx context.Context, event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if !s.scoreEvent(&event) {
+		metrics.EventsSuppressed.WithLabelValues(string(event.Type)).Inc()
+		return
+	}
+	s.persistEvent(ctx, &event)
+
+	event.SpanContext = trace.SpanContextFromContext(ctx)
+	trace.SpanFromContext(ctx).AddEvent("event.emit", trace.WithAttributes(
+		attribute.String("event.type", string(event.Type)),
+		attribute.String("market.id", event.Market.MarketID),
+	))
+
+	var wait <-chan struct{}
+	if event.Type.Priority() == PriorityCritical {
+		wait = s.ctx.Done()
+	}
+	sent := false
 	select {
 	case s.events <- event:
+		sent = true
+	default:
+		if wait != nil {
+			select {
+			case s.events <- event:
+				sent = true
+			case <-wait:
+			}
+		}
+	}
+
+	if sent {
+		metrics.EventsEmitted.WithLabelValues(string(event.Type)).Inc()
+		s.cycleEvents.Add(1)
 		log.Debug().
 			Str("type", string(event.Type)).
 			Str("market", event.Market.Question).
 			Msg("Event emitted")
-	default:
-		log.Warn().Msg("Event channel full, dropping event")
+		return
 	}
+	log.Warn().Str("type", string(event.Type)).Msg("Event channel full, dropping event")
+	metrics.EventsDropped.WithLabelValues("emit").Inc()
 }
 
 // Helper functions
@@ -689,6 +1205,13 @@ func (s *Syncer) GetCachedMarket(marketID string) (*models.Market, bool) {
 	return m, ok
 }
 
+// GetStats returns a copy of the current sync write stats.
+func (s *Syncer) GetStats() SyncStats {
+	s.statsMux.RLock()
+	defer s.statsMux.RUnlock()
+	return s.stats
+}
+
 // SyncNow forces an immediate sync of market data.
 func (s *Syncer) SyncNow() {
 	log.Info().Msg("Manual sync triggered")
@@ -0,0 +1,244 @@
+package sync
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/polymarket"
+	"github.com/leeaandrob/futuresignals/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tradeFetchLimit is how many of a market's most recent trades are fetched
+// per pass.
+const tradeFetchLimit = 500
+
+// tradeWindow holds a market's trades from the past day.
+type tradeWindow struct {
+	trades []polymarket.Trade // oldest first
+	seen   map[string]bool    // trade keys within the window
+	seeded bool               // first fetch done; later new trades may emit events
+}
+
+// tradeKey identifies a trade; one transaction can fill several orders.
+func tradeKey(t polymarket.Trade) string {
+	return t.TransactionHash + ":" + t.Asset + ":" + t.ProxyWallet + ":" + t.Side
+}
+
+// tradeFlowLoop periodically ingests trades for the highest volume markets.
+func (s *Syncer) tradeFlowLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.TradeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.ingestTrades()
+		}
+	}
+}
+
+// ingestTrades fetches recent trades for up to TradeMaxMarkets cached
+// markets, highest 24h volume first, emits EventWhaleTrade for new trades
+// over WhaleTradeUSD and stores each market's trade flow.
+func (s *Syncer) ingestTrades() {
+	ctx, span := tracing.Start(s.ctx, "sync.trades")
+	defer span.End()
+
+	s.cacheMux.RLock()
+	var markets []*models.Market
+	for _, m := range s.marketCache {
+		if m.ConditionID != "" && !m.Closed {
+			markets = append(markets, m)
+		}
+	}
+	s.cacheMux.RUnlock()
+
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Volume24h > markets[j].Volume24h
+	})
+	if len(markets) > s.config.TradeMaxMarkets {
+		markets = markets[:s.config.TradeMaxMarkets]
+	}
+	span.SetAttributes(attribute.Int("markets", len(markets)))
+
+	// Drop windows of markets no longer tracked
+	tracked := make(map[string]bool, len(markets))
+	for _, m := range markets {
+		tracked[m.MarketID] = true
+	}
+	for id := range s.trades {
+		if !tracked[id] {
+			delete(s.trades, id)
+		}
+	}
+
+	now := time.Now()
+	whales := 0
+	for _, m := range markets {
+		trades, err := s.client.GetTrades(ctx, m.ConditionID, tradeFetchLimit)
+		if err != nil {
+			s.countAPIError()
+			log.Debug().Err(err).Str("market", m.MarketID).Msg("Failed to fetch trades")
+			continue
+		}
+
+		w, ok := s.trades[m.MarketID]
+		if !ok {
+			w = &tradeWindow{seen: make(map[string]bool)}
+			s.trades[m.MarketID] = w
+		}
+		fresh := w.add(trades, now)
+
+		// Trades already on the books at the first fetch aren't news
+		if w.seeded {
+			for _, t := range fresh {
+				if t.Notional() >= s.config.WhaleTradeUSD {
+					s.emitWhaleTrade(ctx, m, t)
+					whales++
+				}
+			}
+		}
+		w.seeded = true
+
+		flow := w.flow(now, s.config.WhaleTradeUSD)
+		if err := s.store.SetMarketTradeFlow(ctx, m.MarketID, flow); err != nil {
+			log.Warn().Err(err).Str("market", m.MarketID).Msg("Failed to save trade flow")
+			continue
+		}
+		s.applyTradeFlow(m.MarketID, flow)
+	}
+
+	log.Debug().
+		Int("markets", len(markets)).
+		Int("whale_trades", whales).
+		Msg("Ingested trades")
+}
+
+// add merges fetched trades into the window, evicts trades older than a
+// day and returns the trades not seen before, oldest first.
+func (w *tradeWindow) add(trades []polymarket.Trade, now time.Time) []polymarket.Trade {
+	cutoff := now.Add(-24 * time.Hour).Unix()
+
+	var fresh []polymarket.Trade
+	for _, t := range trades {
+		key := tradeKey(t)
+		if t.Timestamp < cutoff || w.seen[key] {
+			continue
+		}
+		w.seen[key] = true
+		fresh = append(fresh, t)
+	}
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].Timestamp < fresh[j].Timestamp })
+
+	w.trades = append(w.trades, fresh...)
+	sort.SliceStable(w.trades, func(i, j int) bool { return w.trades[i].Timestamp < w.trades[j].Timestamp })
+
+	keep := w.trades[:0]
+	for _, t := range w.trades {
+		if t.Timestamp >= cutoff {
+			keep = append(keep, t)
+		} else {
+			delete(w.seen, tradeKey(t))
+		}
+	}
+	w.trades = keep
+
+	return fresh
+}
+
+// flow summarizes the window over the past hour and day.
+func (w *tradeWindow) flow(now time.Time, whaleUSD float64) *models.TradeFlow {
+	hourCutoff := now.Add(-time.Hour).Unix()
+
+	var hour, day models.TradeStats
+	for _, t := range w.trades {
+		addTrade(&day, t, whaleUSD)
+		if t.Timestamp >= hourCutoff {
+			addTrade(&hour, t, whaleUSD)
+		}
+	}
+	finishStats(&hour)
+	finishStats(&day)
+
+	return &models.TradeFlow{Hour: hour, Day: day, UpdatedAt: now}
+}
+
+func addTrade(stats *models.TradeStats, t polymarket.Trade, whaleUSD float64) {
+	notional := t.Notional()
+	if math.IsNaN(notional) || math.IsInf(notional, 0) || notional < 0 {
+		// A malformed price or size would poison every ratio
+		return
+	}
+	stats.Trades++
+	if bullish(t) {
+		stats.BuyVolume += notional
+	} else {
+		stats.SellVolume += notional
+	}
+	if notional >= whaleUSD {
+		stats.WhaleTrades++
+	}
+	stats.LargestTrade = max(stats.LargestTrade, notional)
+}
+
+func finishStats(stats *models.TradeStats) {
+	total := stats.Volume()
+	if total > 0 {
+		stats.Imbalance = (stats.BuyVolume - stats.SellVolume) / total
+	}
+	if stats.Trades > 0 {
+		stats.AvgTradeSize = total / float64(stats.Trades)
+	}
+}
+
+// bullish reports whether a trade pushes the first outcome's price up:
+// buying it, or selling another outcome.
+func bullish(t polymarket.Trade) bool {
+	buy := strings.EqualFold(t.Side, "BUY")
+	return buy == (t.OutcomeIndex == 0)
+}
+
+// emitWhaleTrade emits an EventWhaleTrade for a large trade.
+func (s *Syncer) emitWhaleTrade(ctx context.Context, m *models.Market, t polymarket.Trade) {
+	side := "sell"
+	if strings.EqualFold(t.Side, "BUY") {
+		side = "buy"
+	}
+
+	s.emitEvent(ctx, Event{
+		Type:      EventWhaleTrade,
+		Market:    m,
+		Timestamp: time.Unix(t.Timestamp, 0),
+		Metadata: map[string]interface{}{
+			"size_usd": t.Notional(),
+			"multiple": t.Notional() / s.config.WhaleTradeUSD,
+			"side":     side,
+			"outcome":  t.Outcome,
+			"price":    t.Price,
+			"bullish":  bullish(t),
+			"trader":   t.ProxyWallet,
+		},
+	})
+}
+
+// applyTradeFlow updates the cached market's trade flow.
+func (s *Syncer) applyTradeFlow(marketID string, flow *models.TradeFlow) {
+	s.cacheMux.Lock()
+	defer s.cacheMux.Unlock()
+
+	if m, ok := s.marketCache[marketID]; ok {
+		updated := *m
+		updated.TradeFlow = flow
+		s.marketCache[marketID] = &updated
+	}
+}
@@ -0,0 +1,316 @@
+// Package webhooks notifies partner endpoints when articles are published,
+// amended or taken down, so sites syndicating them through the content API
+// can refresh their copies.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/metrics"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Change events, sent as the event field and the EventHeader.
+const (
+	EventArticlePublished   = "article.published"
+	EventArticleUpdated     = "article.updated"
+	EventArticleUnpublished = "article.unpublished"
+	EventArticleDeleted     = "article.deleted"
+	EventArticleRestored    = "article.restored"
+)
+
+const (
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>" of
+	// "<t>.<body>", keyed with the shared secret.
+	SignatureHeader = "X-FutureSignals-Signature"
+
+	// EventHeader carries the notification's event.
+	EventHeader = "X-FutureSignals-Event"
+
+	// queueSize is the number of notifications buffered for delivery to
+	// each endpoint.
+	queueSize = 256
+
+	// maxAttempts is how many times a delivery is tried before it is
+	// given up; retries back off from retryBackoff.
+	maxAttempts  = 3
+	retryBackoff = 2 * time.Second
+)
+
+// Config holds content webhook configuration.
+type Config struct {
+	// https endpoints notified of every change
+	Endpoints []string
+
+	// Secret the payloads are signed with
+	Secret string
+
+	// Public API URL for content links in notifications
+	APIURL string
+
+	// Timeout for one delivery attempt
+	Timeout time.Duration
+}
+
+// DefaultConfig returns default configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout: 10 * time.Second,
+	}
+}
+
+// Notification is the JSON body posted to content webhooks. It names the
+// changed article; receivers fetch its current version from URL, which
+// answers 404 once it is unpublished or deleted.
+type Notification struct {
+	// Unique per change, for receivers to drop redelivered notifications
+	ID string `json:"id"`
+
+	Event      string    `json:"event"`
+	ArticleID  string    `json:"article_id"`
+	Slug       string    `json:"slug"`
+	URL        string    `json:"url"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Notifier posts change notifications to the configured endpoints from a
+// queue per endpoint, so callers never wait on a partner's server and a
+// slow or dead one doesn't hold up delivery to the others.
+type Notifier struct {
+	config     Config
+	httpClient *http.Client
+	endpoints  []*endpoint
+
+	// Lifecycle; draining is closed to deliver what is queued, then stop
+	ctx       context.Context
+	cancel    context.CancelFunc
+	draining  chan struct{}
+	drainOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewNotifier creates a notifier. Call Start to begin delivering.
+func NewNotifier(cfg Config) *Notifier {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultConfig().Timeout
+	}
+
+	endpoints := make([]*endpoint, len(cfg.Endpoints))
+	for i, url := range cfg.Endpoints {
+		endpoints[i] = &endpoint{url: url, queue: make(chan delivery, queueSize)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Notifier{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		endpoints:  endpoints,
+		ctx:        ctx,
+		cancel:     cancel,
+		draining:   make(chan struct{}),
+	}
+}
+
+// endpoint is a partner endpoint and its delivery queue.
+type endpoint struct {
+	url   string
+	queue chan delivery
+}
+
+// delivery is a notification encoded for posting.
+type delivery struct {
+	event string
+	slug  string
+	body  []byte
+}
+
+// Start starts delivering queued notifications, with a worker per
+// endpoint.
+func (n *Notifier) Start() {
+	log.Info().Int("endpoints", len(n.endpoints)).Msg("Starting content webhooks")
+
+	for _, ep := range n.endpoints {
+		n.wg.Add(1)
+		go n.run(ep)
+	}
+}
+
+// Drain delivers the notifications already queued, then stops. Deliveries
+// not done when ctx is done are discarded.
+func (n *Notifier) Drain(ctx context.Context) {
+	n.drainOnce.Do(func() { close(n.draining) })
+
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		queued := 0
+		for _, ep := range n.endpoints {
+			queued += len(ep.queue)
+		}
+		log.Warn().Int("queued", queued).Msg("Content webhook drain timed out, discarding queued notifications")
+		n.cancel()
+		<-done
+	}
+	n.cancel()
+}
+
+// PublishArticle notifies that article was published.
+func (n *Notifier) PublishArticle(ctx context.Context, article *models.Article) {
+	n.NotifyArticle(ctx, EventArticlePublished, article)
+}
+
+// UpdateArticle notifies that a published article was amended.
+func (n *Notifier) UpdateArticle(ctx context.Context, article *models.Article) {
+	n.NotifyArticle(ctx, EventArticleUpdated, article)
+}
+
+// NotifyArticle queues a notification of event for article to each
+// endpoint without blocking, dropping it for endpoints whose queue is full.
+func (n *Notifier) NotifyArticle(_ context.Context, event string, article *models.Article) {
+	notification := Notification{
+		ID:         newID(),
+		Event:      event,
+		ArticleID:  article.ID.Hex(),
+		Slug:       article.Slug,
+		URL:        strings.TrimRight(n.config.APIURL, "/") + "/api/v1/content/articles/" + article.Slug,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		log.Error().Err(err).Str("event", event).Msg("Failed to encode content webhook")
+		return
+	}
+
+	d := delivery{event: event, slug: article.Slug, body: body}
+	for _, ep := range n.endpoints {
+		select {
+		case ep.queue <- d:
+		default:
+			log.Warn().Str("endpoint", ep.url).Str("event", event).Str("article", article.Slug).Msg("Content webhook queue full, dropping notification")
+			metrics.ContentWebhooks.WithLabelValues("dropped").Inc()
+		}
+	}
+}
+
+// run delivers ep's queued notifications, in order.
+func (n *Notifier) run(ep *endpoint) {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case d := <-ep.queue:
+			n.deliver(ep, d)
+		case <-n.draining:
+			for {
+				select {
+				case d := <-ep.queue:
+					if n.ctx.Err() != nil {
+						return
+					}
+					n.deliver(ep, d)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver posts a notification to ep.
+func (n *Notifier) deliver(ep *endpoint, d delivery) {
+	if err := n.postWithRetry(ep.url, d.event, d.body); err != nil {
+		log.Warn().Err(err).Str("endpoint", ep.url).Str("event", d.event).Str("article", d.slug).Msg("Content webhook failed")
+		metrics.ContentWebhooks.WithLabelValues("error").Inc()
+		return
+	}
+	metrics.ContentWebhooks.WithLabelValues("ok").Inc()
+}
+
+// postWithRetry posts body to endpoint, retrying failed attempts with
+// exponential backoff.
+func (n *Notifier) postWithRetry(endpoint, event string, body []byte) error {
+	var err error
+	backoff := retryBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = n.post(endpoint, event, body); err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		select {
+		case <-n.ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+func (n *Notifier) post(endpoint, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(n.ctx, n.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FutureSignals-Webhooks/1.0")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(SignatureHeader, sign(n.config.Secret, time.Now(), body))
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the SignatureHeader value for body sent at t.
+func sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newID returns a random notification ID.
+func newID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,94 @@
+package xtracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBlueskyURL is Bluesky's public AppView, which serves author feeds
+// without authentication.
+const DefaultBlueskyURL = "https://public.api.bsky.app"
+
+// BlueskyClient reads posts from Bluesky through the AT Protocol's
+// app.bsky.feed.getAuthorFeed endpoint. Polling one feed per account keeps
+// the worker's model; the firehose would stream every post on the network
+// to find a handful of accounts.
+type BlueskyClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewBlueskyClient creates a Bluesky client. baseURL may be empty for
+// DefaultBlueskyURL.
+func NewBlueskyClient(baseURL string) *BlueskyClient {
+	if baseURL == "" {
+		baseURL = DefaultBlueskyURL
+	}
+	return &BlueskyClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+type blueskyFeedResponse struct {
+	Feed []struct {
+		Post struct {
+			URI    string `json:"uri"`
+			Author struct {
+				DID    string `json:"did"`
+				Handle string `json:"handle"`
+			} `json:"author"`
+			Record struct {
+				Text      string    `json:"text"`
+				CreatedAt time.Time `json:"createdAt"`
+			} `json:"record"`
+			IndexedAt time.Time `json:"indexedAt"`
+		} `json:"post"`
+		// Set for reposts and pinned posts
+		Reason *struct {
+			Type string `json:"$type"`
+		} `json:"reason"`
+	} `json:"feed"`
+}
+
+// GetRecentPosts returns the account's own posts, without replies or
+// reposts, created after since.
+func (c *BlueskyClient) GetRecentPosts(ctx context.Context, handle string, since time.Time, limit int) ([]Post, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	params := url.Values{
+		"actor":  {handle},
+		"limit":  {fmt.Sprint(limit)},
+		"filter": {"posts_no_replies"},
+	}
+
+	var resp blueskyFeedResponse
+	if err := getJSON(ctx, c.httpClient, c.baseURL+"/xrpc/app.bsky.feed.getAuthorFeed?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	var posts []Post
+	for _, item := range resp.Feed {
+		p := item.Post
+		if item.Reason != nil || !p.Record.CreatedAt.After(since) {
+			continue
+		}
+		// Post URIs are at://<did>/app.bsky.feed.post/<rkey>
+		rkey := p.URI[strings.LastIndex(p.URI, "/")+1:]
+		posts = append(posts, Post{
+			ID:         p.URI,
+			UserID:     p.Author.DID,
+			PlatformID: rkey,
+			Content:    p.Record.Text,
+			CreatedAt:  p.Record.CreatedAt,
+			ImportedAt: p.IndexedAt,
+			URL:        fmt.Sprintf("https://bsky.app/profile/%s/post/%s", p.Author.Handle, rkey),
+		})
+	}
+	return posts, nil
+}
@@ -3,9 +3,7 @@ package xtracker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -222,32 +220,7 @@ func (c *Client) GetMetrics(ctx context.Context, userID string, startDate, endDa
 
 // doRequest performs an HTTP GET request and decodes the JSON response.
 func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "FutureSignals/1.0")
-
-	log.Debug().Str("url", url).Msg("XTracker API request")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
-	}
-
-	return nil
+	return getJSON(ctx, c.httpClient, url, result)
 }
 
 // HealthCheck verifies the API is accessible.
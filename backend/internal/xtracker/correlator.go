@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/leeaandrob/futuresignals/internal/embeddings"
 	"github.com/leeaandrob/futuresignals/internal/models"
 	"github.com/leeaandrob/futuresignals/internal/storage"
 	"github.com/rs/zerolog/log"
@@ -14,8 +17,15 @@ import (
 
 // CorrelationConfig holds configuration for signal correlation.
 type CorrelationConfig struct {
-	// TimeWindow is how long after a tweet to look for market movements.
+	// TimeWindow is how old a tweet may be and still be correlated.
 	TimeWindow time.Duration
+	// ImpactDelay is how long after a tweet its impact is measured: the
+	// snapshot nearest before the tweet is compared with the one nearest
+	// ImpactDelay after it.
+	ImpactDelay time.Duration
+	// SnapshotTolerance is how far either snapshot may be from its target
+	// time; a market without both is not correlated.
+	SnapshotTolerance time.Duration
 	// MinMarketChange is the minimum % change to consider (e.g., 0.02 = 2%).
 	MinMarketChange float64
 	// MaxSignalsPerArticle limits signals added to an article.
@@ -28,50 +38,207 @@ type CorrelationConfig struct {
 func DefaultCorrelationConfig() CorrelationConfig {
 	return CorrelationConfig{
 		TimeWindow:           2 * time.Hour,
+		ImpactDelay:          30 * time.Minute,
+		SnapshotTolerance:    15 * time.Minute,
 		MinMarketChange:      0.02, // 2%
 		MaxSignalsPerArticle: 3,
 		Categories:           []string{"politics", "tech", "crypto", "finance", "world"},
 	}
 }
 
+// Store is the storage the correlator reads markets and their price
+// history from.
+type Store interface {
+	storage.MarketStore
+	storage.SnapshotStore
+}
+
 // Correlator finds relationships between social signals and market movements.
 type Correlator struct {
 	client *Client
-	store  *storage.Store
+	store  Store
 	config CorrelationConfig
 
-	// Cache of tracked users
+	// Embedding relevance; nil falls back to keyword matching
+	scorer *embeddings.Scorer
+
+	// LLM relevance, confirming embedding or keyword matches (nil skips it)
+	relevance *RelevanceScorer
+
+	// Persists found signals (nil keeps them only in articles)
+	signals storage.SocialSignalStore
+
+	// Locally tracked accounts merged into XTracker's list (nil uses
+	// XTracker's alone)
+	accounts storage.TrackedAccountStore
+
+	// Post sources for tracked accounts on platforms other than X, by
+	// platform
+	sources map[string]Source
+
+	// Cache of tracked users, shared by article enrichment and the worker
+	usersMu  sync.Mutex
 	users    []TrackedUser
 	usersAt  time.Time
 	cacheTTL time.Duration
 }
 
 // NewCorrelator creates a new signal correlator.
-func NewCorrelator(client *Client, store *storage.Store, config CorrelationConfig) *Correlator {
+func NewCorrelator(client *Client, store Store, config CorrelationConfig) *Correlator {
 	return &Correlator{
 		client:   client,
 		store:    store,
 		config:   config,
+		sources:  make(map[string]Source),
 		cacheTTL: 5 * time.Minute,
 	}
 }
 
+// SetScorer makes relevance use embedding similarity instead of keyword
+// matching. Keywords are still used when an embedding request fails.
+func (c *Correlator) SetScorer(scorer *embeddings.Scorer) {
+	c.scorer = scorer
+}
+
+// SetRelevanceScorer has the LLM confirm each embedding or keyword match,
+// dropping pairs it scores below its threshold.
+func (c *Correlator) SetRelevanceScorer(relevance *RelevanceScorer) {
+	c.relevance = relevance
+}
+
+// SetAccountStore merges the locally tracked accounts into XTracker's list
+// of tracked users.
+func (c *Correlator) SetAccountStore(accounts storage.TrackedAccountStore) {
+	c.accounts = accounts
+}
+
+// AddSource reads the posts of tracked accounts on platform from source.
+// X accounts are always read through XTracker.
+func (c *Correlator) AddSource(platform string, source Source) {
+	c.sources[platform] = source
+}
+
+// recentPosts reads a user's posts created after since from the source for
+// their platform.
+func (c *Correlator) recentPosts(ctx context.Context, user TrackedUser, since time.Time, limit int) ([]Post, error) {
+	if user.Platform == "" {
+		return c.client.GetRecentPosts(ctx, user.Handle, since, limit)
+	}
+	source, ok := c.sources[user.Platform]
+	if !ok {
+		return nil, fmt.Errorf("no post source for platform %s", user.Platform)
+	}
+	return source.GetRecentPosts(ctx, user.Handle, since, limit)
+}
+
+// SetSignalStore persists every signal the correlator finds, for the
+// signals API.
+func (c *Correlator) SetSignalStore(signals storage.SocialSignalStore) {
+	c.signals = signals
+}
+
+// saveSignals persists found signals; failures are logged, as they don't
+// affect the signals returned.
+func (c *Correlator) saveSignals(ctx context.Context, signals []models.StoredSocialSignal) {
+	if c.signals == nil || len(signals) == 0 {
+		return
+	}
+	if _, err := c.signals.SaveSocialSignals(ctx, signals); err != nil {
+		log.Warn().Err(err).Int("signals", len(signals)).Msg("Failed to save social signals")
+	}
+}
+
 // GetTrackedUsers returns cached tracked users or fetches fresh data.
 func (c *Correlator) GetTrackedUsers(ctx context.Context) ([]TrackedUser, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
 	if time.Since(c.usersAt) < c.cacheTTL && len(c.users) > 0 {
 		return c.users, nil
 	}
 
 	users, err := c.client.GetUsers(ctx)
 	if err != nil {
-		return nil, err
+		if c.accounts == nil {
+			return nil, err
+		}
+		// Accounts on other platforms don't depend on XTracker; the users
+		// aren't cached, so XTracker is retried on the next call
+		log.Warn().Err(err).Msg("Failed to get XTracker users, using tracked accounts only")
+		return c.mergeAccounts(ctx, nil), nil
 	}
+	users = c.mergeAccounts(ctx, users)
 
 	c.users = users
 	c.usersAt = time.Now()
 	return users, nil
 }
 
+// mergeAccounts applies the locally tracked accounts to XTracker's users:
+// enabled X accounts set the categories and weight of the handle, adding
+// it if XTracker doesn't list it, and disabled ones drop it. Enabled
+// accounts on other platforms are added. If the accounts can't be loaded,
+// XTracker's users are returned unchanged.
+func (c *Correlator) mergeAccounts(ctx context.Context, users []TrackedUser) []TrackedUser {
+	if c.accounts == nil {
+		return users
+	}
+	accounts, err := c.accounts.GetTrackedAccounts(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load tracked accounts")
+		return users
+	}
+
+	// X accounts, which overlay XTracker's users
+	byHandle := make(map[string]models.TrackedAccount, len(accounts))
+	for _, a := range accounts {
+		if a.Platform == models.PlatformX {
+			byHandle[a.Handle] = a
+		}
+	}
+
+	merged := make([]TrackedUser, 0, len(users)+len(accounts))
+	for _, u := range users {
+		handle := models.NormalizeHandle(u.Handle)
+		a, ok := byHandle[handle]
+		if !ok {
+			merged = append(merged, u)
+			continue
+		}
+		delete(byHandle, handle)
+		if !a.Enabled {
+			continue
+		}
+		u.Categories, u.Weight = a.Categories, a.Weight
+		if u.Name == "" {
+			u.Name = a.Name
+		}
+		merged = append(merged, u)
+	}
+
+	// Accounts XTracker doesn't list, in ID order
+	for _, a := range accounts {
+		if !a.Enabled {
+			continue
+		}
+		user := TrackedUser{
+			Handle:     a.Handle,
+			Name:       a.Name,
+			Categories: a.Categories,
+			Weight:     a.Weight,
+		}
+		if a.Platform != models.PlatformX {
+			user.Platform = a.Platform
+		} else if _, ok := byHandle[a.Handle]; ok {
+			user.Local = true
+		} else {
+			continue
+		}
+		merged = append(merged, user)
+	}
+	return merged
+}
+
 // FindSignalsForMarket finds social signals that may have influenced a market movement.
 func (c *Correlator) FindSignalsForMarket(ctx context.Context, market *models.Market, lookback time.Duration) ([]models.SocialSignal, error) {
 	users, err := c.GetTrackedUsers(ctx)
@@ -83,40 +250,55 @@ func (c *Correlator) FindSignalsForMarket(ctx context.Context, market *models.Ma
 	since := time.Now().Add(-lookback)
 
 	for _, user := range users {
-		posts, err := c.client.GetRecentPosts(ctx, user.Handle, since, 50)
+		posts, err := c.recentPosts(ctx, user, since, 50)
 		if err != nil {
-			log.Warn().Err(err).Str("handle", user.Handle).Msg("Failed to get posts")
+			logPostsError(err, user)
 			continue
 		}
 
-		for _, post := range posts {
+		relevant := c.relevantPosts(ctx, posts, market)
+		for i, post := range posts {
 			// Check if post content is relevant to the market
-			if !c.isRelevant(post.Content, market) {
+			if !relevant[i] {
 				continue
 			}
 
-			// Check timing correlation
-			if !c.isTimeCorrelated(post.CreatedAt, market) {
+			// Check the market moved after the post
+			if !c.isTimeCorrelated(post.CreatedAt) {
+				continue
+			}
+			movement, ok := c.movementAfter(ctx, market, post.CreatedAt)
+			if !ok {
 				continue
 			}
 
 			signal := models.SocialSignal{
-				Handle:       user.Handle,
-				Name:         user.Name,
-				AvatarURL:    user.AvatarURL,
-				Verified:     user.Verified,
-				Content:      truncateContent(post.Content, 280),
-				TweetURL:     post.TweetURL(user.Handle),
-				PostedAt:     post.CreatedAt,
-				MarketImpact: market.Change24h,
-				ImpactWindow: formatDuration(time.Since(post.CreatedAt)),
+				Platform:        user.Platform,
+				Handle:          user.Handle,
+				Name:            user.Name,
+				AvatarURL:       user.AvatarURL,
+				Verified:        user.Verified,
+				Content:         truncateContent(post.Content, 280),
+				TweetURL:        post.TweetURL(user.Handle),
+				PostedAt:        post.CreatedAt,
+				MarketImpact:    movement.Change,
+				ImpactWindow:    formatDuration(c.config.ImpactDelay),
+				Weight:          user.Weight,
+				AffectedMarkets: []models.MarketMovement{movement},
 			}
 
 			signals = append(signals, signal)
 		}
 	}
 
-	// Limit results
+	stored := make([]models.StoredSocialSignal, len(signals))
+	for i, signal := range signals {
+		stored[i] = models.StoredSocialSignal{SocialSignal: signal, MarketIDs: []string{market.MarketID}}
+	}
+	c.saveSignals(ctx, stored)
+
+	// Limit results, keeping the strongest
+	sortByImpact(signals)
 	if len(signals) > c.config.MaxSignalsPerArticle {
 		signals = signals[:c.config.MaxSignalsPerArticle]
 	}
@@ -135,51 +317,72 @@ func (c *Correlator) FindRecentSignals(ctx context.Context, lookback time.Durati
 	since := time.Now().Add(-lookback)
 
 	for _, user := range users {
-		posts, err := c.client.GetRecentPosts(ctx, user.Handle, since, 100)
+		posts, err := c.recentPosts(ctx, user, since, 100)
 		if err != nil {
-			log.Warn().Err(err).Str("handle", user.Handle).Msg("Failed to get posts")
+			logPostsError(err, user)
 			continue
 		}
 
-		for _, post := range posts {
-			// Get markets that moved after this post
-			movements, err := c.findMarketMovements(ctx, post, user)
-			if err != nil {
-				continue
-			}
+		allSignals = append(allSignals, c.CorrelatePosts(ctx, user, posts)...)
+	}
 
-			if len(movements) == 0 {
-				continue
-			}
+	// Sort by impact (highest first)
+	sortByImpact(allSignals)
 
-			// Calculate average impact
-			totalImpact := 0.0
-			for _, m := range movements {
-				totalImpact += math.Abs(m.Change)
-			}
-			avgImpact := totalImpact / float64(len(movements))
+	return allSignals, nil
+}
 
-			signal := models.SocialSignal{
-				Handle:          user.Handle,
-				Name:            user.Name,
-				AvatarURL:       user.AvatarURL,
-				Verified:        user.Verified,
-				Content:         truncateContent(post.Content, 280),
-				TweetURL:        post.TweetURL(user.Handle),
-				PostedAt:        post.CreatedAt,
-				MarketImpact:    avgImpact,
-				ImpactWindow:    formatDuration(c.config.TimeWindow),
-				AffectedMarkets: movements,
-			}
+// CorrelatePosts matches a user's posts to the markets that moved after
+// them and persists the signals found. Posts no market moved on are left
+// out.
+func (c *Correlator) CorrelatePosts(ctx context.Context, user TrackedUser, posts []Post) []models.SocialSignal {
+	var signals []models.SocialSignal
+	for _, post := range posts {
+		// Get markets that moved after this post
+		movements, err := c.findMarketMovements(ctx, post, user)
+		if err != nil {
+			continue
+		}
+
+		if len(movements) == 0 {
+			continue
+		}
 
-			allSignals = append(allSignals, signal)
+		// Calculate average impact
+		totalImpact := 0.0
+		for _, m := range movements {
+			totalImpact += math.Abs(m.Change)
+		}
+		avgImpact := totalImpact / float64(len(movements))
+
+		signal := models.SocialSignal{
+			Platform:        user.Platform,
+			Handle:          user.Handle,
+			Name:            user.Name,
+			AvatarURL:       user.AvatarURL,
+			Verified:        user.Verified,
+			Content:         truncateContent(post.Content, 280),
+			TweetURL:        post.TweetURL(user.Handle),
+			PostedAt:        post.CreatedAt,
+			MarketImpact:    avgImpact,
+			ImpactWindow:    formatDuration(c.config.ImpactDelay),
+			Weight:          user.Weight,
+			AffectedMarkets: movements,
 		}
+
+		signals = append(signals, signal)
 	}
 
-	// Sort by impact (highest first)
-	sortByImpact(allSignals)
+	stored := make([]models.StoredSocialSignal, len(signals))
+	for i, signal := range signals {
+		stored[i] = models.StoredSocialSignal{SocialSignal: signal}
+		for _, m := range signal.AffectedMarkets {
+			stored[i].MarketIDs = append(stored[i].MarketIDs, m.MarketID)
+		}
+	}
+	c.saveSignals(ctx, stored)
 
-	return allSignals, nil
+	return signals
 }
 
 // EnrichArticleWithSignals adds relevant social signals to an article.
@@ -227,52 +430,191 @@ func (c *Correlator) EnrichArticleWithSignals(ctx context.Context, article *mode
 	return nil
 }
 
-// findMarketMovements finds markets that moved significantly after a post.
+// findMarketMovements finds markets relevant to a post that moved
+// significantly after it.
 func (c *Correlator) findMarketMovements(ctx context.Context, post Post, user TrackedUser) ([]models.MarketMovement, error) {
-	var movements []models.MarketMovement
+	if !c.isTimeCorrelated(post.CreatedAt) {
+		return nil, nil
+	}
 
-	// Get markets in relevant categories
-	for _, category := range c.config.Categories {
+	// Get markets in the user's categories, or the configured ones
+	categories := c.config.Categories
+	if len(user.Categories) > 0 {
+		categories = user.Categories
+	}
+	var candidates []*models.Market
+	for _, category := range categories {
 		markets, err := c.store.GetMarketsByCategory(ctx, category, 20)
 		if err != nil {
 			continue
 		}
 
-		for _, market := range markets {
-			// Check if market moved significantly
-			change := market.Change24h
-			if math.Abs(change) < c.config.MinMarketChange {
-				continue
-			}
+		for i := range markets {
+			candidates = append(candidates, &markets[i])
+		}
+	}
 
-			// Check content relevance (basic keyword matching)
-			if !c.isContentRelevantToMarket(post.Content, &market) {
-				continue
+	// Relevance first, so price history is only read for likely matches
+	var movements []models.MarketMovement
+	relevant := c.relevantMarkets(ctx, post.Content, candidates)
+	for i, market := range candidates {
+		if !relevant[i] {
+			continue
+		}
+
+		if movement, ok := c.movementAfter(ctx, market, post.CreatedAt); ok {
+			movements = append(movements, movement)
+		}
+	}
+
+	return movements, nil
+}
+
+// movementAfter compares the market's snapshot nearest before postedAt with
+// the one nearest ImpactDelay after it. It reports false when either
+// snapshot is missing or the change is below MinMarketChange.
+func (c *Correlator) movementAfter(ctx context.Context, market *models.Market, postedAt time.Time) (models.MarketMovement, bool) {
+	since := time.Since(postedAt) + c.config.SnapshotTolerance
+	snapshots, err := c.store.GetSnapshots(ctx, market.MarketID, since)
+	if err != nil {
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Failed to get snapshots")
+		return models.MarketMovement{}, false
+	}
+
+	before, after, ok := snapshotsAround(snapshots, postedAt, c.config.ImpactDelay, c.config.SnapshotTolerance)
+	if !ok {
+		return models.MarketMovement{}, false
+	}
+
+	change := after.Probability - before.Probability
+	if math.Abs(change) < c.config.MinMarketChange {
+		return models.MarketMovement{}, false
+	}
+
+	return models.MarketMovement{
+		MarketID:    market.MarketID,
+		MarketSlug:  market.Slug,
+		MarketTitle: market.Question,
+		Category:    market.Category,
+		ProbBefore:  before.Probability,
+		ProbAfter:   after.Probability,
+		Change:      change,
+		TimeDelta:   formatDuration(after.CapturedAt.Sub(postedAt)) + " after",
+	}, true
+}
+
+// snapshotsAround picks, from snapshots in any order, the latest one at or
+// before postedAt and the one nearest delay after it, each within
+// tolerance of its target.
+func snapshotsAround(snapshots []models.Snapshot, postedAt time.Time, delay, tolerance time.Duration) (before, after models.Snapshot, ok bool) {
+	target := postedAt.Add(delay)
+	var haveBefore, haveAfter bool
+	for _, s := range snapshots {
+		if !s.CapturedAt.After(postedAt) {
+			if postedAt.Sub(s.CapturedAt) <= tolerance && (!haveBefore || s.CapturedAt.After(before.CapturedAt)) {
+				before, haveBefore = s, true
 			}
+			continue
+		}
+		if absDuration(s.CapturedAt.Sub(target)) <= tolerance &&
+			(!haveAfter || absDuration(s.CapturedAt.Sub(target)) < absDuration(after.CapturedAt.Sub(target))) {
+			after, haveAfter = s, true
+		}
+	}
+	return before, after, haveBefore && haveAfter
+}
+
+// relevantPosts reports which posts are relevant to a market.
+func (c *Correlator) relevantPosts(ctx context.Context, posts []Post, market *models.Market) []bool {
+	relevant := make([]bool, len(posts))
+	pairs := make([]RelevancePair, len(posts))
+	for i, post := range posts {
+		pairs[i] = RelevancePair{Text: post.Content, Market: market}
+	}
 
-			movement := models.MarketMovement{
-				MarketSlug:  market.Slug,
-				MarketTitle: market.Question,
-				Category:    market.Category,
-				ProbBefore:  market.Probability - change,
-				ProbAfter:   market.Probability,
-				Change:      change,
-				TimeDelta:   "within " + formatDuration(c.config.TimeWindow),
+	if c.scorer != nil && len(posts) > 0 {
+		texts := make([]string, len(posts))
+		for i, post := range posts {
+			texts[i] = post.Content
+		}
+		scores, err := c.scorer.Score(ctx, market, texts)
+		if err == nil {
+			for i, score := range scores {
+				relevant[i] = c.scorer.Relevant(score)
 			}
+			return c.confirmRelevance(ctx, pairs, relevant)
+		}
+		log.Warn().Err(err).Str("market", market.MarketID).Msg("Embedding relevance failed, using keywords")
+	}
 
-			movements = append(movements, movement)
+	for i, post := range posts {
+		relevant[i] = c.isContentRelevantToMarket(post.Content, market)
+	}
+	return c.confirmRelevance(ctx, pairs, relevant)
+}
+
+// relevantMarkets reports which markets a post is relevant to.
+func (c *Correlator) relevantMarkets(ctx context.Context, content string, markets []*models.Market) []bool {
+	relevant := make([]bool, len(markets))
+	pairs := make([]RelevancePair, len(markets))
+	for i, market := range markets {
+		pairs[i] = RelevancePair{Text: content, Market: market}
+	}
+
+	if c.scorer != nil && len(markets) > 0 {
+		scores, err := c.scorer.ScoreMarkets(ctx, content, markets)
+		if err == nil {
+			for i, score := range scores {
+				relevant[i] = c.scorer.Relevant(score)
+			}
+			return c.confirmRelevance(ctx, pairs, relevant)
 		}
+		log.Warn().Err(err).Msg("Embedding relevance failed, using keywords")
 	}
 
-	return movements, nil
+	for i, market := range markets {
+		relevant[i] = c.isContentRelevantToMarket(content, market)
+	}
+	return c.confirmRelevance(ctx, pairs, relevant)
 }
 
-// isRelevant checks if a post is relevant to a market.
-func (c *Correlator) isRelevant(content string, market *models.Market) bool {
-	return c.isContentRelevantToMarket(content, market)
+// confirmRelevance has the LLM rescore the pairs marked relevant and
+// clears the ones it scores below its threshold. Pairs it couldn't score
+// keep their verdict.
+func (c *Correlator) confirmRelevance(ctx context.Context, pairs []RelevancePair, relevant []bool) []bool {
+	if c.relevance == nil {
+		return relevant
+	}
+
+	var matched []int
+	var candidates []RelevancePair
+	for i, ok := range relevant {
+		if ok {
+			matched = append(matched, i)
+			candidates = append(candidates, pairs[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return relevant
+	}
+
+	for j, r := range c.relevance.Score(ctx, candidates) {
+		if r == nil || c.relevance.Relevant(r.Score) {
+			continue
+		}
+		i := matched[j]
+		relevant[i] = false
+		log.Debug().
+			Str("market", pairs[i].Market.MarketID).
+			Float64("score", r.Score).
+			Str("rationale", r.Rationale).
+			Msg("LLM rejected social signal match")
+	}
+	return relevant
 }
 
-// isContentRelevantToMarket performs basic keyword matching.
+// isContentRelevantToMarket performs basic keyword matching, the fallback
+// when no embedding scorer is available.
 func (c *Correlator) isContentRelevantToMarket(content string, market *models.Market) bool {
 	contentLower := strings.ToLower(content)
 	questionLower := strings.ToLower(market.Question)
@@ -292,11 +634,11 @@ func (c *Correlator) isContentRelevantToMarket(content string, market *models.Ma
 	return matchCount >= 2
 }
 
-// isTimeCorrelated checks if the post timing correlates with market movement.
-func (c *Correlator) isTimeCorrelated(postTime time.Time, market *models.Market) bool {
-	// For now, just check if post is within our lookback window
-	// Future: check market price history for movement after post time
-	return time.Since(postTime) <= c.config.TimeWindow
+// isTimeCorrelated checks the post is recent enough to correlate and old
+// enough for its impact to be measured.
+func (c *Correlator) isTimeCorrelated(postTime time.Time) bool {
+	age := time.Since(postTime)
+	return age <= c.config.TimeWindow && age >= c.config.ImpactDelay
 }
 
 // Helper functions
@@ -339,6 +681,13 @@ func truncateContent(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Hour {
 		return fmt.Sprintf("%dm", int(d.Minutes()))
@@ -349,13 +698,29 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dd", int(d.Hours()/24))
 }
 
+// sortByImpact orders signals by impact scaled by account weight, highest
+// first.
 func sortByImpact(signals []models.SocialSignal) {
-	// Simple bubble sort for now
-	for i := 0; i < len(signals)-1; i++ {
-		for j := i + 1; j < len(signals); j++ {
-			if signals[j].MarketImpact > signals[i].MarketImpact {
-				signals[i], signals[j] = signals[j], signals[i]
-			}
-		}
+	sort.SliceStable(signals, func(i, j int) bool {
+		return weightedImpact(signals[i]) > weightedImpact(signals[j])
+	})
+}
+
+func weightedImpact(s models.SocialSignal) float64 {
+	weight := s.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+	return math.Abs(s.MarketImpact) * weight
+}
+
+// logPostsError logs a failed posts lookup. Local X accounts XTracker
+// doesn't import have no posts there, so their failures are only debug
+// logged.
+func logPostsError(err error, user TrackedUser) {
+	event := log.Warn()
+	if user.Local {
+		event = log.Debug()
 	}
+	event.Err(err).Str("handle", user.Handle).Msg("Failed to get posts")
 }
@@ -18,6 +18,15 @@ type TrackedUser struct {
 	CreatedAt  time.Time `json:"createdAt"`
 	UpdatedAt  time.Time `json:"updatedAt"`
 	PostCount  int       `json:"-"` // Populated from _count.posts
+
+	// Set from a local tracked account: the platform posts are read from
+	// (empty for X), the market categories to correlate posts with (empty
+	// for the correlator's defaults) and the ranking weight (0 for
+	// neutral). Local is true for an X handle XTracker doesn't list.
+	Platform   string   `json:"-"`
+	Categories []string `json:"-"`
+	Weight     float64  `json:"-"`
+	Local      bool     `json:"-"`
 }
 
 // Post represents a tracked post/tweet from XTracker.
@@ -28,10 +37,17 @@ type Post struct {
 	Content    string    `json:"content"`
 	CreatedAt  time.Time `json:"createdAt"`
 	ImportedAt time.Time `json:"importedAt"`
+
+	// URL is set by sources for platforms other than X
+	URL string `json:"-"`
 }
 
-// TweetURL returns the full URL to the tweet on X/Twitter.
+// TweetURL returns the full URL to the post: the one its source set, or
+// the tweet on X/Twitter.
 func (p *Post) TweetURL(handle string) string {
+	if p.URL != "" {
+		return p.URL
+	}
 	return fmt.Sprintf("https://x.com/%s/status/%s", handle, p.PlatformID)
 }
 
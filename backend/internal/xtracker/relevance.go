@@ -0,0 +1,251 @@
+package xtracker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/cache"
+	"github.com/leeaandrob/futuresignals/internal/llm"
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/leeaandrob/futuresignals/internal/prompts"
+	"github.com/rs/zerolog/log"
+)
+
+// RelevanceConfig holds configuration for LLM relevance scoring.
+type RelevanceConfig struct {
+	// Threshold is the score at or above which a post is relevant.
+	Threshold float64
+	// BatchSize is how many post/market pairs go in one request.
+	BatchSize int
+	// MaxPairsPerCycle caps the pairs sent to the LLM per poll cycle;
+	// pairs beyond it keep the keyword or embedding verdict.
+	MaxPairsPerCycle int
+	// CacheTTL is how long a pair's score is reused.
+	CacheTTL time.Duration
+}
+
+// DefaultRelevanceConfig returns sensible defaults.
+func DefaultRelevanceConfig() RelevanceConfig {
+	return RelevanceConfig{
+		Threshold:        0.6,
+		BatchSize:        20,
+		MaxPairsPerCycle: 200,
+		CacheTTL:         7 * 24 * time.Hour,
+	}
+}
+
+// Relevance is the LLM's verdict on how much a post bears on a market.
+type Relevance struct {
+	Score     float64 `json:"score"`
+	Rationale string  `json:"rationale"`
+}
+
+// RelevancePair is a post and the market it is scored against.
+type RelevancePair struct {
+	Text   string
+	Market *models.Market
+}
+
+// RelevanceScorer asks the LLM, in batches, how relevant posts are to
+// market questions. Scores are cached by post text and question, and the
+// pairs sent per cycle are capped to bound cost.
+type RelevanceScorer struct {
+	provider llm.Provider
+	cache    cache.Cache
+	config   RelevanceConfig
+
+	mu    sync.Mutex
+	spent int
+}
+
+// NewRelevanceScorer creates a scorer. c may be nil to score without
+// caching.
+func NewRelevanceScorer(provider llm.Provider, c cache.Cache, config RelevanceConfig) *RelevanceScorer {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultRelevanceConfig().BatchSize
+	}
+	return &RelevanceScorer{
+		provider: provider,
+		cache:    c,
+		config:   config,
+	}
+}
+
+// Relevant reports whether a score clears the threshold.
+func (s *RelevanceScorer) Relevant(score float64) bool {
+	return score >= s.config.Threshold
+}
+
+// BeginCycle resets the per-cycle pair budget.
+func (s *RelevanceScorer) BeginCycle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spent = 0
+}
+
+// reserve takes up to n pairs from the cycle budget and returns how many
+// were granted.
+func (s *RelevanceScorer) reserve(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.MaxPairsPerCycle > 0 {
+		n = min(n, s.config.MaxPairsPerCycle-s.spent)
+	}
+	n = max(n, 0)
+	s.spent += n
+	return n
+}
+
+// Score returns the relevance of each pair. Pairs that are neither cached
+// nor within the cycle budget, or whose batch failed, are nil.
+func (s *RelevanceScorer) Score(ctx context.Context, pairs []RelevancePair) []*Relevance {
+	ctx = llm.WithJob(ctx, "social-relevance")
+	results := make([]*Relevance, len(pairs))
+
+	var pending []int
+	for i, p := range pairs {
+		if r, ok := s.cached(ctx, p); ok {
+			results[i] = r
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	granted := s.reserve(len(pending))
+	if granted < len(pending) {
+		log.Debug().
+			Int("pending", len(pending)).
+			Int("granted", granted).
+			Msg("Relevance budget exhausted for this cycle")
+	}
+	pending = pending[:granted]
+
+	for start := 0; start < len(pending); start += s.config.BatchSize {
+		batch := pending[start:min(start+s.config.BatchSize, len(pending))]
+		scored, err := s.scoreBatch(ctx, pairs, batch)
+		if err != nil {
+			log.Warn().Err(err).Int("pairs", len(batch)).Msg("LLM relevance scoring failed")
+			continue
+		}
+		for _, i := range batch {
+			if r, ok := scored[i]; ok {
+				results[i] = r
+				s.store(ctx, pairs[i], r)
+			}
+		}
+	}
+	return results
+}
+
+type relevanceResponse struct {
+	Pairs []struct {
+		ID        int     `json:"id"`
+		Score     float64 `json:"score"`
+		Rationale string  `json:"rationale"`
+	} `json:"pairs"`
+}
+
+// relevancePromptPair is a pair as the relevance prompt lists it.
+type relevancePromptPair struct {
+	ID       int
+	Question string
+	Post     string
+}
+
+// RelevancePrompt renders the relevance prompt for pairs, identified by
+// their index.
+func RelevancePrompt(pairs []RelevancePair) (*prompts.Prompt, error) {
+	batch := make([]int, len(pairs))
+	for i := range pairs {
+		batch[i] = i
+	}
+	return relevancePrompt(pairs, batch)
+}
+
+// relevancePrompt renders the relevance prompt for the pairs at the given
+// indexes.
+func relevancePrompt(pairs []RelevancePair, batch []int) (*prompts.Prompt, error) {
+	data := struct{ Pairs []relevancePromptPair }{}
+	for _, i := range batch {
+		data.Pairs = append(data.Pairs, relevancePromptPair{ID: i, Question: pairs[i].Market.Question, Post: oneLine(pairs[i].Text)})
+	}
+	return prompts.Render(prompts.Relevance, "", data)
+}
+
+// scoreBatch asks the LLM to score the pairs at the given indexes. Answers
+// for unknown indexes are dropped and scores clamped to [0, 1].
+func (s *RelevanceScorer) scoreBatch(ctx context.Context, pairs []RelevancePair, batch []int) (map[int]*Relevance, error) {
+	requested := make(map[int]bool, len(batch))
+	for _, i := range batch {
+		requested[i] = true
+	}
+	prompt, err := relevancePrompt(pairs, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp relevanceResponse
+	err = s.provider.ChatJSON(ctx, llm.ChatRequest{
+		SystemPrompt: prompt.System,
+		UserPrompt:   prompt.User,
+		Temperature:  0,
+		MaxTokens:    50 * len(batch),
+		Model:        llm.ModelFast,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make(map[int]*Relevance, len(resp.Pairs))
+	for _, p := range resp.Pairs {
+		if requested[p.ID] {
+			scored[p.ID] = &Relevance{Score: max(0, min(1, p.Score)), Rationale: p.Rationale}
+		}
+	}
+	return scored, nil
+}
+
+// cacheKey identifies a pair by its post text and market question, so a
+// reworded question is scored again.
+func cacheKey(p RelevancePair) string {
+	sum := sha256.Sum256([]byte(p.Text + "\x00" + p.Market.Question))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (s *RelevanceScorer) cached(ctx context.Context, p RelevancePair) (*Relevance, bool) {
+	if s.cache == nil {
+		return nil, false
+	}
+	data, ok, err := s.cache.Get(ctx, cache.GroupRelevance, cacheKey(p))
+	if err != nil || !ok {
+		return nil, false
+	}
+	var r Relevance
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, false
+	}
+	return &r, true
+}
+
+func (s *RelevanceScorer) store(ctx context.Context, p RelevancePair, r *Relevance) {
+	if s.cache == nil {
+		return
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	if err := s.cache.Set(ctx, cache.GroupRelevance, cacheKey(p), data, s.config.CacheTTL); err != nil {
+		log.Debug().Err(err).Msg("Failed to cache relevance score")
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,55 @@
+package xtracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Source reads an account's recent posts from a social platform. The
+// XTracker client is the source for X; tracked accounts on other platforms
+// are read from the source registered for theirs.
+type Source interface {
+	GetRecentPosts(ctx context.Context, handle string, since time.Time, limit int) ([]Post, error)
+}
+
+var (
+	_ Source = (*Client)(nil)
+	_ Source = (*BlueskyClient)(nil)
+	_ Source = (*TruthSocialClient)(nil)
+)
+
+// getJSON performs an HTTP GET request and decodes the JSON response.
+func getJSON(ctx context.Context, httpClient *http.Client, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "FutureSignals/1.0")
+
+	log.Debug().Str("url", url).Msg("Social source request")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package xtracker
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTruthSocialURL is Truth Social's web origin, which serves its
+// Mastodon-compatible API.
+const DefaultTruthSocialURL = "https://truthsocial.com"
+
+// TruthSocialClient reads posts ("truths") from Truth Social through its
+// Mastodon-compatible API. Account IDs are looked up once per handle.
+type TruthSocialClient struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// NewTruthSocialClient creates a Truth Social client. baseURL may be empty
+// for DefaultTruthSocialURL.
+func NewTruthSocialClient(baseURL string) *TruthSocialClient {
+	if baseURL == "" {
+		baseURL = DefaultTruthSocialURL
+	}
+	return &TruthSocialClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		ids:        make(map[string]string),
+	}
+}
+
+type truthSocialStatus struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Content   string    `json:"content"`
+	URL       string    `json:"url"`
+}
+
+// GetRecentPosts returns the account's own posts, without replies or
+// reposts, created after since.
+func (c *TruthSocialClient) GetRecentPosts(ctx context.Context, handle string, since time.Time, limit int) ([]Post, error) {
+	id, err := c.accountID(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mastodon caps statuses pages at 40
+	if limit <= 0 || limit > 40 {
+		limit = 40
+	}
+	params := url.Values{
+		"limit":           {fmt.Sprint(limit)},
+		"exclude_replies": {"true"},
+		"exclude_reblogs": {"true"},
+	}
+
+	var statuses []truthSocialStatus
+	if err := getJSON(ctx, c.httpClient, fmt.Sprintf("%s/api/v1/accounts/%s/statuses?%s", c.baseURL, id, params.Encode()), &statuses); err != nil {
+		return nil, err
+	}
+
+	var posts []Post
+	for _, s := range statuses {
+		content := htmlText(s.Content)
+		if !s.CreatedAt.After(since) || content == "" {
+			continue
+		}
+		postURL := s.URL
+		if postURL == "" {
+			postURL = fmt.Sprintf("%s/@%s/posts/%s", c.baseURL, handle, s.ID)
+		}
+		posts = append(posts, Post{
+			ID:         s.ID,
+			UserID:     id,
+			PlatformID: s.ID,
+			Content:    content,
+			CreatedAt:  s.CreatedAt,
+			URL:        postURL,
+		})
+	}
+	return posts, nil
+}
+
+// accountID resolves a handle to its account ID.
+func (c *TruthSocialClient) accountID(ctx context.Context, handle string) (string, error) {
+	c.mu.Lock()
+	id, ok := c.ids[handle]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var account struct {
+		ID string `json:"id"`
+	}
+	if err := getJSON(ctx, c.httpClient, c.baseURL+"/api/v1/accounts/lookup?acct="+url.QueryEscape(handle), &account); err != nil {
+		return "", fmt.Errorf("looking up account: %w", err)
+	}
+	if account.ID == "" {
+		return "", fmt.Errorf("account %s not found", handle)
+	}
+
+	c.mu.Lock()
+	c.ids[handle] = account.ID
+	c.mu.Unlock()
+	return account.ID, nil
+}
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// htmlText reduces status HTML to plain text on one line.
+func htmlText(s string) string {
+	s = htmlBreakPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(htmlTagPattern.ReplaceAllString(s, ""))
+	return strings.Join(strings.Fields(s), " ")
+}
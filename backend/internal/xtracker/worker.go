@@ -0,0 +1,197 @@
+package xtracker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/leeaandrob/futuresignals/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// SignalEmitter publishes social signals to the market event bus.
+type SignalEmitter interface {
+	EmitSocialSignal(ctx context.Context, signal models.SocialSignal) int
+}
+
+// WorkerConfig holds configuration for the polling worker.
+type WorkerConfig struct {
+	// PollInterval is how often tracked users' posts are fetched.
+	PollInterval time.Duration
+	// Lookback is how far back each poll reads posts; posts imported late
+	// are still picked up while they fall within it.
+	Lookback time.Duration
+	// MaxPostsPerUser limits the posts fetched per user per poll.
+	MaxPostsPerUser int
+}
+
+// DefaultWorkerConfig returns sensible defaults.
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		PollInterval:    5 * time.Minute,
+		Lookback:        2 * time.Hour,
+		MaxPostsPerUser: 50,
+	}
+}
+
+// Worker polls tracked users for new posts, correlates each one once with
+// the price move that followed it, and emits the signals found.
+type Worker struct {
+	correlator *Correlator
+	emitter    SignalEmitter
+	config     WorkerConfig
+
+	// Posts already correlated, by post URL, with their post time so they
+	// can be forgotten once they fall out of the lookback
+	seen map[string]time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWorker creates a polling worker. emitter may be nil, in which case
+// signals are only persisted.
+func NewWorker(correlator *Correlator, emitter SignalEmitter, config WorkerConfig) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{
+		correlator: correlator,
+		emitter:    emitter,
+		config:     config,
+		seen:       make(map[string]time.Time),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start begins polling.
+func (w *Worker) Start() {
+	log.Info().
+		Dur("poll_interval", w.config.PollInterval).
+		Dur("lookback", w.config.Lookback).
+		Msg("Starting XTracker worker")
+
+	w.wg.Add(1)
+	go w.pollLoop()
+}
+
+// Stop stops polling and waits for an in-flight poll to finish.
+func (w *Worker) Stop() {
+	log.Info().Msg("Stopping XTracker worker")
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Worker) pollLoop() {
+	defer w.wg.Done()
+
+	w.seedSeen()
+	w.poll()
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// seedSeen marks posts behind already-stored signals as seen, so a restart
+// doesn't emit them again.
+func (w *Worker) seedSeen() {
+	if w.correlator.signals == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, 30*time.Second)
+	defer cancel()
+
+	stored, err := w.correlator.signals.GetRecentSocialSignals(ctx, w.config.Lookback, 1000)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load recent social signals")
+		return
+	}
+	for _, s := range stored {
+		w.seen[s.TweetURL] = s.PostedAt
+	}
+}
+
+// poll fetches each tracked user's recent posts and correlates the ones
+// not seen before.
+func (w *Worker) poll() {
+	ctx, cancel := context.WithTimeout(w.ctx, w.config.PollInterval)
+	defer cancel()
+
+	if w.correlator.relevance != nil {
+		w.correlator.relevance.BeginCycle()
+	}
+
+	users, err := w.correlator.GetTrackedUsers(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get tracked users")
+		return
+	}
+
+	now := time.Now()
+	since := now.Add(-w.config.Lookback)
+	// Posts wait until the snapshot nearest ImpactDelay after them has
+	// been taken, so each is correlated once with its full move
+	ready := now.Add(-w.correlator.config.ImpactDelay - w.correlator.config.SnapshotTolerance)
+	var fresh, signals, emitted int
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			break
+		}
+
+		posts, err := w.correlator.recentPosts(ctx, user, since, w.config.MaxPostsPerUser)
+		if err != nil {
+			logPostsError(err, user)
+			continue
+		}
+
+		var unseen []Post
+		for _, post := range posts {
+			url := post.TweetURL(user.Handle)
+			if _, ok := w.seen[url]; ok || post.CreatedAt.After(ready) {
+				continue
+			}
+			w.seen[url] = post.CreatedAt
+			unseen = append(unseen, post)
+		}
+		if len(unseen) == 0 {
+			continue
+		}
+		fresh += len(unseen)
+
+		for _, signal := range w.correlator.CorrelatePosts(ctx, user, unseen) {
+			signals++
+			if w.emitter != nil {
+				emitted += w.emitter.EmitSocialSignal(ctx, signal)
+			}
+		}
+	}
+
+	for url, postedAt := range w.seen {
+		if postedAt.Before(since) {
+			delete(w.seen, url)
+		}
+	}
+
+	logEvent := log.Debug()
+	if fresh > 0 {
+		logEvent = log.Info()
+	}
+	logEvent.
+		Int("users", len(users)).
+		Int("new_posts", fresh).
+		Int("signals", signals).
+		Int("events", emitted).
+		Dur("duration", time.Since(now)).
+		Msg("XTracker poll complete")
+}
@@ -7,6 +7,7 @@ import {
   formatVolume,
   getMarketUrl,
   getSignificance,
+  imageSrc,
 } from "@/lib/utils";
 import type { Market } from "@/lib/types";
 import { TrendingUp, TrendingDown, ExternalLink, Clock, DollarSign, MessageCircle, Users, BarChart3 } from "lucide-react";
@@ -18,6 +19,8 @@ function MarketImage({ src, alt, size = "md" }: { src?: string; alt: string; siz
     md: "w-12 h-12",
     lg: "w-16 h-16",
   };
+  // Twice the displayed size for high-density screens
+  const widths = { sm: 64, md: 128, lg: 128 };
 
   if (!src) {
     return (
@@ -29,7 +32,7 @@ function MarketImage({ src, alt, size = "md" }: { src?: string; alt: string; siz
 
   return (
     <img
-      src={src}
+      src={imageSrc(src, widths[size])}
       alt={alt}
       className={cn(sizeClasses[size], "rounded-lg object-cover shrink-0")}
       onError={(e) => {
@@ -122,6 +125,7 @@ export function MarketCard({
             </div>
             <a
               href={market.polymarketUrl}
+              data-outbound-market={market.marketId}
               target="_blank"
               rel="noopener noreferrer"
               className="text-muted-foreground hover:text-foreground transition-colors"
@@ -4,6 +4,11 @@ import { cn, formatTimeAgo } from "@/lib/utils";
 import type { SocialSignal, MarketMovement } from "@/lib/types";
 import { TrendingUp, TrendingDown, ExternalLink, CheckCircle } from "lucide-react";
 
+const PLATFORM_NAMES: Record<string, string> = {
+  bluesky: "Bluesky",
+  truthsocial: "Truth Social",
+};
+
 interface SocialSignalCardProps {
   signal: SocialSignal;
   variant?: "default" | "compact" | "inline";
@@ -12,6 +17,7 @@ interface SocialSignalCardProps {
 export function SocialSignalCard({ signal, variant = "default" }: SocialSignalCardProps) {
   const isPositiveImpact = signal.market_impact >= 0;
   const impactPercent = Math.abs(signal.market_impact * 100);
+  const platformName = PLATFORM_NAMES[signal.platform ?? ""] ?? "X";
 
   // Compact inline variant for article headers
   if (variant === "inline") {
@@ -135,7 +141,7 @@ export function SocialSignalCard({ signal, variant = "default" }: SocialSignalCa
             rel="noopener noreferrer"
             className="inline-flex items-center gap-1 text-brand hover:underline"
           >
-            View on X <ExternalLink className="w-3.5 h-3.5" />
+            View on {platformName} <ExternalLink className="w-3.5 h-3.5" />
           </a>
         </div>
       </CardContent>
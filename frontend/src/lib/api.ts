@@ -1,18 +1,22 @@
 // API client for FutureSignals backend
 import type {
   Article,
-  ArticlesResponse,
   Market,
-  MarketsResponse,
   Category,
-  CategoriesResponse,
   CategoryDetailResponse,
   HomeFeedResponse,
   StatsResponse,
   HealthResponse,
   ArticleType,
+  ApiEnvelope,
   CategorySentiment,
-  SentimentResponse,
+  TimelineEntry,
+  TagCount,
+  TagDetailResponse,
+  EventDetailResponse,
+  EventDashboardResponse,
+  StoredSocialSignal,
+  AccuracyScore,
 } from "./types";
 
 const API_BASE = import.meta.env.PUBLIC_API_URL || "http://localhost:8080";
@@ -37,12 +41,19 @@ const fieldMappings: Record<string, string> = {
   volume_7d: "volume7d",
   change_24h: "change24h",
   change_1h: "change1h",
+  change_6h: "change6h",
   change_7d: "change7d",
   // Event-level fields
   event_volume: "eventVolume",
   event_volume_24h: "eventVolume24h",
+  buy_volume_24h: "buyVolume24h",
+  sell_volume_24h: "sellVolume24h",
 };
 
+// Objects under these keys are passed through untouched: JSON-LD uses
+// schema.org's own key names, including "@context", "@type" and "headline"
+const rawFields = new Set(["json_ld"]);
+
 function transformKeys(obj: any): any {
   if (obj === null || obj === undefined) return obj;
   if (Array.isArray(obj)) return obj.map(transformKeys);
@@ -53,7 +64,7 @@ function transformKeys(obj: any): any {
     // First apply field mapping, then snake_case to camelCase
     const mappedKey = fieldMappings[key] || key;
     const camelKey = snakeToCamel(mappedKey);
-    transformed[camelKey] = transformKeys(obj[key]);
+    transformed[camelKey] = rawFields.has(key) ? obj[key] : transformKeys(obj[key]);
   }
   return transformed;
 }
@@ -62,13 +73,41 @@ function transformKeys(obj: any): any {
 // GENERIC FETCH HELPER
 // =============================================================================
 
-async function apiFetch<T>(endpoint: string): Promise<T> {
-  const res = await fetch(`${API_BASE}${endpoint}`);
-  if (!res.ok) {
-    throw new Error(`API error: ${res.status} ${res.statusText}`);
+// Readers are told apart by a random id kept in this cookie, sent with each
+// request so headline tests give a reader the same variant everywhere. The
+// browser sets it on first use; server-rendered pages read it from the
+// request and pass it along, since their requests otherwise all come from
+// the same server.
+export const VISITOR_COOKIE = "fs_vid";
+const VISITOR_MAX_AGE = 60 * 60 * 24 * 365;
+
+export function newVisitorId(): string {
+  return crypto.randomUUID();
+}
+
+function browserVisitorId(): string | undefined {
+  if (typeof document === "undefined") return undefined;
+  const match = document.cookie.match(new RegExp(`(?:^|; )${VISITOR_COOKIE}=([^;]+)`));
+  if (match) return match[1];
+  const id = newVisitorId();
+  document.cookie = `${VISITOR_COOKIE}=${id}; path=/; max-age=${VISITOR_MAX_AGE}; samesite=lax`;
+  return id;
+}
+
+// Options for server-rendered pages setting the visitor cookie
+export const visitorCookieOptions = { path: "/", maxAge: VISITOR_MAX_AGE, sameSite: "lax" as const };
+
+// Fetches an endpoint and unwraps the data from its response envelope
+async function apiFetch<T>(endpoint: string, visitor?: string): Promise<T> {
+  const id = visitor ?? browserVisitorId();
+  const res = await fetch(`${API_BASE}${endpoint}`, {
+    headers: id ? { "X-Session-ID": id } : undefined,
+  });
+  const body = (await res.json().catch(() => ({}))) as ApiEnvelope<unknown>;
+  if (!res.ok || body.error) {
+    throw new Error(`API error: ${res.status} ${body.error?.message ?? res.statusText}`);
   }
-  const data = await res.json();
-  return transformKeys(data) as T;
+  return transformKeys(body.data) as T;
 }
 
 // =============================================================================
@@ -84,8 +123,7 @@ export async function getHomeFeed(): Promise<HomeFeedResponse> {
 // =============================================================================
 
 export async function getArticles(limit: number = 20): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles?limit=${limit}`);
-  return data.articles || [];
+  return (await apiFetch<Article[]>(`/api/articles?limit=${limit}`)) || [];
 }
 
 export async function getArticleBySlug(slug: string): Promise<Article | null> {
@@ -97,33 +135,27 @@ export async function getArticleBySlug(slug: string): Promise<Article | null> {
 }
 
 export async function getTodayArticles(): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>("/api/articles/today");
-  return data.articles || [];
+  return (await apiFetch<Article[]>("/api/articles/today")) || [];
 }
 
 export async function getBreakingArticles(limit: number = 10): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles/breaking?limit=${limit}`);
-  return data.articles || [];
+  return (await apiFetch<Article[]>(`/api/articles/breaking?limit=${limit}`)) || [];
 }
 
 export async function getTrendingArticles(limit: number = 10): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles/trending?limit=${limit}`);
-  return data.articles || [];
+  return (await apiFetch<Article[]>(`/api/articles/trending?limit=${limit}`)) || [];
 }
 
 export async function getFeaturedArticles(limit: number = 5): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles/featured?limit=${limit}`);
-  return data.articles || [];
+  return (await apiFetch<Article[]>(`/api/articles/featured?limit=${limit}`)) || [];
 }
 
 export async function getArticlesByType(type: ArticleType, limit: number = 20): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles/type/${type}?limit=${limit}`);
-  return data.articles || [];
+  return (await apiFetch<Article[]>(`/api/articles/type/${type}?limit=${limit}`)) || [];
 }
 
-export async function getArticlesByCategory(category: string, limit: number = 20): Promise<Article[]> {
-  const data = await apiFetch<ArticlesResponse>(`/api/articles/category/${category}?limit=${limit}`);
-  return data.articles || [];
+export async function getArticlesByCategory(category: string, limit: number = 20, visitor?: string): Promise<Article[]> {
+  return (await apiFetch<Article[]>(`/api/articles/category/${category}?limit=${limit}`, visitor)) || [];
 }
 
 // =============================================================================
@@ -131,8 +163,7 @@ export async function getArticlesByCategory(category: string, limit: number = 20
 // =============================================================================
 
 export async function getMarkets(limit: number = 50): Promise<Market[]> {
-  const data = await apiFetch<MarketsResponse>(`/api/markets?limit=${limit}`);
-  return data.markets || [];
+  return (await apiFetch<Market[]>(`/api/markets?limit=${limit}`)) || [];
 }
 
 export async function getMarketBySlug(slug: string): Promise<Market | null> {
@@ -143,24 +174,49 @@ export async function getMarketBySlug(slug: string): Promise<Market | null> {
   }
 }
 
+// Articles covering a market, oldest first
+export async function getMarketArticles(slug: string, limit: number = 50, visitor?: string): Promise<Article[]> {
+  return (await apiFetch<Article[]>(`/api/markets/${slug}/articles?limit=${limit}`, visitor)) || [];
+}
+
+export async function getMarketTimeline(slug: string, days: number = 7): Promise<TimelineEntry[]> {
+  return (await apiFetch<TimelineEntry[]>(`/api/markets/${slug}/timeline?days=${days}`)) || [];
+}
+
 export async function getTrendingMarkets(limit: number = 20): Promise<Market[]> {
-  const data = await apiFetch<MarketsResponse>(`/api/markets/trending?limit=${limit}`);
-  return data.markets || [];
+  return (await apiFetch<Market[]>(`/api/markets/trending?limit=${limit}`)) || [];
 }
 
 export async function getBreakingMarkets(limit: number = 20): Promise<Market[]> {
-  const data = await apiFetch<MarketsResponse>(`/api/markets/breaking?limit=${limit}`);
-  return data.markets || [];
+  return (await apiFetch<Market[]>(`/api/markets/breaking?limit=${limit}`)) || [];
 }
 
 export async function getNewMarkets(limit: number = 20): Promise<Market[]> {
-  const data = await apiFetch<MarketsResponse>(`/api/markets/new?limit=${limit}`);
-  return data.markets || [];
+  return (await apiFetch<Market[]>(`/api/markets/new?limit=${limit}`)) || [];
+}
+
+export async function getClosingSoonMarkets(days: number = 7, limit: number = 20): Promise<Market[]> {
+  return (await apiFetch<Market[]>(`/api/markets/closing-soon?days=${days}&limit=${limit}`)) || [];
 }
 
 export async function getMarketsByCategory(category: string, limit: number = 20): Promise<Market[]> {
-  const data = await apiFetch<MarketsResponse>(`/api/markets/category/${category}?limit=${limit}`);
-  return data.markets || [];
+  return (await apiFetch<Market[]>(`/api/markets/category/${category}?limit=${limit}`)) || [];
+}
+
+export async function getEvent(slug: string): Promise<EventDetailResponse | null> {
+  try {
+    return await apiFetch<EventDetailResponse>(`/api/events/${encodeURIComponent(slug)}`);
+  } catch {
+    return null;
+  }
+}
+
+export async function getEventDashboard(slug: string, days: number = 30): Promise<EventDashboardResponse | null> {
+  try {
+    return await apiFetch<EventDashboardResponse>(`/api/events/${encodeURIComponent(slug)}/dashboard?days=${days}`);
+  } catch {
+    return null;
+  }
 }
 
 // =============================================================================
@@ -168,8 +224,7 @@ export async function getMarketsByCategory(category: string, limit: number = 20)
 // =============================================================================
 
 export async function getCategories(): Promise<Category[]> {
-  const data = await apiFetch<CategoriesResponse>("/api/categories");
-  return data.categories || [];
+  return (await apiFetch<Category[]>("/api/categories")) || [];
 }
 
 export async function getCategoryBySlug(slug: string): Promise<CategoryDetailResponse | null> {
@@ -180,13 +235,36 @@ export async function getCategoryBySlug(slug: string): Promise<CategoryDetailRes
   }
 }
 
+// =============================================================================
+// TAGS
+// =============================================================================
+
+export async function getSignals(hours: number = 24, limit: number = 20): Promise<StoredSocialSignal[]> {
+  return (await apiFetch<StoredSocialSignal[]>(`/api/signals?hours=${hours}&limit=${limit}`)) || [];
+}
+
+export async function getMarketSignals(slug: string, limit: number = 10): Promise<StoredSocialSignal[]> {
+  return (await apiFetch<StoredSocialSignal[]>(`/api/markets/${slug}/signals?limit=${limit}`)) || [];
+}
+
+export async function getTags(limit: number = 50): Promise<TagCount[]> {
+  return (await apiFetch<TagCount[]>(`/api/tags?limit=${limit}`)) || [];
+}
+
+export async function getTag(tag: string): Promise<TagDetailResponse | null> {
+  try {
+    return await apiFetch<TagDetailResponse>(`/api/tags/${encodeURIComponent(tag)}`);
+  } catch {
+    return null;
+  }
+}
+
 // =============================================================================
 // SENTIMENT / MARKET PULSE
 // =============================================================================
 
 export async function getSentiment(): Promise<CategorySentiment[]> {
-  const data = await apiFetch<SentimentResponse>("/api/sentiment");
-  return data.sentiments || [];
+  return (await apiFetch<CategorySentiment[]>("/api/categories/sentiment")) || [];
 }
 
 export async function getCategorySentiment(category: string): Promise<CategorySentiment | null> {
@@ -197,6 +275,15 @@ export async function getCategorySentiment(category: string): Promise<CategorySe
   }
 }
 
+// =============================================================================
+// FORECAST ACCURACY
+// =============================================================================
+
+export async function getAccuracy(category?: string): Promise<AccuracyScore[]> {
+  const query = category ? `?category=${encodeURIComponent(category)}` : "";
+  return (await apiFetch<AccuracyScore[]>(`/api/stats/accuracy${query}`)) || [];
+}
+
 // =============================================================================
 // STATS & HEALTH
 // =============================================================================
@@ -223,5 +310,7 @@ export type {
   HomeFeedResponse,
   PolymarketTag,
   CategorySentiment,
-  SentimentResponse,
+  AccuracyScore,
+  ApiEnvelope,
+  ApiMeta,
 } from "./types";
@@ -11,6 +11,7 @@ export interface Category {
   icon: string;
   color: string;
   dynamic: boolean;
+  keywords?: string[];
 }
 
 // Category slugs matching Polymarket
@@ -39,6 +40,50 @@ export interface PolymarketTag {
   slug: string;
 }
 
+export interface OrderBook {
+  bestBid: number;
+  bestAsk: number;
+  midpoint: number;
+  spread: number;
+  bidDepth: number;             // USD within 5¢ of the midpoint
+  askDepth: number;
+  updatedAt: string;
+}
+
+export interface TradeStats {
+  trades: number;
+  buyVolume: number;            // USD bought on the Yes side
+  sellVolume: number;
+  imbalance: number;            // -1 (all selling) to 1 (all buying)
+  avgTradeSize: number;
+  whaleTrades: number;
+  largestTrade: number;
+}
+
+export interface TradeFlow {
+  hour: TradeStats;
+  day: TradeStats;
+  updatedAt: string;
+}
+
+export interface OutcomeHolders {
+  outcome: string;
+  holders: number;
+  shares: number;
+  topShare: number;             // Share held by the 10 largest holders (0-1)
+  largestHolder: number;
+}
+
+export interface Holders {
+  outcomes: OutcomeHolders[];
+  uniqueHolders: number;
+  capped?: boolean;             // Counts are a lower bound
+  updatedAt: string;
+}
+
+// Liquidity and volume tiers, recalculated on every sync
+export type MarketTier = "low" | "medium" | "high";
+
 export interface Market {
   id: string;
   marketId: string;
@@ -48,6 +93,7 @@ export interface Market {
   question: string;
   description: string;
   category: string;
+  categorySource?: "keyword" | "llm";
 
   // Media
   image?: string;
@@ -58,6 +104,7 @@ export interface Market {
   previousProb: number;
   lastTradePrice?: number;
   change1h?: number;
+  change6h?: number;
   change24h: number;
   change7d?: number;
 
@@ -68,6 +115,8 @@ export interface Market {
   totalVolume: number;
 
   // Event-level data
+  eventId?: string;
+  eventSlug?: string;
   eventVolume?: number;
   eventVolume24h?: number;
   eventTitle?: string;
@@ -81,14 +130,24 @@ export interface Market {
 
   // Resolution
   resolutionSource?: string;
+  resolved?: boolean;
+  winningOutcome?: string;
+  resolvedAt?: string;
   seriesSlug?: string;
   startDate?: string;
 
   // Status
   liquidity: number;
+  orderBook?: OrderBook;
+  tradeFlow?: TradeFlow;
+  holders?: Holders;
+  liquidityTier?: MarketTier;
+  volumeTier?: MarketTier;
+  tier?: MarketTier;            // The lower of the two
   active: boolean;
   closed: boolean;
   archived: boolean;
+  archivedAt?: string;
   acceptingBid: boolean;
   endDate: string;
 
@@ -101,6 +160,16 @@ export interface Market {
   firstSeenAt: string;
   updatedAt: string;
   polymarketUrl: string;
+
+  // With ?include=sparkline
+  sparkline?: Sparkline;
+}
+
+// Recent probability for mini-charts, oldest first, ending with the
+// current probability
+export interface Sparkline {
+  day: number[]; // Last 24h, hourly
+  week: number[]; // Last 7d, every 6h
 }
 
 export interface Snapshot {
@@ -125,7 +194,9 @@ export type ArticleType =
   | "deep_dive"
   | "digest"
   | "explainer"
-  | "social_signal";
+  | "social_signal"
+  | "resolution"
+  | "week_ahead";
 
 export type BriefingType = "morning" | "midday" | "evening" | "weekly";
 
@@ -134,6 +205,64 @@ export interface ArticleBody {
   whyItMatters: string;
   context: string[];
   whatToWatch: string;
+  analysis?: string;
+  marketData?: MarketDataBlock;
+  // Biggest 24h gainers and losers, for movers reports
+  movers?: MoversTable;
+  // Markets ending each day, for week-ahead previews
+  weekAhead?: WeekAheadDay[];
+  // Events whose outcomes' odds don't sum to 100%, for mispricing watches
+  mispricings?: Mispricing[];
+}
+
+export interface Mispricing {
+  eventId: string;
+  title: string;
+  url: string;
+  sum: number; // Outcomes' probabilities summed, 1 when consistent
+  partial?: boolean; // Some outcomes not synced
+  markets: MarketRef[];
+}
+
+export interface MoversTable {
+  gainers: MarketRef[];
+  losers: MarketRef[];
+}
+
+export interface WeekAheadDay {
+  date: string; // YYYY-MM-DD
+  markets: MarketRef[];
+}
+
+// Primary market data captured when the article was generated (article
+// pages only; omitted from article lists)
+export interface MarketDataBlock {
+  marketId: string;
+  generatedAt: string;
+  history: ProbabilityPoint[];
+  volume: VolumeBreakdown;
+  keyDates?: KeyDate[];
+}
+
+export interface ProbabilityPoint {
+  time: string;
+  probability: number;
+  volume24h: number;
+}
+
+export interface VolumeBreakdown {
+  volume1h: number;
+  volume24h: number;
+  volume7d: number;
+  totalVolume: number;
+  liquidity: number;
+  buyVolume24h?: number;
+  sellVolume24h?: number;
+}
+
+export interface KeyDate {
+  label: "opened" | "tracked" | "peak" | "trough" | "closes" | "resolved";
+  date: string;
 }
 
 export interface RelatedArticle {
@@ -147,6 +276,7 @@ export interface RelatedArticle {
 // =============================================================================
 
 export interface MarketMovement {
+  market_id?: string;
   market_slug: string;
   market_title: string;
   category: string;
@@ -157,6 +287,7 @@ export interface MarketMovement {
 }
 
 export interface SocialSignal {
+  platform?: "bluesky" | "truthsocial"; // Absent for X
   handle: string;
   name: string;
   avatar_url: string;
@@ -169,9 +300,16 @@ export interface SocialSignal {
   replies?: number;
   market_impact: number;
   impact_window: string;
+  weight?: number;
   affected_markets?: MarketMovement[];
 }
 
+export interface StoredSocialSignal extends SocialSignal {
+  market_ids: string[];
+  first_seen_at: string;
+  updated_at: string;
+}
+
 export interface Article {
   id: string;
   slug: string;
@@ -188,31 +326,41 @@ export interface Article {
   featured: boolean;
   priority: number;
   views: number;
+  ogImageUrl?: string;
   publishedAt: string;
   updatedAt: string;
   expiresAt: string;
   briefingType?: BriefingType;
   enrichmentSources?: string[];
   socialSignals?: SocialSignal[];
+  jsonLd?: Record<string, unknown>;
 }
 
 // =============================================================================
 // API RESPONSES
 // =============================================================================
 
-export interface ArticlesResponse {
-  articles: Article[];
+// Every API response is an envelope: data on success, with meta for lists,
+// or error when the request failed
+export interface ApiMeta {
   count: number;
+  sort?: string;
+  type?: string;
+  category?: string;
+  window?: string;
+  marketId?: string;
+  days?: number;
 }
 
-export interface MarketsResponse {
-  markets: Market[];
-  count: number;
+export interface ApiError {
+  status: number;
+  message: string;
 }
 
-export interface CategoriesResponse {
-  categories: Category[];
-  count: number;
+export interface ApiEnvelope<T> {
+  data?: T;
+  meta?: ApiMeta;
+  error?: ApiError;
 }
 
 export interface CategoryDetailResponse {
@@ -221,13 +369,95 @@ export interface CategoryDetailResponse {
   articles: Article[];
 }
 
+// Market timeline: snapshots, sync events and articles in time order
+export interface TimelineEntry {
+  time: string;
+  kind: "snapshot" | "event" | "article";
+  snapshot?: { probability: number; volume24h: number };
+  event?: { id: string; type: string; metadata?: Record<string, unknown> };
+  article?: { id: string; slug: string; type: ArticleType; title: string };
+}
+
+export interface TagCount {
+  tag: string;
+  articles: number;
+  markets: number;
+}
+
+export interface TagDetailResponse {
+  tag: string;
+  markets: Market[];
+  articles: Article[];
+}
+
+export interface PolymarketEvent {
+  id: string;
+  eventId: string;
+  slug: string;
+  seriesSlug?: string;
+  title: string;
+  description?: string;
+  image?: string;
+  icon?: string;
+  polymarketTags?: PolymarketTag[];
+  volume: number;
+  volume24h: number;
+  volume7d: number;
+  liquidity: number;
+  commentCount?: number;
+  competitorCount?: number;
+  resolutionSource?: string;
+  active: boolean;
+  closed: boolean;
+  startDate?: string;
+  endDate?: string;
+  marketIds: string[];
+  firstSeenAt: string;
+  updatedAt: string;
+  polymarketUrl: string;
+}
+
+export interface EventDetailResponse {
+  event: PolymarketEvent;
+  markets: Market[];
+}
+
+export interface MarketRef {
+  marketId: string;
+  question: string;
+  slug: string;
+  probability: number;
+  change24h: number;
+  volume24h: number;
+  totalVolume: number;
+}
+
+export interface EventCandidate {
+  market: Market;
+  history: ProbabilityPoint[];
+}
+
+export interface EventDashboardResponse {
+  event: PolymarketEvent;
+  candidates: EventCandidate[];
+  movers: MarketRef[];
+  articles: Article[];
+}
+
 export interface HomeFeedResponse {
   featured: Article[];
   recent: Article[];
   trendingMarkets: Market[];
+  trendingByCategory: CategoryTrending[];
   today: Article[];
 }
 
+export interface CategoryTrending {
+  category: string;
+  name: string;
+  markets: Market[];
+}
+
 export interface StatsResponse {
   totalMarkets: number;
   activeMarkets: number;
@@ -259,11 +489,30 @@ export interface CategorySentiment {
   topMoverSlug?: string;      // Slug for link
   topMoverChange: number;     // Change of top mover
   avgChange24h: number;       // Simple average change
+  updatedAt?: string;         // When the aggregate was computed
 }
 
-export interface SentimentResponse {
-  sentiments: CategorySentiment[];
+// =============================================================================
+// FORECAST ACCURACY
+// =============================================================================
+
+export interface CalibrationBucket {
+  lower: number;
+  upper: number;
   count: number;
+  forecast: number;           // Mean forecast in the bucket
+  observed: number;           // Share that resolved Yes
+}
+
+export interface AccuracyScore {
+  category: string;           // Category slug, or "all"
+  horizon: string;            // e.g. "7d"
+  horizonHours: number;
+  markets: number;            // Resolved markets scored
+  brierScore: number;         // 0 is perfect, 0.25 is a coin flip
+  logLoss: number;
+  calibration: CalibrationBucket[];
+  computedAt: string;
 }
 
 // =============================================================================
@@ -295,6 +544,8 @@ export function getArticleTypeBadge(type: ArticleType): { label: string; variant
     digest: { label: "DIGEST", variant: "secondary" },
     explainer: { label: "EXPLAINER", variant: "secondary" },
     social_signal: { label: "SIGNAL", variant: "crypto" },
+    resolution: { label: "RESOLVED", variant: "secondary" },
+    week_ahead: { label: "WEEK AHEAD", variant: "default" },
   };
   return badges[type] || { label: type.toUpperCase(), variant: "secondary" };
 }
@@ -5,6 +5,17 @@ export function cn(...inputs: ClassValue[]) {
   return twMerge(clsx(inputs));
 }
 
+// =============================================================================
+// IMAGES
+// =============================================================================
+
+// Requests a proxied market image (/api/assets/) at a width in pixels;
+// other URLs are returned unchanged.
+export function imageSrc(src: string, width: number): string {
+  if (!src.includes("/api/assets/")) return src;
+  return `${src}${src.includes("?") ? "&" : "?"}w=${width}`;
+}
+
 // =============================================================================
 // NUMBER FORMATTING
 // =============================================================================